@@ -177,6 +177,7 @@ type Allocation struct {
 	RescheduleTracker     *RescheduleTracker
 	PreemptedAllocations  []string
 	PreemptedByAllocation string
+	CheckResults          map[string]*CheckResult
 	CreateIndex           uint64
 	ModifyIndex           uint64
 	AllocModifyIndex      uint64
@@ -303,6 +304,23 @@ type AllocDeploymentStatus struct {
 	ModifyIndex uint64
 }
 
+// CheckResult is the latest result of a check of a service using the nomad
+// provider, run by the client of the allocation.
+type CheckResult struct {
+	ID         string
+	Service    string
+	Check      string
+	Group      string
+	Task       string
+	Type       string
+	OnUpdate   string
+	Kind       string
+	Status     string
+	StatusCode int
+	Output     string
+	Timestamp  time.Time
+}
+
 type AllocatedResources struct {
 	Tasks  map[string]*AllocatedTaskResources
 	Shared AllocatedSharedResources
@@ -319,6 +337,8 @@ type AllocatedSharedResources struct {
 	DiskMB   int64
 	Networks []*NetworkResource
 	Ports    []PortMapping
+	Cpu      AllocatedCpuResources
+	Memory   AllocatedMemoryResources
 }
 
 type PortMapping struct {
@@ -218,6 +218,9 @@ func queryClientNode(c *Client, alloc *Allocation, reqPath string, q *QueryOptio
 // * origin: Either "start" or "end" and defines from where the offset is applied.
 // * offset: The offset to start streaming data at.
 // * cancel: A channel that when closed, streaming will end.
+// * q: The query options. Its Filter only streams the lines matching the
+//   expression, and its "since" parameter the lines written after an RFC3339
+//   time or a duration before now.
 //
 // The return value is a channel that will emit StreamFrames as they are read.
 // The chan will be closed when follow=false and the end of the file is
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/url"
 	"sort"
 	"strconv"
@@ -406,6 +408,95 @@ func (j *Jobs) Summary(jobID string, q *QueryOptions) (*JobSummary, *QueryMeta,
 	return &resp, qm, nil
 }
 
+// LogSearchOptions are the options of a search of the logs of a job.
+type LogSearchOptions struct {
+	// Pattern is the regular expression lines are matched against
+	Pattern string
+
+	// Task restricts the search to a task and LogType to either "stdout" or
+	// "stderr" when set
+	Task    string
+	LogType string
+
+	// Limit is the maximum number of matches returned. The server's default
+	// is used if it is zero.
+	Limit int
+}
+
+// LogMatch is a line of the logs of an allocation matching a search. Errors
+// searching the logs of an allocation are returned as a LogMatch with the
+// Error set, and the last result of a search that reached its limit has only
+// Truncated set.
+type LogMatch struct {
+	AllocID   string
+	NodeID    string
+	Task      string
+	LogType   string
+	File      string
+	Line      int
+	Text      string
+	Error     string
+	Truncated bool
+}
+
+// SearchLogs searches the logs of all the allocations of a job for lines
+// matching a pattern. The matches are streamed as the allocations are
+// searched, and the returned channel is closed once the search is done.
+func (j *Jobs) SearchLogs(jobID string, opts *LogSearchOptions, cancel <-chan struct{},
+	q *QueryOptions) (<-chan *LogMatch, <-chan error) {
+
+	errCh := make(chan error, 1)
+
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	if q.Params == nil {
+		q.Params = make(map[string]string)
+	}
+	q.Params["pattern"] = opts.Pattern
+	if opts.Task != "" {
+		q.Params["task"] = opts.Task
+	}
+	if opts.LogType != "" {
+		q.Params["type"] = opts.LogType
+	}
+	if opts.Limit != 0 {
+		q.Params["limit"] = strconv.Itoa(opts.Limit)
+	}
+
+	r, err := j.client.rawQuery("/v1/job/"+url.PathEscape(jobID)+"/logs/search", q)
+	if err != nil {
+		errCh <- err
+		return nil, errCh
+	}
+
+	matches := make(chan *LogMatch, 10)
+	go func() {
+		defer r.Close()
+
+		dec := json.NewDecoder(r)
+		for {
+			var match LogMatch
+			if err := dec.Decode(&match); err != nil {
+				if err == io.EOF || err == io.ErrClosedPipe {
+					close(matches)
+				} else {
+					errCh <- err
+				}
+				return
+			}
+
+			select {
+			case matches <- &match:
+			case <-cancel:
+				return
+			}
+		}
+	}()
+
+	return matches, errCh
+}
+
 func (j *Jobs) Dispatch(jobID string, meta map[string]string,
 	payload []byte, q *WriteOptions) (*JobDispatchResponse, *WriteMeta, error) {
 	var resp JobDispatchResponse
@@ -804,9 +895,36 @@ func (p *PeriodicConfig) GetLocation() (*time.Location, error) {
 
 // ParameterizedJobConfig is used to configure the parameterized job.
 type ParameterizedJobConfig struct {
-	Payload      string   `hcl:"payload,optional"`
-	MetaRequired []string `mapstructure:"meta_required" hcl:"meta_required,optional"`
-	MetaOptional []string `mapstructure:"meta_optional" hcl:"meta_optional,optional"`
+	Payload      string              `hcl:"payload,optional"`
+	MetaRequired []string            `mapstructure:"meta_required" hcl:"meta_required,optional"`
+	MetaOptional []string            `mapstructure:"meta_optional" hcl:"meta_optional,optional"`
+	MaxRunning   int                 `mapstructure:"max_running" hcl:"max_running,optional"`
+	Rate         *DispatchRateConfig `hcl:"rate,block"`
+}
+
+const (
+	JobDependencyConditionComplete = "complete"
+	JobDependencyConditionSuccess  = "success"
+)
+
+// JobDependency is used to delay the evaluation of a job until another job
+// in the same namespace reaches a condition.
+type JobDependency struct {
+	JobID     string `mapstructure:"job" hcl:"job"`
+	Condition string `hcl:"condition,optional"`
+}
+
+func (d *JobDependency) Canonicalize() {
+	if d.Condition == "" {
+		d.Condition = JobDependencyConditionSuccess
+	}
+}
+
+// DispatchRateConfig is used to limit how quickly a parameterized job can be
+// dispatched.
+type DispatchRateConfig struct {
+	Limit float64 `hcl:"limit,optional"`
+	Burst int     `hcl:"burst,optional"`
 }
 
 // Job is used to serialize a job.
@@ -829,6 +947,7 @@ type Job struct {
 	Spreads          []*Spread               `hcl:"spread,block"`
 	Periodic         *PeriodicConfig         `hcl:"periodic,block"`
 	ParameterizedJob *ParameterizedJobConfig `hcl:"parameterized,block"`
+	DependsOn        []*JobDependency        `mapstructure:"depends_on" hcl:"depends_on,block"`
 	Reschedule       *ReschedulePolicy       `hcl:"reschedule,block"`
 	Migrate          *MigrateStrategy        `hcl:"migrate,block"`
 	Meta             map[string]string       `hcl:"meta,block"`
@@ -841,6 +960,7 @@ type Job struct {
 	ParentID                 *string
 	Dispatched               bool
 	DispatchIdempotencyToken *string
+	DependenciesBlocked      bool
 	Payload                  []byte
 	ConsulNamespace          *string `mapstructure:"consul_namespace"`
 	VaultNamespace           *string `mapstructure:"vault_namespace"`
@@ -959,6 +1079,9 @@ func (j *Job) Canonicalize() {
 	for _, a := range j.Affinities {
 		a.Canonicalize()
 	}
+	for _, d := range j.DependsOn {
+		d.Canonicalize()
+	}
 }
 
 // LookupTaskGroup finds a task group by name
@@ -988,6 +1111,7 @@ type JobChildrenSummary struct {
 	Pending int64
 	Running int64
 	Dead    int64
+	Queued  int64
 }
 
 func (jc *JobChildrenSummary) Sum() int {
@@ -995,7 +1119,7 @@ func (jc *JobChildrenSummary) Sum() int {
 		return 0
 	}
 
-	return int(jc.Pending + jc.Running + jc.Dead)
+	return int(jc.Pending + jc.Running + jc.Dead + jc.Queued)
 }
 
 // TaskGroup summarizes the state of all the allocations of a particular
@@ -1312,6 +1436,7 @@ type JobDispatchResponse struct {
 	EvalID          string
 	EvalCreateIndex uint64
 	JobCreateIndex  uint64
+	Queued          bool
 	WriteMeta
 }
 
@@ -481,6 +481,24 @@ func (n *Nodes) GcAlloc(allocID string, q *QueryOptions) error {
 	return err
 }
 
+// ImagePrefetchRequest is used to ask a driver on a node to pull images in
+// the background.
+type ImagePrefetchRequest struct {
+	// Driver is the driver pulling the images, docker if not set
+	Driver string
+
+	// Images are the references of the images to pull
+	Images []string
+}
+
+// PrefetchImages asks a driver on the node to pull images in the background
+// so that they're cached before tasks use them.
+func (n *Nodes) PrefetchImages(nodeID string, req *ImagePrefetchRequest, q *QueryOptions) error {
+	path := fmt.Sprintf("/v1/client/images/prefetch?node_id=%s", nodeID)
+	_, err := n.client.putQuery(path, req, nil, q)
+	return err
+}
+
 // Purge removes a node from the system. Nodes can still re-join the cluster if
 // they are alive.
 func (n *Nodes) Purge(nodeID string, q *QueryOptions) (*NodePurgeResponse, *QueryMeta, error) {
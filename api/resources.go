@@ -96,6 +96,22 @@ func (r *Resources) Merge(other *Resources) {
 	}
 }
 
+// TaskGroupResources is the CPU shares and memory ceiling shared by the tasks
+// of a task group.
+type TaskGroupResources struct {
+	CPU      *int `hcl:"cpu,optional"`
+	MemoryMB *int `mapstructure:"memory" hcl:"memory,optional"`
+}
+
+func (r *TaskGroupResources) Canonicalize() {
+	if r.CPU == nil {
+		r.CPU = intToPtr(0)
+	}
+	if r.MemoryMB == nil {
+		r.MemoryMB = intToPtr(0)
+	}
+}
+
 type Port struct {
 	Label       string `hcl:",label"`
 	Value       int    `mapstructure:"static" hcl:"static,optional"`
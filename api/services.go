@@ -97,6 +97,7 @@ type ServiceCheck struct {
 	FailuresBeforeCritical int                 `mapstructure:"failures_before_critical" hcl:"failures_before_critical,optional"`
 	Body                   string              `hcl:"body,optional"`
 	OnUpdate               string              `mapstructure:"on_update" hcl:"on_update,optional"`
+	Kind                   string              `hcl:"kind,optional"`
 }
 
 // Service represents a Consul service definition.
@@ -116,8 +117,14 @@ type Service struct {
 	CanaryMeta        map[string]string `hcl:"canary_meta,block"`
 	TaskName          string            `mapstructure:"task" hcl:"task,optional"`
 	OnUpdate          string            `mapstructure:"on_update" hcl:"on_update,optional"`
+	Provider          string            `hcl:"provider,optional"`
 }
 
+const (
+	ServiceCheckReadiness = "readiness"
+	ServiceCheckLiveness  = "liveness"
+)
+
 const (
 	OnUpdateRequireHealthy = "require_healthy"
 	OnUpdateIgnoreWarn     = "ignore_warnings"
@@ -148,9 +155,11 @@ func (s *Service) Canonicalize(t *Task, tg *TaskGroup, job *Job) {
 	s.Connect.Canonicalize()
 
 	// Canonicalize CheckRestart on Checks and merge Service.CheckRestart
-	// into each check.
+	// into each check, except readiness checks which never restart tasks.
 	for i, check := range s.Checks {
-		s.Checks[i].CheckRestart = s.CheckRestart.Merge(check.CheckRestart)
+		if check.Kind != ServiceCheckReadiness {
+			s.Checks[i].CheckRestart = s.CheckRestart.Merge(check.CheckRestart)
+		}
 		s.Checks[i].CheckRestart.Canonicalize()
 
 		if s.Checks[i].SuccessBeforePassing < 0 {
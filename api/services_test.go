@@ -109,6 +109,10 @@ func TestService_CheckRestart(t *testing.T) {
 			{
 				Name: "unset",
 			},
+			{
+				Name: "readiness",
+				Kind: ServiceCheckReadiness,
+			},
 		},
 	}
 
@@ -124,6 +128,9 @@ func TestService_CheckRestart(t *testing.T) {
 	require.Equal(t, service.Checks[2].CheckRestart.Limit, 11)
 	require.Equal(t, *service.Checks[2].CheckRestart.Grace, 11*time.Second)
 	require.True(t, service.Checks[2].CheckRestart.IgnoreWarnings)
+
+	// Readiness checks never restart tasks
+	require.Nil(t, service.Checks[3].CheckRestart)
 }
 
 func TestService_Connect_Canonicalize(t *testing.T) {
@@ -67,10 +67,12 @@ type AllocResourceUsage struct {
 // RestartPolicy defines how the Nomad client restarts
 // tasks in a taskgroup when they fail
 type RestartPolicy struct {
-	Interval *time.Duration `hcl:"interval,optional"`
-	Attempts *int           `hcl:"attempts,optional"`
-	Delay    *time.Duration `hcl:"delay,optional"`
-	Mode     *string        `hcl:"mode,optional"`
+	Interval      *time.Duration `hcl:"interval,optional"`
+	Attempts      *int           `hcl:"attempts,optional"`
+	Delay         *time.Duration `hcl:"delay,optional"`
+	Mode          *string        `hcl:"mode,optional"`
+	DelayFunction *string        `mapstructure:"delay_function" hcl:"delay_function,optional"`
+	MaxDelay      *time.Duration `mapstructure:"max_delay" hcl:"max_delay,optional"`
 }
 
 func (r *RestartPolicy) Merge(rp *RestartPolicy) {
@@ -86,6 +88,12 @@ func (r *RestartPolicy) Merge(rp *RestartPolicy) {
 	if rp.Mode != nil {
 		r.Mode = rp.Mode
 	}
+	if rp.DelayFunction != nil {
+		r.DelayFunction = rp.DelayFunction
+	}
+	if rp.MaxDelay != nil {
+		r.MaxDelay = rp.MaxDelay
+	}
 }
 
 // Reschedule configures how Tasks are rescheduled  when they crash or fail.
@@ -433,6 +441,8 @@ type TaskGroup struct {
 	StopAfterClientDisconnect *time.Duration            `mapstructure:"stop_after_client_disconnect" hcl:"stop_after_client_disconnect,optional"`
 	Scaling                   *ScalingPolicy            `hcl:"scaling,block"`
 	Consul                    *Consul                   `hcl:"consul,block"`
+	Stateful                  *bool                     `hcl:"stateful,optional"`
+	Resources                 *TaskGroupResources       `hcl:"resources,block"`
 }
 
 // NewTaskGroup creates a new TaskGroup.
@@ -464,6 +474,9 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 	} else {
 		g.EphemeralDisk.Canonicalize()
 	}
+	if g.Resources != nil {
+		g.Resources.Canonicalize()
+	}
 
 	// Merge job.consul onto group.consul
 	if g.Consul == nil {
@@ -616,8 +629,45 @@ func (g *TaskGroup) AddSpread(s *Spread) *TaskGroup {
 
 // LogConfig provides configuration for log rotation
 type LogConfig struct {
-	MaxFiles      *int `mapstructure:"max_files" hcl:"max_files,optional"`
-	MaxFileSizeMB *int `mapstructure:"max_file_size" hcl:"max_file_size,optional"`
+	MaxFiles      *int       `mapstructure:"max_files" hcl:"max_files,optional"`
+	MaxFileSizeMB *int       `mapstructure:"max_file_size" hcl:"max_file_size,optional"`
+	Sinks         []*LogSink `mapstructure:"sink" hcl:"sink,block"`
+	Format        *string    `mapstructure:"format" hcl:"format,optional"`
+	Level         *string    `mapstructure:"level" hcl:"level,optional"`
+
+	RotateEvery    *time.Duration `mapstructure:"rotate_every" hcl:"rotate_every,optional"`
+	Compression    *string        `mapstructure:"compression" hcl:"compression,optional"`
+	MaxTotalSizeMB *int           `mapstructure:"max_total_size" hcl:"max_total_size,optional"`
+}
+
+// LogSink ships task logs to a syslog server, an HTTP endpoint or journald.
+type LogSink struct {
+	Type         string            `hcl:"type,label"`
+	Address      string            `mapstructure:"address" hcl:"address,optional"`
+	Facility     string            `mapstructure:"facility" hcl:"facility,optional"`
+	Tag          string            `mapstructure:"tag" hcl:"tag,optional"`
+	Header       map[string]string `mapstructure:"header" hcl:"header,block"`
+	BatchSize    *int              `mapstructure:"batch_size" hcl:"batch_size,optional"`
+	BatchWait    *time.Duration    `mapstructure:"batch_wait" hcl:"batch_wait,optional"`
+	BufferSize   *int              `mapstructure:"buffer_size" hcl:"buffer_size,optional"`
+	Backpressure *bool             `mapstructure:"backpressure" hcl:"backpressure,optional"`
+}
+
+func (s *LogSink) Canonicalize() {
+	if s.Type == "http" {
+		if s.BatchSize == nil {
+			s.BatchSize = intToPtr(100)
+		}
+		if s.BatchWait == nil {
+			s.BatchWait = timeToPtr(1 * time.Second)
+		}
+	}
+	if s.BufferSize == nil {
+		s.BufferSize = intToPtr(1024)
+	}
+	if s.Backpressure == nil {
+		s.Backpressure = boolToPtr(false)
+	}
 }
 
 func DefaultLogConfig() *LogConfig {
@@ -634,6 +684,9 @@ func (l *LogConfig) Canonicalize() {
 	if l.MaxFileSizeMB == nil {
 		l.MaxFileSizeMB = intToPtr(10)
 	}
+	for _, sink := range l.Sinks {
+		sink.Canonicalize()
+	}
 }
 
 // DispatchPayloadConfig configures how a task gets its input from a job dispatch
@@ -647,6 +700,11 @@ const (
 	TaskLifecycleHookPoststop  = "poststop"
 )
 
+const (
+	TaskDependencyConditionSuccess  = "success"
+	TaskDependencyConditionComplete = "complete"
+)
+
 type TaskLifecycle struct {
 	Hook    string `mapstructure:"hook" hcl:"hook,optional"`
 	Sidecar bool   `mapstructure:"sidecar" hcl:"sidecar,optional"`
@@ -657,33 +715,67 @@ func (l *TaskLifecycle) Empty() bool {
 	return l == nil || (l.Hook == "")
 }
 
+const (
+	TaskShutdownHookTypeHTTP = "http"
+	TaskShutdownHookTypeExec = "exec"
+)
+
+// TaskShutdownHook is an action run against a task before it is killed.
+type TaskShutdownHook struct {
+	Type      string        `mapstructure:"type" hcl:"type,optional"`
+	PortLabel string        `mapstructure:"port" hcl:"port,optional"`
+	Path      string        `mapstructure:"path" hcl:"path,optional"`
+	Method    string        `mapstructure:"method" hcl:"method,optional"`
+	Protocol  string        `mapstructure:"protocol" hcl:"protocol,optional"`
+	Command   string        `mapstructure:"command" hcl:"command,optional"`
+	Args      []string      `mapstructure:"args" hcl:"args,optional"`
+	Timeout   time.Duration `mapstructure:"timeout" hcl:"timeout,optional"`
+}
+
+func (h *TaskShutdownHook) Canonicalize() {
+	if h.Type == TaskShutdownHookTypeHTTP {
+		if h.Method == "" {
+			h.Method = "POST"
+		}
+		if h.Protocol == "" {
+			h.Protocol = "http"
+		}
+	}
+	if h.Timeout == 0 {
+		h.Timeout = 10 * time.Second
+	}
+}
+
 // Task is a single process in a task group.
 type Task struct {
-	Name            string                 `hcl:"name,label"`
-	Driver          string                 `hcl:"driver,optional"`
-	User            string                 `hcl:"user,optional"`
-	Lifecycle       *TaskLifecycle         `hcl:"lifecycle,block"`
-	Config          map[string]interface{} `hcl:"config,block"`
-	Constraints     []*Constraint          `hcl:"constraint,block"`
-	Affinities      []*Affinity            `hcl:"affinity,block"`
-	Env             map[string]string      `hcl:"env,block"`
-	Services        []*Service             `hcl:"service,block"`
-	Resources       *Resources             `hcl:"resources,block"`
-	RestartPolicy   *RestartPolicy         `hcl:"restart,block"`
-	Meta            map[string]string      `hcl:"meta,block"`
-	KillTimeout     *time.Duration         `mapstructure:"kill_timeout" hcl:"kill_timeout,optional"`
-	LogConfig       *LogConfig             `mapstructure:"logs" hcl:"logs,block"`
-	Artifacts       []*TaskArtifact        `hcl:"artifact,block"`
-	Vault           *Vault                 `hcl:"vault,block"`
-	Templates       []*Template            `hcl:"template,block"`
-	DispatchPayload *DispatchPayloadConfig `hcl:"dispatch_payload,block"`
-	VolumeMounts    []*VolumeMount         `hcl:"volume_mount,block"`
-	CSIPluginConfig *TaskCSIPluginConfig   `mapstructure:"csi_plugin" json:",omitempty" hcl:"csi_plugin,block"`
-	Leader          bool                   `hcl:"leader,optional"`
-	ShutdownDelay   time.Duration          `mapstructure:"shutdown_delay" hcl:"shutdown_delay,optional"`
-	KillSignal      string                 `mapstructure:"kill_signal" hcl:"kill_signal,optional"`
-	Kind            string                 `hcl:"kind,optional"`
-	ScalingPolicies []*ScalingPolicy       `hcl:"scaling,block"`
+	Name               string                 `hcl:"name,label"`
+	Driver             string                 `hcl:"driver,optional"`
+	User               string                 `hcl:"user,optional"`
+	Lifecycle          *TaskLifecycle         `hcl:"lifecycle,block"`
+	DependsOn          []string               `mapstructure:"depends_on" hcl:"depends_on,optional"`
+	DependsOnCondition string                 `mapstructure:"depends_on_condition" hcl:"depends_on_condition,optional"`
+	Config             map[string]interface{} `hcl:"config,block"`
+	Constraints        []*Constraint          `hcl:"constraint,block"`
+	Affinities         []*Affinity            `hcl:"affinity,block"`
+	Env                map[string]string      `hcl:"env,block"`
+	Services           []*Service             `hcl:"service,block"`
+	Resources          *Resources             `hcl:"resources,block"`
+	RestartPolicy      *RestartPolicy         `hcl:"restart,block"`
+	Meta               map[string]string      `hcl:"meta,block"`
+	KillTimeout        *time.Duration         `mapstructure:"kill_timeout" hcl:"kill_timeout,optional"`
+	LogConfig          *LogConfig             `mapstructure:"logs" hcl:"logs,block"`
+	Artifacts          []*TaskArtifact        `hcl:"artifact,block"`
+	Vault              *Vault                 `hcl:"vault,block"`
+	Templates          []*Template            `hcl:"template,block"`
+	DispatchPayload    *DispatchPayloadConfig `hcl:"dispatch_payload,block"`
+	VolumeMounts       []*VolumeMount         `hcl:"volume_mount,block"`
+	CSIPluginConfig    *TaskCSIPluginConfig   `mapstructure:"csi_plugin" json:",omitempty" hcl:"csi_plugin,block"`
+	Leader             bool                   `hcl:"leader,optional"`
+	ShutdownDelay      time.Duration          `mapstructure:"shutdown_delay" hcl:"shutdown_delay,optional"`
+	ShutdownHook       *TaskShutdownHook      `mapstructure:"shutdown_hook" hcl:"shutdown_hook,block"`
+	KillSignal         string                 `mapstructure:"kill_signal" hcl:"kill_signal,optional"`
+	Kind               string                 `hcl:"kind,optional"`
+	ScalingPolicies    []*ScalingPolicy       `hcl:"scaling,block"`
 }
 
 func (t *Task) Canonicalize(tg *TaskGroup, job *Job) {
@@ -721,9 +813,15 @@ func (t *Task) Canonicalize(tg *TaskGroup, job *Job) {
 	if t.Lifecycle.Empty() {
 		t.Lifecycle = nil
 	}
+	if len(t.DependsOn) > 0 && t.DependsOnCondition == "" {
+		t.DependsOnCondition = TaskDependencyConditionSuccess
+	}
 	if t.CSIPluginConfig != nil {
 		t.CSIPluginConfig.Canonicalize()
 	}
+	if t.ShutdownHook != nil {
+		t.ShutdownHook.Canonicalize()
+	}
 	if t.RestartPolicy == nil {
 		t.RestartPolicy = tg.RestartPolicy
 	} else {
@@ -58,6 +58,11 @@ var (
 	// directory
 	TaskSecrets = "secrets"
 
+	// CheckpointDirName is the name of the directory in each alloc directory
+	// holding the checkpoints of its tasks. It is not visible to tasks and is
+	// included in snapshots.
+	CheckpointDirName = "checkpoint"
+
 	// TaskDirs is the set of directories created in each tasks directory.
 	TaskDirs = map[string]os.FileMode{TmpDirName: os.ModeSticky | 0777}
 
@@ -131,7 +136,7 @@ func (d *AllocDir) NewTaskDir(name string) *TaskDir {
 }
 
 // Snapshot creates an archive of the files and directories in the data dir of
-// the allocation and the task local directories
+// the allocation, the task local directories and the task checkpoints
 //
 // Since a valid tar may have been written even when an error occurs, a special
 // file "NOMAD-${ALLOC_ID}-ERROR.log" will be appended to the tar with the
@@ -144,6 +149,9 @@ func (d *AllocDir) Snapshot(w io.Writer) error {
 	rootPaths := []string{allocDataDir}
 	for _, taskdir := range d.TaskDirs {
 		rootPaths = append(rootPaths, taskdir.LocalDir)
+		if _, err := os.Stat(taskdir.CheckpointDir); err == nil {
+			rootPaths = append(rootPaths, taskdir.CheckpointDir)
+		}
 	}
 
 	tw := tar.NewWriter(w)
@@ -215,7 +223,8 @@ func (d *AllocDir) Snapshot(w io.Writer) error {
 	return nil
 }
 
-// Move other alloc directory's shared path and local dir to this alloc dir.
+// Move other alloc directory's shared path, local dirs and task checkpoints to
+// this alloc dir.
 func (d *AllocDir) Move(other *AllocDir, tasks []*structs.Task) error {
 	d.mu.RLock()
 	if !d.built {
@@ -257,6 +266,29 @@ func (d *AllocDir) Move(other *AllocDir, tasks []*structs.Task) error {
 		}
 	}
 
+	return d.MoveCheckpoints(other, tasks)
+}
+
+// MoveCheckpoints moves the checkpoints of the tasks in the other alloc
+// directory to this alloc dir.
+func (d *AllocDir) MoveCheckpoints(other *AllocDir, tasks []*structs.Task) error {
+	for _, task := range tasks {
+		otherCheckpoint := filepath.Join(other.AllocDir, CheckpointDirName, task.Name)
+		if _, err := os.Stat(otherCheckpoint); err != nil {
+			continue
+		}
+
+		checkpointDir := filepath.Join(d.AllocDir, CheckpointDirName)
+		if err := os.MkdirAll(checkpointDir, 0700); err != nil {
+			return fmt.Errorf("error creating checkpoint dir: %v", err)
+		}
+		checkpoint := filepath.Join(checkpointDir, task.Name)
+		os.RemoveAll(checkpoint) // remove a stale checkpoint if it exists
+		if err := os.Rename(otherCheckpoint, checkpoint); err != nil {
+			return fmt.Errorf("error moving task %q checkpoint: %v", task.Name, err)
+		}
+	}
+
 	return nil
 }
 
@@ -290,6 +290,34 @@ func TestAllocDir_Move(t *testing.T) {
 	}
 }
 
+// TestAllocDir_MoveCheckpoints asserts that the checkpoints of tasks are moved
+// without their local dirs.
+func TestAllocDir_MoveCheckpoints(t *testing.T) {
+	d1 := NewAllocDir(testlog.HCLogger(t), t.TempDir(), "test")
+	require.NoError(t, d1.Build())
+	defer d1.Destroy()
+
+	d2 := NewAllocDir(testlog.HCLogger(t), t.TempDir(), "test")
+	require.NoError(t, d2.Build())
+	defer d2.Destroy()
+
+	td1 := d1.NewTaskDir(t1.Name)
+	require.NoError(t, td1.Build(false, nil))
+	td2 := d2.NewTaskDir(t1.Name)
+
+	// Write a checkpoint and a file to the task local
+	require.NoError(t, os.MkdirAll(td1.CheckpointDir, 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(td1.CheckpointDir, "inventory.img"), []byte("foo"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(td1.LocalDir, "lol"), []byte("bar"), 0666))
+
+	// Moving the checkpoints of tasks without one is a noop
+	require.NoError(t, d2.MoveCheckpoints(d1, []*structs.Task{t1, t2}))
+
+	require.FileExists(t, filepath.Join(td2.CheckpointDir, "inventory.img"))
+	require.NoDirExists(t, td1.CheckpointDir)
+	require.NoFileExists(t, filepath.Join(td2.LocalDir, "lol"))
+}
+
 func TestAllocDir_EscapeChecking(t *testing.T) {
 	tmp, err := ioutil.TempDir("", "AllocDir")
 	if err != nil {
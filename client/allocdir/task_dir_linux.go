@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"syscall"
 
 	multierror "github.com/hashicorp/go-multierror"
 )
@@ -34,3 +35,69 @@ func (t *TaskDir) unmountSpecialDirs() error {
 
 	return errs.ErrorOrNil()
 }
+
+// ShiftOwnership changes the owners of the directories a task writes to, and
+// of their contents, into the user namespace mapping IDs 0 to size-1 to the
+// host IDs starting at hostUID and hostGID. Files keep the owner they had on
+// the host as seen from within the namespace, so the task's root and nobody
+// users can write to them without being root or nobody on the host.
+//
+// Files owned by the host IDs of a previous mapping of the allocation,
+// starting at fromUID and fromGID, are moved to the new mapping so that the
+// task keeps access to them when its allocation is mapped to other IDs.
+// Passing the new host IDs as the previous ones moves nothing.
+//
+// IDs outside of the mapped ranges are left untouched, which makes shifting
+// idempotent as long as the host IDs don't overlap the range. Files with
+// more than one link may be hard links to host files and are skipped.
+func ShiftOwnership(taskDir, sharedAllocDir string, fromUID, fromGID, hostUID, hostGID, size uint32) error {
+	dirs := []string{
+		filepath.Join(taskDir, TaskLocal),
+		filepath.Join(taskDir, TaskSecrets),
+		filepath.Join(taskDir, TmpDirName),
+		filepath.Join(sharedAllocDir, SharedDataDir),
+		filepath.Join(sharedAllocDir, TmpDirName),
+	}
+
+	shift := func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		st, ok := info.Sys().(*syscall.Stat_t)
+		if !ok {
+			return fmt.Errorf("failed to stat %q", path)
+		}
+		if !info.IsDir() && st.Nlink > 1 {
+			return nil
+		}
+
+		uid, gid := int(st.Uid), int(st.Gid)
+		if st.Uid < size {
+			uid = int(hostUID + st.Uid)
+		} else if st.Uid >= fromUID && st.Uid-fromUID < size {
+			uid = int(hostUID + st.Uid - fromUID)
+		}
+		if st.Gid < size {
+			gid = int(hostGID + st.Gid)
+		} else if st.Gid >= fromGID && st.Gid-fromGID < size {
+			gid = int(hostGID + st.Gid - fromGID)
+		}
+		if uid == int(st.Uid) && gid == int(st.Gid) {
+			return nil
+		}
+		if err := os.Lchown(path, uid, gid); err != nil {
+			return fmt.Errorf("failed to chown %q: %v", path, err)
+		}
+		return nil
+	}
+
+	for _, dir := range dirs {
+		if !pathExists(dir) {
+			continue
+		}
+		if err := filepath.Walk(dir, shift); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package allocdir
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+// Test that shifting ownership maps the owners of the task's writable
+// directories into the user namespace and leaves the rest alone.
+func TestTaskDir_ShiftOwnership(t *testing.T) {
+	if unix.Geteuid() != 0 {
+		t.Skip("Must be run as root")
+	}
+	require := require.New(t)
+
+	tmp, err := ioutil.TempDir("", "AllocDir")
+	require.NoError(err)
+	defer os.RemoveAll(tmp)
+
+	d := NewAllocDir(testlog.HCLogger(t), tmp, "test")
+	defer d.Destroy()
+	td := d.NewTaskDir(t1.Name)
+	require.NoError(d.Build())
+	require.NoError(td.Build(false, nil))
+
+	owner := func(path string) (uint32, uint32) {
+		fi, err := os.Lstat(path)
+		require.NoError(err)
+		st := fi.Sys().(*syscall.Stat_t)
+		return st.Uid, st.Gid
+	}
+
+	// A file owned by root, and a hard link that may point to a host file
+	file := filepath.Join(td.LocalDir, "file")
+	require.NoError(ioutil.WriteFile(file, []byte("hi"), 0644))
+	host := filepath.Join(tmp, "host")
+	require.NoError(ioutil.WriteFile(host, []byte("hi"), 0644))
+	linked := filepath.Join(td.LocalDir, "linked")
+	require.NoError(os.Link(host, linked))
+
+	// A file owned by an ID outside the mapped range
+	outside := filepath.Join(td.LocalDir, "outside")
+	require.NoError(ioutil.WriteFile(outside, []byte("hi"), 0644))
+	require.NoError(os.Lchown(outside, 500000, 500000))
+
+	nobodyUID, nobodyGID := owner(td.LocalDir)
+
+	require.NoError(ShiftOwnership(td.Dir, d.SharedDir, 100000, 200000, 100000, 200000, 65536))
+
+	uid, gid := owner(td.LocalDir)
+	require.Equal(100000+nobodyUID, uid)
+	require.Equal(200000+nobodyGID, gid)
+
+	uid, gid = owner(file)
+	require.Equal(uint32(100000), uid)
+	require.Equal(uint32(200000), gid)
+
+	uid, gid = owner(filepath.Join(d.SharedDir, SharedDataDir))
+	require.Equal(100000+nobodyUID, uid)
+	require.Equal(200000+nobodyGID, gid)
+
+	uid, _ = owner(linked)
+	require.Zero(uid)
+	uid, _ = owner(outside)
+	require.Equal(uint32(500000), uid)
+
+	// The shared logs aren't writable by the task
+	uid, _ = owner(filepath.Join(d.SharedDir, LogDirName))
+	require.Equal(nobodyUID, uid)
+
+	// Shifting again is a noop
+	require.NoError(ShiftOwnership(td.Dir, d.SharedDir, 100000, 200000, 100000, 200000, 65536))
+	uid, _ = owner(file)
+	require.Equal(uint32(100000), uid)
+
+	// Files are moved from a previous mapping to a new one
+	require.NoError(ShiftOwnership(td.Dir, d.SharedDir, 100000, 200000, 300000, 400000, 65536))
+	uid, gid = owner(td.LocalDir)
+	require.Equal(300000+nobodyUID, uid)
+	require.Equal(400000+nobodyGID, gid)
+	uid, gid = owner(file)
+	require.Equal(uint32(300000), uid)
+	require.Equal(uint32(400000), gid)
+	uid, _ = owner(outside)
+	require.Equal(uint32(500000), uid)
+}
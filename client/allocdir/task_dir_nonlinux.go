@@ -3,7 +3,15 @@
 
 package allocdir
 
+import "errors"
+
 // currently a noop on non-Linux platforms
 func (t *TaskDir) unmountSpecialDirs() error {
 	return nil
 }
+
+// ShiftOwnership is only supported on Linux, where tasks may run in user
+// namespaces.
+func ShiftOwnership(taskDir, sharedAllocDir string, fromUID, fromGID, hostUID, hostGID, size uint32) error {
+	return errors.New("user namespaces are only supported on Linux")
+}
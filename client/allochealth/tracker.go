@@ -3,6 +3,7 @@ package allochealth
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -24,6 +25,13 @@ const (
 	consulCheckLookupInterval = 500 * time.Millisecond
 )
 
+// NomadChecksAPI is the part of the runner of the checks of the services using
+// the nomad provider the Tracker requires.
+type NomadChecksAPI interface {
+	// Results returns the latest results of the checks by ID.
+	Results() map[string]*structs.CheckResult
+}
+
 // Tracker tracks the health of an allocation and makes health events watchable
 // via channels.
 type Tracker struct {
@@ -48,16 +56,30 @@ type Tracker struct {
 	// useChecks specifies whether to use Consul healh checks or not
 	useChecks bool
 
-	// consulCheckCount is the number of checks the task group will attempt to
-	// register
+	// consulCheckCount is the number of readiness checks the task group will
+	// attempt to register
 	consulCheckCount int
 
+	// nomadCheckCount is the number of readiness checks of services using the
+	// nomad provider, which are run by the client rather than Consul
+	nomadCheckCount int
+
+	// consulLivenessCount and nomadLivenessCount are the number of liveness
+	// checks of each provider. They do not determine the health of the
+	// allocation but are watched to report their status separately.
+	consulLivenessCount int
+	nomadLivenessCount  int
+
 	// allocUpdates is a listener for retrieving new alloc updates
 	allocUpdates *cstructs.AllocListener
 
 	// consulClient is used to look up the state of the task's checks
 	consulClient cconsul.ConsulServiceAPI
 
+	// nomadChecks is used to look up the results of the checks of services
+	// using the nomad provider
+	nomadChecks NomadChecksAPI
+
 	// healthy is used to signal whether we have determined the allocation to be
 	// healthy or unhealthy
 	healthy chan bool
@@ -83,6 +105,10 @@ type Tracker struct {
 	// checksHealthy marks whether all the task's Consul checks are healthy
 	checksHealthy bool
 
+	// nomadChecksHealthy marks whether all the checks of the services using
+	// the nomad provider are healthy
+	nomadChecksHealthy bool
+
 	// taskHealth contains the health state for each task
 	taskHealth map[string]*taskHealthState
 
@@ -90,11 +116,12 @@ type Tracker struct {
 }
 
 // NewTracker returns a health tracker for the given allocation. An alloc
-// listener and consul API object are given so that the watcher can detect
-// health changes.
+// listener, consul API object and the runner of the checks of the services
+// using the nomad provider are given so that the watcher can detect health
+// changes.
 func NewTracker(parentCtx context.Context, logger hclog.Logger, alloc *structs.Allocation,
 	allocUpdates *cstructs.AllocListener, consulClient cconsul.ConsulServiceAPI,
-	minHealthyTime time.Duration, useChecks bool) *Tracker {
+	nomadChecks NomadChecksAPI, minHealthyTime time.Duration, useChecks bool) *Tracker {
 
 	// Do not create a named sub-logger as the hook controlling
 	// this struct should pass in an appropriately named
@@ -108,6 +135,7 @@ func NewTracker(parentCtx context.Context, logger hclog.Logger, alloc *structs.A
 		useChecks:           useChecks,
 		allocUpdates:        allocUpdates,
 		consulClient:        consulClient,
+		nomadChecks:         nomadChecks,
 		checkLookupInterval: consulCheckLookupInterval,
 		logger:              logger,
 		lifecycleTasks:      map[string]string{},
@@ -121,25 +149,44 @@ func NewTracker(parentCtx context.Context, logger hclog.Logger, alloc *structs.A
 			t.lifecycleTasks[task.Name] = task.Lifecycle.Hook
 		}
 
-		for _, s := range task.Services {
-			t.consulCheckCount += len(s.Checks)
-		}
+		t.countChecks(task.Services)
 	}
 
-	for _, s := range t.tg.Services {
-		t.consulCheckCount += len(s.Checks)
-	}
+	t.countChecks(t.tg.Services)
 
 	t.ctx, t.cancelFn = context.WithCancel(parentCtx)
 	return t
 }
 
+// countChecks counts the readiness and liveness checks of the services by
+// provider.
+func (t *Tracker) countChecks(services []*structs.Service) {
+	for _, s := range services {
+		nomad := s.Provider == structs.ServiceProviderNomad
+		for _, c := range s.Checks {
+			switch {
+			case c.IsReadiness() && nomad:
+				t.nomadCheckCount++
+			case c.IsReadiness():
+				t.consulCheckCount++
+			case nomad:
+				t.nomadLivenessCount++
+			default:
+				t.consulLivenessCount++
+			}
+		}
+	}
+}
+
 // Start starts the watcher.
 func (t *Tracker) Start() {
 	go t.watchTaskEvents()
-	if t.useChecks {
+	if t.useChecks && t.consulCheckCount+t.consulLivenessCount > 0 {
 		go t.watchConsulEvents()
 	}
+	if t.useChecks && t.nomadCheckCount+t.nomadLivenessCount > 0 {
+		go t.watchNomadEvents()
+	}
 }
 
 // HealthyCh returns a channel that will emit a boolean indicating the health of
@@ -190,13 +237,14 @@ func (t *Tracker) setTaskHealth(healthy, terminal bool) {
 	// if unhealthy, force waiting for new checks health status
 	if !terminal && !healthy {
 		t.checksHealthy = false
+		t.nomadChecksHealthy = false
 		return
 	}
 
-	// If we are marked healthy but we also require Consul to be healthy and it
-	// isn't yet, return, unless the task is terminal
-	requireConsul := t.useChecks && t.consulCheckCount > 0
-	if !terminal && healthy && requireConsul && !t.checksHealthy {
+	// If we are marked healthy but we also require the checks to be healthy
+	// and they aren't yet, return, unless the task is terminal
+	requireChecks := t.useChecks && (t.consulCheckCount > 0 || t.nomadCheckCount > 0)
+	if !terminal && healthy && requireChecks && !t.allChecksHealthyLocked() {
 		return
 	}
 
@@ -209,8 +257,9 @@ func (t *Tracker) setTaskHealth(healthy, terminal bool) {
 	t.cancelFn()
 }
 
-// setCheckHealth is used to mark the checks as either healthy or unhealthy.
-// returns true if health is propagated and no more health monitoring is needed
+// setCheckHealth is used to mark the Consul checks as either healthy or
+// unhealthy. returns true if health is propagated and no more health
+// monitoring is needed
 func (t *Tracker) setCheckHealth(healthy bool) bool {
 	t.l.Lock()
 	defer t.l.Unlock()
@@ -218,9 +267,34 @@ func (t *Tracker) setCheckHealth(healthy bool) bool {
 	// check health should always be false if tasks are unhealthy
 	// as checks might be missing from unhealthy tasks
 	t.checksHealthy = healthy && t.tasksHealthy
+	return t.propagateCheckHealthLocked(healthy)
+}
+
+// setNomadCheckHealth is used to mark the checks of the services using the
+// nomad provider as either healthy or unhealthy. returns true if health is
+// propagated and no more health monitoring is needed
+func (t *Tracker) setNomadCheckHealth(healthy bool) bool {
+	t.l.Lock()
+	defer t.l.Unlock()
 
+	t.nomadChecksHealthy = healthy && t.tasksHealthy
+	return t.propagateCheckHealthLocked(healthy)
+}
+
+// allChecksHealthyLocked returns whether the checks of every provider are
+// healthy. The caller must hold the lock.
+func (t *Tracker) allChecksHealthyLocked() bool {
+	consulHealthy := t.consulCheckCount == 0 || t.checksHealthy
+	nomadHealthy := t.nomadCheckCount == 0 || t.nomadChecksHealthy
+	return consulHealthy && nomadHealthy
+}
+
+// propagateCheckHealthLocked signals the health of the allocation once the
+// checks of every provider and the tasks are healthy. The caller must hold
+// the lock.
+func (t *Tracker) propagateCheckHealthLocked(healthy bool) bool {
 	// Only signal if we are healthy and so is the tasks
-	if !t.checksHealthy {
+	if !healthy || !t.tasksHealthy || !t.allChecksHealthyLocked() {
 		return false
 	}
 
@@ -421,13 +495,17 @@ OUTER:
 		}
 		t.l.Unlock()
 
-		// Detect if all the checks are passing
+		// Detect if all the readiness checks are passing
 		passed := true
 
 	CHECKS:
 		for _, treg := range allocReg.Tasks {
 			for _, sreg := range treg.Services {
 				for _, check := range sreg.Checks {
+					if sreg.CheckKind[check.CheckID] == structs.ServiceCheckLiveness {
+						continue
+					}
+
 					onupdate := sreg.CheckOnUpdate[check.CheckID]
 					switch check.Status {
 					case api.HealthPassing:
@@ -476,6 +554,119 @@ OUTER:
 	}
 }
 
+// watchNomadEvents is a watcher for the health of the allocation's checks of
+// services using the nomad provider, run by the client. If all checks report
+// healthy the watcher will exit after the MinHealthyTime has been reached,
+// Otherwise the watcher will continue to check unhealthy checks until the ctx
+// is cancelled
+func (t *Tracker) watchNomadEvents() {
+	// checkTicker is the ticker that triggers us to look at the results of
+	// the checks
+	checkTicker := time.NewTicker(t.checkLookupInterval)
+	defer checkTicker.Stop()
+
+	// healthyTimer fires when the checks have been healthy for the
+	// MinHealthyTime
+	healthyTimer := time.NewTimer(0)
+	if !healthyTimer.Stop() {
+		select {
+		case <-healthyTimer.C:
+		default:
+		}
+	}
+
+	// primed marks whether the healthy timer has been set
+	primed := false
+
+	// results are the latest results of the checks
+	var results map[string]*structs.CheckResult
+
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-checkTicker.C:
+			results = t.nomadChecks.Results()
+		case <-healthyTimer.C:
+			if t.setNomadCheckHealth(true) {
+				// final health set and propagated
+				return
+			}
+			// tasks are unhealthy, reset and wait until all is healthy
+			primed = false
+		}
+
+		// Store the results of the checks of each task
+		t.l.Lock()
+		for _, v := range t.taskHealth {
+			v.checkResults = nil
+		}
+		for _, result := range results {
+			if v, ok := t.taskHealth[result.Task]; ok {
+				v.checkResults = append(v.checkResults, result)
+			}
+		}
+		t.l.Unlock()
+
+		// Detect if all the readiness checks are running and passing
+		readiness := 0
+		passed := true
+		for _, result := range results {
+			if result.Kind == structs.ServiceCheckLiveness {
+				continue
+			}
+			readiness++
+			if !checkResultPassing(result) {
+				passed = false
+			}
+		}
+		if readiness < t.nomadCheckCount {
+			passed = false
+		}
+
+		if !passed {
+			t.setNomadCheckHealth(false)
+
+			// Reset the timer since we have transitioned back to unhealthy
+			if primed {
+				if !healthyTimer.Stop() {
+					select {
+					case <-healthyTimer.C:
+					default:
+					}
+				}
+				primed = false
+			}
+		} else if !primed {
+			// Reset the timer to fire after MinHealthyTime
+			if !healthyTimer.Stop() {
+				select {
+				case <-healthyTimer.C:
+				default:
+				}
+			}
+
+			primed = true
+			healthyTimer.Reset(t.minHealthyTime)
+		}
+	}
+}
+
+// checkResultPassing returns whether the result of a check allows the
+// allocation to be healthy, given the on_update mode of the check.
+func checkResultPassing(result *structs.CheckResult) bool {
+	switch result.Status {
+	case structs.CheckStatusPassing:
+		return true
+	case structs.CheckStatusWarning:
+		return result.OnUpdate == structs.OnUpdateIgnoreWarn || result.OnUpdate == structs.OnUpdateIgnore
+	case structs.CheckStatusCritical:
+		return result.OnUpdate == structs.OnUpdateIgnore
+	default:
+		return false
+	}
+}
+
 // taskHealthState captures all known health information about a task. It is
 // largely used to determine if the task has contributed to the allocation being
 // unhealthy.
@@ -483,21 +674,31 @@ type taskHealthState struct {
 	task              *structs.Task
 	state             *structs.TaskState
 	taskRegistrations *consul.ServiceRegistrations
+
+	// checkResults are the results of the checks of the task's services
+	// using the nomad provider
+	checkResults []*structs.CheckResult
 }
 
 // event takes the deadline time for the allocation to be healthy and the update
 // strategy of the group. It returns true if the task has contributed to the
-// allocation being unhealthy and if so, an event description of why.
+// allocation being unhealthy and if so, an event description of why. Failing
+// liveness checks do not make the task unhealthy but are reported separately.
 func (t *taskHealthState) event(deadline time.Time, minHealthyTime time.Duration, useChecks bool) (string, bool) {
-	requireChecks := false
-	desiredChecks := 0
+	desiredChecks, desiredNomadChecks := 0, 0
 	for _, s := range t.task.Services {
-		if nc := len(s.Checks); nc > 0 {
-			requireChecks = true
-			desiredChecks += nc
+		for _, c := range s.Checks {
+			if !c.IsReadiness() {
+				continue
+			}
+			if s.Provider == structs.ServiceProviderNomad {
+				desiredNomadChecks++
+			} else {
+				desiredChecks++
+			}
 		}
 	}
-	requireChecks = requireChecks && useChecks
+	requireChecks := desiredChecks > 0 && useChecks
 
 	if t.state != nil {
 		if t.state.Failed {
@@ -520,6 +721,8 @@ func (t *taskHealthState) event(deadline time.Time, minHealthyTime time.Duration
 		}
 	}
 
+	failingLiveness := t.failingLivenessChecks()
+
 	if t.taskRegistrations != nil {
 		var notPassing []string
 		passing := 0
@@ -527,6 +730,10 @@ func (t *taskHealthState) event(deadline time.Time, minHealthyTime time.Duration
 	OUTER:
 		for _, sreg := range t.taskRegistrations.Services {
 			for _, check := range sreg.Checks {
+				if sreg.CheckKind[check.CheckID] == structs.ServiceCheckLiveness {
+					continue
+				}
+
 				if check.Status != api.HealthPassing {
 					notPassing = append(notPassing, sreg.Service.Service)
 					continue OUTER
@@ -537,16 +744,76 @@ func (t *taskHealthState) event(deadline time.Time, minHealthyTime time.Duration
 		}
 
 		if len(notPassing) != 0 {
-			return fmt.Sprintf("Services not healthy by deadline: %s", strings.Join(notPassing, ", ")), true
+			return withLiveness(fmt.Sprintf("Services not healthy by deadline: %s", strings.Join(notPassing, ", ")), failingLiveness), true
 		}
 
 		if passing != desiredChecks {
-			return fmt.Sprintf("Only %d out of %d checks registered and passing", passing, desiredChecks), true
+			return withLiveness(fmt.Sprintf("Only %d out of %d checks registered and passing", passing, desiredChecks), failingLiveness), true
 		}
 
 	} else if requireChecks {
-		return "Service checks not registered", true
+		return withLiveness("Service checks not registered", failingLiveness), true
+	}
+
+	if useChecks && desiredNomadChecks > 0 {
+		notPassing := make(map[string]struct{})
+		passing := 0
+		for _, result := range t.checkResults {
+			if result.Kind == structs.ServiceCheckLiveness {
+				continue
+			}
+
+			if result.Status != structs.CheckStatusPassing {
+				notPassing[result.Service] = struct{}{}
+			} else {
+				passing++
+			}
+		}
+
+		if len(notPassing) != 0 {
+			services := make([]string, 0, len(notPassing))
+			for service := range notPassing {
+				services = append(services, service)
+			}
+			sort.Strings(services)
+			return withLiveness(fmt.Sprintf("Services not healthy by deadline: %s", strings.Join(services, ", ")), failingLiveness), true
+		}
+
+		if passing != desiredNomadChecks {
+			return withLiveness(fmt.Sprintf("Only %d out of %d checks running and passing", passing, desiredNomadChecks), failingLiveness), true
+		}
 	}
 
 	return "", false
 }
+
+// failingLivenessChecks returns the sorted names of the liveness checks of the
+// task that are not passing, as "service/check".
+func (t *taskHealthState) failingLivenessChecks() []string {
+	var failing []string
+	if t.taskRegistrations != nil {
+		for _, sreg := range t.taskRegistrations.Services {
+			for _, check := range sreg.Checks {
+				if sreg.CheckKind[check.CheckID] == structs.ServiceCheckLiveness && check.Status != api.HealthPassing {
+					failing = append(failing, fmt.Sprintf("%s/%s", sreg.Service.Service, check.Name))
+				}
+			}
+		}
+	}
+	for _, result := range t.checkResults {
+		if result.Kind == structs.ServiceCheckLiveness && result.Status != structs.CheckStatusPassing {
+			failing = append(failing, fmt.Sprintf("%s/%s", result.Service, result.Check))
+		}
+	}
+	sort.Strings(failing)
+	return failing
+}
+
+// withLiveness appends the failing liveness checks to the description of why
+// the readiness checks of a task are unhealthy.
+func withLiveness(desc string, failingLiveness []string) string {
+	if len(failingLiveness) == 0 {
+		return desc
+	}
+	return fmt.Sprintf("%s; liveness checks failing: %s", desc, strings.Join(failingLiveness, ", "))
+}
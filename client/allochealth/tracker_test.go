@@ -3,6 +3,7 @@ package allochealth
 import (
 	"context"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -76,7 +77,7 @@ func TestTracker_Checks_Healthy(t *testing.T) {
 	defer cancelFn()
 
 	checkInterval := 10 * time.Millisecond
-	tracker := NewTracker(ctx, logger, alloc, b.Listen(), consul,
+	tracker := NewTracker(ctx, logger, alloc, b.Listen(), consul, nil,
 		time.Millisecond, true)
 	tracker.checkLookupInterval = checkInterval
 	tracker.Start()
@@ -116,7 +117,7 @@ func TestTracker_Checks_PendingPostStop_Healthy(t *testing.T) {
 	defer cancelFn()
 
 	checkInterval := 10 * time.Millisecond
-	tracker := NewTracker(ctx, logger, alloc, b.Listen(), consul,
+	tracker := NewTracker(ctx, logger, alloc, b.Listen(), consul, nil,
 		time.Millisecond, true)
 	tracker.checkLookupInterval = checkInterval
 	tracker.Start()
@@ -157,7 +158,7 @@ func TestTracker_Succeeded_PostStart_Healthy(t *testing.T) {
 	defer cancelFn()
 
 	checkInterval := 10 * time.Millisecond
-	tracker := NewTracker(ctx, logger, alloc, b.Listen(), consul,
+	tracker := NewTracker(ctx, logger, alloc, b.Listen(), consul, nil,
 		alloc.Job.TaskGroups[0].Migrate.MinHealthyTime, true)
 	tracker.checkLookupInterval = checkInterval
 	tracker.Start()
@@ -236,7 +237,7 @@ func TestTracker_Checks_Unhealthy(t *testing.T) {
 	defer cancelFn()
 
 	checkInterval := 10 * time.Millisecond
-	tracker := NewTracker(ctx, logger, alloc, b.Listen(), consul,
+	tracker := NewTracker(ctx, logger, alloc, b.Listen(), consul, nil,
 		time.Millisecond, true)
 	tracker.checkLookupInterval = checkInterval
 	tracker.Start()
@@ -249,7 +250,7 @@ func TestTracker_Checks_Unhealthy(t *testing.T) {
 	})
 
 	tracker.l.Lock()
-	require.False(t, tracker.checksHealthy)
+	require.False(t, tracker.nomadChecksHealthy)
 	tracker.l.Unlock()
 
 	select {
@@ -269,7 +270,7 @@ func TestTracker_Healthy_IfBothTasksAndConsulChecksAreHealthy(t *testing.T) {
 	ctx, cancelFn := context.WithCancel(context.Background())
 	defer cancelFn()
 
-	tracker := NewTracker(ctx, logger, alloc, nil, nil,
+	tracker := NewTracker(ctx, logger, alloc, nil, nil, nil,
 		time.Millisecond, true)
 
 	assertNoHealth := func() {
@@ -378,7 +379,7 @@ func TestTracker_Checks_Healthy_Before_TaskHealth(t *testing.T) {
 	defer cancelFn()
 
 	checkInterval := 10 * time.Millisecond
-	tracker := NewTracker(ctx, logger, alloc, b.Listen(), consul,
+	tracker := NewTracker(ctx, logger, alloc, b.Listen(), consul, nil,
 		time.Millisecond, true)
 	tracker.checkLookupInterval = checkInterval
 	tracker.Start()
@@ -391,7 +392,7 @@ func TestTracker_Checks_Healthy_Before_TaskHealth(t *testing.T) {
 		require.Fail(t, "unexpected health event", h)
 	}
 	require.False(t, tracker.tasksHealthy)
-	require.False(t, tracker.checksHealthy)
+	require.False(t, tracker.nomadChecksHealthy)
 
 	// now set task to healthy
 	runningAlloc := alloc.Copy()
@@ -520,7 +521,7 @@ func TestTracker_Checks_OnUpdate(t *testing.T) {
 			defer cancelFn()
 
 			checkInterval := 10 * time.Millisecond
-			tracker := NewTracker(ctx, logger, alloc, b.Listen(), consul,
+			tracker := NewTracker(ctx, logger, alloc, b.Listen(), consul, nil,
 				time.Millisecond, true)
 			tracker.checkLookupInterval = checkInterval
 			tracker.Start()
@@ -547,3 +548,229 @@ func TestTracker_Checks_OnUpdate(t *testing.T) {
 		})
 	}
 }
+
+// fakeNomadChecks returns the results of the checks of an allocation using
+// the nomad provider.
+type fakeNomadChecks struct {
+	l       sync.Mutex
+	results map[string]*structs.CheckResult
+}
+
+func (f *fakeNomadChecks) Results() map[string]*structs.CheckResult {
+	f.l.Lock()
+	defer f.l.Unlock()
+	return f.results
+}
+
+func (f *fakeNomadChecks) set(results map[string]*structs.CheckResult) {
+	f.l.Lock()
+	defer f.l.Unlock()
+	f.results = results
+}
+
+func TestTracker_NomadChecks(t *testing.T) {
+	t.Parallel()
+
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].Migrate.MinHealthyTime = 1 // let's speed things up
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	for _, s := range task.Services {
+		s.Provider = structs.ServiceProviderNomad
+	}
+
+	// Synthesize running alloc and tasks
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	alloc.TaskStates = map[string]*structs.TaskState{
+		task.Name: {
+			State:     structs.TaskStateRunning,
+			StartedAt: time.Now(),
+		},
+	}
+
+	results := func(status string) map[string]*structs.CheckResult {
+		out := make(map[string]*structs.CheckResult)
+		for _, s := range task.Services {
+			for _, c := range s.Checks {
+				id := s.Name + "-" + c.Name
+				out[id] = &structs.CheckResult{
+					ID:       id,
+					Service:  s.Name,
+					Check:    c.Name,
+					Task:     task.Name,
+					OnUpdate: structs.OnUpdateRequireHealthy,
+					Status:   status,
+				}
+			}
+		}
+		return out
+	}
+	nomadChecks := &fakeNomadChecks{}
+	nomadChecks.set(results(structs.CheckStatusCritical))
+
+	logger := testlog.HCLogger(t)
+	b := cstructs.NewAllocBroadcaster(logger)
+	defer b.Close()
+
+	// Consul is not queried for the checks of nomad services
+	consul := consul.NewMockConsulServiceClient(t, logger)
+	consul.AllocRegistrationsFn = func(string) (*agentconsul.AllocRegistration, error) {
+		require.Fail(t, "unexpected Consul lookup")
+		return nil, nil
+	}
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	defer cancelFn()
+
+	checkInterval := 10 * time.Millisecond
+	tracker := NewTracker(ctx, logger, alloc, b.Listen(), consul, nomadChecks,
+		time.Millisecond, true)
+	tracker.checkLookupInterval = checkInterval
+	tracker.Start()
+
+	// Critical checks keep the allocation from being healthy
+	select {
+	case <-time.After(4 * checkInterval):
+	case h := <-tracker.HealthyCh():
+		require.Fail(t, "unexpected health event", h)
+	}
+
+	tracker.l.Lock()
+	require.False(t, tracker.nomadChecksHealthy)
+	require.Len(t, tracker.taskHealth[task.Name].checkResults, 1)
+	tracker.l.Unlock()
+
+	nomadChecks.set(results(structs.CheckStatusPassing))
+	select {
+	case <-time.After(4 * checkInterval):
+		require.Fail(t, "timed out while waiting for health")
+	case h := <-tracker.HealthyCh():
+		require.True(t, h)
+	}
+}
+
+func TestTracker_Checks_Liveness(t *testing.T) {
+	t.Parallel()
+
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].Migrate.MinHealthyTime = 1 // let's speed things up
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Services[0].Checks = append(task.Services[0].Checks, &structs.ServiceCheck{
+		Name:     "alive",
+		Type:     structs.ServiceCheckTCP,
+		Interval: time.Second,
+		Timeout:  time.Second,
+		Kind:     structs.ServiceCheckLiveness,
+	})
+
+	// Synthesize running alloc and tasks
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	alloc.TaskStates = map[string]*structs.TaskState{
+		task.Name: {
+			State:     structs.TaskStateRunning,
+			StartedAt: time.Now(),
+		},
+	}
+
+	// Make Consul response with a failing liveness check
+	ready := &consulapi.AgentCheck{
+		CheckID: "ready",
+		Name:    task.Services[0].Checks[0].Name,
+		Status:  consulapi.HealthPassing,
+	}
+	alive := &consulapi.AgentCheck{
+		CheckID: "alive",
+		Name:    "alive",
+		Status:  consulapi.HealthCritical,
+	}
+	taskRegs := map[string]*agentconsul.ServiceRegistrations{
+		task.Name: {
+			Services: map[string]*agentconsul.ServiceRegistration{
+				task.Services[0].Name: {
+					Service: &consulapi.AgentService{
+						ID:      "foo",
+						Service: task.Services[0].Name,
+					},
+					Checks: []*consulapi.AgentCheck{ready, alive},
+					CheckOnUpdate: map[string]string{
+						ready.CheckID: structs.OnUpdateRequireHealthy,
+						alive.CheckID: structs.OnUpdateRequireHealthy,
+					},
+					CheckKind: map[string]string{
+						alive.CheckID: structs.ServiceCheckLiveness,
+					},
+				},
+			},
+		},
+	}
+
+	logger := testlog.HCLogger(t)
+	b := cstructs.NewAllocBroadcaster(logger)
+	defer b.Close()
+
+	consul := consul.NewMockConsulServiceClient(t, logger)
+	consul.AllocRegistrationsFn = func(string) (*agentconsul.AllocRegistration, error) {
+		return &agentconsul.AllocRegistration{Tasks: taskRegs}, nil
+	}
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	defer cancelFn()
+
+	checkInterval := 10 * time.Millisecond
+	tracker := NewTracker(ctx, logger, alloc, b.Listen(), consul, nil,
+		time.Millisecond, true)
+	tracker.checkLookupInterval = checkInterval
+	tracker.Start()
+
+	// Liveness checks do not determine the health of the allocation
+	select {
+	case <-time.After(4 * checkInterval):
+		require.Fail(t, "timed out while waiting for health")
+	case h := <-tracker.HealthyCh():
+		require.True(t, h)
+	}
+}
+
+func TestTaskHealthState_Event_Liveness(t *testing.T) {
+	t.Parallel()
+
+	task := mock.Job().TaskGroups[0].Tasks[0]
+	task.Services[0].Checks = append(task.Services[0].Checks, &structs.ServiceCheck{
+		Name: "alive",
+		Kind: structs.ServiceCheckLiveness,
+	})
+
+	state := &taskHealthState{
+		task: task,
+		state: &structs.TaskState{
+			State:     structs.TaskStateRunning,
+			StartedAt: time.Now().Add(-time.Minute),
+		},
+		taskRegistrations: &agentconsul.ServiceRegistrations{
+			Services: map[string]*agentconsul.ServiceRegistration{
+				"foo": {
+					Service: &consulapi.AgentService{
+						Service: task.Services[0].Name,
+					},
+					Checks: []*consulapi.AgentCheck{
+						{CheckID: "ready", Name: "ready", Status: consulapi.HealthPassing},
+						{CheckID: "alive", Name: "alive", Status: consulapi.HealthCritical},
+					},
+					CheckKind: map[string]string{
+						"alive": structs.ServiceCheckLiveness,
+					},
+				},
+			},
+		},
+	}
+
+	// Failing liveness checks alone do not make the task unhealthy
+	_, ok := state.event(time.Now(), time.Second, true)
+	require.False(t, ok)
+
+	// They are reported separately when the readiness checks fail
+	state.taskRegistrations.Services["foo"].Checks[0].Status = consulapi.HealthCritical
+	desc, ok := state.event(time.Now(), time.Second, true)
+	require.True(t, ok)
+	require.Equal(t, fmt.Sprintf("Services not healthy by deadline: %s; liveness checks failing: %s/alive",
+		task.Services[0].Name, task.Services[0].Name), desc)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/nomad/client/allocrunner/state"
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner"
 	"github.com/hashicorp/nomad/client/allocwatcher"
+	"github.com/hashicorp/nomad/client/checks"
 	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/consul"
 	"github.com/hashicorp/nomad/client/devicemanager"
@@ -62,9 +63,14 @@ type allocRunner struct {
 	allocUpdatedCh chan *structs.Allocation
 
 	// consulClient is the client used by the consul service hook for
-	// registering services and checks
+	// registering services and checks. Services using the nomad provider
+	// are handed to checkRunner instead.
 	consulClient consul.ConsulServiceAPI
 
+	// checkRunner runs the checks of the services using the nomad provider
+	// and keeps their results, which are reported with the alloc status
+	checkRunner *checks.Runner
+
 	// consulProxiesClient is the client used by the envoy version hook for
 	// looking up supported envoy versions of the consul agent.
 	consulProxiesClient consul.SupportedProxiesAPI
@@ -196,7 +202,6 @@ func NewAllocRunner(config *Config) (*allocRunner, error) {
 		id:                       alloc.ID,
 		alloc:                    alloc,
 		clientConfig:             config.ClientConfig,
-		consulClient:             config.Consul,
 		consulProxiesClient:      config.ConsulProxies,
 		sidsClient:               config.ConsulSI,
 		vaultClient:              config.Vault,
@@ -225,6 +230,11 @@ func NewAllocRunner(config *Config) (*allocRunner, error) {
 	// Create the logger based on the allocation ID
 	ar.logger = config.Logger.Named("alloc_runner").With("alloc_id", alloc.ID)
 
+	// Run the checks of the services using the nomad provider, reporting
+	// their status changes to the servers
+	ar.checkRunner = checks.NewRunner(ar.logger, ar.TaskStateUpdated)
+	ar.consulClient = checks.NewServiceHandler(config.Consul, ar.checkRunner)
+
 	// Create alloc broadcaster
 	ar.allocBroadcaster = cstructs.NewAllocBroadcaster(ar.logger)
 
@@ -529,8 +539,10 @@ func (ar *allocRunner) handleTaskStateUpdates() {
 			// Task is dead, determine if other tasks should be killed
 			if state.Failed {
 				// Only set failed event if no event has been
-				// set yet to give dead leaders priority.
-				if killEvent == nil {
+				// set yet to give dead leaders priority. Tasks
+				// whose dependents run regardless of their
+				// outcome don't take their siblings down.
+				if killEvent == nil && !ar.taskHookCoordinator.failureToleratedForTask(name) {
 					killTask = name
 					killEvent = structs.NewTaskEvent(structs.TaskSiblingFailed).
 						SetFailedSibling(name)
@@ -658,8 +670,9 @@ func (ar *allocRunner) clientAlloc(taskStates map[string]*structs.TaskState) *st
 	ar.state.TaskStates = taskStates
 
 	a := &structs.Allocation{
-		ID:         ar.id,
-		TaskStates: taskStates,
+		ID:           ar.id,
+		TaskStates:   taskStates,
+		CheckResults: ar.checkRunner.Results(),
 	}
 
 	if d := ar.state.DeploymentStatus; d != nil {
@@ -895,6 +908,9 @@ func (ar *allocRunner) destroyImpl() {
 	// Wait for tasks to exit and postrun hooks to finish
 	<-ar.waitCh
 
+	// Stop the checks of services that were not deregistered
+	ar.checkRunner.Shutdown()
+
 	// Run destroy hooks
 	if err := ar.destroy(); err != nil {
 		ar.logger.Warn("error running destroy hooks", "error", err)
@@ -1073,6 +1089,9 @@ func (ar *allocRunner) Shutdown() {
 		// Wait for Run to exit
 		<-ar.waitCh
 
+		// Stop the checks, which are run again once the alloc is restored
+		ar.checkRunner.Shutdown()
+
 		// Run shutdown hooks
 		ar.shutdownHooks()
 
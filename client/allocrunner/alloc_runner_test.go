@@ -1423,6 +1423,75 @@ func TestAllocRunner_TaskFailed_KillTG(t *testing.T) {
 	})
 }
 
+// Test that a failed task kills the tasks waiting on its success, and that
+// tasks depending on its completion still run.
+func TestAllocRunner_TaskDependencies(t *testing.T) {
+	t.Parallel()
+
+	run := func(condition string) *structs.Allocation {
+		alloc := mock.BatchAlloc()
+		tr := alloc.AllocatedResources.Tasks[alloc.Job.TaskGroups[0].Tasks[0].Name]
+		alloc.Job.TaskGroups[0].RestartPolicy.Attempts = 0
+
+		upstream := alloc.Job.TaskGroups[0].Tasks[0]
+		upstream.Name = "upstream"
+		upstream.Driver = "mock_driver"
+		upstream.RestartPolicy.Attempts = 0
+		upstream.Config = map[string]interface{}{
+			"start_error": "fail task please",
+		}
+
+		downstream := upstream.Copy()
+		downstream.Name = "downstream"
+		downstream.DependsOn = []string{upstream.Name}
+		downstream.DependsOnCondition = condition
+		downstream.Config = map[string]interface{}{
+			"run_for": "10ms",
+		}
+		alloc.Job.TaskGroups[0].Tasks = []*structs.Task{upstream, downstream}
+		alloc.AllocatedResources.Tasks = map[string]*structs.AllocatedTaskResources{
+			upstream.Name:   tr,
+			downstream.Name: tr,
+		}
+
+		conf, cleanup := testAllocRunnerConfig(t, alloc)
+		defer cleanup()
+		ar, err := NewAllocRunner(conf)
+		require.NoError(t, err)
+		defer destroy(ar)
+		go ar.Run()
+
+		upd := conf.StateUpdater.(*MockStateUpdater)
+		testutil.WaitForResult(func() (bool, error) {
+			last := upd.Last()
+			if last == nil {
+				return false, fmt.Errorf("No updates")
+			}
+			for name, state := range last.TaskStates {
+				if state.State != structs.TaskStateDead {
+					return false, fmt.Errorf("task %s is %s; want dead", name, state.State)
+				}
+			}
+			return true, nil
+		}, func(err error) {
+			require.Fail(t, "err: %v", err)
+		})
+
+		return upd.Last()
+	}
+
+	// The downstream task never starts if the upstream task fails
+	last := run(structs.TaskDependencyConditionSuccess)
+	require.Equal(t, structs.AllocClientStatusFailed, last.ClientStatus)
+	require.True(t, last.TaskStates["downstream"].StartedAt.IsZero())
+
+	// The downstream task runs regardless of the upstream outcome
+	last = run(structs.TaskDependencyConditionComplete)
+	require.Equal(t, structs.AllocClientStatusFailed, last.ClientStatus)
+	require.False(t, last.TaskStates["downstream"].StartedAt.IsZero())
+	require.False(t, last.TaskStates["downstream"].Failed)
+}
+
 // Test that alloc becoming terminal should destroy the alloc runner
 func TestAllocRunner_TerminalUpdate_Destroy(t *testing.T) {
 	t.Parallel()
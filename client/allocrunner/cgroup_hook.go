@@ -1,20 +1,27 @@
 package allocrunner
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
-func newCgroupHook(alloc *structs.Allocation, man cgutil.CpusetManager) *cgroupHook {
+func newCgroupHook(alloc *structs.Allocation, man cgutil.CpusetManager, cgroupParent string) *cgroupHook {
 	return &cgroupHook{
 		alloc:         alloc,
 		cpusetManager: man,
+		allocCgroup:   cgutil.AllocCgroupParent(cgroupParent, alloc),
 	}
 }
 
 type cgroupHook struct {
 	alloc         *structs.Allocation
 	cpusetManager cgutil.CpusetManager
+
+	// allocCgroup is the cgroup holding the CPU shares and memory ceiling
+	// shared by the tasks of the allocation, if its group has resources
+	allocCgroup string
 }
 
 func (c *cgroupHook) Name() string {
@@ -23,10 +30,23 @@ func (c *cgroupHook) Name() string {
 
 func (c *cgroupHook) Prerun() error {
 	c.cpusetManager.AddAlloc(c.alloc)
+
+	if c.allocCgroup != "" {
+		shared := c.alloc.AllocatedResources.Shared
+		if err := cgutil.CreateAllocCgroup(c.allocCgroup, shared.Cpu.CpuShares, shared.Memory.MemoryMB); err != nil {
+			return fmt.Errorf("failed to create alloc cgroup: %v", err)
+		}
+	}
 	return nil
 }
 
 func (c *cgroupHook) Postrun() error {
 	c.cpusetManager.RemoveAlloc(c.alloc.ID)
+
+	if c.allocCgroup != "" {
+		if err := cgutil.DestroyAllocCgroup(c.allocCgroup); err != nil {
+			return fmt.Errorf("failed to destroy alloc cgroup: %v", err)
+		}
+	}
 	return nil
 }
@@ -35,6 +35,10 @@ type allocHealthWatcherHook struct {
 	// consul client used to monitor health checks
 	consul consul.ConsulServiceAPI
 
+	// nomadChecks is used to monitor the checks of services using the nomad
+	// provider
+	nomadChecks allochealth.NomadChecksAPI
+
 	// listener is given to trackers to listen for alloc updates and closed
 	// when the alloc is destroyed.
 	listener *cstructs.AllocListener
@@ -68,7 +72,7 @@ type allocHealthWatcherHook struct {
 }
 
 func newAllocHealthWatcherHook(logger log.Logger, alloc *structs.Allocation, hs healthSetter,
-	listener *cstructs.AllocListener, consul consul.ConsulServiceAPI) interfaces.RunnerHook {
+	listener *cstructs.AllocListener, consul consul.ConsulServiceAPI, nomadChecks allochealth.NomadChecksAPI) interfaces.RunnerHook {
 
 	// Neither deployments nor migrations care about the health of
 	// non-service jobs so never watch their health
@@ -85,6 +89,7 @@ func newAllocHealthWatcherHook(logger log.Logger, alloc *structs.Allocation, hs
 		cancelFn:     func() {}, // initialize to prevent nil func panics
 		watchDone:    closedDone,
 		consul:       consul,
+		nomadChecks:  nomadChecks,
 		healthSetter: hs,
 		listener:     listener,
 	}
@@ -133,7 +138,7 @@ func (h *allocHealthWatcherHook) init() error {
 	h.logger.Trace("watching", "deadline", deadline, "checks", useChecks, "min_healthy_time", minHealthyTime)
 	// Create a new tracker, start it, and watch for health results.
 	tracker := allochealth.NewTracker(ctx, h.logger, h.alloc,
-		h.listener, h.consul, minHealthyTime, useChecks)
+		h.listener, h.consul, h.nomadChecks, minHealthyTime, useChecks)
 	tracker.Start()
 
 	// Create a new done chan and start watching for health updates
@@ -95,7 +95,7 @@ func TestHealthHook_PrerunPostrun(t *testing.T) {
 	consul := consul.NewMockConsulServiceClient(t, logger)
 	hs := &mockHealthSetter{}
 
-	h := newAllocHealthWatcherHook(logger, mock.Alloc(), hs, b.Listen(), consul)
+	h := newAllocHealthWatcherHook(logger, mock.Alloc(), hs, b.Listen(), consul, nil)
 
 	// Assert we implemented the right interfaces
 	prerunh, ok := h.(interfaces.RunnerPrerunHook)
@@ -133,7 +133,7 @@ func TestHealthHook_PrerunUpdatePostrun(t *testing.T) {
 	consul := consul.NewMockConsulServiceClient(t, logger)
 	hs := &mockHealthSetter{}
 
-	h := newAllocHealthWatcherHook(logger, alloc.Copy(), hs, b.Listen(), consul).(*allocHealthWatcherHook)
+	h := newAllocHealthWatcherHook(logger, alloc.Copy(), hs, b.Listen(), consul, nil).(*allocHealthWatcherHook)
 
 	// Prerun
 	require.NoError(h.Prerun())
@@ -172,7 +172,7 @@ func TestHealthHook_UpdatePrerunPostrun(t *testing.T) {
 	consul := consul.NewMockConsulServiceClient(t, logger)
 	hs := &mockHealthSetter{}
 
-	h := newAllocHealthWatcherHook(logger, alloc.Copy(), hs, b.Listen(), consul).(*allocHealthWatcherHook)
+	h := newAllocHealthWatcherHook(logger, alloc.Copy(), hs, b.Listen(), consul, nil).(*allocHealthWatcherHook)
 
 	// Set a DeploymentID to cause ClearHealth to be called
 	alloc.DeploymentID = uuid.Generate()
@@ -213,7 +213,7 @@ func TestHealthHook_Postrun(t *testing.T) {
 	consul := consul.NewMockConsulServiceClient(t, logger)
 	hs := &mockHealthSetter{}
 
-	h := newAllocHealthWatcherHook(logger, mock.Alloc(), hs, b.Listen(), consul).(*allocHealthWatcherHook)
+	h := newAllocHealthWatcherHook(logger, mock.Alloc(), hs, b.Listen(), consul, nil).(*allocHealthWatcherHook)
 
 	// Postrun
 	require.NoError(h.Postrun())
@@ -279,7 +279,7 @@ func TestHealthHook_SetHealth_healthy(t *testing.T) {
 
 	hs := newMockHealthSetter()
 
-	h := newAllocHealthWatcherHook(logger, alloc.Copy(), hs, b.Listen(), consul).(*allocHealthWatcherHook)
+	h := newAllocHealthWatcherHook(logger, alloc.Copy(), hs, b.Listen(), consul, nil).(*allocHealthWatcherHook)
 
 	// Prerun
 	require.NoError(h.Prerun())
@@ -367,7 +367,7 @@ func TestHealthHook_SetHealth_unhealthy(t *testing.T) {
 
 	hs := newMockHealthSetter()
 
-	h := newAllocHealthWatcherHook(logger, alloc.Copy(), hs, b.Listen(), consul).(*allocHealthWatcherHook)
+	h := newAllocHealthWatcherHook(logger, alloc.Copy(), hs, b.Listen(), consul, nil).(*allocHealthWatcherHook)
 
 	// Prerun
 	require.NoError(h.Prerun())
@@ -388,7 +388,7 @@ func TestHealthHook_SetHealth_unhealthy(t *testing.T) {
 func TestHealthHook_SystemNoop(t *testing.T) {
 	t.Parallel()
 
-	h := newAllocHealthWatcherHook(testlog.HCLogger(t), mock.SystemAlloc(), nil, nil, nil)
+	h := newAllocHealthWatcherHook(testlog.HCLogger(t), mock.SystemAlloc(), nil, nil, nil, nil)
 
 	// Assert that it's the noop impl
 	_, ok := h.(noopAllocHealthWatcherHook)
@@ -409,7 +409,7 @@ func TestHealthHook_SystemNoop(t *testing.T) {
 func TestHealthHook_BatchNoop(t *testing.T) {
 	t.Parallel()
 
-	h := newAllocHealthWatcherHook(testlog.HCLogger(t), mock.BatchAlloc(), nil, nil, nil)
+	h := newAllocHealthWatcherHook(testlog.HCLogger(t), mock.BatchAlloc(), nil, nil, nil, nil)
 
 	// Assert that it's the noop impl
 	_, ok := h.(noopAllocHealthWatcherHook)
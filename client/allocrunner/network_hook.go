@@ -124,9 +124,16 @@ func (h *networkHook) Prerun() error {
 		}
 	}
 
+	// Allocations of stateful groups get a stable hostname derived from
+	// their index unless the job sets one.
+	hostname := interpolatedNetworks[0].Hostname
+	if hostname == "" && tg.Stateful {
+		hostname = tg.StatefulHostname(h.alloc.Index())
+	}
+
 	// Our network create request.
 	networkCreateReq := drivers.NetworkCreateRequest{
-		Hostname: interpolatedNetworks[0].Hostname,
+		Hostname: hostname,
 	}
 
 	spec, created, err := h.manager.CreateNetwork(h.alloc.ID, &networkCreateReq)
@@ -31,6 +31,23 @@ type taskHookCoordinator struct {
 	prestartEphemeral map[string]struct{}
 	mainTasksRunning  map[string]struct{} // poststop: main tasks running -> finished
 	mainTasksPending  map[string]struct{} // poststart: main tasks pending -> running
+
+	// taskDependencies gates the main tasks that depend on other tasks, keyed
+	// by the name of the dependent task. Entries are removed once released.
+	taskDependencies map[string]*taskDependency
+
+	// failureTolerated is the set of tasks whose failure doesn't prevent any
+	// dependent task from starting, so it must not kill their siblings.
+	failureTolerated map[string]struct{}
+}
+
+// taskDependency is the set of tasks a main task waits on before starting.
+type taskDependency struct {
+	upstream  []string
+	condition string
+
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 func newTaskHookCoordinator(logger hclog.Logger, tasks []*structs.Task) *taskHookCoordinator {
@@ -50,6 +67,8 @@ func newTaskHookCoordinator(logger hclog.Logger, tasks []*structs.Task) *taskHoo
 		prestartEphemeral:      map[string]struct{}{},
 		mainTasksRunning:       map[string]struct{}{},
 		mainTasksPending:       map[string]struct{}{},
+		taskDependencies:       map[string]*taskDependency{},
+		failureTolerated:       map[string]struct{}{},
 		poststartTaskCtx:       poststartTaskCtx,
 		poststartTaskCtxCancel: poststartCancelFn,
 		poststopTaskCtx:        poststopTaskCtx,
@@ -60,11 +79,30 @@ func newTaskHookCoordinator(logger hclog.Logger, tasks []*structs.Task) *taskHoo
 }
 
 func (c *taskHookCoordinator) setTasks(tasks []*structs.Task) {
+	// requireSuccess tracks whether any dependent task requires each upstream
+	// task to succeed
+	requireSuccess := map[string]bool{}
+
 	for _, task := range tasks {
 
 		if task.Lifecycle == nil {
 			c.mainTasksPending[task.Name] = struct{}{}
 			c.mainTasksRunning[task.Name] = struct{}{}
+
+			if len(task.DependsOn) > 0 {
+				ctx, cancel := context.WithCancel(context.Background())
+				c.taskDependencies[task.Name] = &taskDependency{
+					upstream:  task.DependsOn,
+					condition: task.DependsOnCondition,
+					ctx:       ctx,
+					cancel:    cancel,
+				}
+
+				for _, upstream := range task.DependsOn {
+					success := task.DependsOnCondition != structs.TaskDependencyConditionComplete
+					requireSuccess[upstream] = requireSuccess[upstream] || success
+				}
+			}
 			continue
 		}
 
@@ -84,6 +122,12 @@ func (c *taskHookCoordinator) setTasks(tasks []*structs.Task) {
 		}
 	}
 
+	for upstream, success := range requireSuccess {
+		if !success {
+			c.failureTolerated[upstream] = struct{}{}
+		}
+	}
+
 	if !c.hasPrestartTasks() {
 		c.mainTaskCtxCancel()
 	}
@@ -103,6 +147,11 @@ func (c *taskHookCoordinator) hasPendingMainTasks() bool {
 
 func (c *taskHookCoordinator) startConditionForTask(task *structs.Task) <-chan struct{} {
 	if task.Lifecycle == nil {
+		// Dependencies are main tasks themselves, so they can only finish
+		// after the main task context is done
+		if dep, ok := c.taskDependencies[task.Name]; ok {
+			return dep.ctx.Done()
+		}
 		return c.mainTaskCtx.Done()
 	}
 
@@ -160,6 +209,15 @@ func (c *taskHookCoordinator) taskStateUpdated(states map[string]*structs.TaskSt
 		delete(c.mainTasksPending, task)
 	}
 
+	for task, dep := range c.taskDependencies {
+		if !dep.satisfied(states) {
+			continue
+		}
+
+		dep.cancel()
+		delete(c.taskDependencies, task)
+	}
+
 	if !c.hasPrestartTasks() {
 		c.mainTaskCtxCancel()
 	}
@@ -176,6 +234,30 @@ func (c *taskHookCoordinator) StartPoststopTasks() {
 	c.poststopTaskCtxCancel()
 }
 
+// failureToleratedForTask returns true if the failure of the task should not
+// kill its siblings because the tasks depending on it start regardless of
+// its outcome.
+func (c *taskHookCoordinator) failureToleratedForTask(task string) bool {
+	_, ok := c.failureTolerated[task]
+	return ok
+}
+
+// satisfied returns true if all the upstream tasks have finished in a state
+// meeting the dependency condition.
+func (d *taskDependency) satisfied(states map[string]*structs.TaskState) bool {
+	for _, task := range d.upstream {
+		st := states[task]
+		if st == nil || st.State != structs.TaskStateDead {
+			return false
+		}
+		if d.condition != structs.TaskDependencyConditionComplete && st.Failed {
+			return false
+		}
+	}
+
+	return true
+}
+
 // hasNonSidecarTasks returns false if all the passed tasks are sidecar tasks
 func hasNonSidecarTasks(tasks []*taskrunner.TaskRunner) bool {
 	for _, tr := range tasks {
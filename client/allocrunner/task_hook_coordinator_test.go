@@ -270,6 +270,105 @@ func TestTaskHookCoordinator_PoststartStartsAfterMain(t *testing.T) {
 	require.Truef(t, isChannelClosed(mainCh), "%s channel was open, should be closed", mainTask.Name)
 }
 
+func TestTaskHookCoordinator_DependentStartsAfterDependencies(t *testing.T) {
+	logger := testlog.HCLogger(t)
+
+	alloc := mock.BatchAlloc()
+	tasks := alloc.Job.TaskGroups[0].Tasks
+
+	extract := tasks[0]
+	extract.Name = "extract"
+	transform := extract.Copy()
+	transform.Name = "transform"
+	transform.DependsOn = []string{"extract"}
+	transform.DependsOnCondition = structs.TaskDependencyConditionSuccess
+	report := extract.Copy()
+	report.Name = "report"
+	report.DependsOn = []string{"extract", "transform"}
+	report.DependsOnCondition = structs.TaskDependencyConditionComplete
+	tasks = []*structs.Task{extract, transform, report}
+
+	coord := newTaskHookCoordinator(logger, tasks)
+	extractCh := coord.startConditionForTask(extract)
+	transformCh := coord.startConditionForTask(transform)
+	reportCh := coord.startConditionForTask(report)
+
+	require.Truef(t, isChannelClosed(extractCh), "%s channel was open, should be closed", extract.Name)
+	require.Falsef(t, isChannelClosed(transformCh), "%s channel was closed, should be open", transform.Name)
+	require.Falsef(t, isChannelClosed(reportCh), "%s channel was closed, should be open", report.Name)
+
+	// Only the task whose dependents all use the complete condition may fail
+	// without killing its siblings
+	require.False(t, coord.failureToleratedForTask(extract.Name))
+	require.True(t, coord.failureToleratedForTask(transform.Name))
+
+	states := map[string]*structs.TaskState{
+		extract.Name: {
+			State:     structs.TaskStateRunning,
+			StartedAt: time.Now(),
+		},
+		transform.Name: {
+			State: structs.TaskStatePending,
+		},
+		report.Name: {
+			State: structs.TaskStatePending,
+		},
+	}
+
+	coord.taskStateUpdated(states)
+	require.Falsef(t, isChannelClosed(transformCh), "%s channel was closed, should be open", transform.Name)
+
+	states[extract.Name].State = structs.TaskStateDead
+	states[extract.Name].FinishedAt = time.Now()
+	coord.taskStateUpdated(states)
+	require.Truef(t, isChannelClosed(transformCh), "%s channel was open, should be closed", transform.Name)
+	require.Falsef(t, isChannelClosed(reportCh), "%s channel was closed, should be open", report.Name)
+
+	// A failed dependency still satisfies the complete condition
+	states[transform.Name] = &structs.TaskState{
+		State:      structs.TaskStateDead,
+		Failed:     true,
+		StartedAt:  time.Now(),
+		FinishedAt: time.Now(),
+	}
+	coord.taskStateUpdated(states)
+	require.Truef(t, isChannelClosed(reportCh), "%s channel was open, should be closed", report.Name)
+}
+
+func TestTaskHookCoordinator_FailedDependency(t *testing.T) {
+	logger := testlog.HCLogger(t)
+
+	alloc := mock.BatchAlloc()
+	tasks := alloc.Job.TaskGroups[0].Tasks
+
+	extract := tasks[0]
+	extract.Name = "extract"
+	transform := extract.Copy()
+	transform.Name = "transform"
+	transform.DependsOn = []string{"extract"}
+	transform.DependsOnCondition = structs.TaskDependencyConditionSuccess
+	tasks = []*structs.Task{extract, transform}
+
+	coord := newTaskHookCoordinator(logger, tasks)
+	transformCh := coord.startConditionForTask(transform)
+
+	states := map[string]*structs.TaskState{
+		extract.Name: {
+			State:      structs.TaskStateDead,
+			Failed:     true,
+			StartedAt:  time.Now(),
+			FinishedAt: time.Now(),
+		},
+		transform.Name: {
+			State: structs.TaskStatePending,
+		},
+	}
+
+	coord.taskStateUpdated(states)
+	require.Falsef(t, isChannelClosed(transformCh), "%s channel was closed, should be open", transform.Name)
+	require.False(t, coord.failureToleratedForTask(extract.Name))
+}
+
 func isChannelClosed(ch <-chan struct{}) bool {
 	select {
 	case <-ch:
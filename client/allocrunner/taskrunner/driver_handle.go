@@ -3,6 +3,7 @@ package taskrunner
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	cstructs "github.com/hashicorp/nomad/client/structs"
@@ -11,13 +12,14 @@ import (
 )
 
 // NewDriverHandle returns a handle for task operations on a specific task
-func NewDriverHandle(driver drivers.DriverPlugin, taskID string, task *structs.Task, net *drivers.DriverNetwork) *DriverHandle {
+func NewDriverHandle(driver drivers.DriverPlugin, taskID string, task *structs.Task, resources *structs.AllocatedTaskResources, net *drivers.DriverNetwork) *DriverHandle {
 	return &DriverHandle{
 		driver:      driver,
 		net:         net,
 		taskID:      taskID,
 		killSignal:  task.KillSignal,
 		killTimeout: task.KillTimeout,
+		resources:   resources,
 	}
 }
 
@@ -29,6 +31,11 @@ type DriverHandle struct {
 	taskID      string
 	killSignal  string
 	killTimeout time.Duration
+
+	// resources are the resources the task was started with or last
+	// updated to.
+	resources     *structs.AllocatedTaskResources
+	resourcesLock sync.Mutex
 }
 
 func (h *DriverHandle) ID() string {
@@ -106,6 +113,46 @@ func (h *DriverHandle) ExecStreaming(ctx context.Context,
 	return stream.Send(drivers.NewExecStreamingResponseExit(result.ExitCode))
 }
 
+// Checkpoint saves the state of the task to dir and stops it.
+func (h *DriverHandle) Checkpoint(dir string) error {
+	d, ok := h.driver.(drivers.DriverCheckpointer)
+	if !ok {
+		return fmt.Errorf("task driver does not support checkpointing")
+	}
+	return d.CheckpointTask(h.taskID, dir)
+}
+
+// ResourcesChanged returns whether the CPU or memory of the resources differ
+// from the ones applied to the task.
+func (h *DriverHandle) ResourcesChanged(resources *structs.AllocatedTaskResources) bool {
+	h.resourcesLock.Lock()
+	defer h.resourcesLock.Unlock()
+
+	if h.resources == nil || resources == nil {
+		return false
+	}
+	return h.resources.Cpu.CpuShares != resources.Cpu.CpuShares ||
+		h.resources.Memory.MemoryMB != resources.Memory.MemoryMB ||
+		h.resources.Memory.MemoryMaxMB != resources.Memory.MemoryMaxMB
+}
+
+// UpdateResources applies the CPU and memory of the resources to the running
+// task.
+func (h *DriverHandle) UpdateResources(resources *drivers.Resources) error {
+	d, ok := h.driver.(drivers.DriverResourceUpdater)
+	if !ok {
+		return fmt.Errorf("task driver does not support updating resources")
+	}
+	if err := d.UpdateTaskResources(h.taskID, resources); err != nil {
+		return err
+	}
+
+	h.resourcesLock.Lock()
+	h.resources = resources.NomadResources
+	h.resourcesLock.Unlock()
+	return nil
+}
+
 func (h *DriverHandle) Network() *drivers.DriverNetwork {
 	return h.net
 }
@@ -6,12 +6,16 @@ import (
 	"fmt"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"sync"
 	"time"
 
+	metrics "github.com/armon/go-metrics"
 	hclog "github.com/hashicorp/go-hclog"
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	"github.com/hashicorp/nomad/client/logmon"
+	"github.com/hashicorp/nomad/client/logmon/logging"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 	bstructs "github.com/hashicorp/nomad/plugins/base/structs"
@@ -34,6 +38,10 @@ type logmonHook struct {
 	// logmon is the handle to the log monitor process for the task.
 	logmon             logmon.LogMon
 	logmonPluginClient *plugin.Client
+	logmonLock         sync.Mutex
+
+	// sinkStatsCancel stops emitting the metrics of the task's log sinks
+	sinkStatsCancel context.CancelFunc
 
 	config *logmonHookConfig
 
@@ -81,7 +89,9 @@ func (h *logmonHook) launchLogMon(reattachConfig *plugin.ReattachConfig) error {
 		return err
 	}
 
+	h.logmonLock.Lock()
 	h.logmon = l
+	h.logmonLock.Unlock()
 	h.logmonPluginClient = c
 	return nil
 }
@@ -176,24 +186,110 @@ func (h *logmonHook) prestartOneLoop(ctx context.Context, req *interfaces.TaskPr
 		}
 	}
 
-	err := h.logmon.Start(&logmon.LogConfig{
-		LogDir:        h.config.logDir,
-		StdoutLogFile: fmt.Sprintf("%s.stdout", req.Task.Name),
-		StderrLogFile: fmt.Sprintf("%s.stderr", req.Task.Name),
-		StdoutFifo:    h.config.stdoutFifo,
-		StderrFifo:    h.config.stderrFifo,
-		MaxFiles:      req.Task.LogConfig.MaxFiles,
-		MaxFileSizeMB: req.Task.LogConfig.MaxFileSizeMB,
-	})
+	alloc := h.runner.Alloc()
+	cfg := &logmon.LogConfig{
+		LogDir:         h.config.logDir,
+		StdoutLogFile:  fmt.Sprintf("%s.stdout", req.Task.Name),
+		StderrLogFile:  fmt.Sprintf("%s.stderr", req.Task.Name),
+		StdoutFifo:     h.config.stdoutFifo,
+		StderrFifo:     h.config.stderrFifo,
+		MaxFiles:       req.Task.LogConfig.MaxFiles,
+		MaxFileSizeMB:  req.Task.LogConfig.MaxFileSizeMB,
+		Format:         req.Task.LogConfig.Format,
+		Level:          req.Task.LogConfig.Level,
+		RotateEvery:    req.Task.LogConfig.RotateEvery,
+		Compression:    req.Task.LogConfig.Compression,
+		MaxTotalSizeMB: req.Task.LogConfig.MaxTotalSizeMB,
+		Task: &logging.TaskInfo{
+			AllocID:   alloc.ID,
+			Namespace: alloc.Namespace,
+			JobID:     alloc.JobID,
+			GroupName: alloc.TaskGroup,
+			TaskName:  req.Task.Name,
+		},
+	}
+	for _, sink := range req.Task.LogConfig.Sinks {
+		cfg.Sinks = append(cfg.Sinks, &logging.SinkConfig{
+			Type:         sink.Type,
+			Address:      sink.Address,
+			Facility:     sink.Facility,
+			Tag:          sink.Tag,
+			Header:       sink.Header,
+			BatchSize:    sink.BatchSize,
+			BatchWait:    sink.BatchWait,
+			BufferSize:   sink.BufferSize,
+			Backpressure: sink.Backpressure,
+		})
+	}
+
+	err := h.logmon.Start(cfg)
 	if err != nil {
 		h.logger.Error("failed to start logmon", "error", err)
 		return err
 	}
 
+	if len(cfg.Sinks) > 0 && h.runner.clientConfig.PublishAllocationMetrics && h.sinkStatsCancel == nil {
+		ctx, cancel := context.WithCancel(h.runner.shutdownCtx)
+		h.sinkStatsCancel = cancel
+		go h.emitSinkStats(ctx, h.runner.clientConfig.StatsCollectionInterval)
+	}
+
 	return nil
 }
 
+// emitSinkStats periodically emits the counters of the task's log sinks as
+// metrics.
+func (h *logmonHook) emitSinkStats(ctx context.Context, interval time.Duration) {
+	// last holds the counters of the previous collection, since logmon
+	// reports totals
+	var last []*logging.SinkStats
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		h.logmonLock.Lock()
+		l := h.logmon
+		h.logmonLock.Unlock()
+
+		stats, err := l.Stats()
+		if err != nil {
+			h.logger.Trace("failed to collect log sink stats", "error", err)
+			continue
+		}
+
+		for i, s := range stats {
+			labels := append([]metrics.Label{
+				{Name: "sink_type", Value: s.Type},
+				{Name: "sink_index", Value: strconv.Itoa(i)},
+			}, h.runner.baseLabels...)
+
+			prev := &logging.SinkStats{}
+			if i < len(last) && last[i].Sent <= s.Sent && last[i].Dropped <= s.Dropped && last[i].Failed <= s.Failed {
+				prev = last[i]
+			}
+			metrics.IncrCounterWithLabels([]string{"client", "allocs", "logs", "sink", "sent"},
+				float32(s.Sent-prev.Sent), labels)
+			metrics.IncrCounterWithLabels([]string{"client", "allocs", "logs", "sink", "dropped"},
+				float32(s.Dropped-prev.Dropped), labels)
+			metrics.IncrCounterWithLabels([]string{"client", "allocs", "logs", "sink", "failed"},
+				float32(s.Failed-prev.Failed), labels)
+			metrics.SetGaugeWithLabels([]string{"client", "allocs", "logs", "sink", "buffered"},
+				float32(s.Buffered), labels)
+		}
+		last = stats
+	}
+}
+
 func (h *logmonHook) Stop(_ context.Context, req *interfaces.TaskStopRequest, _ *interfaces.TaskStopResponse) error {
+	if h.sinkStatsCancel != nil {
+		h.sinkStatsCancel()
+	}
 
 	// It's possible that Stop was called without calling Prestart on agent
 	// restarts. Attempt to reattach to an existing logmon.
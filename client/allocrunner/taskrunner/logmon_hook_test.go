@@ -72,7 +72,7 @@ func TestTaskRunner_LogmonHook_StartStop(t *testing.T) {
 	}()
 
 	hookConf := newLogMonHookConfig(task.Name, dir)
-	runner := &TaskRunner{logmonHookConfig: hookConf}
+	runner := &TaskRunner{logmonHookConfig: hookConf, alloc: alloc}
 	hook := newLogMonHook(runner, testlog.HCLogger(t))
 
 	req := interfaces.TaskPrestartRequest{
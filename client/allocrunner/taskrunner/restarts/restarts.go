@@ -233,7 +233,11 @@ func (r *RestartTracker) GetState() (string, time.Duration) {
 	}
 
 	r.reason = ReasonWithinPolicy
-	return structs.TaskRestarting, r.jitter()
+	if fn := r.policy.DelayFunction; fn != "" && fn != "constant" {
+		r.reason = fmt.Sprintf("%s, attempt %d of %d with %s backoff",
+			ReasonWithinPolicy, r.count, r.policy.Attempts, fn)
+	}
+	return structs.TaskRestarting, r.jitter(r.policy.NextDelay(r.count))
 }
 
 // getDelay returns the delay time to enter the next interval.
@@ -244,9 +248,9 @@ func (r *RestartTracker) getDelay() time.Duration {
 }
 
 // jitter returns the delay time plus a jitter.
-func (r *RestartTracker) jitter() time.Duration {
+func (r *RestartTracker) jitter(delay time.Duration) time.Duration {
 	// Get the delay and ensure it is valid.
-	d := r.policy.Delay.Nanoseconds()
+	d := delay.Nanoseconds()
 	if d == 0 {
 		d = 1
 	}
@@ -59,6 +59,30 @@ func TestClient_RestartTracker_ModeDelay(t *testing.T) {
 	}
 }
 
+func TestClient_RestartTracker_Backoff(t *testing.T) {
+	t.Parallel()
+	p := testPolicy(true, structs.RestartPolicyModeFail)
+	p.Attempts = 4
+	p.DelayFunction = "exponential"
+	p.MaxDelay = 5 * time.Second
+	rt := NewRestartTracker(p, structs.JobTypeService, nil)
+
+	expected := []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	for i, delay := range expected {
+		state, when := rt.SetExitResult(testExitResult(127)).GetState()
+		require.Equal(t, structs.TaskRestarting, state)
+		require.Truef(t, when >= delay && withinJitter(delay, when),
+			"attempt %d returned %v; want %v+jitter", i+1, when, delay)
+		require.Equal(t,
+			fmt.Sprintf("Restart within policy, attempt %d of 4 with exponential backoff", i+1),
+			rt.GetReason())
+	}
+
+	// Next restart should cause fail
+	state, _ := rt.SetExitResult(testExitResult(127)).GetState()
+	require.Equal(t, structs.TaskNotRestarting, state)
+}
+
 func TestClient_RestartTracker_ModeFail(t *testing.T) {
 	t.Parallel()
 	p := testPolicy(true, structs.RestartPolicyModeFail)
@@ -0,0 +1,190 @@
+package taskrunner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+var _ interfaces.TaskPoststartHook = &shutdownHook{}
+var _ interfaces.TaskUpdateHook = &shutdownHook{}
+var _ interfaces.TaskExitedHook = &shutdownHook{}
+var _ interfaces.TaskPreKillHook = &shutdownHook{}
+
+type shutdownHookConfig struct {
+	task   *structs.Task
+	events ti.EventEmitter
+	logger log.Logger
+}
+
+// shutdownHook implements a task runner hook that runs the task's shutdown
+// hook, either an HTTP request to one of the task's ports or a command
+// executed inside the task, before the task is killed.
+type shutdownHook struct {
+	events ti.EventEmitter
+	logger log.Logger
+
+	// httpClient is used for HTTP shutdown hooks; the request timeout is
+	// enforced by the context passed to each request
+	httpClient *http.Client
+
+	// The following fields can be changed by Update()
+	task       *structs.Task
+	taskEnv    *taskenv.TaskEnv
+	driverExec ti.ScriptExecutor
+
+	// running is true while the task is running and the shutdown hook may
+	// be invoked
+	running bool
+
+	mu sync.Mutex
+}
+
+func newShutdownHook(c shutdownHookConfig) *shutdownHook {
+	h := &shutdownHook{
+		events:     c.events,
+		task:       c.task,
+		httpClient: &http.Client{},
+	}
+	h.logger = c.logger.Named(h.Name())
+	return h
+}
+
+func (h *shutdownHook) Name() string {
+	return "shutdown_hook"
+}
+
+// Poststart implements interfaces.TaskPoststartHook. It stores the driver
+// and environment the shutdown hook is run with.
+func (h *shutdownHook) Poststart(ctx context.Context, req *interfaces.TaskPoststartRequest, _ *interfaces.TaskPoststartResponse) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.driverExec = req.DriverExec
+	h.taskEnv = req.TaskEnv
+	h.running = true
+	return nil
+}
+
+// Update implements interfaces.TaskUpdateHook. It picks up changes to the
+// task's shutdown hook and environment.
+func (h *shutdownHook) Update(ctx context.Context, req *interfaces.TaskUpdateRequest, _ *interfaces.TaskUpdateResponse) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	task := req.Alloc.LookupTask(h.task.Name)
+	if task == nil {
+		return fmt.Errorf("task %q not found in updated alloc", h.task.Name)
+	}
+	h.task = task
+	h.taskEnv = req.TaskEnv
+	return nil
+}
+
+// Exited implements interfaces.TaskExitedHook. There is nothing left to
+// drain once the task has exited.
+func (h *shutdownHook) Exited(context.Context, *interfaces.TaskExitedRequest, *interfaces.TaskExitedResponse) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.running = false
+	return nil
+}
+
+// PreKilling implements interfaces.TaskPreKillHook. It runs the shutdown
+// hook and waits for it to complete or time out before the task is killed.
+func (h *shutdownHook) PreKilling(ctx context.Context, _ *interfaces.TaskPreKillRequest, _ *interfaces.TaskPreKillResponse) error {
+	h.mu.Lock()
+	hook := h.task.ShutdownHook
+	running := h.running
+	env := h.taskEnv
+	driverExec := h.driverExec
+	h.mu.Unlock()
+
+	if hook == nil || !running {
+		return nil
+	}
+
+	h.events.EmitEvent(structs.NewTaskEvent(structs.TaskRunningShutdownHook).
+		SetMessage(fmt.Sprintf("Running %s shutdown hook", hook.Type)))
+
+	ctx, cancel := context.WithTimeout(ctx, hook.Timeout)
+	defer cancel()
+
+	var err error
+	switch hook.Type {
+	case structs.TaskShutdownHookTypeHTTP:
+		err = h.runHTTP(ctx, hook, env)
+	case structs.TaskShutdownHookTypeExec:
+		err = h.runExec(hook, env, driverExec)
+	default:
+		err = fmt.Errorf("unsupported shutdown hook type %q", hook.Type)
+	}
+	if err != nil {
+		h.logger.Warn("shutdown hook failed", "error", err)
+		return err
+	}
+
+	h.events.EmitEvent(structs.NewTaskEvent(structs.TaskRunningShutdownHook).
+		SetMessage("Shutdown hook completed"))
+	return nil
+}
+
+// runHTTP sends the shutdown hook's request to the address of its port
+func (h *shutdownHook) runHTTP(ctx context.Context, hook *structs.TaskShutdownHook, env *taskenv.TaskEnv) error {
+	if env == nil {
+		return fmt.Errorf("task environment not available")
+	}
+
+	addr, ok := env.EnvMap[helper.CleanEnvVar(taskenv.AddrPrefix+hook.PortLabel, '_')]
+	if !ok {
+		return fmt.Errorf("port %q not found", hook.PortLabel)
+	}
+
+	url := fmt.Sprintf("%s://%s%s", hook.Protocol, addr, env.ReplaceEnv(hook.Path))
+	req, err := http.NewRequestWithContext(ctx, hook.Method, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s %s returned status %d", hook.Method, url, resp.StatusCode)
+	}
+	return nil
+}
+
+// runExec executes the shutdown hook's command inside the task
+func (h *shutdownHook) runExec(hook *structs.TaskShutdownHook, env *taskenv.TaskEnv, driverExec ti.ScriptExecutor) error {
+	if driverExec == nil {
+		return fmt.Errorf("driver does not support exec")
+	}
+
+	cmd, args := hook.Command, hook.Args
+	if env != nil {
+		cmd = env.ReplaceEnv(cmd)
+		args = env.ParseAndReplace(args)
+	}
+
+	_, code, err := driverExec.Exec(hook.Timeout, cmd, args)
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		return fmt.Errorf("command exited with code %d", code)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package taskrunner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestShutdownHook(t *testing.T, sh *structs.TaskShutdownHook) (*shutdownHook, *mockEmitter) {
+	me := &mockEmitter{}
+	h := newShutdownHook(shutdownHookConfig{
+		task:   &structs.Task{Name: "web", ShutdownHook: sh},
+		events: me,
+		logger: testlog.HCLogger(t),
+	})
+	return h, me
+}
+
+func TestTaskRunner_ShutdownHook_HTTP(t *testing.T) {
+	t.Parallel()
+
+	var method, path string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+	}))
+	defer ts.Close()
+
+	h, me := newTestShutdownHook(t, &structs.TaskShutdownHook{
+		Type:      structs.TaskShutdownHookTypeHTTP,
+		PortLabel: "http-api",
+		Path:      "/drain/${NOMAD_TASK_NAME}",
+		Method:    "POST",
+		Protocol:  "http",
+		Timeout:   5 * time.Second,
+	})
+
+	env := taskenv.NewTaskEnv(map[string]string{
+		"NOMAD_ADDR_http_api": strings.TrimPrefix(ts.URL, "http://"),
+		"NOMAD_TASK_NAME":     "web",
+	}, nil, nil, nil, "", "")
+	require.NoError(t, h.Poststart(context.Background(), &interfaces.TaskPoststartRequest{TaskEnv: env}, nil))
+
+	require.NoError(t, h.PreKilling(context.Background(), nil, nil))
+	require.Equal(t, "POST", method)
+	require.Equal(t, "/drain/web", path)
+	require.Len(t, me.events, 2)
+	require.Equal(t, "Running http shutdown hook", me.events[0].Message)
+	require.Equal(t, "Shutdown hook completed", me.events[1].Message)
+}
+
+func TestTaskRunner_ShutdownHook_HTTP_Failure(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	h, me := newTestShutdownHook(t, &structs.TaskShutdownHook{
+		Type:      structs.TaskShutdownHookTypeHTTP,
+		PortLabel: "http",
+		Path:      "/drain",
+		Method:    "POST",
+		Protocol:  "http",
+		Timeout:   5 * time.Second,
+	})
+
+	env := taskenv.NewTaskEnv(map[string]string{
+		"NOMAD_ADDR_http": strings.TrimPrefix(ts.URL, "http://"),
+	}, nil, nil, nil, "", "")
+	require.NoError(t, h.Poststart(context.Background(), &interfaces.TaskPoststartRequest{TaskEnv: env}, nil))
+
+	err := h.PreKilling(context.Background(), nil, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "returned status 503")
+	require.Len(t, me.events, 1)
+}
+
+func TestTaskRunner_ShutdownHook_HTTP_Timeout(t *testing.T) {
+	t.Parallel()
+
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer ts.Close()
+	defer close(done)
+
+	h, _ := newTestShutdownHook(t, &structs.TaskShutdownHook{
+		Type:      structs.TaskShutdownHookTypeHTTP,
+		PortLabel: "http",
+		Path:      "/drain",
+		Method:    "POST",
+		Protocol:  "http",
+		Timeout:   100 * time.Millisecond,
+	})
+
+	env := taskenv.NewTaskEnv(map[string]string{
+		"NOMAD_ADDR_http": strings.TrimPrefix(ts.URL, "http://"),
+	}, nil, nil, nil, "", "")
+	require.NoError(t, h.Poststart(context.Background(), &interfaces.TaskPoststartRequest{TaskEnv: env}, nil))
+
+	start := time.Now()
+	require.Error(t, h.PreKilling(context.Background(), nil, nil))
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestTaskRunner_ShutdownHook_Exec(t *testing.T) {
+	t.Parallel()
+
+	sh := &structs.TaskShutdownHook{
+		Type:    structs.TaskShutdownHookTypeExec,
+		Command: "/bin/drain",
+		Timeout: 5 * time.Second,
+	}
+
+	// Successful command
+	h, me := newTestShutdownHook(t, sh)
+	req := &interfaces.TaskPoststartRequest{
+		DriverExec: newSimpleExec(0, nil),
+		TaskEnv:    taskenv.NewEmptyTaskEnv(),
+	}
+	require.NoError(t, h.Poststart(context.Background(), req, nil))
+	require.NoError(t, h.PreKilling(context.Background(), nil, nil))
+	require.Len(t, me.events, 2)
+
+	// Non-zero exit code
+	h, me = newTestShutdownHook(t, sh)
+	req.DriverExec = newSimpleExec(2, nil)
+	require.NoError(t, h.Poststart(context.Background(), req, nil))
+	err := h.PreKilling(context.Background(), nil, nil)
+	require.EqualError(t, err, "command exited with code 2")
+	require.Len(t, me.events, 1)
+}
+
+func TestTaskRunner_ShutdownHook_NotRunning(t *testing.T) {
+	t.Parallel()
+
+	h, me := newTestShutdownHook(t, &structs.TaskShutdownHook{
+		Type:    structs.TaskShutdownHookTypeExec,
+		Command: "/bin/drain",
+		Timeout: 5 * time.Second,
+	})
+
+	// The hook is skipped before the task has started
+	require.NoError(t, h.PreKilling(context.Background(), nil, nil))
+
+	// and after it has exited
+	req := &interfaces.TaskPoststartRequest{
+		DriverExec: newSimpleExec(1, nil),
+		TaskEnv:    taskenv.NewEmptyTaskEnv(),
+	}
+	require.NoError(t, h.Poststart(context.Background(), req, nil))
+	require.NoError(t, h.Exited(context.Background(), nil, nil))
+	require.NoError(t, h.PreKilling(context.Background(), nil, nil))
+	require.Empty(t, me.events)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -63,12 +64,11 @@ const (
 )
 
 type TaskRunner struct {
-	// allocID, taskName, taskLeader, and taskResources are immutable so these fields may
+	// allocID, taskName, and taskLeader are immutable so these fields may
 	// be accessed without locks
-	allocID       string
-	taskName      string
-	taskLeader    bool
-	taskResources *structs.AllocatedTaskResources
+	allocID    string
+	taskName   string
+	taskLeader bool
 
 	alloc     *structs.Allocation
 	allocLock sync.Mutex
@@ -148,6 +148,10 @@ type TaskRunner struct {
 	task     *structs.Task
 	taskLock sync.RWMutex
 
+	// taskResources are the resources allocated to the task, updated with
+	// the allocation. Guarded by taskLock.
+	taskResources *structs.AllocatedTaskResources
+
 	// taskDir is the directory structure for this task.
 	taskDir *allocdir.TaskDir
 
@@ -733,7 +737,8 @@ func (tr *TaskRunner) handleUpdates() {
 			return
 		}
 
-		// Non-terminal update; run hooks
+		// Non-terminal update; apply resources and run hooks
+		tr.updateResources()
 		tr.updateHooks()
 	}
 }
@@ -826,8 +831,12 @@ func (tr *TaskRunner) runDriver() error {
 		return nil
 	}
 
-	// Start the job if there's no existing handle (or if RecoverTask failed)
-	handle, net, err := tr.driver.StartTask(taskConfig)
+	// Start the job if there's no existing handle (or if RecoverTask failed),
+	// unless it can be restored from a checkpoint of the previous alloc
+	handle, net := tr.restoreCheckpoint(taskConfig)
+	if handle == nil {
+		handle, net, err = tr.driver.StartTask(taskConfig)
+	}
 	if err != nil {
 		// The plugin has died, try relaunching it
 		if err == bstructs.ErrPluginShutdown {
@@ -864,13 +873,47 @@ func (tr *TaskRunner) runDriver() error {
 	}
 	tr.stateLock.Unlock()
 
-	tr.setDriverHandle(NewDriverHandle(tr.driver, taskConfig.ID, tr.Task(), net))
+	tr.setDriverHandle(NewDriverHandle(tr.driver, taskConfig.ID, tr.Task(), taskConfig.Resources.NomadResources, net))
 
 	// Emit an event that we started
 	tr.UpdateState(structs.TaskStateRunning, structs.NewTaskEvent(structs.TaskStarted))
 	return nil
 }
 
+// restoreCheckpoint restores the task from the checkpoint migrated from the
+// previous allocation, if any. It returns a nil handle if the task must be
+// started instead. The checkpoint is removed so that it is restored at most
+// once.
+func (tr *TaskRunner) restoreCheckpoint(taskConfig *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork) {
+	dir := tr.taskDir.CheckpointDir
+	if _, err := os.Stat(dir); err != nil {
+		return nil, nil
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			tr.logger.Warn("failed to remove checkpoint", "error", err, "checkpoint_dir", dir)
+		}
+	}()
+
+	cp, ok := tr.driver.(drivers.DriverCheckpointer)
+	if !ok || !tr.driverCapabilities.Checkpoint {
+		return nil, nil
+	}
+
+	handle, net, err := cp.RestoreTask(taskConfig, dir)
+	if err != nil {
+		tr.logger.Warn("failed to restore task from checkpoint; starting it", "error", err)
+		tr.EmitEvent(structs.NewTaskEvent(structs.TaskCheckpoint).
+			SetMessage(fmt.Sprintf("Failed to restore task from checkpoint, starting it: %v", err)))
+		return nil, nil
+	}
+
+	tr.logger.Info("restored task from checkpoint")
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskCheckpoint).
+		SetMessage("Task restored from checkpoint"))
+	return handle, net
+}
+
 // initDriver retrives the DriverPlugin from the plugin loader for this task
 func (tr *TaskRunner) initDriver() error {
 	driver, err := tr.driverManager.Dispense(tr.Task().Driver)
@@ -936,6 +979,9 @@ func (tr *TaskRunner) handleKill(resultCh <-chan *drivers.ExitResult) *drivers.E
 		return nil
 	}
 
+	// Checkpoint the task before killing it if its alloc is migrated
+	tr.checkpointTask(handle)
+
 	// Kill the task using an exponential backoff in-case of failures.
 	result, killErr := tr.killTask(handle, resultCh)
 	if killErr != nil {
@@ -973,6 +1019,32 @@ func (tr *TaskRunner) handleKill(resultCh <-chan *drivers.ExitResult) *drivers.E
 	}
 }
 
+// checkpointTask saves a checkpoint of the task if its alloc is being migrated
+// and its driver supports checkpointing, so that the replacement alloc can
+// restore it. Checkpointing stops the task; if it fails the task is killed as
+// usual. Tasks not configured to be checkpointed are skipped.
+func (tr *TaskRunner) checkpointTask(handle *DriverHandle) {
+	if !tr.driverCapabilities.Checkpoint || !tr.Alloc().DesiredTransition.ShouldMigrate() {
+		return
+	}
+
+	dir := tr.taskDir.CheckpointDir
+	if err := handle.Checkpoint(dir); err != nil {
+		if err == drivers.ErrCheckpointNotEnabled {
+			return
+		}
+		tr.logger.Warn("failed to checkpoint task", "error", err)
+		if err := os.RemoveAll(dir); err != nil {
+			tr.logger.Warn("failed to remove incomplete checkpoint", "error", err, "checkpoint_dir", dir)
+		}
+		return
+	}
+
+	tr.logger.Info("checkpointed task", "checkpoint_dir", dir)
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskCheckpoint).
+		SetMessage("Task checkpointed"))
+}
+
 // killTask kills the task handle. In the case that killing fails,
 // killTask will retry with an exponential backoff and will give up at a
 // given limit. Returns an error if the task could not be killed.
@@ -1020,8 +1092,6 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 	task := tr.Task()
 	alloc := tr.Alloc()
 	invocationid := uuid.Generate()[:8]
-	taskResources := tr.taskResources
-	ports := tr.Alloc().AllocatedResources.Shared.Ports
 	env := tr.envBuilder.Build()
 	tr.networkIsolationLock.Lock()
 	defer tr.networkIsolationLock.Unlock()
@@ -1038,16 +1108,6 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 		}
 	}
 
-	memoryLimit := taskResources.Memory.MemoryMB
-	if max := taskResources.Memory.MemoryMaxMB; max > memoryLimit {
-		memoryLimit = max
-	}
-
-	cpusetCpus := make([]string, len(taskResources.Cpu.ReservedCores))
-	for i, v := range taskResources.Cpu.ReservedCores {
-		cpusetCpus[i] = fmt.Sprintf("%d", v)
-	}
-
 	return &drivers.TaskConfig{
 		ID:            fmt.Sprintf("%s/%s/%s", alloc.ID, task.Name, invocationid),
 		Name:          task.Name,
@@ -1057,16 +1117,7 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 		Namespace:     alloc.Namespace,
 		NodeName:      alloc.NodeName,
 		NodeID:        alloc.NodeID,
-		Resources: &drivers.Resources{
-			NomadResources: taskResources,
-			LinuxResources: &drivers.LinuxResources{
-				MemoryLimitBytes: memoryLimit * 1024 * 1024,
-				CPUShares:        taskResources.Cpu.CpuShares,
-				CpusetCpus:       strings.Join(cpusetCpus, ","),
-				PercentTicks:     float64(taskResources.Cpu.CpuShares) / float64(tr.clientConfig.Node.NodeResources.Cpu.CpuShares),
-			},
-			Ports: &ports,
-		},
+		Resources:        tr.buildDriverResources(tr.TaskResources()),
 		Devices:          tr.hookResources.getDevices(),
 		Mounts:           tr.hookResources.getMounts(),
 		Env:              env.Map(),
@@ -1081,6 +1132,89 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 	}
 }
 
+// buildDriverResources builds the drivers.Resources of the task from its
+// allocated resources.
+func (tr *TaskRunner) buildDriverResources(taskResources *structs.AllocatedTaskResources) *drivers.Resources {
+	alloc := tr.Alloc()
+	ports := alloc.AllocatedResources.Shared.Ports
+
+	memoryLimit := taskResources.Memory.MemoryMB
+	if max := taskResources.Memory.MemoryMaxMB; max > memoryLimit {
+		memoryLimit = max
+	}
+
+	cpusetCpus := make([]string, len(taskResources.Cpu.ReservedCores))
+	for i, v := range taskResources.Cpu.ReservedCores {
+		cpusetCpus[i] = fmt.Sprintf("%d", v)
+	}
+
+	return &drivers.Resources{
+		NomadResources: taskResources,
+		LinuxResources: &drivers.LinuxResources{
+			MemoryLimitBytes: memoryLimit * 1024 * 1024,
+			CPUShares:        taskResources.Cpu.CpuShares,
+			CpusetCpus:       strings.Join(cpusetCpus, ","),
+			CgroupParent:     cgutil.AllocCgroupParent(tr.clientConfig.CgroupParent, alloc),
+			PercentTicks:     float64(taskResources.Cpu.CpuShares) / float64(tr.clientConfig.Node.NodeResources.Cpu.CpuShares),
+		},
+		Ports: &ports,
+	}
+}
+
+// updateResources applies CPU and memory changes of the allocation to the
+// running task. The scheduler updates allocations in place when only these
+// resources change, so the task is restarted if its driver can't apply them
+// to the running task.
+func (tr *TaskRunner) updateResources() {
+	handle := tr.getDriverHandle()
+	if handle == nil {
+		// Not running; the resources are applied when the task starts
+		return
+	}
+
+	resources := tr.TaskResources()
+	if !handle.ResourcesChanged(resources) {
+		return
+	}
+
+	if tr.driverCapabilities != nil && tr.driverCapabilities.UpdateResources {
+		driverResources := tr.buildDriverResources(resources)
+		err := handle.UpdateResources(driverResources)
+		if err == nil {
+			tr.persistTaskHandleResources(driverResources)
+			tr.EmitEvent(structs.NewTaskEvent(structs.TaskResourcesUpdated).
+				SetMessage(fmt.Sprintf("Task resources updated to %d MHz CPU and %d MB memory",
+					resources.Cpu.CpuShares, resources.Memory.MemoryMB)))
+			return
+		}
+		tr.logger.Warn("failed to update task resources; restarting task", "error", err)
+	}
+
+	event := structs.NewTaskEvent(structs.TaskRestartSignal).
+		SetRestartReason("Restarting task to apply updated resources")
+	if err := tr.Restart(tr.killCtx, event, false); err != nil && err != ErrTaskNotRunning {
+		tr.logger.Error("failed to restart task to apply updated resources", "error", err)
+	}
+}
+
+// persistTaskHandleResources records the resources applied to the running
+// task in the persisted task handle, so they are the baseline for detecting
+// resource changes when the handle is restored.
+func (tr *TaskRunner) persistTaskHandleResources(resources *drivers.Resources) {
+	tr.stateLock.Lock()
+	if h := tr.localState.TaskHandle; h != nil && h.Config != nil {
+		h = h.Copy()
+		h.Config.Resources = resources
+		tr.localState.TaskHandle = h
+	}
+	tr.stateLock.Unlock()
+
+	if err := tr.persistLocalState(); err != nil {
+		tr.logger.Warn("error persisting local task state; updated resources may be reapplied after a Nomad restart",
+			"error", err)
+	}
+}
+
 // Restore task runner state. Called by AllocRunner.Restore after NewTaskRunner
 // but before Run so no locks need to be acquired.
 func (tr *TaskRunner) Restore() error {
@@ -1161,8 +1295,20 @@ func (tr *TaskRunner) restoreHandle(taskHandle *drivers.TaskHandle, net *drivers
 		return false
 	}
 
-	// Update driver handle on task runner
-	tr.setDriverHandle(NewDriverHandle(tr.driver, taskHandle.Config.ID, tr.Task(), net))
+	// Update driver handle on task runner. The resources applied to the
+	// task are the ones persisted with its handle, which may differ from
+	// the allocation if it was updated while the client was down.
+	applied := tr.TaskResources()
+	if taskHandle.Config.Resources != nil && taskHandle.Config.Resources.NomadResources != nil {
+		applied = taskHandle.Config.Resources.NomadResources
+	}
+	handle := NewDriverHandle(tr.driver, taskHandle.Config.ID, tr.Task(), applied, net)
+	tr.setDriverHandle(handle)
+
+	// Apply any resource change through the update loop
+	if handle.ResourcesChanged(tr.TaskResources()) {
+		tr.triggerUpdateHooks()
+	}
 	return true
 }
 
@@ -1372,7 +1518,7 @@ func (tr *TaskRunner) LatestResourceUsage() *cstructs.TaskResourceUsage {
 
 	// Look up device statistics lazily when fetched, as currently we do not emit any stats for them yet
 	if ru != nil && tr.deviceStatsReporter != nil {
-		deviceResources := tr.taskResources.Devices
+		deviceResources := tr.TaskResources().Devices
 		ru.ResourceUsage.DeviceStats = tr.deviceStatsReporter.LatestDeviceResourceStats(deviceResources)
 	}
 	return ru
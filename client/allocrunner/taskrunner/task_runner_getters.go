@@ -21,6 +21,13 @@ func (tr *TaskRunner) setAlloc(updated *structs.Allocation, task *structs.Task)
 
 	tr.alloc = updated
 	tr.task = task
+
+	// Resources may be updated in place by the scheduler
+	if updated.AllocatedResources != nil {
+		if tres, ok := updated.AllocatedResources.Tasks[tr.taskName]; ok {
+			tr.taskResources = tres
+		}
+	}
 }
 
 // IsLeader returns true if this task is the leader of its task group.
@@ -39,6 +46,13 @@ func (tr *TaskRunner) Task() *structs.Task {
 	return tr.task
 }
 
+// TaskResources returns the resources allocated to the task.
+func (tr *TaskRunner) TaskResources() *structs.AllocatedTaskResources {
+	tr.taskLock.RLock()
+	defer tr.taskLock.RUnlock()
+	return tr.taskResources
+}
+
 func (tr *TaskRunner) TaskState() *structs.TaskState {
 	tr.stateLock.Lock()
 	defer tr.stateLock.Unlock()
@@ -160,6 +160,14 @@ func (tr *TaskRunner) initHooks() {
 		logger: hookLogger,
 	}))
 
+	// Always add the shutdown hook so it may be added by an update. It runs
+	// after the service hook has deregistered the task's services.
+	tr.runnerHooks = append(tr.runnerHooks, newShutdownHook(shutdownHookConfig{
+		task:   tr.Task(),
+		events: tr,
+		logger: hookLogger,
+	}))
+
 	// If this task driver has remote capabilities, add the remote task
 	// hook.
 	if tr.driverCapabilities.RemoteTasks {
@@ -215,7 +223,7 @@ func (tr *TaskRunner) prestart() error {
 			Task:          tr.Task(),
 			TaskDir:       tr.taskDir,
 			TaskEnv:       tr.envBuilder.Build(),
-			TaskResources: tr.taskResources,
+			TaskResources: tr.TaskResources(),
 		}
 
 		origHookState := tr.hookState(name)
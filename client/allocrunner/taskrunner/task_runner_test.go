@@ -1198,6 +1198,117 @@ func TestTaskRunner_RestartTask(t *testing.T) {
 	require.True(t, found, "restarting task event not found", pretty.Sprint(events))
 }
 
+// TestTaskRunner_UpdateResources_Restart asserts that tasks are restarted to
+// apply updated resources when the driver can't update them in place.
+func TestTaskRunner_UpdateResources_Restart(t *testing.T) {
+	t.Parallel()
+
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "10m",
+	}
+
+	tr, _, cleanup := runTestTaskRunner(t, alloc, task.Name)
+	defer cleanup()
+
+	testWaitForTaskToStart(t, tr)
+
+	// Updates which don't change the resources don't restart the task
+	update := alloc.Copy()
+	update.AllocModifyIndex++
+	tr.Update(update)
+
+	// Update the CPU of the task in place
+	update = update.Copy()
+	update.AllocModifyIndex++
+	resources := update.AllocatedResources.Tasks[task.Name]
+	resources.Cpu.CpuShares += 100
+	tr.Update(update)
+
+	testutil.WaitForResult(func() (bool, error) {
+		ts := tr.TaskState()
+		if ts.Restarts != 1 {
+			return false, fmt.Errorf("expected 1 restart but found %d\nevents: %s",
+				ts.Restarts, pretty.Sprint(ts.Events))
+		}
+		if ts.State != structs.TaskStateRunning {
+			return false, fmt.Errorf("expected running but received %s", ts.State)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	// The restarted task runs with the updated resources
+	handle := tr.getDriverHandle()
+	require.NotNil(t, handle)
+	require.False(t, handle.ResourcesChanged(resources))
+	require.Equal(t, resources, tr.TaskResources())
+}
+
+// TestTaskRunner_UpdateResources_Restore asserts that resources updated while
+// the client was down are applied to the restored task.
+func TestTaskRunner_UpdateResources_Restore(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "10m",
+	}
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name)
+	conf.StateDB = cstate.NewMemDB(conf.Logger) // "persist" state between task runners
+	defer cleanup()
+
+	// Run the first TaskRunner
+	origTR, err := NewTaskRunner(conf)
+	require.NoError(err)
+	go origTR.Run()
+	defer origTR.Kill(context.Background(), structs.NewTaskEvent("cleanup"))
+
+	testWaitForTaskToStart(t, origTR)
+
+	// Cause TR to exit without shutting down task
+	origTR.Shutdown()
+
+	// Restore the task with updated resources
+	update := alloc.Copy()
+	update.AllocModifyIndex++
+	resources := update.AllocatedResources.Tasks[task.Name]
+	resources.Memory.MemoryMB += 64
+	conf.Alloc = update
+
+	newTR, err := NewTaskRunner(conf)
+	require.NoError(err)
+	require.NoError(newTR.Restore())
+	require.True(newTR.getDriverHandle().ResourcesChanged(resources))
+
+	go newTR.Run()
+	defer newTR.Kill(context.Background(), structs.NewTaskEvent("cleanup"))
+
+	testutil.WaitForResult(func() (bool, error) {
+		ts := newTR.TaskState()
+		if ts.Restarts != 1 {
+			return false, fmt.Errorf("expected 1 restart but found %d\nevents: %s",
+				ts.Restarts, pretty.Sprint(ts.Events))
+		}
+		if ts.State != structs.TaskStateRunning {
+			return false, fmt.Errorf("expected running but received %s", ts.State)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(err)
+	})
+
+	handle := newTR.getDriverHandle()
+	require.NotNil(handle)
+	require.False(handle.ResourcesChanged(resources))
+}
+
 // TestTaskRunner_CheckWatcher_Restart asserts that when enabled an unhealthy
 // Consul check will cause a task to restart following restart policy rules.
 func TestTaskRunner_CheckWatcher_Restart(t *testing.T) {
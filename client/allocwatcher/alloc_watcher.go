@@ -272,13 +272,9 @@ func (p *localPrevAlloc) Wait(ctx context.Context) error {
 	}
 }
 
-// Migrate from previous local alloc dir to destination alloc dir.
+// Migrate from previous local alloc dir to destination alloc dir. The
+// checkpoints of tasks are moved even if the ephemeral disk isn't sticky.
 func (p *localPrevAlloc) Migrate(ctx context.Context, dest *allocdir.AllocDir) error {
-	if !p.sticky {
-		// Not a sticky volume, nothing to migrate
-		return nil
-	}
-
 	p.waitingLock.Lock()
 	p.migrating = true
 	p.waitingLock.Unlock()
@@ -288,6 +284,12 @@ func (p *localPrevAlloc) Migrate(ctx context.Context, dest *allocdir.AllocDir) e
 		p.waitingLock.Unlock()
 	}()
 
+	if !p.sticky {
+		// Not a sticky volume, only move the checkpoints of tasks so
+		// they can be restored
+		return dest.MoveCheckpoints(p.prevAllocDir, p.tasks)
+	}
+
 	p.logger.Debug("copying previous alloc")
 
 	moveErr := dest.Move(p.prevAllocDir, p.tasks)
@@ -419,8 +421,8 @@ func (p *remotePrevAlloc) Wait(ctx context.Context) error {
 	return ctx.Err()
 }
 
-// Migrate alloc data from a remote node if the new alloc has migration enabled
-// and the old alloc hasn't been GC'd.
+// Migrate alloc data, including the checkpoints of tasks, from a remote node if
+// the new alloc has migration enabled and the old alloc hasn't been GC'd.
 func (p *remotePrevAlloc) Migrate(ctx context.Context, dest *allocdir.AllocDir) error {
 	if !p.migrate {
 		// Volume wasn't configured to be migrated, return early
@@ -197,6 +197,37 @@ func TestPrevAlloc_LocalPrevAlloc_Terminated(t *testing.T) {
 	require.NoError(t, waiter.Wait(ctx))
 }
 
+// TestPrevAlloc_LocalPrevAlloc_MigrateCheckpoints asserts that the checkpoints
+// of tasks are migrated from a local previous alloc even if its ephemeral disk
+// isn't sticky.
+func TestPrevAlloc_LocalPrevAlloc_MigrateCheckpoints(t *testing.T) {
+	t.Parallel()
+	conf, cleanup := newConfig(t)
+	defer cleanup()
+
+	conf.Alloc.Job.TaskGroups[0].EphemeralDisk.Sticky = false
+	task := conf.Alloc.Job.TaskGroups[0].Tasks[0]
+
+	prevAllocDir := conf.PreviousRunner.GetAllocDir()
+	require.NoError(t, prevAllocDir.Build())
+	prevTaskDir := prevAllocDir.NewTaskDir(task.Name)
+	require.NoError(t, prevTaskDir.Build(false, nil))
+	require.NoError(t, os.MkdirAll(prevTaskDir.CheckpointDir, 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(prevTaskDir.CheckpointDir, "inventory.img"), []byte("foo"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(prevTaskDir.LocalDir, "lol"), []byte("bar"), 0666))
+
+	dest := allocdir.NewAllocDir(testlog.HCLogger(t), t.TempDir(), conf.Alloc.ID)
+	require.NoError(t, dest.Build())
+	defer dest.Destroy()
+	taskDir := dest.NewTaskDir(task.Name)
+
+	_, migrator := NewAllocWatcher(conf)
+	require.NoError(t, migrator.Migrate(context.Background(), dest))
+
+	require.FileExists(t, filepath.Join(taskDir.CheckpointDir, "inventory.img"))
+	require.NoFileExists(t, filepath.Join(taskDir.LocalDir, "lol"))
+}
+
 // TestPrevAlloc_StreamAllocDir_Error asserts that errors encountered while
 // streaming a tar cause the migration to be cancelled and no files are written
 // (migrations are atomic).
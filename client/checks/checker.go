@@ -0,0 +1,248 @@
+package checks
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
+	agentconsul "github.com/hashicorp/nomad/command/agent/consul"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// maxOutputSize is the size the output of checks is truncated to, as
+	// done by Consul.
+	maxOutputSize = 4 * 1024
+
+	// userAgent is the User-Agent header of the requests of http checks,
+	// unless set by the check.
+	userAgent = "Nomad Health Check"
+)
+
+// query is a check to run along with the address or the executor it is run
+// against.
+type query struct {
+	check *structs.ServiceCheck
+
+	// address is the host:port http and tcp checks connect to
+	address string
+
+	// exec runs script checks in the task. Nil for group services or if the
+	// driver does not support exec.
+	exec interfaces.ScriptExecutor
+
+	// restarter restarts the workload of the check according to its
+	// check_restart block
+	restarter agentconsul.WorkloadRestarter
+
+	// template is the result the results of the check are built from
+	template *structs.CheckResult
+}
+
+// equal returns whether the check would be run the same way by both queries.
+// The definition of the check is expected to be the same as its ID is a hash
+// of it, except for its kind and check_restart block.
+func (q *query) equal(o *query) bool {
+	return q.address == o.address &&
+		q.exec == o.exec &&
+		q.check.Kind == o.check.Kind &&
+		q.check.CheckRestart.Equals(o.check.CheckRestart)
+}
+
+// outcome is the result of a single run of a check, before the thresholds of
+// the check are applied.
+type outcome struct {
+	status string
+	code   int
+	output string
+}
+
+// checker runs a check each time do is called.
+type checker struct {
+	q          *query
+	httpClient *http.Client
+}
+
+func newChecker(q *query) *checker {
+	c := &checker{q: q}
+	if strings.ToLower(q.check.Type) == structs.ServiceCheckHTTP {
+		c.httpClient = newHTTPClient(q.check)
+	}
+	return c
+}
+
+// newHTTPClient returns a client that does not reuse connections so that each
+// run of the check connects to the service.
+func newHTTPClient(check *structs.ServiceCheck) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableKeepAlives = true
+	if check.TLSSkipVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	return &http.Client{
+		Transport: transport,
+	}
+}
+
+// do runs the check once, within its timeout.
+func (c *checker) do(ctx context.Context) *outcome {
+	ctx, cancel := context.WithTimeout(ctx, c.q.check.Timeout)
+	defer cancel()
+
+	switch strings.ToLower(c.q.check.Type) {
+	case structs.ServiceCheckHTTP:
+		return c.http(ctx)
+	case structs.ServiceCheckTCP:
+		return c.tcp(ctx)
+	case structs.ServiceCheckScript:
+		return c.script(ctx)
+	default:
+		return critical(fmt.Sprintf("%s checks are not supported", c.q.check.Type))
+	}
+}
+
+func (c *checker) http(ctx context.Context) *outcome {
+	check := c.q.check
+
+	protocol := check.Protocol
+	if protocol == "" {
+		protocol = "http"
+	}
+	base := url.URL{
+		Scheme: protocol,
+		Host:   c.q.address,
+	}
+	relative, err := url.Parse(check.Path)
+	if err != nil {
+		return critical(fmt.Sprintf("invalid path %q: %v", check.Path, err))
+	}
+	checkURL := base.ResolveReference(relative).String()
+
+	method := check.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var body io.Reader
+	if check.Body != "" {
+		body = strings.NewReader(check.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, checkURL, body)
+	if err != nil {
+		return critical(err.Error())
+	}
+	for k, v := range check.Header {
+		for _, vv := range v {
+			req.Header.Add(k, vv)
+		}
+	}
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "text/plain, text/*, */*")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return critical(fmt.Sprintf("HTTP %s %s: %v", method, checkURL, err))
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxOutputSize))
+	out := &outcome{
+		code:   resp.StatusCode,
+		output: fmt.Sprintf("HTTP %s %s: %s Output: %s", method, checkURL, resp.Status, respBody),
+	}
+
+	// Mirror Consul: 2xx responses pass and 429 ones warn
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		out.status = structs.CheckStatusPassing
+	case resp.StatusCode == http.StatusTooManyRequests:
+		out.status = structs.CheckStatusWarning
+	default:
+		out.status = structs.CheckStatusCritical
+	}
+	return out
+}
+
+func (c *checker) tcp(ctx context.Context) *outcome {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", c.q.address)
+	if err != nil {
+		return critical(fmt.Sprintf("TCP connect %s: %v", c.q.address, err))
+	}
+	conn.Close()
+
+	return &outcome{
+		status: structs.CheckStatusPassing,
+		output: fmt.Sprintf("TCP connect %s: Success", c.q.address),
+	}
+}
+
+func (c *checker) script(ctx context.Context) *outcome {
+	if c.q.exec == nil {
+		return critical("driver does not support script checks")
+	}
+
+	type execResult struct {
+		output []byte
+		code   int
+		err    error
+	}
+
+	// Don't trust the executor to obey the timeout
+	check := c.q.check
+	resCh := make(chan execResult, 1)
+	go func() {
+		output, code, err := c.q.exec.Exec(check.Timeout, check.Command, check.Args)
+		resCh <- execResult{output, code, err}
+	}()
+
+	var res execResult
+	select {
+	case res = <-resCh:
+	case <-ctx.Done():
+		return critical(fmt.Sprintf("script timed out after %v", check.Timeout))
+	}
+	if res.err != nil {
+		return critical(res.err.Error())
+	}
+
+	out := &outcome{
+		code:   res.code,
+		output: truncate(string(res.output)),
+	}
+	switch res.code {
+	case 0:
+		out.status = structs.CheckStatusPassing
+	case 1:
+		out.status = structs.CheckStatusWarning
+	default:
+		out.status = structs.CheckStatusCritical
+	}
+	return out
+}
+
+func critical(output string) *outcome {
+	return &outcome{
+		status: structs.CheckStatusCritical,
+		output: output,
+	}
+}
+
+func truncate(output string) string {
+	if len(output) > maxOutputSize {
+		return output[:maxOutputSize]
+	}
+	return output
+}
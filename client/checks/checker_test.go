@@ -0,0 +1,172 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExec is a script executor returning a fixed outcome.
+type fakeExec struct {
+	output string
+	code   int
+	err    error
+	delay  time.Duration
+}
+
+func (e *fakeExec) Exec(_ time.Duration, _ string, _ []string) ([]byte, int, error) {
+	time.Sleep(e.delay)
+	return []byte(e.output), e.code, e.err
+}
+
+func testCheck(checkType string) *structs.ServiceCheck {
+	return &structs.ServiceCheck{
+		Name:     "check",
+		Type:     checkType,
+		Interval: 10 * time.Millisecond,
+		Timeout:  time.Second,
+	}
+}
+
+func TestChecker_HTTP(t *testing.T) {
+	t.Parallel()
+
+	reqs := make(chan *http.Request, 1)
+	codes := make(chan int, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqs <- r
+		w.WriteHeader(<-codes)
+		fmt.Fprint(w, strings.Repeat("x", 2*maxOutputSize))
+	}))
+	defer ts.Close()
+
+	check := testCheck(structs.ServiceCheckHTTP)
+	check.Path = "/health?full=1"
+	check.Method = http.MethodPost
+	check.Header = map[string][]string{"X-Test": {"a", "b"}}
+	check.Body = "ping"
+	c := newChecker(&query{
+		check:   check,
+		address: strings.TrimPrefix(ts.URL, "http://"),
+	})
+
+	cases := []struct {
+		code   int
+		status string
+	}{
+		{http.StatusOK, structs.CheckStatusPassing},
+		{http.StatusNoContent, structs.CheckStatusPassing},
+		{http.StatusTooManyRequests, structs.CheckStatusWarning},
+		{http.StatusServiceUnavailable, structs.CheckStatusCritical},
+	}
+	for _, tc := range cases {
+		codes <- tc.code
+		out := c.do(context.Background())
+		require.Equal(t, tc.status, out.status, "code %d", tc.code)
+		require.Equal(t, tc.code, out.code)
+		require.Less(t, len(out.output), 2*maxOutputSize)
+
+		gotReq := <-reqs
+		require.Equal(t, http.MethodPost, gotReq.Method)
+		require.Equal(t, "/health", gotReq.URL.Path)
+		require.Equal(t, "1", gotReq.URL.Query().Get("full"))
+		require.Equal(t, []string{"a", "b"}, gotReq.Header["X-Test"])
+		require.Equal(t, userAgent, gotReq.Header.Get("User-Agent"))
+	}
+}
+
+func TestChecker_HTTP_Unreachable(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.NotFoundHandler())
+	addr := strings.TrimPrefix(ts.URL, "http://")
+	ts.Close()
+
+	c := newChecker(&query{check: testCheck(structs.ServiceCheckHTTP), address: addr})
+	out := c.do(context.Background())
+	require.Equal(t, structs.CheckStatusCritical, out.status)
+	require.Contains(t, out.output, addr)
+}
+
+func TestChecker_TCP(t *testing.T) {
+	t.Parallel()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+
+	c := newChecker(&query{check: testCheck(structs.ServiceCheckTCP), address: addr})
+	out := c.do(context.Background())
+	require.Equal(t, structs.CheckStatusPassing, out.status)
+
+	l.Close()
+	out = c.do(context.Background())
+	require.Equal(t, structs.CheckStatusCritical, out.status)
+}
+
+func TestChecker_Script(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		exec   *fakeExec
+		status string
+		output string
+	}{
+		{
+			name:   "passing",
+			exec:   &fakeExec{output: "ok", code: 0},
+			status: structs.CheckStatusPassing,
+			output: "ok",
+		},
+		{
+			name:   "warning",
+			exec:   &fakeExec{output: "meh", code: 1},
+			status: structs.CheckStatusWarning,
+			output: "meh",
+		},
+		{
+			name:   "critical",
+			exec:   &fakeExec{output: "bad", code: 2},
+			status: structs.CheckStatusCritical,
+			output: "bad",
+		},
+		{
+			name:   "error",
+			exec:   &fakeExec{err: fmt.Errorf("exec failed")},
+			status: structs.CheckStatusCritical,
+			output: "exec failed",
+		},
+		{
+			name:   "timeout",
+			exec:   &fakeExec{delay: time.Second},
+			status: structs.CheckStatusCritical,
+			output: "timed out",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			check := testCheck(structs.ServiceCheckScript)
+			check.Timeout = 50 * time.Millisecond
+			c := newChecker(&query{check: check, exec: tc.exec})
+
+			out := c.do(context.Background())
+			require.Equal(t, tc.status, out.status)
+			require.Contains(t, out.output, tc.output)
+		})
+	}
+
+	// Drivers without exec support fail script checks
+	c := newChecker(&query{check: testCheck(structs.ServiceCheckScript)})
+	out := c.do(context.Background())
+	require.Equal(t, structs.CheckStatusCritical, out.status)
+}
@@ -0,0 +1,51 @@
+package checks
+
+import (
+	"github.com/hashicorp/nomad/client/consul"
+	agentconsul "github.com/hashicorp/nomad/command/agent/consul"
+)
+
+// serviceHandler registers the services of the workloads of an allocation
+// with their provider: Consul or the runner of the checks of the allocation.
+type serviceHandler struct {
+	consul consul.ConsulServiceAPI
+	runner *Runner
+}
+
+// NewServiceHandler returns a ConsulServiceAPI that registers the services
+// using the nomad provider with the runner and the other services with
+// Consul, so that hooks can handle the services of workloads regardless of
+// their provider.
+func NewServiceHandler(consulClient consul.ConsulServiceAPI, runner *Runner) consul.ConsulServiceAPI {
+	return &serviceHandler{
+		consul: consulClient,
+		runner: runner,
+	}
+}
+
+func (h *serviceHandler) RegisterWorkload(workload *agentconsul.WorkloadServices) error {
+	if err := h.consul.RegisterWorkload(workload.ConsulServices()); err != nil {
+		return err
+	}
+	return h.runner.RegisterWorkload(workload)
+}
+
+func (h *serviceHandler) RemoveWorkload(workload *agentconsul.WorkloadServices) {
+	h.consul.RemoveWorkload(workload.ConsulServices())
+	h.runner.RemoveWorkload(workload)
+}
+
+func (h *serviceHandler) UpdateWorkload(old, newWorkload *agentconsul.WorkloadServices) error {
+	if err := h.consul.UpdateWorkload(old.ConsulServices(), newWorkload.ConsulServices()); err != nil {
+		return err
+	}
+	return h.runner.UpdateWorkload(old, newWorkload)
+}
+
+func (h *serviceHandler) AllocRegistrations(allocID string) (*agentconsul.AllocRegistration, error) {
+	return h.consul.AllocRegistrations(allocID)
+}
+
+func (h *serviceHandler) UpdateTTL(id, namespace, output, status string) error {
+	return h.consul.UpdateTTL(id, namespace, output, status)
+}
@@ -0,0 +1,99 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	agentconsul "github.com/hashicorp/nomad/command/agent/consul"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// checkRestart restarts the workload of a check that stays unhealthy,
+// following the check_restart block of the check the same way Consul checks
+// are watched.
+type checkRestart struct {
+	checkName      string
+	restarter      agentconsul.WorkloadRestarter
+	timeLimit      time.Duration
+	graceUntil     time.Time
+	ignoreWarnings bool
+
+	// unhealthySince is the time the check first went unhealthy. Set to the
+	// zero value if the check passes before timeLimit.
+	unhealthySince time.Time
+
+	logger log.Logger
+}
+
+// newCheckRestart returns nil if the check does not trigger restarts.
+func newCheckRestart(logger log.Logger, check *structs.ServiceCheck, restarter agentconsul.WorkloadRestarter) *checkRestart {
+	if !check.TriggersRestarts() || restarter == nil {
+		return nil
+	}
+
+	return &checkRestart{
+		checkName:      check.Name,
+		restarter:      restarter,
+		timeLimit:      check.Interval * time.Duration(check.CheckRestart.Limit-1),
+		graceUntil:     time.Now().Add(check.CheckRestart.Grace),
+		ignoreWarnings: check.CheckRestart.IgnoreWarnings,
+		logger:         logger,
+	}
+}
+
+// apply the status of the check at the given time and restart the workload if
+// the check has been unhealthy for too long. Returns true if a restart was
+// triggered, after which the check must no longer be applied: the check is
+// registered again once the workload has restarted.
+func (c *checkRestart) apply(now time.Time, status string) bool {
+	switch status {
+	case structs.CheckStatusCritical:
+	case structs.CheckStatusWarning:
+		if !c.ignoreWarnings {
+			break
+		}
+		fallthrough
+	default:
+		if !c.unhealthySince.IsZero() {
+			c.logger.Debug("canceling restart because check became healthy")
+			c.unhealthySince = time.Time{}
+		}
+		return false
+	}
+
+	if now.Before(c.graceUntil) {
+		return false
+	}
+
+	if c.unhealthySince.IsZero() {
+		if c.timeLimit != 0 {
+			c.logger.Debug("check became unhealthy. Will restart if check doesn't become healthy", "time_limit", c.timeLimit)
+		}
+		c.unhealthySince = now
+	}
+
+	// Restart once the deadline is reached, which is the first failure if
+	// limit=1
+	if now.Before(c.unhealthySince.Add(c.timeLimit)) {
+		return false
+	}
+
+	c.logger.Debug("restarting due to unhealthy check")
+	reason := fmt.Sprintf("healthcheck: check %q unhealthy", c.checkName)
+	event := structs.NewTaskEvent(structs.TaskRestartSignal).SetRestartReason(reason)
+	go c.restart(event)
+	return true
+}
+
+// restart the workload, counting it as a failure. The check is removed while
+// the workload restarts so the restart isn't bound to its lifetime.
+func (c *checkRestart) restart(event *structs.TaskEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := c.restarter.Restart(ctx, event, true); err != nil {
+		c.logger.Debug("failed to restart workload", "error", err)
+	}
+}
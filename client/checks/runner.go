@@ -0,0 +1,331 @@
+// Package checks runs the checks of the services using the nomad provider,
+// which are not registered in Consul, on the client of their allocation.
+package checks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	agentconsul "github.com/hashicorp/nomad/command/agent/consul"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Runner runs the checks of the services of an allocation using the nomad
+// provider at their interval, restarts their workloads according to their
+// check_restart blocks and keeps their latest results.
+type Runner struct {
+	// onUpdate is called when a check is added or removed, or when the
+	// status of a check changes
+	onUpdate func()
+
+	logger log.Logger
+
+	// l guards the fields below
+	l sync.Mutex
+
+	// checks are the running checks by ID
+	checks map[string]*runningCheck
+
+	// results are the latest results of the running checks by ID
+	results map[string]*structs.CheckResult
+
+	// shutdown is set once Shutdown has been called
+	shutdown bool
+}
+
+// runningCheck is a check whose goroutine is running.
+type runningCheck struct {
+	q        *query
+	workload string
+	cancel   context.CancelFunc
+}
+
+// NewRunner returns a runner of the checks of an allocation. onUpdate is
+// called whenever the results of the checks are updated in a way that
+// matters to the servers.
+func NewRunner(logger log.Logger, onUpdate func()) *Runner {
+	return &Runner{
+		onUpdate: onUpdate,
+		logger:   logger.Named("checks"),
+		checks:   make(map[string]*runningCheck),
+		results:  make(map[string]*structs.CheckResult),
+	}
+}
+
+// RegisterWorkload starts running the checks of the services of the workload
+// using the nomad provider.
+func (r *Runner) RegisterWorkload(workload *agentconsul.WorkloadServices) error {
+	queries, err := r.queries(workload)
+	if err != nil {
+		return err
+	}
+
+	r.l.Lock()
+	for id, q := range queries {
+		r.startLocked(id, workload.Name(), q)
+	}
+	r.l.Unlock()
+
+	r.updated(len(queries) > 0)
+	return nil
+}
+
+// UpdateWorkload starts the new checks of the workload and stops the ones
+// that were removed. Checks whose definition did not change keep running and
+// keep their results.
+func (r *Runner) UpdateWorkload(old, newWorkload *agentconsul.WorkloadServices) error {
+	queries, err := r.queries(newWorkload)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	r.l.Lock()
+	for id, rc := range r.checks {
+		if rc.workload != old.Name() {
+			continue
+		}
+		if q, ok := queries[id]; ok && q.equal(rc.q) {
+			delete(queries, id)
+			continue
+		}
+		r.stopLocked(id)
+		changed = true
+	}
+	for id, q := range queries {
+		r.startLocked(id, newWorkload.Name(), q)
+		changed = true
+	}
+	r.l.Unlock()
+
+	r.updated(changed)
+	return nil
+}
+
+// RemoveWorkload stops the checks of the workload and forgets their results.
+func (r *Runner) RemoveWorkload(workload *agentconsul.WorkloadServices) {
+	changed := false
+	r.l.Lock()
+	for id, rc := range r.checks {
+		if rc.workload == workload.Name() {
+			r.stopLocked(id)
+			changed = true
+		}
+	}
+	r.l.Unlock()
+
+	r.updated(changed)
+}
+
+// Shutdown stops all the checks without notifying of their removal, as the
+// allocation is no longer run by the client.
+func (r *Runner) Shutdown() {
+	r.l.Lock()
+	defer r.l.Unlock()
+
+	r.shutdown = true
+	for _, rc := range r.checks {
+		rc.cancel()
+	}
+	r.checks = map[string]*runningCheck{}
+}
+
+// Results returns a copy of the latest results of the checks by ID, or nil if
+// there are no checks.
+func (r *Runner) Results() map[string]*structs.CheckResult {
+	r.l.Lock()
+	defer r.l.Unlock()
+
+	if len(r.results) == 0 {
+		return nil
+	}
+
+	results := make(map[string]*structs.CheckResult, len(r.results))
+	for id, result := range r.results {
+		results[id] = result.Copy()
+	}
+	return results
+}
+
+// queries returns the checks of the services of the workload using the nomad
+// provider by ID.
+func (r *Runner) queries(workload *agentconsul.WorkloadServices) (map[string]*query, error) {
+	workload = workload.NomadServices()
+
+	queries := make(map[string]*query)
+	for _, service := range workload.Services {
+		serviceID := agentconsul.MakeAllocServiceID(workload.AllocID, workload.Name(), service)
+
+		for _, check := range service.Checks {
+			q := &query{
+				check:     check,
+				exec:      workload.DriverExec,
+				restarter: workload.Restarter,
+				template: &structs.CheckResult{
+					ID:       agentconsul.MakeCheckID(serviceID, check),
+					Service:  service.Name,
+					Check:    check.Name,
+					Group:    workload.Group,
+					Task:     workload.Task,
+					Type:     check.Type,
+					OnUpdate: check.OnUpdate,
+					Kind:     check.Kind,
+				},
+			}
+
+			if check.RequiresPort() {
+				portLabel := check.PortLabel
+				if portLabel == "" {
+					portLabel = service.PortLabel
+				}
+
+				addrMode := check.AddressMode
+				if addrMode == "" {
+					addrMode = structs.AddressModeHost
+				}
+
+				ip, port, err := agentconsul.GetAddress(addrMode, portLabel, workload.Networks, workload.DriverNetwork, workload.Ports, workload.NetworkStatus)
+				if err != nil {
+					return nil, fmt.Errorf("error getting address for check %q: %v", check.Name, err)
+				}
+				q.address = net.JoinHostPort(ip, strconv.Itoa(port))
+			}
+
+			queries[q.template.ID] = q
+		}
+	}
+	return queries, nil
+}
+
+// startLocked starts running the check, keeping its result if it was already
+// running. The caller must hold the lock.
+func (r *Runner) startLocked(id, workload string, q *query) {
+	if r.shutdown {
+		return
+	}
+
+	if rc, ok := r.checks[id]; ok {
+		rc.cancel()
+	}
+
+	if _, ok := r.results[id]; !ok {
+		// Checks are critical until they pass, unless configured otherwise
+		result := q.template.Copy()
+		result.Status = structs.CheckStatusCritical
+		if q.check.InitialStatus != "" {
+			result.Status = q.check.InitialStatus
+		}
+		r.results[id] = result
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.checks[id] = &runningCheck{
+		q:        q,
+		workload: workload,
+		cancel:   cancel,
+	}
+	go r.run(ctx, id, q)
+}
+
+// stopLocked stops running the check and removes its result. The caller must
+// hold the lock.
+func (r *Runner) stopLocked(id string) {
+	if rc, ok := r.checks[id]; ok {
+		rc.cancel()
+		delete(r.checks, id)
+	}
+	delete(r.results, id)
+}
+
+// updated notifies of an update of the checks.
+func (r *Runner) updated(changed bool) {
+	if changed && r.onUpdate != nil {
+		r.onUpdate()
+	}
+}
+
+// run the check at its interval until the context is canceled.
+func (r *Runner) run(ctx context.Context, id string, q *query) {
+	logger := r.logger.With("check", q.check.Name, "task", q.template.Task)
+	c := newChecker(q)
+	restart := newCheckRestart(logger, q.check, q.restarter)
+
+	// successes and failures count the consecutive outcomes of the check,
+	// which change its status once they reach its thresholds
+	successes, failures := 0, 0
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		out := c.do(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		status := ""
+		switch out.status {
+		case structs.CheckStatusPassing:
+			successes++
+			failures = 0
+			if successes >= q.check.SuccessBeforePassing {
+				status = out.status
+			}
+		default:
+			failures++
+			successes = 0
+			if failures >= q.check.FailuresBeforeCritical {
+				status = out.status
+			}
+		}
+
+		status = r.setResult(id, status, out)
+		if restart != nil && restart.apply(time.Now(), status) {
+			// The workload is restarting and will register the check again
+			restart = nil
+		}
+
+		timer.Reset(q.check.Interval)
+	}
+}
+
+// setResult sets the latest result of the check and returns its status. The
+// status is left unchanged if empty, as the thresholds of the check have not
+// been reached yet.
+func (r *Runner) setResult(id, status string, out *outcome) string {
+	r.l.Lock()
+	result, ok := r.results[id]
+	if !ok {
+		// The check has been removed
+		r.l.Unlock()
+		return ""
+	}
+
+	result = result.Copy()
+	changed := status != "" && status != result.Status
+	if status != "" {
+		result.Status = status
+	}
+	result.StatusCode = out.code
+	result.Output = out.output
+	result.Timestamp = time.Now()
+	r.results[id] = result
+	r.l.Unlock()
+
+	if changed {
+		r.logger.Debug("check status changed", "check", result.Check, "task", result.Task, "status", result.Status)
+	}
+	r.updated(changed)
+	return result.Status
+}
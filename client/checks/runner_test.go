@@ -0,0 +1,269 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	agentconsul "github.com/hashicorp/nomad/command/agent/consul"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRestarter counts the restarts of a workload.
+type fakeRestarter struct {
+	l        sync.Mutex
+	restarts int
+}
+
+func (r *fakeRestarter) Restart(_ context.Context, _ *structs.TaskEvent, _ bool) error {
+	r.l.Lock()
+	defer r.l.Unlock()
+	r.restarts++
+	return nil
+}
+
+func (r *fakeRestarter) count() int {
+	r.l.Lock()
+	defer r.l.Unlock()
+	return r.restarts
+}
+
+// testService is an http service whose health is controlled by the returned
+// status code.
+func testService(t *testing.T) (*httptest.Server, *int32) {
+	code := int32(http.StatusOK)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(atomic.LoadInt32(&code)))
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &code
+}
+
+// testWorkload returns a workload with a nomad service with an http check
+// against the server.
+func testWorkload(t *testing.T, ts *httptest.Server) *agentconsul.WorkloadServices {
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(u.Port())
+	require.NoError(t, err)
+
+	return &agentconsul.WorkloadServices{
+		AllocID: uuid.Generate(),
+		Group:   "web",
+		Task:    "web",
+		Services: []*structs.Service{
+			{
+				Name:      "web",
+				PortLabel: "http",
+				Provider:  structs.ServiceProviderNomad,
+				Checks: []*structs.ServiceCheck{
+					{
+						Name:     "alive",
+						Type:     structs.ServiceCheckHTTP,
+						Path:     "/health",
+						Interval: 10 * time.Millisecond,
+						Timeout:  time.Second,
+						OnUpdate: structs.OnUpdateRequireHealthy,
+					},
+				},
+			},
+			{
+				Name:      "consul",
+				PortLabel: "http",
+				Provider:  structs.ServiceProviderConsul,
+				Checks: []*structs.ServiceCheck{
+					{
+						Name:     "consul-check",
+						Type:     structs.ServiceCheckTCP,
+						Interval: 10 * time.Millisecond,
+						Timeout:  time.Second,
+					},
+				},
+			},
+		},
+		Ports: structs.AllocatedPorts{
+			{Label: "http", Value: port, HostIP: u.Hostname()},
+		},
+	}
+}
+
+// waitForStatus waits until the only check of the runner has the status.
+func waitForStatus(t *testing.T, r *Runner, status string) *structs.CheckResult {
+	var result *structs.CheckResult
+	testutil.WaitForResult(func() (bool, error) {
+		results := r.Results()
+		if len(results) != 1 {
+			return false, fmt.Errorf("expected 1 result, got %d", len(results))
+		}
+		for _, result = range results {
+		}
+		if result.Status != status {
+			return false, fmt.Errorf("expected status %q, got %q", status, result.Status)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+	return result
+}
+
+func TestRunner_HTTP(t *testing.T) {
+	t.Parallel()
+
+	ts, code := testService(t)
+	var updates int32
+	r := NewRunner(testlog.HCLogger(t), func() { atomic.AddInt32(&updates, 1) })
+	defer r.Shutdown()
+
+	ws := testWorkload(t, ts)
+	require.NoError(t, r.RegisterWorkload(ws))
+
+	// Only the check of the nomad service is run
+	result := waitForStatus(t, r, structs.CheckStatusPassing)
+	require.Equal(t, "web", result.Service)
+	require.Equal(t, "alive", result.Check)
+	require.Equal(t, "web", result.Group)
+	require.Equal(t, "web", result.Task)
+	require.Equal(t, http.StatusOK, result.StatusCode)
+	require.False(t, result.Timestamp.IsZero())
+
+	atomic.StoreInt32(code, http.StatusServiceUnavailable)
+	result = waitForStatus(t, r, structs.CheckStatusCritical)
+	require.Equal(t, http.StatusServiceUnavailable, result.StatusCode)
+
+	// Registered, passing and critical
+	require.Equal(t, int32(3), atomic.LoadInt32(&updates))
+
+	r.RemoveWorkload(ws)
+	require.Nil(t, r.Results())
+	require.Equal(t, int32(4), atomic.LoadInt32(&updates))
+}
+
+func TestRunner_Thresholds(t *testing.T) {
+	t.Parallel()
+
+	ts, code := testService(t)
+	r := NewRunner(testlog.HCLogger(t), nil)
+	defer r.Shutdown()
+
+	ws := testWorkload(t, ts)
+	check := ws.Services[0].Checks[0]
+	check.InitialStatus = structs.CheckStatusWarning
+	check.SuccessBeforePassing = 3
+	check.FailuresBeforeCritical = 3
+	check.Interval = 50 * time.Millisecond
+	require.NoError(t, r.RegisterWorkload(ws))
+
+	// The initial status is kept until enough checks pass
+	for _, result := range r.Results() {
+		require.Equal(t, structs.CheckStatusWarning, result.Status)
+	}
+	time.Sleep(60 * time.Millisecond)
+	for _, result := range r.Results() {
+		require.Equal(t, structs.CheckStatusWarning, result.Status)
+	}
+	waitForStatus(t, r, structs.CheckStatusPassing)
+
+	// A single failure does not make the check critical
+	atomic.StoreInt32(code, http.StatusServiceUnavailable)
+	time.Sleep(60 * time.Millisecond)
+	for _, result := range r.Results() {
+		require.Equal(t, structs.CheckStatusPassing, result.Status)
+	}
+	waitForStatus(t, r, structs.CheckStatusCritical)
+}
+
+func TestRunner_UpdateWorkload(t *testing.T) {
+	t.Parallel()
+
+	ts, _ := testService(t)
+	r := NewRunner(testlog.HCLogger(t), nil)
+	defer r.Shutdown()
+
+	ws := testWorkload(t, ts)
+	require.NoError(t, r.RegisterWorkload(ws))
+	before := waitForStatus(t, r, structs.CheckStatusPassing)
+
+	// Unchanged checks keep running with their results
+	require.NoError(t, r.UpdateWorkload(ws, ws))
+	results := r.Results()
+	require.Contains(t, results, before.ID)
+	require.Equal(t, structs.CheckStatusPassing, results[before.ID].Status)
+
+	// Changed checks are replaced
+	newWS := testWorkload(t, ts)
+	newWS.AllocID = ws.AllocID
+	newWS.Services[0].Checks[0].Path = "/ready"
+	require.NoError(t, r.UpdateWorkload(ws, newWS))
+	results = r.Results()
+	require.Len(t, results, 1)
+	require.NotContains(t, results, before.ID)
+
+	// Moving the service to Consul stops its checks
+	consulWS := testWorkload(t, ts)
+	consulWS.AllocID = ws.AllocID
+	consulWS.Services[0].Provider = structs.ServiceProviderConsul
+	require.NoError(t, r.UpdateWorkload(newWS, consulWS))
+	require.Nil(t, r.Results())
+}
+
+func TestRunner_CheckRestart(t *testing.T) {
+	t.Parallel()
+
+	ts, code := testService(t)
+	atomic.StoreInt32(code, http.StatusServiceUnavailable)
+	r := NewRunner(testlog.HCLogger(t), nil)
+	defer r.Shutdown()
+
+	restarter := &fakeRestarter{}
+	ws := testWorkload(t, ts)
+	ws.Restarter = restarter
+	ws.Services[0].Checks[0].CheckRestart = &structs.CheckRestart{Limit: 2}
+	require.NoError(t, r.RegisterWorkload(ws))
+
+	testutil.WaitForResult(func() (bool, error) {
+		if n := restarter.count(); n != 1 {
+			return false, fmt.Errorf("expected 1 restart, got %d", n)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	// The check is not applied again until the workload registers it again
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, 1, restarter.count())
+}
+
+func TestRunner_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	ts, _ := testService(t)
+	var updates int32
+	r := NewRunner(testlog.HCLogger(t), func() { atomic.AddInt32(&updates, 1) })
+
+	ws := testWorkload(t, ts)
+	require.NoError(t, r.RegisterWorkload(ws))
+	waitForStatus(t, r, structs.CheckStatusPassing)
+
+	// Shutting down keeps the last results and does not notify
+	n := atomic.LoadInt32(&updates)
+	r.Shutdown()
+	require.Len(t, r.Results(), 1)
+	require.Equal(t, n, atomic.LoadInt32(&updates))
+
+	// Checks are not started once shut down
+	require.NoError(t, r.RegisterWorkload(testWorkload(t, ts)))
+	require.Len(t, r.Results(), 1)
+}
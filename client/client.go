@@ -1942,6 +1942,7 @@ func (c *Client) AllocStateUpdated(alloc *structs.Allocation) {
 	stripped.ClientDescription = alloc.ClientDescription
 	stripped.DeploymentStatus = alloc.DeploymentStatus
 	stripped.NetworkStatus = alloc.NetworkStatus
+	stripped.CheckResults = alloc.CheckResults
 
 	select {
 	case c.allocUpdates <- stripped:
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
+	"github.com/hashicorp/nomad/client/structs"
+	nstructs "github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// defaultPrefetchDriver is the driver images are prefetched with unless the
+// request sets one
+const defaultPrefetchDriver = "docker"
+
+// ClientImages endpoint is used for managing the images cached by the
+// client's drivers
+type ClientImages struct {
+	c *Client
+}
+
+// Prefetch asks a driver to pull images in the background so that they're
+// cached before tasks use them.
+func (i *ClientImages) Prefetch(args *structs.ImagePrefetchRequest, reply *nstructs.GenericResponse) error {
+	defer metrics.MeasureSince([]string{"client", "client_images", "prefetch"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := i.c.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+		return nstructs.ErrPermissionDenied
+	}
+
+	if len(args.Images) == 0 {
+		return errors.New("missing images")
+	}
+
+	name := args.Driver
+	if name == "" {
+		name = defaultPrefetchDriver
+	}
+
+	driver, err := i.c.drivermanager.Dispense(name)
+	if err == drivermanager.ErrDriverNotFound {
+		return fmt.Errorf("driver %q not found", name)
+	} else if err != nil {
+		return err
+	}
+
+	prefetcher, ok := driver.(drivers.DriverImagePrefetcher)
+	if !ok {
+		return fmt.Errorf("driver %q doesn't support prefetching images", name)
+	}
+
+	return prefetcher.PrefetchImages(args.Images)
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/mock"
+	nstructs "github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientImages_Prefetch(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	client, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	// Images are required
+	req := &structs.ImagePrefetchRequest{Driver: "mock_driver"}
+	var resp nstructs.GenericResponse
+	err := client.ClientRPC("ClientImages.Prefetch", req, &resp)
+	require.EqualError(err, "missing images")
+
+	// The driver must exist
+	req = &structs.ImagePrefetchRequest{Driver: "unknown", Images: []string{"redis:7"}}
+	err = client.ClientRPC("ClientImages.Prefetch", req, &resp)
+	require.EqualError(err, `driver "unknown" not found`)
+
+	// The driver must support prefetching images
+	req = &structs.ImagePrefetchRequest{Driver: "mock_driver", Images: []string{"redis:7"}}
+	err = client.ClientRPC("ClientImages.Prefetch", req, &resp)
+	require.EqualError(err, `driver "mock_driver" doesn't support prefetching images`)
+}
+
+func TestClientImages_Prefetch_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	server, addr, root, cleanupS := testACLServer(t, nil)
+	defer cleanupS()
+
+	client, cleanupC := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{addr}
+		c.ACLEnabled = true
+	})
+	defer cleanupC()
+
+	// Try request without a token and expect failure
+	{
+		req := &structs.ImagePrefetchRequest{Driver: "mock_driver", Images: []string{"redis:7"}}
+		var resp nstructs.GenericResponse
+		err := client.ClientRPC("ClientImages.Prefetch", req, &resp)
+		require.EqualError(err, nstructs.ErrPermissionDenied.Error())
+	}
+
+	// Try request with a read token and expect failure
+	{
+		token := mock.CreatePolicyAndToken(t, server.State(), 1005, "invalid", mock.NodePolicy(acl.PolicyRead))
+		req := &structs.ImagePrefetchRequest{Driver: "mock_driver", Images: []string{"redis:7"}}
+		req.AuthToken = token.SecretID
+
+		var resp nstructs.GenericResponse
+		err := client.ClientRPC("ClientImages.Prefetch", req, &resp)
+		require.EqualError(err, nstructs.ErrPermissionDenied.Error())
+	}
+
+	// Try request with a write token and expect the request to reach the
+	// driver
+	{
+		token := mock.CreatePolicyAndToken(t, server.State(), 1007, "valid", mock.NodePolicy(acl.PolicyWrite))
+		req := &structs.ImagePrefetchRequest{Driver: "mock_driver", Images: []string{"redis:7"}}
+		req.AuthToken = token.SecretID
+
+		var resp nstructs.GenericResponse
+		err := client.ClientRPC("ClientImages.Prefetch", req, &resp)
+		require.EqualError(err, `driver "mock_driver" doesn't support prefetching images`)
+	}
+
+	// Try request with a management token
+	{
+		req := &structs.ImagePrefetchRequest{Driver: "mock_driver", Images: []string{"redis:7"}}
+		req.AuthToken = root.SecretID
+
+		var resp nstructs.GenericResponse
+		err := client.ClientRPC("ClientImages.Prefetch", req, &resp)
+		require.EqualError(err, `driver "mock_driver" doesn't support prefetching images`)
+	}
+}
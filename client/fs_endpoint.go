@@ -1,27 +1,31 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math"
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
-	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/go-bexpr"
 	"github.com/hashicorp/go-msgpack/codec"
 	"github.com/hpcloud/tail/watch"
 
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/client/allocdir"
 	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
+	"github.com/hashicorp/nomad/client/logmon/logging"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -33,6 +37,7 @@ var (
 	taskNotPresentErr    = fmt.Errorf("must provide task name")
 	logTypeNotPresentErr = fmt.Errorf("must provide log type (stdout/stderr)")
 	invalidOrigin        = fmt.Errorf("origin must be start or end")
+	patternNotPresentErr = fmt.Errorf("must provide a pattern")
 )
 
 const (
@@ -67,6 +72,16 @@ const (
 	// and end of a file.
 	OriginStart = "start"
 	OriginEnd   = "end"
+
+	// defaultLogSearchLimit and maxLogSearchLimit are the default and
+	// maximum number of matches returned by a search of the logs of an
+	// allocation.
+	defaultLogSearchLimit = 100
+	maxLogSearchLimit     = 10000
+
+	// maxLogSearchLineSize is the size at which lines are truncated when the
+	// logs are searched.
+	maxLogSearchLineSize = 64 * 1024
 )
 
 // FileSystem endpoint is used for accessing the logs and filesystem of
@@ -154,6 +169,126 @@ func (f *FileSystem) Stat(args *cstructs.FsStatRequest, reply *cstructs.FsStatRe
 	return nil
 }
 
+// SearchLogs is used to search the logs of the tasks of an allocation for
+// lines matching a regular expression. Rotated log files are searched in the
+// order they were written, including compressed ones.
+func (f *FileSystem) SearchLogs(args *cstructs.FsSearchLogsRequest, reply *cstructs.FsSearchLogsResponse) error {
+	defer metrics.MeasureSince([]string{"client", "file_system", "search_logs"}, time.Now())
+
+	if args.AllocID == "" {
+		return allocIDNotPresentErr
+	}
+	alloc, err := f.c.GetAlloc(args.AllocID)
+	if err != nil {
+		return structs.NewErrUnknownAllocation(args.AllocID)
+	}
+
+	// Check read permissions
+	if aclObj, err := f.c.ResolveToken(args.QueryOptions.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil {
+		readfs := aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadFS)
+		logs := aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadLogs)
+		if !readfs && !logs {
+			return structs.ErrPermissionDenied
+		}
+	}
+
+	// Validate the arguments
+	if args.Pattern == "" {
+		return patternNotPresentErr
+	}
+	re, err := regexp.Compile(args.Pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern: %v", err)
+	}
+	logTypes := []string{"stdout", "stderr"}
+	switch args.LogType {
+	case "stdout", "stderr":
+		logTypes = []string{args.LogType}
+	case "":
+	default:
+		return logTypeNotPresentErr
+	}
+	limit := args.Limit
+	if limit <= 0 {
+		limit = defaultLogSearchLimit
+	} else if limit > maxLogSearchLimit {
+		limit = maxLogSearchLimit
+	}
+
+	fs, err := f.c.GetAllocFS(args.AllocID)
+	if err != nil {
+		return err
+	}
+	allocState, err := f.c.GetAllocState(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	var tasks []string
+	if args.Task != "" {
+		if allocState.TaskStates[args.Task] == nil {
+			return fmt.Errorf("unknown task name %q", args.Task)
+		}
+		tasks = []string{args.Task}
+	} else {
+		for task := range allocState.TaskStates {
+			tasks = append(tasks, task)
+		}
+		sort.Strings(tasks)
+	}
+
+	logPath := filepath.Join(allocdir.SharedAllocName, allocdir.LogDirName)
+	entries, err := fs.List(logPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to list entries: %v", err)
+	}
+
+	for _, task := range tasks {
+		for _, logType := range logTypes {
+			indexes, err := logIndexes(entries, task, logType)
+			if err != nil {
+				return err
+			}
+			sort.Sort(indexes)
+
+			base := fmt.Sprintf("%s.%s", task, logType)
+			for _, idx := range indexes {
+				_, compression, _ := logging.LogFileIndex(idx.entry.Name, base)
+				p := filepath.Join(logPath, idx.entry.Name)
+				matches, truncated, err := searchLogFile(fs, p, compression, re, limit-len(reply.Matches))
+				if err != nil {
+					// The file may have been rotated out while searching
+					if os.IsNotExist(err) {
+						continue
+					}
+					return fmt.Errorf("failed to search %q: %v", p, err)
+				}
+
+				for _, m := range matches {
+					m.AllocID = alloc.ID
+					m.NodeID = alloc.NodeID
+					m.Task = task
+					m.LogType = logType
+					m.File = idx.entry.Name
+				}
+				reply.Matches = append(reply.Matches, matches...)
+
+				if truncated {
+					reply.Truncated = true
+					return nil
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 // stream is is used to stream the contents of file in an allocation's
 // directory.
 func (f *FileSystem) stream(conn io.ReadWriteCloser) {
@@ -428,16 +563,32 @@ func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 		return
 	}
 
+	var filter *logFilter
+	if req.Filter != "" || !req.Since.IsZero() {
+		filter, err = newLogFilter(req.Filter, req.Since, fs)
+		if err != nil {
+			handleStreamResultError(err, helper.Int64ToPtr(400), encoder)
+			return
+		}
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	frames := make(chan *sframer.StreamFrame, streamFramesBuffer)
 	errCh := make(chan error)
 
+	// Filtered logs are streamed through the filter
+	logFrames := frames
+	if filter != nil {
+		logFrames = make(chan *sframer.StreamFrame, streamFramesBuffer)
+		go filter.run(ctx, logFrames, frames)
+	}
+
 	// Start streaming
 	go func() {
 		if err := f.logsImpl(ctx, req.Follow, req.PlainText,
-			req.Offset, req.Origin, req.Task, req.LogType, fs, frames); err != nil {
+			req.Offset, req.Origin, req.Task, req.LogType, fs, logFrames); err != nil {
 			select {
 			case errCh <- err:
 			case <-ctx.Done():
@@ -533,6 +684,10 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 	// Path to the logs
 	logPath := filepath.Join(allocdir.SharedAllocName, allocdir.LogDirName)
 
+	// uncompressed caches the uncompressed size of compressed log files
+	base := fmt.Sprintf("%s.%s", task, logType)
+	uncompressed := make(map[string]int64)
+
 	// nextIdx is the next index to read logs from
 	var nextIdx int64
 	switch origin {
@@ -568,11 +723,19 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 			maxIndex = idx
 		}
 
+		// Offsets are in the uncompressed content of compressed files
+		if offset != 0 {
+			entries = uncompressedSizes(fs, logPath, base, entries, uncompressed)
+		}
+
 		logEntry, idx, openOffset, err := findClosest(entries, nextIdx, offset, task, logType)
 		if err != nil {
 			return err
 		}
 
+		// Compressed files are rotated files that are never written to
+		_, compression, _ := logging.LogFileIndex(logEntry.Name, base)
+
 		var eofCancelCh chan error
 		cancelAfterFirstEof := false
 		exitAfter := false
@@ -583,12 +746,16 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 			// At the end
 			cancelAfterFirstEof = true
 			exitAfter = true
-		} else {
+		} else if compression == "" {
 			eofCancelCh = blockUntilNextLog(ctx, fs, logPath, task, logType, idx+1)
 		}
 
 		p := filepath.Join(logPath, logEntry.Name)
-		err = f.streamFile(ctx, openOffset, p, 0, fs, framer, eofCancelCh, cancelAfterFirstEof)
+		if compression != "" {
+			err = f.streamCompressedFile(ctx, openOffset, p, compression, fs, framer)
+		} else {
+			err = f.streamFile(ctx, openOffset, p, 0, fs, framer, eofCancelCh, cancelAfterFirstEof)
+		}
 
 		// Check if the context is cancelled
 		select {
@@ -778,6 +945,168 @@ OUTER:
 	}
 }
 
+// streamCompressedFile streams the decompressed content of a compressed log
+// file from the offset. Compressed files are never written to, so the stream
+// ends at the end of the file.
+func (f *FileSystem) streamCompressedFile(ctx context.Context, offset int64, path, compression string,
+	fs allocdir.AllocDirFS, framer *sframer.StreamFramer) error {
+
+	file, err := fs.ReadAt(path, 0)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	r, err := logging.NewDecompressor(file, compression)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if offset > 0 {
+		if _, err := io.CopyN(ioutil.Discard, r, offset); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+
+	data := make([]byte, streamFrameSize)
+	for {
+		n, readErr := r.Read(data)
+		offset += int64(n)
+		if readErr != nil && readErr != io.EOF {
+			return readErr
+		}
+
+		if n != 0 {
+			if err := framer.Send(path, "", data[:n], offset); err != nil {
+				return parseFramerErr(err)
+			}
+		}
+
+		if readErr == io.EOF {
+			return nil
+		}
+
+		select {
+		case <-framer.ExitCh():
+			return nil
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+	}
+}
+
+// uncompressedSizes returns the entries with the sizes of compressed log
+// files replaced by the size of their content, so that offsets can be found
+// across compressed and uncompressed files. Sizes are cached by file name.
+func uncompressedSizes(fs allocdir.AllocDirFS, logPath, base string, entries []*cstructs.AllocFileInfo,
+	cache map[string]int64) []*cstructs.AllocFileInfo {
+
+	out := make([]*cstructs.AllocFileInfo, len(entries))
+	for i, entry := range entries {
+		out[i] = entry
+		_, compression, _ := logging.LogFileIndex(entry.Name, base)
+		if entry.IsDir || compression == "" {
+			continue
+		}
+
+		size, ok := cache[entry.Name]
+		if !ok {
+			var err error
+			size, err = uncompressedSize(fs, filepath.Join(logPath, entry.Name), compression)
+			if err != nil {
+				continue
+			}
+			cache[entry.Name] = size
+		}
+
+		e := *entry
+		e.Size = size
+		out[i] = &e
+	}
+	return out
+}
+
+func uncompressedSize(fs allocdir.AllocDirFS, path, compression string) (int64, error) {
+	file, err := fs.ReadAt(path, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	r, err := logging.NewDecompressor(file, compression)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+	return io.Copy(ioutil.Discard, r)
+}
+
+// searchLogFile returns the lines of a log file matching re, with their line
+// number, up to limit lines. Lines longer than maxLogSearchLineSize are
+// truncated. It returns true if there were more matching lines than the limit.
+func searchLogFile(fs allocdir.AllocDirFS, path, compression string, re *regexp.Regexp,
+	limit int) ([]*cstructs.LogMatch, bool, error) {
+
+	file, err := fs.ReadAt(path, 0)
+	if err != nil {
+		return nil, false, err
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	if compression != "" {
+		d, err := logging.NewDecompressor(file, compression)
+		if err != nil {
+			return nil, false, err
+		}
+		defer d.Close()
+		r = d
+	}
+
+	var matches []*cstructs.LogMatch
+	br := bufio.NewReaderSize(r, streamFrameSize)
+	line := make([]byte, 0, streamFrameSize)
+	for n := 1; ; n++ {
+		line = line[:0]
+		var readErr error
+		for {
+			var frag []byte
+			var isPrefix bool
+			frag, isPrefix, readErr = br.ReadLine()
+			if len(line) < maxLogSearchLineSize {
+				line = append(line, frag...)
+			}
+			if !isPrefix || readErr != nil {
+				break
+			}
+		}
+		if readErr == io.EOF {
+			return matches, false, nil
+		} else if readErr != nil {
+			return nil, false, readErr
+		}
+
+		if len(line) > maxLogSearchLineSize {
+			line = line[:maxLogSearchLineSize]
+		}
+		if !re.Match(line) {
+			continue
+		}
+		if len(matches) == limit {
+			return matches, true, nil
+		}
+		matches = append(matches, &cstructs.LogMatch{
+			Line: n,
+			Text: string(line),
+		})
+	}
+}
+
 // blockUntilNextLog returns a channel that will have data sent when the next
 // log index or anything greater is created.
 func blockUntilNextLog(ctx context.Context, fs allocdir.AllocDirFS, logPath, task, logType string, nextIndex int64) chan error {
@@ -854,7 +1183,9 @@ func (a indexTupleArray) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 // error is returned.
 func logIndexes(entries []*cstructs.AllocFileInfo, task, logType string) (indexTupleArray, error) {
 	var indexes []indexTuple
-	prefix := fmt.Sprintf("%s.%s.", task, logType)
+	base := fmt.Sprintf("%s.%s", task, logType)
+	prefix := base + "."
+	seen := make(map[int]int)
 	for _, entry := range entries {
 		if entry.IsDir {
 			continue
@@ -866,11 +1197,27 @@ func logIndexes(entries []*cstructs.AllocFileInfo, task, logType string) (indexT
 			continue
 		}
 
+		// Skip the temporary files of log files being compressed
+		if strings.HasSuffix(idxStr, logging.CompressingSuffix) {
+			continue
+		}
+
 		// Convert to an int
-		idx, err := strconv.Atoi(idxStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert %q to a log index: %v", idxStr, err)
+		idx, compression, ok := logging.LogFileIndex(entry.Name, base)
+		if !ok {
+			return nil, fmt.Errorf("failed to convert %q to a log index", idxStr)
+		}
+
+		// A log file is briefly stored both compressed and uncompressed
+		// while it is being compressed. Prefer the uncompressed file, which
+		// may be being streamed.
+		if i, ok := seen[idx]; ok {
+			if compression == "" {
+				indexes[i].entry = entry
+			}
+			continue
 		}
+		seen[idx] = len(indexes)
 
 		indexes = append(indexes, indexTuple{idx: int64(idx), entry: entry})
 	}
@@ -995,3 +1342,166 @@ func parseFramerErr(err error) error {
 
 	return err
 }
+
+// logFilter filters the lines of a log stream by a bexpr expression over the
+// fields of JSON records, and by the time lines were written.
+type logFilter struct {
+	evaluator *bexpr.Evaluator
+	since     time.Time
+	fs        allocdir.AllocDirFS
+
+	// file is the log file being streamed and buf the partial line read
+	// from it
+	file string
+	buf  []byte
+
+	// fileBefore is whether the file was last written to before since, in
+	// which case all its lines are older than since
+	fileBefore bool
+}
+
+func newLogFilter(filter string, since time.Time, fs allocdir.AllocDirFS) (*logFilter, error) {
+	lf := &logFilter{
+		since: since,
+		fs:    fs,
+	}
+	if filter != "" {
+		// Records missing a field referenced by the expression are
+		// evaluated as if the field were empty
+		evaluator, err := bexpr.CreateEvaluator(filter, bexpr.WithUnknownValue(""))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read filter expression: %v", err)
+		}
+		lf.evaluator = evaluator
+	}
+	return lf, nil
+}
+
+// run filters the frames of in and sends the ones with matching lines to
+// out. out is closed once in is.
+func (lf *logFilter) run(ctx context.Context, in <-chan *sframer.StreamFrame, out chan<- *sframer.StreamFrame) {
+	defer close(out)
+
+	var last *sframer.StreamFrame
+	for {
+		var frame *sframer.StreamFrame
+		var ok bool
+		select {
+		case frame, ok = <-in:
+		case <-ctx.Done():
+			return
+		}
+
+		if !ok {
+			// Send the last line if it isn't terminated by a newline
+			if last != nil && len(lf.buf) > 0 {
+				data := lf.filter(lf.buf)
+				lf.buf = nil
+				if len(data) > 0 {
+					select {
+					case out <- &sframer.StreamFrame{File: last.File, Offset: last.Offset, Data: data}:
+					case <-ctx.Done():
+					}
+				}
+			}
+			return
+		}
+
+		if !frame.IsHeartbeat() {
+			last = frame
+			frame = lf.filterFrame(frame)
+			if frame == nil {
+				continue
+			}
+		}
+
+		select {
+		case out <- frame:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// filterFrame returns a frame with the matching complete lines of the frame,
+// or nil if there are none.
+func (lf *logFilter) filterFrame(frame *sframer.StreamFrame) *sframer.StreamFrame {
+	if frame.File != "" && frame.File != lf.file {
+		lf.file = frame.File
+		lf.buf = nil
+		lf.fileBefore = false
+		if !lf.since.IsZero() {
+			if info, err := lf.fs.Stat(frame.File); err == nil {
+				lf.fileBefore = info.ModTime.Before(lf.since)
+			}
+		}
+	}
+
+	data := frame.Data
+	if len(lf.buf) > 0 {
+		data = append(lf.buf, data...)
+	}
+
+	var filtered []byte
+	if i := bytes.LastIndexByte(data, '\n'); i >= 0 {
+		filtered = lf.filter(data[:i+1])
+		lf.buf = append([]byte(nil), data[i+1:]...)
+	} else {
+		lf.buf = append([]byte(nil), data...)
+	}
+
+	if len(filtered) == 0 && frame.FileEvent == "" {
+		return nil
+	}
+	return &sframer.StreamFrame{
+		Offset:    frame.Offset,
+		Data:      filtered,
+		File:      frame.File,
+		FileEvent: frame.FileEvent,
+	}
+}
+
+// filter returns the matching lines of data.
+func (lf *logFilter) filter(data []byte) []byte {
+	var filtered []byte
+	for len(data) > 0 {
+		line := data
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line = data[:i+1]
+		}
+		data = data[len(line):]
+
+		if lf.match(line) {
+			filtered = append(filtered, line...)
+		}
+	}
+	return filtered
+}
+
+// match returns whether the line matches the filter. Lines that aren't JSON
+// records are matched as records with a message field holding the line.
+func (lf *logFilter) match(line []byte) bool {
+	record := logging.ParseRecord(line)
+
+	if !lf.since.IsZero() {
+		if t, ok := logging.RecordTime(record); ok {
+			if t.Before(lf.since) {
+				return false
+			}
+		} else if lf.fileBefore {
+			return false
+		}
+	}
+
+	if lf.evaluator == nil {
+		return true
+	}
+	if record == nil {
+		record = map[string]interface{}{
+			"message": string(bytes.TrimRight(line, "\r\n")),
+		}
+	}
+
+	match, err := lf.evaluator.Evaluate(record)
+	return err == nil && match
+}
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
@@ -10,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"runtime"
 	"strings"
 	"sync"
@@ -21,6 +24,7 @@ import (
 	"github.com/hashicorp/nomad/client/allocdir"
 	"github.com/hashicorp/nomad/client/config"
 	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
+	"github.com/hashicorp/nomad/client/logmon/logging"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/helper/uuid"
@@ -28,6 +32,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/require"
 )
 
@@ -1997,3 +2002,173 @@ func TestFS_logsImpl_Follow(t *testing.T) {
 		t.Fatalf("did not receive data: got %q", string(received))
 	}
 }
+
+func TestFS_logsImpl_Compressed(t *testing.T) {
+	t.Parallel()
+
+	c, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	// Get a temp alloc dir and create the log dir
+	ad := tempAllocDir(t)
+	require.NoError(t, ad.Build())
+	defer ad.Destroy()
+
+	logDir := filepath.Join(ad.SharedDir, allocdir.LogDirName)
+	require.NoError(t, os.MkdirAll(logDir, 0777))
+
+	writeFile := func(name, data string) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(logDir, name), []byte(data), 0777))
+	}
+	writeGzip := func(name, data string) {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		w.Write([]byte(data))
+		require.NoError(t, w.Close())
+		writeFile(name, buf.String())
+	}
+
+	// Rotated files are compressed, the file being compressed is also
+	// stored uncompressed and the temporary compressed file is ignored
+	writeGzip("foo.stdout.0.gz", "012")
+	zw, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	writeFile("foo.stdout.1.zst", string(zw.EncodeAll([]byte("345"), nil)))
+	writeGzip("foo.stdout.2.gz", "678")
+	writeFile("foo.stdout.2", "678")
+	writeFile("foo.stdout.2.gz.tmp", "garbage")
+	writeFile("foo.stdout.3", "9")
+
+	cases := []struct {
+		name     string
+		origin   string
+		offset   int64
+		expected string
+	}{
+		{"start", OriginStart, 0, "0123456789"},
+		{"start offset", OriginStart, 4, "456789"},
+		{"end offset", OriginEnd, 8, "23456789"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			frames := make(chan *sframer.StreamFrame, 32)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			require.NoError(t, c.endpoints.FileSystem.logsImpl(
+				ctx, false, false, tc.offset,
+				tc.origin, "foo", "stdout", ad, frames))
+
+			// The frames are closed once the framer is destroyed
+			var received []byte
+			for frame := range frames {
+				received = append(received, frame.Data...)
+			}
+			require.Equal(t, tc.expected, string(received))
+		})
+	}
+}
+
+func TestFS_searchLogFile(t *testing.T) {
+	t.Parallel()
+
+	ad := tempAllocDir(t)
+	require.NoError(t, ad.Build())
+	defer ad.Destroy()
+
+	logDir := filepath.Join(ad.SharedDir, allocdir.LogDirName)
+	require.NoError(t, os.MkdirAll(logDir, 0777))
+
+	long := strings.Repeat("x", maxLogSearchLineSize+100)
+	content := "GET /health 200\nGET /api 500\n" + long + " 500\nPOST /api 500"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(logDir, "web.stdout.0"), []byte(content), 0777))
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write([]byte(content))
+	require.NoError(t, w.Close())
+	require.NoError(t, ioutil.WriteFile(filepath.Join(logDir, "web.stdout.1.gz"), buf.Bytes(), 0777))
+
+	re := regexp.MustCompile(`(?:^GET|x) `)
+	for _, name := range []string{"web.stdout.0", "web.stdout.1.gz"} {
+		t.Run(name, func(t *testing.T) {
+			_, compression, _ := logging.LogFileIndex(name, "web.stdout")
+			p := filepath.Join(allocdir.SharedAllocName, allocdir.LogDirName, name)
+
+			// Long lines are truncated before being matched
+			matches, truncated, err := searchLogFile(ad, p, compression, re, 10)
+			require.NoError(t, err)
+			require.False(t, truncated)
+			require.Len(t, matches, 2)
+			require.Equal(t, &cstructs.LogMatch{Line: 1, Text: "GET /health 200"}, matches[0])
+			require.Equal(t, &cstructs.LogMatch{Line: 2, Text: "GET /api 500"}, matches[1])
+
+			// The last line doesn't need a newline
+			matches, truncated, err = searchLogFile(ad, p, compression, regexp.MustCompile(`500$`), 10)
+			require.NoError(t, err)
+			require.False(t, truncated)
+			require.Len(t, matches, 2)
+			require.Equal(t, 4, matches[1].Line)
+
+			matches, truncated, err = searchLogFile(ad, p, compression, regexp.MustCompile(`/api`), 1)
+			require.NoError(t, err)
+			require.True(t, truncated)
+			require.Len(t, matches, 1)
+		})
+	}
+}
+
+func TestFS_logFilter(t *testing.T) {
+	t.Parallel()
+
+	ad := tempAllocDir(t)
+	require.NoError(t, ad.Build())
+	defer ad.Destroy()
+
+	logDir := filepath.Join(ad.SharedDir, allocdir.LogDirName)
+	require.NoError(t, os.MkdirAll(logDir, 0777))
+
+	// The first log file was last written to before since
+	now := time.Now()
+	since := now.Add(-time.Hour)
+	old := now.Add(-2 * time.Hour)
+	for i := 0; i < 2; i++ {
+		p := filepath.Join(logDir, fmt.Sprintf("foo.stdout.%d", i))
+		require.NoError(t, ioutil.WriteFile(p, nil, 0666))
+	}
+	require.NoError(t, os.Chtimes(filepath.Join(logDir, "foo.stdout.0"), old, old))
+
+	filter, err := newLogFilter(`level == "error" or message contains "panic"`, since, ad)
+	require.NoError(t, err)
+
+	oldRecord := fmt.Sprintf(`{"level":"error","nomad":{"time":%q}}`, old.Format(time.RFC3339Nano))
+	newRecord := fmt.Sprintf(`{"level":"error","nomad":{"time":%q}}`, now.Format(time.RFC3339Nano))
+	in := make(chan *sframer.StreamFrame, 10)
+	in <- &sframer.StreamFrame{File: "alloc/logs/foo.stdout.0", Data: []byte("panic: old\n" + newRecord + "\n")}
+	in <- &sframer.StreamFrame{}
+	in <- &sframer.StreamFrame{File: "alloc/logs/foo.stdout.1", Data: []byte(oldRecord + "\npanic: ne")}
+	in <- &sframer.StreamFrame{File: "alloc/logs/foo.stdout.1", Data: []byte("w\n{\"level\":\"info\"}\n" + newRecord[:10])}
+	in <- &sframer.StreamFrame{File: "alloc/logs/foo.stdout.1", Data: []byte(newRecord[10:] + "\n{\"msg\":\"no level\"}\npanic")}
+	close(in)
+
+	out := make(chan *sframer.StreamFrame, 10)
+	filter.run(context.Background(), in, out)
+
+	var received []byte
+	var heartbeats int
+	for frame := range out {
+		if frame.IsHeartbeat() {
+			heartbeats++
+		}
+		received = append(received, frame.Data...)
+	}
+
+	// Lines of files written to before since are filtered out unless they
+	// are JSON records with a time
+	require.Equal(t, newRecord+"\npanic: new\n"+newRecord+"\npanic", string(received))
+	require.Equal(t, 1, heartbeats)
+
+	_, err = newLogFilter(`level ==`, time.Time{}, ad)
+	require.Error(t, err)
+}
@@ -3,6 +3,10 @@
 
 package cgutil
 
+import (
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
 const (
 	DefaultCgroupParent = ""
 )
@@ -12,3 +16,22 @@ const (
 func FindCgroupMountpointDir() (string, error) {
 	return "", nil
 }
+
+// AllocCgroupParent returns the cgroup under which the tasks of an allocation
+// sharing a CPU and memory ceiling are nested. Here it is a no-op
+// implementation.
+func AllocCgroupParent(_ string, _ *structs.Allocation) string {
+	return ""
+}
+
+// CreateAllocCgroup creates the cgroup of an allocation. Here it is a no-op
+// implementation.
+func CreateAllocCgroup(_ string, _, _ int64) error {
+	return nil
+}
+
+// DestroyAllocCgroup removes the cgroup of an allocation. Here it is a no-op
+// implementation.
+func DestroyAllocCgroup(_ string) error {
+	return nil
+}
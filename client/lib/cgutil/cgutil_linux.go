@@ -3,13 +3,17 @@ package cgutil
 import (
 	"os"
 	"path/filepath"
+	"strings"
 
 	cgroupFs "github.com/opencontainers/runc/libcontainer/cgroups/fs"
+	"github.com/opencontainers/runc/libcontainer/cgroups/fs2"
 
 	"github.com/opencontainers/runc/libcontainer/cgroups"
 	"github.com/opencontainers/runc/libcontainer/cgroups/fscommon"
 	"github.com/opencontainers/runc/libcontainer/configs"
 	"golang.org/x/sys/unix"
+
+	"github.com/hashicorp/nomad/nomad/structs"
 )
 
 const (
@@ -127,3 +131,86 @@ func FindCgroupMountpointDir() (string, error) {
 	}
 	return mount[0].Mountpoint, nil
 }
+
+// AllocCgroupParent returns the cgroup, relative to the cgroup root, under
+// which the tasks of an allocation sharing the CPU shares and memory ceiling
+// of the group resources are nested. It returns an empty string if the tasks
+// of the allocation don't share them.
+func AllocCgroupParent(cgroupParent string, alloc *structs.Allocation) string {
+	if alloc.AllocatedResources == nil || alloc.AllocatedResources.Shared.Memory.MemoryMB == 0 {
+		return ""
+	}
+	if cgroupParent == "" {
+		cgroupParent = DefaultCgroupParent
+	}
+	return filepath.Join(cgroupParent, alloc.ID)
+}
+
+// CreateAllocCgroup creates the cgroup of an allocation and sets on it the
+// CPU shares and memory limit shared by its tasks. It is safe to call again
+// on an existing cgroup.
+func CreateAllocCgroup(cgroup string, cpuShares, memoryMB int64) error {
+	r := &configs.Resources{
+		CpuShares:   uint64(cpuShares),
+		CpuWeight:   cgroups.ConvertCPUSharesToCgroupV2Value(uint64(cpuShares)),
+		Memory:      memoryMB * 1024 * 1024,
+		SkipDevices: true,
+	}
+	man, err := newAllocCgroupManager(cgroup, r)
+	if err != nil {
+		return err
+	}
+	if err := man.Apply(-1); err != nil {
+		return err
+	}
+
+	// The limit of the allocation only applies to its tasks if the memory
+	// hierarchy is enabled, which older kernels don't default to
+	if !cgroups.IsCgroup2UnifiedMode() {
+		if err := ensureMemoryHierarchy(man.Path("memory")); err != nil {
+			return err
+		}
+	}
+
+	return man.Set(r)
+}
+
+// DestroyAllocCgroup removes the cgroup of an allocation once its tasks have
+// exited.
+func DestroyAllocCgroup(cgroup string) error {
+	man, err := newAllocCgroupManager(cgroup, &configs.Resources{SkipDevices: true})
+	if err != nil {
+		return err
+	}
+
+	// Apply without a process resolves the paths of the cgroup to remove
+	if err := man.Apply(-1); err != nil {
+		return err
+	}
+	return man.Destroy()
+}
+
+func newAllocCgroupManager(cgroup string, r *configs.Resources) (cgroups.Manager, error) {
+	cfg := &configs.Cgroup{
+		Path:      cgroup,
+		Resources: r,
+	}
+	if cgroups.IsCgroup2UnifiedMode() {
+		return fs2.NewManager(cfg, "", false)
+	}
+	return cgroupFs.NewManager(cfg, nil, false), nil
+}
+
+func ensureMemoryHierarchy(path string) error {
+	if path == "" {
+		return nil
+	}
+	enabled, err := fscommon.ReadFile(path, "memory.use_hierarchy")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(enabled) == "1" {
+		return nil
+	}
+	return fscommon.WriteFile(path, "memory.use_hierarchy", "1")
+}
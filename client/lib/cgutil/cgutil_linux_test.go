@@ -0,0 +1,66 @@
+package cgutil
+
+import (
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/cgroups/fscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocCgroup(t *testing.T) {
+	if syscall.Geteuid() != 0 {
+		t.Skip("Test only available running as root on linux")
+	}
+	mount, err := FindCgroupMountpointDir()
+	if err != nil || mount == "" {
+		t.Skipf("Failed to find cgroup mount: %v %v", mount, err)
+	}
+
+	parent := "/gotest-" + uuid.Short()
+	defer func() { require.NoError(t, DestroyAllocCgroup(parent)) }()
+
+	// Allocations without group resources have no cgroup
+	alloc := mock.Alloc()
+	require.Empty(t, AllocCgroupParent(parent, alloc))
+
+	alloc.AllocatedResources.Shared.Cpu.CpuShares = 512
+	alloc.AllocatedResources.Shared.Memory.MemoryMB = 256
+	cgroup := AllocCgroupParent(parent, alloc)
+	require.Equal(t, filepath.Join(parent, alloc.ID), cgroup)
+
+	require.NoError(t, CreateAllocCgroup(cgroup, 512, 256))
+
+	// Creating the cgroup again updates it
+	require.NoError(t, CreateAllocCgroup(cgroup, 1024, 512))
+
+	cgroupPath := func(subsystem string) string {
+		if cgroups.IsCgroup2UnifiedMode() {
+			return filepath.Join(mount, cgroup)
+		}
+		path, err := getCgroupPathHelper(subsystem, cgroup)
+		require.NoError(t, err)
+		return path
+	}
+	readFile := func(subsystem, file string) string {
+		v, err := fscommon.ReadFile(cgroupPath(subsystem), file)
+		require.NoError(t, err)
+		return strings.TrimSpace(v)
+	}
+
+	if cgroups.IsCgroup2UnifiedMode() {
+		require.Equal(t, "536870912", readFile("memory", "memory.max"))
+	} else {
+		require.Equal(t, "536870912", readFile("memory", "memory.limit_in_bytes"))
+		require.Equal(t, "1", readFile("memory", "memory.use_hierarchy"))
+		require.Equal(t, "1024", readFile("cpu", "cpu.shares"))
+	}
+
+	require.NoError(t, DestroyAllocCgroup(cgroup))
+	require.NoDirExists(t, cgroupPath("memory"))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/hashicorp/nomad/client/logmon/logging"
 	"github.com/hashicorp/nomad/client/logmon/proto"
 	"github.com/hashicorp/nomad/helper/pluginutils/grpcutils"
 )
@@ -26,6 +27,31 @@ func (c *logmonClient) Start(cfg *LogConfig) error {
 		MaxFileSizeMb:  uint32(cfg.MaxFileSizeMB),
 		StdoutFifo:     cfg.StdoutFifo,
 		StderrFifo:     cfg.StderrFifo,
+		Format:         cfg.Format,
+		Level:          cfg.Level,
+		RotateEveryNs:  cfg.RotateEvery.Nanoseconds(),
+		Compression:    cfg.Compression,
+		MaxTotalSizeMb: uint32(cfg.MaxTotalSizeMB),
+	}
+	for _, sc := range cfg.Sinks {
+		req.Sinks = append(req.Sinks, &proto.LogSink{
+			Type:         sc.Type,
+			Address:      sc.Address,
+			Facility:     sc.Facility,
+			Tag:          sc.Tag,
+			Header:       sc.Header,
+			BatchSize:    uint32(sc.BatchSize),
+			BatchWaitNs:  sc.BatchWait.Nanoseconds(),
+			BufferSize:   uint32(sc.BufferSize),
+			Backpressure: sc.Backpressure,
+		})
+	}
+	if task := cfg.Task; task != nil {
+		req.AllocId = task.AllocID
+		req.Namespace = task.Namespace
+		req.JobId = task.JobID
+		req.GroupName = task.GroupName
+		req.TaskName = task.TaskName
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), logmonRPCTimeout)
 	defer cancel()
@@ -42,3 +68,27 @@ func (c *logmonClient) Stop() error {
 	_, err := c.client.Stop(ctx, req)
 	return grpcutils.HandleGrpcErr(err, c.doneCtx)
 }
+
+func (c *logmonClient) Stats() ([]*logging.SinkStats, error) {
+	req := &proto.StatsRequest{}
+	ctx, cancel := context.WithTimeout(context.Background(), logmonRPCTimeout)
+	defer cancel()
+
+	resp, err := c.client.Stats(ctx, req)
+	if err != nil {
+		return nil, grpcutils.HandleGrpcErr(err, c.doneCtx)
+	}
+
+	stats := make([]*logging.SinkStats, 0, len(resp.Sinks))
+	for _, s := range resp.Sinks {
+		stats = append(stats, &logging.SinkStats{
+			Type:     s.Type,
+			Address:  s.Address,
+			Sent:     s.Sent,
+			Dropped:  s.Dropped,
+			Failed:   s.Failed,
+			Buffered: s.Buffered,
+		})
+	}
+	return stats, nil
+}
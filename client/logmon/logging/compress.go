@@ -0,0 +1,133 @@
+package logging
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	CompressionNone = "none"
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+
+	// CompressingSuffix is the suffix of the temporary file a rotated log
+	// file is compressed to before replacing it.
+	CompressingSuffix = ".tmp"
+)
+
+// compressionExts maps compression algorithms to the extension of the files
+// they compress.
+var compressionExts = map[string]string{
+	CompressionGzip: ".gz",
+	CompressionZstd: ".zst",
+}
+
+// LogFileIndex parses the name of a log file of the base, named
+// <base>.<index> and followed by an extension once compressed. It returns the
+// index and compression of the file, and false if the name isn't the one of
+// a log file of the base.
+func LogFileIndex(name, base string) (int, string, bool) {
+	idxStr := strings.TrimPrefix(name, base+".")
+	if idxStr == name {
+		return 0, "", false
+	}
+
+	compression := ""
+	for c, ext := range compressionExts {
+		if strings.HasSuffix(idxStr, ext) {
+			compression = c
+			idxStr = strings.TrimSuffix(idxStr, ext)
+			break
+		}
+	}
+
+	idx, err := strconv.Atoi(idxStr)
+	if err != nil || idx < 0 {
+		return 0, "", false
+	}
+	return idx, compression, true
+}
+
+// NewDecompressor returns a reader of the decompressed content of r.
+func NewDecompressor(r io.Reader, compression string) (io.ReadCloser, error) {
+	switch compression {
+	case CompressionGzip:
+		return gzip.NewReader(r)
+	case CompressionZstd:
+		d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unknown compression %q", compression)
+	}
+}
+
+// compressFile compresses the file at path, replacing it with a file named
+// after the compression. The compressed file is written to a temporary file
+// first so that it is never read partially written.
+func compressFile(path, compression string) error {
+	ext, ok := compressionExts[compression]
+	if !ok {
+		return fmt.Errorf("unknown compression %q", compression)
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ext + CompressingSuffix
+	dst, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpPath)
+	defer dst.Close()
+
+	var w io.WriteCloser
+	switch compression {
+	case CompressionGzip:
+		w = gzip.NewWriter(dst)
+	case CompressionZstd:
+		w, err = zstd.NewWriter(dst, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := io.Copy(w, src); err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if err := dst.Sync(); err != nil {
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+
+	// Keep the time of the last write, which log filters rely on
+	if err := os.Chtimes(tmpPath, info.ModTime(), info.ModTime()); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path+ext); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
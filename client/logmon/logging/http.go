@@ -0,0 +1,90 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
+)
+
+// httpRecord is the JSON object of each line of the requests of HTTP sinks.
+type httpRecord struct {
+	Time      time.Time `json:"time"`
+	Stream    string    `json:"stream"`
+	Message   string    `json:"message"`
+	AllocID   string    `json:"alloc_id"`
+	Namespace string    `json:"namespace"`
+	JobID     string    `json:"job_id"`
+	GroupName string    `json:"group_name"`
+	TaskName  string    `json:"task_name"`
+}
+
+// httpSender POSTs batches of messages as newline delimited JSON.
+type httpSender struct {
+	client  *http.Client
+	address string
+	header  map[string]string
+	task    *TaskInfo
+}
+
+func newHTTPSender(config *SinkConfig, task *TaskInfo) (*httpSender, error) {
+	client := cleanhttp.DefaultPooledClient()
+	client.Timeout = sinkDialTimeout
+
+	return &httpSender{
+		client:  client,
+		address: config.Address,
+		header:  config.Header,
+		task:    task,
+	}, nil
+}
+
+func (s *httpSender) send(msgs []*Message) (int, error) {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, m := range msgs {
+		if err := enc.Encode(&httpRecord{
+			Time:      m.Time,
+			Stream:    m.Stream,
+			Message:   string(m.Line),
+			AllocID:   s.task.AllocID,
+			Namespace: s.task.Namespace,
+			JobID:     s.task.JobID,
+			GroupName: s.task.GroupName,
+			TaskName:  s.task.TaskName,
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.address, &body)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	for k, v := range s.header {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return 0, fmt.Errorf("unexpected response code %d", resp.StatusCode)
+	}
+	return len(msgs), nil
+}
+
+func (s *httpSender) close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,99 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"strconv"
+	"time"
+)
+
+// defaultJournaldSocket is the socket of journald's native protocol.
+const defaultJournaldSocket = "/run/systemd/journal/socket"
+
+// journaldSender sends messages with journald's native protocol, tagging
+// them with the task's identity.
+type journaldSender struct {
+	addr   *net.UnixAddr
+	fields [][2]string
+	conn   *net.UnixConn
+}
+
+func newJournaldSender(config *SinkConfig, task *TaskInfo) (*journaldSender, error) {
+	path := config.Address
+	if path == "" {
+		path = defaultJournaldSocket
+	}
+
+	return &journaldSender{
+		addr: &net.UnixAddr{Name: path, Net: "unixgram"},
+		fields: [][2]string{
+			{"SYSLOG_IDENTIFIER", config.Tag},
+			{"NOMAD_ALLOC_ID", task.AllocID},
+			{"NOMAD_NAMESPACE", task.Namespace},
+			{"NOMAD_JOB_ID", task.JobID},
+			{"NOMAD_GROUP_NAME", task.GroupName},
+			{"NOMAD_TASK_NAME", task.TaskName},
+		},
+	}, nil
+}
+
+func (s *journaldSender) send(msgs []*Message) (int, error) {
+	if s.conn == nil {
+		conn, err := net.DialUnix("unixgram", nil, s.addr)
+		if err != nil {
+			return 0, err
+		}
+		s.conn = conn
+	}
+
+	for i, m := range msgs {
+		s.conn.SetWriteDeadline(time.Now().Add(sinkDialTimeout))
+		if _, err := s.conn.Write(s.format(m)); err != nil {
+			s.conn.Close()
+			s.conn = nil
+			return i, err
+		}
+	}
+	return len(msgs), nil
+}
+
+// format serializes the message's fields. Values containing newlines are
+// serialized with their length as required by the protocol.
+func (s *journaldSender) format(m *Message) []byte {
+	priority := syslogSeverityInfo
+	if m.Stream == "stderr" {
+		priority = syslogSeverityErr
+	}
+
+	var buf bytes.Buffer
+	writeField := func(k string, v []byte) {
+		buf.WriteString(k)
+		if bytes.IndexByte(v, '\n') < 0 {
+			buf.WriteByte('=')
+			buf.Write(v)
+		} else {
+			buf.WriteByte('\n')
+			binary.Write(&buf, binary.LittleEndian, uint64(len(v)))
+			buf.Write(v)
+		}
+		buf.WriteByte('\n')
+	}
+
+	writeField("MESSAGE", m.Line)
+	writeField("PRIORITY", []byte(strconv.Itoa(priority)))
+	writeField("NOMAD_STREAM", []byte(m.Stream))
+	for _, f := range s.fields {
+		if f[1] != "" {
+			writeField(f[0], []byte(f[1]))
+		}
+	}
+	return buf.Bytes()
+}
+
+func (s *journaldSender) close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
@@ -0,0 +1,283 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+
+	// RecordMetadataKey is the key of the object JSON records are tagged
+	// with, holding the time the line was written and the task's identity.
+	RecordMetadataKey = "nomad"
+
+	// maxRecordSize is the size at which a line being buffered is written
+	// without being parsed, so that a task writing garbage can't exhaust
+	// memory.
+	maxRecordSize = 1024 * 1024
+)
+
+// Log levels ordered by severity.
+const (
+	levelTrace = iota
+	levelDebug
+	levelInfo
+	levelWarn
+	levelError
+	levelFatal
+)
+
+// logLevels maps the level names of common logging libraries to their
+// severity.
+var logLevels = map[string]int{
+	"trace":    levelTrace,
+	"debug":    levelDebug,
+	"info":     levelInfo,
+	"notice":   levelInfo,
+	"warn":     levelWarn,
+	"warning":  levelWarn,
+	"error":    levelError,
+	"err":      levelError,
+	"fatal":    levelFatal,
+	"panic":    levelFatal,
+	"critical": levelFatal,
+	"crit":     levelFatal,
+}
+
+// recordLevelKeys and recordTimeKeys are the keys the level and time of a
+// record are read from, in order of preference.
+var (
+	recordLevelKeys = []string{"level", "lvl", "severity", "@level"}
+	recordTimeKeys  = []string{"time", "timestamp", "ts", "@timestamp"}
+)
+
+// ParseLevel returns the severity of a level name, or of a numeric level as
+// used by bunyan and pino.
+func ParseLevel(level string) (int, error) {
+	if l, ok := logLevels[strings.ToLower(level)]; ok {
+		return l, nil
+	}
+	if n, err := strconv.Atoi(level); err == nil {
+		return numericLevel(float64(n)), nil
+	}
+	return 0, fmt.Errorf("unknown log level %q", level)
+}
+
+func numericLevel(n float64) int {
+	switch {
+	case n < 20:
+		return levelTrace
+	case n < 30:
+		return levelDebug
+	case n < 40:
+		return levelInfo
+	case n < 50:
+		return levelWarn
+	case n < 60:
+		return levelError
+	default:
+		return levelFatal
+	}
+}
+
+// RecordMetadata tags the JSON records written by a task.
+type RecordMetadata struct {
+	Time      time.Time `json:"time"`
+	Stream    string    `json:"stream"`
+	AllocID   string    `json:"alloc_id,omitempty"`
+	Namespace string    `json:"namespace,omitempty"`
+	JobID     string    `json:"job_id,omitempty"`
+	GroupName string    `json:"group_name,omitempty"`
+	TaskName  string    `json:"task_name,omitempty"`
+}
+
+// ParseRecord parses a line written by a task as a JSON object. It returns
+// nil if the line isn't a JSON object.
+func ParseRecord(line []byte) map[string]interface{} {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 || line[0] != '{' {
+		return nil
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(line, &record); err != nil {
+		return nil
+	}
+	return record
+}
+
+// RecordLevel returns the severity of a record, if it has a known level.
+func RecordLevel(record map[string]interface{}) (int, bool) {
+	for _, k := range recordLevelKeys {
+		switch v := record[k].(type) {
+		case string:
+			if l, err := ParseLevel(v); err == nil {
+				return l, true
+			}
+		case float64:
+			return numericLevel(v), true
+		}
+	}
+	return 0, false
+}
+
+// RecordTime returns the time a record was written, preferring the time it
+// was tagged with by logmon over the ones set by the task.
+func RecordTime(record map[string]interface{}) (time.Time, bool) {
+	if meta, ok := record[RecordMetadataKey].(map[string]interface{}); ok {
+		if t, ok := parseRecordTime(meta["time"]); ok {
+			return t, true
+		}
+	}
+	for _, k := range recordTimeKeys {
+		if t, ok := parseRecordTime(record[k]); ok {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseRecordTime parses RFC3339 times and Unix times in seconds or
+// milliseconds.
+func parseRecordTime(v interface{}) (time.Time, bool) {
+	switch t := v.(type) {
+	case string:
+		parsed, err := time.Parse(time.RFC3339Nano, t)
+		return parsed, err == nil
+	case float64:
+		// Times after 2286 in seconds are assumed to be in milliseconds
+		if t > 1e10 {
+			return time.Unix(0, int64(t*float64(time.Millisecond))), true
+		}
+		return time.Unix(0, int64(t*float64(time.Second))), true
+	}
+	return time.Time{}, false
+}
+
+// JSONWriter parses the lines written by a task as JSON records, drops the
+// records below a level and tags the others with the task's identity before
+// writing them. Lines that aren't JSON objects are written unmodified.
+type JSONWriter struct {
+	w        io.WriteCloser
+	stream   string
+	task     *TaskInfo
+	minLevel int
+	filter   bool
+	buf      []byte
+}
+
+// NewJSONWriter returns a writer of the records of the stream, stdout or
+// stderr, to w. Records below minLevel are dropped unless it is empty.
+func NewJSONWriter(w io.WriteCloser, stream string, task *TaskInfo, minLevel string) (*JSONWriter, error) {
+	jw := &JSONWriter{
+		w:      w,
+		stream: stream,
+		task:   task,
+	}
+	if minLevel != "" {
+		l, err := ParseLevel(minLevel)
+		if err != nil {
+			return nil, err
+		}
+		jw.minLevel = l
+		jw.filter = true
+	}
+	return jw, nil
+}
+
+// Write processes the complete lines of p and buffers the last one if it
+// isn't terminated by a newline.
+func (w *JSONWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, newLineDelimiter)
+		if i < 0 {
+			w.buf = append(w.buf, p...)
+			break
+		}
+
+		line := p[:i+1]
+		if len(w.buf) > 0 {
+			w.buf = append(w.buf, line...)
+			line = w.buf
+		}
+		if err := w.writeLine(line); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[:0]
+		p = p[i+1:]
+	}
+
+	if len(w.buf) > maxRecordSize {
+		_, err := w.w.Write(w.buf)
+		w.buf = w.buf[:0]
+		if err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+// Close writes the last line if it isn't terminated by a newline and closes
+// the underlying writer.
+func (w *JSONWriter) Close() error {
+	if len(w.buf) > 0 {
+		w.writeLine(w.buf)
+		w.buf = nil
+	}
+	return w.w.Close()
+}
+
+func (w *JSONWriter) writeLine(line []byte) error {
+	record := ParseRecord(line)
+	if record == nil {
+		_, err := w.w.Write(line)
+		return err
+	}
+
+	if w.filter {
+		if l, ok := RecordLevel(record); ok && l < w.minLevel {
+			return nil
+		}
+	}
+
+	// Records that already have the key are written unmodified
+	if _, ok := record[RecordMetadataKey]; ok {
+		_, err := w.w.Write(line)
+		return err
+	}
+
+	meta, err := json.Marshal(&RecordMetadata{
+		Time:      time.Now().UTC(),
+		Stream:    w.stream,
+		AllocID:   w.task.AllocID,
+		Namespace: w.task.Namespace,
+		JobID:     w.task.JobID,
+		GroupName: w.task.GroupName,
+		TaskName:  w.task.TaskName,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Append the metadata to the object rather than marshaling the record
+	// again, so that the order of the task's fields is kept
+	trimmed := bytes.TrimRight(line, " \t\r\n")
+	tagged := make([]byte, 0, len(line)+len(meta)+16)
+	tagged = append(tagged, trimmed[:len(trimmed)-1]...)
+	if len(record) > 0 {
+		tagged = append(tagged, ',')
+	}
+	tagged = append(tagged, `"`+RecordMetadataKey+`":`...)
+	tagged = append(tagged, meta...)
+	tagged = append(tagged, '}', newLineDelimiter)
+	_, err = w.w.Write(tagged)
+	return err
+}
@@ -0,0 +1,113 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type nopCloser struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (n *nopCloser) Close() error {
+	n.closed = true
+	return nil
+}
+
+func TestJSONWriter(t *testing.T) {
+	var out nopCloser
+	w, err := NewJSONWriter(&out, "stdout", testTaskInfo, "info")
+	require.NoError(t, err)
+
+	w.Write([]byte(`{"level":"debug","msg":"dropped"}` + "\n"))
+	w.Write([]byte(`{"msg":"kept","level":"WARN","n":1}` + "\n" + `plain text`))
+	w.Write([]byte("\n" + `{"severity":20,"msg":"dropped too"}` + "\n"))
+	w.Write([]byte(`{"msg":"no level"}` + "\r\n" + `{}` + "\n"))
+	w.Write([]byte(`{"msg":"tagged","nomad":"already"}` + "\n"))
+	w.Write([]byte(`{"broken":` + "\n"))
+	w.Write([]byte(`{"msg":"partial"`))
+	require.NoError(t, w.Close())
+	require.True(t, out.closed)
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 7)
+
+	// Records keep the order of their fields and are tagged
+	require.True(t, strings.HasPrefix(lines[0], `{"msg":"kept","level":"WARN","n":1,"nomad":{`), lines[0])
+	var record struct {
+		Msg   string
+		Nomad RecordMetadata
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	require.Equal(t, "kept", record.Msg)
+	require.Equal(t, "stdout", record.Nomad.Stream)
+	require.Equal(t, testTaskInfo.AllocID, record.Nomad.AllocID)
+	require.Equal(t, "example", record.Nomad.JobID)
+	require.Equal(t, "cache", record.Nomad.GroupName)
+	require.Equal(t, "redis", record.Nomad.TaskName)
+	require.WithinDuration(t, time.Now(), record.Nomad.Time, time.Minute)
+
+	require.Equal(t, "plain text", lines[1])
+	require.True(t, strings.HasPrefix(lines[2], `{"msg":"no level","nomad":{`), lines[2])
+	require.True(t, strings.HasPrefix(lines[3], `{"nomad":{`), lines[3])
+	require.Equal(t, `{"msg":"tagged","nomad":"already"}`, lines[4])
+	require.Equal(t, `{"broken":`, lines[5])
+	require.Equal(t, `{"msg":"partial"`, lines[6])
+}
+
+func TestJSONWriter_InvalidLevel(t *testing.T) {
+	_, err := NewJSONWriter(&nopCloser{}, "stdout", testTaskInfo, "loud")
+	require.EqualError(t, err, `unknown log level "loud"`)
+}
+
+func TestRecordLevel(t *testing.T) {
+	cases := []struct {
+		line  string
+		level int
+		ok    bool
+	}{
+		{`{"level":"info"}`, levelInfo, true},
+		{`{"lvl":"eror","severity":"Error"}`, levelError, true},
+		{`{"level":50}`, levelError, true},
+		{`{"@level":"trace"}`, levelTrace, true},
+		{`{"level":true}`, 0, false},
+		{`{"msg":"none"}`, 0, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.line, func(t *testing.T) {
+			level, ok := RecordLevel(ParseRecord([]byte(c.line)))
+			require.Equal(t, c.ok, ok)
+			require.Equal(t, c.level, level)
+		})
+	}
+}
+
+func TestRecordTime(t *testing.T) {
+	expected := time.Date(2021, 11, 10, 16, 4, 5, 0, time.UTC)
+
+	cases := []string{
+		`{"nomad":{"time":"2021-11-10T16:04:05Z"},"time":"2000-01-01T00:00:00Z"}`,
+		`{"time":"2021-11-10T17:04:05+01:00"}`,
+		`{"ts":1636560245}`,
+		`{"timestamp":1636560245000}`,
+	}
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			ts, ok := RecordTime(ParseRecord([]byte(c)))
+			require.True(t, ok)
+			require.True(t, expected.Equal(ts), "expected %v, got %v", expected, ts)
+		})
+	}
+
+	_, ok := RecordTime(ParseRecord([]byte(`{"time":"yesterday"}`)))
+	require.False(t, ok)
+	_, ok = RecordTime(nil)
+	require.False(t, ok)
+}
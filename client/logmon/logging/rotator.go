@@ -8,7 +8,6 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -33,20 +32,40 @@ const (
 	newLineDelimiter = '\n'
 )
 
+// FileRotatorConfig configures the optional behaviours of a FileRotator.
+type FileRotatorConfig struct {
+	// RotateEvery is the age at which a file is rotated regardless of its
+	// size. Files are rotated on the first write after they reach it.
+	RotateEvery time.Duration
+
+	// Compression is the algorithm rotated files are compressed with
+	Compression string
+
+	// MaxTotalSize caps the size of all the files on disk, counting
+	// compressed files by their compressed size
+	MaxTotalSize int64
+}
+
 // FileRotator writes bytes to a rotated set of files
 type FileRotator struct {
 	MaxFiles int   // MaxFiles is the maximum number of rotated files allowed in a path
 	FileSize int64 // FileSize is the size a rotated file is allowed to grow
 
+	rotateEvery  time.Duration // rotateEvery is the age at which a file is rotated
+	compression  string        // compression is the algorithm rotated files are compressed with
+	maxTotalSize int64         // maxTotalSize caps the size of all the files on disk
+
 	path             string // path is the path on the file system where the rotated set of files are opened
 	baseFileName     string // baseFileName is the base file name of the rotated files
 	logFileIdx       int    // logFileIdx is the current index of the rotated files
 	oldestLogFileIdx int    // oldestLogFileIdx is the index of the oldest log file in a path
 
-	currentFile *os.File // currentFile is the file that is currently getting written
-	currentWr   int64    // currentWr is the number of bytes written to the current file
-	bufw        *bufio.Writer
-	bufLock     sync.Mutex
+	currentFile   *os.File  // currentFile is the file that is currently getting written
+	currentWr     int64     // currentWr is the number of bytes written to the current file
+	currentOpened time.Time // currentOpened is when the current file was opened
+	currentIdx    int       // currentIdx is the index of the current file, guarded by bufLock
+	bufw          *bufio.Writer
+	bufLock       sync.Mutex
 
 	flushTicker *time.Ticker
 	logger      hclog.Logger
@@ -60,11 +79,32 @@ type FileRotator struct {
 // NewFileRotator returns a new file rotator
 func NewFileRotator(path string, baseFile string, maxFiles int,
 	fileSize int64, logger hclog.Logger) (*FileRotator, error) {
+	return NewFileRotatorWithConfig(path, baseFile, maxFiles, fileSize, nil, logger)
+}
+
+// NewFileRotatorWithConfig returns a new file rotator rotating files by age
+// and compressing rotated files as configured.
+func NewFileRotatorWithConfig(path string, baseFile string, maxFiles int,
+	fileSize int64, config *FileRotatorConfig, logger hclog.Logger) (*FileRotator, error) {
+	if config == nil {
+		config = &FileRotatorConfig{}
+	}
+	if config.Compression == CompressionNone {
+		config.Compression = ""
+	}
+	if _, ok := compressionExts[config.Compression]; config.Compression != "" && !ok {
+		return nil, fmt.Errorf("unknown compression %q", config.Compression)
+	}
+
 	logger = logger.Named("rotator")
 	rotator := &FileRotator{
 		MaxFiles: maxFiles,
 		FileSize: fileSize,
 
+		rotateEvery:  config.RotateEvery,
+		compression:  config.Compression,
+		maxTotalSize: config.MaxTotalSize,
+
 		path:         path,
 		baseFileName: baseFile,
 
@@ -77,6 +117,12 @@ func NewFileRotator(path string, baseFile string, maxFiles int,
 	if err := rotator.lastFile(); err != nil {
 		return nil, err
 	}
+
+	// Compress the files rotated before a restart
+	if rotator.compression != "" || rotator.maxTotalSize > 0 {
+		rotator.purgeCh <- struct{}{}
+	}
+
 	go rotator.purgeOldFiles()
 	go rotator.flushPeriodically()
 	return rotator, nil
@@ -91,7 +137,7 @@ func (f *FileRotator) Write(p []byte) (n int, err error) {
 	for n < len(p) {
 		// Check if we still have space in the current file, otherwise close and
 		// open the next file
-		if forceRotate || f.currentWr >= f.FileSize {
+		if forceRotate || f.currentWr >= f.FileSize || f.expired() {
 			forceRotate = false
 			f.flushBuffer()
 			f.currentFile.Close()
@@ -157,6 +203,12 @@ func (f *FileRotator) Write(p []byte) (n int, err error) {
 	return
 }
 
+// expired returns whether the current file has data and is older than the
+// rotation period.
+func (f *FileRotator) expired() bool {
+	return f.rotateEvery > 0 && f.currentWr > 0 && time.Since(f.currentOpened) >= f.rotateEvery
+}
+
 // nextFile opens the next file and purges older files if the number of rotated
 // files is larger than the maximum files configured by the user
 func (f *FileRotator) nextFile() error {
@@ -169,16 +221,22 @@ func (f *FileRotator) nextFile() error {
 				continue
 			}
 		}
+		if f.isCompressed(logFileName) {
+			continue
+		}
 		f.logFileIdx = nextFileIdx
 		if err := f.createFile(); err != nil {
 			return err
 		}
 		break
 	}
-	// Purge old files if we have more files than MaxFiles
+	// Purge old files if we have more files than MaxFiles, and compress
+	// the rotated file
 	f.closedLock.Lock()
 	defer f.closedLock.Unlock()
-	if f.logFileIdx-f.oldestLogFileIdx >= f.MaxFiles && !f.closed {
+	mustPurge := f.logFileIdx-f.oldestLogFileIdx >= f.MaxFiles ||
+		f.compression != "" || f.maxTotalSize > 0
+	if mustPurge && !f.closed {
 		select {
 		case f.purgeCh <- struct{}{}:
 		default:
@@ -187,6 +245,16 @@ func (f *FileRotator) nextFile() error {
 	return nil
 }
 
+// isCompressed returns whether a compressed version of the log file exists.
+func (f *FileRotator) isCompressed(logFileName string) bool {
+	for _, ext := range compressionExts {
+		if _, err := os.Stat(logFileName + ext); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 // lastFile finds out the rotated file with the largest index in a path.
 func (f *FileRotator) lastFile() error {
 	finfos, err := ioutil.ReadDir(f.path)
@@ -194,21 +262,24 @@ func (f *FileRotator) lastFile() error {
 		return err
 	}
 
-	prefix := fmt.Sprintf("%s.", f.baseFileName)
+	lastCompressed := false
 	for _, fi := range finfos {
 		if fi.IsDir() {
 			continue
 		}
-		if strings.HasPrefix(fi.Name(), prefix) {
-			fileIdx := strings.TrimPrefix(fi.Name(), prefix)
-			n, err := strconv.Atoi(fileIdx)
-			if err != nil {
-				continue
-			}
-			if n > f.logFileIdx {
-				f.logFileIdx = n
-			}
+		n, compression, ok := LogFileIndex(fi.Name(), f.baseFileName)
+		if !ok {
+			continue
 		}
+		if n > f.logFileIdx || (n == f.logFileIdx && compression != "") {
+			f.logFileIdx = n
+			lastCompressed = compression != ""
+		}
+	}
+
+	// Compressed files are never appended to
+	if lastCompressed {
+		f.logFileIdx++
 	}
 	if err := f.createFile(); err != nil {
 		return err
@@ -230,6 +301,10 @@ func (f *FileRotator) createFile() error {
 		return err
 	}
 	f.currentWr = fi.Size()
+	f.currentOpened = time.Now()
+	f.bufLock.Lock()
+	f.currentIdx = f.logFileIdx
+	f.bufLock.Unlock()
 	f.createOrResetBuffer()
 	return nil
 }
@@ -268,55 +343,123 @@ func (f *FileRotator) Close() error {
 	return nil
 }
 
-// purgeOldFiles removes older files and keeps only the last N files rotated for
-// a file
+// purgeOldFiles compresses rotated files, and removes older files to keep
+// only the last N files rotated for a file and within the maximum total size.
+// Compressing and removing files in the same goroutine ensures a file being
+// compressed is never removed.
 func (f *FileRotator) purgeOldFiles() {
 	for {
 		select {
 		case <-f.purgeCh:
-			var fIndexes []int
+			if f.compression != "" {
+				f.compressRotatedFiles()
+			}
+
 			files, err := ioutil.ReadDir(f.path)
 			if err != nil {
 				f.logger.Error("error getting directory listing", "err", err)
 				return
 			}
-			// Inserting all the rotated files in a slice
+
+			// Inserting all the rotated files in a slice, along with their
+			// size on disk and the names they are stored as
+			var fIndexes []int
+			sizes := make(map[int]int64)
+			names := make(map[int][]string)
+			var totalSize int64
 			for _, fi := range files {
-				if strings.HasPrefix(fi.Name(), f.baseFileName) {
-					fileIdx := strings.TrimPrefix(fi.Name(), fmt.Sprintf("%s.", f.baseFileName))
-					n, err := strconv.Atoi(fileIdx)
-					if err != nil {
-						f.logger.Error("error extracting file index", "err", err)
-						continue
+				if !strings.HasPrefix(fi.Name(), f.baseFileName) {
+					continue
+				}
+				n, _, ok := LogFileIndex(fi.Name(), f.baseFileName)
+				if !ok {
+					if !strings.HasSuffix(fi.Name(), CompressingSuffix) {
+						f.logger.Error("error extracting file index", "file", fi.Name())
 					}
+					continue
+				}
+				if _, ok := sizes[n]; !ok {
 					fIndexes = append(fIndexes, n)
 				}
-			}
-
-			// Not continuing to delete files if the number of files is not more
-			// than MaxFiles
-			if len(fIndexes) <= f.MaxFiles {
-				continue
+				sizes[n] += fi.Size()
+				names[n] = append(names[n], fi.Name())
+				totalSize += fi.Size()
 			}
 
 			// Sorting the file indexes so that we can purge the older files and keep
 			// only the number of files as configured by the user
 			sort.Ints(fIndexes)
-			toDelete := fIndexes[0 : len(fIndexes)-f.MaxFiles]
-			for _, fIndex := range toDelete {
-				fname := filepath.Join(f.path, fmt.Sprintf("%s.%d", f.baseFileName, fIndex))
-				err := os.RemoveAll(fname)
-				if err != nil {
-					f.logger.Error("error removing file", "filename", fname, "err", err)
+			current := f.currentIndex()
+			deleted := 0
+			for _, fIndex := range fIndexes {
+				overCount := len(fIndexes)-deleted > f.MaxFiles
+				overSize := f.maxTotalSize > 0 && totalSize > f.maxTotalSize
+				if (!overCount && !overSize) || fIndex >= current {
+					break
 				}
+
+				for _, name := range names[fIndex] {
+					fname := filepath.Join(f.path, name)
+					if err := os.RemoveAll(fname); err != nil {
+						f.logger.Error("error removing file", "filename", fname, "err", err)
+					}
+				}
+				totalSize -= sizes[fIndex]
+				deleted++
+			}
+			if deleted > 0 {
+				f.oldestLogFileIdx = fIndexes[0]
 			}
-			f.oldestLogFileIdx = fIndexes[0]
 		case <-f.doneCh:
 			return
 		}
 	}
 }
 
+// compressRotatedFiles compresses the files older than the current one, and
+// removes the temporary files left by compressions that were interrupted.
+func (f *FileRotator) compressRotatedFiles() {
+	files, err := ioutil.ReadDir(f.path)
+	if err != nil {
+		f.logger.Error("error getting directory listing", "err", err)
+		return
+	}
+
+	current := f.currentIndex()
+	for _, fi := range files {
+		if fi.IsDir() || !strings.HasPrefix(fi.Name(), f.baseFileName) {
+			continue
+		}
+		fname := filepath.Join(f.path, fi.Name())
+		if strings.HasSuffix(fi.Name(), CompressingSuffix) {
+			os.Remove(fname)
+			continue
+		}
+
+		n, compression, ok := LogFileIndex(fi.Name(), f.baseFileName)
+		if !ok || compression != "" || n >= current {
+			continue
+		}
+
+		// A file compressed before being removed is removed again
+		if f.isCompressed(fname) {
+			os.Remove(fname)
+			continue
+		}
+
+		if err := compressFile(fname, f.compression); err != nil {
+			f.logger.Error("error compressing file", "filename", fname, "err", err)
+		}
+	}
+}
+
+// currentIndex returns the index of the file being written.
+func (f *FileRotator) currentIndex() int {
+	f.bufLock.Lock()
+	defer f.bufLock.Unlock()
+	return f.currentIdx
+}
+
 // flushBuffer flushes the buffer
 func (f *FileRotator) flushBuffer() error {
 	f.bufLock.Lock()
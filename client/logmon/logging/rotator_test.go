@@ -6,7 +6,9 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/testutil"
@@ -288,6 +290,176 @@ func TestFileRotator_PurgeOldFiles(t *testing.T) {
 	})
 }
 
+func TestFileRotator_RotateEvery(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path, err := ioutil.TempDir("", pathPrefix)
+	require.NoError(t, err)
+	defer os.RemoveAll(path)
+
+	config := &FileRotatorConfig{RotateEvery: 50 * time.Millisecond}
+	fr, err := NewFileRotatorWithConfig(path, baseFileName, 10, 1024, config, testlog.HCLogger(t))
+	require.NoError(t, err)
+	defer fr.Close()
+
+	_, err = fr.Write([]byte("first\n"))
+	require.NoError(t, err)
+
+	// Files are rotated on the first write after they expire
+	time.Sleep(100 * time.Millisecond)
+	_, err = fr.Write([]byte("second\n"))
+	require.NoError(t, err)
+
+	testutil.WaitForResult(func() (bool, error) {
+		b, err := ioutil.ReadFile(filepath.Join(path, "redis.stdout.1"))
+		if err != nil {
+			return false, err
+		}
+		if string(b) != "second\n" {
+			return false, fmt.Errorf("unexpected content %q", b)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	b, err := ioutil.ReadFile(filepath.Join(path, "redis.stdout.0"))
+	require.NoError(t, err)
+	require.Equal(t, "first\n", string(b))
+}
+
+func TestFileRotator_Compression(t *testing.T) {
+	for _, c := range []string{CompressionGzip, CompressionZstd} {
+		t.Run(c, func(t *testing.T) {
+			defer goleak.VerifyNone(t)
+
+			path, err := ioutil.TempDir("", pathPrefix)
+			require.NoError(t, err)
+			defer os.RemoveAll(path)
+
+			config := &FileRotatorConfig{Compression: c}
+			fr, err := NewFileRotatorWithConfig(path, baseFileName, 10, 5, config, testlog.HCLogger(t))
+			require.NoError(t, err)
+
+			_, err = fr.Write([]byte("abcdefghijklm"))
+			require.NoError(t, err)
+
+			// Rotated files are compressed and the current one isn't
+			ext := compressionExts[c]
+			testutil.WaitForResult(func() (bool, error) {
+				for _, name := range []string{"redis.stdout.0" + ext, "redis.stdout.1" + ext, "redis.stdout.2"} {
+					if _, err := os.Stat(filepath.Join(path, name)); err != nil {
+						return false, err
+					}
+				}
+				for _, name := range []string{"redis.stdout.0", "redis.stdout.1"} {
+					if _, err := os.Stat(filepath.Join(path, name)); err == nil {
+						return false, fmt.Errorf("%s wasn't removed", name)
+					}
+				}
+				return true, nil
+			}, func(err error) {
+				require.NoError(t, err)
+			})
+
+			f, err := os.Open(filepath.Join(path, "redis.stdout.1"+ext))
+			require.NoError(t, err)
+			defer f.Close()
+			r, err := NewDecompressor(f, c)
+			require.NoError(t, err)
+			defer r.Close()
+			b, err := ioutil.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, "fghij", string(b))
+
+			// Writes continue after the last compressed file
+			require.NoError(t, fr.Close())
+			fr, err = NewFileRotatorWithConfig(path, baseFileName, 10, 5, config, testlog.HCLogger(t))
+			require.NoError(t, err)
+			defer fr.Close()
+			_, err = fr.Write([]byte("nopq"))
+			require.NoError(t, err)
+			fr.Close()
+
+			b, err = ioutil.ReadFile(filepath.Join(path, "redis.stdout.2"))
+			require.NoError(t, err)
+			require.Equal(t, "klmno", string(b))
+		})
+	}
+}
+
+func TestFileRotator_MaxTotalSize(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path, err := ioutil.TempDir("", pathPrefix)
+	require.NoError(t, err)
+	defer os.RemoveAll(path)
+
+	config := &FileRotatorConfig{MaxTotalSize: 10}
+	fr, err := NewFileRotatorWithConfig(path, baseFileName, 10, 4, config, testlog.HCLogger(t))
+	require.NoError(t, err)
+	defer fr.Close()
+
+	_, err = fr.Write([]byte("abcdefghijklmnopq"))
+	require.NoError(t, err)
+
+	// The oldest files are removed until the total size is under the cap
+	testutil.WaitForResult(func() (bool, error) {
+		f, err := ioutil.ReadDir(path)
+		if err != nil {
+			return false, err
+		}
+		var names []string
+		for _, fi := range f {
+			names = append(names, fi.Name())
+		}
+		expected := []string{"redis.stdout.2", "redis.stdout.3", "redis.stdout.4"}
+		if !reflect.DeepEqual(names, expected) {
+			return false, fmt.Errorf("expected files %v, got %v", expected, names)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+}
+
+func TestFileRotator_InvalidCompression(t *testing.T) {
+	path, err := ioutil.TempDir("", pathPrefix)
+	require.NoError(t, err)
+	defer os.RemoveAll(path)
+
+	config := &FileRotatorConfig{Compression: "lz4"}
+	_, err = NewFileRotatorWithConfig(path, baseFileName, 10, 10, config, testlog.HCLogger(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `unknown compression "lz4"`)
+}
+
+func TestLogFileIndex(t *testing.T) {
+	cases := []struct {
+		name        string
+		idx         int
+		compression string
+		ok          bool
+	}{
+		{"redis.stdout.0", 0, "", true},
+		{"redis.stdout.12.gz", 12, CompressionGzip, true},
+		{"redis.stdout.3.zst", 3, CompressionZstd, true},
+		{"redis.stdout.3.zst.tmp", 0, "", false},
+		{"redis.stderr.1", 0, "", false},
+		{"redis.stdout.-1", 0, "", false},
+		{"redis.stdout.fifo", 0, "", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			idx, compression, ok := LogFileIndex(c.name, baseFileName)
+			require.Equal(t, c.ok, ok)
+			require.Equal(t, c.idx, idx)
+			require.Equal(t, c.compression, compression)
+		})
+	}
+}
+
 func BenchmarkRotator(b *testing.B) {
 	kb := 1024
 	for _, inputSize := range []int{kb, 2 * kb, 4 * kb, 8 * kb, 16 * kb, 32 * kb, 64 * kb, 128 * kb, 256 * kb} {
@@ -0,0 +1,395 @@
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+)
+
+const (
+	SinkTypeSyslog   = "syslog"
+	SinkTypeHTTP     = "http"
+	SinkTypeJournald = "journald"
+
+	// maxLineSize is the size at which long lines are split into several
+	// messages, so that every message fits in a datagram.
+	maxLineSize = 16 * 1024
+
+	// sinkRetryMin and sinkRetryMax bound the backoff between attempts to
+	// deliver messages to an unavailable destination.
+	sinkRetryMin = 500 * time.Millisecond
+	sinkRetryMax = 30 * time.Second
+
+	// sinkCloseTimeout is how long closing a sink waits for buffered
+	// messages to be delivered.
+	sinkCloseTimeout = 5 * time.Second
+
+	// sinkDialTimeout is the timeout of connecting to and writing to a
+	// destination.
+	sinkDialTimeout = 10 * time.Second
+
+	// defaultSinkBatchSize is the most messages sent at once by sinks that
+	// don't batch messages, when several are buffered.
+	defaultSinkBatchSize = 64
+)
+
+// SinkConfig configures where a sink ships logs to.
+type SinkConfig struct {
+	// Type is one of syslog, http or journald
+	Type string
+
+	// Address is the destination of the sink
+	Address string
+
+	// Facility is the syslog facility of messages
+	Facility string
+
+	// Tag is the syslog APP-NAME and journald SYSLOG_IDENTIFIER
+	Tag string
+
+	// Header is sent with the requests of http sinks
+	Header map[string]string
+
+	// BatchSize and BatchWait bound the size of the requests of http sinks
+	// and how long messages wait to be sent
+	BatchSize int
+	BatchWait time.Duration
+
+	// BufferSize is the number of messages buffered while the destination
+	// is slow or unavailable
+	BufferSize int
+
+	// Backpressure blocks writes while the buffer is full instead of
+	// dropping messages
+	Backpressure bool
+}
+
+// TaskInfo identifies the task whose logs are shipped.
+type TaskInfo struct {
+	AllocID   string
+	Namespace string
+	JobID     string
+	GroupName string
+	TaskName  string
+}
+
+// Message is a line written by a task.
+type Message struct {
+	Time   time.Time
+	Stream string
+	Line   []byte
+}
+
+// SinkStats counts the messages handled by a sink.
+type SinkStats struct {
+	Type    string
+	Address string
+
+	// Sent is the number of messages delivered
+	Sent uint64
+
+	// Dropped is the number of messages dropped because the buffer was
+	// full or the sink was closed before delivering them
+	Dropped uint64
+
+	// Failed is the number of failed attempts to deliver messages
+	Failed uint64
+
+	// Buffered is the number of messages waiting to be delivered
+	Buffered uint64
+}
+
+// sender delivers messages to a destination. send returns the number of
+// messages delivered before an error.
+type sender interface {
+	send(msgs []*Message) (int, error)
+	close() error
+}
+
+// Sink ships messages to a destination in the background. Messages are
+// buffered while the destination is slow or unavailable, and are dropped or
+// block writers once the buffer is full.
+type Sink struct {
+	config *SinkConfig
+	sender sender
+	logger hclog.Logger
+
+	bufCh     chan *Message
+	batchSize int
+	batchWait time.Duration
+
+	sent    uint64
+	dropped uint64
+	failed  uint64
+
+	releaseCh   chan struct{}
+	releaseOnce sync.Once
+	closeCh     chan struct{}
+	closeOnce   sync.Once
+	doneCh      chan struct{}
+}
+
+// NewSink returns a sink shipping the logs of the task to the destination
+// of the config.
+func NewSink(config *SinkConfig, task *TaskInfo, logger hclog.Logger) (*Sink, error) {
+	if config.BufferSize < 1 {
+		return nil, fmt.Errorf("buffer size must be at least 1")
+	}
+	if config.Tag == "" {
+		config.Tag = task.TaskName
+	}
+
+	var snd sender
+	var err error
+	batchSize, batchWait := defaultSinkBatchSize, time.Duration(0)
+	switch config.Type {
+	case SinkTypeSyslog:
+		snd, err = newSyslogSender(config, task)
+	case SinkTypeHTTP:
+		snd, err = newHTTPSender(config, task)
+		batchSize, batchWait = config.BatchSize, config.BatchWait
+	case SinkTypeJournald:
+		snd, err = newJournaldSender(config, task)
+	default:
+		err = fmt.Errorf("unknown sink type %q", config.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	s := &Sink{
+		config:    config,
+		sender:    snd,
+		logger:    logger.Named("sink").With("type", config.Type, "address", config.Address),
+		bufCh:     make(chan *Message, config.BufferSize),
+		batchSize: batchSize,
+		batchWait: batchWait,
+		releaseCh: make(chan struct{}),
+		closeCh:   make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+	go s.run()
+	return s, nil
+}
+
+// Write queues a message to be shipped.
+func (s *Sink) Write(m *Message) {
+	select {
+	case <-s.closeCh:
+		atomic.AddUint64(&s.dropped, 1)
+		return
+	default:
+	}
+
+	if s.config.Backpressure {
+		select {
+		case s.bufCh <- m:
+			return
+		case <-s.releaseCh:
+		}
+	}
+
+	select {
+	case s.bufCh <- m:
+	default:
+		atomic.AddUint64(&s.dropped, 1)
+	}
+}
+
+// Release stops writes from blocking while the buffer is full, so that the
+// output of a task can be closed while its destination is unavailable.
+func (s *Sink) Release() {
+	s.releaseOnce.Do(func() {
+		close(s.releaseCh)
+	})
+}
+
+// Stats returns the counters of the sink.
+func (s *Sink) Stats() *SinkStats {
+	return &SinkStats{
+		Type:     s.config.Type,
+		Address:  s.config.Address,
+		Sent:     atomic.LoadUint64(&s.sent),
+		Dropped:  atomic.LoadUint64(&s.dropped),
+		Failed:   atomic.LoadUint64(&s.failed),
+		Buffered: uint64(len(s.bufCh)),
+	}
+}
+
+// Close stops accepting messages and waits a short while for the buffered
+// ones to be delivered.
+func (s *Sink) Close() {
+	s.Release()
+	s.closeOnce.Do(func() {
+		close(s.closeCh)
+	})
+
+	select {
+	case <-s.doneCh:
+	case <-time.After(sinkCloseTimeout):
+		s.logger.Warn("timed out delivering buffered logs", "buffered", len(s.bufCh))
+	}
+}
+
+func (s *Sink) run() {
+	defer close(s.doneCh)
+	defer s.sender.close()
+
+	for {
+		batch := s.nextBatch()
+		if len(batch) == 0 {
+			return
+		}
+		s.deliver(batch)
+	}
+}
+
+// nextBatch waits for messages and returns up to a batch of them. It returns
+// an empty batch once the sink is closed and its buffer is drained.
+func (s *Sink) nextBatch() []*Message {
+	batch := make([]*Message, 0, s.batchSize)
+	select {
+	case m := <-s.bufCh:
+		batch = append(batch, m)
+	case <-s.closeCh:
+		return s.fillBatch(batch)
+	}
+
+	batch = s.fillBatch(batch)
+	if len(batch) == s.batchSize || s.batchWait == 0 {
+		return batch
+	}
+
+	timer := time.NewTimer(s.batchWait)
+	defer timer.Stop()
+	for len(batch) < s.batchSize {
+		select {
+		case m := <-s.bufCh:
+			batch = append(batch, m)
+		case <-timer.C:
+			return batch
+		case <-s.closeCh:
+			return s.fillBatch(batch)
+		}
+	}
+	return batch
+}
+
+// fillBatch adds buffered messages to the batch without waiting.
+func (s *Sink) fillBatch(batch []*Message) []*Message {
+	for len(batch) < s.batchSize {
+		select {
+		case m := <-s.bufCh:
+			batch = append(batch, m)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// deliver sends the batch, retrying with a backoff until it succeeds or the
+// sink is closed.
+func (s *Sink) deliver(batch []*Message) {
+	backoff := sinkRetryMin
+	for {
+		n, err := s.sender.send(batch)
+		atomic.AddUint64(&s.sent, uint64(n))
+		batch = batch[n:]
+		if err == nil {
+			return
+		}
+
+		atomic.AddUint64(&s.failed, 1)
+		s.logger.Warn("failed to ship logs", "error", err, "retry", backoff)
+
+		select {
+		case <-s.closeCh:
+			atomic.AddUint64(&s.dropped, uint64(len(batch)))
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > sinkRetryMax {
+			backoff = sinkRetryMax
+		}
+	}
+}
+
+// LineWriter splits the output of a task into lines and writes them to
+// sinks.
+type LineWriter struct {
+	stream string
+	sinks  []*Sink
+	buf    []byte
+}
+
+// NewLineWriter returns a writer of the output of the stream, stdout or
+// stderr, to the sinks.
+func NewLineWriter(stream string, sinks []*Sink) *LineWriter {
+	return &LineWriter{
+		stream: stream,
+		sinks:  sinks,
+	}
+}
+
+// Write splits p into lines. It never fails so that the rotated log files
+// keep being written when sinks can't keep up.
+func (w *LineWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, newLineDelimiter)
+		if i < 0 {
+			w.buf = append(w.buf, p...)
+			break
+		}
+		w.buf = append(w.buf, p[:i]...)
+		w.flush()
+		p = p[i+1:]
+	}
+
+	for len(w.buf) >= maxLineSize {
+		rest := append([]byte(nil), w.buf[maxLineSize:]...)
+		w.buf = w.buf[:maxLineSize]
+		w.flush()
+		w.buf = rest
+	}
+	return n, nil
+}
+
+// Close writes the last line if it isn't terminated by a newline.
+func (w *LineWriter) Close() error {
+	if len(w.buf) > 0 {
+		w.flush()
+	}
+	return nil
+}
+
+func (w *LineWriter) flush() {
+	line := bytes.TrimSuffix(w.buf, []byte{'\r'})
+	for len(line) > maxLineSize {
+		w.write(line[:maxLineSize])
+		line = line[maxLineSize:]
+	}
+	w.write(line)
+	w.buf = w.buf[:0]
+}
+
+func (w *LineWriter) write(line []byte) {
+	m := &Message{
+		Time:   time.Now(),
+		Stream: w.stream,
+		Line:   append([]byte(nil), line...),
+	}
+	for _, s := range w.sinks {
+		s.Write(m)
+	}
+}
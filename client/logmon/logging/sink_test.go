@@ -0,0 +1,315 @@
+package logging
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+var testTaskInfo = &TaskInfo{
+	AllocID:   "2a4ab8b2-6c7b-4b4c-bd3d-1f5b8e0d7a56",
+	Namespace: "default",
+	JobID:     "example",
+	GroupName: "cache",
+	TaskName:  "redis",
+}
+
+// recordingSender records the messages it is sent and fails while failing
+// is set.
+type recordingSender struct {
+	lock    sync.Mutex
+	msgs    []*Message
+	failing bool
+	blockCh chan struct{}
+}
+
+func (r *recordingSender) send(msgs []*Message) (int, error) {
+	if r.blockCh != nil {
+		<-r.blockCh
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.failing {
+		return 0, net.ErrClosed
+	}
+	r.msgs = append(r.msgs, msgs...)
+	return len(msgs), nil
+}
+
+func (r *recordingSender) close() error { return nil }
+
+func (r *recordingSender) lines() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var lines []string
+	for _, m := range r.msgs {
+		lines = append(lines, m.Stream+":"+string(m.Line))
+	}
+	return lines
+}
+
+func newTestSink(t *testing.T, config *SinkConfig, snd sender) *Sink {
+	s := &Sink{
+		config:    config,
+		sender:    snd,
+		logger:    testlog.HCLogger(t),
+		bufCh:     make(chan *Message, config.BufferSize),
+		batchSize: defaultSinkBatchSize,
+		releaseCh: make(chan struct{}),
+		closeCh:   make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func TestLineWriter(t *testing.T) {
+	rec := &recordingSender{}
+	sink := newTestSink(t, &SinkConfig{Type: "test", BufferSize: 100}, rec)
+
+	w := NewLineWriter("stdout", []*Sink{sink})
+	w.Write([]byte("hello\nwor"))
+	w.Write([]byte("ld\r\n\npartial"))
+	w.Write([]byte(strings.Repeat("x", maxLineSize+10) + "\n"))
+	w.Write([]byte("last"))
+	require.NoError(t, w.Close())
+	sink.Close()
+
+	require.Equal(t, []string{
+		"stdout:hello",
+		"stdout:world",
+		"stdout:",
+		"stdout:partial" + strings.Repeat("x", maxLineSize-len("partial")),
+		"stdout:" + strings.Repeat("x", len("partial")+10),
+		"stdout:last",
+	}, rec.lines())
+	require.Equal(t, uint64(6), sink.Stats().Sent)
+}
+
+func TestSink_DropsWhenFull(t *testing.T) {
+	rec := &recordingSender{blockCh: make(chan struct{})}
+	sink := newTestSink(t, &SinkConfig{Type: "test", BufferSize: 2}, rec)
+
+	// The first message is taken by the blocked sender and two are buffered
+	for i := 0; i < 6; i++ {
+		sink.Write(&Message{Stream: "stdout", Line: []byte(strconv.Itoa(i))})
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	stats := sink.Stats()
+	require.Equal(t, uint64(3), stats.Dropped)
+	require.Equal(t, uint64(2), stats.Buffered)
+
+	close(rec.blockCh)
+	sink.Close()
+	require.Equal(t, []string{"stdout:0", "stdout:1", "stdout:2"}, rec.lines())
+	require.Equal(t, uint64(3), sink.Stats().Sent)
+}
+
+func TestSink_Backpressure(t *testing.T) {
+	rec := &recordingSender{blockCh: make(chan struct{})}
+	sink := newTestSink(t, &SinkConfig{Type: "test", BufferSize: 1, Backpressure: true}, rec)
+
+	sink.Write(&Message{Stream: "stdout", Line: []byte("0")})
+	time.Sleep(10 * time.Millisecond)
+	sink.Write(&Message{Stream: "stdout", Line: []byte("1")})
+
+	// The buffer is full so the next write blocks
+	written := make(chan struct{})
+	go func() {
+		sink.Write(&Message{Stream: "stdout", Line: []byte("2")})
+		close(written)
+	}()
+	select {
+	case <-written:
+		t.Fatal("write didn't block")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(rec.blockCh)
+	select {
+	case <-written:
+	case <-time.After(5 * time.Second):
+		t.Fatal("write still blocked")
+	}
+	sink.Close()
+	require.Equal(t, []string{"stdout:0", "stdout:1", "stdout:2"}, rec.lines())
+	require.Zero(t, sink.Stats().Dropped)
+}
+
+func TestSink_Release(t *testing.T) {
+	rec := &recordingSender{failing: true}
+	sink := newTestSink(t, &SinkConfig{Type: "test", BufferSize: 1, Backpressure: true}, rec)
+
+	sink.Write(&Message{Stream: "stdout", Line: []byte("0")})
+	time.Sleep(10 * time.Millisecond)
+	sink.Write(&Message{Stream: "stdout", Line: []byte("1")})
+
+	// Released sinks drop messages instead of blocking
+	sink.Release()
+	sink.Write(&Message{Stream: "stdout", Line: []byte("2")})
+	sink.Close()
+
+	stats := sink.Stats()
+	require.NotZero(t, stats.Failed)
+	require.Equal(t, uint64(3), stats.Dropped)
+	require.Zero(t, stats.Sent)
+}
+
+func TestSink_Syslog(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	received := make(chan string, 2)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// Messages are framed with octet counting
+		r := bufio.NewReader(conn)
+		for {
+			size, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(size))
+			buf := make([]byte, n)
+			if _, err := r.Read(buf); err != nil {
+				return
+			}
+			received <- string(buf)
+		}
+	}()
+
+	sink, err := NewSink(&SinkConfig{
+		Type:       SinkTypeSyslog,
+		Address:    "tcp://" + l.Addr().String(),
+		Facility:   "local3",
+		BufferSize: 10,
+	}, testTaskInfo, testlog.HCLogger(t))
+	require.NoError(t, err)
+
+	ts := time.Date(2021, 11, 10, 16, 4, 5, 123456000, time.UTC)
+	sink.Write(&Message{Time: ts, Stream: "stdout", Line: []byte("ready to accept connections")})
+	sink.Write(&Message{Time: ts, Stream: "stderr", Line: []byte("out of memory")})
+	sink.Close()
+
+	hostname, _ := os.Hostname()
+	require.Equal(t, "<158>1 2021-11-10T16:04:05.123456Z "+hostname+" redis "+testTaskInfo.AllocID+" stdout - ready to accept connections", <-received)
+	require.Equal(t, "<155>1 2021-11-10T16:04:05.123456Z "+hostname+" redis "+testTaskInfo.AllocID+" stderr - out of memory", <-received)
+}
+
+func TestSink_HTTP(t *testing.T) {
+	var lock sync.Mutex
+	var records []*httpRecord
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		header = r.Header
+		dec := json.NewDecoder(r.Body)
+		for dec.More() {
+			var rec httpRecord
+			if err := dec.Decode(&rec); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			records = append(records, &rec)
+		}
+	}))
+	defer srv.Close()
+
+	sink, err := NewSink(&SinkConfig{
+		Type:       SinkTypeHTTP,
+		Address:    srv.URL,
+		Header:     map[string]string{"Authorization": "Bearer token"},
+		BatchSize:  10,
+		BatchWait:  10 * time.Millisecond,
+		BufferSize: 10,
+	}, testTaskInfo, testlog.HCLogger(t))
+	require.NoError(t, err)
+
+	sink.Write(&Message{Time: time.Now(), Stream: "stdout", Line: []byte("one")})
+	sink.Write(&Message{Time: time.Now(), Stream: "stderr", Line: []byte("two")})
+
+	testutil.WaitForResult(func() (bool, error) {
+		return sink.Stats().Sent == 2, nil
+	}, func(error) {
+		t.Fatalf("expected 2 sent lines, got %#v", sink.Stats())
+	})
+	sink.Close()
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Len(t, records, 2)
+	require.Equal(t, "one", records[0].Message)
+	require.Equal(t, "stdout", records[0].Stream)
+	require.Equal(t, "two", records[1].Message)
+	require.Equal(t, "stderr", records[1].Stream)
+	require.Equal(t, testTaskInfo.AllocID, records[1].AllocID)
+	require.Equal(t, "redis", records[1].TaskName)
+	require.Equal(t, "Bearer token", header.Get("Authorization"))
+	require.Equal(t, "application/x-ndjson", header.Get("Content-Type"))
+}
+
+func TestSink_Journald(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("journald isn't supported on Windows")
+	}
+
+	dir, err := ioutil.TempDir("", "nomadtest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	sink, err := NewSink(&SinkConfig{
+		Type:       SinkTypeJournald,
+		Address:    path,
+		BufferSize: 10,
+	}, testTaskInfo, testlog.HCLogger(t))
+	require.NoError(t, err)
+
+	sink.Write(&Message{Stream: "stderr", Line: []byte("multi\nline")})
+	sink.Close()
+
+	buf := make([]byte, 4096)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+
+	size := make([]byte, 8)
+	binary.LittleEndian.PutUint64(size, uint64(len("multi\nline")))
+	require.Equal(t, "MESSAGE\n"+string(size)+"multi\nline\n"+
+		"PRIORITY=3\n"+
+		"NOMAD_STREAM=stderr\n"+
+		"SYSLOG_IDENTIFIER=redis\n"+
+		"NOMAD_ALLOC_ID="+testTaskInfo.AllocID+"\n"+
+		"NOMAD_NAMESPACE=default\n"+
+		"NOMAD_JOB_ID=example\n"+
+		"NOMAD_GROUP_NAME=cache\n"+
+		"NOMAD_TASK_NAME=redis\n", string(buf[:n]))
+}
@@ -0,0 +1,163 @@
+package logging
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// syslog severities of stdout and stderr
+	syslogSeverityErr  = 3
+	syslogSeverityInfo = 6
+
+	// rfc5424Time is the timestamp format of RFC5424 messages
+	rfc5424Time = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// syslogFacilities maps syslog facility names to their codes.
+var syslogFacilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+// syslogSender sends RFC5424 messages over TCP, UDP or a unix socket.
+type syslogSender struct {
+	network string
+	addr    string
+
+	// framed is set for stream connections, whose messages are framed with
+	// octet counting as in RFC6587
+	framed bool
+
+	facility int
+	hostname string
+	appName  string
+	procID   string
+
+	conn net.Conn
+}
+
+func newSyslogSender(config *SinkConfig, task *TaskInfo) (*syslogSender, error) {
+	u, err := url.Parse(config.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid syslog address %q: %v", config.Address, err)
+	}
+
+	s := &syslogSender{
+		network:  u.Scheme,
+		facility: syslogFacilities["user"],
+		appName:  syslogHeaderValue(config.Tag, 48),
+		procID:   syslogHeaderValue(task.AllocID, 128),
+	}
+	switch u.Scheme {
+	case "tcp":
+		s.addr, s.framed = u.Host, true
+	case "udp":
+		s.addr = u.Host
+	case "unix":
+		s.addr, s.framed = u.Path, true
+	case "unixgram":
+		s.addr = u.Path
+	default:
+		return nil, fmt.Errorf("unsupported syslog network %q", u.Scheme)
+	}
+
+	if config.Facility != "" {
+		facility, ok := syslogFacilities[config.Facility]
+		if !ok {
+			return nil, fmt.Errorf("invalid syslog facility %q", config.Facility)
+		}
+		s.facility = facility
+	}
+
+	hostname, _ := os.Hostname()
+	s.hostname = syslogHeaderValue(hostname, 255)
+	return s, nil
+}
+
+func (s *syslogSender) send(msgs []*Message) (int, error) {
+	if s.conn == nil {
+		conn, err := net.DialTimeout(s.network, s.addr, sinkDialTimeout)
+		if err != nil {
+			return 0, err
+		}
+		s.conn = conn
+	}
+
+	for i, m := range msgs {
+		msg := s.format(m)
+		if s.framed {
+			msg = append([]byte(fmt.Sprintf("%d ", len(msg))), msg...)
+		}
+
+		s.conn.SetWriteDeadline(time.Now().Add(sinkDialTimeout))
+		if _, err := s.conn.Write(msg); err != nil {
+			// Reconnect on the next attempt
+			s.conn.Close()
+			s.conn = nil
+			return i, err
+		}
+	}
+	return len(msgs), nil
+}
+
+// format returns the message in the RFC5424 format. The stream is used as
+// the MSGID.
+func (s *syslogSender) format(m *Message) []byte {
+	severity := syslogSeverityInfo
+	if m.Stream == "stderr" {
+		severity = syslogSeverityErr
+	}
+
+	header := fmt.Sprintf("<%d>1 %s %s %s %s %s - ",
+		s.facility*8+severity, m.Time.UTC().Format(rfc5424Time),
+		s.hostname, s.appName, s.procID, m.Stream)
+	return append([]byte(header), m.Line...)
+}
+
+func (s *syslogSender) close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+// syslogHeaderValue returns the value as a field of the RFC5424 header,
+// which is limited to printable ASCII without spaces.
+func syslogHeaderValue(v string, max int) string {
+	if v == "" {
+		return "-"
+	}
+	v = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+		return r
+	}, v)
+	if len(v) > max {
+		v = v[:max]
+	}
+	return v
+}
@@ -41,11 +41,38 @@ type LogConfig struct {
 
 	// MaxFileSizeMB is the max log file size in MB allowed before rotation occures
 	MaxFileSizeMB int
+
+	// Sinks ship the logs to external destinations in addition to the
+	// rotated files
+	Sinks []*logging.SinkConfig
+
+	// Task identifies the task in the logs shipped by sinks and in the JSON
+	// records tagged by logmon
+	Task *logging.TaskInfo
+
+	// Format is either text or json. JSON records are tagged with the task's
+	// identity, and filtered by Level
+	Format string
+
+	// Level is the level below which JSON records are dropped
+	Level string
+
+	// RotateEvery rotates log files once they are older than it
+	RotateEvery time.Duration
+
+	// Compression is the algorithm rotated log files are compressed with
+	Compression string
+
+	// MaxTotalSizeMB caps the size of the log files of each stream on disk
+	MaxTotalSizeMB int
 }
 
 type LogMon interface {
 	Start(*LogConfig) error
 	Stop() error
+
+	// Stats returns the counters of the running sinks.
+	Stats() ([]*logging.SinkStats, error)
 }
 
 func NewLogMon(logger hclog.Logger) LogMon {
@@ -98,6 +125,20 @@ func (l *logmonImpl) Stop() error {
 	return nil
 }
 
+func (l *logmonImpl) Stats() ([]*logging.SinkStats, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.tl == nil {
+		return nil, nil
+	}
+
+	stats := make([]*logging.SinkStats, 0, len(l.tl.sinks))
+	for _, s := range l.tl.sinks {
+		stats = append(stats, s.Stats())
+	}
+	return stats, nil
+}
+
 type TaskLogger struct {
 	config *LogConfig
 
@@ -106,6 +147,9 @@ type TaskLogger struct {
 
 	// rotator for stderr
 	lre *logRotatorWrapper
+
+	// sinks shared by stdout and stderr
+	sinks []*logging.Sink
 }
 
 // IsRunning will return true as long as one rotator wrapper is still running
@@ -117,6 +161,11 @@ func (tl *TaskLogger) IsRunning() bool {
 }
 
 func (tl *TaskLogger) Close() {
+	// Writes to sinks must not block closing the output of the task
+	for _, s := range tl.sinks {
+		s.Release()
+	}
+
 	var wg sync.WaitGroup
 	if tl.lro != nil {
 		wg.Add(1)
@@ -133,33 +182,79 @@ func (tl *TaskLogger) Close() {
 		}()
 	}
 	wg.Wait()
+
+	// Flush the sinks once nothing writes to them anymore
+	for _, s := range tl.sinks {
+		wg.Add(1)
+		go func(s *logging.Sink) {
+			s.Close()
+			wg.Done()
+		}(s)
+	}
+	wg.Wait()
 }
 
 func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 	tl := &TaskLogger{config: cfg}
 
+	task := cfg.Task
+	if task == nil {
+		task = &logging.TaskInfo{}
+	}
+	for _, sc := range cfg.Sinks {
+		sink, err := logging.NewSink(sc, task, logger)
+		if err != nil {
+			tl.Close()
+			return nil, fmt.Errorf("failed to create %s log sink: %v", sc.Type, err)
+		}
+		tl.sinks = append(tl.sinks, sink)
+	}
+
 	logFileSize := int64(cfg.MaxFileSizeMB * 1024 * 1024)
-	lro, err := logging.NewFileRotator(cfg.LogDir, cfg.StdoutLogFile,
-		cfg.MaxFiles, logFileSize, logger)
+	rotatorConfig := &logging.FileRotatorConfig{
+		RotateEvery:  cfg.RotateEvery,
+		Compression:  cfg.Compression,
+		MaxTotalSize: int64(cfg.MaxTotalSizeMB) * 1024 * 1024,
+	}
+	lro, err := logging.NewFileRotatorWithConfig(cfg.LogDir, cfg.StdoutLogFile,
+		cfg.MaxFiles, logFileSize, rotatorConfig, logger)
 	if err != nil {
+		tl.Close()
 		return nil, fmt.Errorf("failed to create stdout logfile for %q: %v", cfg.StdoutLogFile, err)
 	}
 
-	wrapperOut, err := newLogRotatorWrapper(cfg.StdoutFifo, logger, lro)
+	out, err := tl.withFormat("stdout", task, tl.withSinks("stdout", lro))
 	if err != nil {
+		lro.Close()
+		tl.Close()
+		return nil, err
+	}
+
+	wrapperOut, err := newLogRotatorWrapper(cfg.StdoutFifo, logger, out)
+	if err != nil {
+		tl.Close()
 		return nil, err
 	}
 
 	tl.lro = wrapperOut
 
-	lre, err := logging.NewFileRotator(cfg.LogDir, cfg.StderrLogFile,
-		cfg.MaxFiles, logFileSize, logger)
+	lre, err := logging.NewFileRotatorWithConfig(cfg.LogDir, cfg.StderrLogFile,
+		cfg.MaxFiles, logFileSize, rotatorConfig, logger)
 	if err != nil {
+		tl.Close()
 		return nil, fmt.Errorf("failed to create stderr logfile for %q: %v", cfg.StderrLogFile, err)
 	}
 
-	wrapperErr, err := newLogRotatorWrapper(cfg.StderrFifo, logger, lre)
+	errOut, err := tl.withFormat("stderr", task, tl.withSinks("stderr", lre))
+	if err != nil {
+		lre.Close()
+		tl.Close()
+		return nil, err
+	}
+
+	wrapperErr, err := newLogRotatorWrapper(cfg.StderrFifo, logger, errOut)
 	if err != nil {
+		tl.Close()
 		return nil, err
 	}
 
@@ -169,6 +264,43 @@ func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 
 }
 
+// withSinks returns a writer of the stream to the rotator and the sinks.
+func (tl *TaskLogger) withSinks(stream string, rotator io.WriteCloser) io.WriteCloser {
+	if len(tl.sinks) == 0 {
+		return rotator
+	}
+	return &sinkWriter{
+		rotator: rotator,
+		lines:   logging.NewLineWriter(stream, tl.sinks),
+	}
+}
+
+// withFormat returns a writer parsing the records of the stream when the
+// format is json.
+func (tl *TaskLogger) withFormat(stream string, task *logging.TaskInfo, w io.WriteCloser) (io.WriteCloser, error) {
+	if tl.config.Format != logging.LogFormatJSON {
+		return w, nil
+	}
+	return logging.NewJSONWriter(w, stream, task, tl.config.Level)
+}
+
+// sinkWriter writes the output of a task to the rotator and the sinks.
+type sinkWriter struct {
+	rotator io.WriteCloser
+	lines   *logging.LineWriter
+}
+
+func (w *sinkWriter) Write(p []byte) (int, error) {
+	n, err := w.rotator.Write(p)
+	w.lines.Write(p[:n])
+	return n, err
+}
+
+func (w *sinkWriter) Close() error {
+	w.lines.Close()
+	return w.rotator.Close()
+}
+
 // logRotatorWrapper wraps our log rotator and exposes a pipe that can feed the
 // log rotator data. The processOutWriter should be attached to the process and
 // data will be copied from the reader to the rotator.
@@ -25,16 +25,27 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type StartRequest struct {
-	LogDir               string   `protobuf:"bytes,1,opt,name=log_dir,json=logDir,proto3" json:"log_dir,omitempty"`
-	StdoutFileName       string   `protobuf:"bytes,2,opt,name=stdout_file_name,json=stdoutFileName,proto3" json:"stdout_file_name,omitempty"`
-	StderrFileName       string   `protobuf:"bytes,3,opt,name=stderr_file_name,json=stderrFileName,proto3" json:"stderr_file_name,omitempty"`
-	MaxFiles             uint32   `protobuf:"varint,4,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
-	MaxFileSizeMb        uint32   `protobuf:"varint,5,opt,name=max_file_size_mb,json=maxFileSizeMb,proto3" json:"max_file_size_mb,omitempty"`
-	StdoutFifo           string   `protobuf:"bytes,6,opt,name=stdout_fifo,json=stdoutFifo,proto3" json:"stdout_fifo,omitempty"`
-	StderrFifo           string   `protobuf:"bytes,7,opt,name=stderr_fifo,json=stderrFifo,proto3" json:"stderr_fifo,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	LogDir               string     `protobuf:"bytes,1,opt,name=log_dir,json=logDir,proto3" json:"log_dir,omitempty"`
+	StdoutFileName       string     `protobuf:"bytes,2,opt,name=stdout_file_name,json=stdoutFileName,proto3" json:"stdout_file_name,omitempty"`
+	StderrFileName       string     `protobuf:"bytes,3,opt,name=stderr_file_name,json=stderrFileName,proto3" json:"stderr_file_name,omitempty"`
+	MaxFiles             uint32     `protobuf:"varint,4,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
+	MaxFileSizeMb        uint32     `protobuf:"varint,5,opt,name=max_file_size_mb,json=maxFileSizeMb,proto3" json:"max_file_size_mb,omitempty"`
+	StdoutFifo           string     `protobuf:"bytes,6,opt,name=stdout_fifo,json=stdoutFifo,proto3" json:"stdout_fifo,omitempty"`
+	StderrFifo           string     `protobuf:"bytes,7,opt,name=stderr_fifo,json=stderrFifo,proto3" json:"stderr_fifo,omitempty"`
+	Sinks                []*LogSink `protobuf:"bytes,8,rep,name=sinks,proto3" json:"sinks,omitempty"`
+	AllocId              string     `protobuf:"bytes,9,opt,name=alloc_id,json=allocId,proto3" json:"alloc_id,omitempty"`
+	Namespace            string     `protobuf:"bytes,10,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	JobId                string     `protobuf:"bytes,11,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	GroupName            string     `protobuf:"bytes,12,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	TaskName             string     `protobuf:"bytes,13,opt,name=task_name,json=taskName,proto3" json:"task_name,omitempty"`
+	Format               string     `protobuf:"bytes,14,opt,name=format,proto3" json:"format,omitempty"`
+	Level                string     `protobuf:"bytes,15,opt,name=level,proto3" json:"level,omitempty"`
+	RotateEveryNs        int64      `protobuf:"varint,16,opt,name=rotate_every_ns,json=rotateEveryNs,proto3" json:"rotate_every_ns,omitempty"`
+	Compression          string     `protobuf:"bytes,17,opt,name=compression,proto3" json:"compression,omitempty"`
+	MaxTotalSizeMb       uint32     `protobuf:"varint,18,opt,name=max_total_size_mb,json=maxTotalSizeMb,proto3" json:"max_total_size_mb,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *StartRequest) Reset()         { *m = StartRequest{} }
@@ -111,6 +122,83 @@ func (m *StartRequest) GetStderrFifo() string {
 	return ""
 }
 
+func (m *StartRequest) GetSinks() []*LogSink {
+	if m != nil {
+		return m.Sinks
+	}
+	return nil
+}
+
+func (m *StartRequest) GetAllocId() string {
+	if m != nil {
+		return m.AllocId
+	}
+	return ""
+}
+
+func (m *StartRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *StartRequest) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *StartRequest) GetGroupName() string {
+	if m != nil {
+		return m.GroupName
+	}
+	return ""
+}
+
+func (m *StartRequest) GetTaskName() string {
+	if m != nil {
+		return m.TaskName
+	}
+	return ""
+}
+
+func (m *StartRequest) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+func (m *StartRequest) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *StartRequest) GetRotateEveryNs() int64 {
+	if m != nil {
+		return m.RotateEveryNs
+	}
+	return 0
+}
+
+func (m *StartRequest) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
+func (m *StartRequest) GetMaxTotalSizeMb() uint32 {
+	if m != nil {
+		return m.MaxTotalSizeMb
+	}
+	return 0
+}
+
 type StartResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -204,11 +292,268 @@ func (m *StopResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_StopResponse proto.InternalMessageInfo
 
+type LogSink struct {
+	Type                 string            `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Address              string            `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Facility             string            `protobuf:"bytes,3,opt,name=facility,proto3" json:"facility,omitempty"`
+	Tag                  string            `protobuf:"bytes,4,opt,name=tag,proto3" json:"tag,omitempty"`
+	Header               map[string]string `protobuf:"bytes,5,rep,name=header,proto3" json:"header,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	BatchSize            uint32            `protobuf:"varint,6,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	BatchWaitNs          int64             `protobuf:"varint,7,opt,name=batch_wait_ns,json=batchWaitNs,proto3" json:"batch_wait_ns,omitempty"`
+	BufferSize           uint32            `protobuf:"varint,8,opt,name=buffer_size,json=bufferSize,proto3" json:"buffer_size,omitempty"`
+	Backpressure         bool              `protobuf:"varint,9,opt,name=backpressure,proto3" json:"backpressure,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *LogSink) Reset()         { *m = LogSink{} }
+func (m *LogSink) String() string { return proto.CompactTextString(m) }
+func (*LogSink) ProtoMessage()    {}
+func (*LogSink) Descriptor() ([]byte, []int) {
+	return fileDescriptor_be72d5e24d2ecba6, []int{4}
+}
+
+func (m *LogSink) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogSink.Unmarshal(m, b)
+}
+func (m *LogSink) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogSink.Marshal(b, m, deterministic)
+}
+func (m *LogSink) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogSink.Merge(m, src)
+}
+func (m *LogSink) XXX_Size() int {
+	return xxx_messageInfo_LogSink.Size(m)
+}
+func (m *LogSink) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogSink.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogSink proto.InternalMessageInfo
+
+func (m *LogSink) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *LogSink) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *LogSink) GetFacility() string {
+	if m != nil {
+		return m.Facility
+	}
+	return ""
+}
+
+func (m *LogSink) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *LogSink) GetHeader() map[string]string {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *LogSink) GetBatchSize() uint32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
+func (m *LogSink) GetBatchWaitNs() int64 {
+	if m != nil {
+		return m.BatchWaitNs
+	}
+	return 0
+}
+
+func (m *LogSink) GetBufferSize() uint32 {
+	if m != nil {
+		return m.BufferSize
+	}
+	return 0
+}
+
+func (m *LogSink) GetBackpressure() bool {
+	if m != nil {
+		return m.Backpressure
+	}
+	return false
+}
+
+type StatsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatsRequest) Reset()         { *m = StatsRequest{} }
+func (m *StatsRequest) String() string { return proto.CompactTextString(m) }
+func (*StatsRequest) ProtoMessage()    {}
+func (*StatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_be72d5e24d2ecba6, []int{5}
+}
+
+func (m *StatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatsRequest.Unmarshal(m, b)
+}
+func (m *StatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatsRequest.Marshal(b, m, deterministic)
+}
+func (m *StatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatsRequest.Merge(m, src)
+}
+func (m *StatsRequest) XXX_Size() int {
+	return xxx_messageInfo_StatsRequest.Size(m)
+}
+func (m *StatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatsRequest proto.InternalMessageInfo
+
+type StatsResponse struct {
+	Sinks                []*SinkStats `protobuf:"bytes,1,rep,name=sinks,proto3" json:"sinks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *StatsResponse) Reset()         { *m = StatsResponse{} }
+func (m *StatsResponse) String() string { return proto.CompactTextString(m) }
+func (*StatsResponse) ProtoMessage()    {}
+func (*StatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_be72d5e24d2ecba6, []int{6}
+}
+
+func (m *StatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatsResponse.Unmarshal(m, b)
+}
+func (m *StatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatsResponse.Marshal(b, m, deterministic)
+}
+func (m *StatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatsResponse.Merge(m, src)
+}
+func (m *StatsResponse) XXX_Size() int {
+	return xxx_messageInfo_StatsResponse.Size(m)
+}
+func (m *StatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatsResponse proto.InternalMessageInfo
+
+func (m *StatsResponse) GetSinks() []*SinkStats {
+	if m != nil {
+		return m.Sinks
+	}
+	return nil
+}
+
+type SinkStats struct {
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Sent                 uint64   `protobuf:"varint,3,opt,name=sent,proto3" json:"sent,omitempty"`
+	Dropped              uint64   `protobuf:"varint,4,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	Failed               uint64   `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`
+	Buffered             uint64   `protobuf:"varint,6,opt,name=buffered,proto3" json:"buffered,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SinkStats) Reset()         { *m = SinkStats{} }
+func (m *SinkStats) String() string { return proto.CompactTextString(m) }
+func (*SinkStats) ProtoMessage()    {}
+func (*SinkStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_be72d5e24d2ecba6, []int{7}
+}
+
+func (m *SinkStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SinkStats.Unmarshal(m, b)
+}
+func (m *SinkStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SinkStats.Marshal(b, m, deterministic)
+}
+func (m *SinkStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SinkStats.Merge(m, src)
+}
+func (m *SinkStats) XXX_Size() int {
+	return xxx_messageInfo_SinkStats.Size(m)
+}
+func (m *SinkStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_SinkStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SinkStats proto.InternalMessageInfo
+
+func (m *SinkStats) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *SinkStats) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *SinkStats) GetSent() uint64 {
+	if m != nil {
+		return m.Sent
+	}
+	return 0
+}
+
+func (m *SinkStats) GetDropped() uint64 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
+func (m *SinkStats) GetFailed() uint64 {
+	if m != nil {
+		return m.Failed
+	}
+	return 0
+}
+
+func (m *SinkStats) GetBuffered() uint64 {
+	if m != nil {
+		return m.Buffered
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*StartRequest)(nil), "hashicorp.nomad.client.logmon.proto.StartRequest")
 	proto.RegisterType((*StartResponse)(nil), "hashicorp.nomad.client.logmon.proto.StartResponse")
 	proto.RegisterType((*StopRequest)(nil), "hashicorp.nomad.client.logmon.proto.StopRequest")
 	proto.RegisterType((*StopResponse)(nil), "hashicorp.nomad.client.logmon.proto.StopResponse")
+	proto.RegisterType((*LogSink)(nil), "hashicorp.nomad.client.logmon.proto.LogSink")
+	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.client.logmon.proto.LogSink.HeaderEntry")
+	proto.RegisterType((*StatsRequest)(nil), "hashicorp.nomad.client.logmon.proto.StatsRequest")
+	proto.RegisterType((*StatsResponse)(nil), "hashicorp.nomad.client.logmon.proto.StatsResponse")
+	proto.RegisterType((*SinkStats)(nil), "hashicorp.nomad.client.logmon.proto.SinkStats")
 }
 
 func init() {
@@ -216,27 +561,56 @@ func init() {
 }
 
 var fileDescriptor_be72d5e24d2ecba6 = []byte{
-	// 320 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x90, 0x31, 0x6f, 0xc2, 0x30,
-	0x10, 0x85, 0x1b, 0x0a, 0xa1, 0x1c, 0x0d, 0x45, 0x5e, 0x1a, 0xd1, 0xa1, 0x28, 0x1d, 0xca, 0x14,
-	0x0a, 0xfd, 0x07, 0x55, 0xd5, 0xa9, 0x74, 0x80, 0xad, 0x4b, 0x64, 0xc0, 0x09, 0x96, 0x62, 0x5f,
-	0x6a, 0x1b, 0x09, 0xb1, 0xf6, 0xd7, 0xf6, 0x5f, 0x54, 0x71, 0x4c, 0x94, 0x11, 0x26, 0xeb, 0xee,
-	0x7d, 0x4f, 0xf7, 0xfc, 0x60, 0xbc, 0xc9, 0x39, 0x93, 0x66, 0x9a, 0x63, 0x26, 0x50, 0x4e, 0x0b,
-	0x85, 0x06, 0xdd, 0x10, 0xdb, 0x81, 0x3c, 0xed, 0xa8, 0xde, 0xf1, 0x0d, 0xaa, 0x22, 0x96, 0x28,
-	0xe8, 0x36, 0xae, 0x1c, 0x71, 0x13, 0x8a, 0x7e, 0x5b, 0x70, 0xbb, 0x32, 0x54, 0x99, 0x25, 0xfb,
-	0xd9, 0x33, 0x6d, 0xc8, 0x3d, 0x74, 0x73, 0xcc, 0x92, 0x2d, 0x57, 0xa1, 0x37, 0xf6, 0x26, 0xbd,
-	0xa5, 0x9f, 0x63, 0xf6, 0xce, 0x15, 0x99, 0xc0, 0x50, 0x9b, 0x2d, 0xee, 0x4d, 0x92, 0xf2, 0x9c,
-	0x25, 0x92, 0x0a, 0x16, 0xb6, 0x2c, 0x31, 0xa8, 0xf6, 0x1f, 0x3c, 0x67, 0x5f, 0x54, 0x30, 0x47,
-	0x32, 0xa5, 0x1a, 0xe4, 0x75, 0x4d, 0x32, 0xa5, 0x6a, 0xf2, 0x01, 0x7a, 0x82, 0x1e, 0x2c, 0xa6,
-	0xc3, 0xf6, 0xd8, 0x9b, 0x04, 0xcb, 0x1b, 0x41, 0x0f, 0xa5, 0xae, 0xc9, 0x33, 0x0c, 0x4f, 0x62,
-	0xa2, 0xf9, 0x91, 0x25, 0x62, 0x1d, 0x76, 0x2c, 0x13, 0x38, 0x66, 0xc5, 0x8f, 0x6c, 0xb1, 0x26,
-	0x8f, 0xd0, 0xaf, 0x93, 0xa5, 0x18, 0xfa, 0xf6, 0x14, 0x9c, 0x42, 0xa5, 0xe8, 0x80, 0x2a, 0x50,
-	0x8a, 0x61, 0xb7, 0x06, 0x6c, 0x96, 0x14, 0xa3, 0x3b, 0x08, 0x5c, 0x09, 0xba, 0x40, 0xa9, 0x59,
-	0x14, 0x40, 0x7f, 0x65, 0xb0, 0x70, 0xa5, 0x44, 0x83, 0xb2, 0xa4, 0x72, 0xac, 0xe4, 0xf9, 0x9f,
-	0x07, 0xfe, 0x27, 0x66, 0x0b, 0x94, 0xa4, 0x80, 0x8e, 0xb5, 0x92, 0x59, 0x7c, 0x46, 0xdf, 0x71,
-	0xb3, 0xeb, 0xd1, 0xfc, 0x12, 0x8b, 0x4b, 0x76, 0x45, 0x04, 0xb4, 0xcb, 0x30, 0xe4, 0xe5, 0x4c,
-	0x77, 0xfd, 0x8d, 0xd1, 0xec, 0x02, 0xc7, 0xe9, 0xdc, 0x5b, 0xf7, 0xbb, 0x63, 0xf7, 0x6b, 0xdf,
-	0x3e, 0xaf, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff, 0xd8, 0xb4, 0x87, 0xfc, 0x7a, 0x02, 0x00, 0x00,
+	// 773 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x5d, 0x8f, 0xe3, 0x34,
+	0x14, 0xdd, 0xb6, 0xe9, 0xd7, 0x6d, 0xd3, 0xe9, 0x5a, 0x7c, 0x84, 0x02, 0xa2, 0x2a, 0x12, 0x14,
+	0x09, 0x65, 0xd9, 0xe1, 0x65, 0xe1, 0x71, 0xb5, 0x8b, 0x58, 0x69, 0x77, 0x84, 0x52, 0x10, 0x12,
+	0x2f, 0x91, 0x93, 0x38, 0xad, 0xa7, 0x49, 0x1c, 0x6c, 0x77, 0x98, 0xce, 0x5f, 0xe1, 0xa7, 0xf1,
+	0x3f, 0x78, 0xe0, 0x09, 0xf9, 0xda, 0xc9, 0x74, 0xdf, 0xda, 0xa7, 0xe6, 0x9c, 0x7b, 0xaf, 0x3f,
+	0xce, 0x39, 0x2e, 0x2c, 0xd3, 0x82, 0xb3, 0x4a, 0x3f, 0x2b, 0xc4, 0xb6, 0x14, 0xd5, 0xb3, 0x5a,
+	0x0a, 0x2d, 0x1c, 0x08, 0x11, 0x90, 0x2f, 0x77, 0x54, 0xed, 0x78, 0x2a, 0x64, 0x1d, 0x56, 0xa2,
+	0xa4, 0x59, 0x68, 0x27, 0xc2, 0xd3, 0xa6, 0xd5, 0xbf, 0x1e, 0x4c, 0x37, 0x9a, 0x4a, 0x1d, 0xb1,
+	0x3f, 0x0f, 0x4c, 0x69, 0xf2, 0x31, 0x0c, 0x0b, 0xb1, 0x8d, 0x33, 0x2e, 0x83, 0xce, 0xb2, 0xb3,
+	0x1e, 0x47, 0x83, 0x42, 0x6c, 0x5f, 0x71, 0x49, 0xd6, 0x30, 0x57, 0x3a, 0x13, 0x07, 0x1d, 0xe7,
+	0xbc, 0x60, 0x71, 0x45, 0x4b, 0x16, 0x74, 0xb1, 0x63, 0x66, 0xf9, 0x9f, 0x78, 0xc1, 0x6e, 0x68,
+	0xc9, 0x5c, 0x27, 0x93, 0xf2, 0xa4, 0xb3, 0xd7, 0x76, 0x32, 0x29, 0xdb, 0xce, 0x4f, 0x61, 0x5c,
+	0xd2, 0x7b, 0x6c, 0x53, 0x81, 0xb7, 0xec, 0xac, 0xfd, 0x68, 0x54, 0xd2, 0x7b, 0x53, 0x57, 0xe4,
+	0x6b, 0x98, 0x37, 0xc5, 0x58, 0xf1, 0x07, 0x16, 0x97, 0x49, 0xd0, 0xc7, 0x1e, 0xdf, 0xf5, 0x6c,
+	0xf8, 0x03, 0x7b, 0x97, 0x90, 0x2f, 0x60, 0xd2, 0x9e, 0x2c, 0x17, 0xc1, 0x00, 0xb7, 0x82, 0xe6,
+	0x50, 0xb9, 0x70, 0x0d, 0xf6, 0x40, 0xb9, 0x08, 0x86, 0x6d, 0x03, 0x9e, 0x25, 0x17, 0xe4, 0x25,
+	0xf4, 0x15, 0xaf, 0xf6, 0x2a, 0x18, 0x2d, 0x7b, 0xeb, 0xc9, 0xf5, 0xb7, 0xe1, 0x19, 0xd2, 0x85,
+	0x6f, 0xc5, 0x76, 0xc3, 0xab, 0x7d, 0x64, 0x47, 0xc9, 0x27, 0x30, 0xa2, 0x45, 0x21, 0xd2, 0x98,
+	0x67, 0xc1, 0x18, 0x77, 0x18, 0x22, 0x7e, 0x93, 0x91, 0xcf, 0x60, 0x6c, 0x44, 0x50, 0x35, 0x4d,
+	0x59, 0x00, 0x58, 0x7b, 0x24, 0xc8, 0x87, 0x30, 0xb8, 0x15, 0x89, 0x19, 0x9b, 0x60, 0xa9, 0x7f,
+	0x2b, 0x92, 0x37, 0x19, 0xf9, 0x1c, 0x60, 0x2b, 0xc5, 0xa1, 0xb6, 0xfa, 0x4d, 0xed, 0x14, 0x32,
+	0x8d, 0x74, 0x9a, 0xaa, 0xbd, 0xad, 0xfa, 0x58, 0x1d, 0x19, 0x02, 0x8b, 0x1f, 0xc1, 0x20, 0x17,
+	0xb2, 0xa4, 0x3a, 0x98, 0x59, 0x0f, 0x2d, 0x22, 0x1f, 0x40, 0xbf, 0x60, 0x77, 0xac, 0x08, 0xae,
+	0xec, 0x4e, 0x08, 0xc8, 0x57, 0x70, 0x25, 0x85, 0xa6, 0x9a, 0xc5, 0xec, 0x8e, 0xc9, 0x63, 0x5c,
+	0xa9, 0x60, 0xbe, 0xec, 0xac, 0x7b, 0x91, 0x6f, 0xe9, 0xd7, 0x86, 0xbd, 0x51, 0x64, 0x09, 0x93,
+	0x54, 0x94, 0xb5, 0x64, 0x4a, 0x71, 0x51, 0x05, 0x4f, 0x71, 0x8d, 0x53, 0x8a, 0x7c, 0x03, 0x4f,
+	0x8d, 0x65, 0x5a, 0x68, 0x5a, 0xb4, 0x9e, 0x11, 0xf4, 0x6c, 0x56, 0xd2, 0xfb, 0x5f, 0x0d, 0x6f,
+	0x4d, 0x5b, 0x5d, 0x81, 0xef, 0x72, 0xa7, 0x6a, 0x51, 0x29, 0xb6, 0xf2, 0x61, 0xb2, 0xd1, 0xa2,
+	0x76, 0x39, 0x5c, 0xcd, 0x60, 0x6a, 0xa1, 0x2b, 0xff, 0xd7, 0x85, 0xa1, 0x53, 0x9c, 0x10, 0xf0,
+	0xf4, 0xb1, 0x66, 0x2e, 0xa0, 0xf8, 0x4d, 0x02, 0x18, 0xd2, 0x2c, 0x33, 0x07, 0x71, 0xa9, 0x6c,
+	0x20, 0x59, 0xc0, 0x28, 0xa7, 0x29, 0x2f, 0xb8, 0x3e, 0xba, 0x18, 0xb6, 0x98, 0xcc, 0xa1, 0xa7,
+	0xe9, 0x16, 0xa3, 0x37, 0x8e, 0xcc, 0x27, 0xf9, 0x05, 0x06, 0x3b, 0x46, 0x33, 0x26, 0x83, 0x3e,
+	0x66, 0xe1, 0xc5, 0x25, 0x59, 0x08, 0x7f, 0xc6, 0xd1, 0xd7, 0x95, 0x96, 0xc7, 0xc8, 0xad, 0x63,
+	0x8c, 0x4c, 0xa8, 0x4e, 0x77, 0x28, 0x08, 0xa6, 0xd3, 0x8f, 0xc6, 0xc8, 0x18, 0x29, 0xc8, 0x0a,
+	0x7c, 0x5b, 0xfe, 0x8b, 0x72, 0x6d, 0xb4, 0x1f, 0xa2, 0xf6, 0x13, 0x24, 0x7f, 0xa7, 0x5c, 0xdf,
+	0x28, 0x13, 0xe0, 0xe4, 0x90, 0xe7, 0x4c, 0xda, 0x35, 0x46, 0xb8, 0x06, 0x58, 0xca, 0x2d, 0x32,
+	0x4d, 0x68, 0xba, 0x47, 0x23, 0x0e, 0x92, 0x61, 0x00, 0x47, 0xd1, 0x7b, 0xdc, 0xe2, 0x07, 0x98,
+	0x9c, 0x1c, 0xcf, 0x5c, 0x7d, 0xcf, 0x8e, 0x4e, 0x43, 0xf3, 0x69, 0xd2, 0x71, 0x47, 0x8b, 0x43,
+	0xf3, 0xac, 0x2d, 0xf8, 0xb1, 0xfb, 0xa2, 0x63, 0xcd, 0xa0, 0x5a, 0x35, 0xe6, 0xfc, 0x06, 0xbe,
+	0xc3, 0xd6, 0x1d, 0xf2, 0xaa, 0x79, 0x40, 0x1d, 0x14, 0x2d, 0x3c, 0x4b, 0x34, 0xa3, 0x98, 0x5d,
+	0xc6, 0x0e, 0xaf, 0xfe, 0xee, 0xc0, 0xb8, 0x25, 0x2f, 0x74, 0x99, 0x80, 0xa7, 0x58, 0xa5, 0xd1,
+	0x61, 0x2f, 0xc2, 0x6f, 0xd3, 0x9d, 0x49, 0x51, 0xd7, 0x2c, 0x43, 0x87, 0xbd, 0xa8, 0x81, 0xf8,
+	0x40, 0x28, 0x2f, 0x58, 0x86, 0xff, 0x28, 0x5e, 0xe4, 0x90, 0xc9, 0x8a, 0x55, 0x95, 0x65, 0xe8,
+	0x94, 0x17, 0xb5, 0xf8, 0xfa, 0x9f, 0x2e, 0x0c, 0xde, 0x8a, 0xed, 0x3b, 0x51, 0x91, 0x1a, 0xfa,
+	0x18, 0x5e, 0xf2, 0xfc, 0xbc, 0x8b, 0x9e, 0xfc, 0xc1, 0x2e, 0xae, 0x2f, 0x19, 0x71, 0xe1, 0x7f,
+	0x42, 0x4a, 0xf0, 0xcc, 0x73, 0x20, 0xdf, 0x9d, 0x39, 0xdd, 0x3e, 0xa4, 0xc5, 0xf3, 0x0b, 0x26,
+	0xda, 0xed, 0xec, 0x05, 0xb5, 0x3a, 0xff, 0x82, 0x5a, 0x5d, 0x7c, 0xc1, 0xc7, 0xfc, 0xac, 0x9e,
+	0xbc, 0x1c, 0xfe, 0xd1, 0xc7, 0x42, 0x32, 0xc0, 0x9f, 0xef, 0xff, 0x1f, 0x00, 0x4e, 0xc6, 0x97,
+	0x6f, 0xe1, 0x06, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -253,6 +627,7 @@ const _ = grpc.SupportPackageIsVersion6
 type LogMonClient interface {
 	Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartResponse, error)
 	Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error)
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
 }
 
 type logMonClient struct {
@@ -281,10 +656,20 @@ func (c *logMonClient) Stop(ctx context.Context, in *StopRequest, opts ...grpc.C
 	return out, nil
 }
 
+func (c *logMonClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.client.logmon.proto.LogMon/Stats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LogMonServer is the server API for LogMon service.
 type LogMonServer interface {
 	Start(context.Context, *StartRequest) (*StartResponse, error)
 	Stop(context.Context, *StopRequest) (*StopResponse, error)
+	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
 }
 
 // UnimplementedLogMonServer can be embedded to have forward compatible implementations.
@@ -297,6 +682,9 @@ func (*UnimplementedLogMonServer) Start(ctx context.Context, req *StartRequest)
 func (*UnimplementedLogMonServer) Stop(ctx context.Context, req *StopRequest) (*StopResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
 }
+func (*UnimplementedLogMonServer) Stats(ctx context.Context, req *StatsRequest) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
 
 func RegisterLogMonServer(s *grpc.Server, srv LogMonServer) {
 	s.RegisterService(&_LogMon_serviceDesc, srv)
@@ -338,6 +726,24 @@ func _LogMon_Stop_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LogMon_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogMonServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.client.logmon.proto.LogMon/Stats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogMonServer).Stats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _LogMon_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hashicorp.nomad.client.logmon.proto.LogMon",
 	HandlerType: (*LogMonServer)(nil),
@@ -350,6 +756,10 @@ var _LogMon_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Stop",
 			Handler:    _LogMon_Stop_Handler,
 		},
+		{
+			MethodName: "Stats",
+			Handler:    _LogMon_Stats_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "client/logmon/proto/logmon.proto",
@@ -1,9 +1,12 @@
 package logmon
 
 import (
+	"time"
+
 	"golang.org/x/net/context"
 
 	plugin "github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/client/logmon/logging"
 	"github.com/hashicorp/nomad/client/logmon/proto"
 )
 
@@ -14,13 +17,38 @@ type logmonServer struct {
 
 func (s *logmonServer) Start(ctx context.Context, req *proto.StartRequest) (*proto.StartResponse, error) {
 	cfg := &LogConfig{
-		LogDir:        req.LogDir,
-		StdoutLogFile: req.StdoutFileName,
-		StderrLogFile: req.StderrFileName,
-		MaxFiles:      int(req.MaxFiles),
-		MaxFileSizeMB: int(req.MaxFileSizeMb),
-		StdoutFifo:    req.StdoutFifo,
-		StderrFifo:    req.StderrFifo,
+		LogDir:         req.LogDir,
+		StdoutLogFile:  req.StdoutFileName,
+		StderrLogFile:  req.StderrFileName,
+		MaxFiles:       int(req.MaxFiles),
+		MaxFileSizeMB:  int(req.MaxFileSizeMb),
+		StdoutFifo:     req.StdoutFifo,
+		StderrFifo:     req.StderrFifo,
+		Format:         req.Format,
+		Level:          req.Level,
+		RotateEvery:    time.Duration(req.RotateEveryNs),
+		Compression:    req.Compression,
+		MaxTotalSizeMB: int(req.MaxTotalSizeMb),
+		Task: &logging.TaskInfo{
+			AllocID:   req.AllocId,
+			Namespace: req.Namespace,
+			JobID:     req.JobId,
+			GroupName: req.GroupName,
+			TaskName:  req.TaskName,
+		},
+	}
+	for _, s := range req.Sinks {
+		cfg.Sinks = append(cfg.Sinks, &logging.SinkConfig{
+			Type:         s.Type,
+			Address:      s.Address,
+			Facility:     s.Facility,
+			Tag:          s.Tag,
+			Header:       s.Header,
+			BatchSize:    int(s.BatchSize),
+			BatchWait:    time.Duration(s.BatchWaitNs),
+			BufferSize:   int(s.BufferSize),
+			Backpressure: s.Backpressure,
+		})
 	}
 
 	err := s.impl.Start(cfg)
@@ -34,3 +62,23 @@ func (s *logmonServer) Start(ctx context.Context, req *proto.StartRequest) (*pro
 func (s *logmonServer) Stop(ctx context.Context, req *proto.StopRequest) (*proto.StopResponse, error) {
 	return &proto.StopResponse{}, s.impl.Stop()
 }
+
+func (s *logmonServer) Stats(ctx context.Context, req *proto.StatsRequest) (*proto.StatsResponse, error) {
+	stats, err := s.impl.Stats()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.StatsResponse{}
+	for _, s := range stats {
+		resp.Sinks = append(resp.Sinks, &proto.SinkStats{
+			Type:     s.Type,
+			Address:  s.Address,
+			Sent:     s.Sent,
+			Dropped:  s.Dropped,
+			Failed:   s.Failed,
+			Buffered: s.Buffered,
+		})
+	}
+	return resp, nil
+}
@@ -362,6 +362,11 @@ func (i *instanceManager) handleFingerprint(fp *drivers.Fingerprint) {
 	for key, attr := range fp.Attributes {
 		attrs[key] = attr.GoString()
 	}
+	if fp.Health == drivers.HealthStateHealthy {
+		for key, value := range i.capabilityAttributes() {
+			attrs[key] = value
+		}
+	}
 	di := &structs.DriverInfo{
 		Attributes:        attrs,
 		Detected:          fp.Health != drivers.HealthStateUndetected,
@@ -389,6 +394,31 @@ func (i *instanceManager) handleFingerprint(fp *drivers.Fingerprint) {
 	}
 }
 
+// capabilityAttributes returns the node attributes of the capabilities of
+// the driver the scheduler relies on, so that it only places or updates
+// allocations in ways the driver supports.
+func (i *instanceManager) capabilityAttributes() map[string]string {
+	driver, err := i.dispense()
+	if err != nil {
+		i.logger.Warn("failed to dispense driver to fingerprint its capabilities", "error", err)
+		return nil
+	}
+	caps, err := driver.Capabilities()
+	if err != nil {
+		i.logger.Warn("failed to fingerprint driver capabilities", "error", err)
+		return nil
+	}
+
+	attrs := map[string]string{}
+	if caps.UpdateResources {
+		attrs[structs.DriverCapabilityAttr(i.id.Name, structs.DriverCapabilityUpdateResources)] = "true"
+	}
+	if caps.GroupResources {
+		attrs[structs.DriverCapabilityAttr(i.id.Name, structs.DriverCapabilityGroupResources)] = "true"
+	}
+	return attrs
+}
+
 // getLastHealth returns the most recent HealthState from fingerprinting
 func (i *instanceManager) getLastHealth() drivers.HealthState {
 	i.lastHealthStateMu.Lock()
@@ -53,6 +53,9 @@ func mockDriver(fpChan chan *drivers.Fingerprint, evChan chan *drivers.TaskEvent
 		TaskEventsF: func(ctx context.Context) (<-chan *drivers.TaskEvent, error) {
 			return evChan, nil
 		},
+		CapabilitiesF: func() (*drivers.Capabilities, error) {
+			return &drivers.Capabilities{UpdateResources: true, GroupResources: true}, nil
+		},
 	}
 }
 
@@ -161,6 +164,9 @@ func TestManager_Fingerprint(t *testing.T) {
 	require.Len(infos, 3)
 	require.True(infos[0].Healthy)
 	require.True(infos[0].Detected)
+	require.Equal("true", infos[0].Attributes["driver.mock.capabilities.update_resources"])
+	require.Equal("true", infos[0].Attributes["driver.mock.capabilities.group_resources"])
+	require.NotContains(infos[1].Attributes, "driver.mock.capabilities.update_resources")
 	require.False(infos[1].Healthy)
 	require.True(infos[1].Detected)
 	require.False(infos[2].Healthy)
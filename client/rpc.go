@@ -19,11 +19,12 @@ import (
 
 // rpcEndpoints holds the RPC endpoints
 type rpcEndpoints struct {
-	ClientStats *ClientStats
-	CSI         *CSI
-	FileSystem  *FileSystem
-	Allocations *Allocations
-	Agent       *Agent
+	ClientStats  *ClientStats
+	ClientImages *ClientImages
+	CSI          *CSI
+	FileSystem   *FileSystem
+	Allocations  *Allocations
+	Agent        *Agent
 }
 
 // ClientRPC is used to make a local, client only RPC call
@@ -256,6 +257,7 @@ func (c *Client) setupClientRpc(rpcs map[string]interface{}) {
 		}
 	} else {
 		c.endpoints.ClientStats = &ClientStats{c}
+		c.endpoints.ClientImages = &ClientImages{c}
 		c.endpoints.CSI = &CSI{c}
 		c.endpoints.FileSystem = NewFileSystemEndpoint(c)
 		c.endpoints.Allocations = NewAllocationsEndpoint(c)
@@ -270,6 +272,7 @@ func (c *Client) setupClientRpc(rpcs map[string]interface{}) {
 func (c *Client) setupClientRpcServer(server *rpc.Server) {
 	// Register the endpoints
 	server.Register(c.endpoints.ClientStats)
+	server.Register(c.endpoints.ClientImages)
 	server.Register(c.endpoints.CSI)
 	server.Register(c.endpoints.FileSystem)
 	server.Register(c.endpoints.Allocations)
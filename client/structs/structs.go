@@ -34,6 +34,21 @@ type ClientStatsResponse struct {
 	structs.QueryMeta
 }
 
+// ImagePrefetchRequest is used to ask a driver on a client node to pull
+// images in the background.
+type ImagePrefetchRequest struct {
+	// NodeID is the node to prefetch the images on
+	NodeID string
+
+	// Driver is the driver pulling the images, docker if not set
+	Driver string
+
+	// Images are the references of the images to pull
+	Images []string
+
+	structs.QueryOptions
+}
+
 // MonitorRequest is used to request and stream logs from a client node.
 type MonitorRequest struct {
 	// LogLevel is the log level filter we want to stream logs on
@@ -153,9 +168,99 @@ type FsLogsRequest struct {
 	// Follow follows logs.
 	Follow bool
 
+	// Since only streams the lines written after the given time. JSON
+	// records are filtered by their time, and other lines by the time their
+	// log file was last written to.
+	Since time.Time
+
+	structs.QueryOptions
+}
+
+// FsSearchLogsRequest is used to search the logs of an allocation.
+type FsSearchLogsRequest struct {
+	// AllocID is the allocation to search the logs of
+	AllocID string
+
+	// Task is the task to search the logs of. All the tasks of the
+	// allocation are searched if it is empty.
+	Task string
+
+	// LogType is either "stdout" or "stderr". Both are searched if it is
+	// empty.
+	LogType string
+
+	// Pattern is the regular expression lines are matched against
+	Pattern string
+
+	// Limit is the maximum number of matches to return
+	Limit int
+
+	structs.QueryOptions
+}
+
+// FsSearchLogsResponse is used to return the lines of the logs of an
+// allocation matching a pattern.
+type FsSearchLogsResponse struct {
+	// Matches are the matching lines, in the order they were written for
+	// each task and log type
+	Matches []*LogMatch
+
+	// Truncated is set if the limit was reached before all the logs were
+	// searched
+	Truncated bool
+
+	structs.QueryMeta
+}
+
+// FsSearchJobLogsRequest is the initial request for searching the logs of
+// all the allocations of a job.
+type FsSearchJobLogsRequest struct {
+	// JobID is the job to search the allocation logs of
+	JobID string
+
+	// Task is the task to search the logs of. All the tasks are searched if
+	// it is empty.
+	Task string
+
+	// LogType is either "stdout" or "stderr". Both are searched if it is
+	// empty.
+	LogType string
+
+	// Pattern is the regular expression lines are matched against
+	Pattern string
+
+	// Limit is the maximum number of matches to return across all the
+	// allocations
+	Limit int
+
 	structs.QueryOptions
 }
 
+// LogMatch is a line of a task's logs matching a search. The results of a
+// search of the logs of a job are streamed as LogMatches. Errors searching
+// the logs of an allocation are returned as a LogMatch with the Error set,
+// and the last result of a search that reached its limit has only Truncated
+// set.
+type LogMatch struct {
+	AllocID string `json:",omitempty"`
+	NodeID  string `json:",omitempty"`
+	Task    string `json:",omitempty"`
+	LogType string `json:",omitempty"`
+
+	// File is the name of the log file and Line the line number of the
+	// match in it
+	File string `json:",omitempty"`
+	Line int    `json:",omitempty"`
+
+	Text string `json:",omitempty"`
+
+	// Error is set if the logs of the allocation couldn't be searched
+	Error string `json:",omitempty"`
+
+	// Truncated is set if there were more matches than the limit
+	Truncated bool `json:",omitempty"`
+}
+
 // StreamErrWrapper is used to serialize output of a stream of a file or logs.
 type StreamErrWrapper struct {
 	// Error stores any error that may have occurred.
@@ -449,6 +449,21 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		conf.RaftBoltNoFreelistSync = bolt.NoFreelistSync
 	}
 
+	// Set the recommender parameters
+	if rc := agentConfig.Server.Recommender; rc != nil {
+		conf.RecommenderEnabled = rc.Enabled
+		if rc.SampleInterval < 0 {
+			return nil, fmt.Errorf("recommender sample_interval must be greater than 0")
+		} else if rc.SampleInterval > 0 {
+			conf.RecommenderInterval = rc.SampleInterval
+		}
+		if rc.MinSamples < 0 {
+			return nil, fmt.Errorf("recommender min_samples must be greater than 0")
+		} else if rc.MinSamples > 0 {
+			conf.RecommenderMinSamples = rc.MinSamples
+		}
+	}
+
 	return conf, nil
 }
 
@@ -526,6 +526,10 @@ type ServerConfig struct {
 
 	// RaftBoltConfig configures boltdb as used by raft.
 	RaftBoltConfig *RaftBoltConfig `hcl:"raft_boltdb"`
+
+	// Recommender configures the recommender of the leader, which recommends
+	// the CPU and memory of tasks from their resource usage.
+	Recommender *RecommenderConfig `hcl:"recommender"`
 }
 
 // RaftBoltConfig is used in servers to configure parameters of the boltdb
@@ -539,6 +543,57 @@ type RaftBoltConfig struct {
 	NoFreelistSync bool `hcl:"no_freelist_sync"`
 }
 
+// RecommenderConfig is used in servers to configure the recommender.
+type RecommenderConfig struct {
+	// Enabled toggles whether the leader samples the resource usage of the
+	// tasks of service and system jobs to recommend their resources.
+	//
+	// Default: false.
+	Enabled bool `hcl:"enabled"`
+
+	// SampleInterval is the time between two samples of the resource usage
+	// of tasks. The default is 1m.
+	SampleInterval    time.Duration
+	SampleIntervalHCL string `hcl:"sample_interval" json:"-"`
+
+	// MinSamples is the number of samples of the resource usage of a task
+	// required before recommending its resources. The default is 60.
+	MinSamples int `hcl:"min_samples"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+// IsEmpty returns whether no recommender parameter is set.
+func (r *RecommenderConfig) IsEmpty() bool {
+	return r == nil || (!r.Enabled && r.SampleInterval == 0 && r.MinSamples == 0)
+}
+
+// Merge is used to merge two recommender configs together
+func (r *RecommenderConfig) Merge(b *RecommenderConfig) *RecommenderConfig {
+	if r == nil {
+		return b
+	}
+
+	result := *r
+
+	if b == nil {
+		return &result
+	}
+
+	if b.Enabled {
+		result.Enabled = true
+	}
+	if b.SampleInterval != 0 {
+		result.SampleInterval = b.SampleInterval
+	}
+	if b.MinSamples != 0 {
+		result.MinSamples = b.MinSamples
+	}
+
+	return &result
+}
+
 // Search is used in servers to configure search API options.
 type Search struct {
 	// FuzzyEnabled toggles whether the FuzzySearch API is enabled. If not
@@ -1602,6 +1657,10 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		}
 	}
 
+	if b.Recommender != nil {
+		result.Recommender = result.Recommender.Merge(b.Recommender)
+	}
+
 	// Add the schedulers
 	result.EnabledSchedulers = append(result.EnabledSchedulers, b.EnabledSchedulers...)
 
@@ -45,7 +45,7 @@ func ParseConfigFile(path string) (*Config, error) {
 		},
 		ACL:       &ACLConfig{},
 		Audit:     &config.AuditConfig{},
-		Server:    &ServerConfig{ServerJoin: &ServerJoin{}},
+		Server:    &ServerConfig{ServerJoin: &ServerJoin{}, Recommender: &RecommenderConfig{}},
 		Consul:    &config.ConsulConfig{},
 		Autopilot: &config.AutopilotConfig{},
 		Telemetry: &Telemetry{},
@@ -68,6 +68,7 @@ func ParseConfigFile(path string) (*Config, error) {
 		{"server.failover_heartbeat_ttl", &c.Server.FailoverHeartbeatTTL, &c.Server.FailoverHeartbeatTTLHCL, nil},
 		{"server.retry_interval", &c.Server.RetryInterval, &c.Server.RetryIntervalHCL, nil},
 		{"server.server_join.retry_interval", &c.Server.ServerJoin.RetryInterval, &c.Server.ServerJoin.RetryIntervalHCL, nil},
+		{"server.recommender.sample_interval", &c.Server.Recommender.SampleInterval, &c.Server.Recommender.SampleIntervalHCL, nil},
 		{"consul.timeout", &c.Consul.Timeout, &c.Consul.TimeoutHCL, nil},
 		{"autopilot.server_stabilization_time", &c.Autopilot.ServerStabilizationTime, &c.Autopilot.ServerStabilizationTimeHCL, nil},
 		{"autopilot.last_contact_threshold", &c.Autopilot.LastContactThreshold, &c.Autopilot.LastContactThresholdHCL, nil},
@@ -144,6 +145,11 @@ func ParseConfigFile(path string) (*Config, error) {
 	// Set client template config or its members to nil if not set.
 	finalizeClientTemplateConfig(c)
 
+	// Set the recommender config to nil if not set.
+	if c.Server.Recommender.IsEmpty() {
+		c.Server.Recommender = nil
+	}
+
 	return c, nil
 }
 
@@ -226,7 +232,7 @@ func extraKeys(c *Config) error {
 		helper.RemoveEqualFold(&c.Audit.ExtraKeysHCL, "sink")
 	}
 
-	for _, k := range []string{"enabled_schedulers", "start_join", "retry_join", "server_join"} {
+	for _, k := range []string{"enabled_schedulers", "start_join", "retry_join", "server_join", "recommender"} {
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, k)
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, "server")
 	}
@@ -140,6 +140,12 @@ var basicConfig = &Config{
 			},
 		},
 		LicensePath: "/tmp/nomad.hclic",
+		Recommender: &RecommenderConfig{
+			Enabled:           true,
+			SampleInterval:    30 * time.Second,
+			SampleIntervalHCL: "30s",
+			MinSamples:        20,
+		},
 	},
 	ACL: &ACLConfig{
 		Enabled:          true,
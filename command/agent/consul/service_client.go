@@ -455,6 +455,11 @@ type ServiceRegistration struct {
 	// status should be evaluated.
 	CheckOnUpdate map[string]string
 
+	// CheckKind is a map of checkIDs and the associated Kind value from the
+	// ServiceCheck. It is used to ignore liveness checks when determining the
+	// health of the service.
+	CheckKind map[string]string
+
 	// Service is the AgentService registered in Consul.
 	Service *api.AgentService
 
@@ -470,6 +475,7 @@ func (s *ServiceRegistration) copy() *ServiceRegistration {
 		serviceID:     s.serviceID,
 		checkIDs:      helper.CopyMapStringStruct(s.checkIDs),
 		CheckOnUpdate: helper.CopyMapStringString(s.CheckOnUpdate),
+		CheckKind:     helper.CopyMapStringString(s.CheckKind),
 	}
 }
 
@@ -1042,6 +1048,7 @@ func (c *ServiceClient) serviceRegs(ops *operations, service *structs.Service, w
 		serviceID:     id,
 		checkIDs:      make(map[string]struct{}, len(service.Checks)),
 		CheckOnUpdate: make(map[string]string, len(service.Checks)),
+		CheckKind:     make(map[string]string, len(service.Checks)),
 	}
 
 	// Service address modes default to auto
@@ -1051,7 +1058,7 @@ func (c *ServiceClient) serviceRegs(ops *operations, service *structs.Service, w
 	}
 
 	// Determine the address to advertise based on the mode
-	ip, port, err := getAddress(addrMode, service.PortLabel, workload.Networks, workload.DriverNetwork, workload.Ports, workload.NetworkStatus)
+	ip, port, err := GetAddress(addrMode, service.PortLabel, workload.Networks, workload.DriverNetwork, workload.Ports, workload.NetworkStatus)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get address for service %q: %v", service.Name, err)
 	}
@@ -1181,7 +1188,7 @@ func (c *ServiceClient) checkRegs(serviceID string, service *structs.Service,
 			}
 
 			var err error
-			ip, port, err = getAddress(addrMode, portLabel, workload.Networks, workload.DriverNetwork, workload.Ports, workload.NetworkStatus)
+			ip, port, err = GetAddress(addrMode, portLabel, workload.Networks, workload.DriverNetwork, workload.Ports, workload.NetworkStatus)
 			if err != nil {
 				return nil, fmt.Errorf("error getting address for check %q: %v", check.Name, err)
 			}
@@ -1193,6 +1200,7 @@ func (c *ServiceClient) checkRegs(serviceID string, service *structs.Service,
 			return nil, fmt.Errorf("failed to add check %q: %v", check.Name, err)
 		}
 		sreg.CheckOnUpdate[checkID] = check.OnUpdate
+		sreg.CheckKind[checkID] = check.Kind
 		registrations = append(registrations, registration)
 	}
 
@@ -1289,6 +1297,7 @@ func (c *ServiceClient) UpdateWorkload(old, newWorkload *WorkloadServices) error
 			serviceID:     existingID,
 			checkIDs:      make(map[string]struct{}, len(newSvc.Checks)),
 			CheckOnUpdate: make(map[string]string, len(newSvc.Checks)),
+			CheckKind:     make(map[string]string, len(newSvc.Checks)),
 		}
 		regs.Services[existingID] = sreg
 
@@ -1301,12 +1310,14 @@ func (c *ServiceClient) UpdateWorkload(old, newWorkload *WorkloadServices) error
 		// Register new checks
 		for _, check := range newSvc.Checks {
 			checkID := MakeCheckID(existingID, check)
-			if _, exists := existingChecks[checkID]; exists {
+			existingCheck, exists := existingChecks[checkID]
+			if exists {
 				// Check is still required. Remove it from the map so it doesn't get
 				// deleted later.
 				delete(existingChecks, checkID)
 				sreg.checkIDs[checkID] = struct{}{}
 				sreg.CheckOnUpdate[checkID] = check.OnUpdate
+				sreg.CheckKind[checkID] = check.Kind
 			}
 
 			// New check on an unchanged service; add them now
@@ -1318,12 +1329,16 @@ func (c *ServiceClient) UpdateWorkload(old, newWorkload *WorkloadServices) error
 			for _, registration := range checkRegs {
 				sreg.checkIDs[registration.ID] = struct{}{}
 				sreg.CheckOnUpdate[registration.ID] = check.OnUpdate
+				sreg.CheckKind[registration.ID] = check.Kind
 				ops.regChecks = append(ops.regChecks, registration)
 			}
 
-			// Update all watched checks as CheckRestart fields aren't part of ID
+			// Update all watched checks as CheckRestart and Kind fields aren't
+			// part of ID
 			if check.TriggersRestarts() {
 				c.checkWatcher.Watch(newWorkload.AllocID, newWorkload.Name(), checkID, check, newWorkload.Restarter)
+			} else if exists && existingCheck.TriggersRestarts() {
+				c.checkWatcher.Unwatch(checkID)
 			}
 		}
 
@@ -1769,10 +1784,10 @@ func getNomadSidecar(id string, services map[string]*api.AgentService) *api.Agen
 	return services[sidecarID]
 }
 
-// getAddress returns the IP and port to use for a service or check. If no port
+// GetAddress returns the IP and port to use for a service or check. If no port
 // label is specified (an empty value), zero values are returned because no
 // address could be resolved.
-func getAddress(addrMode, portLabel string, networks structs.Networks, driverNet *drivers.DriverNetwork, ports structs.AllocatedPorts, netStatus *structs.AllocNetworkStatus) (string, int, error) {
+func GetAddress(addrMode, portLabel string, networks structs.Networks, driverNet *drivers.DriverNetwork, ports structs.AllocatedPorts, netStatus *structs.AllocNetworkStatus) (string, int, error) {
 	switch addrMode {
 	case structs.AddressModeAuto:
 		if driverNet.Advertise() {
@@ -1780,7 +1795,7 @@ func getAddress(addrMode, portLabel string, networks structs.Networks, driverNet
 		} else {
 			addrMode = structs.AddressModeHost
 		}
-		return getAddress(addrMode, portLabel, networks, driverNet, ports, netStatus)
+		return GetAddress(addrMode, portLabel, networks, driverNet, ports, netStatus)
 	case structs.AddressModeHost:
 		if portLabel == "" {
 			if len(networks) != 1 {
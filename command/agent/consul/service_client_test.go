@@ -447,6 +447,7 @@ func TestServiceRegistration_CheckOnUpdate(t *testing.T) {
 	wsUpdate := new(WorkloadServices)
 	*wsUpdate = *ws
 	wsUpdate.Services[0].Checks[0].OnUpdate = structs.OnUpdateRequireHealthy
+	wsUpdate.Services[0].Checks[0].Kind = structs.ServiceCheckReadiness
 
 	require.NoError(t, sc.UpdateWorkload(ws, wsUpdate))
 
@@ -463,6 +464,12 @@ func TestServiceRegistration_CheckOnUpdate(t *testing.T) {
 		for _, onupdate := range sreg.CheckOnUpdate {
 			require.Equal(t, structs.OnUpdateRequireHealthy, onupdate)
 		}
+
+		// Ensure that CheckKind was updated as well
+		require.NotEmpty(t, sreg.CheckKind)
+		for _, kind := range sreg.CheckKind {
+			require.Equal(t, structs.ServiceCheckReadiness, kind)
+		}
 	}
 }
 
@@ -103,3 +103,28 @@ func (ws *WorkloadServices) Name() string {
 
 	return "group-" + ws.Group
 }
+
+// ConsulServices returns a copy of the workload without the services using
+// the nomad provider, which are not registered in Consul.
+func (ws *WorkloadServices) ConsulServices() *WorkloadServices {
+	return ws.filterServices(false)
+}
+
+// NomadServices returns a copy of the workload with only the services using
+// the nomad provider, whose checks are run by the client.
+func (ws *WorkloadServices) NomadServices() *WorkloadServices {
+	return ws.filterServices(true)
+}
+
+func (ws *WorkloadServices) filterServices(nomad bool) *WorkloadServices {
+	filtered := new(WorkloadServices)
+	*filtered = *ws
+
+	filtered.Services = make([]*structs.Service, 0, len(ws.Services))
+	for _, service := range ws.Services {
+		if (service.Provider == structs.ServiceProviderNomad) == nomad {
+			filtered.Services = append(filtered.Services, service)
+		}
+	}
+	return filtered
+}
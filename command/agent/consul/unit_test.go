@@ -1685,8 +1685,8 @@ func TestGetAddress(t *testing.T) {
 				i++
 			}
 
-			// Run getAddress
-			ip, port, err := getAddress(tc.Mode, tc.PortLabel, networks, tc.Driver, tc.Ports, tc.Status)
+			// Run GetAddress
+			ip, port, err := GetAddress(tc.Mode, tc.PortLabel, networks, tc.Driver, tc.Ports, tc.Status)
 
 			// Assert the results
 			assert.Equal(t, tc.ExpectedIP, ip, "IP mismatch")
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/hashicorp/go-msgpack/codec"
@@ -312,6 +313,16 @@ func (s *HTTPServer) Logs(resp http.ResponseWriter, req *http.Request) (interfac
 		return nil, invalidOrigin
 	}
 
+	// since is either a time or a duration before now
+	var since time.Time
+	if sinceStr := q.Get("since"); sinceStr != "" {
+		if d, err := time.ParseDuration(sinceStr); err == nil {
+			since = time.Now().Add(-d)
+		} else if since, err = time.Parse(time.RFC3339, sinceStr); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("failed to parse since field as a duration or RFC3339 time: %v", sinceStr))
+		}
+	}
+
 	// Create the request arguments
 	fsReq := &cstructs.FsLogsRequest{
 		AllocID:   allocID,
@@ -321,6 +332,7 @@ func (s *HTTPServer) Logs(resp http.ResponseWriter, req *http.Request) (interfac
 		Origin:    origin,
 		PlainText: plain,
 		Follow:    follow,
+		Since:     since,
 	}
 	s.parse(resp, req, &fsReq.QueryOptions.Region, &fsReq.QueryOptions)
 
@@ -336,6 +348,57 @@ func (s *HTTPServer) Logs(resp http.ResponseWriter, req *http.Request) (interfac
 	return s.fsStreamImpl(resp, req, "FileSystem.Logs", fsReq, fsReq.AllocID)
 }
 
+// jobLogsSearch is used to search the logs of all the allocations of a job
+// for lines matching a regular expression. The matches are streamed as
+// newline delimited JSON objects.
+func (s *HTTPServer) jobLogsSearch(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	q := req.URL.Query()
+	args := &cstructs.FsSearchJobLogsRequest{
+		JobID:   jobID,
+		Task:    q.Get("task"),
+		LogType: q.Get("type"),
+		Pattern: q.Get("pattern"),
+	}
+	if args.Pattern == "" {
+		return nil, CodedError(400, "must provide a pattern")
+	}
+	switch args.LogType {
+	case "", "stdout", "stderr":
+	default:
+		return nil, CodedError(400, fmt.Sprintf("invalid log type %q", args.LogType))
+	}
+	if limitStr := q.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return nil, CodedError(400, fmt.Sprintf("failed to parse limit field: %v", err))
+		}
+		args.Limit = limit
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+
+	// Determine the RPC handler to use to find a server
+	var handler structs.StreamingRpcHandler
+	var handlerErr error
+	if server := s.agent.Server(); server != nil {
+		handler, handlerErr = server.StreamingRpcHandler("FileSystem.SearchJobLogs")
+	} else if client := s.agent.Client(); client != nil {
+		handler, handlerErr = client.RemoteStreamingRpcHandler("FileSystem.SearchJobLogs")
+	} else {
+		handlerErr = fmt.Errorf("misconfigured connection")
+	}
+
+	if handlerErr != nil {
+		return nil, CodedError(500, handlerErr.Error())
+	}
+
+	resp.Header().Set("Content-Type", "application/x-ndjson")
+	return s.streamRpcImpl(resp, req, handler, args)
+}
+
 // fsStreamImpl is used to make a streaming filesystem call that serializes the
 // args and then expects a stream of StreamErrWrapper results where the payload
 // is copied to the response body.
@@ -358,6 +421,15 @@ func (s *HTTPServer) fsStreamImpl(resp http.ResponseWriter,
 		return nil, CodedError(500, handlerErr.Error())
 	}
 
+	return s.streamRpcImpl(resp, req, handler, args)
+}
+
+// streamRpcImpl is used to make a streaming call to the handler that
+// serializes the args and then expects a stream of StreamErrWrapper results
+// where the payload is copied to the response body.
+func (s *HTTPServer) streamRpcImpl(resp http.ResponseWriter,
+	req *http.Request, handler structs.StreamingRpcHandler, args interface{}) (interface{}, error) {
+
 	// Create a pipe connecting the (possibly remote) handler to the http response
 	httpPipe, handlerPipe := net.Pipe()
 	decoder := codec.NewDecoder(httpPipe, structs.MsgpackHandle)
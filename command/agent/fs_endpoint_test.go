@@ -219,6 +219,60 @@ func TestHTTP_FS_Stream_MissingParams(t *testing.T) {
 
 // TestHTTP_FS_Logs_MissingParams asserts proper error codes and messages are
 // returned for incorrect parameters (eg missing tasks).
+func TestHTTP_JobLogsSearch(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Pattern Not Present
+		req, err := http.NewRequest("GET", "/v1/job/foo/logs/search", nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		s.Server.mux.ServeHTTP(respW, req)
+		require.Equal("must provide a pattern", respW.Body.String())
+		require.Equal(400, respW.Code)
+
+		// Invalid Log Type
+		req, err = http.NewRequest("GET", "/v1/job/foo/logs/search?pattern=error&type=stdin", nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+
+		s.Server.mux.ServeHTTP(respW, req)
+		require.Equal(`invalid log type "stdin"`, respW.Body.String())
+		require.Equal(400, respW.Code)
+
+		// Invalid Limit
+		req, err = http.NewRequest("GET", "/v1/job/foo/logs/search?pattern=error&limit=many", nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+
+		s.Server.mux.ServeHTTP(respW, req)
+		require.Contains(respW.Body.String(), "failed to parse limit field")
+		require.Equal(400, respW.Code)
+
+		// Unknown Job
+		req, err = http.NewRequest("GET", "/v1/job/foo/logs/search?pattern=error", nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+
+		s.Server.mux.ServeHTTP(respW, req)
+		require.Contains(respW.Body.String(), `job "foo" not found`)
+		require.Equal(404, respW.Code)
+
+		// Job without allocations
+		job := mock.Job()
+		require.NoError(s.Agent.Server().State().UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+		req, err = http.NewRequest("GET", "/v1/job/"+job.ID+"/logs/search?pattern=error", nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+
+		s.Server.mux.ServeHTTP(respW, req)
+		require.Equal(200, respW.Code)
+		require.Empty(respW.Body.String())
+		require.Equal("application/x-ndjson", respW.Header().Get("Content-Type"))
+	})
+}
+
 func TestHTTP_FS_Logs_MissingParams(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
@@ -250,6 +304,15 @@ func TestHTTP_FS_Logs_MissingParams(t *testing.T) {
 		require.Equal(respW.Body.String(), logTypeNotPresentErr.Error())
 		require.Equal(400, respW.Code)
 
+		// Invalid since
+		req, err = http.NewRequest("GET", "/v1/client/fs/logs/foo?task=foo&type=stdout&since=yesterday", nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+
+		s.Server.mux.ServeHTTP(respW, req)
+		require.Contains(respW.Body.String(), "failed to parse since field")
+		require.Equal(400, respW.Code)
+
 		// case where all parameters are set but alloc isn't found
 		req, err = http.NewRequest("GET", "/v1/client/fs/logs/foo?task=foo&type=stdout", nil)
 		require.NoError(err)
@@ -328,6 +328,7 @@ func (s HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.Handle("/v1/client/fs/", wrapCORS(s.wrap(s.FsRequest)))
 	s.mux.HandleFunc("/v1/client/gc", s.wrap(s.ClientGCRequest))
 	s.mux.Handle("/v1/client/stats", wrapCORS(s.wrap(s.ClientStatsRequest)))
+	s.mux.HandleFunc("/v1/client/images/prefetch", s.wrap(s.ClientImagesPrefetchRequest))
 	s.mux.Handle("/v1/client/allocation/", wrapCORS(s.wrap(s.ClientAllocRequest)))
 
 	s.mux.HandleFunc("/v1/agent/self", s.wrap(s.AgentSelfRequest))
@@ -381,6 +382,11 @@ func (s HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/namespace", s.wrap(s.NamespaceCreateRequest))
 	s.mux.HandleFunc("/v1/namespace/", s.wrap(s.NamespaceSpecificRequest))
 
+	s.mux.HandleFunc("/v1/recommendations", s.wrap(s.RecommendationsListRequest))
+	s.mux.HandleFunc("/v1/recommendations/apply", s.wrap(s.RecommendationsApplyRequest))
+	s.mux.HandleFunc("/v1/recommendation", s.wrap(s.RecommendationCreateRequest))
+	s.mux.HandleFunc("/v1/recommendation/", s.wrap(s.RecommendationSpecificRequest))
+
 	uiConfigEnabled := s.agent.config.UI != nil && s.agent.config.UI.Enabled
 
 	if uiEnabled && uiConfigEnabled {
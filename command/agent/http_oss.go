@@ -16,11 +16,6 @@ func (s *HTTPServer) registerEnterpriseHandlers() {
 	s.mux.HandleFunc("/v1/quota-usages", s.wrap(s.entOnly))
 	s.mux.HandleFunc("/v1/quota/", s.wrap(s.entOnly))
 	s.mux.HandleFunc("/v1/quota", s.wrap(s.entOnly))
-
-	s.mux.HandleFunc("/v1/recommendation", s.wrap(s.entOnly))
-	s.mux.HandleFunc("/v1/recommendations", s.wrap(s.entOnly))
-	s.mux.HandleFunc("/v1/recommendations/apply", s.wrap(s.entOnly))
-	s.mux.HandleFunc("/v1/recommendation/", s.wrap(s.entOnly))
 }
 
 func (s *HTTPServer) entOnly(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
@@ -0,0 +1,66 @@
+package agent
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientImagesPrefetchRequest(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Only writes are allowed
+		{
+			req, err := http.NewRequest("GET", "/v1/client/images/prefetch", nil)
+			require.NoError(err)
+
+			_, err = s.Server.ClientImagesPrefetchRequest(httptest.NewRecorder(), req)
+			require.EqualError(err, ErrInvalidMethod)
+		}
+
+		// Images are required
+		{
+			req, err := http.NewRequest("PUT", "/v1/client/images/prefetch", encodeReq(&api.ImagePrefetchRequest{}))
+			require.NoError(err)
+
+			_, err = s.Server.ClientImagesPrefetchRequest(httptest.NewRecorder(), req)
+			require.EqualError(err, "missing images")
+		}
+
+		// Local node, the request reaches the driver
+		{
+			req, err := http.NewRequest("PUT", "/v1/client/images/prefetch", encodeReq(&api.ImagePrefetchRequest{
+				Driver: "mock_driver",
+				Images: []string{"redis:7"},
+			}))
+			require.NoError(err)
+
+			_, err = s.Server.ClientImagesPrefetchRequest(httptest.NewRecorder(), req)
+			require.Error(err)
+			require.Contains(err.Error(), `driver "mock_driver" doesn't support prefetching images`)
+		}
+
+		// Unknown node
+		{
+			srv := s.server
+			s.server = nil
+
+			req, err := http.NewRequest("PUT", fmt.Sprintf("/v1/client/images/prefetch?node_id=%s", uuid.Generate()), encodeReq(&api.ImagePrefetchRequest{
+				Images: []string{"redis:7"},
+			}))
+			require.NoError(err)
+
+			_, err = s.Server.ClientImagesPrefetchRequest(httptest.NewRecorder(), req)
+			require.Error(err)
+			require.Contains(err.Error(), "Unknown node")
+
+			s.server = srv
+		}
+	})
+}
@@ -86,6 +86,9 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/scale"):
 		jobName := strings.TrimSuffix(path, "/scale")
 		return s.jobScale(resp, req, jobName)
+	case strings.HasSuffix(path, "/logs/search"):
+		jobName := strings.TrimSuffix(path, "/logs/search")
+		return s.jobLogsSearch(resp, req, jobName)
 	default:
 		return s.jobCRUD(resp, req, path)
 	}
@@ -915,6 +918,24 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 			Payload:      job.ParameterizedJob.Payload,
 			MetaRequired: job.ParameterizedJob.MetaRequired,
 			MetaOptional: job.ParameterizedJob.MetaOptional,
+			MaxRunning:   job.ParameterizedJob.MaxRunning,
+		}
+
+		if rate := job.ParameterizedJob.Rate; rate != nil {
+			j.ParameterizedJob.Rate = &structs.DispatchRateConfig{
+				Limit: rate.Limit,
+				Burst: rate.Burst,
+			}
+		}
+	}
+
+	if l := len(job.DependsOn); l != 0 {
+		j.DependsOn = make([]*structs.JobDependency, l)
+		for i, dep := range job.DependsOn {
+			j.DependsOn[i] = &structs.JobDependency{
+				JobID:     dep.JobID,
+				Condition: dep.Condition,
+			}
 		}
 	}
 
@@ -957,12 +978,7 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 	tg.Services = ApiServicesToStructs(taskGroup.Services, true)
 	tg.Consul = apiConsulToStructs(taskGroup.Consul)
 
-	tg.RestartPolicy = &structs.RestartPolicy{
-		Attempts: *taskGroup.RestartPolicy.Attempts,
-		Interval: *taskGroup.RestartPolicy.Interval,
-		Delay:    *taskGroup.RestartPolicy.Delay,
-		Mode:     *taskGroup.RestartPolicy.Mode,
-	}
+	tg.RestartPolicy = ApiRestartPolicyToStructs(taskGroup.RestartPolicy)
 
 	if taskGroup.ShutdownDelay != nil {
 		tg.ShutdownDelay = taskGroup.ShutdownDelay
@@ -972,6 +988,17 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		tg.StopAfterClientDisconnect = taskGroup.StopAfterClientDisconnect
 	}
 
+	if taskGroup.Stateful != nil {
+		tg.Stateful = *taskGroup.Stateful
+	}
+
+	if taskGroup.Resources != nil {
+		tg.Resources = &structs.TaskGroupResources{
+			CPU:      *taskGroup.Resources.CPU,
+			MemoryMB: *taskGroup.Resources.MemoryMB,
+		}
+	}
+
 	if taskGroup.ReschedulePolicy != nil {
 		tg.ReschedulePolicy = &structs.ReschedulePolicy{
 			Attempts:      *taskGroup.ReschedulePolicy.Attempts,
@@ -1091,17 +1118,27 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 	structsTask.KillTimeout = *apiTask.KillTimeout
 	structsTask.ShutdownDelay = apiTask.ShutdownDelay
 	structsTask.KillSignal = apiTask.KillSignal
+	structsTask.DependsOn = apiTask.DependsOn
+	structsTask.DependsOnCondition = apiTask.DependsOnCondition
 	structsTask.Kind = structs.TaskKind(apiTask.Kind)
 	structsTask.Constraints = ApiConstraintsToStructs(apiTask.Constraints)
 	structsTask.Affinities = ApiAffinitiesToStructs(apiTask.Affinities)
 	structsTask.CSIPluginConfig = ApiCSIPluginConfigToStructsCSIPluginConfig(apiTask.CSIPluginConfig)
 
 	if apiTask.RestartPolicy != nil {
-		structsTask.RestartPolicy = &structs.RestartPolicy{
-			Attempts: *apiTask.RestartPolicy.Attempts,
-			Interval: *apiTask.RestartPolicy.Interval,
-			Delay:    *apiTask.RestartPolicy.Delay,
-			Mode:     *apiTask.RestartPolicy.Mode,
+		structsTask.RestartPolicy = ApiRestartPolicyToStructs(apiTask.RestartPolicy)
+	}
+
+	if apiTask.ShutdownHook != nil {
+		structsTask.ShutdownHook = &structs.TaskShutdownHook{
+			Type:      apiTask.ShutdownHook.Type,
+			PortLabel: apiTask.ShutdownHook.PortLabel,
+			Path:      apiTask.ShutdownHook.Path,
+			Method:    apiTask.ShutdownHook.Method,
+			Protocol:  apiTask.ShutdownHook.Protocol,
+			Command:   apiTask.ShutdownHook.Command,
+			Args:      apiTask.ShutdownHook.Args,
+			Timeout:   apiTask.ShutdownHook.Timeout,
 		}
 	}
 
@@ -1133,10 +1170,7 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 
 	structsTask.Resources = ApiResourcesToStructs(apiTask.Resources)
 
-	structsTask.LogConfig = &structs.LogConfig{
-		MaxFiles:      *apiTask.LogConfig.MaxFiles,
-		MaxFileSizeMB: *apiTask.LogConfig.MaxFileSizeMB,
-	}
+	structsTask.LogConfig = apiLogConfigToStructs(apiTask.LogConfig)
 
 	if len(apiTask.Artifacts) > 0 {
 		structsTask.Artifacts = []*structs.TaskArtifact{}
@@ -1197,6 +1231,26 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 	}
 }
 
+// ApiRestartPolicyToStructs is a copy and type conversion between the API
+// representation of a RestartPolicy and its struct representation.
+func ApiRestartPolicyToStructs(rp *api.RestartPolicy) *structs.RestartPolicy {
+	out := &structs.RestartPolicy{
+		Attempts: *rp.Attempts,
+		Interval: *rp.Interval,
+		Delay:    *rp.Delay,
+		Mode:     *rp.Mode,
+	}
+
+	if rp.DelayFunction != nil {
+		out.DelayFunction = *rp.DelayFunction
+	}
+	if rp.MaxDelay != nil {
+		out.MaxDelay = *rp.MaxDelay
+	}
+
+	return out
+}
+
 // ApiWaitConfigToStructsWaitConfig is a copy and type conversion between the API
 // representation of a WaitConfig from a struct representation of a WaitConfig.
 func ApiWaitConfigToStructsWaitConfig(waitConfig *api.WaitConfig) *structs.WaitConfig {
@@ -1332,6 +1386,7 @@ func ApiServicesToStructs(in []*api.Service, group bool) []*structs.Service {
 			Meta:              helper.CopyMapStringString(s.Meta),
 			CanaryMeta:        helper.CopyMapStringString(s.CanaryMeta),
 			OnUpdate:          s.OnUpdate,
+			Provider:          s.Provider,
 		}
 
 		if l := len(s.Checks); l != 0 {
@@ -1363,6 +1418,7 @@ func ApiServicesToStructs(in []*api.Service, group bool) []*structs.Service {
 					SuccessBeforePassing:   check.SuccessBeforePassing,
 					FailuresBeforeCritical: check.FailuresBeforeCritical,
 					OnUpdate:               onUpdate,
+					Kind:                   check.Kind,
 				}
 
 				if group {
@@ -1658,10 +1714,42 @@ func apiLogConfigToStructs(in *api.LogConfig) *structs.LogConfig {
 	if in == nil {
 		return nil
 	}
-	return &structs.LogConfig{
+	out := &structs.LogConfig{
 		MaxFiles:      dereferenceInt(in.MaxFiles),
 		MaxFileSizeMB: dereferenceInt(in.MaxFileSizeMB),
 	}
+	if in.Format != nil {
+		out.Format = *in.Format
+	}
+	if in.Level != nil {
+		out.Level = *in.Level
+	}
+	if in.RotateEvery != nil {
+		out.RotateEvery = *in.RotateEvery
+	}
+	if in.Compression != nil {
+		out.Compression = *in.Compression
+	}
+	out.MaxTotalSizeMB = dereferenceInt(in.MaxTotalSizeMB)
+	for _, sink := range in.Sinks {
+		s := &structs.LogSink{
+			Type:       sink.Type,
+			Address:    sink.Address,
+			Facility:   sink.Facility,
+			Tag:        sink.Tag,
+			Header:     helper.CopyMapStringString(sink.Header),
+			BatchSize:  dereferenceInt(sink.BatchSize),
+			BufferSize: dereferenceInt(sink.BufferSize),
+		}
+		if sink.BatchWait != nil {
+			s.BatchWait = *sink.BatchWait
+		}
+		if sink.Backpressure != nil {
+			s.Backpressure = *sink.Backpressure
+		}
+		out.Sinks = append(out.Sinks, s)
+	}
+	return out
 }
 
 func dereferenceInt(in *int) int {
@@ -2295,6 +2295,12 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 				},
 			},
 		},
+		DependsOn: []*api.JobDependency{
+			{
+				JobID:     "upstream",
+				Condition: "complete",
+			},
+		},
 		Periodic: &api.PeriodicConfig{
 			Enabled:         helper.BoolToPtr(true),
 			Spec:            helper.StringToPtr("spec"),
@@ -2306,6 +2312,11 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			Payload:      "payload",
 			MetaRequired: []string{"a", "b"},
 			MetaOptional: []string{"c", "d"},
+			MaxRunning:   5,
+			Rate: &api.DispatchRateConfig{
+				Limit: 0.5,
+				Burst: 10,
+			},
 		},
 		Payload: []byte("payload"),
 		Meta: map[string]string{
@@ -2481,10 +2492,12 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							},
 						},
 						RestartPolicy: &api.RestartPolicy{
-							Interval: helper.TimeToPtr(2 * time.Second),
-							Attempts: helper.IntToPtr(10),
-							Delay:    helper.TimeToPtr(20 * time.Second),
-							Mode:     helper.StringToPtr("delay"),
+							Interval:      helper.TimeToPtr(2 * time.Second),
+							Attempts:      helper.IntToPtr(10),
+							Delay:         helper.TimeToPtr(20 * time.Second),
+							Mode:          helper.StringToPtr("delay"),
+							DelayFunction: helper.StringToPtr("fibonacci"),
+							MaxDelay:      helper.TimeToPtr(time.Minute),
 						},
 						Services: []*api.Service{
 							{
@@ -2586,8 +2599,18 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 						Meta: map[string]string{
 							"lol": "code",
 						},
-						KillTimeout: helper.TimeToPtr(10 * time.Second),
-						KillSignal:  "SIGQUIT",
+						KillTimeout:        helper.TimeToPtr(10 * time.Second),
+						KillSignal:         "SIGQUIT",
+						DependsOn:          []string{"setup"},
+						DependsOnCondition: "complete",
+						ShutdownHook: &api.TaskShutdownHook{
+							Type:      "http",
+							PortLabel: "http",
+							Path:      "/drain",
+							Method:    "POST",
+							Protocol:  "http",
+							Timeout:   15 * time.Second,
+						},
 						LogConfig: &api.LogConfig{
 							MaxFiles:      helper.IntToPtr(10),
 							MaxFileSizeMB: helper.IntToPtr(100),
@@ -2687,6 +2710,12 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			Stagger:     1 * time.Second,
 			MaxParallel: 5,
 		},
+		DependsOn: []*structs.JobDependency{
+			{
+				JobID:     "upstream",
+				Condition: "complete",
+			},
+		},
 		Periodic: &structs.PeriodicConfig{
 			Enabled:         true,
 			Spec:            "spec",
@@ -2698,6 +2727,11 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			Payload:      "payload",
 			MetaRequired: []string{"a", "b"},
 			MetaOptional: []string{"c", "d"},
+			MaxRunning:   5,
+			Rate: &structs.DispatchRateConfig{
+				Limit: 0.5,
+				Burst: 10,
+			},
 		},
 		Payload: []byte("payload"),
 		Meta: map[string]string{
@@ -2876,10 +2910,12 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							},
 						},
 						RestartPolicy: &structs.RestartPolicy{
-							Interval: 2 * time.Second,
-							Attempts: 10,
-							Delay:    20 * time.Second,
-							Mode:     "delay",
+							Interval:      2 * time.Second,
+							Attempts:      10,
+							Delay:         20 * time.Second,
+							Mode:          "delay",
+							DelayFunction: "fibonacci",
+							MaxDelay:      time.Minute,
 						},
 						Services: []*structs.Service{
 							{
@@ -2983,8 +3019,18 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 						Meta: map[string]string{
 							"lol": "code",
 						},
-						KillTimeout: 10 * time.Second,
-						KillSignal:  "SIGQUIT",
+						KillTimeout:        10 * time.Second,
+						KillSignal:         "SIGQUIT",
+						DependsOn:          []string{"setup"},
+						DependsOnCondition: "complete",
+						ShutdownHook: &structs.TaskShutdownHook{
+							Type:      "http",
+							PortLabel: "http",
+							Path:      "/drain",
+							Method:    "POST",
+							Protocol:  "http",
+							Timeout:   15 * time.Second,
+						},
 						LogConfig: &structs.LogConfig{
 							MaxFiles:      10,
 							MaxFileSizeMB: 100,
@@ -3434,6 +3480,67 @@ func TestConversion_apiLogConfigToStructs(t *testing.T) {
 	}))
 }
 
+func TestConversion_apiLogConfigToStructs_Sinks(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, &structs.LogConfig{
+		MaxFiles:      2,
+		MaxFileSizeMB: 8,
+		Sinks: []*structs.LogSink{{
+			Type:         structs.LogSinkTypeHTTP,
+			Address:      "https://logs.example.com",
+			Header:       map[string]string{"Authorization": "token"},
+			BatchSize:    50,
+			BatchWait:    2 * time.Second,
+			BufferSize:   100,
+			Backpressure: true,
+		}},
+	}, apiLogConfigToStructs(&api.LogConfig{
+		MaxFiles:      helper.IntToPtr(2),
+		MaxFileSizeMB: helper.IntToPtr(8),
+		Sinks: []*api.LogSink{{
+			Type:         "http",
+			Address:      "https://logs.example.com",
+			Header:       map[string]string{"Authorization": "token"},
+			BatchSize:    helper.IntToPtr(50),
+			BatchWait:    helper.TimeToPtr(2 * time.Second),
+			BufferSize:   helper.IntToPtr(100),
+			Backpressure: helper.BoolToPtr(true),
+		}},
+	}))
+}
+
+func TestConversion_apiLogConfigToStructs_Format(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, &structs.LogConfig{
+		MaxFiles:      2,
+		MaxFileSizeMB: 8,
+		Format:        structs.LogFormatJSON,
+		Level:         "warn",
+	}, apiLogConfigToStructs(&api.LogConfig{
+		MaxFiles:      helper.IntToPtr(2),
+		MaxFileSizeMB: helper.IntToPtr(8),
+		Format:        helper.StringToPtr("json"),
+		Level:         helper.StringToPtr("warn"),
+	}))
+}
+
+func TestConversion_apiLogConfigToStructs_Rotation(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, &structs.LogConfig{
+		MaxFiles:       2,
+		MaxFileSizeMB:  8,
+		RotateEvery:    time.Hour,
+		Compression:    structs.LogCompressionGzip,
+		MaxTotalSizeMB: 12,
+	}, apiLogConfigToStructs(&api.LogConfig{
+		MaxFiles:       helper.IntToPtr(2),
+		MaxFileSizeMB:  helper.IntToPtr(8),
+		RotateEvery:    helper.TimeToPtr(time.Hour),
+		Compression:    helper.StringToPtr("gzip"),
+		MaxTotalSizeMB: helper.IntToPtr(12),
+	}))
+}
+
 func TestConversion_apiResourcesToStructs(t *testing.T) {
 	t.Parallel()
 
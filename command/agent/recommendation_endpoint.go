@@ -0,0 +1,113 @@
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func (s *HTTPServer) RecommendationsListRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	query := req.URL.Query()
+	args := structs.RecommendationListRequest{
+		JobID: query.Get("job"),
+		Group: query.Get("group"),
+		Task:  query.Get("task"),
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.RecommendationListResponse
+	if err := s.agent.RPC("Recommendation.ListRecommendations", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Recommendations == nil {
+		out.Recommendations = make([]*structs.Recommendation, 0)
+	}
+	return out.Recommendations, nil
+}
+
+func (s *HTTPServer) RecommendationSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	id := strings.TrimPrefix(req.URL.Path, "/v1/recommendation/")
+	if len(id) == 0 {
+		return nil, CodedError(400, "Missing Recommendation ID")
+	}
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.RecommendationSpecificRequest{
+		RecommendationID: id,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.SingleRecommendationResponse
+	if err := s.agent.RPC("Recommendation.GetRecommendation", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Recommendation == nil {
+		return nil, CodedError(404, "Recommendation not found")
+	}
+	return out.Recommendation, nil
+}
+
+func (s *HTTPServer) RecommendationCreateRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "PUT" && req.Method != "POST" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var rec structs.Recommendation
+	if err := decodeBody(req, &rec); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
+	args := structs.RecommendationUpsertRequest{
+		Recommendations: []*structs.Recommendation{&rec},
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+	if rec.Namespace != "" {
+		args.Namespace = rec.Namespace
+	}
+
+	var out structs.RecommendationUpsertResponse
+	if err := s.agent.RPC("Recommendation.UpsertRecommendations", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	if len(out.Recommendations) == 0 {
+		return nil, nil
+	}
+	return out.Recommendations[0], nil
+}
+
+func (s *HTTPServer) RecommendationsApplyRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "PUT" && req.Method != "POST" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.RecommendationApplyRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.RecommendationApplyResponse
+	if err := s.agent.RPC("Recommendation.ApplyRecommendations", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out, nil
+}
@@ -0,0 +1,73 @@
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_RecommendationCRUD(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		job := mock.Job()
+		regReq := structs.JobRegisterRequest{
+			Job:          job,
+			WriteRequest: structs.WriteRequest{Region: "global", Namespace: job.Namespace},
+		}
+		var regResp structs.JobRegisterResponse
+		require.NoError(s.Agent.RPC("Job.Register", &regReq, &regResp))
+
+		// Create a recommendation
+		rec := mock.Recommendation(job)
+		rec.ID = ""
+		buf := encodeReq(rec)
+		req, err := http.NewRequest("POST", "/v1/recommendation", buf)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.RecommendationCreateRequest(respW, req)
+		require.NoError(err)
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+		out := obj.(*structs.Recommendation)
+		require.NotEmpty(out.ID)
+		require.Equal(job.TaskGroups[0].Tasks[0].Resources.CPU, out.Current)
+
+		// List the recommendations of the job
+		req, err = http.NewRequest("GET", "/v1/recommendations?job="+job.ID, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.RecommendationsListRequest(respW, req)
+		require.NoError(err)
+		require.Len(obj.([]*structs.Recommendation), 1)
+
+		// Get the recommendation
+		req, err = http.NewRequest("GET", "/v1/recommendation/"+out.ID, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.RecommendationSpecificRequest(respW, req)
+		require.NoError(err)
+		require.Equal(out.ID, obj.(*structs.Recommendation).ID)
+
+		// Apply the recommendation
+		buf = encodeReq(structs.RecommendationApplyRequest{Apply: []string{out.ID}})
+		req, err = http.NewRequest("POST", "/v1/recommendations/apply", buf)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.RecommendationsApplyRequest(respW, req)
+		require.NoError(err)
+		applyResp := obj.(structs.RecommendationApplyResponse)
+		require.Len(applyResp.UpdatedJobs, 1)
+		require.Empty(applyResp.Errors)
+
+		// The applied recommendation is deleted
+		req, err = http.NewRequest("GET", "/v1/recommendation/"+out.ID, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.RecommendationSpecificRequest(respW, req)
+		require.EqualError(err, "Recommendation not found")
+	})
+}
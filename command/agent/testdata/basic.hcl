@@ -139,6 +139,12 @@ server {
     retry_interval = "15s"
   }
 
+  recommender {
+    enabled         = true
+    sample_interval = "30s"
+    min_samples     = 20
+  }
+
   default_scheduler_config {
     scheduler_algorithm = "spread"
 
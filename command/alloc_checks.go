@@ -0,0 +1,221 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type AllocChecksCommand struct {
+	Meta
+}
+
+func (c *AllocChecksCommand) Help() string {
+	helpText := `
+Usage: nomad alloc checks [options] <allocation>
+
+  Display the latest results of the checks of the services of an allocation
+  using the nomad provider. These checks are run by the client of the
+  allocation instead of Consul.
+
+  When ACLs are enabled, this command requires a token with the 'read-job'
+  capability for the allocation's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Checks Specific Options:
+
+  -verbose
+    Show full information, including the output of the checks.
+
+  -json
+    Output the check results in their JSON format.
+
+  -t
+    Format and display the check results using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AllocChecksCommand) Synopsis() string {
+	return "Display the check results of an allocation"
+}
+
+func (c *AllocChecksCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-verbose": complete.PredictNothing,
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+		})
+}
+
+func (c *AllocChecksCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Allocs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Allocs]
+	})
+}
+
+func (c *AllocChecksCommand) Name() string { return "alloc checks" }
+
+func (c *AllocChecksCommand) Run(args []string) int {
+	var verbose, json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one alloc
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <alloc-id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	allocID := args[0]
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Query the allocation info
+	if len(allocID) == 1 {
+		c.Ui.Error("Alloc ID must contain at least two characters.")
+		return 1
+	}
+
+	allocID = sanitizeUUIDPrefix(allocID)
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	allocs, _, err := client.Allocations().PrefixList(allocID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocation: %v", err))
+		return 1
+	}
+
+	if len(allocs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No allocation(s) with prefix or id %q found", allocID))
+		return 1
+	}
+
+	if len(allocs) > 1 {
+		// Format the allocs
+		out := formatAllocListStubs(allocs, verbose, length)
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple allocations\n\n%s", out))
+		return 1
+	}
+
+	// Prefix lookup matched a single allocation
+	q := &api.QueryOptions{Namespace: allocs[0].Namespace}
+	alloc, _, err := client.Allocations().Info(allocs[0].ID, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocation: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, alloc.CheckResults)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	if len(alloc.CheckResults) == 0 {
+		c.Ui.Output(fmt.Sprintf("No check results for allocation %q", limit(alloc.ID, length)))
+		return 0
+	}
+
+	c.Ui.Output(formatCheckResults(alloc.CheckResults, verbose))
+	return 0
+}
+
+// formatCheckResults formats the check results sorted by service, task and
+// check name. The output of the checks is only shown in verbose mode.
+func formatCheckResults(results map[string]*api.CheckResult, verbose bool) string {
+	sorted := make([]*api.CheckResult, 0, len(results))
+	for _, result := range results {
+		sorted = append(sorted, result)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		if a.Task != b.Task {
+			return a.Task < b.Task
+		}
+		return a.Check < b.Check
+	})
+
+	if !verbose {
+		out := make([]string, 0, len(sorted)+1)
+		out = append(out, "Service|Task|Check|Type|Status|Last Run")
+		for _, r := range sorted {
+			out = append(out, fmt.Sprintf("%s|%s|%s|%s|%s|%s",
+				r.Service, r.Task, r.Check, r.Type, r.Status, formatCheckTime(r)))
+		}
+		return formatList(out)
+	}
+
+	blocks := make([]string, 0, len(sorted))
+	for _, r := range sorted {
+		basic := []string{
+			fmt.Sprintf("ID|%s", r.ID),
+			fmt.Sprintf("Service|%s", r.Service),
+			fmt.Sprintf("Task|%s", r.Task),
+			fmt.Sprintf("Check|%s", r.Check),
+			fmt.Sprintf("Type|%s", r.Type),
+			fmt.Sprintf("On Update|%s", r.OnUpdate),
+			fmt.Sprintf("Status|%s", r.Status),
+			fmt.Sprintf("Status Code|%d", r.StatusCode),
+			fmt.Sprintf("Last Run|%s", formatCheckTime(r)),
+		}
+		blocks = append(blocks, fmt.Sprintf("%s\n\nOutput:\n%s",
+			formatKV(basic), strings.TrimSpace(r.Output)))
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// formatCheckTime formats the time of the last run of the check, which is
+// zero until the check has run once.
+func formatCheckTime(r *api.CheckResult) string {
+	if r.Timestamp.IsZero() {
+		return "<none>"
+	}
+	return formatTime(r.Timestamp)
+}
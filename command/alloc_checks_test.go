@@ -0,0 +1,113 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocChecksCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &AllocChecksCommand{}
+}
+
+func TestAllocChecksCommand_Fails(t *testing.T) {
+	t.Parallel()
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	require := require.New(t)
+	ui := cli.NewMockUi()
+	cmd := &AllocChecksCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	require.Equal(1, cmd.Run([]string{"some", "garbage", "args"}))
+	require.Contains(ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	require.Equal(1, cmd.Run([]string{"-address=nope", "foobar"}))
+	require.Contains(ui.ErrorWriter.String(), "Error querying allocation")
+	ui.ErrorWriter.Reset()
+
+	// Fails on missing alloc
+	require.Equal(1, cmd.Run([]string{"-address=" + url, "26470238-5CF2-438F-8772-DC67CFB0705C"}))
+	require.Contains(ui.ErrorWriter.String(), "No allocation(s) with prefix or id")
+	ui.ErrorWriter.Reset()
+
+	// Fail on identifier with too few characters
+	require.Equal(1, cmd.Run([]string{"-address=" + url, "2"}))
+	require.Contains(ui.ErrorWriter.String(), "must contain at least two characters")
+	ui.ErrorWriter.Reset()
+}
+
+func TestAllocChecksCommand_Run(t *testing.T) {
+	t.Parallel()
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	require := require.New(t)
+	state := srv.Agent.Server().State()
+
+	// An allocation without nomad checks
+	a1 := mock.Alloc()
+	a1.Metrics = &structs.AllocMetric{}
+
+	// An allocation with the results of nomad checks
+	a2 := mock.Alloc()
+	a2.Metrics = &structs.AllocMetric{}
+	a2.CheckResults = map[string]*structs.CheckResult{
+		"_nomad-check-1": {
+			ID:         "_nomad-check-1",
+			Service:    "web",
+			Check:      "alive",
+			Group:      "web",
+			Task:       "web",
+			Type:       structs.ServiceCheckHTTP,
+			OnUpdate:   structs.OnUpdateRequireHealthy,
+			Status:     structs.CheckStatusCritical,
+			StatusCode: 503,
+			Output:     "HTTP GET http://127.0.0.1:8080/health: 503 Service Unavailable Output: down",
+			Timestamp:  time.Now(),
+		},
+		"_nomad-check-2": {
+			ID:       "_nomad-check-2",
+			Service:  "web",
+			Check:    "port",
+			Group:    "web",
+			Task:     "web",
+			Type:     structs.ServiceCheckTCP,
+			OnUpdate: structs.OnUpdateRequireHealthy,
+			Status:   structs.CheckStatusCritical,
+		},
+	}
+	require.NoError(state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{a1, a2}))
+
+	ui := cli.NewMockUi()
+	cmd := &AllocChecksCommand{Meta: Meta{Ui: ui}}
+
+	require.Equal(0, cmd.Run([]string{"-address=" + url, a1.ID}))
+	require.Contains(ui.OutputWriter.String(), "No check results")
+	ui.OutputWriter.Reset()
+
+	require.Equal(0, cmd.Run([]string{"-address=" + url, a2.ID}))
+	out := ui.OutputWriter.String()
+	require.Contains(out, "alive")
+	require.Contains(out, "port")
+	require.Contains(out, "<none>")
+	require.NotContains(out, "Service Unavailable")
+	ui.OutputWriter.Reset()
+
+	require.Equal(0, cmd.Run([]string{"-address=" + url, "-verbose", a2.ID}))
+	out = ui.OutputWriter.String()
+	require.Contains(out, "_nomad-check-1")
+	require.Contains(out, "Service Unavailable")
+	ui.OutputWriter.Reset()
+
+	require.Equal(0, cmd.Run([]string{"-address=" + url, "-json", a2.ID}))
+	require.Contains(ui.OutputWriter.String(), `"StatusCode": 503`)
+}
@@ -63,6 +63,17 @@ Logs Specific Options:
   -c
     Sets the tail location in number of bytes relative to the end of the logs.
 
+  -filter <expression>
+    Only shows the lines matching the filter expression. Lines are matched on
+    the fields of JSON records, and other lines on a "message" field holding
+    the line. Fields missing from a record are evaluated as empty strings.
+    When combined with -tail, the filter applies to the tail of the logs.
+
+  -since <duration|time>
+    Only shows the lines written after the given RFC3339 time, or in the given
+    duration before now such as "15m". JSON records are filtered by their
+    time, and other lines by the time their log file was last written to.
+
   Note that the -no-color option applies to Nomad's own output. If the task's
   logs include terminal escape sequences for color codes, Nomad will not
   remove them.
@@ -86,6 +97,8 @@ func (l *AllocLogsCommand) AutocompleteFlags() complete.Flags {
 			"-tail":    complete.PredictAnything,
 			"-n":       complete.PredictAnything,
 			"-c":       complete.PredictAnything,
+			"-filter":  complete.PredictAnything,
+			"-since":   complete.PredictAnything,
 		})
 }
 
@@ -109,7 +122,7 @@ func (l *AllocLogsCommand) Name() string { return "alloc logs" }
 func (l *AllocLogsCommand) Run(args []string) int {
 	var verbose, job, tail, stderr, follow bool
 	var numLines, numBytes int64
-	var task string
+	var task, filter, since string
 
 	flags := l.Meta.FlagSet(l.Name(), FlagSetClient)
 	flags.Usage = func() { l.Ui.Output(l.Help()) }
@@ -121,6 +134,8 @@ func (l *AllocLogsCommand) Run(args []string) int {
 	flags.Int64Var(&numLines, "n", -1, "")
 	flags.Int64Var(&numBytes, "c", -1, "")
 	flags.StringVar(&task, "task", "", "")
+	flags.StringVar(&filter, "filter", "", "")
+	flags.StringVar(&since, "since", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -218,11 +233,19 @@ func (l *AllocLogsCommand) Run(args []string) int {
 		logType = "stderr"
 	}
 
+	logsQuery := &api.QueryOptions{
+		Namespace: alloc.Namespace,
+		Filter:    filter,
+	}
+	if since != "" {
+		logsQuery.Params = map[string]string{"since": since}
+	}
+
 	// We have a file, output it.
 	var r io.ReadCloser
 	var readErr error
 	if !tail {
-		r, readErr = l.followFile(client, alloc, follow, task, logType, api.OriginStart, 0)
+		r, readErr = l.followFile(client, alloc, follow, task, logType, api.OriginStart, 0, logsQuery)
 		if readErr != nil {
 			readErr = fmt.Errorf("Error reading file: %v", readErr)
 		}
@@ -241,7 +264,7 @@ func (l *AllocLogsCommand) Run(args []string) int {
 			numLines = defaultTailLines
 		}
 
-		r, readErr = l.followFile(client, alloc, follow, task, logType, api.OriginEnd, offset)
+		r, readErr = l.followFile(client, alloc, follow, task, logType, api.OriginEnd, offset, logsQuery)
 
 		// If numLines is set, wrap the reader
 		if numLines != -1 {
@@ -271,10 +294,10 @@ func (l *AllocLogsCommand) Run(args []string) int {
 // followFile outputs the contents of the file to stdout relative to the end of
 // the file.
 func (l *AllocLogsCommand) followFile(client *api.Client, alloc *api.Allocation,
-	follow bool, task, logType, origin string, offset int64) (io.ReadCloser, error) {
+	follow bool, task, logType, origin string, offset int64, q *api.QueryOptions) (io.ReadCloser, error) {
 
 	cancel := make(chan struct{})
-	frames, errCh := client.AllocFS().Logs(alloc, follow, task, logType, origin, offset, cancel, nil)
+	frames, errCh := client.AllocFS().Logs(alloc, follow, task, logType, origin, offset, cancel, q)
 	select {
 	case err := <-errCh:
 		return nil, err
@@ -147,6 +147,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"alloc checks": func() (cli.Command, error) {
+			return &AllocChecksCommand{
+				Meta: meta,
+			}, nil
+		},
 		"alloc exec": func() (cli.Command, error) {
 			return &AllocExecCommand{
 				Meta: meta,
@@ -350,6 +355,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job logs": func() (cli.Command, error) {
+			return &JobLogsCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job periodic": func() (cli.Command, error) {
 			return &JobPeriodicCommand{
 				Meta: meta,
@@ -480,6 +490,16 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"node image": func() (cli.Command, error) {
+			return &NodeImageCommand{
+				Meta: meta,
+			}, nil
+		},
+		"node image prefetch": func() (cli.Command, error) {
+			return &NodeImagePrefetchCommand{
+				Meta: meta,
+			}, nil
+		},
 		"node-status": func() (cli.Command, error) {
 			return &NodeStatusCommand{
 				Meta: meta,
@@ -192,6 +192,11 @@ func (c *JobDispatchCommand) Run(args []string) int {
 	}
 	c.Ui.Output(formatKV(basic))
 
+	if resp.Queued {
+		c.Ui.Output("\nJob queued: the parameterized job has reached its max_running limit.\n" +
+			"An evaluation will be created once running children complete.")
+	}
+
 	// Nothing to do
 	if detach || !evalCreated {
 		return 0
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/hashicorp/nomad/jobspec2/compose"
 	"github.com/posener/complete"
 )
 
@@ -36,6 +38,13 @@ Init Options:
 
   -connect
     If the connect flag is set, the jobspec includes Consul Connect integration.
+
+  -from-compose <path>
+    Converts the given docker-compose file into a job instead of writing the
+    example. Each service runs as a docker task in a single task group that
+    shares a bridge network. Settings that can't be converted are reported as
+    warnings. The job is named after the directory holding the compose file
+    unless the file sets a name.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -47,7 +56,9 @@ func (c *JobInitCommand) Synopsis() string {
 func (c *JobInitCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-short": complete.PredictNothing,
+			"-short":        complete.PredictNothing,
+			"-connect":      complete.PredictNothing,
+			"-from-compose": complete.PredictFiles("*.y*ml"),
 		})
 }
 
@@ -60,11 +71,13 @@ func (c *JobInitCommand) Name() string { return "job init" }
 func (c *JobInitCommand) Run(args []string) int {
 	var short bool
 	var connect bool
+	var fromCompose string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&short, "short", false, "")
 	flags.BoolVar(&connect, "connect", false, "")
+	flags.StringVar(&fromCompose, "from-compose", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -95,6 +108,10 @@ func (c *JobInitCommand) Run(args []string) int {
 		return 1
 	}
 
+	if fromCompose != "" {
+		return c.writeFromCompose(fromCompose, filename)
+	}
+
 	var jobSpec []byte
 	switch {
 	case connect && !short:
@@ -124,3 +141,41 @@ func (c *JobInitCommand) Run(args []string) int {
 	c.Ui.Output(fmt.Sprintf("Example job file written to %s", filename))
 	return 0
 }
+
+// writeFromCompose converts a docker-compose file into a jobspec.
+func (c *JobInitCommand) writeFromCompose(path, filename string) int {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to read compose file: %v", err))
+		return 1
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to resolve '%s': %v", path, err))
+		return 1
+	}
+	name := filepath.Base(filepath.Dir(abs))
+
+	job, warnings, err := compose.Convert(name, src)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to convert compose file: %v", err))
+		return 1
+	}
+	jobSpec, err := compose.Render(job)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to render job: %v", err))
+		return 1
+	}
+
+	if err := ioutil.WriteFile(filename, jobSpec, 0660); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to write '%s': %v", filename, err))
+		return 1
+	}
+
+	for _, w := range warnings {
+		c.Ui.Warn(fmt.Sprintf("Warning: %s", w))
+	}
+	c.Ui.Output(fmt.Sprintf("Job file converted from %s written to %s", path, filename))
+	return 0
+}
@@ -3,9 +3,11 @@ package command
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/nomad/jobspec2"
 	"github.com/mitchellh/cli"
 	"github.com/stretchr/testify/require"
 )
@@ -143,3 +145,49 @@ func TestInitCommand_customFilename(t *testing.T) {
 		t.Fatalf("expect file exists error, got: %s", out)
 	}
 }
+
+func TestInitCommand_fromCompose(t *testing.T) {
+	t.Parallel()
+	ui := cli.NewMockUi()
+	cmd := &JobInitCommand{Meta: Meta{Ui: ui}}
+
+	dir, err := ioutil.TempDir("", "nomad")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	composeFile := filepath.Join(dir, "docker-compose.yml")
+	require.NoError(t, ioutil.WriteFile(composeFile, []byte(`
+services:
+  web:
+    image: nginx:1.21
+    ports: ["8080:80"]
+    depends_on: [cache]
+  cache:
+    image: redis:6
+    healthcheck:
+      test: ["CMD", "redis-cli", "ping"]
+`), 0600))
+	filename := filepath.Join(dir, "app.nomad")
+
+	code := cmd.Run([]string{"-from-compose", composeFile, filename})
+	require.Zero(t, code, "stderr: %s", ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "written to "+filename)
+	require.Contains(t, ui.ErrorWriter.String(), `service "cache": healthcheck isn't supported`)
+
+	f, err := os.Open(filename)
+	require.NoError(t, err)
+	defer f.Close()
+
+	job, err := jobspec2.Parse(filename, f)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Base(dir), *job.ID)
+	require.Len(t, job.TaskGroups[0].Tasks, 2)
+	require.Equal(t, "prestart", job.TaskGroups[0].Tasks[0].Lifecycle.Hook)
+
+	// Fails if the compose file can't be converted
+	ui.ErrorWriter.Reset()
+	require.NoError(t, ioutil.WriteFile(composeFile, []byte("services:\n  web:\n    build: .\n"), 0600))
+	code = cmd.Run([]string{"-from-compose", composeFile, filepath.Join(dir, "other.nomad")})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "image is required")
+}
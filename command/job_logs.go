@@ -0,0 +1,220 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type JobLogsCommand struct {
+	Meta
+}
+
+func (c *JobLogsCommand) Help() string {
+	helpText := `
+Usage: nomad job logs -grep <pattern> [options] <job>
+
+  Search the logs of all the allocations of a job for lines matching a regular
+  expression. The logs are searched by the clients running the allocations,
+  including rotated and compressed log files, and the matching lines are
+  displayed as they are found, prefixed by the allocation, log file and line
+  number.
+
+  When ACLs are enabled, this command requires a token with the 'read-logs'
+  and 'list-jobs' capabilities for the job's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Logs Options:
+
+  -grep <pattern>
+    The regular expression lines are matched against, using the RE2 syntax.
+    Required.
+
+  -task <task>
+    Only search the logs of the given task.
+
+  -stdout
+    Only search stdout logs.
+
+  -stderr
+    Only search stderr logs.
+
+  -limit <n>
+    The maximum number of matching lines to display. Defaults to the limit of
+    the servers, 1000.
+
+  -json
+    Output the matches in a JSON format, one per line.
+
+  -verbose
+    Display full allocation IDs.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobLogsCommand) Synopsis() string {
+	return "Search the logs of the allocations of a job"
+}
+
+func (c *JobLogsCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-grep":    complete.PredictAnything,
+			"-task":    complete.PredictAnything,
+			"-stdout":  complete.PredictNothing,
+			"-stderr":  complete.PredictNothing,
+			"-limit":   complete.PredictAnything,
+			"-json":    complete.PredictNothing,
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *JobLogsCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobLogsCommand) Name() string { return "job logs" }
+
+func (c *JobLogsCommand) Run(args []string) int {
+	var stdout, stderr, jsonOutput, verbose bool
+	var pattern, task string
+	var matchLimit int
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&pattern, "grep", "", "")
+	flags.StringVar(&task, "task", "", "")
+	flags.BoolVar(&stdout, "stdout", false, "")
+	flags.BoolVar(&stderr, "stderr", false, "")
+	flags.IntVar(&matchLimit, "limit", 0, "")
+	flags.BoolVar(&jsonOutput, "json", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one job
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <job>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if pattern == "" {
+		c.Ui.Error("A pattern must be given with -grep")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if stdout && stderr {
+		c.Ui.Error("-stdout and -stderr are exclusive")
+		return 1
+	}
+	logType := ""
+	if stdout {
+		logType = "stdout"
+	} else if stderr {
+		logType = "stderr"
+	}
+
+	if matchLimit < 0 {
+		c.Ui.Error("-limit must not be negative")
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	jobID := strings.TrimSpace(args[0])
+
+	// Check if the job exists
+	jobs, _, err := client.Jobs().PrefixList(jobID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing jobs: %s", err))
+		return 1
+	}
+	if len(jobs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No job(s) with prefix or id %q found", jobID))
+		return 1
+	}
+	if len(jobs) > 1 {
+		if (jobID != jobs[0].ID) || (c.allNamespaces() && jobs[0].ID == jobs[1].ID) {
+			c.Ui.Error(fmt.Sprintf("Prefix matched multiple jobs\n\n%s", createStatusListOutput(jobs, c.allNamespaces())))
+			return 1
+		}
+	}
+
+	q := &api.QueryOptions{Namespace: jobs[0].JobSummary.Namespace}
+	opts := &api.LogSearchOptions{
+		Pattern: pattern,
+		Task:    task,
+		LogType: logType,
+		Limit:   matchLimit,
+	}
+
+	cancel := make(chan struct{})
+	defer close(cancel)
+	matches, errCh := client.Jobs().SearchLogs(jobs[0].ID, opts, cancel, q)
+
+	for {
+		select {
+		case err := <-errCh:
+			c.Ui.Error(fmt.Sprintf("Error searching logs: %s", err))
+			return 1
+		case m, ok := <-matches:
+			if !ok {
+				return 0
+			}
+
+			if jsonOutput {
+				out, err := json.Marshal(m)
+				if err != nil {
+					c.Ui.Error(fmt.Sprintf("Error formatting match: %s", err))
+					return 1
+				}
+				c.Ui.Output(string(out))
+				continue
+			}
+
+			switch {
+			case m.Error != "":
+				c.Ui.Warn(fmt.Sprintf("Error searching the logs of allocation %q: %s",
+					limit(m.AllocID, length), m.Error))
+			case m.Truncated:
+				c.Ui.Warn("Matches truncated: refine the pattern or raise -limit")
+			default:
+				c.Ui.Output(fmt.Sprintf("%s/%s:%d: %s", limit(m.AllocID, length), m.File, m.Line, m.Text))
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobLogsCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &JobLogsCommand{}
+}
+
+func TestJobLogsCommand_Fails(t *testing.T) {
+	t.Parallel()
+	ui := cli.NewMockUi()
+	cmd := &JobLogsCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"-grep", "error", "some", "bad", "args"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails without a pattern
+	code = cmd.Run([]string{"example"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "A pattern must be given with -grep")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-grep", "error", "-stdout", "-stderr", "example"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "-stdout and -stderr are exclusive")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=nope", "-grep", "error", "example"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "Error listing jobs")
+	ui.ErrorWriter.Reset()
+}
+
+func TestJobLogsCommand_NoMatches(t *testing.T) {
+	t.Parallel()
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	// Create a job without allocations
+	state := srv.Agent.Server().State()
+	j := mock.Job()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, j))
+
+	ui := cli.NewMockUi()
+	cmd := &JobLogsCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url, "-grep", "error", j.ID})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Empty(t, ui.OutputWriter.String())
+
+	// Invalid patterns are rejected by the servers
+	code = cmd.Run([]string{"-address=" + url, "-grep", "(", j.ID})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "invalid pattern")
+}
+
+func TestJobLogsCommand_AutocompleteArgs(t *testing.T) {
+	t.Parallel()
+
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &JobLogsCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// Create a fake job
+	state := srv.Agent.Server().State()
+	j := mock.Job()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, j))
+
+	prefix := j.ID[:len(j.ID)-5]
+	args := complete.Args{Last: prefix}
+	predictor := cmd.AutocompleteArgs()
+
+	res := predictor.Predict(args)
+	require.Equal(t, []string{j.ID}, res)
+}
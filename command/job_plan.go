@@ -670,7 +670,7 @@ func colorAnnotations(annotations []string) string {
 			colored[i] = fmt.Sprintf("[green]%s[reset]", annotation)
 		case "forces destroy":
 			colored[i] = fmt.Sprintf("[red]%s[reset]", annotation)
-		case "forces in-place update":
+		case "forces in-place update", "forces in-place (resources) update":
 			colored[i] = fmt.Sprintf("[cyan]%s[reset]", annotation)
 		case "forces create/destroy update":
 			colored[i] = fmt.Sprintf("[yellow]%s[reset]", annotation)
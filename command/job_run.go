@@ -325,6 +325,13 @@ func (c *JobRunCommand) Run(args []string) int {
 
 	evalID := resp.EvalID
 
+	// Jobs blocked on their dependencies have no evaluation yet
+	if evalID == "" && len(job.DependsOn) > 0 {
+		c.Ui.Output("Job registration successful")
+		c.Ui.Output("Job is waiting for its dependencies to be satisfied")
+		return 0
+	}
+
 	// Check if we should enter monitor mode
 	if detach || periodic || paramjob || multiregion {
 		c.Ui.Output("Job registration successful")
@@ -210,6 +210,10 @@ func (c *JobStatusCommand) Run(args []string) int {
 		}
 	}
 
+	if len(job.DependsOn) > 0 {
+		basic = append(basic, fmt.Sprintf("Dependencies Blocked|%v", job.DependenciesBlocked))
+	}
+
 	c.Ui.Output(formatKV(basic))
 
 	// Exit early
@@ -217,6 +221,14 @@ func (c *JobStatusCommand) Run(args []string) int {
 		return 0
 	}
 
+	// Print the upstream jobs this job depends on
+	if len(job.DependsOn) > 0 {
+		if err := c.outputDependencies(client, job); err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+	}
+
 	// Print periodic job information
 	if periodic && !parameterized {
 		if err := c.outputPeriodicInfo(client, job); err != nil {
@@ -277,6 +289,32 @@ func (c *JobStatusCommand) outputPeriodicInfo(client *api.Client, job *api.Job)
 	return nil
 }
 
+// outputDependencies prints the upstream jobs the passed job depends on along
+// with their current status. If a request fails, an error is returned.
+func (c *JobStatusCommand) outputDependencies(client *api.Client, job *api.Job) error {
+	q := &api.QueryOptions{Namespace: *job.Namespace}
+
+	out := make([]string, 1, len(job.DependsOn)+1)
+	out[0] = "Job ID|Condition|Status"
+	for _, dep := range job.DependsOn {
+		status := "<not found>"
+		upstream, _, err := client.Jobs().Info(dep.JobID, q)
+		if err != nil {
+			if !strings.Contains(err.Error(), "404") {
+				return fmt.Errorf("Error querying job %q: %s", dep.JobID, err)
+			}
+		} else if upstream.Status != nil {
+			status = *upstream.Status
+		}
+
+		out = append(out, fmt.Sprintf("%s|%s|%s", dep.JobID, dep.Condition, status))
+	}
+
+	c.Ui.Output(c.Colorize().Color("\n[bold]Dependencies[reset]"))
+	c.Ui.Output(formatList(out))
+	return nil
+}
+
 // outputParameterizedInfo prints information about a parameterized job. If a
 // request fails, an error is returned.
 func (c *JobStatusCommand) outputParameterizedInfo(client *api.Client, job *api.Job) error {
@@ -569,6 +607,12 @@ func (c *JobStatusCommand) outputJobSummary(client *api.Client, job *api.Job) er
 		summaries[0] = "Pending|Running|Dead"
 		summaries[1] = fmt.Sprintf("%d|%d|%d",
 			summary.Children.Pending, summary.Children.Running, summary.Children.Dead)
+
+		// Dispatches can only be queued by parameterized jobs
+		if parameterizedJob {
+			summaries[0] = "Queued|" + summaries[0]
+			summaries[1] = fmt.Sprintf("%d|%s", summary.Children.Queued, summaries[1])
+		}
 		c.Ui.Output(formatList(summaries))
 	}
 
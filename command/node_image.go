@@ -0,0 +1,39 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+type NodeImageCommand struct {
+	Meta
+}
+
+func (c *NodeImageCommand) Help() string {
+	helpText := `
+Usage: nomad node image <subcommand> [options] [args]
+
+  This command groups subcommands for managing the images cached by the task
+  drivers of a node.
+
+  Pull an image on a node in the background so that the first allocation
+  using it starts quickly:
+
+      $ nomad node image prefetch -node <node-id> redis:7
+
+  Please see the individual subcommand help for detailed usage information.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeImageCommand) Synopsis() string {
+	return "Manage the images cached by nodes"
+}
+
+func (c *NodeImageCommand) Name() string { return "node image" }
+
+func (c *NodeImageCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
@@ -0,0 +1,156 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type NodeImagePrefetchCommand struct {
+	Meta
+}
+
+func (c *NodeImagePrefetchCommand) Help() string {
+	helpText := `
+Usage: nomad node image prefetch [options] <image>...
+
+  Asks a task driver of a node to pull images in the background, so that they
+  are cached before allocations use them. The command returns once the pulls
+  are started. Pull failures are reported in the client's logs, and cached
+  images are fingerprinted as node attributes once pulled.
+
+  It is required that either -node or -self is specified.
+
+  If ACLs are enabled, this option requires a token with the 'node:write'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Node Image Prefetch Options:
+
+  -node=<node-id>
+    The ID of the node pulling the images.
+
+  -self
+    Pull the images on the local node.
+
+  -driver=<driver>
+    The task driver pulling the images. Defaults to "docker".
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeImagePrefetchCommand) Synopsis() string {
+	return "Pull images on a node in the background"
+}
+
+func (c *NodeImagePrefetchCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-node": complete.PredictFunc(func(a complete.Args) []string {
+				client, err := c.Meta.Client()
+				if err != nil {
+					return nil
+				}
+
+				resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Nodes, nil)
+				if err != nil {
+					return []string{}
+				}
+				return resp.Matches[contexts.Nodes]
+			}),
+			"-self":   complete.PredictNothing,
+			"-driver": complete.PredictAnything,
+		})
+}
+
+func (c *NodeImagePrefetchCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *NodeImagePrefetchCommand) Name() string { return "node image prefetch" }
+
+func (c *NodeImagePrefetchCommand) Run(args []string) int {
+	var nodeID, driver string
+	var self bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&nodeID, "node", "", "")
+	flags.BoolVar(&self, "self", false, "")
+	flags.StringVar(&driver, "driver", "docker", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got either a node ID or -self, but not both.
+	if (nodeID != "") == self {
+		c.Ui.Error("Either the '-node' or '-self' flag must be set")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Check that we got images
+	images := flags.Args()
+	if len(images) == 0 {
+		c.Ui.Error("At least one image must be specified")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// If -self flag is set then determine the current node.
+	if self {
+		if nodeID, err = getLocalNodeID(client); err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+	}
+
+	// Check if node exists
+	if len(nodeID) == 1 {
+		c.Ui.Error("Identifier must contain at least two characters.")
+		return 1
+	}
+
+	nodeID = sanitizeUUIDPrefix(nodeID)
+	nodes, _, err := client.Nodes().PrefixList(nodeID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error prefetching images: %s", err))
+		return 1
+	}
+	// Return error if no nodes are found
+	if len(nodes) == 0 {
+		c.Ui.Error(fmt.Sprintf("No node(s) with prefix or id %q found", nodeID))
+		return 1
+	}
+	if len(nodes) > 1 {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple nodes\n\n%s",
+			formatNodeStubList(nodes, true)))
+		return 1
+	}
+
+	req := &api.ImagePrefetchRequest{
+		Driver: driver,
+		Images: images,
+	}
+	if err := client.Nodes().PrefetchImages(nodes[0].ID, req, nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error prefetching images: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Node %q started prefetching %d image(s)", nodes[0].ID, len(images)))
+	return 0
+}
@@ -62,23 +62,14 @@ func TestRecommendationDismissCommand_Run(t *testing.T) {
 		Stats:    map[string]float64{"p13": 1.13},
 	}
 	recResp, _, err := client.Recommendations().Upsert(&rec, nil)
-	if srv.Enterprise {
-		require.NoError(err)
-
-		// Read the recommendation out to ensure it is there as a control on
-		// later tests.
-		recInfo, _, err := client.Recommendations().Info(recResp.ID, nil)
-		require.NoError(err)
-		require.NotNil(recInfo)
-	} else {
-		require.Error(err, "Nomad Enterprise only endpoint")
-	}
+	require.NoError(err)
+
+	// Read the recommendation out to ensure it is there as a control on
+	// later tests.
+	recInfo, _, err := client.Recommendations().Info(recResp.ID, nil)
+	require.NoError(err)
+	require.NotNil(recInfo)
 
-	// Only perform the call if we are running enterprise tests. Otherwise the
-	// recResp object will be nil.
-	if !srv.Enterprise {
-		return
-	}
 	code := cmd.Run([]string{"-address=" + url, recResp.ID})
 	require.Equal(0, code)
 	out := ui.OutputWriter.String()
@@ -86,7 +77,7 @@ func TestRecommendationDismissCommand_Run(t *testing.T) {
 
 	// Perform an info call on the recommendation which should return not
 	// found.
-	recInfo, _, err := client.Recommendations().Info(recResp.ID, nil)
+	recInfo, _, err = client.Recommendations().Info(recResp.ID, nil)
 	require.Error(err, "not found")
 	require.Nil(recInfo)
 }
@@ -128,12 +119,7 @@ func testRecommendationAutocompleteCommand(t *testing.T, client *api.Client, srv
 		Stats:    map[string]float64{"p13": 1.13},
 	}
 	rec, _, err = client.Recommendations().Upsert(rec, nil)
-	if srv.Enterprise {
-		require.NoError(err)
-	} else {
-		require.Error(err, "Nomad Enterprise only endpoint")
-		return
-	}
+	require.NoError(err)
 
 	prefix := rec.ID[:5]
 	args := complete.Args{Last: prefix}
@@ -41,14 +41,9 @@ func TestRecommendationInfoCommand_Run(t *testing.T) {
 
 	// Perform an initial call, which should return a not found error.
 	code := cmd.Run([]string{"-address=" + url, "2c13f001-f5b6-ce36-03a5-e37afe160df5"})
-	if srv.Enterprise {
-		require.Equal(1, code)
-		out := ui.ErrorWriter.String()
-		require.Contains(out, "Recommendation not found")
-	} else {
-		require.Equal(1, code)
-		require.Contains(ui.ErrorWriter.String(), "Nomad Enterprise only endpoint")
-	}
+	require.Equal(1, code)
+	out := ui.ErrorWriter.String()
+	require.Contains(out, "Recommendation not found")
 
 	// Register a test job to write a recommendation against.
 	testJob := testJob("recommendation_info")
@@ -68,23 +63,15 @@ func TestRecommendationInfoCommand_Run(t *testing.T) {
 		Stats:    map[string]float64{"p13": 1.13},
 	}
 	recResp, _, err := client.Recommendations().Upsert(&rec, nil)
-	if srv.Enterprise {
-		require.NoError(err)
-	} else {
-		require.Error(err, "Nomad Enterprise only endpoint")
-	}
+	require.NoError(err)
 
-	// Only perform the call if we are running enterprise tests. Otherwise the
-	// recResp object will be nil.
-	if srv.Enterprise {
-		code = cmd.Run([]string{"-address=" + url, recResp.ID})
-		require.Equal(0, code)
-		out := ui.OutputWriter.String()
-		require.Contains(out, "test-meta-entry")
-		require.Contains(out, "p13")
-		require.Contains(out, "1.13")
-		require.Contains(out, recResp.ID)
-	}
+	code = cmd.Run([]string{"-address=" + url, recResp.ID})
+	require.Equal(0, code)
+	out = ui.OutputWriter.String()
+	require.Contains(out, "test-meta-entry")
+	require.Contains(out, "p13")
+	require.Contains(out, "1.13")
+	require.Contains(out, recResp.ID)
 }
 
 func TestRecommendationInfoCommand_AutocompleteArgs(t *testing.T) {
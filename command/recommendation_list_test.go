@@ -22,14 +22,9 @@ func TestRecommendationListCommand_Run(t *testing.T) {
 
 	// Perform an initial list, which should return zero results.
 	code := cmd.Run([]string{"-address=" + url})
-	if srv.Enterprise {
-		require.Equal(0, code)
-		out := ui.OutputWriter.String()
-		require.Contains(out, "No recommendations found")
-	} else {
-		require.Equal(1, code)
-		require.Contains(ui.ErrorWriter.String(), "Nomad Enterprise only endpoint")
-	}
+	require.Equal(0, code)
+	out := ui.OutputWriter.String()
+	require.Contains(out, "No recommendations found")
 
 	// Register a test job to write a recommendation against.
 	testJob := testJob("recommendation_list")
@@ -49,28 +44,19 @@ func TestRecommendationListCommand_Run(t *testing.T) {
 		Stats:    map[string]float64{"p13": 1.13},
 	}
 	_, _, err = client.Recommendations().Upsert(&rec, nil)
-	if srv.Enterprise {
-		require.NoError(err)
-	} else {
-		require.Error(err, "Nomad Enterprise only endpoint")
-	}
+	require.NoError(err)
 
 	// Perform a new list which should yield results.
 	code = cmd.Run([]string{"-address=" + url})
-	if srv.Enterprise {
-		require.Equal(0, code)
-		out := ui.OutputWriter.String()
-		require.Contains(out, "ID")
-		require.Contains(out, "Job")
-		require.Contains(out, "Group")
-		require.Contains(out, "Task")
-		require.Contains(out, "Resource")
-		require.Contains(out, "Value")
-		require.Contains(out, "CPU")
-	} else {
-		require.Equal(1, code)
-		require.Contains(ui.ErrorWriter.String(), "Nomad Enterprise only endpoint")
-	}
+	require.Equal(0, code)
+	out = ui.OutputWriter.String()
+	require.Contains(out, "ID")
+	require.Contains(out, "Job")
+	require.Contains(out, "Group")
+	require.Contains(out, "Task")
+	require.Contains(out, "Resource")
+	require.Contains(out, "Value")
+	require.Contains(out, "CPU")
 }
 
 func TestRecommendationListCommand_Sort(t *testing.T) {
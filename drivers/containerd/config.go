@@ -0,0 +1,191 @@
+package containerd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/containerd/containerd/reference/docker"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/shared/hclspec"
+)
+
+var (
+	// configSpec is the hcl specification returned by the ConfigSchema RPC
+	configSpec = hclspec.NewObject(map[string]*hclspec.Spec{
+		// address is the path of containerd's gRPC socket
+		"address": hclspec.NewDefault(
+			hclspec.NewAttr("address", "string", false),
+			hclspec.NewLiteral(`"/run/containerd/containerd.sock"`),
+		),
+
+		// namespace is the containerd namespace that images, containers and
+		// tasks are created in
+		"namespace": hclspec.NewDefault(
+			hclspec.NewAttr("namespace", "string", false),
+			hclspec.NewLiteral(`"nomad"`),
+		),
+
+		// runtime is the containerd runtime used to run tasks
+		"runtime": hclspec.NewDefault(
+			hclspec.NewAttr("runtime", "string", false),
+			hclspec.NewLiteral(`"io.containerd.runc.v2"`),
+		),
+
+		// snapshotter is the containerd snapshotter used to unpack images
+		"snapshotter": hclspec.NewDefault(
+			hclspec.NewAttr("snapshotter", "string", false),
+			hclspec.NewLiteral(`"overlayfs"`),
+		),
+
+		// image registry authentication options
+		"auth": hclspec.NewBlock("auth", false, hclspec.NewObject(map[string]*hclspec.Spec{
+			"config": hclspec.NewAttr("config", "string", false),
+		})),
+
+		"allow_privileged": hclspec.NewAttr("allow_privileged", "bool", false),
+	})
+
+	// taskConfigSpec is the hcl specification for the driver config section of
+	// a task within a job. It is returned in the TaskConfigSchema RPC
+	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
+		"image":      hclspec.NewAttr("image", "string", true),
+		"command":    hclspec.NewAttr("command", "string", false),
+		"args":       hclspec.NewAttr("args", "list(string)", false),
+		"entrypoint": hclspec.NewAttr("entrypoint", "list(string)", false),
+		"auth": hclspec.NewBlock("auth", false, hclspec.NewObject(map[string]*hclspec.Spec{
+			"username": hclspec.NewAttr("username", "string", false),
+			"password": hclspec.NewAttr("password", "string", false),
+		})),
+		"image_pull_timeout": hclspec.NewDefault(
+			hclspec.NewAttr("image_pull_timeout", "string", false),
+			hclspec.NewLiteral(`"5m"`),
+		),
+		"hostname":        hclspec.NewAttr("hostname", "string", false),
+		"work_dir":        hclspec.NewAttr("work_dir", "string", false),
+		"readonly_rootfs": hclspec.NewAttr("readonly_rootfs", "bool", false),
+		"privileged":      hclspec.NewAttr("privileged", "bool", false),
+	})
+
+	// driverCapabilities represents the RPC response for what features are
+	// implemented by the containerd task driver
+	driverCapabilities = &drivers.Capabilities{
+		SendSignals: true,
+		Exec:        true,
+		FSIsolation: drivers.FSIsolationImage,
+		NetIsolationModes: []drivers.NetIsolationMode{
+			drivers.NetIsolationModeHost,
+			drivers.NetIsolationModeGroup,
+		},
+		MustInitiateNetwork: true,
+		MountConfigs:        drivers.MountConfigSupportAll,
+	}
+)
+
+// Config is the driver configuration set by the SetConfig RPC call
+type Config struct {
+	// Address is the path of containerd's gRPC socket
+	Address string `codec:"address"`
+
+	// Namespace is the containerd namespace used for the driver's images,
+	// containers and tasks
+	Namespace string `codec:"namespace"`
+
+	// Runtime is the containerd runtime used to run tasks
+	Runtime string `codec:"runtime"`
+
+	// Snapshotter is the containerd snapshotter used to unpack images and
+	// create the root filesystem of tasks
+	Snapshotter string `codec:"snapshotter"`
+
+	// Auth configures how images are pulled from private registries
+	Auth AuthConfig `codec:"auth"`
+
+	// AllowPrivileged allows tasks to run privileged containers
+	AllowPrivileged bool `codec:"allow_privileged"`
+}
+
+// AuthConfig is the plugin level registry authentication configuration
+type AuthConfig struct {
+	// Config is the path of a Docker config.json file holding registry
+	// credentials
+	Config string `codec:"config"`
+}
+
+func (c *Config) validate() error {
+	if c.Address == "" {
+		return fmt.Errorf("address must be set")
+	}
+	if c.Namespace == "" {
+		return fmt.Errorf("namespace must be set")
+	}
+	if c.Runtime == "" {
+		return fmt.Errorf("runtime must be set")
+	}
+	if c.Snapshotter == "" {
+		return fmt.Errorf("snapshotter must be set")
+	}
+	return nil
+}
+
+// TaskConfig is the driver configuration of a task within a job
+type TaskConfig struct {
+	// Image is the reference of the image to run
+	Image string `codec:"image"`
+
+	// Command replaces the image's default command
+	Command string `codec:"command"`
+
+	// Args are passed along to Command, or the image's entrypoint if no
+	// command is set
+	Args []string `codec:"args"`
+
+	// Entrypoint replaces the image's entrypoint
+	Entrypoint []string `codec:"entrypoint"`
+
+	// Auth are the credentials used to pull the image
+	Auth TaskAuth `codec:"auth"`
+
+	// ImagePullTimeout bounds how long pulling the image may take
+	ImagePullTimeout string `codec:"image_pull_timeout"`
+
+	// Hostname is the hostname of the container. It defaults to the
+	// hostname of the group network, if any.
+	Hostname string `codec:"hostname"`
+
+	// WorkDir replaces the image's working directory
+	WorkDir string `codec:"work_dir"`
+
+	// ReadonlyRootfs mounts the container's root filesystem read only
+	ReadonlyRootfs bool `codec:"readonly_rootfs"`
+
+	// Privileged runs the container with all capabilities and access to
+	// the host's devices
+	Privileged bool `codec:"privileged"`
+}
+
+// TaskAuth are the registry credentials of a task
+type TaskAuth struct {
+	Username string `codec:"username"`
+	Password string `codec:"password"`
+}
+
+func (tc *TaskConfig) validate() error {
+	if _, err := docker.ParseDockerRef(tc.Image); err != nil {
+		return fmt.Errorf("invalid image %q: %v", tc.Image, err)
+	}
+	if tc.ImagePullTimeout != "" {
+		if _, err := time.ParseDuration(tc.ImagePullTimeout); err != nil {
+			return fmt.Errorf("failed to parse image_pull_timeout: %v", err)
+		}
+	}
+	return nil
+}
+
+// imagePullTimeout returns how long pulling the image may take
+func (tc *TaskConfig) imagePullTimeout() time.Duration {
+	d, err := time.ParseDuration(tc.ImagePullTimeout)
+	if err != nil || d <= 0 {
+		return defaultImagePullTimeout
+	}
+	return d
+}
@@ -0,0 +1,100 @@
+package containerd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_ParseAllHCL(t *testing.T) {
+	cfgStr := `
+config {
+  image      = "redis:6"
+  command    = "redis-server"
+  args       = ["--port", "6380"]
+  entrypoint = ["/entrypoint.sh"]
+
+  auth {
+    username = "user"
+    password = "pass"
+  }
+
+  image_pull_timeout = "10m"
+  hostname           = "cache"
+  work_dir           = "/data"
+  readonly_rootfs    = true
+  privileged         = true
+}`
+
+	expected := &TaskConfig{
+		Image:      "redis:6",
+		Command:    "redis-server",
+		Args:       []string{"--port", "6380"},
+		Entrypoint: []string{"/entrypoint.sh"},
+		Auth: TaskAuth{
+			Username: "user",
+			Password: "pass",
+		},
+		ImagePullTimeout: "10m",
+		Hostname:         "cache",
+		WorkDir:          "/data",
+		ReadonlyRootfs:   true,
+		Privileged:       true,
+	}
+
+	var tc *TaskConfig
+	hclutils.NewConfigParser(taskConfigSpec).ParseHCL(t, cfgStr, &tc)
+
+	require.EqualValues(t, expected, tc)
+}
+
+func TestConfig_PluginDefaults(t *testing.T) {
+	var config *Config
+	hclutils.NewConfigParser(configSpec).ParseHCL(t, `config {}`, &config)
+
+	require.Equal(t, "/run/containerd/containerd.sock", config.Address)
+	require.Equal(t, "nomad", config.Namespace)
+	require.Equal(t, "io.containerd.runc.v2", config.Runtime)
+	require.Equal(t, "overlayfs", config.Snapshotter)
+	require.NoError(t, config.validate())
+}
+
+func TestTaskConfig_Validate(t *testing.T) {
+	cases := []struct {
+		name   string
+		config TaskConfig
+		err    string
+	}{
+		{
+			name:   "valid",
+			config: TaskConfig{Image: "busybox", ImagePullTimeout: "1m"},
+		},
+		{
+			name:   "invalid image",
+			config: TaskConfig{Image: "Busybox"},
+			err:    "invalid image",
+		},
+		{
+			name:   "invalid image_pull_timeout",
+			config: TaskConfig{Image: "busybox", ImagePullTimeout: "soon"},
+			err:    "image_pull_timeout",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.config.validate()
+			if c.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), c.err)
+			}
+		})
+	}
+
+	require.Equal(t, time.Minute, (&TaskConfig{ImagePullTimeout: "1m"}).imagePullTimeout())
+	require.Equal(t, defaultImagePullTimeout, (&TaskConfig{}).imagePullTimeout())
+}
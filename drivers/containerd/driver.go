@@ -0,0 +1,609 @@
+package containerd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/hashicorp/consul-template/signals"
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/drivers/shared/eventer"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/shared/hclspec"
+	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
+)
+
+const (
+	// pluginName is the name of the plugin
+	pluginName = "containerd"
+
+	// fingerprintPeriod is the interval at which the driver will send fingerprint responses
+	fingerprintPeriod = 30 * time.Second
+
+	// taskHandleVersion is the version of task handle which this driver sets
+	// and understands how to decode driver state
+	taskHandleVersion = 1
+
+	// connectTimeout bounds how long connecting to containerd may take
+	connectTimeout = 5 * time.Second
+
+	// defaultImagePullTimeout is used when a task doesn't set a valid
+	// image_pull_timeout
+	defaultImagePullTimeout = 5 * time.Minute
+
+	// maxContainerIDLength is the longest identifier containerd accepts
+	maxContainerIDLength = 76
+)
+
+var (
+	// PluginID is the containerd plugin metadata registered in the plugin
+	// catalog.
+	PluginID = loader.PluginID{
+		Name:       pluginName,
+		PluginType: base.PluginTypeDriver,
+	}
+
+	// PluginConfig is the containerd driver factory function registered in
+	// the plugin catalog.
+	PluginConfig = &loader.InternalPluginConfig{
+		Config:  map[string]interface{}{},
+		Factory: func(ctx context.Context, l hclog.Logger) interface{} { return NewContainerdDriver(ctx, l) },
+	}
+
+	// pluginInfo is the response returned for the PluginInfo RPC
+	pluginInfo = &base.PluginInfoResponse{
+		Type:              base.PluginTypeDriver,
+		PluginApiVersions: []string{drivers.ApiVersion010},
+		PluginVersion:     "0.1.0",
+		Name:              pluginName,
+	}
+
+	// invalidIDChars matches the characters task names may contain which
+	// containerd doesn't accept in identifiers
+	invalidIDChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+)
+
+// Driver runs tasks as containers managed by containerd.
+type Driver struct {
+	// eventer is used to handle multiplexing of TaskEvents calls such that an
+	// event can be broadcast to all callers
+	eventer *eventer.Eventer
+
+	// config is the driver configuration set by the SetConfig RPC
+	config Config
+
+	// nomadConfig is the client config from nomad
+	nomadConfig *base.ClientDriverConfig
+
+	// tasks is the in memory datastore mapping taskIDs to driverHandles
+	tasks *taskStore
+
+	// ctx is the context for the driver. It is passed to other subsystems to
+	// coordinate shutdown
+	ctx context.Context
+
+	// logger will log to the Nomad agent
+	logger hclog.Logger
+
+	// client is the containerd client, which is connected on first use
+	client     *containerd.Client
+	clientLock sync.Mutex
+
+	// A tri-state boolean to know if the fingerprinting has happened and
+	// whether it has been successful
+	fingerprintSuccess *bool
+	fingerprintLock    sync.Mutex
+}
+
+// TaskState is the state which is encoded in the handle returned in
+// StartTask. This information is needed to rebuild the task state and handler
+// during recovery.
+type TaskState struct {
+	TaskConfig  *drivers.TaskConfig
+	ContainerID string
+	Pid         uint32
+	StartedAt   time.Time
+}
+
+// NewContainerdDriver returns a new DriverPlugin implementation
+func NewContainerdDriver(ctx context.Context, logger hclog.Logger) drivers.DriverPlugin {
+	logger = logger.Named(pluginName)
+	return &Driver{
+		eventer: eventer.NewEventer(ctx, logger),
+		tasks:   newTaskStore(),
+		ctx:     ctx,
+		logger:  logger,
+	}
+}
+
+// setFingerprintSuccess marks the driver as having fingerprinted successfully
+func (d *Driver) setFingerprintSuccess() {
+	d.fingerprintLock.Lock()
+	d.fingerprintSuccess = helper.BoolToPtr(true)
+	d.fingerprintLock.Unlock()
+}
+
+// setFingerprintFailure marks the driver as having failed fingerprinting
+func (d *Driver) setFingerprintFailure() {
+	d.fingerprintLock.Lock()
+	d.fingerprintSuccess = helper.BoolToPtr(false)
+	d.fingerprintLock.Unlock()
+}
+
+// fingerprintSuccessful returns true if the driver has
+// never fingerprinted or has successfully fingerprinted
+func (d *Driver) fingerprintSuccessful() bool {
+	d.fingerprintLock.Lock()
+	defer d.fingerprintLock.Unlock()
+	return d.fingerprintSuccess == nil || *d.fingerprintSuccess
+}
+
+func (d *Driver) PluginInfo() (*base.PluginInfoResponse, error) {
+	return pluginInfo, nil
+}
+
+func (d *Driver) ConfigSchema() (*hclspec.Spec, error) {
+	return configSpec, nil
+}
+
+func (d *Driver) SetConfig(cfg *base.Config) error {
+	var config Config
+	if len(cfg.PluginConfig) != 0 {
+		if err := base.MsgPackDecode(cfg.PluginConfig, &config); err != nil {
+			return err
+		}
+	}
+	if err := config.validate(); err != nil {
+		return err
+	}
+
+	d.clientLock.Lock()
+	if d.client != nil && (d.config.Address != config.Address || d.config.Runtime != config.Runtime) {
+		d.client.Close()
+		d.client = nil
+	}
+	d.config = config
+	d.clientLock.Unlock()
+
+	if cfg.AgentConfig != nil {
+		d.nomadConfig = cfg.AgentConfig.Driver
+	}
+	return nil
+}
+
+func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
+	return taskConfigSpec, nil
+}
+
+// Capabilities is returned by the Capabilities RPC and indicates what
+// optional features this driver supports
+func (d *Driver) Capabilities() (*drivers.Capabilities, error) {
+	return driverCapabilities, nil
+}
+
+// getClient returns the containerd client, connecting to containerd if it
+// isn't connected yet
+func (d *Driver) getClient() (*containerd.Client, error) {
+	d.clientLock.Lock()
+	defer d.clientLock.Unlock()
+
+	if d.client != nil {
+		return d.client, nil
+	}
+
+	client, err := containerd.New(d.config.Address,
+		containerd.WithTimeout(connectTimeout),
+		containerd.WithDefaultRuntime(d.config.Runtime),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to containerd: %v", err)
+	}
+	d.client = client
+	return client, nil
+}
+
+// withNamespace returns a context scoped to the driver's containerd
+// namespace
+func (d *Driver) withNamespace(ctx context.Context) context.Context {
+	return namespaces.WithNamespace(ctx, d.config.Namespace)
+}
+
+func (d *Driver) Fingerprint(ctx context.Context) (<-chan *drivers.Fingerprint, error) {
+	ch := make(chan *drivers.Fingerprint)
+	go d.handleFingerprint(ctx, ch)
+	return ch, nil
+}
+
+func (d *Driver) handleFingerprint(ctx context.Context, ch chan<- *drivers.Fingerprint) {
+	defer close(ch)
+	ticker := time.NewTimer(0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+			ticker.Reset(fingerprintPeriod)
+			ch <- d.buildFingerprint()
+		}
+	}
+}
+
+func (d *Driver) buildFingerprint() *drivers.Fingerprint {
+	if runtime.GOOS != "linux" {
+		d.setFingerprintFailure()
+		return &drivers.Fingerprint{
+			Health:            drivers.HealthStateUndetected,
+			HealthDescription: "containerd driver unsupported on client OS",
+		}
+	}
+
+	fp := &drivers.Fingerprint{
+		Attributes:        map[string]*pstructs.Attribute{},
+		Health:            drivers.HealthStateHealthy,
+		HealthDescription: drivers.DriverHealthy,
+	}
+
+	client, err := d.getClient()
+	if err != nil {
+		if d.fingerprintSuccessful() {
+			d.logger.Debug("could not connect to containerd", "error", err)
+		}
+		d.setFingerprintFailure()
+		fp.Health = drivers.HealthStateUndetected
+		fp.HealthDescription = "Failed to connect to containerd"
+		return fp
+	}
+
+	ctx, cancel := context.WithTimeout(d.withNamespace(d.ctx), connectTimeout)
+	defer cancel()
+	version, err := client.Version(ctx)
+	if err != nil {
+		if d.fingerprintSuccessful() {
+			d.logger.Warn("failed to query containerd version", "error", err)
+		}
+		d.setFingerprintFailure()
+		fp.Health = drivers.HealthStateUnhealthy
+		fp.HealthDescription = "Failed to query containerd version"
+		return fp
+	}
+
+	fp.Attributes["driver.containerd"] = pstructs.NewBoolAttribute(true)
+	fp.Attributes["driver.containerd.version"] = pstructs.NewStringAttribute(version.Version)
+	fp.Attributes["driver.containerd.runtime"] = pstructs.NewStringAttribute(d.config.Runtime)
+	if d.config.AllowPrivileged {
+		fp.Attributes["driver.containerd.privileged.enabled"] = pstructs.NewBoolAttribute(true)
+	}
+	d.setFingerprintSuccess()
+	return fp
+}
+
+func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
+	if handle == nil {
+		return fmt.Errorf("handle cannot be nil")
+	}
+
+	// If already attached to handle there's nothing to recover.
+	if _, ok := d.tasks.Get(handle.Config.ID); ok {
+		d.logger.Trace("nothing to recover; task already exists",
+			"task_id", handle.Config.ID,
+			"task_name", handle.Config.Name,
+		)
+		return nil
+	}
+
+	var taskState TaskState
+	if err := handle.GetDriverState(&taskState); err != nil {
+		d.logger.Error("failed to decode task state from handle", "error", err, "task_id", handle.Config.ID)
+		return fmt.Errorf("failed to decode task state from handle: %v", err)
+	}
+
+	client, err := d.getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := d.withNamespace(d.ctx)
+	container, err := client.LoadContainer(ctx, taskState.ContainerID)
+	if err != nil {
+		return fmt.Errorf("failed to load container %q: %v", taskState.ContainerID, err)
+	}
+
+	// The task's output is written by the shim directly into the log
+	// fifos, so there is no IO to attach to
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to load task of container %q: %v", taskState.ContainerID, err)
+	}
+
+	exitCh, err := task.Wait(d.withNamespace(context.Background()))
+	if err != nil {
+		return fmt.Errorf("failed to wait on task of container %q: %v", taskState.ContainerID, err)
+	}
+
+	h := newTaskHandle(d, taskState.TaskConfig, container, task)
+	h.pid = taskState.Pid
+	h.startedAt = taskState.StartedAt
+	d.tasks.Set(taskState.TaskConfig.ID, h)
+
+	go h.run(exitCh)
+	return nil
+}
+
+func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	if _, ok := d.tasks.Get(cfg.ID); ok {
+		return nil, nil, fmt.Errorf("task with ID %q already started", cfg.ID)
+	}
+
+	var driverConfig TaskConfig
+	if err := cfg.DecodeDriverConfig(&driverConfig); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode driver config: %v", err)
+	}
+
+	if err := driverConfig.validate(); err != nil {
+		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
+	}
+
+	if driverConfig.Privileged && !d.config.AllowPrivileged {
+		return nil, nil, fmt.Errorf(`containerd privileged mode is disabled on this Nomad agent`)
+	}
+
+	d.logger.Info("starting task", "driver_cfg", hclog.Fmt("%+v", driverConfig))
+	handle := drivers.NewTaskHandle(taskHandleVersion)
+	handle.Config = cfg
+
+	client, err := d.getClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx := d.withNamespace(d.ctx)
+	image, err := d.pullImage(ctx, client, cfg, &driverConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	containerID := containerIDForTask(cfg)
+
+	// A container may be left behind if the client stopped while the task
+	// was being started or destroyed
+	if err := d.removeStaleContainer(ctx, client, containerID); err != nil {
+		return nil, nil, err
+	}
+
+	specOpts, err := d.specOpts(ctx, cfg, &driverConfig, image)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	container, err := client.NewContainer(ctx, containerID,
+		containerd.WithImage(image),
+		containerd.WithSnapshotter(d.config.Snapshotter),
+		containerd.WithNewSnapshot(containerID, image),
+		containerd.WithNewSpec(specOpts...),
+		containerd.WithContainerLabels(map[string]string{
+			"com.hashicorp.nomad.alloc_id":  cfg.AllocID,
+			"com.hashicorp.nomad.task_name": cfg.Name,
+		}),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create container: %v", err)
+	}
+
+	task, err := container.NewTask(ctx, logIO(cfg))
+	if err != nil {
+		d.deleteContainer(ctx, container)
+		return nil, nil, fmt.Errorf("failed to create task: %v", err)
+	}
+
+	// Wait must be called before the task is started so that its exit
+	// can't be missed
+	exitCh, err := task.Wait(d.withNamespace(context.Background()))
+	if err != nil {
+		d.deleteTask(ctx, task)
+		d.deleteContainer(ctx, container)
+		return nil, nil, fmt.Errorf("failed to wait on task: %v", err)
+	}
+
+	if err := task.Start(ctx); err != nil {
+		d.deleteTask(ctx, task)
+		d.deleteContainer(ctx, container)
+		return nil, nil, fmt.Errorf("failed to start task: %v", err)
+	}
+
+	h := newTaskHandle(d, cfg, container, task)
+	h.pid = task.Pid()
+	h.startedAt = time.Now().Round(time.Millisecond)
+
+	driverState := TaskState{
+		TaskConfig:  cfg,
+		ContainerID: containerID,
+		Pid:         h.pid,
+		StartedAt:   h.startedAt,
+	}
+
+	if err := handle.SetDriverState(&driverState); err != nil {
+		d.logger.Error("failed to start task, error setting driver state", "error", err)
+		task.Kill(ctx, sigkill)
+		<-exitCh
+		d.deleteTask(ctx, task)
+		d.deleteContainer(ctx, container)
+		return nil, nil, fmt.Errorf("failed to set driver state: %v", err)
+	}
+
+	d.tasks.Set(cfg.ID, h)
+	go h.run(exitCh)
+	return handle, nil, nil
+}
+
+// containerIDForTask returns the identifier of the container that runs the
+// task. Task names may contain characters containerd doesn't accept in
+// identifiers, which are replaced.
+func containerIDForTask(cfg *drivers.TaskConfig) string {
+	name := invalidIDChars.ReplaceAllString(cfg.Name, "-")
+	if max := maxContainerIDLength - len(cfg.AllocID) - 1; len(name) > max {
+		name = name[:max]
+	}
+	return fmt.Sprintf("%s-%s", name, cfg.AllocID)
+}
+
+// removeStaleContainer removes the container with the given ID, if any,
+// along with its task
+func (d *Driver) removeStaleContainer(ctx context.Context, client *containerd.Client, id string) error {
+	container, err := client.LoadContainer(ctx, id)
+	if errdefs.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to load container %q: %v", id, err)
+	}
+
+	d.logger.Debug("removing stale container", "container_id", id)
+	task, err := container.Task(ctx, nil)
+	if err == nil {
+		task.Kill(ctx, sigkill)
+		d.deleteTask(ctx, task)
+	} else if !errdefs.IsNotFound(err) {
+		return fmt.Errorf("failed to load task of container %q: %v", id, err)
+	}
+
+	if err := container.Delete(ctx, containerd.WithSnapshotCleanup); err != nil && !errdefs.IsNotFound(err) {
+		return fmt.Errorf("failed to remove stale container %q: %v", id, err)
+	}
+	return nil
+}
+
+// deleteTask deletes the task, logging rather than returning errors as it is
+// used to clean up
+func (d *Driver) deleteTask(ctx context.Context, task containerd.Task) {
+	if _, err := task.Delete(ctx, containerd.WithProcessKill); err != nil && !errdefs.IsNotFound(err) {
+		d.logger.Warn("failed to delete task", "container_id", task.ID(), "error", err)
+	}
+}
+
+// deleteContainer deletes the container and its snapshot, logging rather
+// than returning errors as it is used to clean up
+func (d *Driver) deleteContainer(ctx context.Context, container containerd.Container) {
+	if err := container.Delete(ctx, containerd.WithSnapshotCleanup); err != nil && !errdefs.IsNotFound(err) {
+		d.logger.Warn("failed to delete container", "container_id", container.ID(), "error", err)
+	}
+}
+
+func (d *Driver) WaitTask(ctx context.Context, taskID string) (<-chan *drivers.ExitResult, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	ch := make(chan *drivers.ExitResult)
+	go d.handleWait(ctx, handle, ch)
+	return ch, nil
+}
+
+func (d *Driver) handleWait(ctx context.Context, handle *taskHandle, ch chan *drivers.ExitResult) {
+	defer close(ch)
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-d.ctx.Done():
+		return
+	case <-handle.waitCh:
+	}
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-d.ctx.Done():
+		return
+	case ch <- handle.ExitResult():
+	}
+}
+
+func (d *Driver) StopTask(taskID string, timeout time.Duration, signal string) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if signal == "" {
+		signal = "SIGTERM"
+	}
+	sig, err := signals.Parse(signal)
+	if err != nil {
+		return fmt.Errorf("failed to parse signal: %v", err)
+	}
+
+	return handle.Kill(d.withNamespace(d.ctx), timeout, sig)
+}
+
+func (d *Driver) DestroyTask(taskID string, force bool) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if handle.IsRunning() && !force {
+		return fmt.Errorf("cannot destroy running task")
+	}
+
+	ctx := d.withNamespace(d.ctx)
+	if handle.IsRunning() {
+		if err := handle.Kill(ctx, 0, sigkill); err != nil {
+			handle.logger.Error("failed to kill task", "error", err)
+		}
+	}
+
+	d.deleteTask(ctx, handle.task)
+	d.deleteContainer(ctx, handle.container)
+
+	d.tasks.Delete(taskID)
+	return nil
+}
+
+func (d *Driver) InspectTask(taskID string) (*drivers.TaskStatus, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return handle.TaskStatus(), nil
+}
+
+func (d *Driver) TaskStats(ctx context.Context, taskID string, interval time.Duration) (<-chan *drivers.TaskResourceUsage, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	ch := make(chan *drivers.TaskResourceUsage)
+	go handle.collectStats(d.withNamespace(ctx), ch, interval)
+	return ch, nil
+}
+
+func (d *Driver) TaskEvents(ctx context.Context) (<-chan *drivers.TaskEvent, error) {
+	return d.eventer.TaskEvents(ctx)
+}
+
+func (d *Driver) SignalTask(taskID string, signal string) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	sig, err := signals.Parse(signal)
+	if err != nil {
+		return fmt.Errorf("failed to parse signal: %v", err)
+	}
+
+	return handle.Signal(d.withNamespace(d.ctx), sig)
+}
@@ -0,0 +1,483 @@
+//go:build linux
+// +build linux
+
+package containerd
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/containerd/cgroups/stats/v1"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	dtestutil "github.com/hashicorp/nomad/plugins/drivers/testutils"
+	"github.com/hashicorp/nomad/testutil"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+var testResources = &drivers.Resources{
+	NomadResources: &structs.AllocatedTaskResources{
+		Memory: structs.AllocatedMemoryResources{
+			MemoryMB: 128,
+		},
+		Cpu: structs.AllocatedCpuResources{
+			CpuShares: 100,
+		},
+	},
+	LinuxResources: &drivers.LinuxResources{
+		MemoryLimitBytes: 134217728,
+		CPUShares:        100,
+	},
+}
+
+// newTestDriver returns a harness for a driver connected to a new fake
+// containerd whose image runs a shell
+func newTestDriver(t *testing.T) (*dtestutil.DriverHarness, *fakeContainerd) {
+	f := newFakeContainerd(t, ocispec.ImageConfig{
+		Env: []string{"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"},
+		Cmd: []string{"/bin/sh"},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	d := NewContainerdDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	setTestConfig(t, harness, f)
+	return harness, f
+}
+
+// setTestConfig configures the driver of harness to use the fake containerd
+func setTestConfig(t *testing.T, harness *dtestutil.DriverHarness, f *fakeContainerd) {
+	config := &Config{
+		Address:     f.address,
+		Namespace:   "nomad",
+		Runtime:     "io.containerd.runc.v2",
+		Snapshotter: "overlayfs",
+	}
+
+	var data []byte
+	require.NoError(t, base.MsgPackEncode(&data, config))
+	require.NoError(t, harness.SetConfig(&base.Config{PluginConfig: data}))
+}
+
+// newTestTask returns a task running a shell script in the fake's image
+func newTestTask(t *testing.T, script string) *drivers.TaskConfig {
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		AllocID:   uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+
+	tc := &TaskConfig{
+		Image:   "busybox",
+		Command: "/bin/sh",
+		Args:    []string{"-c", script},
+	}
+	require.NoError(t, task.EncodeConcreteDriverConfig(&tc))
+	return task
+}
+
+func TestContainerdDriver_Fingerprint(t *testing.T) {
+	t.Parallel()
+
+	harness, _ := newTestDriver(t)
+
+	fingerCh, err := harness.Fingerprint(context.Background())
+	require.NoError(t, err)
+	select {
+	case finger := <-fingerCh:
+		require.Equal(t, drivers.HealthStateHealthy, finger.Health)
+		detected, _ := finger.Attributes["driver.containerd"].GetBool()
+		require.True(t, detected)
+		version, _ := finger.Attributes["driver.containerd.version"].GetString()
+		require.Equal(t, "v1.5.9", version)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail(t, "timeout receiving fingerprint")
+	}
+}
+
+func TestContainerdDriver_Fingerprint_NotRunning(t *testing.T) {
+	t.Parallel()
+
+	harness, f := newTestDriver(t)
+	f.stop()
+
+	fingerCh, err := harness.Fingerprint(context.Background())
+	require.NoError(t, err)
+	select {
+	case finger := <-fingerCh:
+		require.Equal(t, drivers.HealthStateUndetected, finger.Health)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*10) * time.Second):
+		require.Fail(t, "timeout receiving fingerprint")
+	}
+}
+
+func TestContainerdDriver_StartWait(t *testing.T) {
+	t.Parallel()
+
+	harness, f := newTestDriver(t)
+	task := newTestTask(t, "exit 3")
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(t, err)
+
+	ch, err := harness.WaitTask(context.Background(), handle.Config.ID)
+	require.NoError(t, err)
+	select {
+	case result := <-ch:
+		require.Equal(t, 3, result.ExitCode)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail(t, "timeout waiting for task")
+	}
+
+	containerID := containerIDForTask(task)
+	spec := f.containerSpec(t, containerID)
+	require.Equal(t, []string{"/bin/sh", "-c", "exit 3"}, spec.Process.Args)
+	require.Contains(t, spec.Process.Env, "NOMAD_TASK_DIR=/local")
+	require.Equal(t, int64(134217728), *spec.Linux.Resources.Memory.Limit)
+	require.Equal(t, uint64(100), *spec.Linux.Resources.CPU.Shares)
+
+	destinations := map[string]string{}
+	for _, m := range spec.Mounts {
+		destinations[m.Destination] = m.Source
+	}
+	taskDir := task.TaskDir()
+	require.Equal(t, taskDir.SharedAllocDir, destinations["/alloc"])
+	require.Equal(t, taskDir.LocalDir, destinations["/local"])
+	require.Equal(t, taskDir.SecretsDir, destinations["/secrets"])
+	require.Equal(t, "/etc/resolv.conf", destinations["/etc/resolv.conf"])
+
+	require.NoError(t, harness.DestroyTask(task.ID, true))
+	_, ok := f.container(containerID)
+	require.False(t, ok, "container should be deleted")
+	require.False(t, f.hasSnapshot(containerID), "snapshot should be removed")
+}
+
+func TestContainerdDriver_StartStop(t *testing.T) {
+	t.Parallel()
+
+	harness, _ := newTestDriver(t)
+	task := newTestTask(t, "echo hello; exec sleep 100")
+	cleanup := harness.MkAllocDir(task, true)
+	defer cleanup()
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(t, err)
+	require.NoError(t, harness.WaitUntilStarted(task.ID, 5*time.Second))
+
+	ch, err := harness.WaitTask(context.Background(), handle.Config.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, harness.StopTask(task.ID, 5*time.Second, "SIGINT"))
+	select {
+	case result := <-ch:
+		require.Equal(t, 130, result.ExitCode)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail(t, "timeout waiting for task")
+	}
+
+	status, err := harness.InspectTask(task.ID)
+	require.NoError(t, err)
+	require.Equal(t, drivers.TaskStateExited, status.State)
+
+	// The task's output is written directly into its log fifos
+	stdout := filepath.Join(task.TaskDir().LogDir, "test.stdout.0")
+	testutil.WaitForResult(func() (bool, error) {
+		b, err := ioutil.ReadFile(stdout)
+		if err != nil {
+			return false, err
+		}
+		return strings.TrimSpace(string(b)) == "hello", nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	require.NoError(t, harness.DestroyTask(task.ID, false))
+}
+
+func TestContainerdDriver_RecoverTask(t *testing.T) {
+	t.Parallel()
+
+	harness, f := newTestDriver(t)
+	task := newTestTask(t, "exec sleep 100")
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(t, err)
+	require.NoError(t, harness.WaitUntilStarted(task.ID, 5*time.Second))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := NewContainerdDriver(ctx, testlog.HCLogger(t))
+	recovered := dtestutil.NewDriverHarness(t, d)
+	setTestConfig(t, recovered, f)
+
+	require.NoError(t, recovered.RecoverTask(handle))
+	status, err := recovered.InspectTask(task.ID)
+	require.NoError(t, err)
+	require.Equal(t, drivers.TaskStateRunning, status.State)
+	require.Equal(t, containerIDForTask(task), status.DriverAttributes["container_id"])
+
+	ch, err := recovered.WaitTask(context.Background(), task.ID)
+	require.NoError(t, err)
+	require.NoError(t, recovered.StopTask(task.ID, 5*time.Second, "SIGKILL"))
+	select {
+	case result := <-ch:
+		require.Equal(t, 137, result.ExitCode)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail(t, "timeout waiting for task")
+	}
+
+	require.NoError(t, recovered.DestroyTask(task.ID, true))
+}
+
+func TestContainerdDriver_StartTask_StaleContainer(t *testing.T) {
+	t.Parallel()
+
+	harness, f := newTestDriver(t)
+	task := newTestTask(t, "exec sleep 100")
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(t, err)
+
+	// A new driver starting the same task, as done after the client lost
+	// its state, replaces the container left behind
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := NewContainerdDriver(ctx, testlog.HCLogger(t))
+	restarted := dtestutil.NewDriverHarness(t, d)
+	setTestConfig(t, restarted, f)
+
+	_, _, err = restarted.StartTask(task)
+	require.NoError(t, err)
+	require.NoError(t, restarted.WaitUntilStarted(task.ID, 5*time.Second))
+	require.NoError(t, restarted.DestroyTask(task.ID, true))
+}
+
+func TestContainerdDriver_Exec(t *testing.T) {
+	t.Parallel()
+
+	harness, _ := newTestDriver(t)
+	task := newTestTask(t, "exec sleep 100")
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(t, err)
+	defer harness.DestroyTask(task.ID, true)
+	require.NoError(t, harness.WaitUntilStarted(task.ID, 5*time.Second))
+
+	t.Run("exec", func(t *testing.T) {
+		res, err := harness.ExecTask(task.ID, []string{"/bin/sh", "-c", "echo hello; echo oops >&2; exit 2"}, 5*time.Second)
+		require.NoError(t, err)
+		require.Equal(t, 2, res.ExitResult.ExitCode)
+		require.Equal(t, "hello\n", string(res.Stdout))
+		require.Equal(t, "oops\n", string(res.Stderr))
+	})
+
+	t.Run("streaming", func(t *testing.T) {
+		code, stdout, stderr := dtestutil.ExecTask(t, harness, task.ID, "echo $NOMAD_TASK_DIR; echo oops >&2", false, "")
+		require.Zero(t, code)
+		require.Equal(t, "/local\n", stdout)
+		require.Equal(t, "oops\n", stderr)
+	})
+
+	t.Run("streaming stdin", func(t *testing.T) {
+		code, stdout, _ := dtestutil.ExecTask(t, harness, task.ID, "cat", false, "hello from stdin")
+		require.Zero(t, code)
+		require.Equal(t, "hello from stdin", stdout)
+	})
+}
+
+func TestContainerdDriver_Stats(t *testing.T) {
+	t.Parallel()
+
+	harness, f := newTestDriver(t)
+	f.setMetrics(t, &v1.Metrics{
+		Memory: &v1.MemoryStat{
+			RSS:   1024,
+			Cache: 2048,
+			Usage: &v1.MemoryEntry{Usage: 4096, Max: 8192},
+		},
+		CPU: &v1.CPUStat{
+			Usage:      &v1.CPUUsage{Total: 1000, User: 600, Kernel: 400},
+			Throttling: &v1.Throttle{ThrottledPeriods: 2, ThrottledTime: 300},
+		},
+	})
+
+	task := newTestTask(t, "exec sleep 100")
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(t, err)
+	defer harness.DestroyTask(task.ID, true)
+	require.NoError(t, harness.WaitUntilStarted(task.ID, 5*time.Second))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	statsCh, err := harness.TaskStats(ctx, task.ID, time.Second)
+	require.NoError(t, err)
+
+	select {
+	case usage := <-statsCh:
+		require.NotNil(t, usage)
+		ms := usage.ResourceUsage.MemoryStats
+		require.Equal(t, uint64(1024), ms.RSS)
+		require.Equal(t, uint64(2048), ms.Cache)
+		require.Equal(t, uint64(4096), ms.Usage)
+		require.Equal(t, uint64(8192), ms.MaxUsage)
+		require.Equal(t, cgroupV1MeasuredMemStats, ms.Measured)
+		cs := usage.ResourceUsage.CpuStats
+		require.Equal(t, uint64(2), cs.ThrottledPeriods)
+		require.Equal(t, uint64(300), cs.ThrottledTime)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail(t, "timeout receiving stats")
+	}
+}
+
+func TestContainerdDriver_SignalTask(t *testing.T) {
+	t.Parallel()
+
+	harness, _ := newTestDriver(t)
+	task := newTestTask(t, `trap "exit 5" USR1; while true; do sleep 0.1; done`)
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(t, err)
+	defer harness.DestroyTask(task.ID, true)
+	require.NoError(t, harness.WaitUntilStarted(task.ID, 5*time.Second))
+
+	ch, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(t, err)
+
+	// Give the shell time to install its trap
+	time.Sleep(500 * time.Millisecond)
+	require.NoError(t, harness.SignalTask(task.ID, "SIGUSR1"))
+	select {
+	case result := <-ch:
+		require.Equal(t, 5, result.ExitCode)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail(t, "timeout waiting for task")
+	}
+}
+
+func TestContainerdDriver_StartTask_PrivilegedDisabled(t *testing.T) {
+	t.Parallel()
+
+	harness, _ := newTestDriver(t)
+	task := &drivers.TaskConfig{
+		ID:      uuid.Generate(),
+		AllocID: uuid.Generate(),
+		Name:    "test",
+	}
+	tc := &TaskConfig{Image: "busybox", Privileged: true}
+	require.NoError(t, task.EncodeConcreteDriverConfig(&tc))
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	_, _, err := harness.StartTask(task)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "privileged mode is disabled")
+}
+
+func TestProcessArgs(t *testing.T) {
+	image := ocispec.ImageConfig{
+		Entrypoint: []string{"/entrypoint.sh"},
+		Cmd:        []string{"serve"},
+	}
+
+	cases := []struct {
+		name     string
+		config   TaskConfig
+		expected []string
+	}{
+		{
+			name:     "image defaults",
+			expected: []string{"/entrypoint.sh", "serve"},
+		},
+		{
+			name:     "command",
+			config:   TaskConfig{Command: "run", Args: []string{"-v"}},
+			expected: []string{"/entrypoint.sh", "run", "-v"},
+		},
+		{
+			name:     "args",
+			config:   TaskConfig{Args: []string{"worker"}},
+			expected: []string{"/entrypoint.sh", "worker"},
+		},
+		{
+			name:     "entrypoint",
+			config:   TaskConfig{Entrypoint: []string{"/bin/sh"}},
+			expected: []string{"/bin/sh"},
+		},
+		{
+			name:     "entrypoint and args",
+			config:   TaskConfig{Entrypoint: []string{"/bin/sh"}, Args: []string{"-c", "true"}},
+			expected: []string{"/bin/sh", "-c", "true"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.expected, processArgs(image, &c.config))
+		})
+	}
+}
+
+func TestContainerIDForTask(t *testing.T) {
+	allocID := uuid.Generate()
+
+	id := containerIDForTask(&drivers.TaskConfig{Name: "web/api server", AllocID: allocID})
+	require.Equal(t, "web-api-server-"+allocID, id)
+
+	id = containerIDForTask(&drivers.TaskConfig{Name: strings.Repeat("a", 100), AllocID: allocID})
+	require.Len(t, id, maxContainerIDLength)
+	require.True(t, strings.HasSuffix(id, "-"+allocID))
+}
+
+func TestRegistryCredentials(t *testing.T) {
+	authConfig := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, ioutil.WriteFile(authConfig, []byte(`{
+  "auths": {
+    "https://index.docker.io/v1/": {"auth": "aHViOnNlY3JldA=="},
+    "registry.example.com": {"identitytoken": "token"}
+  }
+}`), 0600))
+
+	d := &Driver{config: Config{Auth: AuthConfig{Config: authConfig}}}
+
+	// Docker Hub credentials are stored under its legacy key
+	user, pass, err := d.registryCredentials(&TaskConfig{})(dockerHubRegistry)
+	require.NoError(t, err)
+	require.Equal(t, "hub", user)
+	require.Equal(t, "secret", pass)
+
+	user, pass, err = d.registryCredentials(&TaskConfig{})("registry.example.com")
+	require.NoError(t, err)
+	require.Empty(t, user)
+	require.Equal(t, "token", pass)
+
+	// Credentials of the task take precedence
+	tc := &TaskConfig{Auth: TaskAuth{Username: "task", Password: "pass"}}
+	user, pass, err = d.registryCredentials(tc)(dockerHubRegistry)
+	require.NoError(t, err)
+	require.Equal(t, "task", user)
+	require.Equal(t, "pass", pass)
+}
@@ -0,0 +1,164 @@
+package containerd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/armon/circbuf"
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// execIO are the streams of a command run in a task's container
+type execIO struct {
+	tty      bool
+	stdin    io.Reader
+	stdout   io.Writer
+	stderr   io.Writer
+	resizeCh <-chan drivers.TerminalSize
+}
+
+func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*drivers.ExecTaskResult, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	if len(cmd) == 0 {
+		return nil, fmt.Errorf("cmd is required, but was empty")
+	}
+
+	ctx, cancel := context.WithTimeout(d.withNamespace(d.ctx), timeout)
+	defer cancel()
+
+	stdout, _ := circbuf.NewBuffer(int64(drivers.CheckBufSize))
+	stderr, _ := circbuf.NewBuffer(int64(drivers.CheckBufSize))
+	exitCode, err := d.exec(ctx, handle, cmd, &execIO{stdout: stdout, stderr: stderr})
+	if err != nil {
+		return nil, err
+	}
+
+	return &drivers.ExecTaskResult{
+		Stdout: stdout.Bytes(),
+		Stderr: stderr.Bytes(),
+		ExitResult: &drivers.ExitResult{
+			ExitCode: exitCode,
+		},
+	}, nil
+}
+
+var _ drivers.ExecTaskStreamingDriver = (*Driver)(nil)
+
+func (d *Driver) ExecTaskStreaming(ctx context.Context, taskID string, opts *drivers.ExecOptions) (*drivers.ExitResult, error) {
+	defer opts.Stdout.Close()
+	defer opts.Stderr.Close()
+
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	if len(opts.Command) == 0 {
+		return nil, fmt.Errorf("command is required but was empty")
+	}
+
+	exitCode, err := d.exec(d.withNamespace(ctx), handle, opts.Command, &execIO{
+		tty:      opts.Tty,
+		stdin:    opts.Stdin,
+		stdout:   opts.Stdout,
+		stderr:   opts.Stderr,
+		resizeCh: opts.ResizeCh,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &drivers.ExitResult{
+		ExitCode: exitCode,
+	}, nil
+}
+
+// exec runs cmd in the task's container with the given streams and returns
+// its exit code once it has exited
+func (d *Driver) exec(ctx context.Context, handle *taskHandle, cmd []string, streams *execIO) (int, error) {
+	spec, err := handle.task.Spec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get spec of task: %v", err)
+	}
+
+	// The command runs with the same environment, user and working
+	// directory as the task
+	pspec := *spec.Process
+	pspec.Args = cmd
+	pspec.Terminal = streams.tty
+
+	// The fifos used to stream the command's IO are created within the task
+	// directory so that they are cleaned up with the task
+	ioOpts := []cio.Opt{
+		cio.WithStreams(streams.stdin, streams.stdout, streams.stderr),
+		cio.WithFIFODir(handle.taskConfig.TaskDir().Dir),
+	}
+	if streams.tty {
+		ioOpts = append(ioOpts, cio.WithTerminal)
+	}
+
+	process, err := handle.task.Exec(ctx, uuid.Generate(), &pspec, cio.NewCreator(ioOpts...))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create exec process: %v", err)
+	}
+
+	// The process is deleted with a context that isn't canceled so that it
+	// is killed if the exec times out or its caller goes away
+	defer func() {
+		if _, err := process.Delete(d.withNamespace(context.Background()), containerd.WithProcessKill); err != nil {
+			handle.logger.Warn("failed to delete exec process", "error", err)
+		}
+	}()
+
+	exitCh, err := process.Wait(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to wait on exec process: %v", err)
+	}
+
+	if err := process.Start(ctx); err != nil {
+		return 0, fmt.Errorf("failed to start exec process: %v", err)
+	}
+
+	if streams.tty && streams.resizeCh != nil {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case s, ok := <-streams.resizeCh:
+					if !ok {
+						return
+					}
+					if err := process.Resize(ctx, uint32(s.Width), uint32(s.Height)); err != nil {
+						handle.logger.Debug("failed to resize exec terminal", "error", err)
+					}
+				}
+			}
+		}()
+	}
+
+	var status containerd.ExitStatus
+	select {
+	case status = <-exitCh:
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+
+	code, _, err := status.Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to wait on exec process: %v", err)
+	}
+
+	// Wait for the remaining output to be copied
+	process.IO().Wait()
+	return int(code), nil
+}
@@ -0,0 +1,660 @@
+//go:build linux
+// +build linux
+
+package containerd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	containersapi "github.com/containerd/containerd/api/services/containers/v1"
+	contentapi "github.com/containerd/containerd/api/services/content/v1"
+	imagesapi "github.com/containerd/containerd/api/services/images/v1"
+	leasesapi "github.com/containerd/containerd/api/services/leases/v1"
+	snapshotsapi "github.com/containerd/containerd/api/services/snapshots/v1"
+	tasksapi "github.com/containerd/containerd/api/services/tasks/v1"
+	versionapi "github.com/containerd/containerd/api/services/version/v1"
+	"github.com/containerd/containerd/api/types"
+	tasktypes "github.com/containerd/containerd/api/types/task"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/fifo"
+	"github.com/containerd/typeurl"
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/identity"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fakeContainerd is an in-memory containerd serving the subset of its gRPC
+// API used by the driver. It holds a single unpacked image, and runs the
+// processes of tasks directly on the host.
+type fakeContainerd struct {
+	address string
+	server  *grpc.Server
+
+	// imageName is the name of the only image held by the fake
+	imageName string
+	image     imagesapi.Image
+	chainID   string
+	blobs     map[digest.Digest][]byte
+
+	// metrics is returned by the Metrics RPC for every task
+	metrics *ptypes.Any
+
+	lock       sync.Mutex
+	snapshots  map[string]struct{}
+	containers map[string]containersapi.Container
+	tasks      map[string]*fakeTask
+}
+
+// fakeTask is a task or exec process run by the fake
+type fakeTask struct {
+	id     string
+	execs  map[string]*fakeTask
+	args   []string
+	env    []string
+	stdin  string
+	stdout string
+	stderr string
+
+	cmd      *exec.Cmd
+	status   tasktypes.Status
+	exitCode uint32
+	exitedAt time.Time
+	exitCh   chan struct{}
+}
+
+// newFakeContainerd starts a fake containerd listening on a unix socket. The
+// entrypoint and command of its image are set from imageConfig.
+func newFakeContainerd(t *testing.T, imageConfig ocispec.ImageConfig) *fakeContainerd {
+	f := &fakeContainerd{
+		address:    filepath.Join(t.TempDir(), "containerd.sock"),
+		server:     grpc.NewServer(),
+		imageName:  "docker.io/library/busybox:latest",
+		blobs:      map[digest.Digest][]byte{},
+		snapshots:  map[string]struct{}{},
+		containers: map[string]containersapi.Container{},
+		tasks:      map[string]*fakeTask{},
+	}
+
+	layer := f.addBlob(t, []byte("layer"))
+	diffID := digest.FromString("diff")
+	config := f.addBlob(t, ocispec.Image{
+		Architecture: runtime.GOARCH,
+		OS:           "linux",
+		Config:       imageConfig,
+		RootFS: ocispec.RootFS{
+			Type:    "layers",
+			DiffIDs: []digest.Digest{diffID},
+		},
+	})
+	manifest := f.addBlob(t, ocispec.Manifest{
+		Config: ocispec.Descriptor{
+			MediaType: images.MediaTypeDockerSchema2Config,
+			Digest:    config,
+			Size:      int64(len(f.blobs[config])),
+		},
+		Layers: []ocispec.Descriptor{{
+			MediaType: images.MediaTypeDockerSchema2LayerGzip,
+			Digest:    layer,
+			Size:      int64(len(f.blobs[layer])),
+		}},
+	})
+
+	f.image = imagesapi.Image{
+		Name: f.imageName,
+		Target: types.Descriptor{
+			MediaType: images.MediaTypeDockerSchema2Manifest,
+			Digest:    manifest,
+			Size_:     int64(len(f.blobs[manifest])),
+		},
+	}
+	f.chainID = identity.ChainID([]digest.Digest{diffID}).String()
+	f.snapshots[f.chainID] = struct{}{}
+
+	versionapi.RegisterVersionServer(f.server, &fakeVersionService{})
+	imagesapi.RegisterImagesServer(f.server, &fakeImagesService{f: f})
+	contentapi.RegisterContentServer(f.server, &fakeContentService{f: f})
+	snapshotsapi.RegisterSnapshotsServer(f.server, &fakeSnapshotsService{f: f})
+	leasesapi.RegisterLeasesServer(f.server, &fakeLeasesService{})
+	containersapi.RegisterContainersServer(f.server, &fakeContainersService{f: f})
+	tasksapi.RegisterTasksServer(f.server, &fakeTasksService{f: f})
+
+	l, err := net.Listen("unix", f.address)
+	require.NoError(t, err)
+	go f.server.Serve(l)
+
+	t.Cleanup(f.stop)
+	return f
+}
+
+// stop stops the fake and kills the processes of its tasks
+func (f *fakeContainerd) stop() {
+	f.server.Stop()
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, task := range f.tasks {
+		for _, e := range task.execs {
+			e.kill(syscall.SIGKILL)
+		}
+		task.kill(syscall.SIGKILL)
+	}
+}
+
+// addBlob adds v, encoded as JSON unless it's a byte slice, to the content
+// store and returns its digest
+func (f *fakeContainerd) addBlob(t *testing.T, v interface{}) digest.Digest {
+	b, ok := v.([]byte)
+	if !ok {
+		var err error
+		b, err = json.Marshal(v)
+		require.NoError(t, err)
+	}
+	d := digest.FromBytes(b)
+	f.blobs[d] = b
+	return d
+}
+
+// container returns the container with the given ID
+func (f *fakeContainerd) container(id string) (containersapi.Container, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	c, ok := f.containers[id]
+	return c, ok
+}
+
+// containerSpec returns the OCI runtime spec of the container with the given
+// ID
+func (f *fakeContainerd) containerSpec(t *testing.T, id string) *specs.Spec {
+	c, ok := f.container(id)
+	require.True(t, ok, "container %q not found", id)
+
+	var spec specs.Spec
+	require.NoError(t, json.Unmarshal(c.Spec.Value, &spec))
+	return &spec
+}
+
+// hasSnapshot returns whether the snapshot with the given key exists
+func (f *fakeContainerd) hasSnapshot(key string) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	_, ok := f.snapshots[key]
+	return ok
+}
+
+// task returns the task, or exec process if execID is set, of a container
+func (f *fakeContainerd) task(containerID, execID string) (*fakeTask, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	task, ok := f.tasks[containerID]
+	if !ok {
+		return nil, errdefs.ToGRPCf(errdefs.ErrNotFound, "task %q not found", containerID)
+	}
+	if execID == "" {
+		return task, nil
+	}
+	e, ok := task.execs[execID]
+	if !ok {
+		return nil, errdefs.ToGRPCf(errdefs.ErrNotFound, "exec %q not found", execID)
+	}
+	return e, nil
+}
+
+// start starts the task's process on the host, with the task's fifos as its
+// standard streams. The task's status is updated while holding lock.
+func (t *fakeTask) start(lock *sync.Mutex) error {
+	cmd := exec.Command(t.args[0], t.args[1:]...)
+	cmd.Env = t.env
+
+	var closers []io.Closer
+	open := func(path string, flag int) (*os.File, error) {
+		if path == "" {
+			return nil, nil
+		}
+		f, err := os.OpenFile(path, flag, 0)
+		if err != nil {
+			return nil, err
+		}
+		closers = append(closers, f)
+		return f, nil
+	}
+
+	stdout, err := open(t.stdout, os.O_WRONLY)
+	if err != nil {
+		return err
+	}
+	stderr, err := open(t.stderr, os.O_WRONLY)
+	if err != nil {
+		return err
+	}
+	if t.stdin != "" {
+		// Like the shims, stdin is opened without waiting for its writer,
+		// which may never be opened if there is no input
+		stdin, err := fifo.OpenFifo(context.Background(), t.stdin, syscall.O_RDONLY|syscall.O_NONBLOCK, 0)
+		if err != nil {
+			return err
+		}
+		closers = append(closers, stdin)
+
+		// Unlike a fifo passed to the process, the copy of its input isn't
+		// waited on once the process exits
+		pr, pw, err := os.Pipe()
+		if err != nil {
+			return err
+		}
+		closers = append(closers, pr, pw)
+		cmd.Stdin = pr
+		go func() {
+			io.Copy(pw, stdin)
+			pw.Close()
+		}()
+	}
+	if stdout != nil {
+		cmd.Stdout = stdout
+	}
+	if stderr != nil {
+		cmd.Stderr = stderr
+	} else if stdout != nil {
+		// Terminals have a single output stream
+		cmd.Stderr = stdout
+	}
+
+	if err := cmd.Start(); err != nil {
+		for _, c := range closers {
+			c.Close()
+		}
+		return err
+	}
+
+	lock.Lock()
+	t.cmd = cmd
+	t.status = tasktypes.StatusRunning
+	lock.Unlock()
+
+	go func() {
+		cmd.Wait()
+		for _, c := range closers {
+			c.Close()
+		}
+
+		code := uint32(cmd.ProcessState.ExitCode())
+		if ws, ok := cmd.ProcessState.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			code = 128 + uint32(ws.Signal())
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+		t.exitCode = code
+		t.exitedAt = time.Now()
+		t.status = tasktypes.StatusStopped
+		close(t.exitCh)
+	}()
+	return nil
+}
+
+// kill sends sig to the task's process if it's running
+func (t *fakeTask) kill(sig syscall.Signal) {
+	if t.cmd != nil && t.status == tasktypes.StatusRunning {
+		t.cmd.Process.Signal(sig)
+	}
+}
+
+func (t *fakeTask) process(containerID string) *tasktypes.Process {
+	p := &tasktypes.Process{
+		ContainerID: containerID,
+		ID:          t.id,
+		Status:      t.status,
+		ExitStatus:  t.exitCode,
+		ExitedAt:    t.exitedAt,
+	}
+	if t.cmd != nil {
+		p.Pid = uint32(t.cmd.Process.Pid)
+	}
+	return p
+}
+
+type fakeVersionService struct {
+	versionapi.UnimplementedVersionServer
+}
+
+func (s *fakeVersionService) Version(context.Context, *ptypes.Empty) (*versionapi.VersionResponse, error) {
+	return &versionapi.VersionResponse{Version: "v1.5.9"}, nil
+}
+
+type fakeImagesService struct {
+	imagesapi.UnimplementedImagesServer
+	f *fakeContainerd
+}
+
+func (s *fakeImagesService) Get(_ context.Context, req *imagesapi.GetImageRequest) (*imagesapi.GetImageResponse, error) {
+	if req.Name != s.f.imageName {
+		return nil, errdefs.ToGRPCf(errdefs.ErrNotFound, "image %q not found", req.Name)
+	}
+	image := s.f.image
+	return &imagesapi.GetImageResponse{Image: &image}, nil
+}
+
+type fakeContentService struct {
+	contentapi.UnimplementedContentServer
+	f *fakeContainerd
+}
+
+func (s *fakeContentService) Info(_ context.Context, req *contentapi.InfoRequest) (*contentapi.InfoResponse, error) {
+	b, ok := s.f.blobs[req.Digest]
+	if !ok {
+		return nil, errdefs.ToGRPCf(errdefs.ErrNotFound, "content %v not found", req.Digest)
+	}
+	return &contentapi.InfoResponse{
+		Info: contentapi.Info{Digest: req.Digest, Size_: int64(len(b))},
+	}, nil
+}
+
+func (s *fakeContentService) Read(req *contentapi.ReadContentRequest, srv contentapi.Content_ReadServer) error {
+	b, ok := s.f.blobs[req.Digest]
+	if !ok {
+		return errdefs.ToGRPCf(errdefs.ErrNotFound, "content %v not found", req.Digest)
+	}
+	if req.Offset > int64(len(b)) {
+		return errdefs.ToGRPCf(errdefs.ErrInvalidArgument, "offset out of range")
+	}
+	b = b[req.Offset:]
+	if req.Size_ > 0 && req.Size_ < int64(len(b)) {
+		b = b[:req.Size_]
+	}
+	return srv.Send(&contentapi.ReadContentResponse{Offset: req.Offset, Data: b})
+}
+
+type fakeSnapshotsService struct {
+	snapshotsapi.UnimplementedSnapshotsServer
+	f *fakeContainerd
+}
+
+func (s *fakeSnapshotsService) Stat(_ context.Context, req *snapshotsapi.StatSnapshotRequest) (*snapshotsapi.StatSnapshotResponse, error) {
+	if !s.f.hasSnapshot(req.Key) {
+		return nil, errdefs.ToGRPCf(errdefs.ErrNotFound, "snapshot %q not found", req.Key)
+	}
+	return &snapshotsapi.StatSnapshotResponse{
+		Info: snapshotsapi.Info{Name: req.Key, Kind: snapshotsapi.KindCommitted},
+	}, nil
+}
+
+func (s *fakeSnapshotsService) Prepare(_ context.Context, req *snapshotsapi.PrepareSnapshotRequest) (*snapshotsapi.PrepareSnapshotResponse, error) {
+	s.f.lock.Lock()
+	defer s.f.lock.Unlock()
+
+	if _, ok := s.f.snapshots[req.Key]; ok {
+		return nil, errdefs.ToGRPCf(errdefs.ErrAlreadyExists, "snapshot %q exists", req.Key)
+	}
+	if _, ok := s.f.snapshots[req.Parent]; !ok {
+		return nil, errdefs.ToGRPCf(errdefs.ErrNotFound, "parent snapshot %q not found", req.Parent)
+	}
+	s.f.snapshots[req.Key] = struct{}{}
+	return &snapshotsapi.PrepareSnapshotResponse{Mounts: s.mounts(req.Key)}, nil
+}
+
+func (s *fakeSnapshotsService) Mounts(_ context.Context, req *snapshotsapi.MountsRequest) (*snapshotsapi.MountsResponse, error) {
+	if !s.f.hasSnapshot(req.Key) {
+		return nil, errdefs.ToGRPCf(errdefs.ErrNotFound, "snapshot %q not found", req.Key)
+	}
+	return &snapshotsapi.MountsResponse{Mounts: s.mounts(req.Key)}, nil
+}
+
+func (s *fakeSnapshotsService) Remove(_ context.Context, req *snapshotsapi.RemoveSnapshotRequest) (*ptypes.Empty, error) {
+	s.f.lock.Lock()
+	defer s.f.lock.Unlock()
+
+	if _, ok := s.f.snapshots[req.Key]; !ok {
+		return nil, errdefs.ToGRPCf(errdefs.ErrNotFound, "snapshot %q not found", req.Key)
+	}
+	delete(s.f.snapshots, req.Key)
+	return &ptypes.Empty{}, nil
+}
+
+func (s *fakeSnapshotsService) mounts(key string) []*types.Mount {
+	return []*types.Mount{{Type: "bind", Source: "/snapshots/" + key, Options: []string{"rbind"}}}
+}
+
+type fakeLeasesService struct {
+	leasesapi.UnimplementedLeasesServer
+}
+
+func (s *fakeLeasesService) Create(_ context.Context, req *leasesapi.CreateRequest) (*leasesapi.CreateResponse, error) {
+	return &leasesapi.CreateResponse{Lease: &leasesapi.Lease{ID: req.ID, Labels: req.Labels}}, nil
+}
+
+func (s *fakeLeasesService) Delete(context.Context, *leasesapi.DeleteRequest) (*ptypes.Empty, error) {
+	return &ptypes.Empty{}, nil
+}
+
+type fakeContainersService struct {
+	containersapi.UnimplementedContainersServer
+	f *fakeContainerd
+}
+
+func (s *fakeContainersService) Get(_ context.Context, req *containersapi.GetContainerRequest) (*containersapi.GetContainerResponse, error) {
+	c, ok := s.f.container(req.ID)
+	if !ok {
+		return nil, errdefs.ToGRPCf(errdefs.ErrNotFound, "container %q not found", req.ID)
+	}
+	return &containersapi.GetContainerResponse{Container: c}, nil
+}
+
+func (s *fakeContainersService) Create(_ context.Context, req *containersapi.CreateContainerRequest) (*containersapi.CreateContainerResponse, error) {
+	s.f.lock.Lock()
+	defer s.f.lock.Unlock()
+
+	if _, ok := s.f.containers[req.Container.ID]; ok {
+		return nil, errdefs.ToGRPCf(errdefs.ErrAlreadyExists, "container %q exists", req.Container.ID)
+	}
+	s.f.containers[req.Container.ID] = req.Container
+	return &containersapi.CreateContainerResponse{Container: req.Container}, nil
+}
+
+func (s *fakeContainersService) Delete(_ context.Context, req *containersapi.DeleteContainerRequest) (*ptypes.Empty, error) {
+	s.f.lock.Lock()
+	defer s.f.lock.Unlock()
+
+	if _, ok := s.f.containers[req.ID]; !ok {
+		return nil, errdefs.ToGRPCf(errdefs.ErrNotFound, "container %q not found", req.ID)
+	}
+	delete(s.f.containers, req.ID)
+	return &ptypes.Empty{}, nil
+}
+
+type fakeTasksService struct {
+	tasksapi.UnimplementedTasksServer
+	f *fakeContainerd
+}
+
+func (s *fakeTasksService) Create(_ context.Context, req *tasksapi.CreateTaskRequest) (*tasksapi.CreateTaskResponse, error) {
+	c, ok := s.f.container(req.ContainerID)
+	if !ok {
+		return nil, errdefs.ToGRPCf(errdefs.ErrNotFound, "container %q not found", req.ContainerID)
+	}
+	var spec specs.Spec
+	if err := json.Unmarshal(c.Spec.Value, &spec); err != nil {
+		return nil, errdefs.ToGRPC(err)
+	}
+
+	s.f.lock.Lock()
+	defer s.f.lock.Unlock()
+
+	if _, ok := s.f.tasks[req.ContainerID]; ok {
+		return nil, errdefs.ToGRPCf(errdefs.ErrAlreadyExists, "task %q exists", req.ContainerID)
+	}
+	s.f.tasks[req.ContainerID] = &fakeTask{
+		id:     req.ContainerID,
+		execs:  map[string]*fakeTask{},
+		args:   spec.Process.Args,
+		env:    spec.Process.Env,
+		stdout: req.Stdout,
+		stderr: req.Stderr,
+		status: tasktypes.StatusCreated,
+		exitCh: make(chan struct{}),
+	}
+	return &tasksapi.CreateTaskResponse{ContainerID: req.ContainerID}, nil
+}
+
+func (s *fakeTasksService) Start(_ context.Context, req *tasksapi.StartRequest) (*tasksapi.StartResponse, error) {
+	task, err := s.f.task(req.ContainerID, req.ExecID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The task's fifos are opened without holding the lock as opening them
+	// blocks until their other end is opened
+	if err := task.start(&s.f.lock); err != nil {
+		return nil, errdefs.ToGRPC(err)
+	}
+
+	s.f.lock.Lock()
+	defer s.f.lock.Unlock()
+	return &tasksapi.StartResponse{Pid: uint32(task.cmd.Process.Pid)}, nil
+}
+
+func (s *fakeTasksService) Get(_ context.Context, req *tasksapi.GetRequest) (*tasksapi.GetResponse, error) {
+	task, err := s.f.task(req.ContainerID, req.ExecID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.f.lock.Lock()
+	defer s.f.lock.Unlock()
+	return &tasksapi.GetResponse{Process: task.process(req.ContainerID)}, nil
+}
+
+func (s *fakeTasksService) Kill(_ context.Context, req *tasksapi.KillRequest) (*ptypes.Empty, error) {
+	task, err := s.f.task(req.ContainerID, req.ExecID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.f.lock.Lock()
+	defer s.f.lock.Unlock()
+	if task.status != tasktypes.StatusRunning {
+		return nil, errdefs.ToGRPCf(errdefs.ErrNotFound, "process already finished")
+	}
+	task.kill(syscall.Signal(req.Signal))
+	return &ptypes.Empty{}, nil
+}
+
+func (s *fakeTasksService) Wait(ctx context.Context, req *tasksapi.WaitRequest) (*tasksapi.WaitResponse, error) {
+	task, err := s.f.task(req.ContainerID, req.ExecID)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, errdefs.ToGRPC(ctx.Err())
+	case <-task.exitCh:
+	}
+
+	s.f.lock.Lock()
+	defer s.f.lock.Unlock()
+	return &tasksapi.WaitResponse{ExitStatus: task.exitCode, ExitedAt: task.exitedAt}, nil
+}
+
+func (s *fakeTasksService) Delete(_ context.Context, req *tasksapi.DeleteTaskRequest) (*tasksapi.DeleteResponse, error) {
+	task, err := s.f.task(req.ContainerID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	s.f.lock.Lock()
+	defer s.f.lock.Unlock()
+	if task.status == tasktypes.StatusRunning {
+		return nil, errdefs.ToGRPCf(errdefs.ErrFailedPrecondition, "task %q is running", req.ContainerID)
+	}
+	delete(s.f.tasks, req.ContainerID)
+	return &tasksapi.DeleteResponse{ID: req.ContainerID, ExitStatus: task.exitCode, ExitedAt: task.exitedAt}, nil
+}
+
+func (s *fakeTasksService) Exec(_ context.Context, req *tasksapi.ExecProcessRequest) (*ptypes.Empty, error) {
+	task, err := s.f.task(req.ContainerID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var pspec specs.Process
+	if err := json.Unmarshal(req.Spec.Value, &pspec); err != nil {
+		return nil, errdefs.ToGRPC(err)
+	}
+
+	s.f.lock.Lock()
+	defer s.f.lock.Unlock()
+	if task.status != tasktypes.StatusRunning {
+		return nil, errdefs.ToGRPCf(errdefs.ErrFailedPrecondition, "task %q isn't running", req.ContainerID)
+	}
+	task.execs[req.ExecID] = &fakeTask{
+		id:     req.ExecID,
+		args:   pspec.Args,
+		env:    pspec.Env,
+		stdin:  req.Stdin,
+		stdout: req.Stdout,
+		stderr: req.Stderr,
+		status: tasktypes.StatusCreated,
+		exitCh: make(chan struct{}),
+	}
+	return &ptypes.Empty{}, nil
+}
+
+func (s *fakeTasksService) ResizePty(_ context.Context, req *tasksapi.ResizePtyRequest) (*ptypes.Empty, error) {
+	if _, err := s.f.task(req.ContainerID, req.ExecID); err != nil {
+		return nil, err
+	}
+	return &ptypes.Empty{}, nil
+}
+
+func (s *fakeTasksService) DeleteProcess(_ context.Context, req *tasksapi.DeleteProcessRequest) (*tasksapi.DeleteResponse, error) {
+	e, err := s.f.task(req.ContainerID, req.ExecID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.f.lock.Lock()
+	defer s.f.lock.Unlock()
+	if e.status == tasktypes.StatusRunning {
+		return nil, errdefs.ToGRPCf(errdefs.ErrFailedPrecondition, "exec %q is running", req.ExecID)
+	}
+	delete(s.f.tasks[req.ContainerID].execs, req.ExecID)
+	return &tasksapi.DeleteResponse{ID: req.ExecID, ExitStatus: e.exitCode, ExitedAt: e.exitedAt}, nil
+}
+
+func (s *fakeTasksService) Metrics(_ context.Context, req *tasksapi.MetricsRequest) (*tasksapi.MetricsResponse, error) {
+	if s.f.metrics == nil {
+		return nil, errdefs.ToGRPCf(errdefs.ErrNotImplemented, "no metrics")
+	}
+
+	s.f.lock.Lock()
+	defer s.f.lock.Unlock()
+
+	resp := &tasksapi.MetricsResponse{}
+	for id := range s.f.tasks {
+		resp.Metrics = append(resp.Metrics, &types.Metric{ID: id, Data: s.f.metrics})
+	}
+	return resp, nil
+}
+
+// setMetrics sets the metrics returned for every task
+func (f *fakeContainerd) setMetrics(t *testing.T, m interface{}) {
+	any, err := typeurl.MarshalAny(m)
+	require.NoError(t, err)
+	f.metrics = any
+}
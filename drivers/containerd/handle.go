@@ -0,0 +1,154 @@
+package containerd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/stats"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// sigkill is the signal used to forcefully stop tasks
+const sigkill = syscall.SIGKILL
+
+type taskHandle struct {
+	container containerd.Container
+	task      containerd.Task
+	pid       uint32
+	logger    hclog.Logger
+
+	// waitCh is closed once the task has exited and exitResult is set
+	waitCh chan struct{}
+
+	// cpu stats track the previous cpu usage of the task to compute the
+	// percentage used since
+	totalCpuStats  *stats.CpuStats
+	userCpuStats   *stats.CpuStats
+	systemCpuStats *stats.CpuStats
+
+	// stateLock syncs access to all fields below
+	stateLock sync.RWMutex
+
+	taskConfig  *drivers.TaskConfig
+	procState   drivers.TaskState
+	startedAt   time.Time
+	completedAt time.Time
+	exitResult  *drivers.ExitResult
+}
+
+func newTaskHandle(d *Driver, cfg *drivers.TaskConfig, container containerd.Container, task containerd.Task) *taskHandle {
+	return &taskHandle{
+		container:      container,
+		task:           task,
+		logger:         d.logger.With("container_id", container.ID()),
+		waitCh:         make(chan struct{}),
+		totalCpuStats:  stats.NewCpuStats(),
+		userCpuStats:   stats.NewCpuStats(),
+		systemCpuStats: stats.NewCpuStats(),
+		taskConfig:     cfg,
+		procState:      drivers.TaskStateRunning,
+	}
+}
+
+func (h *taskHandle) TaskStatus() *drivers.TaskStatus {
+	h.stateLock.RLock()
+	defer h.stateLock.RUnlock()
+
+	return &drivers.TaskStatus{
+		ID:          h.taskConfig.ID,
+		Name:        h.taskConfig.Name,
+		State:       h.procState,
+		StartedAt:   h.startedAt,
+		CompletedAt: h.completedAt,
+		ExitResult:  h.exitResult,
+		DriverAttributes: map[string]string{
+			"container_id": h.container.ID(),
+			"pid":          strconv.FormatUint(uint64(h.pid), 10),
+		},
+	}
+}
+
+func (h *taskHandle) IsRunning() bool {
+	h.stateLock.RLock()
+	defer h.stateLock.RUnlock()
+	return h.procState == drivers.TaskStateRunning
+}
+
+func (h *taskHandle) ExitResult() *drivers.ExitResult {
+	h.stateLock.RLock()
+	defer h.stateLock.RUnlock()
+	return h.exitResult.Copy()
+}
+
+// run blocks until the task exits and records its exit status
+func (h *taskHandle) run(exitCh <-chan containerd.ExitStatus) {
+	status := <-exitCh
+	code, exitedAt, err := status.Result()
+
+	h.stateLock.Lock()
+	defer h.stateLock.Unlock()
+
+	h.exitResult = &drivers.ExitResult{}
+	if err != nil {
+		h.logger.Error("failed to wait for task", "error", err)
+		h.exitResult.Err = err
+		h.procState = drivers.TaskStateUnknown
+		h.completedAt = time.Now()
+	} else {
+		h.exitResult.ExitCode = int(code)
+		h.procState = drivers.TaskStateExited
+		h.completedAt = exitedAt
+	}
+	close(h.waitCh)
+}
+
+// Signal sends the signal to the task's main process
+func (h *taskHandle) Signal(ctx context.Context, s os.Signal) error {
+	sig, ok := s.(syscall.Signal)
+	if !ok {
+		return fmt.Errorf("failed to determine signal number")
+	}
+	return h.task.Kill(ctx, sig)
+}
+
+// Kill sends the signal to the task and waits up to timeout for it to exit
+// before killing all of its processes.
+func (h *taskHandle) Kill(ctx context.Context, timeout time.Duration, s os.Signal) error {
+	if s != sigkill {
+		if err := h.Signal(ctx, s); err != nil {
+			if errdefs.IsNotFound(err) || errdefs.IsFailedPrecondition(err) {
+				h.logger.Debug("attempted to signal a task that is not running")
+				return nil
+			}
+			return fmt.Errorf("failed to signal task: %v", err)
+		}
+
+		select {
+		case <-h.waitCh:
+			return nil
+		case <-time.After(timeout):
+		}
+	}
+
+	if err := h.task.Kill(ctx, sigkill, containerd.WithKillAll); err != nil {
+		if errdefs.IsNotFound(err) || errdefs.IsFailedPrecondition(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to kill task: %v", err)
+	}
+
+	select {
+	case <-h.waitCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,130 @@
+package containerd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/reference/docker"
+	remotesdocker "github.com/containerd/containerd/remotes/docker"
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// dockerHubRegistry is the host images from Docker Hub are pulled from
+	// and dockerHubConfigKey is the key of its credentials in Docker config
+	// files
+	dockerHubRegistry  = "registry-1.docker.io"
+	dockerHubConfigKey = "https://index.docker.io/v1/"
+)
+
+// pullImage returns the task's image, pulling and unpacking it if it isn't
+// present yet
+func (d *Driver) pullImage(ctx context.Context, client *containerd.Client, task *drivers.TaskConfig, tc *TaskConfig) (containerd.Image, error) {
+	named, err := docker.ParseDockerRef(tc.Image)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image %q: %v", tc.Image, err)
+	}
+	ref := named.String()
+
+	image, err := client.GetImage(ctx, ref)
+	switch {
+	case err == nil:
+		unpacked, err := image.IsUnpacked(ctx, d.config.Snapshotter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if image %q is unpacked: %v", ref, err)
+		}
+		if unpacked {
+			d.logger.Trace("using existing image", "image", ref)
+			return image, nil
+		}
+	case !errdefs.IsNotFound(err):
+		return nil, fmt.Errorf("failed to get image %q: %v", ref, err)
+	}
+
+	d.eventer.EmitEvent(&drivers.TaskEvent{
+		TaskID:    task.ID,
+		AllocID:   task.AllocID,
+		TaskName:  task.Name,
+		Timestamp: time.Now(),
+		Message:   "Downloading image",
+		Annotations: map[string]string{
+			"image": ref,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(ctx, tc.imagePullTimeout())
+	defer cancel()
+
+	resolver := remotesdocker.NewResolver(remotesdocker.ResolverOptions{
+		Hosts: remotesdocker.ConfigureDefaultRegistries(
+			remotesdocker.WithAuthorizer(remotesdocker.NewDockerAuthorizer(
+				remotesdocker.WithAuthCreds(d.registryCredentials(tc)),
+			)),
+		),
+	})
+
+	image, err = client.Pull(ctx, ref,
+		containerd.WithPullUnpack,
+		containerd.WithPullSnapshotter(d.config.Snapshotter),
+		containerd.WithResolver(resolver),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull image %q: %v", ref, err)
+	}
+	return image, nil
+}
+
+// registryCredentials returns the function used to look up the credentials
+// for a registry host. Credentials set in the task take precedence over those
+// of the plugin's Docker config file.
+func (d *Driver) registryCredentials(tc *TaskConfig) func(string) (string, string, error) {
+	return func(host string) (string, string, error) {
+		if tc.Auth.Username != "" || tc.Auth.Password != "" {
+			return tc.Auth.Username, tc.Auth.Password, nil
+		}
+
+		if d.config.Auth.Config == "" {
+			return "", "", nil
+		}
+
+		cfile, err := loadDockerConfig(d.config.Auth.Config)
+		if err != nil {
+			return "", "", err
+		}
+
+		if host == dockerHubRegistry {
+			host = dockerHubConfigKey
+		}
+		auth, err := cfile.GetAuthConfig(host)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get credentials of registry %q: %v", host, err)
+		}
+
+		// Identity tokens are passed to the registry as a refresh token with
+		// an empty username
+		if auth.IdentityToken != "" {
+			return "", auth.IdentityToken, nil
+		}
+		return auth.Username, auth.Password, nil
+	}
+}
+
+// loadDockerConfig loads the Docker config file at path
+func loadDockerConfig(path string) (*configfile.ConfigFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open auth config file: %v", err)
+	}
+	defer f.Close()
+
+	cfile := configfile.New(path)
+	if err := cfile.LoadFromReader(f); err != nil {
+		return nil, fmt.Errorf("failed to parse auth config file: %v", err)
+	}
+	return cfile, nil
+}
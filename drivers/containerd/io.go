@@ -0,0 +1,30 @@
+package containerd
+
+import (
+	"github.com/containerd/containerd/cio"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// logIO returns a cio.Creator that has the shim write the task's output
+// directly into the task's log fifos. The driver doesn't copy any output
+// itself, so logs keep flowing while the Nomad client is stopped.
+func logIO(cfg *drivers.TaskConfig) cio.Creator {
+	return func(string) (cio.IO, error) {
+		return &directIO{
+			config: cio.Config{
+				Stdout: cfg.StdoutPath,
+				Stderr: cfg.StderrPath,
+			},
+		}, nil
+	}
+}
+
+// directIO is a cio.IO of paths that are opened by the shim
+type directIO struct {
+	config cio.Config
+}
+
+func (d *directIO) Config() cio.Config { return d.config }
+func (d *directIO) Cancel()            {}
+func (d *directIO) Wait()              {}
+func (d *directIO) Close() error       { return nil }
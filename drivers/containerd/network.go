@@ -0,0 +1,11 @@
+package containerd
+
+import "github.com/hashicorp/nomad/plugins/drivers"
+
+// netSpecHostnameKey is the label set on the network isolation spec when the
+// group network has a hostname. It shares its key with the Docker driver's
+// label so that the client's network hook writes the hostname into the
+// allocation's /etc/hosts file.
+const netSpecHostnameKey = "docker_sandbox_hostname"
+
+var _ drivers.DriverNetworkManager = (*Driver)(nil)
@@ -0,0 +1,18 @@
+//go:build !linux
+// +build !linux
+
+package containerd
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+func (d *Driver) CreateNetwork(string, *drivers.NetworkCreateRequest) (*drivers.NetworkIsolationSpec, bool, error) {
+	return nil, false, fmt.Errorf("containerd driver unsupported on client OS")
+}
+
+func (d *Driver) DestroyNetwork(string, *drivers.NetworkIsolationSpec) error {
+	return fmt.Errorf("containerd driver unsupported on client OS")
+}
@@ -0,0 +1,45 @@
+package containerd
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/hashicorp/nomad/client/lib/nsutil"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// CreateNetwork creates the network namespace shared by the tasks of the
+// allocation. Unlike the Docker driver no sandbox container is needed, as
+// tasks join the namespace by its path.
+func (d *Driver) CreateNetwork(allocID string, createSpec *drivers.NetworkCreateRequest) (*drivers.NetworkIsolationSpec, bool, error) {
+	spec := &drivers.NetworkIsolationSpec{
+		Mode:   drivers.NetIsolationModeGroup,
+		Path:   filepath.Join(nsutil.NetNSRunDir, allocID),
+		Labels: make(map[string]string),
+	}
+
+	// If the user supplied a hostname, set the label.
+	if createSpec.Hostname != "" {
+		spec.Labels[netSpecHostnameKey] = createSpec.Hostname
+	}
+
+	netns, err := nsutil.NewNS(allocID)
+	if err != nil {
+		// When the client restarts the namespace already exists and is in
+		// use by the allocation's tasks
+		if e, ok := err.(*os.PathError); ok && e.Err == syscall.EPERM {
+			if _, err := os.Stat(spec.Path); err == nil {
+				return spec, false, nil
+			}
+		}
+		return nil, false, err
+	}
+
+	spec.Path = netns.Path()
+	return spec, true, nil
+}
+
+func (d *Driver) DestroyNetwork(allocID string, spec *drivers.NetworkIsolationSpec) error {
+	return nsutil.UnmountNS(spec.Path)
+}
@@ -0,0 +1,51 @@
+package containerd
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/content"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// readImageConfig returns the runtime configuration of the image
+func readImageConfig(ctx context.Context, image containerd.Image) (ocispec.ImageConfig, error) {
+	desc, err := image.Config(ctx)
+	if err != nil {
+		return ocispec.ImageConfig{}, err
+	}
+
+	buf, err := content.ReadBlob(ctx, image.ContentStore(), desc)
+	if err != nil {
+		return ocispec.ImageConfig{}, err
+	}
+
+	var spec ocispec.Image
+	if err := json.Unmarshal(buf, &spec); err != nil {
+		return ocispec.ImageConfig{}, err
+	}
+	return spec.Config, nil
+}
+
+// processArgs returns the arguments of the task's process. Like Docker, the
+// task's entrypoint replaces the image's entrypoint and its command and args
+// replace the image's command.
+func processArgs(config ocispec.ImageConfig, tc *TaskConfig) []string {
+	entrypoint, cmd := config.Entrypoint, config.Cmd
+	if len(tc.Entrypoint) > 0 {
+		// The image's command is meant for the image's entrypoint
+		entrypoint, cmd = tc.Entrypoint, nil
+	}
+
+	switch {
+	case tc.Command != "":
+		cmd = append([]string{tc.Command}, tc.Args...)
+	case len(tc.Args) > 0:
+		cmd = tc.Args
+	}
+
+	args := make([]string, 0, len(entrypoint)+len(cmd))
+	args = append(args, entrypoint...)
+	return append(args, cmd...)
+}
@@ -0,0 +1,17 @@
+//go:build !linux
+// +build !linux
+
+package containerd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/oci"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+func (d *Driver) specOpts(context.Context, *drivers.TaskConfig, *TaskConfig, containerd.Image) ([]oci.SpecOpts, error) {
+	return nil, fmt.Errorf("containerd driver unsupported on client OS")
+}
@@ -0,0 +1,175 @@
+package containerd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/oci"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/drivers/shared/hostnames"
+	"github.com/hashicorp/nomad/drivers/shared/resolvconf"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// mountPropagationModes maps the propagation modes of volume mounts to their
+// bind mount options
+var mountPropagationModes = map[string]string{
+	"":                                       "rprivate",
+	structs.VolumeMountPropagationPrivate:    "rprivate",
+	structs.VolumeMountPropagationHostToTask: "rslave",
+	structs.VolumeMountPropagationBidirectional: "rshared",
+}
+
+// specOpts returns the options used to build the OCI runtime spec of the
+// task's container
+func (d *Driver) specOpts(ctx context.Context, cfg *drivers.TaskConfig, tc *TaskConfig, image containerd.Image) ([]oci.SpecOpts, error) {
+	imageConfig, err := readImageConfig(ctx, image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config of image %q: %v", image.Name(), err)
+	}
+
+	args := processArgs(imageConfig, tc)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("command must be set when the image has no entrypoint or command")
+	}
+
+	// The image's configuration is applied here rather than with
+	// oci.WithImageConfig, which mounts the container's snapshot to look up
+	// the user's supplementary groups
+	opts := []oci.SpecOpts{oci.WithProcessArgs(args...)}
+	if len(imageConfig.Env) == 0 {
+		opts = append(opts, oci.WithDefaultPathEnv)
+	} else {
+		opts = append(opts, oci.WithEnv(imageConfig.Env))
+	}
+	opts = append(opts, oci.WithEnv(cfg.EnvList()))
+
+	cwd := imageConfig.WorkingDir
+	if tc.WorkDir != "" {
+		cwd = tc.WorkDir
+	}
+	if cwd != "" {
+		opts = append(opts, oci.WithProcessCwd(cwd))
+	}
+
+	user := imageConfig.User
+	if cfg.User != "" {
+		user = cfg.User
+	}
+	if user != "" {
+		opts = append(opts, oci.WithUser(user))
+	}
+
+	if tc.ReadonlyRootfs {
+		opts = append(opts, oci.WithRootFSReadonly())
+	}
+	if tc.Privileged {
+		opts = append(opts, oci.WithPrivileged, oci.WithAllDevicesAllowed, oci.WithHostDevices)
+	}
+
+	if res := cfg.Resources; res != nil && res.LinuxResources != nil {
+		if res.LinuxResources.CPUShares > 0 {
+			opts = append(opts, oci.WithCPUShares(uint64(res.LinuxResources.CPUShares)))
+		}
+		if res.LinuxResources.MemoryLimitBytes > 0 {
+			opts = append(opts, oci.WithMemoryLimit(uint64(res.LinuxResources.MemoryLimitBytes)))
+		}
+		if res.LinuxResources.CpusetCpus != "" {
+			opts = append(opts, oci.WithCPUs(res.LinuxResources.CpusetCpus))
+		}
+	}
+
+	for _, dev := range cfg.Devices {
+		opts = append(opts, oci.WithDevices(dev.HostPath, dev.TaskPath, dev.Permissions))
+	}
+
+	mounts, err := d.mounts(cfg)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, oci.WithMounts(mounts))
+
+	// Tasks join the network namespace created for the group, or share the
+	// host's network otherwise
+	if cfg.NetworkIsolation != nil && cfg.NetworkIsolation.Path != "" {
+		opts = append(opts, oci.WithLinuxNamespace(specs.LinuxNamespace{
+			Type: specs.NetworkNamespace,
+			Path: cfg.NetworkIsolation.Path,
+		}))
+	} else {
+		opts = append(opts, oci.WithHostNamespace(specs.NetworkNamespace), oci.WithHostHostsFile)
+	}
+
+	hostname := tc.Hostname
+	if hostname == "" && cfg.NetworkIsolation != nil {
+		hostname = cfg.NetworkIsolation.Labels[netSpecHostnameKey]
+	}
+	if hostname != "" {
+		opts = append(opts, oci.WithHostname(hostname))
+	}
+
+	return opts, nil
+}
+
+// mounts returns the mounts of the task's container: the task directories,
+// the task's volumes, and the files configuring name resolution
+func (d *Driver) mounts(cfg *drivers.TaskConfig) ([]specs.Mount, error) {
+	taskDir := cfg.TaskDir()
+	mounts := []specs.Mount{
+		bindMount(taskDir.SharedAllocDir, cfg.Env[taskenv.AllocDir], false, ""),
+		bindMount(taskDir.LocalDir, cfg.Env[taskenv.TaskLocalDir], false, ""),
+		bindMount(taskDir.SecretsDir, cfg.Env[taskenv.SecretsDir], false, ""),
+	}
+
+	for _, m := range cfg.Mounts {
+		propagation, ok := mountPropagationModes[m.PropagationMode]
+		if !ok {
+			return nil, fmt.Errorf("unknown mount propagation mode %q", m.PropagationMode)
+		}
+		mounts = append(mounts, bindMount(m.HostPath, m.TaskPath, m.Readonly, propagation))
+	}
+
+	// Without a DNS configuration, the host's resolv.conf is used like for
+	// other drivers sharing the host's network
+	if cfg.DNS != nil {
+		dnsMount, err := resolvconf.GenerateDNSMount(taskDir.Dir, cfg.DNS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build mount for resolv.conf: %v", err)
+		}
+		mounts = append(mounts, bindMount(dnsMount.HostPath, dnsMount.TaskPath, dnsMount.Readonly, ""))
+	} else {
+		mounts = append(mounts, bindMount("/etc/resolv.conf", "/etc/resolv.conf", true, ""))
+	}
+
+	etcHostsMount, err := hostnames.GenerateEtcHostsMount(cfg.AllocDir, cfg.NetworkIsolation, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build mount for /etc/hosts: %v", err)
+	}
+	if etcHostsMount != nil {
+		mounts = append(mounts, bindMount(etcHostsMount.HostPath, etcHostsMount.TaskPath, etcHostsMount.Readonly, ""))
+	}
+
+	return mounts, nil
+}
+
+// bindMount returns a recursive bind mount of source at destination
+func bindMount(source, destination string, readonly bool, propagation string) specs.Mount {
+	options := []string{"rbind"}
+	if readonly {
+		options = append(options, "ro")
+	} else {
+		options = append(options, "rw")
+	}
+	if propagation != "" {
+		options = append(options, propagation)
+	}
+	return specs.Mount{
+		Type:        "bind",
+		Source:      source,
+		Destination: destination,
+		Options:     options,
+	}
+}
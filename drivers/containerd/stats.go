@@ -0,0 +1,132 @@
+package containerd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/containerd/cgroups/stats/v1"
+	v2 "github.com/containerd/cgroups/v2/stats"
+	"github.com/containerd/typeurl"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+var (
+	// measuredCpuStats are the CPU stats reported for tasks
+	measuredCpuStats = []string{"System Mode", "User Mode", "Throttled Periods", "Throttled Time", "Percent"}
+
+	// cgroup-v2 only exposes a subset of memory stats
+	cgroupV1MeasuredMemStats = []string{"RSS", "Cache", "Swap", "Usage", "Max Usage", "Kernel Usage", "Kernel Max Usage"}
+	cgroupV2MeasuredMemStats = []string{"Cache", "Swap", "Usage"}
+)
+
+// collectStats sends the task's resource usage on ch every interval until
+// ctx is canceled or the task exits
+func (h *taskHandle) collectStats(ctx context.Context, ch chan<- *drivers.TaskResourceUsage, interval time.Duration) {
+	defer close(ch)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.waitCh:
+			return
+		case <-timer.C:
+			timer.Reset(interval)
+		}
+
+		usage, err := h.stats(ctx)
+		if err != nil {
+			h.logger.Warn("error collecting stats", "error", err)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case ch <- usage:
+		}
+	}
+}
+
+// stats returns the current resource usage of the task
+func (h *taskHandle) stats(ctx context.Context) (*drivers.TaskResourceUsage, error) {
+	metric, err := h.task.Metrics(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task metrics: %v", err)
+	}
+
+	data, err := typeurl.UnmarshalAny(metric.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode task metrics: %v", err)
+	}
+
+	var ms *cstructs.MemoryStats
+	var totalCPU, userCPU, systemCPU float64
+	cs := &cstructs.CpuStats{Measured: measuredCpuStats}
+
+	switch m := data.(type) {
+	case *v1.Metrics:
+		ms = &cstructs.MemoryStats{Measured: cgroupV1MeasuredMemStats}
+		if mem := m.Memory; mem != nil {
+			ms.RSS = mem.RSS
+			ms.Cache = mem.Cache
+			ms.MappedFile = mem.MappedFile
+			if mem.Usage != nil {
+				ms.Usage = mem.Usage.Usage
+				ms.MaxUsage = mem.Usage.Max
+			}
+			if mem.Swap != nil {
+				ms.Swap = mem.Swap.Usage
+			}
+			if mem.Kernel != nil {
+				ms.KernelUsage = mem.Kernel.Usage
+				ms.KernelMaxUsage = mem.Kernel.Max
+			}
+		}
+		if cpu := m.CPU; cpu != nil {
+			if cpu.Usage != nil {
+				totalCPU = float64(cpu.Usage.Total)
+				userCPU = float64(cpu.Usage.User)
+				systemCPU = float64(cpu.Usage.Kernel)
+			}
+			if cpu.Throttling != nil {
+				cs.ThrottledPeriods = cpu.Throttling.ThrottledPeriods
+				cs.ThrottledTime = cpu.Throttling.ThrottledTime
+			}
+		}
+	case *v2.Metrics:
+		ms = &cstructs.MemoryStats{Measured: cgroupV2MeasuredMemStats}
+		if mem := m.Memory; mem != nil {
+			ms.Cache = mem.File
+			ms.Swap = mem.SwapUsage
+			ms.Usage = mem.Usage
+		}
+		if cpu := m.CPU; cpu != nil {
+			// cgroup-v2 reports CPU time in microseconds
+			totalCPU = float64(cpu.UsageUsec * 1000)
+			userCPU = float64(cpu.UserUsec * 1000)
+			systemCPU = float64(cpu.SystemUsec * 1000)
+			cs.ThrottledPeriods = cpu.NrThrottled
+			cs.ThrottledTime = cpu.ThrottledUsec * 1000
+		}
+	default:
+		return nil, fmt.Errorf("unsupported task metrics type %T", data)
+	}
+
+	cs.Percent = h.totalCpuStats.Percent(totalCPU)
+	cs.UserMode = h.userCpuStats.Percent(userCPU)
+	cs.SystemMode = h.systemCpuStats.Percent(systemCPU)
+	cs.TotalTicks = h.systemCpuStats.TicksConsumed(cs.Percent)
+
+	return &drivers.TaskResourceUsage{
+		ResourceUsage: &cstructs.ResourceUsage{
+			MemoryStats: ms,
+			CpuStats:    cs,
+		},
+		Timestamp: time.Now().UTC().UnixNano(),
+	}, nil
+}
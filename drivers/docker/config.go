@@ -290,6 +290,8 @@ var (
 			hclspec.NewLiteral(`"2m"`),
 		),
 		"pids_limit": hclspec.NewAttr("pids_limit", "number", false),
+		// images pulled in the background once the driver is configured
+		"image_prefetch": hclspec.NewAttr("image_prefetch", "list(string)", false),
 		// disable_log_collection indicates whether docker driver should collect logs of docker
 		// task containers.  If true, nomad doesn't start docker_logger/logmon processes
 		"disable_log_collection": hclspec.NewAttr("disable_log_collection", "bool", false),
@@ -628,6 +630,7 @@ type DriverConfig struct {
 	pullActivityTimeoutDuration   time.Duration `codec:"-"`
 	ExtraLabels                   []string      `codec:"extra_labels"`
 	Logging                       LoggingConfig `codec:"logging"`
+	ImagePrefetch                 []string      `codec:"image_prefetch"`
 
 	AllowRuntimesList []string            `codec:"allow_runtimes"`
 	allowRuntimes     map[string]struct{} `codec:"-"`
@@ -756,6 +759,12 @@ func (d *Driver) SetConfig(c *base.Config) error {
 
 	d.reconciler = newReconciler(d)
 
+	if len(d.config.ImagePrefetch) > 0 {
+		if err := d.PrefetchImages(d.config.ImagePrefetch); err != nil {
+			return fmt.Errorf("invalid image_prefetch: %v", err)
+		}
+	}
+
 	return nil
 }
 
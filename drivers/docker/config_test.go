@@ -714,3 +714,9 @@ func TestConfig_DriverConfig_AllowRuntimes(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_DriverConfig_ImagePrefetch(t *testing.T) {
+	var tc DriverConfig
+	hclutils.NewConfigParser(configSpec).ParseHCL(t, `config { image_prefetch = ["redis:7", "postgres:14"] }`, &tc)
+	require.Equal(t, []string{"redis:7", "postgres:14"}, tc.ImagePrefetch)
+}
@@ -2,16 +2,30 @@ package docker
 
 import (
 	"context"
+	"fmt"
 	"runtime"
 	"sort"
 	"strings"
 	"time"
 
+	docker "github.com/fsouza/go-dockerclient"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
 )
 
+const (
+	// imageAttributePrefix prefixes the attributes of the images cached on
+	// the node, whose values are the images' sizes in bytes. They are in the
+	// unique namespace so that pulling or pruning images doesn't change the
+	// computed class of the node.
+	imageAttributePrefix = "unique.driver.docker.image."
+
+	// maxFingerprintedImages bounds the number of image attributes so that
+	// nodes with large caches don't bloat the node object
+	maxFingerprintedImages = 128
+)
+
 func (d *Driver) Fingerprint(ctx context.Context) (<-chan *drivers.Fingerprint, error) {
 	// start reconciler when we start fingerprinting
 	// this is the only method called when driver is launched properly
@@ -184,7 +198,66 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 		}
 	}
 
+	if images, err := client.ListImages(docker.ListImagesOptions{}); err != nil {
+		d.logger.Warn("failed to list images", "error", err)
+	} else {
+		for name, attr := range imageAttributes(images) {
+			fp.Attributes[name] = attr
+		}
+	}
+
 	d.setFingerprintSuccess()
 
 	return fp
 }
+
+// imageAttributes returns the attributes of the tagged images cached on the
+// node, so that jobs can set affinities for nodes that have their images.
+func imageAttributes(images []docker.APIImages) map[string]*pstructs.Attribute {
+	sizes := map[string]int64{}
+	for _, image := range images {
+		for _, ref := range image.RepoTags {
+			if ref == "" || strings.HasPrefix(ref, "<none>") {
+				continue
+			}
+			sizes[ref] = image.Size
+		}
+	}
+
+	refs := make([]string, 0, len(sizes))
+	for ref := range sizes {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	if len(refs) > maxFingerprintedImages {
+		refs = refs[:maxFingerprintedImages]
+	}
+
+	attrs := make(map[string]*pstructs.Attribute, len(refs))
+	for _, ref := range refs {
+		attrs[imageAttributePrefix+imageAttributeName(ref)] = pstructs.NewIntAttribute(sizes[ref], "")
+	}
+	return attrs
+}
+
+// imageAttributeName returns the name of an image reference within its
+// attribute, so that the attribute can be interpolated in HCL2, such as in
+// "${attr.unique.driver.docker.image.redis_3a7}". Letters are kept, as are
+// digits and '-' past the first character. Other characters are escaped as
+// '_' followed by their hexadecimal code, so that distinct references always
+// have distinct names.
+func imageAttributeName(ref string) string {
+	var b strings.Builder
+	for i := 0; i < len(ref); i++ {
+		c := ref[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+			b.WriteByte(c)
+		case i > 0 && (c >= '0' && c <= '9' || c == '-'):
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "_%02x", c)
+		}
+	}
+	return b.String()
+}
@@ -2,11 +2,14 @@ package docker
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	docker "github.com/fsouza/go-dockerclient"
 	"github.com/hashicorp/nomad/client/testutil"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/plugins/drivers"
+	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
 	tu "github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/require"
 )
@@ -29,3 +32,34 @@ func TestDockerDriver_FingerprintHealth(t *testing.T) {
 	fp := d.buildFingerprint()
 	require.Equal(t, drivers.HealthStateHealthy, fp.Health)
 }
+
+func TestDockerDriver_ImageAttributes(t *testing.T) {
+	images := []docker.APIImages{
+		{ID: "sha256:1", RepoTags: []string{"redis:7", "redis:latest"}, Size: 1024},
+		{ID: "sha256:2", RepoTags: []string{"<none>:<none>"}, Size: 2048},
+		{ID: "sha256:3", RepoTags: []string{"registry.example.com/team/app:1.21-alpine"}, Size: 4096},
+		{ID: "sha256:4", RepoTags: []string{"3scale/apicast:latest"}, Size: 512},
+
+		// References differing only by separators don't collide
+		{ID: "sha256:5", RepoTags: []string{"my_app:1"}, Size: 256},
+		{ID: "sha256:6", RepoTags: []string{"my/app:1"}, Size: 128},
+	}
+
+	require.Equal(t, map[string]*pstructs.Attribute{
+		"unique.driver.docker.image.redis_3a7":                                             pstructs.NewIntAttribute(1024, ""),
+		"unique.driver.docker.image.redis_3alatest":                                        pstructs.NewIntAttribute(1024, ""),
+		"unique.driver.docker.image.registry_2eexample_2ecom_2fteam_2fapp_3a1_2e21-alpine": pstructs.NewIntAttribute(4096, ""),
+		"unique.driver.docker.image._33scale_2fapicast_3alatest":                           pstructs.NewIntAttribute(512, ""),
+		"unique.driver.docker.image.my_5fapp_3a1":                                          pstructs.NewIntAttribute(256, ""),
+		"unique.driver.docker.image.my_2fapp_3a1":                                          pstructs.NewIntAttribute(128, ""),
+	}, imageAttributes(images))
+
+	// The number of attributes is bounded
+	images = images[:0]
+	for i := 0; i < maxFingerprintedImages+10; i++ {
+		images = append(images, docker.APIImages{RepoTags: []string{fmt.Sprintf("app:%03d", i)}})
+	}
+	attrs := imageAttributes(images)
+	require.Len(t, attrs, maxFingerprintedImages)
+	require.Contains(t, attrs, "unique.driver.docker.image.app_3a000")
+}
@@ -0,0 +1,72 @@
+package docker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// prefetchCallerID is the caller the coordinator references prefetched
+	// images for, so that image garbage collection doesn't remove them
+	prefetchCallerID = "nomad-image-prefetch"
+
+	// prefetchPullTimeout bounds how long an image may take to prefetch.
+	// Stalled pulls are canceled sooner by pull_activity_timeout.
+	prefetchPullTimeout = 30 * time.Minute
+)
+
+var _ drivers.DriverImagePrefetcher = (*Driver)(nil)
+
+// PrefetchImages pulls the images in the background so that they're cached
+// before tasks use them.
+func (d *Driver) PrefetchImages(images []string) error {
+	if d.coordinator == nil {
+		return fmt.Errorf("docker driver isn't configured")
+	}
+
+	refs := make([]string, 0, len(images))
+	for _, image := range images {
+		image = strings.TrimPrefix(strings.TrimSpace(image), "https://")
+		if image == "" {
+			return fmt.Errorf("image reference can't be empty")
+		}
+		refs = append(refs, image)
+	}
+
+	for _, image := range refs {
+		go d.prefetchImage(image)
+	}
+	return nil
+}
+
+// prefetchImage pulls an image unless it's already present. Images tagged
+// latest are always pulled to refresh them.
+func (d *Driver) prefetchImage(image string) {
+	repo, tag := parseDockerImage(image)
+	if tag != "latest" {
+		if dockerImage, _ := d.coordinator.client.InspectImage(image); dockerImage != nil {
+			d.coordinator.IncrementImageReference(dockerImage.ID, image, prefetchCallerID)
+			d.logger.Debug("prefetched image already present", "image", image)
+			return
+		}
+	}
+
+	authOptions, err := firstValidAuth(repo, []authBackend{
+		authFromDockerConfig(d.config.Auth.Config),
+		authFromHelper(d.config.Auth.Helper),
+	})
+	if err != nil {
+		d.logger.Debug("auth failed for prefetched image pull", "image", image, "error", err)
+	}
+
+	d.logger.Info("prefetching image", "image_ref", dockerImageRef(repo, tag))
+	id, err := d.coordinator.PullImage(image, authOptions, prefetchCallerID, noopLogEventFn, prefetchPullTimeout, d.config.pullActivityTimeoutDuration)
+	if err != nil {
+		d.logger.Warn("failed to prefetch image", "image", image, "error", err)
+		return
+	}
+	d.logger.Info("prefetched image", "image", image, "image_id", id)
+}
@@ -0,0 +1,49 @@
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDockerDriver_PrefetchImages(t *testing.T) {
+	t.Parallel()
+
+	mock := newMockImageClient(map[string]string{"redis:latest": "sha256:1"}, 0)
+	d := &Driver{
+		config: &DriverConfig{},
+		logger: testlog.HCLogger(t),
+		coordinator: newDockerCoordinator(&dockerCoordinatorConfig{
+			ctx:     context.Background(),
+			logger:  testlog.HCLogger(t),
+			cleanup: true,
+			client:  mock,
+		}),
+	}
+
+	err := d.PrefetchImages([]string{"redis:latest", " "})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "can't be empty")
+
+	require.NoError(t, d.PrefetchImages([]string{"https://redis:latest"}))
+	testutil.WaitForResult(func() (bool, error) {
+		mock.lock.Lock()
+		defer mock.lock.Unlock()
+		return mock.pulled["redis"] == 1, nil
+	}, func(err error) {
+		t.Fatalf("image wasn't pulled")
+	})
+
+	// Prefetched images are referenced so image GC doesn't remove them
+	testutil.WaitForResult(func() (bool, error) {
+		d.coordinator.imageLock.Lock()
+		defer d.coordinator.imageLock.Unlock()
+		_, ok := d.coordinator.imageRefCount["sha256:1"][prefetchCallerID]
+		return ok, nil
+	}, func(err error) {
+		t.Fatalf("prefetched image isn't referenced")
+	})
+}
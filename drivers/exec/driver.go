@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	osexec "os/exec"
 	"path/filepath"
 	"runtime"
 	"sync"
@@ -16,7 +17,9 @@ import (
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/drivers/shared/eventer"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/drivers/shared/ociimage"
 	"github.com/hashicorp/nomad/drivers/shared/resolvconf"
+	"github.com/hashicorp/nomad/drivers/shared/subids"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/plugins/base"
@@ -24,6 +27,7 @@ import (
 	"github.com/hashicorp/nomad/plugins/drivers/utils"
 	"github.com/hashicorp/nomad/plugins/shared/hclspec"
 	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
+	"github.com/opencontainers/runc/libcontainer/apparmor"
 )
 
 const (
@@ -79,17 +83,37 @@ var (
 			hclspec.NewAttr("allow_caps", "list(string)", false),
 			hclspec.NewLiteral(capabilities.HCLSpecLiteral),
 		),
+		"image_cache_dir": hclspec.NewDefault(
+			hclspec.NewAttr("image_cache_dir", "string", false),
+			hclspec.NewLiteral(`"/var/cache/nomad/exec/images"`),
+		),
+		"image_paths":       hclspec.NewAttr("image_paths", "list(string)", false),
+		"seccomp_profile":   hclspec.NewAttr("seccomp_profile", "string", false),
+		"seccomp_violation": hclspec.NewAttr("seccomp_violation", "string", false),
+		"apparmor_profile":  hclspec.NewAttr("apparmor_profile", "string", false),
+		"allow_runtimes":    hclspec.NewAttr("allow_runtimes", "list(string)", false),
+		"user_namespace": hclspec.NewBlock("user_namespace", false, hclspec.NewObject(map[string]*hclspec.Spec{
+			"enabled":    hclspec.NewAttr("enabled", "bool", false),
+			"subid_user": hclspec.NewAttr("subid_user", "string", false),
+			"size":       hclspec.NewAttr("size", "number", false),
+		})),
 	})
 
 	// taskConfigSpec is the hcl specification for the driver config section of
 	// a task within a job. It is returned in the TaskConfigSchema RPC
 	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
-		"command":  hclspec.NewAttr("command", "string", true),
-		"args":     hclspec.NewAttr("args", "list(string)", false),
-		"pid_mode": hclspec.NewAttr("pid_mode", "string", false),
-		"ipc_mode": hclspec.NewAttr("ipc_mode", "string", false),
-		"cap_add":  hclspec.NewAttr("cap_add", "list(string)", false),
-		"cap_drop": hclspec.NewAttr("cap_drop", "list(string)", false),
+		"command":           hclspec.NewAttr("command", "string", false),
+		"image":             hclspec.NewAttr("image", "string", false),
+		"args":              hclspec.NewAttr("args", "list(string)", false),
+		"pid_mode":          hclspec.NewAttr("pid_mode", "string", false),
+		"ipc_mode":          hclspec.NewAttr("ipc_mode", "string", false),
+		"cap_add":           hclspec.NewAttr("cap_add", "list(string)", false),
+		"cap_drop":          hclspec.NewAttr("cap_drop", "list(string)", false),
+		"seccomp_profile":   hclspec.NewAttr("seccomp_profile", "string", false),
+		"seccomp_violation": hclspec.NewAttr("seccomp_violation", "string", false),
+		"apparmor_profile":  hclspec.NewAttr("apparmor_profile", "string", false),
+		"checkpoint":        hclspec.NewAttr("checkpoint", "bool", false),
+		"runtime":           hclspec.NewAttr("runtime", "string", false),
 	})
 
 	// driverCapabilities represents the RPC response for what features are
@@ -102,7 +126,10 @@ var (
 			drivers.NetIsolationModeHost,
 			drivers.NetIsolationModeGroup,
 		},
-		MountConfigs: drivers.MountConfigSupportAll,
+		MountConfigs:    drivers.MountConfigSupportAll,
+		Checkpoint:      true,
+		UpdateResources: true,
+		GroupResources:  true,
 	}
 )
 
@@ -129,6 +156,13 @@ type Driver struct {
 	// logger will log to the Nomad agent
 	logger hclog.Logger
 
+	// images caches the OCI images tasks are run from
+	images *ociimage.Store
+
+	// subids hands out the subordinate IDs tasks' user namespaces are mapped
+	// to. It is nil unless user namespaces are enabled.
+	subids *subids.Allocator
+
 	// A tri-state boolean to know if the fingerprinting has happened and
 	// whether it has been successful
 	fingerprintSuccess *bool
@@ -152,6 +186,47 @@ type Config struct {
 	// AllowCaps configures which Linux Capabilities are enabled for tasks
 	// running on this node.
 	AllowCaps []string `codec:"allow_caps"`
+
+	// ImageCacheDir is the directory OCI images are unpacked into
+	ImageCacheDir string `codec:"image_cache_dir"`
+
+	// ImagePaths are the host directories tasks may use OCI images from.
+	// Images within the task directory are always allowed.
+	ImagePaths []string `codec:"image_paths"`
+
+	// UserNamespace configures running tasks in user namespaces
+	UserNamespace UserNamespaceConfig `codec:"user_namespace"`
+
+	// SeccompProfile is the default seccomp profile of tasks: the host path
+	// of a profile in Docker's JSON format, "default" or "unconfined".
+	SeccompProfile string `codec:"seccomp_profile"`
+
+	// SeccompViolation is the default action on system calls not allowed by
+	// the seccomp profile of tasks: "errno" or "trap".
+	SeccompViolation string `codec:"seccomp_violation"`
+
+	// AppArmorProfile is the name of the default AppArmor profile of tasks.
+	AppArmorProfile string `codec:"apparmor_profile"`
+
+	// AllowRuntimes are the OCI runtime binaries, such as runsc or crun,
+	// tasks may run with instead of libcontainer. Each is either a name
+	// looked up in the PATH or an absolute path.
+	AllowRuntimes []string `codec:"allow_runtimes"`
+}
+
+// UserNamespaceConfig configures running tasks in user namespaces mapping
+// their root to unprivileged subordinate IDs.
+type UserNamespaceConfig struct {
+	// Enabled runs all tasks in user namespaces
+	Enabled bool `codec:"enabled"`
+
+	// SubIDUser is the user whose ranges of /etc/subuid and /etc/subgid are
+	// handed out to allocations. Defaults to root.
+	SubIDUser string `codec:"subid_user"`
+
+	// Size is the number of IDs mapped into each allocation's namespace.
+	// Defaults to 65536.
+	Size int `codec:"size"`
 }
 
 func (c *Config) validate() error {
@@ -172,14 +247,55 @@ func (c *Config) validate() error {
 		return fmt.Errorf("allow_caps configured with capabilities not supported by system: %s", badCaps)
 	}
 
+	for _, p := range c.ImagePaths {
+		if !filepath.IsAbs(p) {
+			return fmt.Errorf("image_paths must be absolute, got %q", p)
+		}
+	}
+
+	if c.UserNamespace.Enabled {
+		if c.DefaultModePID != executor.IsolationModePrivate {
+			return fmt.Errorf("user_namespace requires default_pid_mode to be %q", executor.IsolationModePrivate)
+		}
+		if c.UserNamespace.Size != 0 && c.UserNamespace.Size < minUserNamespaceSize {
+			return fmt.Errorf("user_namespace size must be at least %d, got %d", minUserNamespaceSize, c.UserNamespace.Size)
+		}
+	}
+
+	switch c.SeccompProfile {
+	case "", executor.SeccompProfileDefault, executor.SecurityProfileUnconfined:
+	default:
+		if !filepath.IsAbs(c.SeccompProfile) {
+			return fmt.Errorf("seccomp_profile must be an absolute path, %q or %q, got %q",
+				executor.SeccompProfileDefault, executor.SecurityProfileUnconfined, c.SeccompProfile)
+		}
+	}
+	if c.SeccompProfile != "" && c.SeccompProfile != executor.SecurityProfileUnconfined && !executor.SeccompSupported {
+		return fmt.Errorf("seccomp_profile is set but seccomp profiles are not supported: Nomad was built without seccomp support")
+	}
+	if err := executor.ValidateSeccompViolation(c.SeccompViolation); err != nil {
+		return err
+	}
+
+	for _, r := range c.AllowRuntimes {
+		if r != filepath.Base(r) && !filepath.IsAbs(r) {
+			return fmt.Errorf("allow_runtimes must be names or absolute paths, got %q", r)
+		}
+	}
+
 	return nil
 }
 
 // TaskConfig is the driver configuration of a task within a job
 type TaskConfig struct {
-	// Command is the thing to exec.
+	// Command is the thing to exec. It defaults to the entrypoint of the
+	// image if one is set.
 	Command string `codec:"command"`
 
+	// Image is the path of an OCI image layout, or a tarball of one, to use
+	// as the root filesystem of the task.
+	Image string `codec:"image"`
+
 	// Args are passed along to Command.
 	Args []string `codec:"args"`
 
@@ -196,6 +312,28 @@ type TaskConfig struct {
 
 	// CapDrop is a set of linux capabilities to disable.
 	CapDrop []string `codec:"cap_drop"`
+
+	// SeccompProfile is the path within the task directory of a seccomp
+	// profile in Docker's JSON format, "default" or "unconfined". Defaults
+	// to the driver's profile.
+	SeccompProfile string `codec:"seccomp_profile"`
+
+	// SeccompViolation is the action on system calls not allowed by the
+	// seccomp profile: "errno" or "trap". Defaults to the driver's.
+	SeccompViolation string `codec:"seccomp_violation"`
+
+	// AppArmorProfile is the name of the AppArmor profile the task runs
+	// with. Defaults to the driver's profile.
+	AppArmorProfile string `codec:"apparmor_profile"`
+
+	// Checkpoint launches the task so that it can be checkpointed with CRIU
+	// when its allocation is migrated, and restored in the replacement
+	// allocation.
+	Checkpoint bool `codec:"checkpoint"`
+
+	// Runtime is the name of an allowed OCI runtime to run the task with
+	// instead of libcontainer.
+	Runtime string `codec:"runtime"`
 }
 
 func (tc *TaskConfig) validate() error {
@@ -221,6 +359,14 @@ func (tc *TaskConfig) validate() error {
 		return fmt.Errorf("cap_drop configured with capabilities not supported by system: %s", badDrops)
 	}
 
+	if tc.Runtime != "" && tc.Checkpoint {
+		return fmt.Errorf("checkpoint is not supported with runtime %q", tc.Runtime)
+	}
+
+	if err := executor.ValidateSeccompViolation(tc.SeccompViolation); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -232,6 +378,9 @@ type TaskState struct {
 	TaskConfig     *drivers.TaskConfig
 	Pid            int
 	StartedAt      time.Time
+
+	// UserNamespace is the mapping of the task's user namespace, if any
+	UserNamespace *subids.Mapping
 }
 
 // NewExecDriver returns a new DrivePlugin implementation
@@ -287,6 +436,16 @@ func (d *Driver) SetConfig(cfg *base.Config) error {
 		return err
 	}
 	d.config = config
+	d.images = ociimage.NewStore(config.ImageCacheDir, d.logger)
+
+	d.subids = nil
+	if config.UserNamespace.Enabled {
+		allocator, err := newSubIDAllocator(config.UserNamespace)
+		if err != nil {
+			return fmt.Errorf("failed to configure user namespaces: %v", err)
+		}
+		d.subids = allocator
+	}
 
 	if cfg != nil && cfg.AgentConfig != nil {
 		d.nomadConfig = cfg.AgentConfig.Driver
@@ -367,6 +526,16 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 	}
 
 	fp.Attributes["driver.exec"] = pstructs.NewBoolAttribute(true)
+	fp.Attributes["driver.exec.seccomp"] = pstructs.NewBoolAttribute(executor.SeccompSupported)
+	fp.Attributes["driver.exec.apparmor"] = pstructs.NewBoolAttribute(apparmor.IsEnabled())
+	for _, r := range d.config.AllowRuntimes {
+		if _, err := osexec.LookPath(r); err == nil {
+			fp.Attributes["driver.exec.runtime."+filepath.Base(r)] = pstructs.NewBoolAttribute(true)
+		}
+	}
+	if d.subids != nil {
+		fp.Attributes["driver.exec.user_namespace.enabled"] = pstructs.NewBoolAttribute(true)
+	}
 	d.setFingerprintSuccess()
 	return fp
 }
@@ -422,6 +591,15 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 		logger:       d.logger,
 	}
 
+	if m := taskState.UserNamespace; m != nil {
+		h.userNamespace = m
+		if d.subids == nil {
+			d.logger.Warn("recovered task runs in a user namespace but user namespaces are disabled", "task_id", handle.Config.ID)
+		} else if err := d.subids.Reserve(taskState.TaskConfig.AllocID, taskState.TaskConfig.AllocDir, m); err != nil {
+			d.logger.Error("failed to reserve user namespace IDs of recovered task", "error", err, "task_id", handle.Config.ID)
+		}
+	}
+
 	d.tasks.Set(taskState.TaskConfig.ID, h)
 
 	go h.run()
@@ -429,6 +607,18 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 }
 
 func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	return d.startTask(cfg, "")
+}
+
+// RestoreTask starts a task from the checkpoint saved to dir by
+// CheckpointTask.
+func (d *Driver) RestoreTask(cfg *drivers.TaskConfig, dir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	return d.startTask(cfg, dir)
+}
+
+// startTask launches the task's command or, if checkpointDir is set,
+// restores it from the checkpoint.
+func (d *Driver) startTask(cfg *drivers.TaskConfig, checkpointDir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
 	if _, ok := d.tasks.Get(cfg.ID); ok {
 		return nil, nil, fmt.Errorf("task with ID %q already started", cfg.ID)
 	}
@@ -442,15 +632,37 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
 	}
 
-	d.logger.Info("starting task", "driver_cfg", hclog.Fmt("%+v", driverConfig))
+	d.logger.Info("starting task", "driver_cfg", hclog.Fmt("%+v", driverConfig), "checkpoint_dir", checkpointDir)
 	handle := drivers.NewTaskHandle(taskHandleVersion)
 	handle.Config = cfg
 
+	command, args, env, user := driverConfig.Command, driverConfig.Args, cfg.EnvList(), cfg.User
+	if driverConfig.Image != "" {
+		img, err := d.prepareImage(cfg, driverConfig.Image)
+		if err != nil {
+			return nil, nil, err
+		}
+		command, args = imageCommand(img, command, args)
+		env = mergeEnv(img.Config.Env, env)
+		if user == "" {
+			user = img.Config.User
+		}
+	}
+	if command == "" {
+		return nil, nil, fmt.Errorf("command must be set unless the task runs an image with an entrypoint")
+	}
+
+	runtimePath, err := d.runtimePath(driverConfig.Runtime)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	pluginLogFile := filepath.Join(cfg.TaskDir().Dir, "executor.out")
 	executorConfig := &executor.ExecutorConfig{
 		LogFile:     pluginLogFile,
 		LogLevel:    "debug",
 		FSIsolation: true,
+		OCIRuntime:  runtimePath,
 	}
 
 	exec, pluginClient, err := executor.CreateExecutor(
@@ -460,7 +672,6 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		return nil, nil, fmt.Errorf("failed to create executor: %v", err)
 	}
 
-	user := cfg.User
 	if user == "" {
 		user = "nobody"
 	}
@@ -481,10 +692,35 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	}
 	d.logger.Debug("task capabilities", "capabilities", caps)
 
+	seccompProfile, err := executor.SeccompProfile(cfg.TaskDir().Dir, d.config.SeccompProfile, driverConfig.SeccompProfile)
+	if err != nil {
+		pluginClient.Kill()
+		return nil, nil, err
+	}
+	seccompProfile, err = executor.ApplySeccompViolation(seccompProfile, d.config.SeccompViolation, driverConfig.SeccompViolation)
+	if err != nil {
+		pluginClient.Kill()
+		return nil, nil, err
+	}
+	apparmorProfile, err := executor.AppArmorProfile(d.config.AppArmorProfile, driverConfig.AppArmorProfile)
+	if err != nil {
+		pluginClient.Kill()
+		return nil, nil, err
+	}
+
+	var userns *subids.Mapping
+	if d.subids != nil {
+		userns, err = d.acquireUserNamespace(cfg)
+		if err != nil {
+			pluginClient.Kill()
+			return nil, nil, err
+		}
+	}
+
 	execCmd := &executor.ExecCommand{
-		Cmd:              driverConfig.Command,
-		Args:             driverConfig.Args,
-		Env:              cfg.EnvList(),
+		Cmd:              command,
+		Args:             args,
+		Env:              env,
 		User:             user,
 		ResourceLimits:   true,
 		NoPivotRoot:      d.config.NoPivotRoot,
@@ -498,22 +734,39 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		ModePID:          executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID),
 		ModeIPC:          executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC),
 		Capabilities:     caps,
+		SeccompProfile:   seccompProfile,
+		AppArmorProfile:  apparmorProfile,
+		Checkpoint:       driverConfig.Checkpoint,
+	}
+	if userns != nil {
+		execCmd.UserNamespace = &executor.UserNamespace{
+			HostUID: userns.HostUID,
+			HostGID: userns.HostGID,
+			Size:    userns.Size,
+		}
 	}
 
-	ps, err := exec.Launch(execCmd)
+	var ps *executor.ProcessState
+	if checkpointDir == "" {
+		ps, err = exec.Launch(execCmd)
+	} else {
+		ps, err = exec.Restore(execCmd, checkpointDir)
+	}
 	if err != nil {
+		d.releaseUserNamespace(cfg.AllocID, userns)
 		pluginClient.Kill()
 		return nil, nil, fmt.Errorf("failed to launch command with executor: %v", err)
 	}
 
 	h := &taskHandle{
-		exec:         exec,
-		pid:          ps.Pid,
-		pluginClient: pluginClient,
-		taskConfig:   cfg,
-		procState:    drivers.TaskStateRunning,
-		startedAt:    time.Now().Round(time.Millisecond),
-		logger:       d.logger,
+		exec:          exec,
+		pid:           ps.Pid,
+		pluginClient:  pluginClient,
+		taskConfig:    cfg,
+		procState:     drivers.TaskStateRunning,
+		startedAt:     time.Now().Round(time.Millisecond),
+		logger:        d.logger,
+		userNamespace: userns,
 	}
 
 	driverState := TaskState{
@@ -521,11 +774,13 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		Pid:            ps.Pid,
 		TaskConfig:     cfg,
 		StartedAt:      h.startedAt,
+		UserNamespace:  userns,
 	}
 
 	if err := handle.SetDriverState(&driverState); err != nil {
 		d.logger.Error("failed to start task, error setting driver state", "error", err)
 		_ = exec.Shutdown("", 0)
+		d.releaseUserNamespace(cfg.AllocID, userns)
 		pluginClient.Kill()
 		return nil, nil, fmt.Errorf("failed to set driver state: %v", err)
 	}
@@ -535,6 +790,26 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	return handle, nil, nil
 }
 
+// runtimePath returns the path of the binary of an allowed OCI runtime, or
+// an empty path if no runtime is set.
+func (d *Driver) runtimePath(runtime string) (string, error) {
+	if runtime == "" {
+		return "", nil
+	}
+
+	for _, r := range d.config.AllowRuntimes {
+		if r != runtime && filepath.Base(r) != runtime {
+			continue
+		}
+		path, err := osexec.LookPath(r)
+		if err != nil {
+			return "", fmt.Errorf("runtime %q not found: %v", runtime, err)
+		}
+		return path, nil
+	}
+	return "", fmt.Errorf("runtime %q is not allowed, allowed runtimes: %v", runtime, d.config.AllowRuntimes)
+}
+
 func (d *Driver) WaitTask(ctx context.Context, taskID string) (<-chan *drivers.ExitResult, error) {
 	handle, ok := d.tasks.Get(taskID)
 	if !ok {
@@ -560,6 +835,9 @@ func (d *Driver) handleWait(ctx context.Context, handle *taskHandle, ch chan *dr
 			ExitCode: ps.ExitCode,
 			Signal:   ps.Signal,
 		}
+		if ev := executor.SeccompViolationEvent(handle.taskConfig, result); ev != nil {
+			d.eventer.EmitEvent(ev)
+		}
 	}
 
 	select {
@@ -605,6 +883,7 @@ func (d *Driver) DestroyTask(taskID string, force bool) error {
 		handle.pluginClient.Kill()
 	}
 
+	d.releaseUserNamespace(handle.taskConfig.AllocID, handle.userNamespace)
 	d.tasks.Delete(taskID)
 	return nil
 }
@@ -692,3 +971,41 @@ func (d *Driver) ExecTaskStreamingRaw(ctx context.Context,
 
 	return handle.exec.ExecStreaming(ctx, command, tty, stream)
 }
+
+var _ drivers.DriverCheckpointer = (*Driver)(nil)
+
+// CheckpointTask saves the state of the task to dir with CRIU, which stops
+// it. The task must have been started with checkpoint enabled.
+func (d *Driver) CheckpointTask(taskID string, dir string) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	var driverConfig TaskConfig
+	if err := handle.taskConfig.DecodeDriverConfig(&driverConfig); err != nil {
+		return fmt.Errorf("failed to decode driver config: %v", err)
+	}
+	if !driverConfig.Checkpoint {
+		return drivers.ErrCheckpointNotEnabled
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create checkpoint dir: %v", err)
+	}
+
+	return handle.exec.Checkpoint(dir)
+}
+
+var _ drivers.DriverResourceUpdater = (*Driver)(nil)
+
+// UpdateTaskResources applies the CPU shares and memory limits of the
+// resources to the cgroup of the running task.
+func (d *Driver) UpdateTaskResources(taskID string, resources *drivers.Resources) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	return handle.exec.UpdateResources(resources)
+}
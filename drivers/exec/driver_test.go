@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	osexec "os/exec"
 	"path/filepath"
 	"runtime"
 	"strconv"
@@ -134,6 +135,43 @@ func TestExecDriver_StartWait(t *testing.T) {
 	require.NoError(harness.DestroyTask(task.ID, true))
 }
 
+func TestExecDriver_CheckpointNotEnabled(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"100"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	// Tasks not launched to be checkpointed are skipped without creating the
+	// checkpoint dir
+	dir := filepath.Join(t.TempDir(), "checkpoint")
+	cp := harness.DriverPlugin.(drivers.DriverCheckpointer)
+	require.Equal(drivers.ErrCheckpointNotEnabled, cp.CheckpointTask(task.ID, dir))
+	require.NoDirExists(dir)
+}
+
 func TestExecDriver_StartWaitStopKill(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
@@ -709,11 +747,23 @@ func TestConfig_ParseAllHCL(t *testing.T) {
 config {
   command = "/bin/bash"
   args = ["-c", "echo hello"]
+  image = "local/image.tar"
+  seccomp_profile = "local/seccomp.json"
+  seccomp_violation = "trap"
+  apparmor_profile = "nomad-exec"
+  checkpoint = true
+  runtime = "runsc"
 }`
 
 	expected := &TaskConfig{
-		Command: "/bin/bash",
-		Args:    []string{"-c", "echo hello"},
+		Command:          "/bin/bash",
+		Args:             []string{"-c", "echo hello"},
+		Image:            "local/image.tar",
+		SeccompProfile:   "local/seccomp.json",
+		SeccompViolation: "trap",
+		AppArmorProfile:  "nomad-exec",
+		Checkpoint:       true,
+		Runtime:          "runsc",
 	}
 
 	var tc *TaskConfig
@@ -801,6 +851,93 @@ func TestDriver_Config_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+
+	t.Run("image_paths", func(t *testing.T) {
+		require.NoError(t, (&Config{
+			DefaultModePID: "private",
+			DefaultModeIPC: "private",
+			ImagePaths:     []string{"/opt/images"},
+		}).validate())
+		require.EqualError(t, (&Config{
+			DefaultModePID: "private",
+			DefaultModeIPC: "private",
+			ImagePaths:     []string{"images"},
+		}).validate(), `image_paths must be absolute, got "images"`)
+	})
+
+	t.Run("user_namespace", func(t *testing.T) {
+		require.NoError(t, (&Config{
+			DefaultModePID: "private",
+			DefaultModeIPC: "host",
+			UserNamespace:  UserNamespaceConfig{Enabled: true},
+		}).validate())
+		require.NoError(t, (&Config{
+			DefaultModePID: "private",
+			DefaultModeIPC: "private",
+			UserNamespace:  UserNamespaceConfig{Enabled: true, Size: 131072},
+		}).validate())
+		require.EqualError(t, (&Config{
+			DefaultModePID: "host",
+			DefaultModeIPC: "private",
+			UserNamespace:  UserNamespaceConfig{Enabled: true},
+		}).validate(), `user_namespace requires default_pid_mode to be "private"`)
+		require.EqualError(t, (&Config{
+			DefaultModePID: "private",
+			DefaultModeIPC: "private",
+			UserNamespace:  UserNamespaceConfig{Enabled: true, Size: 1000},
+		}).validate(), `user_namespace size must be at least 65536, got 1000`)
+	})
+
+	t.Run("seccomp_profile", func(t *testing.T) {
+		// Profiles are rejected by builds without seccomp support
+		var unsupported error
+		if !executor.SeccompSupported {
+			unsupported = errors.New("seccomp_profile is set but seccomp profiles are not supported: Nomad was built without seccomp support")
+		}
+
+		for _, tc := range []struct {
+			profile string
+			exp     error
+		}{
+			{profile: "", exp: nil},
+			{profile: "default", exp: unsupported},
+			{profile: "unconfined", exp: nil},
+			{profile: "/etc/nomad/seccomp.json", exp: unsupported},
+			{profile: "seccomp.json", exp: errors.New(`seccomp_profile must be an absolute path, "default" or "unconfined", got "seccomp.json"`)},
+		} {
+			require.Equal(t, tc.exp, (&Config{
+				DefaultModePID: "private",
+				DefaultModeIPC: "private",
+				SeccompProfile: tc.profile,
+			}).validate())
+		}
+	})
+
+	t.Run("seccomp_violation", func(t *testing.T) {
+		require.NoError(t, (&Config{
+			DefaultModePID:   "private",
+			DefaultModeIPC:   "private",
+			SeccompViolation: "trap",
+		}).validate())
+		require.EqualError(t, (&Config{
+			DefaultModePID:   "private",
+			DefaultModeIPC:   "private",
+			SeccompViolation: "kill",
+		}).validate(), `seccomp_violation must be "errno" or "trap", got "kill"`)
+	})
+
+	t.Run("allow_runtimes", func(t *testing.T) {
+		require.NoError(t, (&Config{
+			DefaultModePID: "private",
+			DefaultModeIPC: "private",
+			AllowRuntimes:  []string{"runsc", "/usr/local/bin/crun"},
+		}).validate())
+		require.EqualError(t, (&Config{
+			DefaultModePID: "private",
+			DefaultModeIPC: "private",
+			AllowRuntimes:  []string{"bin/crun"},
+		}).validate(), `allow_runtimes must be names or absolute paths, got "bin/crun"`)
+	})
 }
 
 func TestDriver_TaskConfig_validate(t *testing.T) {
@@ -859,4 +996,34 @@ func TestDriver_TaskConfig_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+
+	t.Run("runtime", func(t *testing.T) {
+		require.NoError(t, (&TaskConfig{Runtime: "runsc"}).validate())
+		require.EqualError(t, (&TaskConfig{
+			Runtime:    "runsc",
+			Checkpoint: true,
+		}).validate(), `checkpoint is not supported with runtime "runsc"`)
+	})
+}
+
+func TestDriver_runtimePath(t *testing.T) {
+	sh, err := osexec.LookPath("sh")
+	require.NoError(t, err)
+
+	d := &Driver{config: Config{AllowRuntimes: []string{"sh", "/nonexistent/runsc"}}}
+
+	path, err := d.runtimePath("")
+	require.NoError(t, err)
+	require.Empty(t, path)
+
+	path, err = d.runtimePath("sh")
+	require.NoError(t, err)
+	require.Equal(t, sh, path)
+
+	_, err = d.runtimePath("runsc")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `runtime "runsc" not found`)
+
+	_, err = d.runtimePath("crun")
+	require.EqualError(t, err, `runtime "crun" is not allowed, allowed runtimes: [sh /nonexistent/runsc]`)
 }
@@ -6,12 +6,17 @@ package exec
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/drivers/shared/subids"
 	basePlug "github.com/hashicorp/nomad/plugins/base"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sys/unix"
@@ -303,3 +308,96 @@ func TestExecDriver_Capabilities(t *testing.T) {
 		})
 	}
 }
+
+func TestExecDriver_UserNamespace(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	defer harness.Kill()
+
+	config := &Config{
+		DefaultModePID: executor.IsolationModePrivate,
+		DefaultModeIPC: executor.IsolationModePrivate,
+	}
+	var data []byte
+	require.NoError(basePlug.MsgPackEncode(&data, config))
+	require.NoError(harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	// Hand out IDs without depending on the host's /etc/subuid
+	allocator, err := subids.NewAllocator(
+		[]subids.Range{{Start: 300000, Count: 65536}},
+		[]subids.Range{{Start: 400000, Count: 65536}},
+		65536)
+	require.NoError(err)
+	d.(*Driver).subids = allocator
+
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		AllocID:   uuid.Generate(),
+		Name:      "userns",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	// The task's root must be able to reach its root filesystem, like it can
+	// within the client's alloc directory
+	allocsDir := filepath.Dir(filepath.Dir(task.TaskDir().Dir))
+	require.NoError(os.Chmod(allocsDir, 0711))
+
+	tc := &TaskConfig{
+		Command: "/bin/bash",
+		Args:    []string{"-c", "id -u > /alloc/data/uid && cat /proc/self/uid_map > /local/uid_map"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err = harness.StartTask(task)
+	require.NoError(err)
+
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(err)
+	select {
+	case res := <-waitCh:
+		require.True(res.Successful(), "task should have exited successfully: %v", res)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail("timeout waiting for task")
+	}
+
+	// The task runs as nobody within the namespace
+	uidFile := filepath.Join(task.TaskDir().SharedAllocDir, "data", "uid")
+	uid, err := ioutil.ReadFile(uidFile)
+	require.NoError(err)
+	require.Equal("65534", strings.TrimSpace(string(uid)))
+
+	uidMap, err := ioutil.ReadFile(filepath.Join(task.TaskDir().LocalDir, "uid_map"))
+	require.NoError(err)
+	require.Equal([]string{"0", "300000", "65536"}, strings.Fields(string(uidMap)))
+
+	// Files it writes are owned by the mapped IDs on the host
+	fi, err := os.Stat(uidFile)
+	require.NoError(err)
+	st := fi.Sys().(*syscall.Stat_t)
+	require.Equal(uint32(300000+65534), st.Uid)
+
+	// The mapping is recorded in the allocation directory
+	recorded, err := readUserNamespace(task.AllocDir)
+	require.NoError(err)
+	require.Equal(&subids.Mapping{HostUID: 300000, HostGID: 400000, Size: 65536}, recorded)
+
+	// The allocation keeps its IDs once the task is destroyed, until its
+	// directory is removed
+	require.NoError(harness.DestroyTask(task.ID, true))
+	_, err = allocator.Acquire(uuid.Generate(), "", nil)
+	require.Error(err)
+
+	cleanup()
+	m, err := allocator.Acquire(uuid.Generate(), "", nil)
+	require.NoError(err)
+	require.Equal(uint32(300000), m.HostUID)
+}
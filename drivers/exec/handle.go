@@ -9,6 +9,7 @@ import (
 	hclog "github.com/hashicorp/go-hclog"
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/drivers/shared/subids"
 	"github.com/hashicorp/nomad/plugins/drivers"
 )
 
@@ -18,6 +19,9 @@ type taskHandle struct {
 	pluginClient *plugin.Client
 	logger       hclog.Logger
 
+	// userNamespace is the mapping of the task's user namespace, if any
+	userNamespace *subids.Mapping
+
 	// stateLock syncs access to all fields below
 	stateLock sync.RWMutex
 
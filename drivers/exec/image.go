@@ -0,0 +1,102 @@
+package exec
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/drivers/shared/ociimage"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// imagePreservedDirs are the entries of the task directory that are kept
+// when it is populated from an image
+var imagePreservedDirs = []string{
+	allocdir.SharedAllocName,
+	allocdir.TaskLocal,
+	allocdir.TaskSecrets,
+	allocdir.TmpDirName,
+}
+
+// prepareImage unpacks the task's image and populates the task directory,
+// which is the root of the task's chroot, with its root filesystem.
+func (d *Driver) prepareImage(cfg *drivers.TaskConfig, image string) (*ociimage.Image, error) {
+	if d.images == nil {
+		return nil, fmt.Errorf("exec driver is not configured")
+	}
+
+	taskDir := cfg.TaskDir().Dir
+	path, err := d.imagePath(taskDir, image)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := d.images.Unpack(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack image: %v", err)
+	}
+
+	if err := img.PopulateRootFS(taskDir, imagePreservedDirs); err != nil {
+		return nil, fmt.Errorf("failed to populate task directory from image: %v", err)
+	}
+
+	d.logger.Debug("populated task directory from image", "image", image, "digest", img.Digest, "task_name", cfg.Name)
+	return img, nil
+}
+
+// imagePath returns the host path of the image. Relative paths, such as
+// those of images downloaded by an artifact, are within the task directory.
+// Absolute paths must be within one of the configured image paths.
+func (d *Driver) imagePath(taskDir, image string) (string, error) {
+	if !filepath.IsAbs(image) {
+		return securejoin.SecureJoin(taskDir, image)
+	}
+
+	image = filepath.Clean(image)
+	for _, allowed := range d.config.ImagePaths {
+		rel, err := filepath.Rel(allowed, image)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return image, nil
+		}
+	}
+	return "", fmt.Errorf("image %q is not within the driver's image_paths", image)
+}
+
+// imageCommand returns the command and arguments to run. A command set by
+// the task replaces the image's entrypoint, and arguments set by the task
+// replace the image's command.
+func imageCommand(img *ociimage.Image, command string, args []string) (string, []string) {
+	if command != "" {
+		return command, args
+	}
+
+	argv := append([]string{}, img.Config.Entrypoint...)
+	if len(args) > 0 {
+		argv = append(argv, args...)
+	} else {
+		argv = append(argv, img.Config.Cmd...)
+	}
+
+	if len(argv) == 0 {
+		return "", nil
+	}
+	return argv[0], argv[1:]
+}
+
+// mergeEnv returns the image's environment overridden by the task's
+func mergeEnv(imageEnv, taskEnv []string) []string {
+	set := make(map[string]struct{}, len(taskEnv))
+	for _, kv := range taskEnv {
+		set[strings.SplitN(kv, "=", 2)[0]] = struct{}{}
+	}
+
+	env := make([]string, 0, len(imageEnv)+len(taskEnv))
+	for _, kv := range imageEnv {
+		if _, ok := set[strings.SplitN(kv, "=", 2)[0]]; !ok {
+			env = append(env, kv)
+		}
+	}
+	return append(env, taskEnv...)
+}
@@ -0,0 +1,68 @@
+package exec
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/drivers/shared/ociimage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriver_imagePath(t *testing.T) {
+	d := &Driver{config: Config{ImagePaths: []string{"/opt/images"}}}
+
+	path, err := d.imagePath("/task", "local/image.tar")
+	require.NoError(t, err)
+	require.Equal(t, "/task/local/image.tar", path)
+
+	// Relative paths can't escape the task directory
+	path, err = d.imagePath("/task", "../../etc")
+	require.NoError(t, err)
+	require.Equal(t, "/task/etc", path)
+
+	path, err = d.imagePath("/task", "/opt/images/app")
+	require.NoError(t, err)
+	require.Equal(t, "/opt/images/app", path)
+
+	_, err = d.imagePath("/task", "/opt/images/../secrets")
+	require.Error(t, err)
+
+	_, err = d.imagePath("/task", "/opt/images-other/app")
+	require.Error(t, err)
+}
+
+func TestDriver_imageCommand(t *testing.T) {
+	img := &ociimage.Image{Config: ociimage.Config{
+		Entrypoint: []string{"/bin/app", "--config", "/etc/app"},
+		Cmd:        []string{"serve"},
+	}}
+
+	cmd, args := imageCommand(img, "", nil)
+	require.Equal(t, "/bin/app", cmd)
+	require.Equal(t, []string{"--config", "/etc/app", "serve"}, args)
+
+	// Task arguments replace the image's command
+	cmd, args = imageCommand(img, "", []string{"migrate"})
+	require.Equal(t, "/bin/app", cmd)
+	require.Equal(t, []string{"--config", "/etc/app", "migrate"}, args)
+
+	// A task command replaces the entrypoint
+	cmd, args = imageCommand(img, "/bin/sh", []string{"-c", "true"})
+	require.Equal(t, "/bin/sh", cmd)
+	require.Equal(t, []string{"-c", "true"}, args)
+
+	// Images with only a command
+	cmd, args = imageCommand(&ociimage.Image{Config: ociimage.Config{Cmd: []string{"/bin/sh"}}}, "", nil)
+	require.Equal(t, "/bin/sh", cmd)
+	require.Empty(t, args)
+
+	cmd, _ = imageCommand(&ociimage.Image{}, "", nil)
+	require.Empty(t, cmd)
+}
+
+func TestDriver_mergeEnv(t *testing.T) {
+	env := mergeEnv(
+		[]string{"PATH=/usr/bin", "LANG=C.UTF-8"},
+		[]string{"LANG=en_US.UTF-8", "NOMAD_TASK_NAME=web"},
+	)
+	require.Equal(t, []string{"PATH=/usr/bin", "LANG=en_US.UTF-8", "NOMAD_TASK_NAME=web"}, env)
+}
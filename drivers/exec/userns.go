@@ -0,0 +1,127 @@
+package exec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/drivers/shared/subids"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// defaultSubIDUser is the user whose subordinate IDs are used if none is
+	// configured
+	defaultSubIDUser = "root"
+
+	// minUserNamespaceSize is the smallest number of IDs mapped into a
+	// task's user namespace, so that the nobody user (65534) tasks run as
+	// by default is mapped
+	minUserNamespaceSize = 65536
+
+	// userNamespaceFile is the file of the allocation directory recording
+	// the mapping of the allocation's user namespace. It is out of reach of
+	// the tasks.
+	userNamespaceFile = "userns.json"
+)
+
+// newSubIDAllocator returns an allocator handing out blocks of the
+// subordinate IDs of the configured user.
+func newSubIDAllocator(c UserNamespaceConfig) (*subids.Allocator, error) {
+	name := c.SubIDUser
+	if name == "" {
+		name = defaultSubIDUser
+	}
+	size := c.Size
+	if size == 0 {
+		size = minUserNamespaceSize
+	}
+
+	u, err := user.Lookup(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up subid_user: %v", err)
+	}
+	uids, err := subids.ParseFile(subids.SubUIDPath, u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subordinate UIDs: %v", err)
+	}
+	gids, err := subids.ParseFile(subids.SubGIDPath, u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subordinate GIDs: %v", err)
+	}
+
+	return subids.NewAllocator(uids, gids, uint32(size))
+}
+
+// acquireUserNamespace returns the mapping of the user namespace of the
+// task's allocation and shifts the ownership of the task's directories into
+// it. An allocation is given back the block of IDs recorded in its directory
+// if it is free, such as after a client restart, and files are moved to the
+// new block otherwise.
+func (d *Driver) acquireUserNamespace(cfg *drivers.TaskConfig) (*subids.Mapping, error) {
+	prev, err := readUserNamespace(cfg.AllocDir)
+	if err != nil {
+		d.logger.Warn("failed to read previous user namespace of allocation", "error", err, "alloc_id", cfg.AllocID)
+	}
+
+	m, err := d.subids.Acquire(cfg.AllocID, cfg.AllocDir, prev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate user namespace IDs: %v", err)
+	}
+
+	from := m
+	if prev != nil && prev.Size == m.Size {
+		from = prev
+	}
+	taskDir := cfg.TaskDir()
+	if err := allocdir.ShiftOwnership(taskDir.Dir, taskDir.SharedAllocDir, from.HostUID, from.HostGID, m.HostUID, m.HostGID, m.Size); err != nil {
+		d.subids.Release(cfg.AllocID)
+		return nil, fmt.Errorf("failed to change owner of task directories: %v", err)
+	}
+	if err := writeUserNamespace(cfg.AllocDir, m); err != nil {
+		d.subids.Release(cfg.AllocID)
+		return nil, fmt.Errorf("failed to record user namespace IDs: %v", err)
+	}
+	return m, nil
+}
+
+// releaseUserNamespace drops the task's hold on its allocation's
+// subordinate IDs, if it runs in a user namespace. The allocation keeps its
+// IDs until its directory is removed.
+func (d *Driver) releaseUserNamespace(allocID string, m *subids.Mapping) {
+	if m == nil || d.subids == nil {
+		return
+	}
+	d.subids.Release(allocID)
+}
+
+// readUserNamespace returns the mapping recorded in an allocation
+// directory, or nil if none is.
+func readUserNamespace(allocDir string) (*subids.Mapping, error) {
+	data, err := ioutil.ReadFile(filepath.Join(allocDir, userNamespaceFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var m subids.Mapping
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// writeUserNamespace records the mapping of an allocation in its directory.
+func writeUserNamespace(allocDir string, m *subids.Mapping) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(allocDir, userNamespaceFile), data, 0600)
+}
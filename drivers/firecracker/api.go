@@ -0,0 +1,207 @@
+package firecracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	// apiRequestTimeout bounds how long a request to the Firecracker API may
+	// take
+	apiRequestTimeout = 5 * time.Second
+
+	// apiReadyTimeout bounds how long Firecracker may take to serve its API
+	// after being launched
+	apiReadyTimeout = 10 * time.Second
+
+	// Firecracker's actions
+	actionInstanceStart  = "InstanceStart"
+	actionSendCtrlAltDel = "SendCtrlAltDel"
+)
+
+// machineConfig is the body of PUT /machine-config
+type machineConfig struct {
+	VcpuCount  int `json:"vcpu_count"`
+	MemSizeMib int `json:"mem_size_mib"`
+}
+
+// bootSource is the body of PUT /boot-source
+type bootSource struct {
+	KernelImagePath string `json:"kernel_image_path"`
+	BootArgs        string `json:"boot_args,omitempty"`
+}
+
+// drive is the body of PUT /drives/{drive_id}
+type drive struct {
+	DriveID      string `json:"drive_id"`
+	PathOnHost   string `json:"path_on_host"`
+	IsRootDevice bool   `json:"is_root_device"`
+	IsReadOnly   bool   `json:"is_read_only"`
+}
+
+// networkInterface is the body of PUT /network-interfaces/{iface_id}
+type networkInterface struct {
+	IfaceID     string `json:"iface_id"`
+	GuestMac    string `json:"guest_mac,omitempty"`
+	HostDevName string `json:"host_dev_name"`
+}
+
+// action is the body of PUT /actions
+type action struct {
+	ActionType string `json:"action_type"`
+}
+
+// instanceInfo is the response of GET /
+type instanceInfo struct {
+	ID         string `json:"id"`
+	State      string `json:"state"`
+	VMMVersion string `json:"vmm_version"`
+}
+
+// apiError is the body of the responses of failed requests
+type apiError struct {
+	FaultMessage string `json:"fault_message"`
+}
+
+// vmConfig is the configuration of a microVM set through the API before it
+// is started.
+type vmConfig struct {
+	Machine   machineConfig
+	Boot      bootSource
+	Rootfs    drive
+	Interface *networkInterface
+}
+
+// apiClient is a client of the HTTP API Firecracker serves on a unix socket.
+type apiClient struct {
+	socketPath string
+	client     *http.Client
+}
+
+func newAPIClient(socketPath string) *apiClient {
+	dialer := &net.Dialer{}
+	return &apiClient{
+		socketPath: socketPath,
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return dialer.DialContext(ctx, "unix", socketPath)
+				},
+			},
+		},
+	}
+}
+
+// waitReady blocks until the API is served or the context is done.
+func (c *apiClient) waitReady(ctx context.Context) error {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		_, err := c.describe(ctx)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("firecracker API not ready: %v", err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// configure sets up the microVM before it is started.
+func (c *apiClient) configure(ctx context.Context, config *vmConfig) error {
+	if err := c.put(ctx, "/machine-config", config.Machine); err != nil {
+		return fmt.Errorf("failed to configure machine: %v", err)
+	}
+	if err := c.put(ctx, "/boot-source", config.Boot); err != nil {
+		return fmt.Errorf("failed to configure boot source: %v", err)
+	}
+	if err := c.put(ctx, "/drives/"+config.Rootfs.DriveID, config.Rootfs); err != nil {
+		return fmt.Errorf("failed to configure root drive: %v", err)
+	}
+	if iface := config.Interface; iface != nil {
+		if err := c.put(ctx, "/network-interfaces/"+iface.IfaceID, iface); err != nil {
+			return fmt.Errorf("failed to configure network interface: %v", err)
+		}
+	}
+	return nil
+}
+
+// start boots the configured microVM.
+func (c *apiClient) start(ctx context.Context) error {
+	return c.put(ctx, "/actions", action{ActionType: actionInstanceStart})
+}
+
+// sendCtrlAltDel asks the guest to shut down by sending it a Ctrl+Alt+Del
+// keystroke. Firecracker exits once the guest reboots.
+func (c *apiClient) sendCtrlAltDel(ctx context.Context) error {
+	return c.put(ctx, "/actions", action{ActionType: actionSendCtrlAltDel})
+}
+
+// describe returns information about the microVM.
+func (c *apiClient) describe(ctx context.Context) (*instanceInfo, error) {
+	var info instanceInfo
+	if err := c.do(ctx, http.MethodGet, "/", nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func (c *apiClient) put(ctx context.Context, path string, body interface{}) error {
+	return c.do(ctx, http.MethodPut, path, body, nil)
+}
+
+func (c *apiClient) do(ctx context.Context, method, path string, body, out interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, apiRequestTimeout)
+	defer cancel()
+
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			return err
+		}
+	}
+
+	// The host is ignored when dialing the unix socket
+	req, err := http.NewRequestWithContext(ctx, method, "http://localhost"+path, &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr apiError
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.FaultMessage != "" {
+			return fmt.Errorf("%s %s: %s", method, path, apiErr.FaultMessage)
+		}
+		return fmt.Errorf("%s %s: unexpected status %s", method, path, resp.Status)
+	}
+
+	if out != nil {
+		return json.Unmarshal(data, out)
+	}
+	return nil
+}
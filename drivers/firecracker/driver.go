@@ -0,0 +1,693 @@
+package firecracker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/drivers/shared/eventer"
+	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/drivers/shared/vmnet"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/drivers/utils"
+	"github.com/hashicorp/nomad/plugins/shared/hclspec"
+	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
+)
+
+const (
+	// pluginName is the name of the plugin
+	pluginName = "firecracker"
+
+	// fingerprintPeriod is the interval at which the driver will send fingerprint responses
+	fingerprintPeriod = 30 * time.Second
+
+	// The key populated in Node Attributes to indicate presence of the
+	// Firecracker driver
+	driverAttr        = "driver.firecracker"
+	driverVersionAttr = "driver.firecracker.version"
+
+	// apiSocketName is the name of the Firecracker API socket in the task
+	// directory
+	apiSocketName = "firecracker.sock"
+
+	// maxSocketPathLen is the longest path of a unix socket
+	maxSocketPathLen = 107
+
+	// kvmPath is the device Firecracker requires to run microVMs
+	kvmPath = "/dev/kvm"
+
+	// taskHandleVersion is the version of task handle which this driver sets
+	// and understands how to decode driver state
+	taskHandleVersion = 1
+)
+
+var (
+	// PluginID is the firecracker plugin metadata registered in the plugin
+	// catalog.
+	PluginID = loader.PluginID{
+		Name:       pluginName,
+		PluginType: base.PluginTypeDriver,
+	}
+
+	// PluginConfig is the firecracker driver factory function registered in
+	// the plugin catalog.
+	PluginConfig = &loader.InternalPluginConfig{
+		Config:  map[string]interface{}{},
+		Factory: func(ctx context.Context, l hclog.Logger) interface{} { return NewFirecrackerDriver(ctx, l) },
+	}
+
+	versionRegex = regexp.MustCompile(`Firecracker v(\d[\.\d+]+)`)
+
+	// pluginInfo is the response returned for the PluginInfo RPC
+	pluginInfo = &base.PluginInfoResponse{
+		Type:              base.PluginTypeDriver,
+		PluginApiVersions: []string{drivers.ApiVersion010},
+		PluginVersion:     "0.1.0",
+		Name:              pluginName,
+	}
+
+	// configSpec is the hcl specification returned by the ConfigSchema RPC
+	configSpec = hclspec.NewObject(map[string]*hclspec.Spec{
+		"firecracker_path": hclspec.NewDefault(
+			hclspec.NewAttr("firecracker_path", "string", false),
+			hclspec.NewLiteral(`"firecracker"`),
+		),
+		"image_paths": hclspec.NewAttr("image_paths", "list(string)", false),
+	})
+
+	// taskConfigSpec is the hcl specification for the driver config section of
+	// a task within a job. It is returned in the TaskConfigSchema RPC
+	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
+		"kernel_image": hclspec.NewAttr("kernel_image", "string", true),
+		"rootfs_image": hclspec.NewAttr("rootfs_image", "string", true),
+		"kernel_args": hclspec.NewDefault(
+			hclspec.NewAttr("kernel_args", "string", false),
+			hclspec.NewLiteral(`"console=ttyS0 reboot=k panic=1 pci=off"`),
+		),
+		"vcpu_count":       hclspec.NewAttr("vcpu_count", "number", false),
+		"read_only_rootfs": hclspec.NewAttr("read_only_rootfs", "bool", false),
+	})
+
+	// capabilities is returned by the Capabilities RPC and indicates what
+	// optional features this driver supports
+	capabilities = &drivers.Capabilities{
+		SendSignals: false,
+		Exec:        false,
+		FSIsolation: drivers.FSIsolationImage,
+		NetIsolationModes: []drivers.NetIsolationMode{
+			drivers.NetIsolationModeHost,
+			drivers.NetIsolationModeGroup,
+		},
+		MountConfigs: drivers.MountConfigSupportNone,
+	}
+
+	_ drivers.DriverPlugin = (*Driver)(nil)
+)
+
+// TaskConfig is the driver configuration of a task within a job
+type TaskConfig struct {
+	// KernelImage is the path of the uncompressed kernel the microVM boots.
+	KernelImage string `codec:"kernel_image"`
+
+	// RootfsImage is the path of the image of the microVM's root
+	// filesystem.
+	RootfsImage string `codec:"rootfs_image"`
+
+	// KernelArgs are the arguments the kernel is booted with.
+	KernelArgs string `codec:"kernel_args"`
+
+	// VcpuCount is the number of vCPUs of the microVM. It defaults to the
+	// number of cores reserved by the task, or 1.
+	VcpuCount int `codec:"vcpu_count"`
+
+	// ReadOnlyRootfs attaches the root filesystem image read only.
+	ReadOnlyRootfs bool `codec:"read_only_rootfs"`
+}
+
+// TaskState is the state which is encoded in the handle returned in StartTask.
+// This information is needed to rebuild the task state and handler during
+// recovery.
+type TaskState struct {
+	ReattachConfig *pstructs.ReattachConfig
+	TaskConfig     *drivers.TaskConfig
+	Pid            int
+	StartedAt      time.Time
+
+	// APISocket is the path of the Firecracker API socket
+	APISocket string
+}
+
+// Config is the driver configuration set by SetConfig RPC call
+type Config struct {
+	// FirecrackerPath is the path of the firecracker binary, looked up in
+	// the PATH if it isn't absolute
+	FirecrackerPath string `codec:"firecracker_path"`
+
+	// ImagePaths is an allow-list of paths outside of the allocation
+	// directory that kernel and rootfs images can be loaded from
+	ImagePaths []string `codec:"image_paths"`
+}
+
+// Driver is a driver for running Firecracker microVMs
+type Driver struct {
+	// eventer is used to handle multiplexing of TaskEvents calls such that an
+	// event can be broadcast to all callers
+	eventer *eventer.Eventer
+
+	// config is the driver configuration set by the SetConfig RPC
+	config Config
+
+	// tasks is the in memory datastore mapping taskIDs to taskHandles
+	tasks *taskStore
+
+	// ctx is the context for the driver. It is passed to other subsystems to
+	// coordinate shutdown
+	ctx context.Context
+
+	// nomadConf is the client agent's configuration
+	nomadConfig *base.ClientDriverConfig
+
+	// logger will log to the Nomad agent
+	logger hclog.Logger
+}
+
+// NewFirecrackerDriver returns a new DriverPlugin implementation
+func NewFirecrackerDriver(ctx context.Context, logger hclog.Logger) drivers.DriverPlugin {
+	logger = logger.Named(pluginName)
+	return &Driver{
+		eventer: eventer.NewEventer(ctx, logger),
+		config:  Config{FirecrackerPath: "firecracker"},
+		tasks:   newTaskStore(),
+		ctx:     ctx,
+		logger:  logger,
+	}
+}
+
+func (d *Driver) PluginInfo() (*base.PluginInfoResponse, error) {
+	return pluginInfo, nil
+}
+
+func (d *Driver) ConfigSchema() (*hclspec.Spec, error) {
+	return configSpec, nil
+}
+
+func (d *Driver) SetConfig(cfg *base.Config) error {
+	var config Config
+	if len(cfg.PluginConfig) != 0 {
+		if err := base.MsgPackDecode(cfg.PluginConfig, &config); err != nil {
+			return err
+		}
+	}
+	if config.FirecrackerPath == "" {
+		config.FirecrackerPath = "firecracker"
+	}
+
+	d.config = config
+	if cfg.AgentConfig != nil {
+		d.nomadConfig = cfg.AgentConfig.Driver
+	}
+	return nil
+}
+
+func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
+	return taskConfigSpec, nil
+}
+
+func (d *Driver) Capabilities() (*drivers.Capabilities, error) {
+	return capabilities, nil
+}
+
+func (d *Driver) Fingerprint(ctx context.Context) (<-chan *drivers.Fingerprint, error) {
+	ch := make(chan *drivers.Fingerprint)
+	go d.handleFingerprint(ctx, ch)
+	return ch, nil
+}
+
+func (d *Driver) handleFingerprint(ctx context.Context, ch chan *drivers.Fingerprint) {
+	ticker := time.NewTimer(0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+			ticker.Reset(fingerprintPeriod)
+			ch <- d.buildFingerprint()
+		}
+	}
+}
+
+func (d *Driver) buildFingerprint() *drivers.Fingerprint {
+	fingerprint := &drivers.Fingerprint{
+		Attributes:        map[string]*pstructs.Attribute{},
+		Health:            drivers.HealthStateHealthy,
+		HealthDescription: drivers.DriverHealthy,
+	}
+
+	if runtime.GOOS != "linux" {
+		fingerprint.Health = drivers.HealthStateUndetected
+		fingerprint.HealthDescription = "firecracker driver unsupported on client OS"
+		return fingerprint
+	}
+
+	bin, err := exec.LookPath(d.config.FirecrackerPath)
+	if err != nil {
+		// it isn't an error to not find firecracker, it just means we can't
+		// use it.
+		fingerprint.Health = drivers.HealthStateUndetected
+		fingerprint.HealthDescription = ""
+		return fingerprint
+	}
+
+	if !utils.IsUnixRoot() {
+		fingerprint.Health = drivers.HealthStateUndetected
+		fingerprint.HealthDescription = drivers.DriverRequiresRootMessage
+		return fingerprint
+	}
+
+	if _, err := os.Stat(kvmPath); err != nil {
+		fingerprint.Health = drivers.HealthStateUndetected
+		fingerprint.HealthDescription = fmt.Sprintf("KVM is unavailable: %v", err)
+		return fingerprint
+	}
+
+	outBytes, err := exec.Command(bin, "--version").Output()
+	if err != nil {
+		fingerprint.Health = drivers.HealthStateUndetected
+		fingerprint.HealthDescription = fmt.Sprintf("Failed to run %s --version: %v", bin, err)
+		return fingerprint
+	}
+	out := strings.TrimSpace(string(outBytes))
+
+	matches := versionRegex.FindStringSubmatch(out)
+	if len(matches) != 2 {
+		fingerprint.Health = drivers.HealthStateUndetected
+		fingerprint.HealthDescription = fmt.Sprintf("Failed to parse firecracker version from %v", out)
+		return fingerprint
+	}
+
+	fingerprint.Attributes[driverAttr] = pstructs.NewBoolAttribute(true)
+	fingerprint.Attributes[driverVersionAttr] = pstructs.NewStringAttribute(matches[1])
+	return fingerprint
+}
+
+func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
+	if handle == nil {
+		return fmt.Errorf("error: handle cannot be nil")
+	}
+
+	// If already attached to handle there's nothing to recover.
+	if _, ok := d.tasks.Get(handle.Config.ID); ok {
+		d.logger.Trace("nothing to recover; task already exists",
+			"task_id", handle.Config.ID,
+			"task_name", handle.Config.Name,
+		)
+		return nil
+	}
+
+	var taskState TaskState
+	if err := handle.GetDriverState(&taskState); err != nil {
+		d.logger.Error("failed to decode task state from handle", "error", err, "task_id", handle.Config.ID)
+		return fmt.Errorf("failed to decode task state from handle: %v", err)
+	}
+
+	plugRC, err := pstructs.ReattachConfigToGoPlugin(taskState.ReattachConfig)
+	if err != nil {
+		d.logger.Error("failed to build ReattachConfig from task state", "error", err, "task_id", handle.Config.ID)
+		return fmt.Errorf("failed to build ReattachConfig from task state: %v", err)
+	}
+
+	execImpl, pluginClient, err := executor.ReattachToExecutor(plugRC,
+		d.logger.With("task_name", handle.Config.Name, "alloc_id", handle.Config.AllocID))
+	if err != nil {
+		d.logger.Error("failed to reattach to executor", "error", err, "task_id", handle.Config.ID)
+		return fmt.Errorf("failed to reattach to executor: %v", err)
+	}
+
+	h := &taskHandle{
+		exec:         execImpl,
+		pid:          taskState.Pid,
+		pluginClient: pluginClient,
+		api:          newAPIClient(taskState.APISocket),
+		doneCh:       make(chan struct{}),
+		taskConfig:   taskState.TaskConfig,
+		procState:    drivers.TaskStateRunning,
+		startedAt:    taskState.StartedAt,
+		exitResult:   &drivers.ExitResult{},
+		logger:       d.logger,
+	}
+
+	// The microVM is left running if its API is unreachable, as it may
+	// still be killed through the executor
+	if info, err := h.api.describe(d.ctx); err != nil {
+		d.logger.Warn("failed to reach firecracker API of recovered task", "error", err, "task_id", handle.Config.ID)
+	} else {
+		d.logger.Debug("recovered microVM", "state", info.State, "task_id", handle.Config.ID)
+	}
+
+	d.tasks.Set(taskState.TaskConfig.ID, h)
+
+	go h.run()
+	return nil
+}
+
+// imagePath returns the absolute path of an image set in the task config,
+// which may be relative to the task directory.
+func (d *Driver) imagePath(cfg *drivers.TaskConfig, path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(cfg.TaskDir().Dir, path)
+	}
+	path = filepath.Clean(path)
+
+	if !isAllowedImagePath(d.config.ImagePaths, cfg.AllocDir, path) {
+		return "", fmt.Errorf("image %q is not in the allowed paths", path)
+	}
+	return path, nil
+}
+
+// isAllowedImagePath returns true if the image is in the allocation directory
+// or in one of the paths allowed by the plugin config.
+func isAllowedImagePath(allowedPaths []string, allocDir, imagePath string) bool {
+	if !filepath.IsAbs(imagePath) {
+		imagePath = filepath.Join(allocDir, imagePath)
+	}
+
+	isParent := func(parent, path string) bool {
+		rel, err := filepath.Rel(parent, path)
+		return err == nil && !strings.HasPrefix(rel, "..")
+	}
+
+	// check if path is under alloc dir
+	if isParent(allocDir, imagePath) {
+		return true
+	}
+
+	// check allowed paths
+	for _, ap := range allowedPaths {
+		if isParent(ap, imagePath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// vcpuCount returns the number of vCPUs of the microVM.
+func vcpuCount(cfg *drivers.TaskConfig, driverConfig *TaskConfig) int {
+	if driverConfig.VcpuCount > 0 {
+		return driverConfig.VcpuCount
+	}
+	if cfg.Resources.LinuxResources != nil && cfg.Resources.LinuxResources.CpusetCpus != "" {
+		return len(strings.Split(cfg.Resources.LinuxResources.CpusetCpus, ","))
+	}
+	return 1
+}
+
+func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	if _, ok := d.tasks.Get(cfg.ID); ok {
+		return nil, nil, fmt.Errorf("task with ID %q already started", cfg.ID)
+	}
+
+	var driverConfig TaskConfig
+	if err := cfg.DecodeDriverConfig(&driverConfig); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode driver config: %v", err)
+	}
+
+	handle := drivers.NewTaskHandle(taskHandleVersion)
+	handle.Config = cfg
+
+	kernelPath, err := d.imagePath(cfg, driverConfig.KernelImage)
+	if err != nil {
+		return nil, nil, err
+	}
+	rootfsPath, err := d.imagePath(cfg, driverConfig.RootfsImage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mb := cfg.Resources.NomadResources.Memory.MemoryMB
+	if mb < 128 {
+		return nil, nil, fmt.Errorf("firecracker requires at least 128MB of memory")
+	}
+
+	socketPath := filepath.Join(cfg.TaskDir().Dir, apiSocketName)
+	if len(socketPath) > maxSocketPathLen {
+		return nil, nil, fmt.Errorf("firecracker API socket path %q is too long", socketPath)
+	}
+
+	// Firecracker refuses to start if the socket of a previous run remains
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("failed to remove stale firecracker API socket: %v", err)
+	}
+
+	// Attach the microVM to the allocation's network namespace
+	var guestNet *vmnet.GuestNetwork
+	if cfg.NetworkIsolation != nil && cfg.NetworkIsolation.Path != "" {
+		guestNet, err = vmnet.SetupTap(cfg.NetworkIsolation.Path, tapName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to attach microVM to network: %v", err)
+		}
+	}
+
+	vm := &vmConfig{
+		Machine: machineConfig{
+			VcpuCount:  vcpuCount(cfg, &driverConfig),
+			MemSizeMib: int(mb),
+		},
+		Boot: bootSource{
+			KernelImagePath: kernelPath,
+			BootArgs:        bootArgs(driverConfig.KernelArgs, guestNet, cfg.DNS),
+		},
+		Rootfs: drive{
+			DriveID:      "rootfs",
+			PathOnHost:   rootfsPath,
+			IsRootDevice: true,
+			IsReadOnly:   driverConfig.ReadOnlyRootfs,
+		},
+	}
+	if guestNet != nil {
+		vm.Interface = guestInterface(guestNet)
+	}
+
+	bin, err := exec.LookPath(d.config.FirecrackerPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve path to firecracker executable: %v", err)
+	}
+
+	pluginLogFile := filepath.Join(cfg.TaskDir().Dir, fmt.Sprintf("%s-executor.out", cfg.Name))
+	executorConfig := &executor.ExecutorConfig{
+		LogFile:  pluginLogFile,
+		LogLevel: "debug",
+	}
+
+	execImpl, pluginClient, err := executor.CreateExecutor(
+		d.logger.With("task_name", handle.Config.Name, "alloc_id", handle.Config.AllocID),
+		d.nomadConfig, executorConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Firecracker runs in the allocation's network namespace to open the
+	// tap device
+	execCmd := &executor.ExecCommand{
+		Cmd:              bin,
+		Args:             []string{"--api-sock", socketPath},
+		Env:              cfg.EnvList(),
+		User:             cfg.User,
+		TaskDir:          cfg.TaskDir().Dir,
+		StdoutPath:       cfg.StdoutPath,
+		StderrPath:       cfg.StderrPath,
+		NetworkIsolation: cfg.NetworkIsolation,
+	}
+	d.logger.Debug("starting firecracker", "args", strings.Join(execCmd.Args, " "))
+
+	ps, err := execImpl.Launch(execCmd)
+	if err != nil {
+		pluginClient.Kill()
+		return nil, nil, err
+	}
+
+	api := newAPIClient(socketPath)
+	if err := d.bootVM(api, vm); err != nil {
+		execImpl.Shutdown("", 0)
+		pluginClient.Kill()
+		return nil, nil, err
+	}
+	d.logger.Debug("started microVM", "task_id", cfg.ID, "vcpus", vm.Machine.VcpuCount, "memory_mb", mb)
+
+	h := &taskHandle{
+		exec:         execImpl,
+		pid:          ps.Pid,
+		pluginClient: pluginClient,
+		api:          api,
+		doneCh:       make(chan struct{}),
+		taskConfig:   cfg,
+		procState:    drivers.TaskStateRunning,
+		startedAt:    time.Now().Round(time.Millisecond),
+		logger:       d.logger,
+	}
+
+	driverState := TaskState{
+		ReattachConfig: pstructs.ReattachConfigFromGoPlugin(pluginClient.ReattachConfig()),
+		Pid:            ps.Pid,
+		TaskConfig:     cfg,
+		StartedAt:      h.startedAt,
+		APISocket:      socketPath,
+	}
+
+	if err := handle.SetDriverState(&driverState); err != nil {
+		d.logger.Error("failed to start task, error setting driver state", "error", err)
+		execImpl.Shutdown("", 0)
+		pluginClient.Kill()
+		return nil, nil, fmt.Errorf("failed to set driver state: %v", err)
+	}
+
+	d.tasks.Set(cfg.ID, h)
+	go h.run()
+
+	var driverNetwork *drivers.DriverNetwork
+	if guestNet != nil {
+		driverNetwork = &drivers.DriverNetwork{
+			IP: guestNet.Addr.IP.String(),
+		}
+	}
+	return handle, driverNetwork, nil
+}
+
+// bootVM configures the microVM through the API of a newly launched
+// Firecracker and starts it.
+func (d *Driver) bootVM(api *apiClient, vm *vmConfig) error {
+	ctx, cancel := context.WithTimeout(d.ctx, apiReadyTimeout)
+	defer cancel()
+
+	if err := api.waitReady(ctx); err != nil {
+		return err
+	}
+	if err := api.configure(d.ctx, vm); err != nil {
+		return err
+	}
+	if err := api.start(d.ctx); err != nil {
+		return fmt.Errorf("failed to start microVM: %v", err)
+	}
+	return nil
+}
+
+func (d *Driver) WaitTask(ctx context.Context, taskID string) (<-chan *drivers.ExitResult, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	ch := make(chan *drivers.ExitResult)
+	go d.handleWait(ctx, handle, ch)
+
+	return ch, nil
+}
+
+func (d *Driver) handleWait(ctx context.Context, handle *taskHandle, ch chan *drivers.ExitResult) {
+	defer close(ch)
+	var result *drivers.ExitResult
+	ps, err := handle.exec.Wait(ctx)
+	if err != nil {
+		result = &drivers.ExitResult{
+			Err: fmt.Errorf("executor: error waiting on process: %v", err),
+		}
+	} else {
+		result = &drivers.ExitResult{
+			ExitCode: ps.ExitCode,
+			Signal:   ps.Signal,
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-d.ctx.Done():
+	case ch <- result:
+	}
+}
+
+// StopTask shuts the guest down through the Firecracker API, and kills
+// Firecracker if the guest hasn't shut down within the timeout.
+func (d *Driver) StopTask(taskID string, timeout time.Duration, signal string) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if handle.shutdown(timeout) {
+		return nil
+	}
+
+	if err := handle.exec.Shutdown(signal, 0); err != nil {
+		if handle.pluginClient.Exited() {
+			return nil
+		}
+		return fmt.Errorf("executor Shutdown failed: %v", err)
+	}
+
+	return nil
+}
+
+func (d *Driver) DestroyTask(taskID string, force bool) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if handle.IsRunning() && !force {
+		return fmt.Errorf("cannot destroy running task")
+	}
+
+	if !handle.pluginClient.Exited() {
+		if err := handle.exec.Shutdown("", 0); err != nil {
+			handle.logger.Error("destroying executor failed", "err", err)
+		}
+
+		handle.pluginClient.Kill()
+	}
+
+	d.tasks.Delete(taskID)
+	return nil
+}
+
+func (d *Driver) InspectTask(taskID string) (*drivers.TaskStatus, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return handle.TaskStatus(), nil
+}
+
+// TaskStats returns the resource usage of the Firecracker process, which
+// includes the memory of the guest and the time spent running its vCPUs.
+func (d *Driver) TaskStats(ctx context.Context, taskID string, interval time.Duration) (<-chan *drivers.TaskResourceUsage, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return handle.exec.Stats(ctx, interval)
+}
+
+func (d *Driver) TaskEvents(ctx context.Context) (<-chan *drivers.TaskEvent, error) {
+	return d.eventer.TaskEvents(ctx)
+}
+
+func (d *Driver) SignalTask(taskID string, signal string) error {
+	return fmt.Errorf("Firecracker driver can't signal commands")
+}
+
+func (d *Driver) ExecTask(taskID string, cmdArgs []string, timeout time.Duration) (*drivers.ExecTaskResult, error) {
+	return nil, fmt.Errorf("Firecracker driver can't execute commands")
+}
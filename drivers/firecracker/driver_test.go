@@ -0,0 +1,289 @@
+package firecracker
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/drivers/shared/vmnet"
+	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	dtestutil "github.com/hashicorp/nomad/plugins/drivers/testutils"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	if !runFakeFirecracker() {
+		os.Exit(m.Run())
+	}
+}
+
+// newTestDriver returns a driver running the test binary as firecracker.
+func newTestDriver(t *testing.T, ctx context.Context) (*Driver, *dtestutil.DriverHarness) {
+	if runtime.GOOS != "linux" {
+		t.Skip("firecracker driver is only supported on linux")
+	}
+
+	d := NewFirecrackerDriver(ctx, testlog.HCLogger(t)).(*Driver)
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	var data []byte
+	require.NoError(t, base.MsgPackEncode(&data, &Config{FirecrackerPath: os.Args[0]}))
+	require.NoError(t, harness.SetConfig(&base.Config{PluginConfig: data}))
+	return d, harness
+}
+
+func newTestTask(t *testing.T, harness *dtestutil.DriverHarness) (*drivers.TaskConfig, func()) {
+	task := &drivers.TaskConfig{
+		ID:      uuid.Generate(),
+		AllocID: uuid.Generate(),
+		Name:    "vm",
+		Resources: &drivers.Resources{
+			NomadResources: &structs.AllocatedTaskResources{
+				Memory: structs.AllocatedMemoryResources{
+					MemoryMB: 256,
+				},
+				Cpu: structs.AllocatedCpuResources{
+					CpuShares:     100,
+					ReservedCores: []uint16{0, 1},
+				},
+			},
+			LinuxResources: &drivers.LinuxResources{
+				MemoryLimitBytes: 256 * 1024 * 1024,
+				CPUShares:        100,
+				CpusetCpus:       "0,1",
+			},
+		},
+	}
+
+	tc := &TaskConfig{
+		KernelImage: "local/vmlinux",
+		RootfsImage: "local/rootfs.ext4",
+		KernelArgs:  "console=ttyS0",
+	}
+	require.NoError(t, task.EncodeConcreteDriverConfig(&tc))
+	cleanup := harness.MkAllocDir(task, false)
+
+	taskDir := filepath.Join(task.AllocDir, task.Name)
+	for _, image := range []string{tc.KernelImage, tc.RootfsImage} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(taskDir, image), []byte("image"), 0644))
+	}
+	return task, cleanup
+}
+
+// Verifies booting a microVM, shutting it down gracefully and recovering it
+func TestFirecrackerDriver_Start_Recover_Stop(t *testing.T) {
+	if !testutil.IsCI() {
+		t.Parallel()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d, harness := newTestDriver(t, ctx)
+	task, cleanup := newTestTask(t, harness)
+	defer cleanup()
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(t, err)
+	defer harness.DestroyTask(task.ID, true)
+
+	var state TaskState
+	require.NoError(t, handle.GetDriverState(&state))
+	require.Equal(t, filepath.Join(task.TaskDir().Dir, apiSocketName), state.APISocket)
+
+	// The microVM is configured from the task and running
+	api := newAPIClient(state.APISocket)
+	info, err := api.describe(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "Running", info.State)
+
+	var vm vmConfig
+	require.NoError(t, api.do(ctx, "GET", fakeConfigPath, nil, &vm))
+	require.Equal(t, 2, vm.Machine.VcpuCount)
+	require.Equal(t, 256, vm.Machine.MemSizeMib)
+	require.Equal(t, filepath.Join(task.TaskDir().Dir, "local/vmlinux"), vm.Boot.KernelImagePath)
+	require.Equal(t, "console=ttyS0", vm.Boot.BootArgs)
+	require.Equal(t, filepath.Join(task.TaskDir().Dir, "local/rootfs.ext4"), vm.Rootfs.PathOnHost)
+	require.True(t, vm.Rootfs.IsRootDevice)
+	require.Nil(t, vm.Interface)
+
+	// Recovering a task reattaches to the running microVM
+	d.tasks.Delete(task.ID)
+	require.NoError(t, d.RecoverTask(handle))
+	status, err := d.InspectTask(task.ID)
+	require.NoError(t, err)
+	require.Equal(t, drivers.TaskStateRunning, status.State)
+	require.Equal(t, state.APISocket, status.DriverAttributes["api_socket"])
+
+	ch, err := harness.WaitTask(ctx, task.ID)
+	require.NoError(t, err)
+
+	// Stopping the task shuts the guest down through the API
+	require.NoError(t, harness.StopTask(task.ID, 5*time.Second, "SIGINT"))
+
+	select {
+	case res := <-ch:
+		require.True(t, res.Successful(), "guest should have shut down: %v", res)
+	case <-time.After(10 * time.Second):
+		t.Fatalf("timeout waiting for the microVM to exit")
+	}
+}
+
+func TestFirecrackerDriver_Start_MissingImage(t *testing.T) {
+	if !testutil.IsCI() {
+		t.Parallel()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, harness := newTestDriver(t, ctx)
+	task, cleanup := newTestTask(t, harness)
+	defer cleanup()
+
+	require.NoError(t, os.Remove(filepath.Join(task.TaskDir().Dir, "local/vmlinux")))
+
+	_, _, err := harness.StartTask(task)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to configure boot source")
+}
+
+func TestAPIClient_Configure(t *testing.T) {
+	if !testutil.IsCI() {
+		t.Parallel()
+	}
+
+	dir, err := ioutil.TempDir("", "firecracker")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	kernel := filepath.Join(dir, "vmlinux")
+	require.NoError(t, ioutil.WriteFile(kernel, []byte("kernel"), 0644))
+
+	f := startFakeFirecracker(t, filepath.Join(dir, apiSocketName))
+	api := newAPIClient(filepath.Join(dir, apiSocketName))
+
+	ctx := context.Background()
+	require.NoError(t, api.waitReady(ctx))
+
+	vm := &vmConfig{
+		Machine: machineConfig{VcpuCount: 1, MemSizeMib: 128},
+		Boot:    bootSource{KernelImagePath: kernel},
+		Rootfs: drive{
+			DriveID:      "rootfs",
+			PathOnHost:   filepath.Join(dir, "missing.ext4"),
+			IsRootDevice: true,
+		},
+		Interface: &networkInterface{IfaceID: guestIfaceID, HostDevName: tapName},
+	}
+
+	// Errors returned by the API are surfaced
+	err = api.configure(ctx, vm)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to configure root drive")
+	require.Contains(t, err.Error(), "cannot open drive")
+
+	vm.Rootfs.PathOnHost = kernel
+	require.NoError(t, api.configure(ctx, vm))
+	require.Equal(t, *vm, f.config())
+
+	require.NoError(t, api.start(ctx))
+	info, err := api.describe(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "Running", info.State)
+	require.Equal(t, fakeVersion, info.VMMVersion)
+
+	require.NoError(t, api.sendCtrlAltDel(ctx))
+	select {
+	case <-f.exitCh:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout waiting for the guest to shut down")
+	}
+}
+
+func TestGuestNetwork_BootArgs(t *testing.T) {
+	_, addr, err := net.ParseCIDR("172.26.64.0/20")
+	require.NoError(t, err)
+	addr.IP = net.ParseIP("172.26.64.12").To4()
+
+	gn := &vmnet.GuestNetwork{
+		MAC:     net.HardwareAddr{0x02, 0x42, 0xac, 0x1a, 0x40, 0x0c},
+		Addr:    addr,
+		Gateway: net.ParseIP("172.26.64.1"),
+	}
+
+	require.Equal(t, "console=ttyS0", bootArgs("console=ttyS0", nil, nil))
+	require.Equal(t,
+		"console=ttyS0 ip=172.26.64.12::172.26.64.1:255.255.240.0::eth0:off",
+		bootArgs("console=ttyS0", gn, nil))
+
+	dns := &drivers.DNSConfig{Servers: []string{"1.1.1.1", "8.8.8.8", "9.9.9.9"}}
+	require.Equal(t,
+		"ip=172.26.64.12::172.26.64.1:255.255.240.0::eth0:off:1.1.1.1:8.8.8.8",
+		kernelIPArg(gn, dns))
+
+	iface := guestInterface(gn)
+	require.Equal(t, "02:42:ac:1a:40:0c", iface.GuestMac)
+	require.Equal(t, tapName, iface.HostDevName)
+}
+
+func TestConfig_ParseAllHCL(t *testing.T) {
+	cfgStr := `
+config {
+  kernel_image = "local/vmlinux"
+  rootfs_image = "local/rootfs.ext4"
+  kernel_args = "console=ttyS0 reboot=k"
+  vcpu_count = 2
+  read_only_rootfs = true
+}`
+
+	expected := &TaskConfig{
+		KernelImage:    "local/vmlinux",
+		RootfsImage:    "local/rootfs.ext4",
+		KernelArgs:     "console=ttyS0 reboot=k",
+		VcpuCount:      2,
+		ReadOnlyRootfs: true,
+	}
+
+	var tc *TaskConfig
+	hclutils.NewConfigParser(taskConfigSpec).ParseHCL(t, cfgStr, &tc)
+
+	require.EqualValues(t, expected, tc)
+}
+
+func TestIsAllowedImagePath(t *testing.T) {
+	allowedPaths := []string{"/var/lib/firecracker"}
+	allocDir := "/opt/nomad/some-alloc-dir"
+
+	validPaths := []string{
+		"local/vmlinux",
+		"/var/lib/firecracker/vmlinux",
+		"/opt/nomad/some-alloc-dir/vm/local/rootfs.ext4",
+	}
+
+	invalidPaths := []string{
+		"/vmlinux",
+		"../vmlinux",
+		"/var/lib/firecracker-other/vmlinux",
+		"/opt/nomad/other-alloc-dir/vm/local/rootfs.ext4",
+	}
+
+	for _, p := range validPaths {
+		require.Truef(t, isAllowedImagePath(allowedPaths, allocDir, p), "path should be allowed: %v", p)
+	}
+
+	for _, p := range invalidPaths {
+		require.Falsef(t, isAllowedImagePath(allowedPaths, allocDir, p), "path should be not allowed: %v", p)
+	}
+}
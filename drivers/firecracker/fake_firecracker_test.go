@@ -0,0 +1,230 @@
+package firecracker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// fakeVersion is the version reported by the fake firecracker
+	fakeVersion = "1.1.0"
+
+	// fakeConfigPath is a path served only by the fake firecracker, returning
+	// the configuration of the microVM
+	fakeConfigPath = "/fake/vm"
+)
+
+// runFakeFirecracker makes the test binary act as firecracker if it was
+// executed with firecracker's arguments. It returns false if the binary
+// should run the tests.
+func runFakeFirecracker() bool {
+	if len(os.Args) < 2 {
+		return false
+	}
+
+	switch os.Args[1] {
+	case "--version":
+		fmt.Printf("Firecracker v%s\n\nSupported snapshot data format versions: v1.0.0\n", fakeVersion)
+		os.Exit(0)
+	case "--api-sock":
+		if len(os.Args) != 3 {
+			fmt.Fprintln(os.Stderr, "missing API socket path")
+			os.Exit(1)
+		}
+		f, err := newFakeFirecracker(os.Args[2])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		<-f.exitCh
+		f.close()
+		os.Exit(0)
+	}
+	return false
+}
+
+// fakeFirecracker serves a subset of the Firecracker API on a unix socket. It
+// validates the configuration of the microVM like firecracker does, but
+// doesn't boot it.
+type fakeFirecracker struct {
+	listener net.Listener
+	server   *http.Server
+
+	// exitCh is closed when the guest is shut down
+	exitCh   chan struct{}
+	exitOnce sync.Once
+
+	lock  sync.Mutex
+	state string
+	vm    vmConfig
+}
+
+func newFakeFirecracker(socketPath string) (*fakeFirecracker, error) {
+	// firecracker refuses to reuse an existing socket
+	if _, err := os.Stat(socketPath); err == nil {
+		return nil, fmt.Errorf("socket %s already exists", socketPath)
+	}
+
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &fakeFirecracker{
+		listener: l,
+		exitCh:   make(chan struct{}),
+		state:    "Not started",
+	}
+	f.server = &http.Server{Handler: http.HandlerFunc(f.handle)}
+	go f.server.Serve(l)
+	return f, nil
+}
+
+// startFakeFirecracker runs a fake firecracker in the test process.
+func startFakeFirecracker(t *testing.T, socketPath string) *fakeFirecracker {
+	f, err := newFakeFirecracker(socketPath)
+	require.NoError(t, err)
+	t.Cleanup(f.close)
+	return f
+}
+
+func (f *fakeFirecracker) close() {
+	f.server.Close()
+}
+
+func (f *fakeFirecracker) config() vmConfig {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.vm
+}
+
+func (f *fakeFirecracker) handle(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	fault := func(code int, format string, args ...interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(apiError{FaultMessage: fmt.Sprintf(format, args...)})
+	}
+
+	decode := func(v interface{}) bool {
+		if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+			fault(http.StatusBadRequest, "invalid body: %v", err)
+			return false
+		}
+		return true
+	}
+
+	path := r.URL.Path
+	switch {
+	case r.Method == http.MethodGet && path == "/":
+		json.NewEncoder(w).Encode(instanceInfo{
+			ID:         "anonymous-instance",
+			State:      f.state,
+			VMMVersion: fakeVersion,
+		})
+		return
+
+	case r.Method == http.MethodGet && path == fakeConfigPath:
+		json.NewEncoder(w).Encode(f.vm)
+		return
+
+	case r.Method != http.MethodPut:
+		fault(http.StatusMethodNotAllowed, "invalid method %s", r.Method)
+		return
+
+	case path == "/actions":
+		var a action
+		if !decode(&a) {
+			return
+		}
+		switch a.ActionType {
+		case actionInstanceStart:
+			if f.state != "Not started" {
+				fault(http.StatusBadRequest, "the microVM is already running")
+				return
+			}
+			if f.vm.Boot.KernelImagePath == "" {
+				fault(http.StatusBadRequest, "missing kernel configuration")
+				return
+			}
+			if f.vm.Rootfs.PathOnHost == "" {
+				fault(http.StatusBadRequest, "missing root drive")
+				return
+			}
+			f.state = "Running"
+		case actionSendCtrlAltDel:
+			if f.state != "Running" {
+				fault(http.StatusBadRequest, "the microVM is not running")
+				return
+			}
+			// the guest takes a moment to shut down
+			time.AfterFunc(100*time.Millisecond, func() {
+				f.exitOnce.Do(func() { close(f.exitCh) })
+			})
+		default:
+			fault(http.StatusBadRequest, "unknown action %q", a.ActionType)
+			return
+		}
+
+	case f.state != "Not started":
+		fault(http.StatusBadRequest, "the microVM is already running")
+		return
+
+	case path == "/machine-config":
+		var mc machineConfig
+		if !decode(&mc) {
+			return
+		}
+		if mc.VcpuCount < 1 || mc.VcpuCount > 32 {
+			fault(http.StatusBadRequest, "invalid vcpu_count %d", mc.VcpuCount)
+			return
+		}
+		f.vm.Machine = mc
+
+	case path == "/boot-source":
+		var bs bootSource
+		if !decode(&bs) {
+			return
+		}
+		if _, err := os.Stat(bs.KernelImagePath); err != nil {
+			fault(http.StatusBadRequest, "cannot open kernel image: %v", err)
+			return
+		}
+		f.vm.Boot = bs
+
+	case strings.HasPrefix(path, "/drives/"):
+		var d drive
+		if !decode(&d) {
+			return
+		}
+		if _, err := os.Stat(d.PathOnHost); err != nil {
+			fault(http.StatusBadRequest, "cannot open drive: %v", err)
+			return
+		}
+		f.vm.Rootfs = d
+
+	case strings.HasPrefix(path, "/network-interfaces/"):
+		var iface networkInterface
+		if !decode(&iface) {
+			return
+		}
+		f.vm.Interface = &iface
+
+	default:
+		fault(http.StatusNotFound, "unknown path %s", path)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
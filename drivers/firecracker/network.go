@@ -0,0 +1,66 @@
+package firecracker
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/nomad/drivers/shared/vmnet"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// tapName is the name of the tap device created in the allocation's
+	// network namespace for the microVM
+	tapName = "tap0"
+
+	// guestIfaceID is the ID of the microVM's network interface
+	guestIfaceID = "eth0"
+)
+
+// guestInterface returns the API configuration of the interface of a microVM
+// attached to the allocation's network namespace.
+func guestInterface(n *vmnet.GuestNetwork) *networkInterface {
+	return &networkInterface{
+		IfaceID:     guestIfaceID,
+		GuestMac:    n.MAC.String(),
+		HostDevName: tapName,
+	}
+}
+
+// kernelIPArg returns the ip= kernel argument configuring the guest's
+// interface, in the format ip=<client-ip>:<server-ip>:<gw-ip>:<netmask>:
+// <hostname>:<device>:<autoconf>:<dns0-ip>:<dns1-ip>.
+func kernelIPArg(n *vmnet.GuestNetwork, dns *drivers.DNSConfig) string {
+	gateway := ""
+	if n.Gateway != nil {
+		gateway = n.Gateway.String()
+	}
+
+	fields := []string{
+		n.Addr.IP.String(),
+		"",
+		gateway,
+		net.IP(n.Addr.Mask).String(),
+		"",
+		guestIfaceID,
+		"off",
+	}
+	if dns != nil {
+		for i, server := range dns.Servers {
+			if i == 2 {
+				break
+			}
+			fields = append(fields, server)
+		}
+	}
+	return "ip=" + strings.Join(fields, ":")
+}
+
+// bootArgs returns the kernel arguments of the microVM.
+func bootArgs(kernelArgs string, gn *vmnet.GuestNetwork, dns *drivers.DNSConfig) string {
+	if gn == nil {
+		return kernelArgs
+	}
+	return fmt.Sprintf("%s %s", kernelArgs, kernelIPArg(gn, dns))
+}
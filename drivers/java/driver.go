@@ -23,6 +23,7 @@ import (
 	"github.com/hashicorp/nomad/plugins/drivers/utils"
 	"github.com/hashicorp/nomad/plugins/shared/hclspec"
 	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
+	"github.com/opencontainers/runc/libcontainer/apparmor"
 )
 
 const (
@@ -78,6 +79,9 @@ var (
 			hclspec.NewAttr("allow_caps", "list(string)", false),
 			hclspec.NewLiteral(capabilities.HCLSpecLiteral),
 		),
+		"seccomp_profile":   hclspec.NewAttr("seccomp_profile", "string", false),
+		"seccomp_violation": hclspec.NewAttr("seccomp_violation", "string", false),
+		"apparmor_profile":  hclspec.NewAttr("apparmor_profile", "string", false),
 	})
 
 	// taskConfigSpec is the hcl specification for the driver config section of
@@ -86,15 +90,18 @@ var (
 		// It's required for either `class` or `jar_path` to be set,
 		// but that's not expressable in hclspec.  Marking both as optional
 		// and setting checking explicitly later
-		"class":       hclspec.NewAttr("class", "string", false),
-		"class_path":  hclspec.NewAttr("class_path", "string", false),
-		"jar_path":    hclspec.NewAttr("jar_path", "string", false),
-		"jvm_options": hclspec.NewAttr("jvm_options", "list(string)", false),
-		"args":        hclspec.NewAttr("args", "list(string)", false),
-		"pid_mode":    hclspec.NewAttr("pid_mode", "string", false),
-		"ipc_mode":    hclspec.NewAttr("ipc_mode", "string", false),
-		"cap_add":     hclspec.NewAttr("cap_add", "list(string)", false),
-		"cap_drop":    hclspec.NewAttr("cap_drop", "list(string)", false),
+		"class":             hclspec.NewAttr("class", "string", false),
+		"class_path":        hclspec.NewAttr("class_path", "string", false),
+		"jar_path":          hclspec.NewAttr("jar_path", "string", false),
+		"jvm_options":       hclspec.NewAttr("jvm_options", "list(string)", false),
+		"args":              hclspec.NewAttr("args", "list(string)", false),
+		"pid_mode":          hclspec.NewAttr("pid_mode", "string", false),
+		"ipc_mode":          hclspec.NewAttr("ipc_mode", "string", false),
+		"cap_add":           hclspec.NewAttr("cap_add", "list(string)", false),
+		"cap_drop":          hclspec.NewAttr("cap_drop", "list(string)", false),
+		"seccomp_profile":   hclspec.NewAttr("seccomp_profile", "string", false),
+		"seccomp_violation": hclspec.NewAttr("seccomp_violation", "string", false),
+		"apparmor_profile":  hclspec.NewAttr("apparmor_profile", "string", false),
 	})
 
 	// driverCapabilities is returned by the Capabilities RPC and indicates what
@@ -117,6 +124,7 @@ func init() {
 	if runtime.GOOS == "linux" {
 		driverCapabilities.FSIsolation = drivers.FSIsolationChroot
 		driverCapabilities.MountConfigs = drivers.MountConfigSupportAll
+		driverCapabilities.GroupResources = true
 	}
 }
 
@@ -133,6 +141,17 @@ type Config struct {
 	// AllowCaps configures which Linux Capabilities are enabled for tasks
 	// running on this node.
 	AllowCaps []string `codec:"allow_caps"`
+
+	// SeccompProfile is the default seccomp profile of tasks: the host path
+	// of a profile in Docker's JSON format, "default" or "unconfined".
+	SeccompProfile string `codec:"seccomp_profile"`
+
+	// SeccompViolation is the default action on system calls not allowed by
+	// the seccomp profile of tasks: "errno" or "trap".
+	SeccompViolation string `codec:"seccomp_violation"`
+
+	// AppArmorProfile is the name of the default AppArmor profile of tasks.
+	AppArmorProfile string `codec:"apparmor_profile"`
 }
 
 func (c *Config) validate() error {
@@ -153,6 +172,21 @@ func (c *Config) validate() error {
 		return fmt.Errorf("allow_caps configured with capabilities not supported by system: %s", badCaps)
 	}
 
+	switch c.SeccompProfile {
+	case "", executor.SeccompProfileDefault, executor.SecurityProfileUnconfined:
+	default:
+		if !filepath.IsAbs(c.SeccompProfile) {
+			return fmt.Errorf("seccomp_profile must be an absolute path, %q or %q, got %q",
+				executor.SeccompProfileDefault, executor.SecurityProfileUnconfined, c.SeccompProfile)
+		}
+	}
+	if c.SeccompProfile != "" && c.SeccompProfile != executor.SecurityProfileUnconfined && !executor.SeccompSupported {
+		return fmt.Errorf("seccomp_profile is set but seccomp profiles are not supported: Nomad was built without seccomp support")
+	}
+	if err := executor.ValidateSeccompViolation(c.SeccompViolation); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -186,6 +220,19 @@ type TaskConfig struct {
 
 	// CapDrop is a set of linux capabilities to disable.
 	CapDrop []string `codec:"cap_drop"`
+
+	// SeccompProfile is the path within the task directory of a seccomp
+	// profile in Docker's JSON format, "default" or "unconfined". Defaults
+	// to the driver's profile.
+	SeccompProfile string `codec:"seccomp_profile"`
+
+	// SeccompViolation is the action on system calls not allowed by the
+	// seccomp profile: "errno" or "trap". Defaults to the driver's.
+	SeccompViolation string `codec:"seccomp_violation"`
+
+	// AppArmorProfile is the name of the AppArmor profile the task runs
+	// with. Defaults to the driver's profile.
+	AppArmorProfile string `codec:"apparmor_profile"`
 }
 
 func (tc *TaskConfig) validate() error {
@@ -212,6 +259,10 @@ func (tc *TaskConfig) validate() error {
 		return fmt.Errorf("cap_drop configured with capabilities not supported by system: %s", badDrops)
 	}
 
+	if err := executor.ValidateSeccompViolation(tc.SeccompViolation); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -342,6 +393,9 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 			fp.HealthDescription = drivers.CgroupMountEmpty
 			return fp
 		}
+
+		fp.Attributes["driver.java.seccomp"] = pstructs.NewBoolAttribute(executor.SeccompSupported)
+		fp.Attributes["driver.java.apparmor"] = pstructs.NewBoolAttribute(apparmor.IsEnabled())
 	}
 	if runtime.GOOS == "darwin" {
 		_, err := checkForMacJVM()
@@ -493,6 +547,22 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	}
 	d.logger.Debug("task capabilities", "capabilities", caps)
 
+	seccompProfile, err := executor.SeccompProfile(cfg.TaskDir().Dir, d.config.SeccompProfile, driverConfig.SeccompProfile)
+	if err != nil {
+		pluginClient.Kill()
+		return nil, nil, err
+	}
+	seccompProfile, err = executor.ApplySeccompViolation(seccompProfile, d.config.SeccompViolation, driverConfig.SeccompViolation)
+	if err != nil {
+		pluginClient.Kill()
+		return nil, nil, err
+	}
+	apparmorProfile, err := executor.AppArmorProfile(d.config.AppArmorProfile, driverConfig.AppArmorProfile)
+	if err != nil {
+		pluginClient.Kill()
+		return nil, nil, err
+	}
+
 	execCmd := &executor.ExecCommand{
 		Cmd:              absPath,
 		Args:             args,
@@ -509,6 +579,8 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		ModePID:          executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID),
 		ModeIPC:          executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC),
 		Capabilities:     caps,
+		SeccompProfile:   seccompProfile,
+		AppArmorProfile:  apparmorProfile,
 	}
 
 	ps, err := exec.Launch(execCmd)
@@ -602,6 +674,9 @@ func (d *Driver) handleWait(ctx context.Context, handle *taskHandle, ch chan *dr
 			ExitCode: ps.ExitCode,
 			Signal:   ps.Signal,
 		}
+		if ev := executor.SeccompViolationEvent(handle.taskConfig, result); ev != nil {
+			d.eventer.EmitEvent(ev)
+		}
 	}
 
 	select {
@@ -14,6 +14,7 @@ import (
 	dtestutil "github.com/hashicorp/nomad/plugins/drivers/testutils"
 
 	ctestutil "github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/drivers/shared/executor"
 	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/helper/uuid"
@@ -342,14 +343,20 @@ config {
   jar_path = "/tmp/jar.jar"
   jvm_options = ["-Xmx600"]
   args = ["arg1", "arg2"]
+  seccomp_profile = "local/seccomp.json"
+  seccomp_violation = "trap"
+  apparmor_profile = "nomad-java"
 }`
 
 	expected := &TaskConfig{
-		Class:     "java.main",
-		ClassPath: "/tmp/cp",
-		JarPath:   "/tmp/jar.jar",
-		JvmOpts:   []string{"-Xmx600"},
-		Args:      []string{"arg1", "arg2"},
+		Class:            "java.main",
+		ClassPath:        "/tmp/cp",
+		JarPath:          "/tmp/jar.jar",
+		JvmOpts:          []string{"-Xmx600"},
+		Args:             []string{"arg1", "arg2"},
+		SeccompProfile:   "local/seccomp.json",
+		SeccompViolation: "trap",
+		AppArmorProfile:  "nomad-java",
 	}
 
 	var tc *TaskConfig
@@ -452,6 +459,44 @@ func TestDriver_Config_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+
+	t.Run("seccomp_profile", func(t *testing.T) {
+		// Profiles are rejected by builds without seccomp support
+		var unsupported error
+		if !executor.SeccompSupported {
+			unsupported = errors.New("seccomp_profile is set but seccomp profiles are not supported: Nomad was built without seccomp support")
+		}
+
+		for _, tc := range []struct {
+			profile string
+			exp     error
+		}{
+			{profile: "", exp: nil},
+			{profile: "default", exp: unsupported},
+			{profile: "unconfined", exp: nil},
+			{profile: "/etc/nomad/seccomp.json", exp: unsupported},
+			{profile: "seccomp.json", exp: errors.New(`seccomp_profile must be an absolute path, "default" or "unconfined", got "seccomp.json"`)},
+		} {
+			require.Equal(t, tc.exp, (&Config{
+				DefaultModePID: "private",
+				DefaultModeIPC: "private",
+				SeccompProfile: tc.profile,
+			}).validate())
+		}
+	})
+
+	t.Run("seccomp_violation", func(t *testing.T) {
+		require.NoError(t, (&Config{
+			DefaultModePID:   "private",
+			DefaultModeIPC:   "private",
+			SeccompViolation: "trap",
+		}).validate())
+		require.EqualError(t, (&Config{
+			DefaultModePID:   "private",
+			DefaultModeIPC:   "private",
+			SeccompViolation: "kill",
+		}).validate(), `seccomp_violation must be "errno" or "trap", got "kill"`)
+	})
 }
 
 func TestDriver_TaskConfig_validate(t *testing.T) {
@@ -0,0 +1,199 @@
+package qemu
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/nomad/drivers/shared/vmnet"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// cloudInitISOName is the name of the cloud-init seed image generated in
+	// the task directory
+	cloudInitISOName = "cloud-init.iso"
+
+	// cloudInitVolumeID is the volume label of seed images looked up by the
+	// cloud-init NoCloud datasource
+	cloudInitVolumeID = "cidata"
+)
+
+// isoTools are the commands generating ISO images the driver looks up, in
+// order of preference.
+var isoTools = [][]string{
+	{"genisoimage"},
+	{"mkisofs"},
+	{"xorriso", "-as", "mkisofs"},
+}
+
+// CloudInitConfig is the configuration of the cloud-init seed image attached
+// to the VM. Its files are usually rendered by template blocks.
+type CloudInitConfig struct {
+	// UserData is the path of the user-data file.
+	UserData string `codec:"user_data"`
+
+	// MetaData is the path of the meta-data file. It's generated with the
+	// allocation ID as the instance ID if not set.
+	MetaData string `codec:"meta_data"`
+
+	// NetworkConfig is the path of the network-config file. It's generated
+	// for VMs attached to the allocation's network if not set.
+	NetworkConfig string `codec:"network_config"`
+}
+
+// netplanConfig is a cloud-init network configuration in version 2 format.
+type netplanConfig struct {
+	Version   int                        `json:"version"`
+	Ethernets map[string]netplanEthernet `json:"ethernets"`
+}
+
+type netplanEthernet struct {
+	Match       netplanMatch       `json:"match"`
+	SetName     string             `json:"set-name"`
+	Addresses   []string           `json:"addresses"`
+	Routes      []netplanRoute     `json:"routes,omitempty"`
+	Nameservers *netplanNameserver `json:"nameservers,omitempty"`
+}
+
+type netplanMatch struct {
+	MACAddress string `json:"macaddress"`
+}
+
+type netplanRoute struct {
+	To  string `json:"to"`
+	Via string `json:"via"`
+}
+
+type netplanNameserver struct {
+	Addresses []string `json:"addresses,omitempty"`
+	Search    []string `json:"search,omitempty"`
+}
+
+// cloudInitMetaData returns the meta-data of VMs which don't set it. cloud-init
+// accepts JSON in place of YAML.
+func cloudInitMetaData(cfg *drivers.TaskConfig) ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"instance-id":    cfg.AllocID,
+		"local-hostname": cfg.Name,
+	})
+}
+
+// cloudInitNetworkConfig returns the network-config configuring the guest's
+// interface with the address of the allocation.
+func cloudInitNetworkConfig(gn *vmnet.GuestNetwork, dns *drivers.DNSConfig) ([]byte, error) {
+	eth := netplanEthernet{
+		Match:     netplanMatch{MACAddress: gn.MAC.String()},
+		SetName:   "eth0",
+		Addresses: []string{gn.Addr.String()},
+	}
+	if gn.Gateway != nil {
+		eth.Routes = []netplanRoute{{To: "0.0.0.0/0", Via: gn.Gateway.String()}}
+	}
+	if dns != nil && (len(dns.Servers) > 0 || len(dns.Searches) > 0) {
+		eth.Nameservers = &netplanNameserver{
+			Addresses: dns.Servers,
+			Search:    dns.Searches,
+		}
+	}
+
+	return json.Marshal(netplanConfig{
+		Version:   2,
+		Ethernets: map[string]netplanEthernet{"eth0": eth},
+	})
+}
+
+// buildCloudInitISO generates the cloud-init seed image of the task in its
+// task directory and returns its path. The image is generated on every start
+// so that it reflects re-rendered templates.
+func (d *Driver) buildCloudInitISO(cfg *drivers.TaskConfig, ci *CloudInitConfig, gn *vmnet.GuestNetwork) (string, error) {
+	tool, err := isoTool()
+	if err != nil {
+		return "", err
+	}
+
+	staging, err := ioutil.TempDir(cfg.TaskDir().Dir, "cloud-init")
+	if err != nil {
+		return "", fmt.Errorf("failed to create cloud-init staging directory: %v", err)
+	}
+	defer os.RemoveAll(staging)
+
+	// readFile reads a file set in the cloud_init block
+	readFile := func(name, path string) ([]byte, error) {
+		if !isAllowedImagePath(d.config.ImagePaths, cfg.AllocDir, path) {
+			return nil, fmt.Errorf("cloud_init %s %q is not in the allowed paths", name, path)
+		}
+		data, err := ioutil.ReadFile(resolvePath(cfg, path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cloud_init %s: %v", name, err)
+		}
+		return data, nil
+	}
+
+	files := map[string][]byte{}
+
+	if ci.UserData == "" {
+		return "", fmt.Errorf("cloud_init requires user_data")
+	}
+	if files["user-data"], err = readFile("user_data", ci.UserData); err != nil {
+		return "", err
+	}
+
+	if ci.MetaData != "" {
+		files["meta-data"], err = readFile("meta_data", ci.MetaData)
+	} else {
+		files["meta-data"], err = cloudInitMetaData(cfg)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if ci.NetworkConfig != "" {
+		files["network-config"], err = readFile("network_config", ci.NetworkConfig)
+	} else if gn != nil {
+		files["network-config"], err = cloudInitNetworkConfig(gn, cfg.DNS)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	paths := make([]string, 0, len(files))
+	for name, data := range files {
+		path := filepath.Join(staging, name)
+		if err := ioutil.WriteFile(path, data, 0644); err != nil {
+			return "", fmt.Errorf("failed to write cloud-init %s: %v", name, err)
+		}
+		paths = append(paths, path)
+	}
+
+	iso := filepath.Join(cfg.TaskDir().Dir, cloudInitISOName)
+	args := append([]string{}, tool[1:]...)
+	args = append(args,
+		"-output", iso,
+		"-volid", cloudInitVolumeID,
+		"-joliet", "-rock",
+	)
+	args = append(args, paths...)
+
+	if out, err := exec.Command(tool[0], args...).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to generate cloud-init seed image: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return iso, nil
+}
+
+// isoTool returns the command of the first tool generating ISO images found
+// in the PATH.
+func isoTool() ([]string, error) {
+	for _, tool := range isoTools {
+		bin, err := exec.LookPath(tool[0])
+		if err != nil {
+			continue
+		}
+		return append([]string{bin}, tool[1:]...), nil
+	}
+	return nil, fmt.Errorf("cloud_init requires genisoimage, mkisofs or xorriso to be installed")
+}
@@ -0,0 +1,50 @@
+package qemu
+
+import (
+	"net"
+	"testing"
+
+	"github.com/hashicorp/nomad/drivers/shared/vmnet"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudInitNetworkConfig(t *testing.T) {
+	_, addr, err := net.ParseCIDR("172.26.64.0/20")
+	require.NoError(t, err)
+	addr.IP = net.ParseIP("172.26.64.12").To4()
+
+	gn := &vmnet.GuestNetwork{
+		MAC:     net.HardwareAddr{0x02, 0x42, 0xac, 0x1a, 0x40, 0x0c},
+		Addr:    addr,
+		Gateway: net.ParseIP("172.26.64.1"),
+	}
+
+	config, err := cloudInitNetworkConfig(gn, &drivers.DNSConfig{
+		Servers:  []string{"1.1.1.1"},
+		Searches: []string{"service.consul"},
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "version": 2,
+  "ethernets": {
+    "eth0": {
+      "match": {"macaddress": "02:42:ac:1a:40:0c"},
+      "set-name": "eth0",
+      "addresses": ["172.26.64.12/20"],
+      "routes": [{"to": "0.0.0.0/0", "via": "172.26.64.1"}],
+      "nameservers": {"addresses": ["1.1.1.1"], "search": ["service.consul"]}
+    }
+  }
+}`, string(config))
+
+	config, err = cloudInitNetworkConfig(gn, nil)
+	require.NoError(t, err)
+	require.NotContains(t, string(config), "nameservers")
+}
+
+func TestCloudInitMetaData(t *testing.T) {
+	data, err := cloudInitMetaData(&drivers.TaskConfig{AllocID: "1234", Name: "vm"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"instance-id": "1234", "local-hostname": "vm"}`, string(data))
+}
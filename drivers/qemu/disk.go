@@ -0,0 +1,219 @@
+package qemu
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// defaultDiskInterface is the interface additional disks are attached
+	// with unless set
+	defaultDiskInterface = "virtio"
+
+	// overlayFormat is the format of overlays and disks created by the driver
+	overlayFormat = "qcow2"
+)
+
+// DiskConfig is an additional disk attached to the VM
+type DiskConfig struct {
+	// ImagePath is the path of the disk image. The image is used as the
+	// backing file of a copy-on-write overlay if Overlay is set.
+	ImagePath string `codec:"image_path"`
+
+	// Size is the size of an empty disk created for the task if ImagePath
+	// isn't set, in a format accepted by qemu-img such as 10G.
+	Size string `codec:"size"`
+
+	// Format is the format of the image. QEMU probes the format if it isn't
+	// set, which prevents raw images from writing to their first sector.
+	Format string `codec:"format"`
+
+	// Interface is the interface the disk is attached with, such as virtio,
+	// ide or scsi.
+	Interface string `codec:"interface"`
+
+	ReadOnly bool `codec:"read_only"`
+	Overlay  bool `codec:"overlay"`
+}
+
+func (c *DiskConfig) validate() error {
+	switch {
+	case c.ImagePath == "" && c.Size == "":
+		return fmt.Errorf("disk requires an image_path or a size")
+	case c.ImagePath != "" && c.Size != "":
+		return fmt.Errorf("disk can't set both image_path and size")
+	case c.Overlay && c.ImagePath == "":
+		return fmt.Errorf("disk overlay requires an image_path")
+	case c.Overlay && c.ReadOnly:
+		return fmt.Errorf("disk overlay can't be read only")
+	}
+	return nil
+}
+
+// diskPath returns the path of the image of the ith disk created by the
+// driver in the task's local directory, where it persists across restarts
+// and is migrated with the allocation's ephemeral disk. The boot image is
+// disk 0.
+func diskPath(cfg *drivers.TaskConfig, i int) string {
+	return filepath.Join(cfg.TaskDir().LocalDir, fmt.Sprintf("qemu-disk-%d.%s", i, overlayFormat))
+}
+
+// resolvePath returns the absolute path of a path relative to the task
+// directory.
+func resolvePath(cfg *drivers.TaskConfig, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(cfg.TaskDir().Dir, path)
+}
+
+// prepareOverlay creates a copy-on-write overlay backed by the image at base
+// unless it already exists, and returns its path. The overlay references
+// images in the allocation directory by relative path so it keeps working
+// once the ephemeral disk is migrated.
+func prepareOverlay(cfg *drivers.TaskConfig, i int, base, format string) (string, error) {
+	overlay := diskPath(cfg, i)
+	if _, err := os.Stat(overlay); err == nil {
+		return overlay, nil
+	}
+
+	base = resolvePath(cfg, base)
+	if format == "" {
+		var err error
+		if format, err = imageFormat(base); err != nil {
+			return "", err
+		}
+	}
+
+	backing := base
+	if isAllowedImagePath(nil, cfg.AllocDir, base) {
+		rel, err := filepath.Rel(filepath.Dir(overlay), base)
+		if err != nil {
+			return "", err
+		}
+		backing = rel
+	}
+
+	if err := qemuImg("create", "-f", overlayFormat, "-b", backing, "-F", format, overlay); err != nil {
+		return "", fmt.Errorf("failed to create overlay of %s: %v", base, err)
+	}
+	return overlay, nil
+}
+
+// prepareEmptyDisk creates an empty disk of the given size unless it already
+// exists, and returns its path.
+func prepareEmptyDisk(cfg *drivers.TaskConfig, i int, size string) (string, error) {
+	path := diskPath(cfg, i)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	if err := qemuImg("create", "-f", overlayFormat, path, size); err != nil {
+		return "", fmt.Errorf("failed to create disk: %v", err)
+	}
+	return path, nil
+}
+
+// imageFormat returns the format of an image as probed by qemu-img.
+func imageFormat(path string) (string, error) {
+	bin, err := GetAbsolutePath("qemu-img")
+	if err != nil {
+		return "", err
+	}
+
+	out, err := exec.Command(bin, "info", "--output=json", path).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get format of %s: %v", path, err)
+	}
+
+	var info struct {
+		Format string `json:"format"`
+	}
+	if err := json.Unmarshal(out, &info); err != nil {
+		return "", fmt.Errorf("failed to parse qemu-img info output: %v", err)
+	}
+	return info.Format, nil
+}
+
+func qemuImg(args ...string) error {
+	bin, err := GetAbsolutePath("qemu-img")
+	if err != nil {
+		return err
+	}
+
+	out, err := exec.Command(bin, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// driveArg returns the value of the -drive argument attaching an image.
+func driveArg(path, format, iface string, readOnly bool) string {
+	// Commas are escaped by doubling them in QEMU options
+	opts := []string{"file=" + strings.ReplaceAll(path, ",", ",,")}
+	if iface != "" {
+		opts = append(opts, "if="+iface)
+	}
+	if format != "" {
+		opts = append(opts, "format="+format)
+	}
+	if readOnly {
+		opts = append(opts, "readonly=on")
+	}
+	return strings.Join(opts, ",")
+}
+
+// diskArgs prepares the boot image and additional disks of the VM, and
+// returns the arguments attaching them.
+func (d *Driver) diskArgs(cfg *drivers.TaskConfig, driverConfig *TaskConfig) ([]string, error) {
+	var args []string
+
+	if driverConfig.ImageOverlay {
+		overlay, err := prepareOverlay(cfg, 0, driverConfig.ImagePath, "")
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "-drive", driveArg(overlay, overlayFormat, "", false))
+	} else {
+		args = append(args, "-drive", "file="+driverConfig.ImagePath)
+	}
+
+	for i, disk := range driverConfig.Disks {
+		if err := disk.validate(); err != nil {
+			return nil, err
+		}
+		if disk.ImagePath != "" && !isAllowedImagePath(d.config.ImagePaths, cfg.AllocDir, disk.ImagePath) {
+			return nil, fmt.Errorf("disk image_path %q is not in the allowed paths", disk.ImagePath)
+		}
+
+		iface := disk.Interface
+		if iface == "" {
+			iface = defaultDiskInterface
+		}
+
+		var err error
+		path, format := disk.ImagePath, disk.Format
+		switch {
+		case disk.Overlay:
+			path, err = prepareOverlay(cfg, i+1, disk.ImagePath, disk.Format)
+			format = overlayFormat
+		case disk.Size != "":
+			path, err = prepareEmptyDisk(cfg, i+1, disk.Size)
+			format = overlayFormat
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, "-drive", driveArg(path, format, iface, disk.ReadOnly))
+	}
+
+	return args, nil
+}
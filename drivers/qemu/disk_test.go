@@ -0,0 +1,102 @@
+package qemu
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriveArg(t *testing.T) {
+	require.Equal(t, "file=local/disk.img", driveArg("local/disk.img", "", "", false))
+	require.Equal(t,
+		"file=/alloc/a,,b.img,if=virtio,format=raw,readonly=on",
+		driveArg("/alloc/a,b.img", "raw", "virtio", true))
+}
+
+func TestDiskConfig_Validate(t *testing.T) {
+	valid := []DiskConfig{
+		{ImagePath: "local/data.img"},
+		{ImagePath: "local/data.img", ReadOnly: true},
+		{ImagePath: "local/data.img", Overlay: true},
+		{Size: "10G"},
+	}
+	invalid := []DiskConfig{
+		{},
+		{ImagePath: "local/data.img", Size: "10G"},
+		{Size: "10G", Overlay: true},
+		{ImagePath: "local/data.img", Overlay: true, ReadOnly: true},
+	}
+
+	for _, c := range valid {
+		require.NoErrorf(t, c.validate(), "disk should be valid: %+v", c)
+	}
+	for _, c := range invalid {
+		require.Errorf(t, c.validate(), "disk should be invalid: %+v", c)
+	}
+}
+
+func TestQemuDriver_DiskArgs(t *testing.T) {
+	d := &Driver{logger: testlog.HCLogger(t)}
+	cfg := &drivers.TaskConfig{Name: "vm", AllocDir: "/opt/nomad/alloc/1234"}
+
+	args, err := d.diskArgs(cfg, &TaskConfig{
+		ImagePath: "local/linux.img",
+		Disks: []DiskConfig{
+			{ImagePath: "local/data.img", Format: "raw"},
+			{ImagePath: "/opt/nomad/alloc/1234/vm/local/iso.img", Interface: "ide", ReadOnly: true},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"-drive", "file=local/linux.img",
+		"-drive", "file=local/data.img,if=virtio,format=raw",
+		"-drive", "file=/opt/nomad/alloc/1234/vm/local/iso.img,if=ide,readonly=on",
+	}, args)
+
+	_, err = d.diskArgs(cfg, &TaskConfig{
+		ImagePath: "local/linux.img",
+		Disks:     []DiskConfig{{ImagePath: "/etc/shadow"}},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not in the allowed paths")
+}
+
+func TestQemuDriver_DiskArgs_Overlay(t *testing.T) {
+	if _, err := exec.LookPath("qemu-img"); err != nil {
+		t.Skip("qemu-img not found")
+	}
+
+	allocDir, err := ioutil.TempDir("", "qemu")
+	require.NoError(t, err)
+	defer os.RemoveAll(allocDir)
+
+	cfg := &drivers.TaskConfig{Name: "vm", AllocDir: allocDir}
+	require.NoError(t, os.MkdirAll(cfg.TaskDir().LocalDir, 0755))
+	require.NoError(t, qemuImg("create", "-f", "raw", filepath.Join(cfg.TaskDir().LocalDir, "base.img"), "1M"))
+
+	d := &Driver{logger: testlog.HCLogger(t)}
+	args, err := d.diskArgs(cfg, &TaskConfig{
+		ImagePath:    "local/base.img",
+		ImageOverlay: true,
+		Disks:        []DiskConfig{{Size: "1M"}},
+	})
+	require.NoError(t, err)
+
+	overlay, disk := diskPath(cfg, 0), diskPath(cfg, 1)
+	require.Equal(t, []string{
+		"-drive", "file=" + overlay + ",format=qcow2",
+		"-drive", "file=" + disk + ",if=virtio,format=qcow2",
+	}, args)
+
+	// The overlay references its base by relative path
+	out, err := exec.Command("qemu-img", "info", "--output=json", overlay).Output()
+	require.NoError(t, err)
+	require.Contains(t, string(out), `"backing-filename": "base.img"`)
+	require.FileExists(t, disk)
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/drivers/shared/eventer"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/drivers/shared/vmnet"
 	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/plugins/base"
@@ -36,10 +37,17 @@ const (
 	driverAttr        = "driver.qemu"
 	driverVersionAttr = "driver.qemu.version"
 
-	// Represents an ACPI shutdown request to the VM (emulates pressing a physical power button)
-	// Reference: https://en.wikibooks.org/wiki/QEMU/Monitor
-	qemuGracefulShutdownMsg = "system_powerdown\n"
-	qemuMonitorSocketName   = "qemu-monitor.sock"
+	// qemuMonitorSocketName is the name of the QMP socket in the task
+	// directory
+	qemuMonitorSocketName = "qemu-monitor.sock"
+
+	// snapshotName is the name of the snapshot saved on stop
+	snapshotName = "nomad"
+
+	// snapshotMarkerName is the name of the file in the task's local
+	// directory marking that the VM was saved on stop, and should be restored
+	// from the snapshot on start
+	snapshotMarkerName = ".qemu-snapshot"
 
 	// Maximum socket path length prior to qemu 2.10.1
 	qemuLegacyMaxMonitorPathLen = 108
@@ -96,6 +104,26 @@ var (
 		"graceful_shutdown": hclspec.NewAttr("graceful_shutdown", "bool", false),
 		"args":              hclspec.NewAttr("args", "list(string)", false),
 		"port_map":          hclspec.NewAttr("port_map", "list(map(number))", false),
+		"image_overlay":     hclspec.NewAttr("image_overlay", "bool", false),
+		"disk": hclspec.NewBlockList("disk", hclspec.NewObject(map[string]*hclspec.Spec{
+			"image_path": hclspec.NewAttr("image_path", "string", false),
+			"size":       hclspec.NewAttr("size", "string", false),
+			"format":     hclspec.NewAttr("format", "string", false),
+			"interface":  hclspec.NewAttr("interface", "string", false),
+			"read_only":  hclspec.NewAttr("read_only", "bool", false),
+			"overlay":    hclspec.NewAttr("overlay", "bool", false),
+		})),
+		"network_mode": hclspec.NewDefault(
+			hclspec.NewAttr("network_mode", "string", false),
+			hclspec.NewLiteral(`"user"`),
+		),
+		"cloud_init": hclspec.NewBlock("cloud_init", false, hclspec.NewObject(map[string]*hclspec.Spec{
+			"user_data":      hclspec.NewAttr("user_data", "string", true),
+			"meta_data":      hclspec.NewAttr("meta_data", "string", false),
+			"network_config": hclspec.NewAttr("network_config", "string", false),
+		})),
+		"snapshot_on_stop":   hclspec.NewAttr("snapshot_on_stop", "bool", false),
+		"guest_memory_stats": hclspec.NewAttr("guest_memory_stats", "bool", false),
 	})
 
 	// capabilities is returned by the Capabilities RPC and indicates what
@@ -121,6 +149,27 @@ type TaskConfig struct {
 	Args             []string           `codec:"args"`     // extra arguments to qemu executable
 	PortMap          hclutils.MapStrInt `codec:"port_map"` // A map of host port and the port name defined in the image manifest file
 	GracefulShutdown bool               `codec:"graceful_shutdown"`
+
+	// ImageOverlay boots the VM from a copy-on-write overlay of the image
+	ImageOverlay bool `codec:"image_overlay"`
+
+	// Disks are additional disks attached to the VM
+	Disks []DiskConfig `codec:"disk"`
+
+	// NetworkMode is the network the VM is attached to, either user or
+	// bridge
+	NetworkMode string `codec:"network_mode"`
+
+	// CloudInit configures the cloud-init seed image attached to the VM
+	CloudInit *CloudInitConfig `codec:"cloud_init"`
+
+	// SnapshotOnStop saves the state of the VM when the task is stopped, and
+	// restores it when the task restarts
+	SnapshotOnStop bool `codec:"snapshot_on_stop"`
+
+	// GuestMemoryStats reports the memory used by the guest, as reported by
+	// the balloon driver of the guest
+	GuestMemoryStats bool `codec:"guest_memory_stats"`
 }
 
 // TaskState is the state which is encoded in the handle returned in StartTask.
@@ -131,6 +180,12 @@ type TaskState struct {
 	TaskConfig     *drivers.TaskConfig
 	Pid            int
 	StartedAt      time.Time
+
+	// MonitorPath is the path of the QMP socket of the VM, if any
+	MonitorPath      string
+	GracefulShutdown bool
+	SnapshotOnStop   bool
+	GuestMemoryStats bool
 }
 
 // Config is the driver configuration set by SetConfig RPC call
@@ -303,14 +358,20 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 	}
 
 	h := &taskHandle{
-		exec:         execImpl,
-		pid:          taskState.Pid,
-		pluginClient: pluginClient,
-		taskConfig:   taskState.TaskConfig,
-		procState:    drivers.TaskStateRunning,
-		startedAt:    taskState.StartedAt,
-		exitResult:   &drivers.ExitResult{},
-		logger:       d.logger,
+		exec:             execImpl,
+		pid:              taskState.Pid,
+		pluginClient:     pluginClient,
+		gracefulShutdown: taskState.GracefulShutdown,
+		snapshotOnStop:   taskState.SnapshotOnStop,
+		guestMemoryStats: taskState.GuestMemoryStats,
+		taskConfig:       taskState.TaskConfig,
+		procState:        drivers.TaskStateRunning,
+		startedAt:        taskState.StartedAt,
+		exitResult:       &drivers.ExitResult{},
+		logger:           d.logger,
+	}
+	if taskState.MonitorPath != "" {
+		h.monitor = newQMPClient(taskState.MonitorPath)
 	}
 
 	d.tasks.Set(taskState.TaskConfig.ID, h)
@@ -396,6 +457,19 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		return nil, nil, fmt.Errorf("image_path is not in the allowed paths")
 	}
 
+	switch driverConfig.NetworkMode {
+	case "", networkModeUser:
+	case networkModeBridge:
+		if cfg.NetworkIsolation == nil || cfg.NetworkIsolation.Path == "" {
+			return nil, nil, fmt.Errorf("network_mode %q requires the task group to use bridge networking", networkModeBridge)
+		}
+		if len(driverConfig.PortMap) > 0 {
+			return nil, nil, fmt.Errorf("port_map is unsupported with network_mode %q", networkModeBridge)
+		}
+	default:
+		return nil, nil, fmt.Errorf("unknown network_mode %q", driverConfig.NetworkMode)
+	}
+
 	// Parse configuration arguments
 	// Create the base arguments
 	accelerator := "tcg"
@@ -419,8 +493,31 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		"-machine", "type=pc,accel=" + accelerator,
 		"-name", vmID,
 		"-m", mem,
-		"-drive", "file=" + vmPath,
-		"-nographic",
+	}
+
+	diskArgs, err := d.diskArgs(cfg, &driverConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	args = append(args, diskArgs...)
+	args = append(args, "-nographic")
+
+	// Attach the VM to the allocation's network namespace
+	var guestNet *vmnet.GuestNetwork
+	if driverConfig.NetworkMode == networkModeBridge {
+		guestNet, err = vmnet.SetupTap(cfg.NetworkIsolation.Path, tapName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to attach VM to network: %v", err)
+		}
+		args = append(args, bridgeNetworkArgs(guestNet)...)
+	}
+
+	if driverConfig.CloudInit != nil {
+		iso, err := d.buildCloudInitISO(cfg, driverConfig.CloudInit, guestNet)
+		if err != nil {
+			return nil, nil, err
+		}
+		args = append(args, "-drive", driveArg(iso, "raw", "virtio", true))
 	}
 
 	var netdevArgs []string
@@ -434,13 +531,16 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		}
 	}
 
+	// The QMP socket is used to manage the virtual machine, and is required
+	// by the features which depend on it
+	requireMonitor := driverConfig.GracefulShutdown || driverConfig.SnapshotOnStop || driverConfig.GuestMemoryStats
+
 	var monitorPath string
-	if driverConfig.GracefulShutdown {
-		if runtime.GOOS == "windows" {
-			return nil, nil, errors.New("QEMU graceful shutdown is unsupported on the Windows platform")
+	if runtime.GOOS == "windows" {
+		if requireMonitor {
+			return nil, nil, errors.New("QEMU graceful shutdown, snapshots and guest stats are unsupported on the Windows platform")
 		}
-		// This socket will be used to manage the virtual machine (for example,
-		// to perform graceful shutdowns)
+	} else {
 		taskDir := filepath.Join(cfg.AllocDir, cfg.Name)
 		fingerPrint := d.buildFingerprint()
 		if fingerPrint.Attributes == nil {
@@ -449,10 +549,30 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		monitorPath, err = d.getMonitorPath(taskDir, fingerPrint)
 		if err != nil {
 			d.logger.Debug("could not get qemu monitor path", "error", err)
-			return nil, nil, err
+			if requireMonitor {
+				return nil, nil, err
+			}
+		} else {
+			d.logger.Debug("got monitor path", "monitorPath", monitorPath)
+			args = append(args, "-qmp", fmt.Sprintf("unix:%s,server,nowait", monitorPath))
+		}
+	}
+
+	if driverConfig.GuestMemoryStats {
+		args = append(args, "-device", "virtio-balloon-pci,id=balloon0")
+	}
+
+	// Restore the VM from the snapshot saved when it was last stopped. The
+	// marker is removed so that the VM boots from its disks if it fails
+	// before being saved again.
+	if driverConfig.SnapshotOnStop {
+		marker := filepath.Join(cfg.TaskDir().LocalDir, snapshotMarkerName)
+		if _, err := os.Stat(marker); err == nil {
+			args = append(args, "-loadvm", snapshotName)
+			if err := os.Remove(marker); err != nil {
+				return nil, nil, fmt.Errorf("failed to remove snapshot marker: %v", err)
+			}
 		}
-		d.logger.Debug("got monitor path", "monitorPath", monitorPath)
-		args = append(args, "-monitor", fmt.Sprintf("unix:%s,server,nowait", monitorPath))
 	}
 
 	// Add pass through arguments to qemu executable. A user can specify
@@ -468,7 +588,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	// still reach out to the world, but without port mappings it is effectively
 	// firewalled
 	protocols := []string{"udp", "tcp"}
-	if len(cfg.Resources.NomadResources.Networks) > 0 {
+	if driverConfig.NetworkMode != networkModeBridge && len(cfg.Resources.NomadResources.Networks) > 0 {
 		// Loop through the port map and construct the hostfwd string, to map
 		// reserved ports to the ports listenting in the VM
 		// Ex: hostfwd=tcp::22000-:22,hostfwd=tcp::80-:8080
@@ -543,21 +663,30 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	d.logger.Debug("started new QemuVM", "ID", vmID)
 
 	h := &taskHandle{
-		exec:         execImpl,
-		pid:          ps.Pid,
-		monitorPath:  monitorPath,
-		pluginClient: pluginClient,
-		taskConfig:   cfg,
-		procState:    drivers.TaskStateRunning,
-		startedAt:    time.Now().Round(time.Millisecond),
-		logger:       d.logger,
+		exec:             execImpl,
+		pid:              ps.Pid,
+		pluginClient:     pluginClient,
+		gracefulShutdown: driverConfig.GracefulShutdown,
+		snapshotOnStop:   driverConfig.SnapshotOnStop,
+		guestMemoryStats: driverConfig.GuestMemoryStats,
+		taskConfig:       cfg,
+		procState:        drivers.TaskStateRunning,
+		startedAt:        time.Now().Round(time.Millisecond),
+		logger:           d.logger,
+	}
+	if monitorPath != "" {
+		h.monitor = newQMPClient(monitorPath)
 	}
 
 	qemuDriverState := TaskState{
-		ReattachConfig: pstructs.ReattachConfigFromGoPlugin(pluginClient.ReattachConfig()),
-		Pid:            ps.Pid,
-		TaskConfig:     cfg,
-		StartedAt:      h.startedAt,
+		ReattachConfig:   pstructs.ReattachConfigFromGoPlugin(pluginClient.ReattachConfig()),
+		Pid:              ps.Pid,
+		TaskConfig:       cfg,
+		StartedAt:        h.startedAt,
+		MonitorPath:      monitorPath,
+		GracefulShutdown: driverConfig.GracefulShutdown,
+		SnapshotOnStop:   driverConfig.SnapshotOnStop,
+		GuestMemoryStats: driverConfig.GuestMemoryStats,
 	}
 
 	if err := handle.SetDriverState(&qemuDriverState); err != nil {
@@ -575,6 +704,10 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		driverNetwork = &drivers.DriverNetwork{
 			PortMap: driverConfig.PortMap,
 		}
+	} else if guestNet != nil {
+		driverNetwork = &drivers.DriverNetwork{
+			IP: guestNet.Addr.IP.String(),
+		}
 	}
 	return handle, driverNetwork, nil
 }
@@ -597,9 +730,14 @@ func (d *Driver) StopTask(taskID string, timeout time.Duration, signal string) e
 		return drivers.ErrTaskNotFound
 	}
 
-	// Attempt a graceful shutdown only if it was configured in the job
-	if handle.monitorPath != "" {
-		if err := sendQemuShutdown(d.logger, handle.monitorPath, handle.pid); err != nil {
+	// Save the state of the VM so the task resumes where it left off, or
+	// attempt a graceful shutdown if either was configured in the job
+	if handle.snapshotOnStop {
+		if err := handle.snapshot(timeout); err != nil {
+			d.logger.Warn("failed to snapshot VM", "pid", handle.pid, "error", err)
+		}
+	} else if handle.gracefulShutdown {
+		if err := handle.shutdown(); err != nil {
 			d.logger.Debug("error sending graceful shutdown ", "pid", handle.pid, "error", err)
 		}
 	}
@@ -654,7 +792,11 @@ func (d *Driver) TaskStats(ctx context.Context, taskID string, interval time.Dur
 		return nil, drivers.ErrTaskNotFound
 	}
 
-	return handle.exec.Stats(ctx, interval)
+	ch, err := handle.exec.Stats(ctx, interval)
+	if err != nil || !handle.guestMemoryStats || handle.monitor == nil {
+		return ch, err
+	}
+	return handle.withGuestStats(ctx, ch, interval), nil
 }
 
 func (d *Driver) TaskEvents(ctx context.Context) (<-chan *drivers.TaskEvent, error) {
@@ -725,23 +867,3 @@ func (d *Driver) getMonitorPath(dir string, fingerPrint *drivers.Fingerprint) (s
 	}
 	return fullSocketPath, nil
 }
-
-// sendQemuShutdown attempts to issue an ACPI power-off command via the qemu
-// monitor
-func sendQemuShutdown(logger hclog.Logger, monitorPath string, userPid int) error {
-	if monitorPath == "" {
-		return errors.New("monitorPath not set")
-	}
-	monitorSocket, err := net.Dial("unix", monitorPath)
-	if err != nil {
-		logger.Warn("could not connect to qemu monitor", "pid", userPid, "monitorPath", monitorPath, "error", err)
-		return err
-	}
-	defer monitorSocket.Close()
-	logger.Debug("sending graceful shutdown command to qemu monitor socket", "monitor_path", monitorPath, "pid", userPid)
-	_, err = monitorSocket.Write([]byte(qemuGracefulShutdownMsg))
-	if err != nil {
-		logger.Warn("failed to send shutdown message", "shutdown message", qemuGracefulShutdownMsg, "monitorPath", monitorPath, "userPid", userPid, "error", err)
-	}
-	return err
-}
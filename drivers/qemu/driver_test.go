@@ -406,6 +406,23 @@ config {
     https = 443
   }
   graceful_shutdown = true
+  image_overlay = true
+  disk {
+    image_path = "local/data.img"
+    format = "raw"
+    interface = "scsi"
+    read_only = true
+  }
+  disk {
+    size = "10G"
+  }
+  network_mode = "bridge"
+  cloud_init {
+    user_data = "local/user-data"
+    meta_data = "local/meta-data"
+  }
+  snapshot_on_stop = true
+  guest_memory_stats = true
 }`
 
 	expected := &TaskConfig{
@@ -417,6 +434,25 @@ config {
 			"https": 443,
 		},
 		GracefulShutdown: true,
+		ImageOverlay:     true,
+		Disks: []DiskConfig{
+			{
+				ImagePath: "local/data.img",
+				Format:    "raw",
+				Interface: "scsi",
+				ReadOnly:  true,
+			},
+			{
+				Size: "10G",
+			},
+		},
+		NetworkMode: "bridge",
+		CloudInit: &CloudInitConfig{
+			UserData: "local/user-data",
+			MetaData: "local/meta-data",
+		},
+		SnapshotOnStop:   true,
+		GuestMemoryStats: true,
 	}
 
 	var tc *TaskConfig
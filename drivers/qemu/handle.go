@@ -2,6 +2,10 @@ package qemu
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"strconv"
 	"sync"
 	"time"
@@ -17,7 +21,12 @@ type taskHandle struct {
 	pid          int
 	pluginClient *plugin.Client
 	logger       hclog.Logger
-	monitorPath  string
+
+	// monitor is the client of the VM's QMP socket, if any
+	monitor          *qmpClient
+	gracefulShutdown bool
+	snapshotOnStop   bool
+	guestMemoryStats bool
 
 	// stateLock syncs access to all fields below
 	stateLock sync.RWMutex
@@ -77,3 +86,92 @@ func (h *taskHandle) run() {
 
 	// TODO: detect if the taskConfig OOMed
 }
+
+// shutdown sends an ACPI shutdown request to the VM.
+func (h *taskHandle) shutdown() error {
+	if h.monitor == nil {
+		return errors.New("qemu monitor not available")
+	}
+	h.logger.Debug("sending graceful shutdown command to qemu monitor", "pid", h.pid)
+	return h.monitor.systemPowerdown(context.Background())
+}
+
+// snapshot pauses the VM and saves its state so that it's restored when the
+// task restarts.
+func (h *taskHandle) snapshot(timeout time.Duration) error {
+	if h.monitor == nil {
+		return errors.New("qemu monitor not available")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	h.logger.Debug("saving VM snapshot", "pid", h.pid, "name", snapshotName)
+	if err := h.monitor.saveSnapshot(ctx, snapshotName); err != nil {
+		return err
+	}
+
+	marker := filepath.Join(h.taskConfig.TaskDir().LocalDir, snapshotMarkerName)
+	if err := ioutil.WriteFile(marker, []byte(snapshotName), 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot marker: %v", err)
+	}
+	return nil
+}
+
+// withGuestStats adds the memory used by the guest, as reported by its
+// balloon driver, to the stats of the QEMU process.
+func (h *taskHandle) withGuestStats(ctx context.Context, ch <-chan *drivers.TaskResourceUsage, interval time.Duration) <-chan *drivers.TaskResourceUsage {
+	if err := h.monitor.setGuestStatsInterval(ctx, interval); err != nil {
+		h.logger.Debug("failed to enable guest memory stats", "error", err)
+	}
+
+	out := make(chan *drivers.TaskResourceUsage)
+	go func() {
+		defer close(out)
+		for usage := range ch {
+			if err := h.addGuestStats(ctx, usage); err != nil {
+				h.logger.Trace("failed to get guest memory stats", "error", err)
+			}
+
+			select {
+			case out <- usage:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func (h *taskHandle) addGuestStats(ctx context.Context, usage *drivers.TaskResourceUsage) error {
+	if usage.ResourceUsage == nil || usage.ResourceUsage.MemoryStats == nil {
+		return nil
+	}
+
+	stats, err := h.monitor.guestStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Stats the guest doesn't report are -1, and all are 0 until the guest
+	// reports them for the first time
+	total, available := stats.Stats["stat-total-memory"], stats.Stats["stat-available-memory"]
+	if available < 0 {
+		available = stats.Stats["stat-free-memory"]
+	}
+	if total <= 0 || available < 0 || available > total {
+		return nil
+	}
+
+	ms := usage.ResourceUsage.MemoryStats
+	ms.Usage = uint64(total - available)
+
+	measured := make([]string, 0, len(ms.Measured)+1)
+	for _, m := range ms.Measured {
+		if m != "Usage" {
+			measured = append(measured, m)
+		}
+	}
+	ms.Measured = append(measured, "Usage")
+	return nil
+}
@@ -0,0 +1,31 @@
+package qemu
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/nomad/drivers/shared/vmnet"
+)
+
+const (
+	// networkModeUser attaches the VM to a user mode network stack, which
+	// forwards the ports of the port_map
+	networkModeUser = "user"
+
+	// networkModeBridge attaches the VM to the allocation's network
+	// namespace, where it takes over the allocation's address
+	networkModeBridge = "bridge"
+
+	// tapName is the name of the tap device created in the allocation's
+	// network namespace in bridge mode
+	tapName = "tap0"
+)
+
+// bridgeNetworkArgs returns the arguments attaching a virtio-net interface to
+// the tap device in the allocation's network namespace. The interface has
+// the MAC of the namespace's interface so the guest gets its traffic.
+func bridgeNetworkArgs(gn *vmnet.GuestNetwork) []string {
+	return []string{
+		"-netdev", fmt.Sprintf("tap,id=net0,ifname=%s,script=no,downscript=no", tapName),
+		"-device", fmt.Sprintf("virtio-net-pci,netdev=net0,mac=%s", gn.MAC),
+	}
+}
@@ -0,0 +1,185 @@
+package qemu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// qmpRequestTimeout bounds how long a QMP command may take unless the
+	// caller sets a deadline
+	qmpRequestTimeout = 5 * time.Second
+
+	// qmpBalloonPath is the QOM path of the balloon device added to VMs
+	// reporting guest memory stats
+	qmpBalloonPath = "/machine/peripheral/balloon0"
+)
+
+// qmpCommand is a command sent to the QEMU Machine Protocol server.
+type qmpCommand struct {
+	Execute   string      `json:"execute"`
+	Arguments interface{} `json:"arguments,omitempty"`
+}
+
+// qmpResponse is a message received from the QEMU Machine Protocol server.
+// Asynchronous events are received between responses and ignored.
+type qmpResponse struct {
+	Return json.RawMessage `json:"return"`
+	Error  *qmpError       `json:"error"`
+	Event  string          `json:"event"`
+}
+
+type qmpError struct {
+	Class string `json:"class"`
+	Desc  string `json:"desc"`
+}
+
+func (e *qmpError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Class, e.Desc)
+}
+
+// guestStats is the value of the guest-stats property of the balloon device.
+// Stats the guest doesn't report are -1.
+type guestStats struct {
+	Stats      map[string]int64 `json:"stats"`
+	LastUpdate int64            `json:"last-update"`
+}
+
+// qmpClient is a client of the QEMU Machine Protocol server a VM serves on a
+// unix socket. QEMU only serves one client at a time, so each command is sent
+// on a new connection and commands are serialized.
+type qmpClient struct {
+	socketPath string
+	lock       sync.Mutex
+}
+
+func newQMPClient(socketPath string) *qmpClient {
+	return &qmpClient{socketPath: socketPath}
+}
+
+// execute runs a command and decodes its return value into out if it isn't
+// nil.
+func (c *qmpClient) execute(ctx context.Context, command string, args, out interface{}) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, qmpRequestTimeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", c.socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to connect to qemu monitor: %v", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	dec := json.NewDecoder(conn)
+	enc := json.NewEncoder(conn)
+
+	// The server greets new clients, which have to negotiate capabilities
+	// before running commands
+	var greeting map[string]json.RawMessage
+	if err := dec.Decode(&greeting); err != nil {
+		return fmt.Errorf("failed to read qemu monitor greeting: %v", err)
+	}
+	if _, ok := greeting["QMP"]; !ok {
+		return fmt.Errorf("unexpected qemu monitor greeting")
+	}
+	if err := c.roundTrip(enc, dec, qmpCommand{Execute: "qmp_capabilities"}, nil); err != nil {
+		return fmt.Errorf("failed to negotiate qemu monitor capabilities: %v", err)
+	}
+
+	if err := c.roundTrip(enc, dec, qmpCommand{Execute: command, Arguments: args}, out); err != nil {
+		return fmt.Errorf("qemu monitor command %s failed: %v", command, err)
+	}
+	return nil
+}
+
+func (c *qmpClient) roundTrip(enc *json.Encoder, dec *json.Decoder, cmd qmpCommand, out interface{}) error {
+	if err := enc.Encode(cmd); err != nil {
+		return err
+	}
+
+	for {
+		var resp qmpResponse
+		if err := dec.Decode(&resp); err != nil {
+			return err
+		}
+		if resp.Event != "" {
+			continue
+		}
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if out != nil {
+			return json.Unmarshal(resp.Return, out)
+		}
+		return nil
+	}
+}
+
+// systemPowerdown sends an ACPI shutdown request to the VM, which emulates
+// pressing a physical power button.
+func (c *qmpClient) systemPowerdown(ctx context.Context) error {
+	return c.execute(ctx, "system_powerdown", nil, nil)
+}
+
+// saveSnapshot pauses the VM and saves its state in a snapshot of its disks,
+// which must all be writable qcow2 images.
+func (c *qmpClient) saveSnapshot(ctx context.Context, name string) error {
+	if err := c.execute(ctx, "stop", nil, nil); err != nil {
+		return err
+	}
+
+	// savevm is only available as a human monitor command in the QEMU
+	// versions supported, and reports errors in its output
+	var output string
+	args := map[string]string{"command-line": "savevm " + name}
+	if err := c.execute(ctx, "human-monitor-command", args, &output); err != nil {
+		return err
+	}
+	if output = strings.TrimSpace(output); output != "" {
+		return fmt.Errorf("failed to save snapshot: %s", output)
+	}
+	return nil
+}
+
+// setGuestStatsInterval sets how often the guest reports its memory stats to
+// the balloon device.
+func (c *qmpClient) setGuestStatsInterval(ctx context.Context, interval time.Duration) error {
+	secs := int(interval.Seconds())
+	if secs < 1 {
+		secs = 1
+	}
+	args := map[string]interface{}{
+		"path":     qmpBalloonPath,
+		"property": "guest-stats-polling-interval",
+		"value":    secs,
+	}
+	return c.execute(ctx, "qom-set", args, nil)
+}
+
+// guestStats returns the last memory stats reported by the guest.
+func (c *qmpClient) guestStats(ctx context.Context) (*guestStats, error) {
+	args := map[string]interface{}{
+		"path":     qmpBalloonPath,
+		"property": "guest-stats",
+	}
+	var stats guestStats
+	if err := c.execute(ctx, "qom-get", args, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
@@ -0,0 +1,192 @@
+package qemu
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQMP serves the QEMU Machine Protocol on a unix socket, replying to
+// commands with the results set in replies.
+type fakeQMP struct {
+	listener net.Listener
+
+	lock     sync.Mutex
+	replies  map[string]interface{}
+	commands []qmpCommand
+}
+
+func newFakeQMP(t *testing.T, replies map[string]interface{}) (*fakeQMP, string) {
+	dir, err := ioutil.TempDir("", "qmp")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, qemuMonitorSocketName)
+	l, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	f := &fakeQMP{listener: l, replies: replies}
+	go f.serve()
+	return f, path
+}
+
+func (f *fakeQMP) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		f.handle(conn)
+	}
+}
+
+func (f *fakeQMP) handle(conn net.Conn) {
+	defer conn.Close()
+
+	enc := json.NewEncoder(conn)
+	dec := json.NewDecoder(conn)
+	enc.Encode(map[string]interface{}{
+		"QMP": map[string]interface{}{"version": map[string]interface{}{}, "capabilities": []string{}},
+	})
+
+	for {
+		var cmd qmpCommand
+		if err := dec.Decode(&cmd); err != nil {
+			return
+		}
+
+		f.lock.Lock()
+		reply, ok := f.replies[cmd.Execute]
+		if cmd.Execute != "qmp_capabilities" {
+			f.commands = append(f.commands, cmd)
+		}
+		f.lock.Unlock()
+
+		// Events may be received before the response
+		enc.Encode(map[string]interface{}{"event": "RTC_CHANGE", "data": map[string]interface{}{}})
+
+		switch {
+		case cmd.Execute == "qmp_capabilities":
+			enc.Encode(map[string]interface{}{"return": map[string]interface{}{}})
+		case !ok:
+			enc.Encode(map[string]interface{}{
+				"error": qmpError{Class: "CommandNotFound", Desc: "The command " + cmd.Execute + " has not been found"},
+			})
+		default:
+			enc.Encode(map[string]interface{}{"return": reply})
+		}
+	}
+}
+
+func (f *fakeQMP) executed() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var names []string
+	for _, cmd := range f.commands {
+		names = append(names, cmd.Execute)
+	}
+	return names
+}
+
+func TestQMPClient_Execute(t *testing.T) {
+	f, path := newFakeQMP(t, map[string]interface{}{
+		"system_powerdown":      map[string]interface{}{},
+		"stop":                  map[string]interface{}{},
+		"human-monitor-command": "Error: Device 'ide1-cd0' is writable but does not support snapshots\r\n",
+	})
+	c := newQMPClient(path)
+	ctx := context.Background()
+
+	require.NoError(t, c.systemPowerdown(ctx))
+
+	err := c.saveSnapshot(ctx, snapshotName)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not support snapshots")
+
+	err = c.execute(ctx, "query-unknown", nil, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "CommandNotFound")
+
+	require.Equal(t, []string{"system_powerdown", "stop", "human-monitor-command", "query-unknown"}, f.executed())
+}
+
+func TestQemuDriver_Snapshot(t *testing.T) {
+	_, path := newFakeQMP(t, map[string]interface{}{
+		"stop":                  map[string]interface{}{},
+		"human-monitor-command": "",
+	})
+
+	allocDir, err := ioutil.TempDir("", "qemu")
+	require.NoError(t, err)
+	defer os.RemoveAll(allocDir)
+
+	cfg := &drivers.TaskConfig{Name: "vm", AllocDir: allocDir}
+	require.NoError(t, os.MkdirAll(cfg.TaskDir().LocalDir, 0755))
+
+	h := &taskHandle{
+		monitor:    newQMPClient(path),
+		taskConfig: cfg,
+		logger:     testlog.HCLogger(t),
+	}
+	require.NoError(t, h.snapshot(5*time.Second))
+	require.FileExists(t, filepath.Join(cfg.TaskDir().LocalDir, snapshotMarkerName))
+}
+
+func TestQemuDriver_GuestStats(t *testing.T) {
+	f, path := newFakeQMP(t, map[string]interface{}{
+		"qom-set": map[string]interface{}{},
+		"qom-get": map[string]interface{}{
+			"stats": map[string]int64{
+				"stat-total-memory":     512 * 1024 * 1024,
+				"stat-free-memory":      256 * 1024 * 1024,
+				"stat-available-memory": 384 * 1024 * 1024,
+			},
+			"last-update": 1600000000,
+		},
+	})
+
+	h := &taskHandle{
+		monitor: newQMPClient(path),
+		logger:  testlog.HCLogger(t),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan *drivers.TaskResourceUsage, 1)
+	measured := []string{"RSS", "Swap"}
+	ch <- &drivers.TaskResourceUsage{
+		ResourceUsage: &drivers.ResourceUsage{
+			MemoryStats: &drivers.MemoryStats{RSS: 600 * 1024 * 1024, Measured: measured},
+		},
+	}
+	close(ch)
+
+	out := h.withGuestStats(ctx, ch, 10*time.Second)
+	select {
+	case usage := <-out:
+		ms := usage.ResourceUsage.MemoryStats
+		require.Equal(t, uint64(600*1024*1024), ms.RSS)
+		require.Equal(t, uint64(128*1024*1024), ms.Usage)
+		require.Equal(t, []string{"RSS", "Swap", "Usage"}, ms.Measured)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout receiving stats")
+	}
+
+	// The measured stats shared by tasks aren't modified
+	require.Equal(t, []string{"RSS", "Swap"}, measured)
+	require.Equal(t, []string{"qom-set", "qom-get"}, f.executed())
+	require.EqualValues(t, 10, f.commands[0].Arguments.(map[string]interface{})["value"])
+}
@@ -32,7 +32,20 @@ type grpcExecutorClient struct {
 
 func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
 	ctx := context.Background()
-	req := &proto.LaunchRequest{
+	resp, err := c.client.Launch(ctx, launchRequestFromCommand(cmd))
+	if err != nil {
+		return nil, err
+	}
+
+	ps, err := processStateFromProto(resp.Process)
+	if err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+func launchRequestFromCommand(cmd *ExecCommand) *proto.LaunchRequest {
+	return &proto.LaunchRequest{
 		Cmd:                cmd.Cmd,
 		Args:               cmd.Args,
 		Resources:          drivers.ResourcesToProto(cmd.Resources),
@@ -50,17 +63,11 @@ func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
 		DefaultPidMode:     cmd.ModePID,
 		DefaultIpcMode:     cmd.ModeIPC,
 		Capabilities:       cmd.Capabilities,
+		UserNamespace:      userNamespaceToProto(cmd.UserNamespace),
+		SeccompProfile:     cmd.SeccompProfile,
+		ApparmorProfile:    cmd.AppArmorProfile,
+		Checkpoint:         cmd.Checkpoint,
 	}
-	resp, err := c.client.Launch(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	ps, err := processStateFromProto(resp.Process)
-	if err != nil {
-		return nil, err
-	}
-	return ps, nil
 }
 
 func (c *grpcExecutorClient) Wait(ctx context.Context) (*ProcessState, error) {
@@ -265,3 +272,25 @@ func (c *grpcExecutorClient) execStreaming(ctx context.Context,
 		}
 	}
 }
+
+func (c *grpcExecutorClient) Checkpoint(dir string) error {
+	ctx := context.Background()
+	if _, err := c.client.Checkpoint(ctx, &proto.CheckpointRequest{Dir: dir}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *grpcExecutorClient) Restore(cmd *ExecCommand, dir string) (*ProcessState, error) {
+	ctx := context.Background()
+	req := &proto.RestoreRequest{
+		Launch: launchRequestFromCommand(cmd),
+		Dir:    dir,
+	}
+	resp, err := c.client.Restore(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return processStateFromProto(resp.Process)
+}
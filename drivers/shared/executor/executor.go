@@ -88,6 +88,14 @@ type Executor interface {
 
 	ExecStreaming(ctx context.Context, cmd []string, tty bool,
 		stream drivers.ExecTaskStream) error
+
+	// Checkpoint saves the state of the user process to dir with CRIU and
+	// stops it. The process must have been launched with Checkpoint set.
+	Checkpoint(dir string) error
+
+	// Restore launches a user process configured by the given ExecCommand
+	// from the checkpoint in dir instead of running its command.
+	Restore(command *ExecCommand, dir string) (*ProcessState, error)
 }
 
 // ExecCommand holds the user command, args, and other isolation related
@@ -154,6 +162,31 @@ type ExecCommand struct {
 
 	// Capabilities are the linux capabilities to be enabled by the task driver.
 	Capabilities []string
+
+	// UserNamespace, if set, runs the task in a user namespace mapping its
+	// root to an unprivileged range of host IDs.
+	UserNamespace *UserNamespace
+
+	// SeccompProfile is a seccomp profile in Docker's JSON format the task
+	// runs with, if set.
+	SeccompProfile []byte
+
+	// AppArmorProfile is the name of the AppArmor profile the task runs
+	// with, if set.
+	AppArmorProfile string
+
+	// Checkpoint prepares the process to be checkpointed, by connecting its
+	// stdout and stderr through pipes a restored process can inherit rather
+	// than opening the log fifos directly.
+	Checkpoint bool
+}
+
+// UserNamespace maps the IDs 0 to Size-1 of a task's user namespace to the
+// host IDs starting at HostUID and HostGID.
+type UserNamespace struct {
+	HostUID uint32
+	HostGID uint32
+	Size    uint32
 }
 
 // SetWriters sets the writer for the process stdout and stderr. This should
@@ -446,6 +479,18 @@ func (e *UniversalExecutor) ExecStreaming(ctx context.Context, command []string,
 	return execHelper.run(ctx, tty, stream)
 }
 
+// Checkpoint is not supported as processes aren't isolated enough for CRIU
+// to restore them.
+func (e *UniversalExecutor) Checkpoint(dir string) error {
+	return fmt.Errorf("checkpointing requires an isolated executor")
+}
+
+// Restore is not supported as processes aren't isolated enough for CRIU to
+// restore them.
+func (e *UniversalExecutor) Restore(command *ExecCommand, dir string) (*ProcessState, error) {
+	return nil, fmt.Errorf("restoring requires an isolated executor")
+}
+
 // Wait waits until a process has exited and returns it's exitcode and errors
 func (e *UniversalExecutor) Wait(ctx context.Context) (*ProcessState, error) {
 	select {
@@ -16,6 +16,12 @@ func NewExecutorWithIsolation(logger hclog.Logger) Executor {
 	return NewExecutor(logger)
 }
 
+func NewOCIRuntimeExecutor(logger hclog.Logger, _ string) Executor {
+	logger = logger.Named("executor")
+	logger.Error("OCI runtime executor is not supported on this platform, using default")
+	return NewExecutor(logger)
+}
+
 func (e *UniversalExecutor) configureResourceContainer(_ int) error { return nil }
 
 func (e *UniversalExecutor) getAllPids() (map[int]*nomadPid, error) {
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/armon/circbuf"
+	securejoin "github.com/cyphar/filepath-securejoin"
 	"github.com/hashicorp/consul-template/signals"
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/allocdir"
@@ -88,6 +89,20 @@ func NewExecutorWithIsolation(logger hclog.Logger) Executor {
 
 // Launch creates a new container in libcontainer and starts a new process with it
 func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, error) {
+	return l.launch(command, "")
+}
+
+// Restore creates a new container in libcontainer and restores the processes
+// checkpointed to dir in it
+func (l *LibcontainerExecutor) Restore(command *ExecCommand, dir string) (*ProcessState, error) {
+	// The restored process inherits the pipes CRIU is started with
+	command.Checkpoint = true
+	return l.launch(command, dir)
+}
+
+// launch creates the container of the command and either starts its process
+// or, if checkpointDir is set, restores it from the checkpoint.
+func (l *LibcontainerExecutor) launch(command *ExecCommand, checkpointDir string) (*ProcessState, error) {
 	l.logger.Trace("preparing to launch command", "command", command.Cmd, "args", strings.Join(command.Args, " "))
 
 	if command.Resources == nil {
@@ -123,32 +138,11 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	}
 	l.container = container
 
-	// Look up the binary path and make it executable
-	absPath, err := lookupTaskBin(command)
-
+	path, err := containerTaskBin(command)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := makeExecutable(absPath); err != nil {
-		return nil, err
-	}
-
-	path := absPath
-
-	// Ensure that the path is contained in the chroot, and find it relative to the container
-	rel, err := filepath.Rel(command.TaskDir, path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to determine relative path base=%q target=%q: %v", command.TaskDir, path, err)
-	}
-
-	// Turn relative-to-chroot path into absolute path to avoid
-	// libcontainer trying to resolve the binary using $PATH.
-	// Do *not* use filepath.Join as it will translate ".."s returned by
-	// filepath.Rel. Prepending "/" will cause the path to be rooted in the
-	// chroot which is the desired behavior.
-	path = "/" + rel
-
 	combined := append([]string{path}, command.Args...)
 	stdout, err := command.Stdout()
 	if err != nil {
@@ -158,8 +152,13 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	if err != nil {
 		return nil, err
 	}
+	if command.Checkpoint {
+		// Hide the fifos, which are outside of the container and can't be
+		// restored, so that the process is connected to them through pipes
+		stdout, stderr = pipeWriter{stdout}, pipeWriter{stderr}
+	}
 
-	l.logger.Debug("launching", "command", command.Cmd, "args", strings.Join(command.Args, " "))
+	l.logger.Debug("launching", "command", command.Cmd, "args", strings.Join(command.Args, " "), "checkpoint", checkpointDir)
 
 	// the task process will be started by the container
 	process := &libcontainer.Process{
@@ -180,7 +179,12 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	l.systemCpuStats = stats.NewCpuStats()
 
 	// Starts the task
-	if err := container.Run(process); err != nil {
+	if checkpointDir == "" {
+		err = container.Run(process)
+	} else {
+		err = container.Restore(process, criuOpts(checkpointDir))
+	}
+	if err != nil {
 		container.Destroy()
 		return nil, err
 	}
@@ -204,6 +208,39 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	}, nil
 }
 
+// Checkpoint saves the state of the container's processes to dir with CRIU,
+// which stops them.
+func (l *LibcontainerExecutor) Checkpoint(dir string) error {
+	if l.container == nil {
+		return fmt.Errorf("no process to checkpoint")
+	}
+	if !l.command.Checkpoint {
+		return fmt.Errorf("process was not launched to be checkpointed")
+	}
+	if l.command.ModePID != IsolationModePrivate {
+		return fmt.Errorf("checkpointing requires a private PID namespace")
+	}
+	if l.command.UserNamespace != nil {
+		return fmt.Errorf("checkpointing processes in user namespaces is not supported")
+	}
+
+	l.logger.Debug("checkpointing", "dir", dir)
+	return l.container.Checkpoint(criuOpts(dir))
+}
+
+func criuOpts(dir string) *libcontainer.CriuOpts {
+	return &libcontainer.CriuOpts{
+		ImagesDirectory: dir,
+		FileLocks:       true,
+	}
+}
+
+// pipeWriter hides the file of a writer from libcontainer, which then
+// connects the process to it through a pipe.
+type pipeWriter struct {
+	io.WriteCloser
+}
+
 func (l *LibcontainerExecutor) getAllPids() (map[int]*nomadPid, error) {
 	pids, err := l.container.Processes()
 	if err != nil {
@@ -330,7 +367,31 @@ func (l *LibcontainerExecutor) Shutdown(signal string, grace time.Duration) erro
 
 // UpdateResources updates the resource isolation with new values to be enforced
 func (l *LibcontainerExecutor) UpdateResources(resources *drivers.Resources) error {
-	return nil
+	if l.container == nil {
+		return fmt.Errorf("no process to update")
+	}
+	if !l.command.ResourceLimits {
+		return fmt.Errorf("resources are not limited")
+	}
+	if resources == nil || resources.NomadResources == nil {
+		return nil
+	}
+
+	// Copy the cgroup config so the container's config is only changed if
+	// the new resources are applied
+	cfg := l.container.Config()
+	cg := *cfg.Cgroups
+	res := *cg.Resources
+	cg.Resources = &res
+	cfg.Cgroups = &cg
+
+	if err := setCgroupResources(cfg.Cgroups.Resources, resources.NomadResources); err != nil {
+		return err
+	}
+
+	l.logger.Debug("updating resources",
+		"cpu_shares", res.CpuShares, "memory", res.Memory, "memory_reservation", res.MemoryReservation)
+	return l.container.Set(cfg)
 }
 
 // Version returns the api version of the executor
@@ -576,6 +637,7 @@ func configureNamespaces(pidMode, ipcMode string) lconfigs.Namespaces {
 // * dedicated mount points namespace, but shares the PID, User, domain, network namespaces with host
 // * small subset of devices (e.g. stdout/stderr/stdin, tty, shm, pts); default to using the same set of devices as Docker
 // * some special filesystems: `/proc`, `/sys`.  Some case is given to avoid exec escaping or setting malicious values through them.
+// * optionally, a user namespace mapping the task's root to unprivileged host IDs
 func configureIsolation(cfg *lconfigs.Config, command *ExecCommand) error {
 	defaultMountFlags := syscall.MS_NOEXEC | syscall.MS_NOSUID | syscall.MS_NODEV
 
@@ -657,6 +719,21 @@ func configureIsolation(cfg *lconfigs.Config, command *ExecCommand) error {
 		},
 	}
 
+	if command.UserNamespace != nil {
+		if err := configureUserNamespace(cfg, command); err != nil {
+			return err
+		}
+	}
+
+	if len(command.SeccompProfile) > 0 {
+		seccomp, err := seccompConfig(command.SeccompProfile, command.Capabilities)
+		if err != nil {
+			return fmt.Errorf("invalid seccomp profile: %v", err)
+		}
+		cfg.Seccomp = seccomp
+	}
+	cfg.AppArmorProfile = command.AppArmorProfile
+
 	if len(command.Mounts) > 0 {
 		cfg.Mounts = append(cfg.Mounts, cmdMounts(command.Mounts)...)
 	}
@@ -664,6 +741,46 @@ func configureIsolation(cfg *lconfigs.Config, command *ExecCommand) error {
 	return nil
 }
 
+// configureUserNamespace runs the task in a new user namespace, mapping its
+// root to the unprivileged host IDs of command.UserNamespace.
+//
+// Filesystems tied to a namespace can only be mounted from a user namespace
+// owning it, so /sys, whose network namespace is never owned by the task,
+// and /dev/mqueue, if the IPC namespace is shared with the host, are bind
+// mounted from the host instead.
+func configureUserNamespace(cfg *lconfigs.Config, command *ExecCommand) error {
+	userns := command.UserNamespace
+	if command.ModePID != IsolationModePrivate {
+		return fmt.Errorf("user namespaces require a private PID namespace")
+	}
+
+	cfg.Namespaces = append(cfg.Namespaces, lconfigs.Namespace{Type: lconfigs.NEWUSER})
+	cfg.UidMappings = []lconfigs.IDMap{{
+		ContainerID: 0,
+		HostID:      int(userns.HostUID),
+		Size:        int(userns.Size),
+	}}
+	cfg.GidMappings = []lconfigs.IDMap{{
+		ContainerID: 0,
+		HostID:      int(userns.HostGID),
+		Size:        int(userns.Size),
+	}}
+
+	bindFlags := unix.MS_BIND | unix.MS_REC | unix.MS_NOEXEC | unix.MS_NOSUID | unix.MS_NODEV
+	for _, m := range cfg.Mounts {
+		switch {
+		case m.Device == "sysfs":
+			m.Source, m.Device = "/sys", "bind"
+			m.Flags = bindFlags | unix.MS_RDONLY
+		case m.Device == "mqueue" && command.ModeIPC != IsolationModePrivate:
+			m.Source, m.Device = "/dev/mqueue", "bind"
+			m.Flags = bindFlags
+		}
+	}
+
+	return nil
+}
+
 func configureCgroups(cfg *lconfigs.Config, command *ExecCommand) error {
 
 	// If resources are not limited then manually create cgroups needed
@@ -672,14 +789,41 @@ func configureCgroups(cfg *lconfigs.Config, command *ExecCommand) error {
 	}
 
 	id := uuid.Generate()
-	cfg.Cgroups.Path = filepath.Join("/", defaultCgroupParent, id)
+	cfg.Cgroups.Path = filepath.Join("/", cgroupParent(command), id)
 
 	if command.Resources == nil || command.Resources.NomadResources == nil {
 		return nil
 	}
 
+	if err := setCgroupResources(cfg.Cgroups.Resources, command.Resources.NomadResources); err != nil {
+		return err
+	}
+
+	if command.Resources.LinuxResources != nil && command.Resources.LinuxResources.CpusetCgroupPath != "" {
+		cfg.Hooks = lconfigs.Hooks{
+			lconfigs.CreateRuntime: lconfigs.HookList{
+				newSetCPUSetCgroupHook(command.Resources.LinuxResources.CpusetCgroupPath),
+			},
+		}
+	}
+
+	return nil
+}
+
+// cgroupParent returns the cgroup the task is nested under, which is the
+// cgroup of its allocation if the tasks of the group share their CPU shares
+// and memory ceiling.
+func cgroupParent(command *ExecCommand) string {
+	if command.Resources != nil && command.Resources.LinuxResources != nil && command.Resources.LinuxResources.CgroupParent != "" {
+		return command.Resources.LinuxResources.CgroupParent
+	}
+	return defaultCgroupParent
+}
+
+// setCgroupResources sets the memory limits and CPU shares of the task
+// resources on the cgroup resources.
+func setCgroupResources(r *lconfigs.Resources, res *structs.AllocatedTaskResources) error {
 	// Total amount of memory allowed to consume
-	res := command.Resources.NomadResources
 	memHard, memSoft := res.Memory.MemoryMaxMB, res.Memory.MemoryMB
 	if memHard <= 0 {
 		memHard = res.Memory.MemoryMB
@@ -687,12 +831,12 @@ func configureCgroups(cfg *lconfigs.Config, command *ExecCommand) error {
 	}
 
 	if memHard > 0 {
-		cfg.Cgroups.Resources.Memory = memHard * 1024 * 1024
-		cfg.Cgroups.Resources.MemoryReservation = memSoft * 1024 * 1024
+		r.Memory = memHard * 1024 * 1024
+		r.MemoryReservation = memSoft * 1024 * 1024
 
 		// Disable swap to avoid issues on the machine
 		var memSwappiness uint64
-		cfg.Cgroups.Resources.MemorySwappiness = &memSwappiness
+		r.MemorySwappiness = &memSwappiness
 	}
 
 	cpuShares := res.Cpu.CpuShares
@@ -701,17 +845,8 @@ func configureCgroups(cfg *lconfigs.Config, command *ExecCommand) error {
 	}
 
 	// Set the relative CPU shares for this cgroup, and convert for cgroupv2
-	cfg.Cgroups.Resources.CpuShares = uint64(cpuShares)
-	cfg.Cgroups.Resources.CpuWeight = cgroups.ConvertCPUSharesToCgroupV2Value(uint64(cpuShares))
-
-	if command.Resources.LinuxResources != nil && command.Resources.LinuxResources.CpusetCgroupPath != "" {
-		cfg.Hooks = lconfigs.Hooks{
-			lconfigs.CreateRuntime: lconfigs.HookList{
-				newSetCPUSetCgroupHook(command.Resources.LinuxResources.CpusetCgroupPath),
-			},
-		}
-	}
-
+	r.CpuShares = uint64(cpuShares)
+	r.CpuWeight = cgroups.ConvertCPUSharesToCgroupV2Value(uint64(cpuShares))
 	return nil
 }
 
@@ -839,6 +974,39 @@ func cmdMounts(mounts []*drivers.MountConfig) []*lconfigs.Mount {
 	return r
 }
 
+// containerTaskBin looks up the task's binary, makes it executable and
+// returns its path within the task directory the container is rooted in.
+func containerTaskBin(command *ExecCommand) (string, error) {
+	// Look up the binary path and make it executable
+	absPath, err := lookupTaskBin(command)
+	if err != nil {
+		return "", err
+	}
+
+	// Ensure that the path is contained in the chroot, and find it relative to the container
+	rel, err := filepath.Rel(command.TaskDir, absPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine relative path base=%q target=%q: %v", command.TaskDir, absPath, err)
+	}
+
+	// Resolve symlinks within the chroot so that absolute links, such as
+	// those found in container images, don't point at the host.
+	resolved, err := securejoin.SecureJoin(command.TaskDir, rel)
+	if err != nil {
+		return "", err
+	}
+	if err := makeExecutable(resolved); err != nil {
+		return "", err
+	}
+
+	// Turn relative-to-chroot path into absolute path to avoid
+	// libcontainer trying to resolve the binary using $PATH.
+	// Do *not* use filepath.Join as it will translate ".."s returned by
+	// filepath.Rel. Prepending "/" will cause the path to be rooted in the
+	// chroot which is the desired behavior.
+	return "/" + rel, nil
+}
+
 // lookupTaskBin finds the file `bin` in taskDir/local, taskDir in that order, then performs
 // a PATH search inside taskDir. It returns an absolute path. See also executor.lookupBin
 func lookupTaskBin(command *ExecCommand) (string, error) {
@@ -848,13 +1016,13 @@ func lookupTaskBin(command *ExecCommand) (string, error) {
 	// Check in the local directory
 	localDir := filepath.Join(taskDir, allocdir.TaskLocal)
 	local := filepath.Join(localDir, bin)
-	if _, err := os.Stat(local); err == nil {
+	if _, err := statInRoot(taskDir, local); err == nil {
 		return local, nil
 	}
 
 	// Check at the root of the task's directory
 	root := filepath.Join(taskDir, bin)
-	if _, err := os.Stat(root); err == nil {
+	if _, err := statInRoot(taskDir, root); err == nil {
 		return root, nil
 	}
 
@@ -876,7 +1044,7 @@ func lookPathIn(path string, root string, bin string) (string, error) {
 			dir = "."
 		}
 		path := filepath.Join(root, dir, bin)
-		f, err := os.Stat(path)
+		f, err := statInRoot(root, path)
 		if err != nil {
 			continue
 		}
@@ -887,6 +1055,20 @@ func lookPathIn(path string, root string, bin string) (string, error) {
 	return "", fmt.Errorf("file %s not found under path %s", bin, root)
 }
 
+// statInRoot is like os.Stat but resolves symlinks in path as if root was
+// the root of the filesystem.
+func statInRoot(root, path string) (os.FileInfo, error) {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := securejoin.SecureJoin(root, rel)
+	if err != nil {
+		return nil, err
+	}
+	return os.Stat(resolved)
+}
+
 func newSetCPUSetCgroupHook(cgroupPath string) lconfigs.Hook {
 	return lconfigs.NewFunctionHook(func(state *specs.State) error {
 		return cgroups.WriteCgroupProc(cgroupPath, state.Pid)
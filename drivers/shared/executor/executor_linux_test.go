@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -13,10 +14,12 @@ import (
 	"time"
 
 	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/client/testutil"
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
 	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	tu "github.com/hashicorp/nomad/testutil"
@@ -250,6 +253,83 @@ passwd`
 	}, func(err error) { t.Error(err) })
 }
 
+func TestExecutor_UserNamespace(t *testing.T) {
+	t.Parallel()
+	r := require.New(t)
+	testutil.ExecCompatible(t)
+
+	testExecCmd := testExecutorCommandWithChroot(t)
+	execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+	execCmd.Cmd = "/bin/cat"
+	execCmd.Args = []string{"/proc/self/uid_map", "/proc/self/gid_map"}
+	defer allocDir.Destroy()
+
+	execCmd.ResourceLimits = true
+	execCmd.ModePID = "private"
+	execCmd.ModeIPC = "private"
+	execCmd.UserNamespace = &UserNamespace{HostUID: 100000, HostGID: 200000, Size: 65536}
+
+	executor := NewExecutorWithIsolation(testlog.HCLogger(t))
+	defer executor.Shutdown("SIGKILL", 0)
+
+	ps, err := executor.Launch(execCmd)
+	r.NoError(err)
+	r.NotZero(ps.Pid)
+
+	estate, err := executor.Wait(context.Background())
+	r.NoError(err)
+	r.Zero(estate.ExitCode)
+
+	expected := []string{"0", "100000", "65536", "0", "200000", "65536"}
+	tu.WaitForResult(func() (bool, error) {
+		maps := strings.Fields(testExecCmd.stdout.String())
+		if !reflect.DeepEqual(maps, expected) {
+			return false, fmt.Errorf("unexpected ID maps: want %v; got %v", expected, maps)
+		}
+		return true, nil
+	}, func(err error) { t.Error(err) })
+}
+
+func TestExecutor_configureUserNamespace(t *testing.T) {
+	command := &ExecCommand{
+		TaskDir:       "/tmp/task",
+		ModePID:       "private",
+		ModeIPC:       "host",
+		UserNamespace: &UserNamespace{HostUID: 100000, HostGID: 200000, Size: 65536},
+	}
+
+	cfg := &lconfigs.Config{}
+	require.NoError(t, configureIsolation(cfg, command))
+
+	require.Contains(t, cfg.Namespaces, lconfigs.Namespace{Type: lconfigs.NEWUSER})
+	require.Equal(t, []lconfigs.IDMap{{ContainerID: 0, HostID: 100000, Size: 65536}}, cfg.UidMappings)
+	require.Equal(t, []lconfigs.IDMap{{ContainerID: 0, HostID: 200000, Size: 65536}}, cfg.GidMappings)
+
+	// sysfs and the host's mqueue can't be mounted from the user namespace
+	mounts := map[string]*lconfigs.Mount{}
+	for _, m := range cfg.Mounts {
+		mounts[m.Destination] = m
+	}
+	require.Equal(t, "bind", mounts["/sys"].Device)
+	require.Equal(t, "/sys", mounts["/sys"].Source)
+	require.NotZero(t, mounts["/sys"].Flags&unix.MS_RDONLY)
+	require.Equal(t, "bind", mounts["/dev/mqueue"].Device)
+	require.Equal(t, "proc", mounts["/proc"].Device)
+
+	command.ModeIPC = "private"
+	cfg = &lconfigs.Config{}
+	require.NoError(t, configureIsolation(cfg, command))
+	for _, m := range cfg.Mounts {
+		if m.Destination == "/dev/mqueue" {
+			require.Equal(t, "mqueue", m.Device)
+		}
+	}
+
+	// The task can't mount /proc without its own PID namespace
+	command.ModePID = "host"
+	require.Error(t, configureIsolation(&lconfigs.Config{}, command))
+}
+
 // TestExecutor_CgroupPaths asserts that process starts with independent cgroups
 // hierarchy created for this process
 func TestExecutor_CgroupPaths(t *testing.T) {
@@ -305,6 +385,72 @@ func TestExecutor_CgroupPaths(t *testing.T) {
 	}, func(err error) { t.Error(err) })
 }
 
+// TestExecutor_UpdateResources asserts that the CPU shares and memory limits
+// of a running task's cgroup are updated
+func TestExecutor_UpdateResources(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	testutil.ExecCompatible(t)
+
+	testExecCmd := testExecutorCommandWithChroot(t)
+	execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+	execCmd.Cmd = "/bin/sleep"
+	execCmd.Args = []string{"30"}
+	defer allocDir.Destroy()
+
+	execCmd.ResourceLimits = true
+
+	executor := NewExecutorWithIsolation(testlog.HCLogger(t))
+	defer executor.Shutdown("SIGKILL", 0)
+
+	ps, err := executor.Launch(execCmd)
+	require.NoError(err)
+	require.NotZero(ps.Pid)
+
+	resources := execCmd.Resources.NomadResources.Copy()
+	resources.Cpu.CpuShares = 1000
+	resources.Memory.MemoryMB = 512
+	require.NoError(executor.UpdateResources(&drivers.Resources{NomadResources: resources}))
+
+	cfg := executor.(*LibcontainerExecutor).container.Config()
+	require.EqualValues(1000, cfg.Cgroups.Resources.CpuShares)
+	require.EqualValues(512*1024*1024, cfg.Cgroups.Resources.Memory)
+
+	// CPU shares below the minimum are rejected
+	resources.Cpu.CpuShares = 1
+	require.Error(executor.UpdateResources(&drivers.Resources{NomadResources: resources}))
+}
+
+// TestExecutor_CgroupParent asserts that the cgroup of a task is nested
+// under the cgroup parent of its resources
+func TestExecutor_CgroupParent(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	testutil.ExecCompatible(t)
+
+	testExecCmd := testExecutorCommandWithChroot(t)
+	execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+	execCmd.Cmd = "/bin/sleep"
+	execCmd.Args = []string{"30"}
+	defer allocDir.Destroy()
+
+	parent := filepath.Join(defaultCgroupParent, uuid.Generate())
+	defer cgutil.DestroyAllocCgroup(parent)
+
+	execCmd.ResourceLimits = true
+	execCmd.Resources.LinuxResources = &drivers.LinuxResources{CgroupParent: parent}
+
+	executor := NewExecutorWithIsolation(testlog.HCLogger(t))
+	defer executor.Shutdown("SIGKILL", 0)
+
+	ps, err := executor.Launch(execCmd)
+	require.NoError(err)
+	require.NotZero(ps.Pid)
+
+	cfg := executor.(*LibcontainerExecutor).container.Config()
+	require.Equal(parent, filepath.Dir(cfg.Cgroups.Path))
+}
+
 // TestExecutor_CgroupPaths asserts that all cgroups created for a task
 // are destroyed on shutdown
 func TestExecutor_CgroupPathsAreDestroyed(t *testing.T) {
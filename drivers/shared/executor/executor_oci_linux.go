@@ -0,0 +1,466 @@
+//go:build linux
+// +build linux
+
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/armon/circbuf"
+	"github.com/creack/pty"
+	"github.com/hashicorp/consul-template/signals"
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/stats"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	shelpers "github.com/hashicorp/nomad/helper/stats"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/opencontainers/runc/types"
+)
+
+var (
+	// ExecutorOCIRuntimeMeasuredMemStats is the list of memory stats captured
+	// by the executor from an OCI runtime's events
+	ExecutorOCIRuntimeMeasuredMemStats = []string{"RSS", "Cache", "Swap", "Usage", "Max Usage"}
+
+	// ExecutorOCIRuntimeMeasuredCpuStats is the list of CPU stats captured by
+	// the executor from an OCI runtime's events
+	ExecutorOCIRuntimeMeasuredCpuStats = []string{"System Mode", "User Mode", "Throttled Periods", "Throttled Time", "Percent"}
+)
+
+// OCIRuntimeExecutor implements an Executor that runs the task in a
+// container managed by an external OCI runtime, such as runsc, crun or
+// youki, through the runtime's command line interface. The container is
+// configured by an OCI config.json generated from the ExecCommand.
+type OCIRuntimeExecutor struct {
+	id      string
+	runtime string
+	command *ExecCommand
+
+	logger hclog.Logger
+
+	// root is the directory the runtime keeps the state of containers in
+	root string
+
+	// bundle is the directory of the container's config.json
+	bundle string
+
+	totalCpuStats  *stats.CpuStats
+	userCpuStats   *stats.CpuStats
+	systemCpuStats *stats.CpuStats
+
+	runtimeCmd     *exec.Cmd
+	userProcExited chan interface{}
+	exitState      *ProcessState
+}
+
+// NewOCIRuntimeExecutor returns an Executor that launches tasks with the OCI
+// runtime binary at runtimePath.
+func NewOCIRuntimeExecutor(logger hclog.Logger, runtimePath string) Executor {
+	logger = logger.Named("oci_runtime_executor").With("runtime", runtimePath)
+	if err := shelpers.Init(); err != nil {
+		logger.Error("unable to initialize stats", "error", err)
+	}
+	return &OCIRuntimeExecutor{
+		id:             strings.ReplaceAll(uuid.Generate(), "-", "_"),
+		runtime:        runtimePath,
+		logger:         logger,
+		totalCpuStats:  stats.NewCpuStats(),
+		userCpuStats:   stats.NewCpuStats(),
+		systemCpuStats: stats.NewCpuStats(),
+	}
+}
+
+// Launch writes the container's config.json and runs it with the runtime
+func (o *OCIRuntimeExecutor) Launch(command *ExecCommand) (*ProcessState, error) {
+	o.logger.Trace("preparing to launch command", "command", command.Cmd, "args", strings.Join(command.Args, " "))
+
+	if command.Resources == nil {
+		command.Resources = &drivers.Resources{
+			NomadResources: &structs.AllocatedTaskResources{},
+		}
+	}
+	o.command = command
+
+	containerDir := path.Join(command.TaskDir, "../alloc/container")
+	o.root = filepath.Join(containerDir, "runtime")
+	o.bundle = filepath.Join(containerDir, o.id)
+	if err := os.MkdirAll(o.bundle, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create bundle directory: %v", err)
+	}
+
+	bin, err := containerTaskBin(command)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := newOCISpec(command, bin, filepath.Join("/", cgroupParent(command), o.id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure container(%s): %v", o.id, err)
+	}
+	config, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode container config: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(o.bundle, "config.json"), config, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write container config: %v", err)
+	}
+
+	stdout, err := command.Stdout()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := command.Stderr()
+	if err != nil {
+		return nil, err
+	}
+
+	o.logger.Debug("launching", "command", command.Cmd, "args", strings.Join(command.Args, " "))
+
+	// run keeps the runtime in the foreground until the container exits, and
+	// exits with the container's exit code
+	cmd := o.runtimeCommand(context.Background(), "run", "--bundle", o.bundle, o.id)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	o.totalCpuStats = stats.NewCpuStats()
+	o.userCpuStats = stats.NewCpuStats()
+	o.systemCpuStats = stats.NewCpuStats()
+
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(o.bundle)
+		return nil, fmt.Errorf("failed to start runtime: %v", err)
+	}
+	o.runtimeCmd = cmd
+
+	o.userProcExited = make(chan interface{})
+	go o.wait()
+
+	return &ProcessState{
+		Pid:      cmd.Process.Pid,
+		ExitCode: -1,
+		Time:     time.Now(),
+	}, nil
+}
+
+// runtimeCommand returns a command invoking the runtime with the given
+// arguments and the executor's state directory.
+func (o *OCIRuntimeExecutor) runtimeCommand(ctx context.Context, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, o.runtime, append([]string{"--root", o.root}, args...)...)
+}
+
+// runtimeOutput runs the runtime with the given arguments and returns its
+// output, including stderr in the error if it fails.
+func (o *OCIRuntimeExecutor) runtimeOutput(ctx context.Context, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := o.runtimeCommand(ctx, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %v: %s", filepath.Base(o.runtime), args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// Wait waits until a process has exited and returns it's exitcode and errors
+func (o *OCIRuntimeExecutor) Wait(ctx context.Context) (*ProcessState, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-o.userProcExited:
+		return o.exitState, nil
+	}
+}
+
+func (o *OCIRuntimeExecutor) wait() {
+	defer close(o.userProcExited)
+
+	err := o.runtimeCmd.Wait()
+	o.command.Close()
+
+	// run exits with the container's exit code, which is the signal number
+	// plus 128 if the container was killed by a signal
+	const exitSignalBase = 128
+	var exitCode, signal int
+	if err != nil {
+		exitErr, ok := err.(*exec.ExitError)
+		if !ok {
+			o.logger.Error("failed to call wait on runtime", "error", err)
+			o.exitState = &ProcessState{Pid: 0, ExitCode: 1, Time: time.Now()}
+			return
+		}
+
+		exitCode = exitErr.ExitCode()
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			exitCode = exitSignalBase + int(status.Signal())
+		}
+		if exitCode > exitSignalBase {
+			signal = exitCode - exitSignalBase
+		}
+	}
+
+	if _, err := o.runtimeOutput(context.Background(), "delete", "--force", o.id); err != nil {
+		o.logger.Debug("failed to delete container", "error", err)
+	}
+	os.RemoveAll(o.bundle)
+
+	o.exitState = &ProcessState{
+		Pid:      o.runtimeCmd.Process.Pid,
+		ExitCode: exitCode,
+		Signal:   signal,
+		Time:     time.Now(),
+	}
+}
+
+// Shutdown stops the container, first with the given signal and after the
+// grace period by killing it, and deletes it.
+func (o *OCIRuntimeExecutor) Shutdown(signal string, grace time.Duration) error {
+	if o.runtimeCmd == nil {
+		return nil
+	}
+
+	select {
+	case <-o.userProcExited:
+		return nil
+	default:
+	}
+
+	if grace > 0 {
+		if signal == "" {
+			signal = "SIGINT"
+		}
+
+		sig, ok := signals.SignalLookup[signal]
+		if !ok {
+			return fmt.Errorf("error unknown signal given for shutdown: %s", signal)
+		}
+
+		if err := o.Signal(sig); err != nil {
+			return err
+		}
+
+		select {
+		case <-o.userProcExited:
+			return nil
+		case <-time.After(grace):
+		}
+	}
+
+	if err := o.Signal(syscall.SIGKILL); err != nil {
+		return err
+	}
+
+	select {
+	case <-o.userProcExited:
+		return nil
+	case <-time.After(time.Second * 15):
+		return fmt.Errorf("process failed to exit after 15 seconds")
+	}
+}
+
+// UpdateResources isn't supported by the OCI runtime executor, whose
+// container resources are set by the runtime when it is created.
+func (o *OCIRuntimeExecutor) UpdateResources(resources *drivers.Resources) error {
+	return fmt.Errorf("updating resources isn't supported by OCI runtimes")
+}
+
+// Version returns the api version of the executor
+func (o *OCIRuntimeExecutor) Version() (*ExecutorVersion, error) {
+	return &ExecutorVersion{Version: ExecutorVersionLatest}, nil
+}
+
+// Stats returns the resource statistics the runtime reports for the
+// container
+func (o *OCIRuntimeExecutor) Stats(ctx context.Context, interval time.Duration) (<-chan *cstructs.TaskResourceUsage, error) {
+	ch := make(chan *cstructs.TaskResourceUsage)
+	go o.handleStats(ch, ctx, interval)
+	return ch, nil
+}
+
+func (o *OCIRuntimeExecutor) handleStats(ch chan *cstructs.TaskResourceUsage, ctx context.Context, interval time.Duration) {
+	defer close(ch)
+	timer := time.NewTimer(0)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-timer.C:
+			timer.Reset(interval)
+		}
+
+		out, err := o.runtimeOutput(ctx, "events", "--stats", o.id)
+		if err != nil {
+			o.logger.Warn("error collecting stats", "error", err)
+			return
+		}
+
+		s, err := parseRuntimeStats(out)
+		if err != nil {
+			o.logger.Warn("error collecting stats", "error", err)
+			return
+		}
+
+		taskResUsage := o.resourceUsage(s, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case ch <- taskResUsage:
+		}
+	}
+}
+
+// parseRuntimeStats decodes the event printed by `events --stats`.
+func parseRuntimeStats(out []byte) (*types.Stats, error) {
+	var event struct {
+		Type string       `json:"type"`
+		Data *types.Stats `json:"data"`
+	}
+	if err := json.Unmarshal(out, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode stats event: %v", err)
+	}
+	if event.Type != "stats" || event.Data == nil {
+		return nil, fmt.Errorf("unexpected event %q", event.Type)
+	}
+	return event.Data, nil
+}
+
+func (o *OCIRuntimeExecutor) resourceUsage(s *types.Stats, ts time.Time) *cstructs.TaskResourceUsage {
+	ms := &cstructs.MemoryStats{
+		RSS:        s.Memory.Raw["rss"],
+		Cache:      s.Memory.Cache,
+		Swap:       s.Memory.Swap.Usage,
+		MappedFile: s.Memory.Raw["mapped_file"],
+		Usage:      s.Memory.Usage.Usage,
+		MaxUsage:   s.Memory.Usage.Max,
+		Measured:   ExecutorOCIRuntimeMeasuredMemStats,
+	}
+
+	totalPercent := o.totalCpuStats.Percent(float64(s.CPU.Usage.Total))
+	cs := &cstructs.CpuStats{
+		SystemMode:       o.systemCpuStats.Percent(float64(s.CPU.Usage.Kernel)),
+		UserMode:         o.userCpuStats.Percent(float64(s.CPU.Usage.User)),
+		Percent:          totalPercent,
+		ThrottledPeriods: s.CPU.Throttling.ThrottledPeriods,
+		ThrottledTime:    s.CPU.Throttling.ThrottledTime,
+		TotalTicks:       o.systemCpuStats.TicksConsumed(totalPercent),
+		Measured:         ExecutorOCIRuntimeMeasuredCpuStats,
+	}
+
+	return &cstructs.TaskResourceUsage{
+		ResourceUsage: &cstructs.ResourceUsage{
+			MemoryStats: ms,
+			CpuStats:    cs,
+		},
+		Timestamp: ts.UTC().UnixNano(),
+	}
+}
+
+// Signal sends a signal to the container's init process
+func (o *OCIRuntimeExecutor) Signal(s os.Signal) error {
+	sig, ok := s.(syscall.Signal)
+	if !ok {
+		return fmt.Errorf("unsupported signal %v", s)
+	}
+	_, err := o.runtimeOutput(context.Background(), "kill", o.id, strconv.Itoa(int(sig)))
+	return err
+}
+
+// Exec starts an additional process inside the container
+func (o *OCIRuntimeExecutor) Exec(deadline time.Time, cmd string, args []string) ([]byte, int, error) {
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	// Capture output
+	buf, _ := circbuf.NewBuffer(int64(drivers.CheckBufSize))
+
+	c := o.runtimeCommand(ctx, append([]string{"exec", o.id, cmd}, args...)...)
+	c.Stdout = buf
+	c.Stderr = buf
+
+	err := c.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, 0, context.DeadlineExceeded
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return buf.Bytes(), exitErr.ExitCode(), nil
+	} else if err != nil {
+		return nil, 0, err
+	}
+	return buf.Bytes(), 0, nil
+}
+
+func (o *OCIRuntimeExecutor) ExecStreaming(ctx context.Context, command []string, tty bool,
+	stream drivers.ExecTaskStream) error {
+
+	if len(command) == 0 {
+		return fmt.Errorf("command is required")
+	}
+
+	args := []string{"exec"}
+	if tty {
+		args = append(args, "--tty")
+	}
+	cmd := o.runtimeCommand(ctx, append(append(args, o.id), command...)...)
+
+	execHelper := &execHelper{
+		logger: o.logger,
+
+		newTerminal: func() (func() (*os.File, error), *os.File, error) {
+			pty, tty, err := pty.Open()
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return func() (*os.File, error) { return pty, nil }, tty, err
+		},
+		setTTY: func(tty *os.File) error {
+			cmd.SysProcAttr = sessionCmdAttr(tty)
+
+			cmd.Stdin = tty
+			cmd.Stdout = tty
+			cmd.Stderr = tty
+			return nil
+		},
+		setIO: func(stdin io.Reader, stdout, stderr io.Writer) error {
+			cmd.Stdin = stdin
+			cmd.Stdout = stdout
+			cmd.Stderr = stderr
+			return nil
+		},
+		processStart: cmd.Start,
+		processWait: func() (*os.ProcessState, error) {
+			err := cmd.Wait()
+			return cmd.ProcessState, err
+		},
+	}
+
+	return execHelper.run(ctx, tty, stream)
+}
+
+// Checkpoint isn't supported by the OCI runtime executor
+func (o *OCIRuntimeExecutor) Checkpoint(dir string) error {
+	return fmt.Errorf("checkpointing is not supported with OCI runtime %q", o.runtime)
+}
+
+// Restore isn't supported by the OCI runtime executor
+func (o *OCIRuntimeExecutor) Restore(command *ExecCommand, dir string) (*ProcessState, error) {
+	return nil, fmt.Errorf("restoring checkpoints is not supported with OCI runtime %q", o.runtime)
+}
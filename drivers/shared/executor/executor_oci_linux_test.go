@@ -0,0 +1,169 @@
+package executor
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutor_newOCISpec(t *testing.T) {
+	taskDir, err := ioutil.TempDir("", "nomad-oci")
+	require.NoError(t, err)
+	defer os.RemoveAll(taskDir)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(taskDir, "etc"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(taskDir, "etc/passwd"),
+		[]byte("root:x:0:0:root:/root:/bin/sh\nnobody:x:65534:65534:nobody:/nonexistent:/bin/false\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(taskDir, "etc/group"),
+		[]byte("root:x:0:\nnogroup:x:65534:\nusers:x:100:nobody\n"), 0644))
+
+	command := &ExecCommand{
+		Args:    []string{"-c", "sleep 1"},
+		Env:     []string{"FOO=bar"},
+		User:    "nobody",
+		TaskDir: taskDir,
+		ModePID: IsolationModePrivate,
+		ModeIPC: IsolationModeHost,
+		NetworkIsolation: &drivers.NetworkIsolationSpec{
+			Path: "/var/run/netns/test",
+		},
+		Mounts: []*drivers.MountConfig{{
+			TaskPath: "/data",
+			HostPath: "/srv/data",
+			Readonly: true,
+		}},
+		Capabilities:    []string{"CAP_CHOWN"},
+		AppArmorProfile: "nomad-tasks",
+		ResourceLimits:  true,
+		Resources: &drivers.Resources{
+			NomadResources: &structs.AllocatedTaskResources{
+				Cpu: structs.AllocatedCpuResources{
+					CpuShares:     500,
+					ReservedCores: []uint16{1, 3},
+				},
+				Memory: structs.AllocatedMemoryResources{
+					MemoryMB:    256,
+					MemoryMaxMB: 512,
+				},
+			},
+		},
+	}
+
+	spec, err := newOCISpec(command, "/bin/sh", "/nomad/test")
+	require.NoError(t, err)
+
+	require.Equal(t, taskDir, spec.Root.Path)
+	require.Equal(t, []string{"/bin/sh", "-c", "sleep 1"}, spec.Process.Args)
+	require.Equal(t, []string{"FOO=bar"}, spec.Process.Env)
+	require.Equal(t, specs.User{UID: 65534, GID: 65534, AdditionalGids: []uint32{100}}, spec.Process.User)
+	require.Equal(t, []string{"CAP_CHOWN"}, spec.Process.Capabilities.Bounding)
+	require.Equal(t, "nomad-tasks", spec.Process.ApparmorProfile)
+
+	require.Equal(t, []specs.LinuxNamespace{
+		{Type: specs.MountNamespace},
+		{Type: specs.PIDNamespace},
+		{Type: specs.NetworkNamespace, Path: "/var/run/netns/test"},
+	}, spec.Linux.Namespaces)
+	require.Contains(t, spec.Mounts, specs.Mount{
+		Source:      "/srv/data",
+		Destination: "/data",
+		Type:        "bind",
+		Options:     []string{"bind", "rprivate", "ro"},
+	})
+
+	require.Equal(t, "/nomad/test", spec.Linux.CgroupsPath)
+	require.Equal(t, int64(512*1024*1024), *spec.Linux.Resources.Memory.Limit)
+	require.Equal(t, int64(256*1024*1024), *spec.Linux.Resources.Memory.Reservation)
+	require.Equal(t, uint64(500), *spec.Linux.Resources.CPU.Shares)
+	require.Equal(t, "1,3", spec.Linux.Resources.CPU.Cpus)
+	require.False(t, spec.Linux.Resources.Devices[0].Allow)
+	require.Nil(t, spec.Linux.Seccomp)
+
+	// Root keeps the legacy capabilities and user namespaces map its IDs
+	command.User = "root"
+	command.UserNamespace = &UserNamespace{HostUID: 100000, HostGID: 100000, Size: 65536}
+	spec, err = newOCISpec(command, "/bin/sh", "/nomad/test")
+	require.NoError(t, err)
+	require.Equal(t, specs.User{}, spec.Process.User)
+	require.NotEmpty(t, spec.Process.Capabilities.Effective)
+	require.Contains(t, spec.Linux.Namespaces, specs.LinuxNamespace{Type: specs.UserNamespace})
+	require.Equal(t, []specs.LinuxIDMapping{{HostID: 100000, Size: 65536}}, spec.Linux.UIDMappings)
+	for _, m := range spec.Mounts {
+		if m.Destination == "/sys" || m.Destination == "/dev/mqueue" {
+			require.Equal(t, "bind", m.Type)
+		}
+	}
+
+	// Unknown users fail
+	command.User = "missing"
+	_, err = newOCISpec(command, "/bin/sh", "/nomad/test")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `failed to look up user "missing"`)
+}
+
+// TestExecutor_OCI_CgroupParent asserts that the container of a task is
+// nested under the cgroup parent of its resources
+func TestExecutor_OCI_CgroupParent(t *testing.T) {
+	t.Parallel()
+	testutil.ExecCompatible(t)
+
+	testExecCmd := testExecutorCommandWithChroot(t)
+	execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+	execCmd.Cmd = "/bin/sleep"
+	execCmd.Args = []string{"30"}
+	execCmd.ResourceLimits = true
+	execCmd.Resources.LinuxResources = &drivers.LinuxResources{CgroupParent: "/nomad/alloc"}
+	defer allocDir.Destroy()
+
+	// The runtime exits without running the container, which leaves its
+	// config in the bundle
+	o := NewOCIRuntimeExecutor(testlog.HCLogger(t), "/bin/true").(*OCIRuntimeExecutor)
+	_, err := o.Launch(execCmd)
+	require.NoError(t, err)
+	defer os.RemoveAll(o.bundle)
+
+	config, err := ioutil.ReadFile(filepath.Join(o.bundle, "config.json"))
+	require.NoError(t, err)
+	var spec specs.Spec
+	require.NoError(t, json.Unmarshal(config, &spec))
+	require.Equal(t, filepath.Join("/nomad/alloc", o.id), spec.Linux.CgroupsPath)
+}
+
+func TestExecutor_parseRuntimeStats(t *testing.T) {
+	out := []byte(`{"type":"stats","id":"abc","data":{"cpu":{"usage":{"total":2000000,"kernel":500000,"user":1500000},"throttling":{"throttledPeriods":2,"throttledTime":300}},"memory":{"cache":4096,"usage":{"usage":1048576,"max":2097152,"limit":0,"failcnt":0},"swap":{"usage":512,"limit":0,"failcnt":0},"raw":{"rss":8192}}}}`)
+
+	s, err := parseRuntimeStats(out)
+	require.NoError(t, err)
+
+	o := NewOCIRuntimeExecutor(testlog.HCLogger(t), "/usr/bin/runsc").(*OCIRuntimeExecutor)
+	usage := o.resourceUsage(s, time.Now())
+
+	ms := usage.ResourceUsage.MemoryStats
+	require.Equal(t, uint64(8192), ms.RSS)
+	require.Equal(t, uint64(4096), ms.Cache)
+	require.Equal(t, uint64(512), ms.Swap)
+	require.Equal(t, uint64(1048576), ms.Usage)
+	require.Equal(t, uint64(2097152), ms.MaxUsage)
+	require.Equal(t, ExecutorOCIRuntimeMeasuredMemStats, ms.Measured)
+
+	cs := usage.ResourceUsage.CpuStats
+	require.Equal(t, uint64(2), cs.ThrottledPeriods)
+	require.Equal(t, uint64(300), cs.ThrottledTime)
+	require.Equal(t, ExecutorOCIRuntimeMeasuredCpuStats, cs.Measured)
+
+	_, err = parseRuntimeStats([]byte(`{"type":"oom","id":"abc"}`))
+	require.EqualError(t, err, `unexpected event "oom"`)
+
+	_, err = parseRuntimeStats([]byte(`not json`))
+	require.Error(t, err)
+}
@@ -14,12 +14,16 @@ type ExecutorPlugin struct {
 	plugin.NetRPCUnsupportedPlugin
 	logger      hclog.Logger
 	fsIsolation bool
+	ociRuntime  string
 }
 
 func (p *ExecutorPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
-	if p.fsIsolation {
+	switch {
+	case p.fsIsolation && p.ociRuntime != "":
+		proto.RegisterExecutorServer(s, &grpcExecutorServer{impl: NewOCIRuntimeExecutor(p.logger, p.ociRuntime)})
+	case p.fsIsolation:
 		proto.RegisterExecutorServer(s, &grpcExecutorServer{impl: NewExecutorWithIsolation(p.logger)})
-	} else {
+	default:
 		proto.RegisterExecutorServer(s, &grpcExecutorServer{impl: NewExecutor(p.logger)})
 	}
 	return nil
@@ -43,6 +43,14 @@ func (l *legacyExecutorWrapper) Launch(launchCmd *ExecCommand) (*ProcessState, e
 	return nil, fmt.Errorf("operation not supported for legacy exec wrapper")
 }
 
+func (l *legacyExecutorWrapper) Checkpoint(dir string) error {
+	return fmt.Errorf("operation not supported for legacy exec wrapper")
+}
+
+func (l *legacyExecutorWrapper) Restore(command *ExecCommand, dir string) (*ProcessState, error) {
+	return nil, fmt.Errorf("operation not supported for legacy exec wrapper")
+}
+
 func (l *legacyExecutorWrapper) Wait(ctx context.Context) (*ProcessState, error) {
 	ps, err := l.client.Wait()
 	if err != nil {
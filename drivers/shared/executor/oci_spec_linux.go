@@ -0,0 +1,326 @@
+//go:build linux
+// +build linux
+
+package executor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/hashicorp/nomad/drivers/shared/capabilities"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/opencontainers/runc/libcontainer/devices"
+	"github.com/opencontainers/runc/libcontainer/specconv"
+	"github.com/opencontainers/runc/libcontainer/user"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// ociPropagation maps volume mount propagation modes to OCI mount options.
+var ociPropagation = map[string]string{
+	"":                                       "rprivate",
+	structs.VolumeMountPropagationPrivate:    "rprivate",
+	structs.VolumeMountPropagationHostToTask: "rslave",
+	structs.VolumeMountPropagationBidirectional: "rshared",
+}
+
+// newOCISpec builds the OCI runtime spec of a container running the command
+// with the same isolation the libcontainer executor configures. bin is the
+// path of the command within the task directory.
+func newOCISpec(command *ExecCommand, bin, cgroupPath string) (*specs.Spec, error) {
+	spec := &specs.Spec{
+		Version: specs.Version,
+		Root: &specs.Root{
+			Path: command.TaskDir,
+		},
+		Process: &specs.Process{
+			Args: append([]string{bin}, command.Args...),
+			Env:  command.Env,
+			Cwd:  "/",
+		},
+		Linux: &specs.Linux{
+			MaskedPaths: []string{
+				"/proc/kcore",
+				"/sys/firmware",
+			},
+			ReadonlyPaths: []string{
+				"/proc/sys", "/proc/sysrq-trigger", "/proc/irq", "/proc/bus",
+			},
+			Resources: &specs.LinuxResources{},
+		},
+	}
+
+	// children should not inherit Nomad agent oom_score_adj value
+	oomScoreAdj := 0
+	spec.Process.OOMScoreAdj = &oomScoreAdj
+
+	if err := configureOCIUser(spec, command); err != nil {
+		return nil, err
+	}
+	configureOCICapabilities(spec, command)
+
+	if err := configureOCIIsolation(spec, command); err != nil {
+		return nil, err
+	}
+	if err := configureOCIDevices(spec, command); err != nil {
+		return nil, err
+	}
+	if err := configureOCIResources(spec, command, cgroupPath); err != nil {
+		return nil, err
+	}
+
+	if len(command.SeccompProfile) > 0 {
+		seccomp, err := ociSeccomp(command.SeccompProfile, command.Capabilities)
+		if err != nil {
+			return nil, fmt.Errorf("invalid seccomp profile: %v", err)
+		}
+		spec.Linux.Seccomp = seccomp
+	}
+	spec.Process.ApparmorProfile = command.AppArmorProfile
+
+	return spec, nil
+}
+
+// configureOCIUser resolves the task's user with the passwd and group files
+// of the task directory, since the spec requires numeric IDs.
+func configureOCIUser(spec *specs.Spec, command *ExecCommand) error {
+	passwd, err := securejoin.SecureJoin(command.TaskDir, "/etc/passwd")
+	if err != nil {
+		return err
+	}
+	group, err := securejoin.SecureJoin(command.TaskDir, "/etc/group")
+	if err != nil {
+		return err
+	}
+
+	execUser, err := user.GetExecUserPath(command.User, &user.ExecUser{}, passwd, group)
+	if err != nil {
+		return fmt.Errorf("failed to look up user %q: %v", command.User, err)
+	}
+
+	spec.Process.User = specs.User{
+		UID: uint32(execUser.Uid),
+		GID: uint32(execUser.Gid),
+	}
+	for _, gid := range execUser.Sgids {
+		spec.Process.User.AdditionalGids = append(spec.Process.User.AdditionalGids, uint32(gid))
+	}
+	return nil
+}
+
+// configureOCICapabilities mirrors configureCapabilities.
+func configureOCICapabilities(spec *specs.Spec, command *ExecCommand) {
+	switch command.User {
+	case "root":
+		legacyCaps := capabilities.LegacySupported().Slice(true)
+		spec.Process.Capabilities = &specs.LinuxCapabilities{
+			Bounding:  legacyCaps,
+			Permitted: legacyCaps,
+			Effective: legacyCaps,
+		}
+	default:
+		spec.Process.Capabilities = &specs.LinuxCapabilities{
+			Bounding: command.Capabilities,
+		}
+	}
+}
+
+// configureOCIIsolation mirrors configureIsolation.
+func configureOCIIsolation(spec *specs.Spec, command *ExecCommand) error {
+	defaultMountOptions := []string{"noexec", "nosuid", "nodev"}
+
+	spec.Linux.Namespaces = []specs.LinuxNamespace{{Type: specs.MountNamespace}}
+	if command.ModePID == IsolationModePrivate {
+		spec.Linux.Namespaces = append(spec.Linux.Namespaces, specs.LinuxNamespace{Type: specs.PIDNamespace})
+	}
+	if command.ModeIPC == IsolationModePrivate {
+		spec.Linux.Namespaces = append(spec.Linux.Namespaces, specs.LinuxNamespace{Type: specs.IPCNamespace})
+	}
+	if command.NetworkIsolation != nil {
+		spec.Linux.Namespaces = append(spec.Linux.Namespaces, specs.LinuxNamespace{
+			Type: specs.NetworkNamespace,
+			Path: command.NetworkIsolation.Path,
+		})
+	}
+
+	spec.Mounts = []specs.Mount{
+		{
+			Source:      "tmpfs",
+			Destination: "/dev",
+			Type:        "tmpfs",
+			Options:     []string{"nosuid", "strictatime", "mode=755"},
+		},
+		{
+			Source:      "proc",
+			Destination: "/proc",
+			Type:        "proc",
+			Options:     defaultMountOptions,
+		},
+		{
+			Source:      "devpts",
+			Destination: "/dev/pts",
+			Type:        "devpts",
+			Options:     []string{"nosuid", "noexec", "newinstance", "ptmxmode=0666", "mode=0620", "gid=5"},
+		},
+		{
+			Source:      "shm",
+			Destination: "/dev/shm",
+			Type:        "tmpfs",
+			Options:     append([]string{"mode=1777", "size=65536k"}, defaultMountOptions...),
+		},
+		{
+			Source:      "mqueue",
+			Destination: "/dev/mqueue",
+			Type:        "mqueue",
+			Options:     defaultMountOptions,
+		},
+		{
+			Source:      "sysfs",
+			Destination: "/sys",
+			Type:        "sysfs",
+			Options:     append([]string{"ro"}, defaultMountOptions...),
+		},
+	}
+
+	if userns := command.UserNamespace; userns != nil {
+		if command.ModePID != IsolationModePrivate {
+			return fmt.Errorf("user namespaces require a private PID namespace")
+		}
+
+		spec.Linux.Namespaces = append(spec.Linux.Namespaces, specs.LinuxNamespace{Type: specs.UserNamespace})
+		spec.Linux.UIDMappings = []specs.LinuxIDMapping{{
+			ContainerID: 0,
+			HostID:      userns.HostUID,
+			Size:        userns.Size,
+		}}
+		spec.Linux.GIDMappings = []specs.LinuxIDMapping{{
+			ContainerID: 0,
+			HostID:      userns.HostGID,
+			Size:        userns.Size,
+		}}
+
+		// See configureUserNamespace for why these are bind mounted
+		bindOptions := append([]string{"rbind"}, defaultMountOptions...)
+		for i, m := range spec.Mounts {
+			switch {
+			case m.Type == "sysfs":
+				spec.Mounts[i].Source, spec.Mounts[i].Type = "/sys", "bind"
+				spec.Mounts[i].Options = append([]string{"ro"}, bindOptions...)
+			case m.Type == "mqueue" && command.ModeIPC != IsolationModePrivate:
+				spec.Mounts[i].Source, spec.Mounts[i].Type = "/dev/mqueue", "bind"
+				spec.Mounts[i].Options = bindOptions
+			}
+		}
+	}
+
+	for _, m := range command.Mounts {
+		options := []string{"bind", ociPropagation[m.PropagationMode]}
+		if m.Readonly {
+			options = append(options, "ro")
+		}
+		spec.Mounts = append(spec.Mounts, specs.Mount{
+			Source:      m.HostPath,
+			Destination: m.TaskPath,
+			Type:        "bind",
+			Options:     options,
+		})
+	}
+
+	return nil
+}
+
+// configureOCIDevices allows the same devices as the libcontainer executor
+// and creates the task's devices.
+func configureOCIDevices(spec *specs.Spec, command *ExecCommand) error {
+	resources := spec.Linux.Resources
+	resources.Devices = []specs.LinuxDeviceCgroup{{Allow: false, Access: "rwm"}}
+	for _, d := range specconv.AllowedDevices {
+		resources.Devices = append(resources.Devices, ociDeviceRule(d.Rule))
+	}
+
+	for _, d := range command.Devices {
+		dev, err := devices.DeviceFromPath(d.HostPath, d.Permissions)
+		if err != nil {
+			return fmt.Errorf("failed to make device out for %s: %v", d.HostPath, err)
+		}
+
+		mode := dev.FileMode
+		uid, gid := dev.Uid, dev.Gid
+		spec.Linux.Devices = append(spec.Linux.Devices, specs.LinuxDevice{
+			Path:     d.TaskPath,
+			Type:     string(dev.Type),
+			Major:    dev.Major,
+			Minor:    dev.Minor,
+			FileMode: &mode,
+			UID:      &uid,
+			GID:      &gid,
+		})
+		resources.Devices = append(resources.Devices, ociDeviceRule(dev.Rule))
+	}
+	return nil
+}
+
+func ociDeviceRule(rule devices.Rule) specs.LinuxDeviceCgroup {
+	r := specs.LinuxDeviceCgroup{
+		Allow:  rule.Allow,
+		Type:   string(rule.Type),
+		Access: string(rule.Permissions),
+	}
+	if rule.Major != devices.Wildcard {
+		major := rule.Major
+		r.Major = &major
+	}
+	if rule.Minor != devices.Wildcard {
+		minor := rule.Minor
+		r.Minor = &minor
+	}
+	return r
+}
+
+// configureOCIResources mirrors configureCgroups. The runtime creates the
+// container's cgroup at cgroupPath.
+func configureOCIResources(spec *specs.Spec, command *ExecCommand, cgroupPath string) error {
+	spec.Linux.CgroupsPath = cgroupPath
+	if !command.ResourceLimits || command.Resources == nil || command.Resources.NomadResources == nil {
+		return nil
+	}
+
+	res := command.Resources.NomadResources
+	memHard, memSoft := res.Memory.MemoryMaxMB, res.Memory.MemoryMB
+	if memHard <= 0 {
+		memHard = res.Memory.MemoryMB
+		memSoft = 0
+	}
+
+	if memHard > 0 {
+		limit := memHard * 1024 * 1024
+		reservation := memSoft * 1024 * 1024
+		var swappiness uint64
+		spec.Linux.Resources.Memory = &specs.LinuxMemory{
+			Limit:       &limit,
+			Reservation: &reservation,
+			Swappiness:  &swappiness,
+		}
+	}
+
+	cpuShares := uint64(res.Cpu.CpuShares)
+	if cpuShares < 2 {
+		return fmt.Errorf("resources.Cpu.CpuShares must be equal to or greater than 2: %v", cpuShares)
+	}
+	spec.Linux.Resources.CPU = &specs.LinuxCPU{
+		Shares: &cpuShares,
+	}
+
+	// The runtime's cgroup can't be moved into Nomad's cpuset cgroup, so
+	// the reserved cores are pinned directly instead
+	if len(res.Cpu.ReservedCores) > 0 {
+		cores := make([]string, 0, len(res.Cpu.ReservedCores))
+		for _, core := range res.Cpu.ReservedCores {
+			cores = append(cores, strconv.Itoa(int(core)))
+		}
+		spec.Linux.Resources.CPU.Cpus = strings.Join(cores, ",")
+	}
+
+	return nil
+}
@@ -19,6 +19,10 @@ type ExecutorConfig struct {
 	// FSIsolation if set will use an executor implementation that support
 	// filesystem isolation
 	FSIsolation bool
+
+	// OCIRuntime, if set with FSIsolation, is the path of an OCI runtime
+	// binary the executor launches tasks with instead of libcontainer
+	OCIRuntime string
 }
 
 func GetPluginMap(logger hclog.Logger, fsIsolation bool) map[string]plugin.Plugin {
@@ -30,6 +34,18 @@ func GetPluginMap(logger hclog.Logger, fsIsolation bool) map[string]plugin.Plugi
 	}
 }
 
+// getServerPluginMap returns the plugin map an executor process serves for
+// the given config.
+func getServerPluginMap(logger hclog.Logger, config *ExecutorConfig) map[string]plugin.Plugin {
+	return map[string]plugin.Plugin{
+		"executor": &ExecutorPlugin{
+			logger:      logger,
+			fsIsolation: config.FSIsolation,
+			ociRuntime:  config.OCIRuntime,
+		},
+	}
+}
+
 func GetPre09PluginMap(logger hclog.Logger, fsIsolation bool) map[string]plugin.Plugin {
 	return map[string]plugin.Plugin{
 		"executor": newPre09ExecutorPlugin(logger),
@@ -46,6 +46,10 @@ type LaunchRequest struct {
 	CpusetCgroup         string                       `protobuf:"bytes,17,opt,name=cpuset_cgroup,json=cpusetCgroup,proto3" json:"cpuset_cgroup,omitempty"`
 	AllowCaps            []string                     `protobuf:"bytes,18,rep,name=allow_caps,json=allowCaps,proto3" json:"allow_caps,omitempty"`
 	Capabilities         []string                     `protobuf:"bytes,19,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	UserNamespace        *UserNamespace               `protobuf:"bytes,20,opt,name=user_namespace,json=userNamespace,proto3" json:"user_namespace,omitempty"`
+	SeccompProfile       []byte                       `protobuf:"bytes,21,opt,name=seccomp_profile,json=seccompProfile,proto3" json:"seccomp_profile,omitempty"`
+	ApparmorProfile      string                       `protobuf:"bytes,22,opt,name=apparmor_profile,json=apparmorProfile,proto3" json:"apparmor_profile,omitempty"`
+	Checkpoint           bool                         `protobuf:"varint,23,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
 	XXX_unrecognized     []byte                       `json:"-"`
 	XXX_sizecache        int32                        `json:"-"`
@@ -209,6 +213,89 @@ func (m *LaunchRequest) GetCapabilities() []string {
 	return nil
 }
 
+func (m *LaunchRequest) GetUserNamespace() *UserNamespace {
+	if m != nil {
+		return m.UserNamespace
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetSeccompProfile() []byte {
+	if m != nil {
+		return m.SeccompProfile
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetApparmorProfile() string {
+	if m != nil {
+		return m.ApparmorProfile
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetCheckpoint() bool {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return false
+}
+
+type UserNamespace struct {
+	HostUid              uint32   `protobuf:"varint,1,opt,name=host_uid,json=hostUid,proto3" json:"host_uid,omitempty"`
+	HostGid              uint32   `protobuf:"varint,2,opt,name=host_gid,json=hostGid,proto3" json:"host_gid,omitempty"`
+	Size                 uint32   `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UserNamespace) Reset()         { *m = UserNamespace{} }
+func (m *UserNamespace) String() string { return proto.CompactTextString(m) }
+func (*UserNamespace) ProtoMessage()    {}
+func (*UserNamespace) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{1}
+}
+
+func (m *UserNamespace) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserNamespace.Unmarshal(m, b)
+}
+func (m *UserNamespace) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserNamespace.Marshal(b, m, deterministic)
+}
+func (m *UserNamespace) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserNamespace.Merge(m, src)
+}
+func (m *UserNamespace) XXX_Size() int {
+	return xxx_messageInfo_UserNamespace.Size(m)
+}
+func (m *UserNamespace) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserNamespace.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserNamespace proto.InternalMessageInfo
+
+func (m *UserNamespace) GetHostUid() uint32 {
+	if m != nil {
+		return m.HostUid
+	}
+	return 0
+}
+
+func (m *UserNamespace) GetHostGid() uint32 {
+	if m != nil {
+		return m.HostGid
+	}
+	return 0
+}
+
+func (m *UserNamespace) GetSize() uint32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
 type LaunchResponse struct {
 	Process              *ProcessState `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
@@ -220,7 +307,7 @@ func (m *LaunchResponse) Reset()         { *m = LaunchResponse{} }
 func (m *LaunchResponse) String() string { return proto.CompactTextString(m) }
 func (*LaunchResponse) ProtoMessage()    {}
 func (*LaunchResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{1}
+	return fileDescriptor_66b85426380683f3, []int{2}
 }
 
 func (m *LaunchResponse) XXX_Unmarshal(b []byte) error {
@@ -258,7 +345,7 @@ func (m *WaitRequest) Reset()         { *m = WaitRequest{} }
 func (m *WaitRequest) String() string { return proto.CompactTextString(m) }
 func (*WaitRequest) ProtoMessage()    {}
 func (*WaitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{2}
+	return fileDescriptor_66b85426380683f3, []int{3}
 }
 
 func (m *WaitRequest) XXX_Unmarshal(b []byte) error {
@@ -290,7 +377,7 @@ func (m *WaitResponse) Reset()         { *m = WaitResponse{} }
 func (m *WaitResponse) String() string { return proto.CompactTextString(m) }
 func (*WaitResponse) ProtoMessage()    {}
 func (*WaitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{3}
+	return fileDescriptor_66b85426380683f3, []int{4}
 }
 
 func (m *WaitResponse) XXX_Unmarshal(b []byte) error {
@@ -330,7 +417,7 @@ func (m *ShutdownRequest) Reset()         { *m = ShutdownRequest{} }
 func (m *ShutdownRequest) String() string { return proto.CompactTextString(m) }
 func (*ShutdownRequest) ProtoMessage()    {}
 func (*ShutdownRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{4}
+	return fileDescriptor_66b85426380683f3, []int{5}
 }
 
 func (m *ShutdownRequest) XXX_Unmarshal(b []byte) error {
@@ -375,7 +462,7 @@ func (m *ShutdownResponse) Reset()         { *m = ShutdownResponse{} }
 func (m *ShutdownResponse) String() string { return proto.CompactTextString(m) }
 func (*ShutdownResponse) ProtoMessage()    {}
 func (*ShutdownResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{5}
+	return fileDescriptor_66b85426380683f3, []int{6}
 }
 
 func (m *ShutdownResponse) XXX_Unmarshal(b []byte) error {
@@ -407,7 +494,7 @@ func (m *UpdateResourcesRequest) Reset()         { *m = UpdateResourcesRequest{}
 func (m *UpdateResourcesRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateResourcesRequest) ProtoMessage()    {}
 func (*UpdateResourcesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{6}
+	return fileDescriptor_66b85426380683f3, []int{7}
 }
 
 func (m *UpdateResourcesRequest) XXX_Unmarshal(b []byte) error {
@@ -445,7 +532,7 @@ func (m *UpdateResourcesResponse) Reset()         { *m = UpdateResourcesResponse
 func (m *UpdateResourcesResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateResourcesResponse) ProtoMessage()    {}
 func (*UpdateResourcesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{7}
+	return fileDescriptor_66b85426380683f3, []int{8}
 }
 
 func (m *UpdateResourcesResponse) XXX_Unmarshal(b []byte) error {
@@ -476,7 +563,7 @@ func (m *VersionRequest) Reset()         { *m = VersionRequest{} }
 func (m *VersionRequest) String() string { return proto.CompactTextString(m) }
 func (*VersionRequest) ProtoMessage()    {}
 func (*VersionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{8}
+	return fileDescriptor_66b85426380683f3, []int{9}
 }
 
 func (m *VersionRequest) XXX_Unmarshal(b []byte) error {
@@ -508,7 +595,7 @@ func (m *VersionResponse) Reset()         { *m = VersionResponse{} }
 func (m *VersionResponse) String() string { return proto.CompactTextString(m) }
 func (*VersionResponse) ProtoMessage()    {}
 func (*VersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{9}
+	return fileDescriptor_66b85426380683f3, []int{10}
 }
 
 func (m *VersionResponse) XXX_Unmarshal(b []byte) error {
@@ -547,7 +634,7 @@ func (m *StatsRequest) Reset()         { *m = StatsRequest{} }
 func (m *StatsRequest) String() string { return proto.CompactTextString(m) }
 func (*StatsRequest) ProtoMessage()    {}
 func (*StatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{10}
+	return fileDescriptor_66b85426380683f3, []int{11}
 }
 
 func (m *StatsRequest) XXX_Unmarshal(b []byte) error {
@@ -586,7 +673,7 @@ func (m *StatsResponse) Reset()         { *m = StatsResponse{} }
 func (m *StatsResponse) String() string { return proto.CompactTextString(m) }
 func (*StatsResponse) ProtoMessage()    {}
 func (*StatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{11}
+	return fileDescriptor_66b85426380683f3, []int{12}
 }
 
 func (m *StatsResponse) XXX_Unmarshal(b []byte) error {
@@ -625,7 +712,7 @@ func (m *SignalRequest) Reset()         { *m = SignalRequest{} }
 func (m *SignalRequest) String() string { return proto.CompactTextString(m) }
 func (*SignalRequest) ProtoMessage()    {}
 func (*SignalRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{12}
+	return fileDescriptor_66b85426380683f3, []int{13}
 }
 
 func (m *SignalRequest) XXX_Unmarshal(b []byte) error {
@@ -663,7 +750,7 @@ func (m *SignalResponse) Reset()         { *m = SignalResponse{} }
 func (m *SignalResponse) String() string { return proto.CompactTextString(m) }
 func (*SignalResponse) ProtoMessage()    {}
 func (*SignalResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{13}
+	return fileDescriptor_66b85426380683f3, []int{14}
 }
 
 func (m *SignalResponse) XXX_Unmarshal(b []byte) error {
@@ -697,7 +784,7 @@ func (m *ExecRequest) Reset()         { *m = ExecRequest{} }
 func (m *ExecRequest) String() string { return proto.CompactTextString(m) }
 func (*ExecRequest) ProtoMessage()    {}
 func (*ExecRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{14}
+	return fileDescriptor_66b85426380683f3, []int{15}
 }
 
 func (m *ExecRequest) XXX_Unmarshal(b []byte) error {
@@ -751,7 +838,7 @@ func (m *ExecResponse) Reset()         { *m = ExecResponse{} }
 func (m *ExecResponse) String() string { return proto.CompactTextString(m) }
 func (*ExecResponse) ProtoMessage()    {}
 func (*ExecResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{15}
+	return fileDescriptor_66b85426380683f3, []int{16}
 }
 
 func (m *ExecResponse) XXX_Unmarshal(b []byte) error {
@@ -786,6 +873,162 @@ func (m *ExecResponse) GetExitCode() int32 {
 	return 0
 }
 
+type CheckpointRequest struct {
+	Dir                  string   `protobuf:"bytes,1,opt,name=dir,proto3" json:"dir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointRequest) Reset()         { *m = CheckpointRequest{} }
+func (m *CheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckpointRequest) ProtoMessage()    {}
+func (*CheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{17}
+}
+
+func (m *CheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointRequest.Unmarshal(m, b)
+}
+func (m *CheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointRequest.Merge(m, src)
+}
+func (m *CheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckpointRequest.Size(m)
+}
+func (m *CheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointRequest proto.InternalMessageInfo
+
+func (m *CheckpointRequest) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+type CheckpointResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointResponse) Reset()         { *m = CheckpointResponse{} }
+func (m *CheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckpointResponse) ProtoMessage()    {}
+func (*CheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{18}
+}
+
+func (m *CheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointResponse.Unmarshal(m, b)
+}
+func (m *CheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointResponse.Merge(m, src)
+}
+func (m *CheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckpointResponse.Size(m)
+}
+func (m *CheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointResponse proto.InternalMessageInfo
+
+type RestoreRequest struct {
+	Launch               *LaunchRequest `protobuf:"bytes,1,opt,name=launch,proto3" json:"launch,omitempty"`
+	Dir                  string         `protobuf:"bytes,2,opt,name=dir,proto3" json:"dir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RestoreRequest) Reset()         { *m = RestoreRequest{} }
+func (m *RestoreRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreRequest) ProtoMessage()    {}
+func (*RestoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{19}
+}
+
+func (m *RestoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreRequest.Unmarshal(m, b)
+}
+func (m *RestoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreRequest.Merge(m, src)
+}
+func (m *RestoreRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreRequest.Size(m)
+}
+func (m *RestoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreRequest proto.InternalMessageInfo
+
+func (m *RestoreRequest) GetLaunch() *LaunchRequest {
+	if m != nil {
+		return m.Launch
+	}
+	return nil
+}
+
+func (m *RestoreRequest) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+type RestoreResponse struct {
+	Process              *ProcessState `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *RestoreResponse) Reset()         { *m = RestoreResponse{} }
+func (m *RestoreResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreResponse) ProtoMessage()    {}
+func (*RestoreResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{20}
+}
+
+func (m *RestoreResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreResponse.Unmarshal(m, b)
+}
+func (m *RestoreResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreResponse.Merge(m, src)
+}
+func (m *RestoreResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreResponse.Size(m)
+}
+func (m *RestoreResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreResponse proto.InternalMessageInfo
+
+func (m *RestoreResponse) GetProcess() *ProcessState {
+	if m != nil {
+		return m.Process
+	}
+	return nil
+}
+
 type ProcessState struct {
 	Pid                  int32                `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
 	ExitCode             int32                `protobuf:"varint,2,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
@@ -800,7 +1043,7 @@ func (m *ProcessState) Reset()         { *m = ProcessState{} }
 func (m *ProcessState) String() string { return proto.CompactTextString(m) }
 func (*ProcessState) ProtoMessage()    {}
 func (*ProcessState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{16}
+	return fileDescriptor_66b85426380683f3, []int{21}
 }
 
 func (m *ProcessState) XXX_Unmarshal(b []byte) error {
@@ -851,6 +1094,7 @@ func (m *ProcessState) GetTime() *timestamp.Timestamp {
 
 func init() {
 	proto.RegisterType((*LaunchRequest)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchRequest")
+	proto.RegisterType((*UserNamespace)(nil), "hashicorp.nomad.plugins.executor.proto.UserNamespace")
 	proto.RegisterType((*LaunchResponse)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchResponse")
 	proto.RegisterType((*WaitRequest)(nil), "hashicorp.nomad.plugins.executor.proto.WaitRequest")
 	proto.RegisterType((*WaitResponse)(nil), "hashicorp.nomad.plugins.executor.proto.WaitResponse")
@@ -866,6 +1110,10 @@ func init() {
 	proto.RegisterType((*SignalResponse)(nil), "hashicorp.nomad.plugins.executor.proto.SignalResponse")
 	proto.RegisterType((*ExecRequest)(nil), "hashicorp.nomad.plugins.executor.proto.ExecRequest")
 	proto.RegisterType((*ExecResponse)(nil), "hashicorp.nomad.plugins.executor.proto.ExecResponse")
+	proto.RegisterType((*CheckpointRequest)(nil), "hashicorp.nomad.plugins.executor.proto.CheckpointRequest")
+	proto.RegisterType((*CheckpointResponse)(nil), "hashicorp.nomad.plugins.executor.proto.CheckpointResponse")
+	proto.RegisterType((*RestoreRequest)(nil), "hashicorp.nomad.plugins.executor.proto.RestoreRequest")
+	proto.RegisterType((*RestoreResponse)(nil), "hashicorp.nomad.plugins.executor.proto.RestoreResponse")
 	proto.RegisterType((*ProcessState)(nil), "hashicorp.nomad.plugins.executor.proto.ProcessState")
 }
 
@@ -874,74 +1122,87 @@ func init() {
 }
 
 var fileDescriptor_66b85426380683f3 = []byte{
-	// 1058 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0x6d, 0x6f, 0x1b, 0x45,
-	0x10, 0xee, 0xc5, 0x89, 0x5f, 0xc6, 0x76, 0xe2, 0x2e, 0xa8, 0x5c, 0x8d, 0x50, 0xcd, 0x21, 0x51,
-	0x0b, 0xca, 0x25, 0x4a, 0xdf, 0x90, 0x90, 0x28, 0x22, 0x2d, 0xa8, 0x52, 0x1a, 0x45, 0x97, 0x42,
-	0x25, 0x3e, 0x70, 0x6c, 0xee, 0xb6, 0xf6, 0x2a, 0xf6, 0xed, 0xb2, 0xbb, 0xe7, 0x04, 0x09, 0x89,
-	0x4f, 0xfc, 0x03, 0x90, 0xf8, 0x31, 0xfc, 0x38, 0xb4, 0x6f, 0x17, 0x3b, 0x2d, 0xd5, 0xb9, 0x88,
-	0x4f, 0xbe, 0x1d, 0x3f, 0xcf, 0xcc, 0xec, 0xce, 0xcc, 0x33, 0x70, 0x27, 0x17, 0x74, 0x41, 0x84,
-	0xdc, 0x95, 0x53, 0x2c, 0x48, 0xbe, 0x4b, 0x2e, 0x48, 0x56, 0x2a, 0x26, 0x76, 0xb9, 0x60, 0x8a,
-	0x55, 0xc7, 0xd8, 0x1c, 0xd1, 0xc7, 0x53, 0x2c, 0xa7, 0x34, 0x63, 0x82, 0xc7, 0x05, 0x9b, 0xe3,
-	0x3c, 0xe6, 0xb3, 0x72, 0x42, 0x0b, 0x19, 0xaf, 0xe2, 0x86, 0xb7, 0x26, 0x8c, 0x4d, 0x66, 0xc4,
-	0x3a, 0x39, 0x2d, 0x5f, 0xee, 0x2a, 0x3a, 0x27, 0x52, 0xe1, 0x39, 0x77, 0x80, 0xc8, 0x11, 0x77,
-	0x7d, 0x78, 0x1b, 0xce, 0x9e, 0x2c, 0x26, 0xfa, 0xbb, 0x09, 0xfd, 0x43, 0x5c, 0x16, 0xd9, 0x34,
-	0x21, 0x3f, 0x97, 0x44, 0x2a, 0x34, 0x80, 0x46, 0x36, 0xcf, 0xc3, 0x60, 0x14, 0x8c, 0x3b, 0x89,
-	0xfe, 0x44, 0x08, 0x36, 0xb1, 0x98, 0xc8, 0x70, 0x63, 0xd4, 0x18, 0x77, 0x12, 0xf3, 0x8d, 0x8e,
-	0xa0, 0x23, 0x88, 0x64, 0xa5, 0xc8, 0x88, 0x0c, 0x1b, 0xa3, 0x60, 0xdc, 0xdd, 0xdf, 0x8b, 0xff,
-	0x2d, 0x71, 0x17, 0xdf, 0x86, 0x8c, 0x13, 0xcf, 0x4b, 0x2e, 0x5d, 0xa0, 0x5b, 0xd0, 0x95, 0x2a,
-	0x67, 0xa5, 0x4a, 0x39, 0x56, 0xd3, 0x70, 0xd3, 0x44, 0x07, 0x6b, 0x3a, 0xc6, 0x6a, 0xea, 0x00,
-	0x44, 0x08, 0x0b, 0xd8, 0xaa, 0x00, 0x44, 0x08, 0x03, 0x18, 0x40, 0x83, 0x14, 0x8b, 0xb0, 0x69,
-	0x92, 0xd4, 0x9f, 0x3a, 0xef, 0x52, 0x12, 0x11, 0xb6, 0x0c, 0xd6, 0x7c, 0xa3, 0x9b, 0xd0, 0x56,
-	0x58, 0x9e, 0xa5, 0x39, 0x15, 0x61, 0xdb, 0xd8, 0x5b, 0xfa, 0xfc, 0x98, 0x0a, 0x74, 0x1b, 0x76,
-	0x7c, 0x3e, 0xe9, 0x8c, 0xce, 0xa9, 0x92, 0x61, 0x67, 0x14, 0x8c, 0xdb, 0xc9, 0xb6, 0x37, 0x1f,
-	0x1a, 0x2b, 0xda, 0x83, 0x77, 0x4f, 0xb1, 0xa4, 0x59, 0xca, 0x05, 0xcb, 0x88, 0x94, 0x69, 0x36,
-	0x11, 0xac, 0xe4, 0x21, 0x18, 0x34, 0x32, 0xff, 0x1d, 0xdb, 0xbf, 0x0e, 0xcc, 0x3f, 0xe8, 0x31,
-	0x34, 0xe7, 0xac, 0x2c, 0x94, 0x0c, 0xbb, 0xa3, 0xc6, 0xb8, 0xbb, 0x7f, 0xa7, 0xe6, 0x53, 0x3d,
-	0xd3, 0xa4, 0xc4, 0x71, 0xd1, 0xb7, 0xd0, 0xca, 0xc9, 0x82, 0xea, 0x17, 0xef, 0x19, 0x37, 0x9f,
-	0xd5, 0x74, 0xf3, 0xd8, 0xb0, 0x12, 0xcf, 0x46, 0x53, 0xb8, 0x5e, 0x10, 0x75, 0xce, 0xc4, 0x59,
-	0x4a, 0x25, 0x9b, 0x61, 0x45, 0x59, 0x11, 0xf6, 0x4d, 0x11, 0xbf, 0xa8, 0xe9, 0xf2, 0xc8, 0xf2,
-	0x9f, 0x7a, 0xfa, 0x09, 0x27, 0x59, 0x32, 0x28, 0xae, 0x58, 0x51, 0x04, 0xfd, 0x82, 0xa5, 0x9c,
-	0x2e, 0x98, 0x4a, 0x05, 0x63, 0x2a, 0xdc, 0x36, 0x6f, 0xd4, 0x2d, 0xd8, 0xb1, 0xb6, 0x25, 0x8c,
-	0x29, 0x34, 0x86, 0x41, 0x4e, 0x5e, 0xe2, 0x72, 0xa6, 0x52, 0x4e, 0xf3, 0x74, 0xce, 0x72, 0x12,
-	0xee, 0x98, 0xd2, 0x6c, 0x3b, 0xfb, 0x31, 0xcd, 0x9f, 0xb1, 0x9c, 0x2c, 0x23, 0x29, 0xcf, 0x2c,
-	0x72, 0xb0, 0x82, 0x7c, 0xca, 0x33, 0x83, 0xfc, 0x08, 0xfa, 0x19, 0x2f, 0x25, 0x51, 0xbe, 0x36,
-	0xd7, 0x0d, 0xac, 0x67, 0x8d, 0xae, 0x2a, 0x1f, 0x00, 0xe0, 0xd9, 0x8c, 0x9d, 0xa7, 0x19, 0xe6,
-	0x32, 0x44, 0xa6, 0x71, 0x3a, 0xc6, 0x72, 0x80, 0xb9, 0x44, 0x11, 0xf4, 0x32, 0xcc, 0xf1, 0x29,
-	0x9d, 0x51, 0x45, 0x89, 0x0c, 0xdf, 0x31, 0x80, 0x15, 0x5b, 0xf4, 0x13, 0x6c, 0xfb, 0xe9, 0x91,
-	0x9c, 0x15, 0x92, 0xa0, 0x23, 0x68, 0xb9, 0xb6, 0x30, 0x23, 0xd4, 0xdd, 0xbf, 0x17, 0xd7, 0x9b,
-	0xe7, 0xd8, 0xb5, 0xcc, 0x89, 0xc2, 0x8a, 0x24, 0xde, 0x49, 0xd4, 0x87, 0xee, 0x0b, 0x4c, 0x95,
-	0x9b, 0xce, 0xe8, 0x47, 0xe8, 0xd9, 0xe3, 0xff, 0x14, 0xee, 0x10, 0x76, 0x4e, 0xa6, 0xa5, 0xca,
-	0xd9, 0x79, 0xe1, 0x05, 0xe1, 0x06, 0x34, 0x25, 0x9d, 0x14, 0x78, 0xe6, 0x34, 0xc1, 0x9d, 0xd0,
-	0x87, 0xd0, 0x9b, 0x08, 0x9c, 0x91, 0x94, 0x13, 0x41, 0x59, 0x1e, 0x6e, 0x8c, 0x82, 0x71, 0x23,
-	0xe9, 0x1a, 0xdb, 0xb1, 0x31, 0x45, 0x08, 0x06, 0x97, 0xde, 0x6c, 0xc6, 0xd1, 0x14, 0x6e, 0x7c,
-	0xc7, 0x73, 0x1d, 0xb4, 0xd2, 0x01, 0x17, 0x68, 0x45, 0x53, 0x82, 0xff, 0xac, 0x29, 0xd1, 0x4d,
-	0x78, 0xef, 0x95, 0x48, 0x2e, 0x89, 0x01, 0x6c, 0x7f, 0x4f, 0x84, 0xa4, 0xcc, 0xdf, 0x32, 0xfa,
-	0x14, 0x76, 0x2a, 0x8b, 0x7b, 0xdb, 0x10, 0x5a, 0x0b, 0x6b, 0x72, 0x37, 0xf7, 0xc7, 0xe8, 0x13,
-	0xe8, 0xe9, 0x77, 0xab, 0x32, 0x1f, 0x42, 0x9b, 0x16, 0x8a, 0x88, 0x85, 0x7b, 0xa4, 0x46, 0x52,
-	0x9d, 0xa3, 0x17, 0xd0, 0x77, 0x58, 0xe7, 0xf6, 0x1b, 0xd8, 0x92, 0xda, 0xb0, 0xe6, 0x15, 0x9f,
-	0x63, 0x79, 0x66, 0x1d, 0x59, 0x7a, 0x74, 0x1b, 0xfa, 0x27, 0xa6, 0x12, 0xaf, 0x2f, 0xd4, 0x96,
-	0x2f, 0x94, 0xbe, 0xac, 0x07, 0xba, 0xeb, 0x9f, 0x41, 0xf7, 0xc9, 0x05, 0xc9, 0x3c, 0xf1, 0x01,
-	0xb4, 0x73, 0x82, 0xf3, 0x19, 0x2d, 0x88, 0x4b, 0x6a, 0x18, 0xdb, 0xe5, 0x12, 0xfb, 0xe5, 0x12,
-	0x3f, 0xf7, 0xcb, 0x25, 0xa9, 0xb0, 0x7e, 0x55, 0x6c, 0xbc, 0xba, 0x2a, 0x1a, 0x97, 0xab, 0x22,
-	0x3a, 0x80, 0x9e, 0x0d, 0xe6, 0xee, 0x7f, 0x03, 0x9a, 0xac, 0x54, 0xbc, 0x54, 0x26, 0x56, 0x2f,
-	0x71, 0x27, 0xf4, 0x3e, 0x74, 0xc8, 0x05, 0x55, 0x69, 0xa6, 0xc7, 0x7a, 0xc3, 0xdc, 0xa0, 0xad,
-	0x0d, 0x07, 0x2c, 0x27, 0xd1, 0xef, 0x01, 0xf4, 0x96, 0x3b, 0x56, 0xc7, 0xe6, 0x34, 0x77, 0x37,
-	0xd5, 0x9f, 0x6f, 0xe4, 0x2f, 0xbd, 0x4d, 0x63, 0xf9, 0x6d, 0x50, 0x0c, 0x9b, 0x7a, 0x6d, 0x9a,
-	0x85, 0xf3, 0xe6, 0x6b, 0x1b, 0xdc, 0xfe, 0x9f, 0x1d, 0x68, 0x3f, 0x71, 0x83, 0x84, 0x7e, 0x81,
-	0xa6, 0x9d, 0x7e, 0x74, 0xbf, 0xee, 0xd4, 0xad, 0xec, 0xda, 0xe1, 0x83, 0x75, 0x69, 0xae, 0x7e,
-	0xd7, 0x90, 0x84, 0x4d, 0xad, 0x03, 0xe8, 0x6e, 0x5d, 0x0f, 0x4b, 0x22, 0x32, 0xbc, 0xb7, 0x1e,
-	0xa9, 0x0a, 0xfa, 0x1b, 0xb4, 0xfd, 0x38, 0xa3, 0x87, 0x75, 0x7d, 0x5c, 0x91, 0x93, 0xe1, 0xe7,
-	0xeb, 0x13, 0xab, 0x04, 0xfe, 0x08, 0x60, 0xe7, 0xca, 0x48, 0xa3, 0x2f, 0xeb, 0xfa, 0x7b, 0xbd,
-	0xea, 0x0c, 0x1f, 0xbd, 0x35, 0xbf, 0x4a, 0xeb, 0x57, 0x68, 0x39, 0xed, 0x40, 0xb5, 0x2b, 0xba,
-	0x2a, 0x3f, 0xc3, 0x87, 0x6b, 0xf3, 0xaa, 0xe8, 0x17, 0xb0, 0x65, 0x74, 0x01, 0xd5, 0x2e, 0xeb,
-	0xb2, 0x76, 0x0d, 0xef, 0xaf, 0xc9, 0xf2, 0x71, 0xf7, 0x02, 0xdd, 0xff, 0x56, 0x58, 0xea, 0xf7,
-	0xff, 0x8a, 0x62, 0xd5, 0xef, 0xff, 0x2b, 0xfa, 0x65, 0xfa, 0x5f, 0x8f, 0x61, 0xfd, 0xfe, 0x5f,
-	0xd2, 0xbb, 0xfa, 0xfd, 0xbf, 0xac, 0x5b, 0xd1, 0x35, 0xf4, 0x57, 0x00, 0x7d, 0x6d, 0x3a, 0x51,
-	0x82, 0xe0, 0x39, 0x2d, 0x26, 0xe8, 0x51, 0x4d, 0xf1, 0xd6, 0x2c, 0x2b, 0xe0, 0x8e, 0xe9, 0x53,
-	0xf9, 0xea, 0xed, 0x1d, 0xf8, 0xb4, 0xc6, 0xc1, 0x5e, 0xf0, 0x75, 0xeb, 0x87, 0x2d, 0xab, 0x59,
-	0x4d, 0xf3, 0x73, 0xf7, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x2e, 0x63, 0xc4, 0xd3, 0x74, 0x0c,
-	0x00, 0x00,
+	// 1269 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xed, 0x6f, 0x1c, 0xb5,
+	0x13, 0xfe, 0x6d, 0x2e, 0xb9, 0xbb, 0xcc, 0xbd, 0xd6, 0xbf, 0x92, 0x6e, 0x17, 0x41, 0xc3, 0x22,
+	0xe8, 0x01, 0xe5, 0x12, 0xa5, 0x6f, 0xbc, 0x48, 0x14, 0x91, 0x96, 0xaa, 0x52, 0x1b, 0x45, 0x9b,
+	0x96, 0x0a, 0x84, 0x58, 0xdc, 0x5d, 0xf7, 0xce, 0xca, 0xdd, 0xda, 0xb5, 0xbd, 0x69, 0x40, 0x48,
+	0x7c, 0xea, 0x57, 0x3e, 0xf1, 0x01, 0x89, 0x7f, 0x16, 0xd9, 0x6b, 0x6f, 0xf6, 0x9a, 0x02, 0x7b,
+	0x41, 0xfd, 0x74, 0xeb, 0xf1, 0xf3, 0xcc, 0x8c, 0xc7, 0xe3, 0x67, 0x0e, 0xae, 0xa4, 0x82, 0x1e,
+	0x11, 0x21, 0xb7, 0xe4, 0x14, 0x0b, 0x92, 0x6e, 0x91, 0x63, 0x92, 0xe4, 0x8a, 0x89, 0x2d, 0x2e,
+	0x98, 0x62, 0xe5, 0x72, 0x6c, 0x96, 0xe8, 0xfd, 0x29, 0x96, 0x53, 0x9a, 0x30, 0xc1, 0xc7, 0x19,
+	0x9b, 0xe3, 0x74, 0xcc, 0x67, 0xf9, 0x84, 0x66, 0x72, 0xbc, 0x88, 0x0b, 0x2e, 0x4d, 0x18, 0x9b,
+	0xcc, 0x48, 0xe1, 0xe4, 0x49, 0xfe, 0x74, 0x4b, 0xd1, 0x39, 0x91, 0x0a, 0xcf, 0xb9, 0x05, 0x84,
+	0x96, 0xb8, 0xe5, 0xc2, 0x17, 0xe1, 0x8a, 0x55, 0x81, 0x09, 0x7f, 0x6b, 0x43, 0xef, 0x3e, 0xce,
+	0xb3, 0x64, 0x1a, 0x91, 0x67, 0x39, 0x91, 0x0a, 0x0d, 0xa1, 0x91, 0xcc, 0x53, 0xdf, 0xdb, 0xf4,
+	0x46, 0xeb, 0x91, 0xfe, 0x44, 0x08, 0x56, 0xb1, 0x98, 0x48, 0x7f, 0x65, 0xb3, 0x31, 0x5a, 0x8f,
+	0xcc, 0x37, 0xda, 0x83, 0x75, 0x41, 0x24, 0xcb, 0x45, 0x42, 0xa4, 0xdf, 0xd8, 0xf4, 0x46, 0x9d,
+	0x9d, 0xed, 0xf1, 0xdf, 0x25, 0x6e, 0xe3, 0x17, 0x21, 0xc7, 0x91, 0xe3, 0x45, 0x27, 0x2e, 0xd0,
+	0x25, 0xe8, 0x48, 0x95, 0xb2, 0x5c, 0xc5, 0x1c, 0xab, 0xa9, 0xbf, 0x6a, 0xa2, 0x43, 0x61, 0xda,
+	0xc7, 0x6a, 0x6a, 0x01, 0x44, 0x88, 0x02, 0xb0, 0x56, 0x02, 0x88, 0x10, 0x06, 0x30, 0x84, 0x06,
+	0xc9, 0x8e, 0xfc, 0xa6, 0x49, 0x52, 0x7f, 0xea, 0xbc, 0x73, 0x49, 0x84, 0xdf, 0x32, 0x58, 0xf3,
+	0x8d, 0x2e, 0x42, 0x5b, 0x61, 0x79, 0x18, 0xa7, 0x54, 0xf8, 0x6d, 0x63, 0x6f, 0xe9, 0xf5, 0x6d,
+	0x2a, 0xd0, 0x65, 0x18, 0xb8, 0x7c, 0xe2, 0x19, 0x9d, 0x53, 0x25, 0xfd, 0xf5, 0x4d, 0x6f, 0xd4,
+	0x8e, 0xfa, 0xce, 0x7c, 0xdf, 0x58, 0xd1, 0x36, 0x9c, 0x7f, 0x82, 0x25, 0x4d, 0x62, 0x2e, 0x58,
+	0x42, 0xa4, 0x8c, 0x93, 0x89, 0x60, 0x39, 0xf7, 0xc1, 0xa0, 0x91, 0xd9, 0xdb, 0x2f, 0xb6, 0x76,
+	0xcd, 0x0e, 0xba, 0x0d, 0xcd, 0x39, 0xcb, 0x33, 0x25, 0xfd, 0xce, 0x66, 0x63, 0xd4, 0xd9, 0xb9,
+	0x52, 0xb3, 0x54, 0x0f, 0x34, 0x29, 0xb2, 0x5c, 0x74, 0x17, 0x5a, 0x29, 0x39, 0xa2, 0xba, 0xe2,
+	0x5d, 0xe3, 0xe6, 0xe3, 0x9a, 0x6e, 0x6e, 0x1b, 0x56, 0xe4, 0xd8, 0x68, 0x0a, 0xe7, 0x32, 0xa2,
+	0x9e, 0x33, 0x71, 0x18, 0x53, 0xc9, 0x66, 0x58, 0x51, 0x96, 0xf9, 0x3d, 0x73, 0x89, 0x9f, 0xd7,
+	0x74, 0xb9, 0x57, 0xf0, 0xef, 0x39, 0xfa, 0x01, 0x27, 0x49, 0x34, 0xcc, 0x5e, 0xb2, 0xa2, 0x10,
+	0x7a, 0x19, 0x8b, 0x39, 0x3d, 0x62, 0x2a, 0x16, 0x8c, 0x29, 0xbf, 0x6f, 0x6a, 0xd4, 0xc9, 0xd8,
+	0xbe, 0xb6, 0x45, 0x8c, 0x29, 0x34, 0x82, 0x61, 0x4a, 0x9e, 0xe2, 0x7c, 0xa6, 0x62, 0x4e, 0xd3,
+	0x78, 0xce, 0x52, 0xe2, 0x0f, 0xcc, 0xd5, 0xf4, 0xad, 0x7d, 0x9f, 0xa6, 0x0f, 0x58, 0x4a, 0xaa,
+	0x48, 0xca, 0x93, 0x02, 0x39, 0x5c, 0x40, 0xde, 0xe3, 0x89, 0x41, 0xbe, 0x0b, 0xbd, 0x84, 0xe7,
+	0x92, 0x28, 0x77, 0x37, 0xe7, 0x0c, 0xac, 0x5b, 0x18, 0xed, 0xad, 0xbc, 0x05, 0x80, 0x67, 0x33,
+	0xf6, 0x3c, 0x4e, 0x30, 0x97, 0x3e, 0x32, 0x8d, 0xb3, 0x6e, 0x2c, 0xbb, 0x98, 0x4b, 0x14, 0x42,
+	0x37, 0xc1, 0x1c, 0x3f, 0xa1, 0x33, 0xaa, 0x28, 0x91, 0xfe, 0xff, 0x0d, 0x60, 0xc1, 0x86, 0xbe,
+	0x87, 0xbe, 0x6e, 0xab, 0x38, 0xc3, 0x73, 0x22, 0x39, 0x4e, 0x88, 0x7f, 0xde, 0x94, 0xf1, 0xfa,
+	0xb8, 0xde, 0x23, 0x1e, 0x3f, 0x92, 0x44, 0xec, 0x39, 0x72, 0xd4, 0xcb, 0xab, 0x4b, 0xdd, 0x91,
+	0x92, 0x24, 0x09, 0x9b, 0x73, 0xdd, 0x6a, 0x4f, 0xe9, 0x8c, 0xf8, 0x6f, 0x6c, 0x7a, 0xa3, 0x6e,
+	0xd4, 0xb7, 0xe6, 0xfd, 0xc2, 0x8a, 0x3e, 0x80, 0x21, 0xe6, 0x1c, 0x8b, 0x39, 0x13, 0x25, 0x72,
+	0xc3, 0x9c, 0x78, 0xe0, 0xec, 0x0e, 0xfa, 0x36, 0x40, 0x32, 0x25, 0xc9, 0x21, 0x67, 0x34, 0x53,
+	0xfe, 0x05, 0x73, 0x1d, 0x15, 0x4b, 0xf8, 0x2d, 0xf4, 0x16, 0x72, 0xd2, 0x2f, 0x66, 0xca, 0xa4,
+	0x8a, 0x73, 0x5a, 0x88, 0x42, 0x2f, 0x6a, 0xe9, 0xf5, 0x23, 0x9a, 0x96, 0x5b, 0x13, 0x9a, 0xfa,
+	0x2b, 0x27, 0x5b, 0x77, 0xa9, 0xd1, 0x0c, 0x49, 0x7f, 0x26, 0x46, 0x1a, 0x7a, 0x91, 0xf9, 0x0e,
+	0x7f, 0x84, 0xbe, 0x93, 0x1a, 0xc9, 0x59, 0x26, 0x09, 0xda, 0x83, 0x96, 0x7d, 0x43, 0xc6, 0x75,
+	0x67, 0xe7, 0x5a, 0xdd, 0xba, 0xd9, 0xf7, 0x75, 0xa0, 0xb0, 0x22, 0x91, 0x73, 0x12, 0xf6, 0xa0,
+	0xf3, 0x18, 0x53, 0x65, 0xa5, 0x2c, 0xfc, 0x01, 0xba, 0xc5, 0xf2, 0x35, 0x85, 0xbb, 0x0f, 0x83,
+	0x83, 0x69, 0xae, 0x52, 0xf6, 0x3c, 0x73, 0xea, 0xb9, 0x01, 0x4d, 0x49, 0x27, 0x19, 0x9e, 0x59,
+	0x01, 0xb5, 0x2b, 0xf4, 0x0e, 0x74, 0x27, 0x02, 0x27, 0x24, 0xe6, 0x44, 0x50, 0x56, 0x94, 0xab,
+	0x11, 0x75, 0x8c, 0x6d, 0xdf, 0x98, 0x42, 0x04, 0xc3, 0x13, 0x6f, 0x45, 0xc6, 0xe1, 0x14, 0x36,
+	0x1e, 0xf1, 0x54, 0x07, 0x2d, 0x45, 0xd3, 0x06, 0x5a, 0x10, 0x60, 0xef, 0x3f, 0x0b, 0x70, 0x78,
+	0x11, 0x2e, 0x9c, 0x8a, 0x64, 0x93, 0x18, 0x42, 0xff, 0x1b, 0x22, 0x24, 0x65, 0xee, 0x94, 0xe1,
+	0x47, 0x30, 0x28, 0x2d, 0xb6, 0xb6, 0x3e, 0xb4, 0x8e, 0x0a, 0x93, 0x3d, 0xb9, 0x5b, 0x86, 0x1f,
+	0x42, 0x57, 0xd7, 0xad, 0xcc, 0x3c, 0x80, 0x36, 0xcd, 0x14, 0x11, 0x47, 0xb6, 0x48, 0x8d, 0xa8,
+	0x5c, 0x87, 0x8f, 0xa1, 0x67, 0xb1, 0xd6, 0xed, 0xd7, 0xb0, 0x26, 0xb5, 0x61, 0xc9, 0x23, 0x3e,
+	0xc4, 0xf2, 0xb0, 0x70, 0x54, 0xd0, 0xc3, 0xcb, 0xd0, 0x3b, 0x30, 0x37, 0xf1, 0xea, 0x8b, 0x5a,
+	0x73, 0x17, 0xa5, 0x0f, 0xeb, 0x80, 0xf6, 0xf8, 0x87, 0xd0, 0xb9, 0x73, 0x4c, 0x12, 0x47, 0xbc,
+	0x01, 0xed, 0x94, 0xe0, 0x74, 0x46, 0x33, 0x62, 0x93, 0x0a, 0xc6, 0xc5, 0x24, 0x1e, 0xbb, 0x49,
+	0x3c, 0x7e, 0xe8, 0x26, 0x71, 0x54, 0x62, 0xdd, 0x5c, 0x5d, 0x39, 0x3d, 0x57, 0x1b, 0x27, 0x73,
+	0x35, 0xdc, 0x85, 0x6e, 0x11, 0xcc, 0x9e, 0x7f, 0x03, 0x9a, 0x2c, 0x57, 0x3c, 0x57, 0x26, 0x56,
+	0x37, 0xb2, 0x2b, 0xf4, 0x26, 0xac, 0x93, 0x63, 0xaa, 0xe2, 0x44, 0x6b, 0xe0, 0x8a, 0x39, 0x41,
+	0x5b, 0x1b, 0x76, 0x59, 0x4a, 0xc2, 0xf7, 0xe0, 0xdc, 0x6e, 0xf9, 0xa2, 0x2b, 0x73, 0x5d, 0x0f,
+	0x3d, 0x3b, 0xd7, 0x53, 0x2a, 0xc2, 0xf3, 0x80, 0xaa, 0x30, 0x7b, 0xdc, 0x67, 0xd0, 0x8f, 0x88,
+	0x54, 0x4c, 0x10, 0xc7, 0x7c, 0x00, 0xcd, 0x99, 0x79, 0xb7, 0xbe, 0xb7, 0x9c, 0xb8, 0x2d, 0xfc,
+	0xb1, 0x88, 0xac, 0x13, 0x97, 0xc8, 0xca, 0x49, 0x22, 0x18, 0x06, 0x65, 0xc8, 0xd7, 0xf4, 0x54,
+	0x5f, 0x78, 0xd0, 0xad, 0xee, 0xe8, 0x2c, 0xb8, 0x55, 0xb4, 0xb5, 0x48, 0x7f, 0xfe, 0x63, 0x49,
+	0x2b, 0xed, 0xd2, 0xa8, 0xb6, 0x0b, 0x1a, 0xc3, 0xaa, 0xfe, 0xdb, 0xe5, 0xaf, 0xfe, 0x6b, 0x27,
+	0x18, 0xdc, 0xce, 0x9f, 0x1d, 0x68, 0xdf, 0xb1, 0x09, 0xa3, 0x9f, 0xa0, 0x59, 0x94, 0x08, 0x9d,
+	0xad, 0xa4, 0xc1, 0x8d, 0x65, 0x69, 0xf6, 0x8e, 0xff, 0x87, 0x24, 0xac, 0x6a, 0x69, 0x44, 0x57,
+	0xeb, 0x7a, 0xa8, 0xe8, 0x6a, 0x70, 0x6d, 0x39, 0x52, 0x19, 0xf4, 0x57, 0x68, 0x3b, 0x85, 0x43,
+	0x37, 0xeb, 0xfa, 0x78, 0x49, 0x61, 0x83, 0x4f, 0x96, 0x27, 0x96, 0x09, 0xfc, 0xee, 0xc1, 0xe0,
+	0x25, 0x95, 0x43, 0x5f, 0xd4, 0x1e, 0xd5, 0xaf, 0x14, 0xe2, 0xe0, 0xd6, 0x99, 0xf9, 0x65, 0x5a,
+	0xbf, 0x40, 0xcb, 0xca, 0x29, 0xaa, 0x7d, 0xa3, 0x8b, 0x8a, 0x1c, 0xdc, 0x5c, 0x9a, 0x57, 0x46,
+	0x3f, 0x86, 0x35, 0x23, 0x95, 0xa8, 0xf6, 0xb5, 0x56, 0xe5, 0x3c, 0xb8, 0xbe, 0x24, 0xcb, 0xc5,
+	0xdd, 0xf6, 0x74, 0xff, 0x17, 0x5a, 0x5b, 0xbf, 0xff, 0x17, 0x44, 0x3c, 0xb8, 0xb1, 0x2c, 0xad,
+	0xda, 0xff, 0xfa, 0x19, 0xd6, 0xef, 0xff, 0xca, 0x08, 0x08, 0xae, 0x2d, 0x47, 0x2a, 0x83, 0xbe,
+	0xf0, 0x00, 0x4e, 0x14, 0x17, 0x7d, 0x5a, 0xd7, 0xcd, 0x29, 0x31, 0x0f, 0x3e, 0x3b, 0x0b, 0xb5,
+	0xda, 0x6f, 0x56, 0x6f, 0xeb, 0xf7, 0xdb, 0xe2, 0x4c, 0x08, 0x6e, 0x2e, 0xcd, 0x2b, 0xa3, 0xff,
+	0xe1, 0x41, 0x4f, 0x17, 0xe6, 0x40, 0x09, 0x82, 0xe7, 0x34, 0x9b, 0xa0, 0x5b, 0x35, 0xa7, 0xba,
+	0x66, 0x15, 0x93, 0xdd, 0x32, 0x5d, 0x36, 0x5f, 0x9e, 0xdd, 0x81, 0x4b, 0x6b, 0xe4, 0x6d, 0x7b,
+	0x5f, 0xb5, 0xbe, 0x5b, 0x2b, 0x94, 0xbb, 0x69, 0x7e, 0xae, 0xfe, 0x35, 0x00, 0xa1, 0x97, 0xd2,
+	0x11, 0xba, 0x0f, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -964,6 +1225,8 @@ type ExecutorClient interface {
 	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (Executor_StatsClient, error)
 	Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (*SignalResponse, error)
 	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error)
+	Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error)
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
 	// buf:lint:ignore RPC_REQUEST_RESPONSE_UNIQUE
 	ExecStreaming(ctx context.Context, opts ...grpc.CallOption) (Executor_ExecStreamingClient, error)
 }
@@ -1071,6 +1334,24 @@ func (c *executorClient) Exec(ctx context.Context, in *ExecRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *executorClient) Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error) {
+	out := new(CheckpointResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.executor.proto.Executor/Checkpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error) {
+	out := new(RestoreResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.executor.proto.Executor/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *executorClient) ExecStreaming(ctx context.Context, opts ...grpc.CallOption) (Executor_ExecStreamingClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_Executor_serviceDesc.Streams[1], "/hashicorp.nomad.plugins.executor.proto.Executor/ExecStreaming", opts...)
 	if err != nil {
@@ -1112,6 +1393,8 @@ type ExecutorServer interface {
 	Stats(*StatsRequest, Executor_StatsServer) error
 	Signal(context.Context, *SignalRequest) (*SignalResponse, error)
 	Exec(context.Context, *ExecRequest) (*ExecResponse, error)
+	Checkpoint(context.Context, *CheckpointRequest) (*CheckpointResponse, error)
+	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
 	// buf:lint:ignore RPC_REQUEST_RESPONSE_UNIQUE
 	ExecStreaming(Executor_ExecStreamingServer) error
 }
@@ -1144,6 +1427,12 @@ func (*UnimplementedExecutorServer) Signal(ctx context.Context, req *SignalReque
 func (*UnimplementedExecutorServer) Exec(ctx context.Context, req *ExecRequest) (*ExecResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Exec not implemented")
 }
+func (*UnimplementedExecutorServer) Checkpoint(ctx context.Context, req *CheckpointRequest) (*CheckpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Checkpoint not implemented")
+}
+func (*UnimplementedExecutorServer) Restore(ctx context.Context, req *RestoreRequest) (*RestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
 func (*UnimplementedExecutorServer) ExecStreaming(srv Executor_ExecStreamingServer) error {
 	return status.Errorf(codes.Unimplemented, "method ExecStreaming not implemented")
 }
@@ -1299,6 +1588,42 @@ func _Executor_Exec_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Executor_Checkpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).Checkpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.executor.proto.Executor/Checkpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).Checkpoint(ctx, req.(*CheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Executor_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.executor.proto.Executor/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Executor_ExecStreaming_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(ExecutorServer).ExecStreaming(&executorExecStreamingServer{stream})
 }
@@ -1357,6 +1682,14 @@ var _Executor_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Exec",
 			Handler:    _Executor_Exec_Handler,
 		},
+		{
+			MethodName: "Checkpoint",
+			Handler:    _Executor_Checkpoint_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _Executor_Restore_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,8 @@
+//go:build !linux || !cgo || !seccomp
+// +build !linux !cgo !seccomp
+
+package executor
+
+// SeccompSupported is true if Nomad was built with libseccomp, which
+// libcontainer requires to apply seccomp profiles.
+const SeccompSupported = false
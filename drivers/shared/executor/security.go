@@ -0,0 +1,110 @@
+package executor
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/opencontainers/runc/libcontainer/apparmor"
+)
+
+const (
+	// SecurityProfileUnconfined disables the profile a task would otherwise
+	// inherit from its driver's configuration.
+	SecurityProfileUnconfined = "unconfined"
+
+	// SeccompProfileDefault selects Docker's default seccomp profile.
+	SeccompProfileDefault = "default"
+
+	// SeccompViolationErrno lets system calls not allowed by a seccomp
+	// profile fail as the profile specifies. Such violations can't be
+	// reported as task events.
+	SeccompViolationErrno = "errno"
+
+	// SeccompViolationTrap kills tasks making system calls not allowed by
+	// their seccomp profile with SIGSYS, which is reported as a task event.
+	SeccompViolationTrap = "trap"
+)
+
+// SeccompProfile returns the seccomp profile, in Docker's JSON format, a
+// task runs with. The task's profile takes precedence over the driver's and
+// is a path within the task directory, while the driver's is a path on the
+// host. Either may also be "default" or "unconfined". A nil profile means no
+// syscall filtering.
+func SeccompProfile(taskDir, plugin, task string) ([]byte, error) {
+	profile, path := task, ""
+	switch {
+	case task == "":
+		profile, path = plugin, plugin
+	case task != SeccompProfileDefault && task != SecurityProfileUnconfined:
+		p, err := securejoin.SecureJoin(taskDir, task)
+		if err != nil {
+			return nil, fmt.Errorf("invalid seccomp_profile %q: %v", task, err)
+		}
+		path = p
+	}
+
+	switch profile {
+	case "", SecurityProfileUnconfined:
+		return nil, nil
+	}
+	if !SeccompSupported {
+		return nil, fmt.Errorf("seccomp profiles are not supported: Nomad was built without seccomp support")
+	}
+	if profile == SeccompProfileDefault {
+		return defaultSeccompProfile()
+	}
+
+	if !filepath.IsAbs(path) {
+		return nil, fmt.Errorf("seccomp_profile must be an absolute path, got %q", path)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seccomp profile: %v", err)
+	}
+	return data, nil
+}
+
+// ValidateSeccompViolation returns an error if the seccomp violation mode is
+// unknown.
+func ValidateSeccompViolation(violation string) error {
+	switch violation {
+	case "", SeccompViolationErrno, SeccompViolationTrap:
+		return nil
+	}
+	return fmt.Errorf("seccomp_violation must be %q or %q, got %q", SeccompViolationErrno, SeccompViolationTrap, violation)
+}
+
+// ApplySeccompViolation returns the seccomp profile with the action taken on
+// system calls it doesn't allow set according to the violation mode. The
+// task's mode takes precedence over the driver's. Only the profile's default
+// action is changed, since the errors returned by its explicit rules are
+// usually fallbacks the task relies on.
+func ApplySeccompViolation(profile []byte, plugin, task string) ([]byte, error) {
+	violation := task
+	if violation == "" {
+		violation = plugin
+	}
+	if profile == nil || violation != SeccompViolationTrap {
+		return profile, nil
+	}
+	return trapSeccompViolations(profile)
+}
+
+// AppArmorProfile returns the name of the AppArmor profile a task runs
+// with. The task's profile takes precedence over the driver's, and an empty
+// name means the task runs with the profile of the executor.
+func AppArmorProfile(plugin, task string) (string, error) {
+	profile := task
+	if profile == "" {
+		profile = plugin
+	}
+	if profile == "" || profile == SecurityProfileUnconfined {
+		return "", nil
+	}
+	if !apparmor.IsEnabled() {
+		return "", fmt.Errorf("AppArmor profile %q set but AppArmor is not enabled", profile)
+	}
+	return profile, nil
+}
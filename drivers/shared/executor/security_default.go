@@ -0,0 +1,24 @@
+//go:build !linux
+// +build !linux
+
+package executor
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+func defaultSeccompProfile() ([]byte, error) {
+	return nil, fmt.Errorf("seccomp profiles are only supported on Linux")
+}
+
+func trapSeccompViolations(profile []byte) ([]byte, error) {
+	return nil, fmt.Errorf("seccomp profiles are only supported on Linux")
+}
+
+// SeccompViolationEvent always returns nil as seccomp is only supported on
+// Linux.
+func SeccompViolationEvent(cfg *drivers.TaskConfig, result *drivers.ExitResult) *drivers.TaskEvent {
+	return nil
+}
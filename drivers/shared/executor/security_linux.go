@@ -0,0 +1,80 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/profiles/seccomp"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	lconfigs "github.com/opencontainers/runc/libcontainer/configs"
+	"github.com/opencontainers/runc/libcontainer/specconv"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"golang.org/x/sys/unix"
+)
+
+// defaultSeccompProfile returns Docker's default seccomp profile.
+func defaultSeccompProfile() ([]byte, error) {
+	profile := seccomp.DefaultProfile()
+	if profile == nil {
+		return nil, fmt.Errorf("default seccomp profile is not available")
+	}
+	return json.Marshal(profile)
+}
+
+// seccompConfig converts a seccomp profile in Docker's JSON format into
+// libcontainer's configuration. Rules conditional on capabilities apply
+// according to the task's capabilities.
+func seccompConfig(profile []byte, caps []string) (*lconfigs.Seccomp, error) {
+	linuxSeccomp, err := ociSeccomp(profile, caps)
+	if err != nil {
+		return nil, err
+	}
+	return specconv.SetupSeccomp(linuxSeccomp)
+}
+
+// ociSeccomp converts a seccomp profile in Docker's JSON format into the
+// OCI runtime spec's configuration.
+func ociSeccomp(profile []byte, caps []string) (*specs.LinuxSeccomp, error) {
+	spec := &specs.Spec{
+		Process: &specs.Process{
+			Capabilities: &specs.LinuxCapabilities{
+				Bounding: caps,
+			},
+		},
+	}
+	return seccomp.LoadProfile(string(profile), spec)
+}
+
+// trapSeccompViolations returns the seccomp profile with its default action
+// replaced by SCMP_ACT_TRAP if it returns an error.
+func trapSeccompViolations(profile []byte) ([]byte, error) {
+	var p seccomp.Seccomp
+	if err := json.Unmarshal(profile, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse seccomp profile: %v", err)
+	}
+	if p.DefaultAction != specs.ActErrno {
+		return profile, nil
+	}
+	p.DefaultAction = specs.ActTrap
+	return json.Marshal(&p)
+}
+
+// SeccompViolationEvent returns the event to emit if a task was killed by its
+// seccomp profile for making a disallowed system call, or nil otherwise.
+// Filters that return an error instead of killing the task are not detected.
+func SeccompViolationEvent(cfg *drivers.TaskConfig, result *drivers.ExitResult) *drivers.TaskEvent {
+	if result == nil || result.Signal != int(unix.SIGSYS) {
+		return nil
+	}
+	return &drivers.TaskEvent{
+		TaskID:    cfg.ID,
+		AllocID:   cfg.AllocID,
+		TaskName:  cfg.Name,
+		Timestamp: time.Now(),
+		Message:   "Task was killed by its seccomp profile for making a disallowed system call",
+		Annotations: map[string]string{
+			"signal": "SIGSYS",
+		},
+	}
+}
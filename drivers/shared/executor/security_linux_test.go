@@ -0,0 +1,126 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/plugins/drivers"
+	lconfigs "github.com/opencontainers/runc/libcontainer/configs"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestExecutor_configureSecurityProfiles(t *testing.T) {
+	profile := []byte(`{
+  "defaultAction": "SCMP_ACT_ERRNO",
+  "syscalls": [
+    {
+      "names": ["read", "write"],
+      "action": "SCMP_ACT_ALLOW"
+    },
+    {
+      "names": ["mount"],
+      "action": "SCMP_ACT_ALLOW",
+      "includes": {"caps": ["CAP_SYS_ADMIN"]}
+    }
+  ]
+}`)
+
+	command := &ExecCommand{
+		TaskDir:         "/tmp/task",
+		ModePID:         "private",
+		ModeIPC:         "private",
+		Capabilities:    []string{"CAP_CHOWN"},
+		SeccompProfile:  profile,
+		AppArmorProfile: "nomad-tasks",
+	}
+
+	cfg := &lconfigs.Config{}
+	require.NoError(t, configureIsolation(cfg, command))
+	require.Equal(t, "nomad-tasks", cfg.AppArmorProfile)
+	require.NotNil(t, cfg.Seccomp)
+	require.Equal(t, lconfigs.Errno, cfg.Seccomp.DefaultAction)
+
+	// Rules conditional on capabilities the task lacks don't apply
+	var names []string
+	for _, s := range cfg.Seccomp.Syscalls {
+		names = append(names, s.Name)
+		require.Equal(t, lconfigs.Allow, s.Action)
+	}
+	require.Equal(t, []string{"read", "write"}, names)
+
+	command.Capabilities = append(command.Capabilities, "CAP_SYS_ADMIN")
+	cfg = &lconfigs.Config{}
+	require.NoError(t, configureIsolation(cfg, command))
+	require.Len(t, cfg.Seccomp.Syscalls, 3)
+
+	command.SeccompProfile = []byte(`{"defaultAction": "SCMP_ACT_BOGUS"}`)
+	require.Error(t, configureIsolation(&lconfigs.Config{}, command))
+
+	// No profiles by default
+	cfg = &lconfigs.Config{}
+	require.NoError(t, configureIsolation(cfg, &ExecCommand{ModePID: "private"}))
+	require.Nil(t, cfg.Seccomp)
+	require.Empty(t, cfg.AppArmorProfile)
+}
+
+func TestSeccompViolationEvent(t *testing.T) {
+	cfg := &drivers.TaskConfig{ID: "id", AllocID: "alloc", Name: "web"}
+
+	require.Nil(t, SeccompViolationEvent(cfg, &drivers.ExitResult{ExitCode: 1}))
+	require.Nil(t, SeccompViolationEvent(cfg, &drivers.ExitResult{Signal: int(unix.SIGKILL)}))
+
+	ev := SeccompViolationEvent(cfg, &drivers.ExitResult{Signal: int(unix.SIGSYS)})
+	require.NotNil(t, ev)
+	require.Equal(t, "id", ev.TaskID)
+	require.Equal(t, "alloc", ev.AllocID)
+	require.Equal(t, "web", ev.TaskName)
+	require.Contains(t, ev.Message, "seccomp")
+}
+
+func TestApplySeccompViolation(t *testing.T) {
+	profile := []byte(`{
+  "defaultAction": "SCMP_ACT_ERRNO",
+  "syscalls": [
+    {
+      "names": ["read", "write"],
+      "action": "SCMP_ACT_ALLOW"
+    },
+    {
+      "names": ["clone3"],
+      "action": "SCMP_ACT_ERRNO",
+      "errnoRet": 38
+    }
+  ]
+}`)
+
+	// Errors are kept unless tasks trap violations
+	out, err := ApplySeccompViolation(profile, "", "")
+	require.NoError(t, err)
+	require.Equal(t, profile, out)
+
+	out, err = ApplySeccompViolation(profile, SeccompViolationTrap, SeccompViolationErrno)
+	require.NoError(t, err)
+	require.Equal(t, profile, out)
+
+	out, err = ApplySeccompViolation(nil, SeccompViolationTrap, "")
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	// Only the default action traps, explicit errors are kept
+	out, err = ApplySeccompViolation(profile, SeccompViolationTrap, "")
+	require.NoError(t, err)
+
+	cfg, err := seccompConfig(out, nil)
+	require.NoError(t, err)
+	require.Equal(t, lconfigs.Trap, cfg.DefaultAction)
+	for _, s := range cfg.Syscalls {
+		if s.Name == "clone3" {
+			require.Equal(t, lconfigs.Errno, s.Action)
+		} else {
+			require.Equal(t, lconfigs.Allow, s.Action)
+		}
+	}
+
+	_, err = ApplySeccompViolation([]byte(`{`), "", SeccompViolationTrap)
+	require.Error(t, err)
+}
@@ -0,0 +1,94 @@
+package executor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/apparmor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeccompProfile(t *testing.T) {
+	taskDir := t.TempDir()
+	hostDir := t.TempDir()
+
+	taskProfile := []byte(`{"defaultAction": "SCMP_ACT_ALLOW"}`)
+	require.NoError(t, os.MkdirAll(filepath.Join(taskDir, "local"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(taskDir, "local", "profile.json"), taskProfile, 0644))
+
+	hostProfile := []byte(`{"defaultAction": "SCMP_ACT_ERRNO"}`)
+	hostPath := filepath.Join(hostDir, "profile.json")
+	require.NoError(t, ioutil.WriteFile(hostPath, hostProfile, 0644))
+
+	// Without a profile, or with an unconfined one, there's no filtering
+	for _, tc := range []struct{ plugin, task string }{
+		{"", ""},
+		{"unconfined", ""},
+		{hostPath, "unconfined"},
+	} {
+		profile, err := SeccompProfile(taskDir, tc.plugin, tc.task)
+		require.NoError(t, err)
+		require.Nil(t, profile)
+	}
+
+	cases := []struct {
+		name   string
+		plugin string
+		task   string
+		exp    []byte
+		err    string
+	}{
+		{name: "plugin", plugin: hostPath, exp: hostProfile},
+		{name: "task overrides plugin", plugin: hostPath, task: "local/profile.json", exp: taskProfile},
+		{name: "task absolute path", task: "/local/profile.json", exp: taskProfile},
+		{name: "task path escaping", task: "../" + filepath.Base(hostDir) + "/profile.json", err: "failed to read"},
+		{name: "missing", plugin: filepath.Join(hostDir, "missing.json"), err: "failed to read"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			profile, err := SeccompProfile(taskDir, c.plugin, c.task)
+			switch {
+			case !SeccompSupported:
+				require.EqualError(t, err, "seccomp profiles are not supported: Nomad was built without seccomp support")
+			case c.err != "":
+				require.Error(t, err)
+				require.Contains(t, err.Error(), c.err)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, c.exp, profile)
+			}
+		})
+	}
+}
+
+func TestAppArmorProfile(t *testing.T) {
+	profile, err := AppArmorProfile("", "")
+	require.NoError(t, err)
+	require.Empty(t, profile)
+
+	profile, err = AppArmorProfile("nomad-tasks", "unconfined")
+	require.NoError(t, err)
+	require.Empty(t, profile)
+
+	profile, err = AppArmorProfile("nomad-tasks", "")
+	if !apparmor.IsEnabled() {
+		require.Error(t, err)
+		return
+	}
+	require.NoError(t, err)
+	require.Equal(t, "nomad-tasks", profile)
+
+	profile, err = AppArmorProfile("nomad-tasks", "custom")
+	require.NoError(t, err)
+	require.Equal(t, "custom", profile)
+}
+
+func TestValidateSeccompViolation(t *testing.T) {
+	require.NoError(t, ValidateSeccompViolation(""))
+	require.NoError(t, ValidateSeccompViolation(SeccompViolationErrno))
+	require.NoError(t, ValidateSeccompViolation(SeccompViolationTrap))
+	require.Error(t, ValidateSeccompViolation("kill"))
+}
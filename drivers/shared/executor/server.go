@@ -20,7 +20,23 @@ type grpcExecutorServer struct {
 }
 
 func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchRequest) (*proto.LaunchResponse, error) {
-	ps, err := s.impl.Launch(&ExecCommand{
+	ps, err := s.impl.Launch(commandFromLaunchRequest(req))
+	if err != nil {
+		return nil, err
+	}
+
+	process, err := processStateToProto(ps)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.LaunchResponse{
+		Process: process,
+	}, nil
+}
+
+func commandFromLaunchRequest(req *proto.LaunchRequest) *ExecCommand {
+	return &ExecCommand{
 		Cmd:                req.Cmd,
 		Args:               req.Args,
 		Resources:          drivers.ResourcesFromProto(req.Resources),
@@ -38,20 +54,11 @@ func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchReques
 		ModePID:            req.DefaultPidMode,
 		ModeIPC:            req.DefaultIpcMode,
 		Capabilities:       req.Capabilities,
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	process, err := processStateToProto(ps)
-	if err != nil {
-		return nil, err
+		UserNamespace:      userNamespaceFromProto(req.UserNamespace),
+		SeccompProfile:     req.SeccompProfile,
+		AppArmorProfile:    req.ApparmorProfile,
+		Checkpoint:         req.Checkpoint,
 	}
-
-	return &proto.LaunchResponse{
-		Process: process,
-	}, nil
 }
 
 func (s *grpcExecutorServer) Wait(ctx context.Context, req *proto.WaitRequest) (*proto.WaitResponse, error) {
@@ -175,3 +182,26 @@ func (s *grpcExecutorServer) ExecStreaming(server proto.Executor_ExecStreamingSe
 		msg.Setup.Command, msg.Setup.Tty,
 		server)
 }
+
+func (s *grpcExecutorServer) Checkpoint(ctx context.Context, req *proto.CheckpointRequest) (*proto.CheckpointResponse, error) {
+	if err := s.impl.Checkpoint(req.Dir); err != nil {
+		return nil, err
+	}
+	return &proto.CheckpointResponse{}, nil
+}
+
+func (s *grpcExecutorServer) Restore(ctx context.Context, req *proto.RestoreRequest) (*proto.RestoreResponse, error) {
+	ps, err := s.impl.Restore(commandFromLaunchRequest(req.Launch), req.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	process, err := processStateToProto(ps)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.RestoreResponse{
+		Process: process,
+	}, nil
+}
@@ -140,6 +140,28 @@ func processStateFromProto(pb *proto.ProcessState) (*ProcessState, error) {
 	}, nil
 }
 
+func userNamespaceToProto(userns *UserNamespace) *proto.UserNamespace {
+	if userns == nil {
+		return nil
+	}
+	return &proto.UserNamespace{
+		HostUid: userns.HostUID,
+		HostGid: userns.HostGID,
+		Size:    userns.Size,
+	}
+}
+
+func userNamespaceFromProto(pb *proto.UserNamespace) *UserNamespace {
+	if pb == nil {
+		return nil
+	}
+	return &UserNamespace{
+		HostUID: pb.HostUid,
+		HostGID: pb.HostGid,
+		Size:    pb.Size,
+	}
+}
+
 // IsolationMode returns the namespace isolation mode as determined from agent
 // plugin configuration and task driver configuration. The task configuration
 // takes precedence, if it is configured.
@@ -43,12 +43,9 @@ func init() {
 
 		plugin.Serve(&plugin.ServeConfig{
 			HandshakeConfig: base.Handshake,
-			Plugins: GetPluginMap(
-				logger,
-				executorConfig.FSIsolation,
-			),
-			GRPCServer: plugin.DefaultGRPCServer,
-			Logger:     logger,
+			Plugins:         getServerPluginMap(logger, &executorConfig),
+			GRPCServer:      plugin.DefaultGRPCServer,
+			Logger:          logger,
 		})
 		os.Exit(0)
 	}
@@ -0,0 +1,166 @@
+// Package ociimage unpacks OCI images into root filesystems that task drivers
+// can use in place of a chroot built from the host.
+package ociimage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	hclog "github.com/hashicorp/go-hclog"
+	digest "github.com/opencontainers/go-digest"
+)
+
+const (
+	// rootfsDir and configFile are the names of the unpacked root filesystem
+	// and image configuration within an image's cache directory
+	rootfsDir  = "rootfs"
+	configFile = "config.json"
+)
+
+// Config is the subset of an image's configuration used to run it.
+type Config struct {
+	User       string   `json:",omitempty"`
+	Env        []string `json:",omitempty"`
+	Entrypoint []string `json:",omitempty"`
+	Cmd        []string `json:",omitempty"`
+	WorkingDir string   `json:",omitempty"`
+}
+
+// Image is an image that has been unpacked into the store.
+type Image struct {
+	// Digest is the digest of the image's manifest
+	Digest digest.Digest
+
+	// Config is the image's runtime configuration
+	Config Config
+
+	// RootFS is the path of the unpacked root filesystem. It is shared by
+	// every user of the image and must not be modified.
+	RootFS string
+}
+
+// Store unpacks images into a directory on disk, caching them by the digest
+// of their manifest so that each image is only unpacked once.
+type Store struct {
+	dir    string
+	logger hclog.Logger
+
+	// locks serializes unpacking of each image digest
+	locks   map[digest.Digest]*sync.Mutex
+	locksMu sync.Mutex
+}
+
+// NewStore returns a Store that caches images in dir.
+func NewStore(dir string, logger hclog.Logger) *Store {
+	return &Store{
+		dir:    dir,
+		logger: logger.Named("ociimage"),
+		locks:  make(map[digest.Digest]*sync.Mutex),
+	}
+}
+
+// Unpack unpacks the image at path, which is either an OCI image layout
+// directory or a tarball of one, and returns it. Images that have already
+// been unpacked are returned from the cache.
+func (s *Store) Unpack(path string) (*Image, error) {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create image cache: %v", err)
+	}
+
+	l, err := openLayout(path, s.dir)
+	if err != nil {
+		return nil, err
+	}
+	defer l.close()
+
+	manifest, manifestDigest, err := l.resolveManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	lock := s.lock(manifestDigest)
+	lock.Lock()
+	defer lock.Unlock()
+
+	imageDir := filepath.Join(s.dir, manifestDigest.Algorithm().String(), manifestDigest.Encoded())
+	if img, err := s.load(imageDir, manifestDigest); err == nil {
+		s.logger.Trace("using cached image", "digest", manifestDigest)
+		return img, nil
+	}
+
+	s.logger.Debug("unpacking image", "path", path, "digest", manifestDigest)
+
+	// Unpack into a temporary directory and move it into place once
+	// complete so that a partially unpacked image is never used.
+	tmpDir, err := ioutil.TempDir(s.dir, "unpack-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	config, err := l.readConfig(manifest.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	rootfs := filepath.Join(tmpDir, rootfsDir)
+	if err := os.Mkdir(rootfs, 0755); err != nil {
+		return nil, err
+	}
+	for _, layer := range manifest.Layers {
+		if err := l.applyLayer(rootfs, layer); err != nil {
+			return nil, fmt.Errorf("failed to unpack layer %s: %v", layer.Digest, err)
+		}
+	}
+
+	buf, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(tmpDir, configFile), buf, 0600); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(imageDir), 0700); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmpDir, imageDir); err != nil {
+		return nil, fmt.Errorf("failed to move unpacked image into cache: %v", err)
+	}
+
+	return s.load(imageDir, manifestDigest)
+}
+
+// load returns the unpacked image in dir
+func (s *Store) load(dir string, d digest.Digest) (*Image, error) {
+	buf, err := ioutil.ReadFile(filepath.Join(dir, configFile))
+	if err != nil {
+		return nil, err
+	}
+
+	img := &Image{
+		Digest: d,
+		RootFS: filepath.Join(dir, rootfsDir),
+	}
+	if err := json.Unmarshal(buf, &img.Config); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// lock returns the lock for unpacking the image with digest d
+func (s *Store) lock(d digest.Digest) *sync.Mutex {
+	s.locksMu.Lock()
+	defer s.locksMu.Unlock()
+
+	l, ok := s.locks[d]
+	if !ok {
+		l = &sync.Mutex{}
+		s.locks[d] = l
+	}
+	return l
+}
@@ -0,0 +1,299 @@
+package ociimage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+// testEntry is a file in a test layer
+type testEntry struct {
+	name     string
+	typeflag byte
+	content  string
+	linkname string
+}
+
+// testLayout writes OCI image layouts for tests
+type testLayout struct {
+	t   *testing.T
+	dir string
+}
+
+func newTestLayout(t *testing.T) *testLayout {
+	dir := t.TempDir()
+	l := &testLayout{t: t, dir: dir}
+	l.writeJSON(filepath.Join(dir, specs.ImageLayoutFile), specs.ImageLayout{Version: specs.ImageLayoutVersion})
+	return l
+}
+
+func (l *testLayout) writeJSON(path string, v interface{}) {
+	buf, err := json.Marshal(v)
+	require.NoError(l.t, err)
+	require.NoError(l.t, ioutil.WriteFile(path, buf, 0644))
+}
+
+func (l *testLayout) writeBlob(mediaType string, buf []byte) specs.Descriptor {
+	d := digest.FromBytes(buf)
+	dir := filepath.Join(l.dir, "blobs", d.Algorithm().String())
+	require.NoError(l.t, os.MkdirAll(dir, 0755))
+	require.NoError(l.t, ioutil.WriteFile(filepath.Join(dir, d.Encoded()), buf, 0644))
+	return specs.Descriptor{MediaType: mediaType, Digest: d, Size: int64(len(buf))}
+}
+
+func (l *testLayout) writeJSONBlob(mediaType string, v interface{}) specs.Descriptor {
+	buf, err := json.Marshal(v)
+	require.NoError(l.t, err)
+	return l.writeBlob(mediaType, buf)
+}
+
+func (l *testLayout) layer(entries ...testEntry) specs.Descriptor {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{
+			Name:     e.name,
+			Typeflag: e.typeflag,
+			Linkname: e.linkname,
+			Mode:     0755,
+			Size:     int64(len(e.content)),
+		}
+		if e.typeflag == 0 {
+			hdr.Typeflag = tar.TypeReg
+			hdr.Mode = 0644
+		}
+		require.NoError(l.t, tw.WriteHeader(hdr))
+		if hdr.Typeflag == tar.TypeReg {
+			_, err := tw.Write([]byte(e.content))
+			require.NoError(l.t, err)
+		}
+	}
+	require.NoError(l.t, tw.Close())
+	require.NoError(l.t, gz.Close())
+	return l.writeBlob(specs.MediaTypeImageLayerGzip, buf.Bytes())
+}
+
+// image writes an image with the given layers and makes it the layout's
+// only manifest
+func (l *testLayout) image(config specs.ImageConfig, layers ...specs.Descriptor) digest.Digest {
+	cfg := l.writeJSONBlob(specs.MediaTypeImageConfig, specs.Image{
+		OS:           "linux",
+		Architecture: "amd64",
+		Config:       config,
+	})
+	manifest := l.writeJSONBlob(specs.MediaTypeImageManifest, specs.Manifest{
+		Config: cfg,
+		Layers: layers,
+	})
+	l.writeJSON(filepath.Join(l.dir, "index.json"), specs.Index{
+		Manifests: []specs.Descriptor{manifest},
+	})
+	return manifest.Digest
+}
+
+// tarball writes the layout into a tarball and returns its path
+func (l *testLayout) tarball() string {
+	path := filepath.Join(l.t.TempDir(), "image.tar")
+	f, err := os.Create(path)
+	require.NoError(l.t, err)
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+	err = filepath.Walk(l.dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(l.dir, p)
+		buf, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: rel, Mode: 0644, Size: int64(len(buf))}); err != nil {
+			return err
+		}
+		_, err = tw.Write(buf)
+		return err
+	})
+	require.NoError(l.t, err)
+	require.NoError(l.t, tw.Close())
+	return path
+}
+
+func readFile(t *testing.T, path string) string {
+	buf, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	return string(buf)
+}
+
+func TestStore_Unpack(t *testing.T) {
+	l := newTestLayout(t)
+	d := l.image(specs.ImageConfig{
+		User:       "1000",
+		Env:        []string{"PATH=/bin"},
+		Entrypoint: []string{"/bin/app"},
+		Cmd:        []string{"serve"},
+	},
+		l.layer(
+			testEntry{name: "bin/", typeflag: tar.TypeDir},
+			testEntry{name: "bin/app", content: "v1"},
+			testEntry{name: "etc/", typeflag: tar.TypeDir},
+			testEntry{name: "etc/removed", content: "gone"},
+			testEntry{name: "data/", typeflag: tar.TypeDir},
+			testEntry{name: "data/old", content: "old"},
+		),
+		l.layer(
+			testEntry{name: "bin/app", content: "v2"},
+			testEntry{name: "bin/app-link", typeflag: tar.TypeLink, linkname: "bin/app"},
+			testEntry{name: "etc/.wh.removed"},
+			testEntry{name: "data/new", content: "new"},
+			testEntry{name: "data/.wh..wh..opq"},
+			testEntry{name: "sh", typeflag: tar.TypeSymlink, linkname: "/bin/app"},
+		),
+	)
+
+	s := NewStore(t.TempDir(), testlog.HCLogger(t))
+	img, err := s.Unpack(l.dir)
+	require.NoError(t, err)
+
+	require.Equal(t, d, img.Digest)
+	require.Equal(t, Config{
+		User:       "1000",
+		Env:        []string{"PATH=/bin"},
+		Entrypoint: []string{"/bin/app"},
+		Cmd:        []string{"serve"},
+	}, img.Config)
+
+	// Upper layers replace files of lower layers
+	require.Equal(t, "v2", readFile(t, filepath.Join(img.RootFS, "bin/app")))
+	require.Equal(t, "v2", readFile(t, filepath.Join(img.RootFS, "bin/app-link")))
+
+	// Whiteouts remove files of lower layers
+	require.NoFileExists(t, filepath.Join(img.RootFS, "etc/removed"))
+	require.NoFileExists(t, filepath.Join(img.RootFS, "etc/.wh.removed"))
+
+	// Opaque whiteouts only keep the directory's content from their layer
+	require.NoFileExists(t, filepath.Join(img.RootFS, "data/old"))
+	require.Equal(t, "new", readFile(t, filepath.Join(img.RootFS, "data/new")))
+
+	// Symlinks are unpacked as is
+	target, err := os.Readlink(filepath.Join(img.RootFS, "sh"))
+	require.NoError(t, err)
+	require.Equal(t, "/bin/app", target)
+
+	// Unpacking again returns the cached image
+	require.NoError(t, os.Remove(filepath.Join(l.dir, specs.ImageLayoutFile)))
+	l.writeJSON(filepath.Join(l.dir, specs.ImageLayoutFile), specs.ImageLayout{Version: specs.ImageLayoutVersion})
+	cached, err := s.Unpack(l.dir)
+	require.NoError(t, err)
+	require.Equal(t, img, cached)
+}
+
+func TestStore_Unpack_Tarball(t *testing.T) {
+	l := newTestLayout(t)
+	l.image(specs.ImageConfig{}, l.layer(testEntry{name: "hello", content: "world"}))
+
+	s := NewStore(t.TempDir(), testlog.HCLogger(t))
+	img, err := s.Unpack(l.tarball())
+	require.NoError(t, err)
+	require.Equal(t, "world", readFile(t, filepath.Join(img.RootFS, "hello")))
+
+	// The extracted layout is removed
+	entries, err := ioutil.ReadDir(s.dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "sha256", entries[0].Name())
+}
+
+func TestStore_Unpack_SymlinkEscape(t *testing.T) {
+	outside := t.TempDir()
+
+	l := newTestLayout(t)
+	l.image(specs.ImageConfig{}, l.layer(
+		testEntry{name: "escape", typeflag: tar.TypeSymlink, linkname: outside},
+		testEntry{name: "escape/file", content: "pwned"},
+	))
+
+	s := NewStore(t.TempDir(), testlog.HCLogger(t))
+	img, err := s.Unpack(l.dir)
+	require.NoError(t, err)
+
+	// The file is written within the root filesystem rather than through
+	// the symlink
+	require.NoFileExists(t, filepath.Join(outside, "file"))
+	require.Equal(t, "pwned", readFile(t, filepath.Join(img.RootFS, outside, "file")))
+}
+
+func TestStore_Unpack_DigestMismatch(t *testing.T) {
+	l := newTestLayout(t)
+	layer := l.layer(testEntry{name: "hello", content: "world"})
+	l.image(specs.ImageConfig{}, layer)
+
+	// Corrupt the layer
+	path := filepath.Join(l.dir, "blobs", "sha256", layer.Digest.Encoded())
+	require.NoError(t, ioutil.WriteFile(path, []byte("corrupt"), 0644))
+
+	s := NewStore(t.TempDir(), testlog.HCLogger(t))
+	_, err := s.Unpack(l.dir)
+	require.Error(t, err)
+}
+
+func TestStore_Unpack_NotLayout(t *testing.T) {
+	s := NewStore(t.TempDir(), testlog.HCLogger(t))
+	_, err := s.Unpack(t.TempDir())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not an OCI image layout")
+}
+
+func TestImage_PopulateRootFS(t *testing.T) {
+	l := newTestLayout(t)
+	l.image(specs.ImageConfig{}, l.layer(
+		testEntry{name: "bin/", typeflag: tar.TypeDir},
+		testEntry{name: "bin/app", content: "app"},
+		testEntry{name: "local/", typeflag: tar.TypeDir},
+		testEntry{name: "local/from-image", content: "image"},
+		testEntry{name: "sh", typeflag: tar.TypeSymlink, linkname: "/bin/app"},
+	))
+
+	s := NewStore(t.TempDir(), testlog.HCLogger(t))
+	img, err := s.Unpack(l.dir)
+	require.NoError(t, err)
+
+	// Build a task directory with a chroot and a local directory
+	taskDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(taskDir, "usr/bin"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(taskDir, "usr/bin/host"), []byte("host"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(taskDir, "local"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(taskDir, "local/artifact"), []byte("artifact"), 0644))
+
+	require.NoError(t, img.PopulateRootFS(taskDir, []string{"local"}))
+
+	// The chroot is replaced by the image
+	require.NoDirExists(t, filepath.Join(taskDir, "usr"))
+	require.Equal(t, "app", readFile(t, filepath.Join(taskDir, "bin/app")))
+	target, err := os.Readlink(filepath.Join(taskDir, "sh"))
+	require.NoError(t, err)
+	require.Equal(t, "/bin/app", target)
+
+	// Preserved directories are untouched
+	require.Equal(t, "artifact", readFile(t, filepath.Join(taskDir, "local/artifact")))
+	require.NoFileExists(t, filepath.Join(taskDir, "local/from-image"))
+
+	// Modifying the task directory doesn't modify the cached image
+	require.NoError(t, ioutil.WriteFile(filepath.Join(taskDir, "bin/app"), []byte("modified"), 0644))
+	require.Equal(t, "app", readFile(t, filepath.Join(img.RootFS, "bin/app")))
+
+	// Populating again replaces the previous content
+	require.NoError(t, img.PopulateRootFS(taskDir, []string{"local"}))
+	require.Equal(t, "app", readFile(t, filepath.Join(taskDir, "bin/app")))
+}
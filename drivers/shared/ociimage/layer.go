@@ -0,0 +1,177 @@
+package ociimage
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// whiteoutPrefix marks a file that deletes the entry of the same name,
+	// without the prefix, from lower layers
+	whiteoutPrefix = ".wh."
+
+	// whiteoutOpaque marks a directory whose contents from lower layers are
+	// hidden
+	whiteoutOpaque = whiteoutPrefix + whiteoutPrefix + ".opq"
+)
+
+// applyLayer unpacks the layer described by desc on top of rootfs, applying
+// its whiteouts to the content of lower layers.
+func (l *layout) applyLayer(rootfs string, desc specs.Descriptor) error {
+	// Layers are either uncompressed or gzipped tarballs, which is detected
+	// from their content rather than their media type
+	if strings.HasSuffix(desc.MediaType, "+zstd") {
+		return fmt.Errorf("unsupported layer media type %q", desc.MediaType)
+	}
+
+	blob, err := l.openBlob(desc)
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+
+	r, err := decompress(blob)
+	if err != nil {
+		return err
+	}
+
+	// written tracks the entries of this layer so that an opaque whiteout
+	// only removes content from lower layers
+	written := make(map[string]struct{})
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		name := filepath.Clean("/" + hdr.Name)
+		if name == "/" {
+			continue
+		}
+		dir, base := filepath.Split(name)
+
+		// Resolve the parent directory within the root filesystem so that
+		// symlinks from the image can't be used to write outside of it
+		parent, err := securejoin.SecureJoin(rootfs, dir)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case base == whiteoutOpaque:
+			if err := removeLowerEntries(parent, filepath.Join(rootfs, dir), written); err != nil {
+				return err
+			}
+			continue
+		case strings.HasPrefix(base, whiteoutPrefix):
+			if err := os.RemoveAll(filepath.Join(parent, strings.TrimPrefix(base, whiteoutPrefix))); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(parent, 0755); err != nil {
+			return err
+		}
+
+		path := filepath.Join(parent, base)
+		if err := applyEntry(rootfs, path, hdr, tr); err != nil {
+			return fmt.Errorf("failed to unpack %q: %v", hdr.Name, err)
+		}
+		written[filepath.Join(rootfs, name)] = struct{}{}
+	}
+
+	// Drain the blob so that its digest is verified
+	_, err = io.Copy(ioutil.Discard, blob)
+	return err
+}
+
+// applyEntry creates the entry for hdr at path
+func applyEntry(rootfs, path string, hdr *tar.Header, r io.Reader) error {
+	// Entries replace those from lower layers, other than directories which
+	// are merged
+	if fi, err := os.Lstat(path); err == nil {
+		if !(fi.IsDir() && hdr.Typeflag == tar.TypeDir) {
+			if err := os.RemoveAll(path); err != nil {
+				return err
+			}
+		}
+	}
+
+	mode := hdr.FileInfo().Mode()
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		if err := os.MkdirAll(path, 0755); err != nil {
+			return err
+		}
+	case tar.TypeReg, tar.TypeRegA:
+		if err := writeFile(path, r, 0600); err != nil {
+			return err
+		}
+	case tar.TypeSymlink:
+		if err := os.Symlink(hdr.Linkname, path); err != nil {
+			return err
+		}
+		return lchown(path, hdr)
+	case tar.TypeLink:
+		target, err := securejoin.SecureJoin(rootfs, hdr.Linkname)
+		if err != nil {
+			return err
+		}
+		return os.Link(target, path)
+	default:
+		// Device nodes and fifos are provided by the container runtime
+		return nil
+	}
+
+	if err := lchown(path, hdr); err != nil {
+		return err
+	}
+	if err := os.Chmod(path, mode.Perm()|mode&(os.ModeSetuid|os.ModeSetgid|os.ModeSticky)); err != nil {
+		return err
+	}
+	return os.Chtimes(path, hdr.ModTime, hdr.ModTime)
+}
+
+// lchown sets the ownership of path to that of hdr if the process is able to
+func lchown(path string, hdr *tar.Header) error {
+	if os.Geteuid() != 0 {
+		return nil
+	}
+	return os.Lchown(path, hdr.Uid, hdr.Gid)
+}
+
+// removeLowerEntries removes the contents of dir that were not written by
+// the current layer. dirInRoot is the unresolved path of dir used to key
+// written.
+func removeLowerEntries(dir, dirInRoot string, written map[string]struct{}) error {
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if _, ok := written[filepath.Join(dirInRoot, e.Name())]; ok {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dir, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
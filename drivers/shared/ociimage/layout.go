@@ -0,0 +1,285 @@
+package ociimage
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// mediaTypeDockerManifestList and mediaTypeDockerManifest are the Docker
+	// equivalents of the OCI index and manifest media types, which may be
+	// found in layouts exported by Docker tooling
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+
+	// maxIndexDepth bounds the nesting of image indexes
+	maxIndexDepth = 4
+)
+
+// layout is an OCI image layout on disk
+type layout struct {
+	dir string
+
+	// tmpDir is set when the layout was extracted from a tarball and is
+	// removed when the layout is closed
+	tmpDir string
+}
+
+// openLayout opens the OCI image layout at path. If path is a tarball it is
+// extracted into a temporary directory within tmpRoot.
+func openLayout(path, tmpRoot string) (*layout, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image: %v", err)
+	}
+
+	l := &layout{dir: path}
+	if !fi.IsDir() {
+		tmpDir, err := ioutil.TempDir(tmpRoot, "layout-")
+		if err != nil {
+			return nil, err
+		}
+		l.dir, l.tmpDir = tmpDir, tmpDir
+
+		if err := extractLayout(path, tmpDir); err != nil {
+			l.close()
+			return nil, fmt.Errorf("failed to extract image %q: %v", path, err)
+		}
+	}
+
+	var header specs.ImageLayout
+	if err := l.readJSON(filepath.Join(l.dir, specs.ImageLayoutFile), &header); err != nil {
+		l.close()
+		return nil, fmt.Errorf("%q is not an OCI image layout: %v", path, err)
+	}
+	if header.Version != specs.ImageLayoutVersion {
+		l.close()
+		return nil, fmt.Errorf("unsupported OCI image layout version %q", header.Version)
+	}
+
+	return l, nil
+}
+
+func (l *layout) close() {
+	if l.tmpDir != "" {
+		os.RemoveAll(l.tmpDir)
+	}
+}
+
+// resolveManifest returns the manifest of the image in the layout for the
+// current platform and its digest.
+func (l *layout) resolveManifest() (*specs.Manifest, digest.Digest, error) {
+	var index specs.Index
+	if err := l.readJSON(filepath.Join(l.dir, "index.json"), &index); err != nil {
+		return nil, "", fmt.Errorf("failed to read image index: %v", err)
+	}
+
+	for depth := 0; depth < maxIndexDepth; depth++ {
+		desc, err := selectManifest(index.Manifests)
+		if err != nil {
+			return nil, "", err
+		}
+
+		switch desc.MediaType {
+		case specs.MediaTypeImageIndex, mediaTypeDockerManifestList:
+			index = specs.Index{}
+			if err := l.readBlobJSON(desc, &index); err != nil {
+				return nil, "", err
+			}
+		case specs.MediaTypeImageManifest, mediaTypeDockerManifest:
+			var manifest specs.Manifest
+			if err := l.readBlobJSON(desc, &manifest); err != nil {
+				return nil, "", err
+			}
+			return &manifest, desc.Digest, nil
+		default:
+			return nil, "", fmt.Errorf("unsupported manifest media type %q", desc.MediaType)
+		}
+	}
+
+	return nil, "", fmt.Errorf("image index nested more than %d levels deep", maxIndexDepth)
+}
+
+// selectManifest picks the manifest to use from an image index. An index
+// with a single manifest is used regardless of its platform.
+func selectManifest(manifests []specs.Descriptor) (specs.Descriptor, error) {
+	switch len(manifests) {
+	case 0:
+		return specs.Descriptor{}, fmt.Errorf("image index contains no manifests")
+	case 1:
+		return manifests[0], nil
+	}
+
+	for _, m := range manifests {
+		if m.Platform == nil {
+			continue
+		}
+		if m.Platform.OS == runtime.GOOS && m.Platform.Architecture == runtime.GOARCH {
+			return m, nil
+		}
+	}
+	return specs.Descriptor{}, fmt.Errorf("image index contains no manifest for %s/%s", runtime.GOOS, runtime.GOARCH)
+}
+
+// readConfig reads the runtime configuration of the image
+func (l *layout) readConfig(desc specs.Descriptor) (*Config, error) {
+	var image specs.Image
+	if err := l.readBlobJSON(desc, &image); err != nil {
+		return nil, fmt.Errorf("failed to read image config: %v", err)
+	}
+
+	return &Config{
+		User:       image.Config.User,
+		Env:        image.Config.Env,
+		Entrypoint: image.Config.Entrypoint,
+		Cmd:        image.Config.Cmd,
+		WorkingDir: image.Config.WorkingDir,
+	}, nil
+}
+
+// openBlob opens the blob for desc. Reads from the returned reader fail if
+// the content doesn't match the descriptor's digest.
+func (l *layout) openBlob(desc specs.Descriptor) (io.ReadCloser, error) {
+	if err := desc.Digest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid digest %q: %v", desc.Digest, err)
+	}
+
+	f, err := os.Open(filepath.Join(l.dir, "blobs", desc.Digest.Algorithm().String(), desc.Digest.Encoded()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob: %v", err)
+	}
+
+	return &verifiedReader{
+		f:        f,
+		verifier: desc.Digest.Verifier(),
+		digest:   desc.Digest,
+	}, nil
+}
+
+func (l *layout) readBlobJSON(desc specs.Descriptor, out interface{}) error {
+	r, err := l.openBlob(desc)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(buf, out)
+}
+
+func (l *layout) readJSON(path string, out interface{}) error {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(buf, out)
+}
+
+// verifiedReader returns an error at EOF if the content read doesn't match
+// the expected digest.
+type verifiedReader struct {
+	f        *os.File
+	verifier digest.Verifier
+	digest   digest.Digest
+}
+
+func (r *verifiedReader) Read(p []byte) (int, error) {
+	n, err := r.f.Read(p)
+	r.verifier.Write(p[:n])
+	if err == io.EOF && !r.verifier.Verified() {
+		return n, fmt.Errorf("content of blob does not match digest %s", r.digest)
+	}
+	return n, err
+}
+
+func (r *verifiedReader) Close() error {
+	return r.f.Close()
+}
+
+// extractLayout extracts an OCI image layout from the optionally gzipped
+// tarball src into dst. Only regular files and directories are extracted.
+func extractLayout(src, dst string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r, err := decompress(f)
+	if err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := filepath.Clean(hdr.Name)
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("invalid path %q in image", hdr.Name)
+		}
+		path := filepath.Join(dst, name)
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+				return err
+			}
+			if err := writeFile(path, tr, 0600); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// decompress returns a reader of r's content, transparently decompressing it
+// if it is gzipped.
+func decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(br)
+	}
+	return br, nil
+}
+
+// writeFile creates the file at path with the content of r
+func writeFile(path string, r io.Reader, mode os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
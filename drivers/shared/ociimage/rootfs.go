@@ -0,0 +1,125 @@
+package ociimage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// PopulateRootFS replaces the content of dir with a copy of the image's root
+// filesystem. Top level entries of dir named in preserve, such as the task's
+// local and secrets directories, are left in place and never written to.
+//
+// The image is copied rather than linked so that tasks cannot modify the
+// cached image shared by other tasks.
+func (img *Image) PopulateRootFS(dir string, preserve []string) error {
+	keep := make(map[string]struct{}, len(preserve))
+	for _, p := range preserve {
+		keep[p] = struct{}{}
+	}
+
+	// Remove the content of any previous root filesystem, such as a chroot
+	// built from the host or an image from a previous run of the task
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	names, err := f.Readdirnames(-1)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if _, ok := keep[name]; ok {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("failed to remove %q: %v", name, err)
+		}
+	}
+
+	return filepath.Walk(img.RootFS, func(src string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(img.RootFS, src)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if _, ok := keep[rel]; ok {
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		dst := filepath.Join(dir, rel)
+		if err := copyEntry(src, dst, fi); err != nil {
+			return fmt.Errorf("failed to copy %q: %v", rel, err)
+		}
+		return nil
+	})
+}
+
+// copyEntry copies the file, directory or symlink at src to dst, preserving
+// its mode and ownership
+func copyEntry(src, dst string, fi os.FileInfo) error {
+	mode := fi.Mode()
+	switch {
+	case mode.IsDir():
+		if err := os.Mkdir(dst, 0755); err != nil {
+			return err
+		}
+	case mode&os.ModeSymlink != 0:
+		target, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		if err := os.Symlink(target, dst); err != nil {
+			return err
+		}
+		return chown(dst, fi)
+	case mode.IsRegular():
+		in, err := os.Open(src)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+
+		out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		if err := out.Close(); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	if err := chown(dst, fi); err != nil {
+		return err
+	}
+	if err := os.Chmod(dst, mode.Perm()|mode&(os.ModeSetuid|os.ModeSetgid|os.ModeSticky)); err != nil {
+		return err
+	}
+	return os.Chtimes(dst, fi.ModTime(), fi.ModTime())
+}
+
+// chown sets the ownership of path to that of fi if the process is able to
+func chown(path string, fi os.FileInfo) error {
+	uid, gid := getOwner(fi)
+	if uid == -1 || os.Geteuid() != 0 {
+		return nil
+	}
+	return os.Lchown(path, uid, gid)
+}
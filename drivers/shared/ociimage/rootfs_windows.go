@@ -0,0 +1,10 @@
+package ociimage
+
+import (
+	"os"
+)
+
+// getOwner returns -1 as ownership is not tracked on Windows
+func getOwner(os.FileInfo) (int, int) {
+	return -1, -1
+}
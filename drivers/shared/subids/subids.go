@@ -0,0 +1,272 @@
+// Package subids parses the subordinate ID ranges of /etc/subuid and
+// /etc/subgid, and hands out blocks of them to allocations so that each
+// allocation's tasks run in a user namespace mapped to IDs no other
+// allocation, nor the host, uses.
+package subids
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// SubUIDPath and SubGIDPath are the files the subordinate IDs of users
+	// are read from.
+	SubUIDPath = "/etc/subuid"
+	SubGIDPath = "/etc/subgid"
+)
+
+// Range is a range of Count subordinate IDs starting at Start.
+type Range struct {
+	Start uint32
+	Count uint32
+}
+
+// Mapping maps IDs 0 to Size-1 within a user namespace to the host IDs
+// starting at HostUID and HostGID.
+type Mapping struct {
+	HostUID uint32
+	HostGID uint32
+	Size    uint32
+}
+
+// ParseFile returns the ranges of path assigned to the user u, which
+// entries may reference either by name or by UID.
+func ParseFile(path string, u *user.User) ([]Range, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(f, u.Username, u.Uid)
+}
+
+// Parse returns the ranges of a subuid or subgid file assigned to the user
+// with the given name or ID.
+func Parse(r io.Reader, name, id string) ([]Range, error) {
+	var ranges []Range
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.Split(line, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("line %d: expected 3 fields, got %d", n, len(parts))
+		}
+		if parts[0] != name && parts[0] != id {
+			continue
+		}
+
+		start, err := strconv.ParseUint(parts[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid start: %v", n, err)
+		}
+		count, err := strconv.ParseUint(parts[2], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid count: %v", n, err)
+		}
+		if start+count > 1<<32 {
+			return nil, fmt.Errorf("line %d: range exceeds the maximum ID", n)
+		}
+		ranges = append(ranges, Range{Start: uint32(start), Count: uint32(count)})
+	}
+
+	return ranges, scanner.Err()
+}
+
+// Allocator splits subordinate UID and GID ranges into blocks of a fixed
+// size and assigns one to each allocation. All the tasks of an allocation
+// share its block so that files in the shared alloc directory keep the
+// same owners across them. Since the files of the allocation directory are
+// owned by the IDs of the block, an allocation keeps its block after its
+// tasks stop, until its directory is removed.
+type Allocator struct {
+	size uint32
+
+	// uids and gids are the starts of the blocks, in the same order so that
+	// the Nth UID block is always paired with the Nth GID block
+	uids []uint32
+	gids []uint32
+
+	// allocs tracks the block index assigned to each allocation, its
+	// directory and how many of its tasks hold it
+	allocs map[string]*lease
+	used   map[int]string
+	l      sync.Mutex
+
+	// dirExists returns whether an allocation directory still exists
+	dirExists func(dir string) bool
+}
+
+type lease struct {
+	block int
+	dir   string
+	refs  int
+}
+
+// NewAllocator returns an Allocator handing out blocks of size IDs from the
+// given UID and GID ranges. It fails if the ranges don't hold at least one
+// block.
+func NewAllocator(uidRanges, gidRanges []Range, size uint32) (*Allocator, error) {
+	if size == 0 {
+		return nil, fmt.Errorf("size must be greater than zero")
+	}
+
+	uids, gids := blocks(uidRanges, size), blocks(gidRanges, size)
+	if len(gids) < len(uids) {
+		uids = uids[:len(gids)]
+	}
+	gids = gids[:len(uids)]
+	if len(uids) == 0 {
+		return nil, fmt.Errorf("no subordinate UID and GID ranges of at least %d IDs", size)
+	}
+
+	return &Allocator{
+		size:      size,
+		uids:      uids,
+		gids:      gids,
+		allocs:    make(map[string]*lease),
+		used:      make(map[int]string),
+		dirExists: dirExists,
+	}, nil
+}
+
+func dirExists(dir string) bool {
+	_, err := os.Stat(dir)
+	return !os.IsNotExist(err)
+}
+
+func blocks(ranges []Range, size uint32) []uint32 {
+	var starts []uint32
+	for _, r := range ranges {
+		for off := uint64(0); off+uint64(size) <= uint64(r.Count); off += uint64(size) {
+			starts = append(starts, r.Start+uint32(off))
+		}
+	}
+	return starts
+}
+
+// Capacity returns the number of allocations the allocator can serve at once.
+func (a *Allocator) Capacity() int {
+	return len(a.uids)
+}
+
+// Acquire returns the mapping of the allocation whose directory is
+// allocDir, assigning it a block if it holds none. The block of the previous
+// mapping of the allocation, if any, is assigned if it is free, so that the
+// allocation keeps its block across client restarts.
+func (a *Allocator) Acquire(allocID, allocDir string, prev *Mapping) (*Mapping, error) {
+	a.l.Lock()
+	defer a.l.Unlock()
+
+	if l, ok := a.allocs[allocID]; ok {
+		l.refs++
+		return a.mapping(l.block), nil
+	}
+
+	a.reclaim()
+
+	if block := a.block(prev); block != -1 {
+		if _, ok := a.used[block]; !ok {
+			a.assign(allocID, allocDir, block)
+			return a.mapping(block), nil
+		}
+	}
+
+	for i := range a.uids {
+		if _, ok := a.used[i]; ok {
+			continue
+		}
+		a.assign(allocID, allocDir, i)
+		return a.mapping(i), nil
+	}
+
+	return nil, fmt.Errorf("all %d subordinate ID blocks are in use", len(a.uids))
+}
+
+// Reserve marks the mapping of a task being recovered as held by the
+// allocation. It fails if the mapping doesn't match one of the allocator's
+// blocks or is assigned to another allocation.
+func (a *Allocator) Reserve(allocID, allocDir string, m *Mapping) error {
+	a.l.Lock()
+	defer a.l.Unlock()
+
+	block := a.block(m)
+	if block == -1 {
+		return fmt.Errorf("mapping %d:%d:%d is not a configured subordinate ID block", m.HostUID, m.HostGID, m.Size)
+	}
+
+	if l, ok := a.allocs[allocID]; ok {
+		if l.block != block {
+			return fmt.Errorf("allocation %s already holds a different subordinate ID block", allocID)
+		}
+		l.refs++
+		return nil
+	}
+
+	if other, ok := a.used[block]; ok {
+		return fmt.Errorf("subordinate ID block is in use by allocation %s", other)
+	}
+	a.assign(allocID, allocDir, block)
+	return nil
+}
+
+// Release drops a task's hold on the allocation's block. The allocation
+// keeps the block once none of its tasks hold it, until its directory is
+// removed.
+func (a *Allocator) Release(allocID string) {
+	a.l.Lock()
+	defer a.l.Unlock()
+
+	if l, ok := a.allocs[allocID]; ok && l.refs > 0 {
+		l.refs--
+	}
+}
+
+// reclaim frees the blocks no task holds of the allocations whose directory
+// was removed. Must be called with the lock held.
+func (a *Allocator) reclaim() {
+	for allocID, l := range a.allocs {
+		if l.refs == 0 && !a.dirExists(l.dir) {
+			delete(a.allocs, allocID)
+			delete(a.used, l.block)
+		}
+	}
+}
+
+// block returns the index of the block of the mapping, or -1 if it isn't
+// one of the allocator's blocks.
+func (a *Allocator) block(m *Mapping) int {
+	if m == nil || m.Size != a.size {
+		return -1
+	}
+	for i := range a.uids {
+		if a.uids[i] == m.HostUID && a.gids[i] == m.HostGID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (a *Allocator) assign(allocID, allocDir string, block int) {
+	a.allocs[allocID] = &lease{block: block, dir: allocDir, refs: 1}
+	a.used[block] = allocID
+}
+
+func (a *Allocator) mapping(block int) *Mapping {
+	return &Mapping{
+		HostUID: a.uids[block],
+		HostGID: a.gids[block],
+		Size:    a.size,
+	}
+}
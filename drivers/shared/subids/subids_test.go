@@ -0,0 +1,153 @@
+package subids
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	input := `
+# comment
+root:100000:65536
+nomad:200000:131072
+1000:400000:65536
+root:600000:65536
+`
+	ranges, err := Parse(strings.NewReader(input), "root", "0")
+	require.NoError(t, err)
+	require.Equal(t, []Range{{100000, 65536}, {600000, 65536}}, ranges)
+
+	ranges, err = Parse(strings.NewReader(input), "alice", "1000")
+	require.NoError(t, err)
+	require.Equal(t, []Range{{400000, 65536}}, ranges)
+
+	ranges, err = Parse(strings.NewReader(input), "bob", "1001")
+	require.NoError(t, err)
+	require.Empty(t, ranges)
+
+	_, err = Parse(strings.NewReader("root:100000"), "root", "0")
+	require.Error(t, err)
+
+	_, err = Parse(strings.NewReader("root:x:65536"), "root", "0")
+	require.Error(t, err)
+
+	_, err = Parse(strings.NewReader("root:4294967295:2"), "root", "0")
+	require.Error(t, err)
+}
+
+func TestNewAllocator(t *testing.T) {
+	_, err := NewAllocator([]Range{{100000, 65536}}, []Range{{100000, 65536}}, 0)
+	require.Error(t, err)
+
+	_, err = NewAllocator([]Range{{100000, 1000}}, []Range{{100000, 65536}}, 65536)
+	require.Error(t, err)
+
+	// The number of blocks is limited by the smaller of the ranges
+	a, err := NewAllocator(
+		[]Range{{100000, 65536 * 3}},
+		[]Range{{200000, 65536}, {500000, 65536 + 10}},
+		65536)
+	require.NoError(t, err)
+	require.Equal(t, 2, a.Capacity())
+}
+
+func TestAllocator_AcquireRelease(t *testing.T) {
+	a, err := NewAllocator(
+		[]Range{{100000, 65536 * 2}},
+		[]Range{{300000, 65536 * 2}},
+		65536)
+	require.NoError(t, err)
+	dirs := map[string]bool{"/alloc1": true, "/alloc2": true}
+	a.dirExists = func(dir string) bool { return dirs[dir] }
+
+	m1, err := a.Acquire("alloc1", "/alloc1", nil)
+	require.NoError(t, err)
+	require.Equal(t, &Mapping{HostUID: 100000, HostGID: 300000, Size: 65536}, m1)
+
+	// Tasks of the same allocation share its block
+	again, err := a.Acquire("alloc1", "/alloc1", nil)
+	require.NoError(t, err)
+	require.Equal(t, m1, again)
+
+	m2, err := a.Acquire("alloc2", "/alloc2", nil)
+	require.NoError(t, err)
+	require.Equal(t, &Mapping{HostUID: 165536, HostGID: 365536, Size: 65536}, m2)
+
+	_, err = a.Acquire("alloc3", "/alloc3", nil)
+	require.Error(t, err)
+
+	// The allocation keeps its block once every task released it, so that
+	// its restarted tasks get the same IDs
+	a.Release("alloc1")
+	a.Release("alloc1")
+	_, err = a.Acquire("alloc3", "/alloc3", nil)
+	require.Error(t, err)
+	restarted, err := a.Acquire("alloc1", "/alloc1", nil)
+	require.NoError(t, err)
+	require.Equal(t, m1, restarted)
+
+	// The block is freed once the allocation directory is removed and no
+	// task holds it
+	delete(dirs, "/alloc1")
+	_, err = a.Acquire("alloc3", "/alloc3", nil)
+	require.Error(t, err)
+	a.Release("alloc1")
+	m3, err := a.Acquire("alloc3", "/alloc3", nil)
+	require.NoError(t, err)
+	require.Equal(t, m1, m3)
+
+	// Releasing unknown allocations is a noop
+	a.Release("unknown")
+}
+
+func TestAllocator_AcquirePrevious(t *testing.T) {
+	a, err := NewAllocator(
+		[]Range{{100000, 65536 * 3}},
+		[]Range{{100000, 65536 * 3}},
+		65536)
+	require.NoError(t, err)
+	a.dirExists = func(string) bool { return true }
+
+	// The previous block of an allocation is assigned again if it's free
+	prev := &Mapping{HostUID: 165536, HostGID: 165536, Size: 65536}
+	m, err := a.Acquire("alloc1", "/alloc1", prev)
+	require.NoError(t, err)
+	require.Equal(t, prev, m)
+
+	// Otherwise another block is assigned
+	m, err = a.Acquire("alloc2", "/alloc2", prev)
+	require.NoError(t, err)
+	require.Equal(t, uint32(100000), m.HostUID)
+
+	// Unknown previous blocks are ignored
+	m, err = a.Acquire("alloc3", "/alloc3", &Mapping{HostUID: 1, HostGID: 1, Size: 65536})
+	require.NoError(t, err)
+	require.Equal(t, uint32(231072), m.HostUID)
+}
+
+func TestAllocator_Reserve(t *testing.T) {
+	a, err := NewAllocator(
+		[]Range{{100000, 65536 * 2}},
+		[]Range{{100000, 65536 * 2}},
+		65536)
+	require.NoError(t, err)
+
+	m := &Mapping{HostUID: 165536, HostGID: 165536, Size: 65536}
+	require.NoError(t, a.Reserve("alloc1", "/alloc1", m))
+	require.NoError(t, a.Reserve("alloc1", "/alloc1", m))
+
+	// Reserved blocks aren't handed out again
+	other, err := a.Acquire("alloc2", "/alloc2", nil)
+	require.NoError(t, err)
+	require.Equal(t, uint32(100000), other.HostUID)
+
+	require.Error(t, a.Reserve("alloc3", "/alloc3", m))
+	require.Error(t, a.Reserve("alloc1", "/alloc1", other))
+	require.Error(t, a.Reserve("alloc4", "/alloc4", &Mapping{HostUID: 1, HostGID: 1, Size: 65536}))
+
+	acquired, err := a.Acquire("alloc1", "/alloc1", nil)
+	require.NoError(t, err)
+	require.Equal(t, m, acquired)
+}
@@ -0,0 +1,15 @@
+// Package vmnet attaches virtual machines to the network namespace of an
+// allocation.
+package vmnet
+
+import "net"
+
+// GuestNetwork is the network configuration of a virtual machine attached to
+// an allocation's network namespace. The guest takes over the address and MAC
+// of the namespace's interface, whose traffic is redirected to the tap device
+// of the virtual machine.
+type GuestNetwork struct {
+	MAC     net.HardwareAddr
+	Addr    *net.IPNet
+	Gateway net.IP
+}
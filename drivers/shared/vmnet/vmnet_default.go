@@ -0,0 +1,11 @@
+//go:build !linux
+// +build !linux
+
+package vmnet
+
+import "fmt"
+
+// SetupTap is only supported on Linux.
+func SetupTap(string, string) (*GuestNetwork, error) {
+	return nil, fmt.Errorf("attaching virtual machines to a network namespace is unsupported on this OS")
+}
@@ -0,0 +1,128 @@
+package vmnet
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// SetupTap creates a tap device named tapName in the network namespace at
+// netnsPath, and redirects all traffic between it and the namespace's
+// interface so that the guest attached to the tap device takes over the
+// interface's address. A tap device left by a previous guest is replaced.
+func SetupTap(netnsPath, tapName string) (*GuestNetwork, error) {
+	netns, err := ns.GetNS(netnsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open network namespace: %v", err)
+	}
+	defer netns.Close()
+
+	var gn *GuestNetwork
+	err = netns.Do(func(ns.NetNS) error {
+		link, gateway, err := defaultRouteLink()
+		if err != nil {
+			return err
+		}
+
+		addrs, err := netlink.AddrList(link, netlink.FAMILY_V4)
+		if err != nil {
+			return fmt.Errorf("failed to list addresses of %s: %v", link.Attrs().Name, err)
+		}
+		if len(addrs) == 0 {
+			return fmt.Errorf("no IPv4 address on %s", link.Attrs().Name)
+		}
+
+		// Remove the tap left by a previous guest
+		if old, err := netlink.LinkByName(tapName); err == nil {
+			if err := netlink.LinkDel(old); err != nil {
+				return fmt.Errorf("failed to remove stale tap device: %v", err)
+			}
+		}
+
+		tap := &netlink.Tuntap{
+			LinkAttrs: netlink.LinkAttrs{
+				Name: tapName,
+				MTU:  link.Attrs().MTU,
+			},
+			Mode: netlink.TUNTAP_MODE_TAP,
+		}
+		if err := netlink.LinkAdd(tap); err != nil {
+			return fmt.Errorf("failed to create tap device: %v", err)
+		}
+		if err := netlink.LinkSetUp(tap); err != nil {
+			return fmt.Errorf("failed to set up tap device: %v", err)
+		}
+
+		if err := redirect(link, tap); err != nil {
+			return err
+		}
+		if err := redirect(tap, link); err != nil {
+			return err
+		}
+
+		gn = &GuestNetwork{
+			MAC:     link.Attrs().HardwareAddr,
+			Addr:    addrs[0].IPNet,
+			Gateway: gateway,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return gn, nil
+}
+
+// defaultRouteLink returns the link and gateway of the default IPv4 route.
+func defaultRouteLink() (netlink.Link, net.IP, error) {
+	routes, err := netlink.RouteList(nil, netlink.FAMILY_V4)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list routes: %v", err)
+	}
+
+	for _, route := range routes {
+		if route.Dst != nil {
+			continue
+		}
+		link, err := netlink.LinkByIndex(route.LinkIndex)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get link of default route: %v", err)
+		}
+		return link, route.Gw, nil
+	}
+	return nil, nil, fmt.Errorf("no default route in network namespace")
+}
+
+// redirect redirects all traffic received by from to to.
+func redirect(from, to netlink.Link) error {
+	ingress := &netlink.Ingress{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: from.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_INGRESS,
+		},
+	}
+
+	// Replacing the qdisc removes the filters added by a previous run
+	_ = netlink.QdiscDel(ingress)
+	if err := netlink.QdiscAdd(ingress); err != nil {
+		return fmt.Errorf("failed to add ingress qdisc to %s: %v", from.Attrs().Name, err)
+	}
+
+	filter := &netlink.U32{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: from.Attrs().Index,
+			Parent:    ingress.Handle,
+			Priority:  1,
+			Protocol:  unix.ETH_P_ALL,
+		},
+		Actions: []netlink.Action{netlink.NewMirredAction(to.Attrs().Index)},
+	}
+	if err := netlink.FilterAdd(filter); err != nil {
+		return fmt.Errorf("failed to redirect %s to %s: %v", from.Attrs().Name, to.Attrs().Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package catalog
+
+import "github.com/hashicorp/nomad/drivers/containerd"
+
+// Register the containerd driver, whose client doesn't build on Windows, with
+// the builtin driver plugin catalog.
+func init() {
+	Register(containerd.PluginID, containerd.PluginConfig)
+}
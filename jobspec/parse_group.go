@@ -57,6 +57,8 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			"volume",
 			"scaling",
 			"stop_after_client_disconnect",
+			"stateful",
+			"resources",
 		}
 		if err := checkHCLKeys(listVal, valid); err != nil {
 			return multierror.Prefix(err, fmt.Sprintf("'%s' ->", n))
@@ -82,6 +84,7 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 		delete(m, "service")
 		delete(m, "volume")
 		delete(m, "scaling")
+		delete(m, "resources")
 
 		// Build the group with the basic decode
 		var g api.TaskGroup
@@ -157,6 +160,13 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			}
 		}
 
+		// Parse group resources
+		if o := listVal.Filter("resources"); len(o.Items) > 0 {
+			if err := parseGroupResources(&g.Resources, o); err != nil {
+				return multierror.Prefix(err, fmt.Sprintf("'%s', resources ->", n))
+			}
+		}
+
 		// If we have an update strategy, then parse that
 		if o := listVal.Filter("update"); len(o.Items) > 0 {
 			if err := parseUpdate(&g.Update, o); err != nil {
@@ -301,6 +311,38 @@ func parseEphemeralDisk(result **api.EphemeralDisk, list *ast.ObjectList) error
 	return nil
 }
 
+func parseGroupResources(result **api.TaskGroupResources, list *ast.ObjectList) error {
+	list = list.Elem()
+	if len(list.Items) > 1 {
+		return fmt.Errorf("only one 'resources' block allowed per group")
+	}
+
+	// Get our resources object
+	obj := list.Items[0]
+
+	// Check for invalid keys
+	valid := []string{
+		"cpu",
+		"memory",
+	}
+	if err := checkHCLKeys(obj.Val, valid); err != nil {
+		return err
+	}
+
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, obj.Val); err != nil {
+		return err
+	}
+
+	var resources api.TaskGroupResources
+	if err := mapstructure.WeakDecode(m, &resources); err != nil {
+		return err
+	}
+	*result = &resources
+
+	return nil
+}
+
 func parseRestartPolicy(final **api.RestartPolicy, list *ast.ObjectList) error {
 	list = list.Elem()
 	if len(list.Items) > 1 {
@@ -316,6 +358,8 @@ func parseRestartPolicy(final **api.RestartPolicy, list *ast.ObjectList) error {
 		"interval",
 		"delay",
 		"mode",
+		"delay_function",
+		"max_delay",
 	}
 	if err := checkHCLKeys(obj.Val, valid); err != nil {
 		return err
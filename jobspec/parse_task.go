@@ -31,11 +31,14 @@ var (
 		"artifact",
 		"constraint",
 		"affinity",
+		"depends_on",
+		"depends_on_condition",
 		"dispatch_payload",
 		"lifecycle",
 		"leader",
 		"restart",
 		"service",
+		"shutdown_hook",
 		"template",
 		"vault",
 		"kind",
@@ -108,6 +111,7 @@ func parseTask(item *ast.ObjectItem, keys []string) (*api.Task, error) {
 	delete(m, "resources")
 	delete(m, "restart")
 	delete(m, "service")
+	delete(m, "shutdown_hook")
 	delete(m, "template")
 	delete(m, "vault")
 	delete(m, "volume_mount")
@@ -248,6 +252,12 @@ func parseTask(item *ast.ObjectItem, keys []string) (*api.Task, error) {
 		valid := []string{
 			"max_files",
 			"max_file_size",
+			"sink",
+			"format",
+			"level",
+			"rotate_every",
+			"compression",
+			"max_total_size",
 		}
 		if err := checkHCLKeys(logsBlock.Val, valid); err != nil {
 			return nil, multierror.Prefix(err, "logs ->")
@@ -256,11 +266,28 @@ func parseTask(item *ast.ObjectItem, keys []string) (*api.Task, error) {
 		if err := hcl.DecodeObject(&m, logsBlock.Val); err != nil {
 			return nil, err
 		}
+		delete(m, "sink")
 
 		var log api.LogConfig
-		if err := mapstructure.WeakDecode(m, &log); err != nil {
+		dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+			WeaklyTypedInput: true,
+			Result:           &log,
+		})
+		if err != nil {
 			return nil, err
 		}
+		if err := dec.Decode(m); err != nil {
+			return nil, err
+		}
+
+		if ot, ok := logsBlock.Val.(*ast.ObjectType); ok {
+			if o := ot.List.Filter("sink"); len(o.Items) > 0 {
+				if err := parseLogSinks(&log.Sinks, o); err != nil {
+					return nil, multierror.Prefix(err, "logs ->")
+				}
+			}
+		}
 
 		t.LogConfig = &log
 	}
@@ -353,6 +380,48 @@ func parseTask(item *ast.ObjectItem, keys []string) (*api.Task, error) {
 			return nil, err
 		}
 	}
+
+	// If we have a shutdown_hook block parse that
+	if o := listVal.Filter("shutdown_hook"); len(o.Items) > 0 {
+		if len(o.Items) > 1 {
+			return nil, fmt.Errorf("only one shutdown_hook block is allowed in a task. Number of shutdown_hook blocks found: %d", len(o.Items))
+		}
+
+		var m map[string]interface{}
+		hookBlock := o.Items[0]
+
+		// Check for invalid keys
+		valid := []string{
+			"type",
+			"port",
+			"path",
+			"method",
+			"protocol",
+			"command",
+			"args",
+			"timeout",
+		}
+		if err := checkHCLKeys(hookBlock.Val, valid); err != nil {
+			return nil, multierror.Prefix(err, "shutdown_hook ->")
+		}
+
+		if err := hcl.DecodeObject(&m, hookBlock.Val); err != nil {
+			return nil, err
+		}
+
+		t.ShutdownHook = &api.TaskShutdownHook{}
+		dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+			WeaklyTypedInput: true,
+			Result:           t.ShutdownHook,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := dec.Decode(m); err != nil {
+			return nil, err
+		}
+	}
 	return &t, nil
 }
 
@@ -681,3 +750,63 @@ func parseVolumeMounts(out *[]*api.VolumeMount, list *ast.ObjectList) error {
 	*out = mounts
 	return nil
 }
+
+func parseLogSinks(result *[]*api.LogSink, list *ast.ObjectList) error {
+	for _, o := range list.Items {
+		if len(o.Keys) != 1 {
+			return fmt.Errorf("sink blocks must have exactly one label, the sink type")
+		}
+		sinkType := o.Keys[0].Token.Value().(string)
+
+		valid := []string{
+			"address",
+			"facility",
+			"tag",
+			"header",
+			"batch_size",
+			"batch_wait",
+			"buffer_size",
+			"backpressure",
+		}
+		if err := checkHCLKeys(o.Val, valid); err != nil {
+			return multierror.Prefix(err, fmt.Sprintf("sink %q ->", sinkType))
+		}
+
+		var m map[string]interface{}
+		if err := hcl.DecodeObject(&m, o.Val); err != nil {
+			return err
+		}
+
+		sink := &api.LogSink{Type: sinkType}
+
+		// HCL allows repeating stanzas so merge 'header' into a single map
+		if headerI, ok := m["header"]; ok {
+			headerRaw, ok := headerI.([]map[string]interface{})
+			if !ok {
+				return fmt.Errorf("sink %q -> header -> expected a map but found %T", sinkType, headerI)
+			}
+			sink.Header = map[string]string{}
+			for _, rawm := range headerRaw {
+				for k, v := range rawm {
+					sink.Header[k] = fmt.Sprint(v)
+				}
+			}
+			delete(m, "header")
+		}
+
+		dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+			WeaklyTypedInput: true,
+			Result:           sink,
+		})
+		if err != nil {
+			return err
+		}
+		if err := dec.Decode(m); err != nil {
+			return err
+		}
+
+		*result = append(*result, sink)
+	}
+	return nil
+}
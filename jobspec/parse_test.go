@@ -172,10 +172,12 @@ func TestParse(t *testing.T) {
 							"elb_checks":   "3",
 						},
 						RestartPolicy: &api.RestartPolicy{
-							Interval: timeToPtr(10 * time.Minute),
-							Attempts: intToPtr(5),
-							Delay:    timeToPtr(15 * time.Second),
-							Mode:     stringToPtr("delay"),
+							Interval:      timeToPtr(10 * time.Minute),
+							Attempts:      intToPtr(5),
+							Delay:         timeToPtr(15 * time.Second),
+							Mode:          stringToPtr("delay"),
+							DelayFunction: stringToPtr("exponential"),
+							MaxDelay:      timeToPtr(2 * time.Minute),
 						},
 						Spreads: []*api.Spread{
 							{
@@ -326,8 +328,26 @@ func TestParse(t *testing.T) {
 								KillTimeout:   timeToPtr(22 * time.Second),
 								ShutdownDelay: 11 * time.Second,
 								LogConfig: &api.LogConfig{
-									MaxFiles:      intToPtr(14),
-									MaxFileSizeMB: intToPtr(101),
+									MaxFiles:       intToPtr(14),
+									MaxFileSizeMB:  intToPtr(101),
+									MaxTotalSizeMB: intToPtr(707),
+									RotateEvery:    timeToPtr(time.Hour),
+									Compression:    stringToPtr("zstd"),
+									Format:         stringToPtr("json"),
+									Level:          stringToPtr("info"),
+									Sinks: []*api.LogSink{
+										{
+											Type:     "syslog",
+											Address:  "tcp://logs.example.com:514",
+											Facility: "local3",
+										},
+										{
+											Type:      "http",
+											Address:   "https://logs.example.com/ingest",
+											BatchWait: timeToPtr(5 * time.Second),
+											Header:    map[string]string{"Authorization": "Bearer token"},
+										},
+									},
 								},
 								Artifacts: []*api.TaskArtifact{
 									{
@@ -800,6 +820,11 @@ func TestParse(t *testing.T) {
 					Payload:      "required",
 					MetaRequired: []string{"foo", "bar"},
 					MetaOptional: []string{"baz", "bam"},
+					MaxRunning:   10,
+					Rate: &api.DispatchRateConfig{
+						Limit: 0.5,
+						Burst: 20,
+					},
 				},
 
 				TaskGroups: []*api.TaskGroup{
@@ -819,6 +844,161 @@ func TestParse(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"job-depends-on.hcl",
+			&api.Job{
+				ID:   stringToPtr("transform"),
+				Name: stringToPtr("transform"),
+				Type: stringToPtr("batch"),
+				DependsOn: []*api.JobDependency{
+					{
+						JobID:     "extract",
+						Condition: "success",
+					},
+					{
+						JobID:     "cleanup",
+						Condition: "complete",
+					},
+				},
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("transform"),
+						Tasks: []*api.Task{
+							{
+								Name:   "transform",
+								Driver: "exec",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"task-depends-on.hcl",
+			&api.Job{
+				ID:   stringToPtr("etl"),
+				Name: stringToPtr("etl"),
+				Type: stringToPtr("batch"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("etl"),
+						Tasks: []*api.Task{
+							{
+								Name:   "extract",
+								Driver: "exec",
+							},
+							{
+								Name:      "transform",
+								Driver:    "exec",
+								DependsOn: []string{"extract"},
+							},
+							{
+								Name:               "report",
+								Driver:             "exec",
+								DependsOn:          []string{"extract", "transform"},
+								DependsOnCondition: "complete",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"tg-stateful.hcl",
+			&api.Job{
+				ID:   stringToPtr("db"),
+				Name: stringToPtr("db"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name:     stringToPtr("db"),
+						Count:    intToPtr(3),
+						Stateful: boolToPtr(true),
+						Update: &api.UpdateStrategy{
+							MaxParallel: intToPtr(1),
+						},
+						Tasks: []*api.Task{
+							{
+								Name:   "db",
+								Driver: "docker",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"tg-resources.hcl",
+			&api.Job{
+				ID:   stringToPtr("web"),
+				Name: stringToPtr("web"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("web"),
+						Resources: &api.TaskGroupResources{
+							CPU:      intToPtr(1000),
+							MemoryMB: intToPtr(512),
+						},
+						Tasks: []*api.Task{
+							{
+								Name:   "app",
+								Driver: "exec",
+								Resources: &api.Resources{
+									CPU:      intToPtr(800),
+									MemoryMB: intToPtr(384),
+								},
+							},
+							{
+								Name:   "sidecar",
+								Driver: "exec",
+								Resources: &api.Resources{
+									CPU:         intToPtr(100),
+									MemoryMB:    intToPtr(64),
+									MemoryMaxMB: intToPtr(256),
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"task-shutdown-hook.hcl",
+			&api.Job{
+				ID:   stringToPtr("web"),
+				Name: stringToPtr("web"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("web"),
+						Tasks: []*api.Task{
+							{
+								Name:   "api",
+								Driver: "docker",
+								ShutdownHook: &api.TaskShutdownHook{
+									Type:      "http",
+									PortLabel: "http",
+									Path:      "/drain",
+									Timeout:   30 * time.Second,
+								},
+							},
+							{
+								Name:   "worker",
+								Driver: "docker",
+								ShutdownHook: &api.TaskShutdownHook{
+									Type:    "exec",
+									Command: "/bin/drain",
+									Args:    []string{"-wait"},
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
 		{
 			"job-with-kill-signal.hcl",
 			&api.Job{
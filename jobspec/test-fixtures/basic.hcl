@@ -99,10 +99,12 @@ job "binstore-storagelocker" {
     }
 
     restart {
-      attempts = 5
-      interval = "10m"
-      delay    = "15s"
-      mode     = "delay"
+      attempts       = 5
+      interval       = "10m"
+      delay          = "15s"
+      mode           = "delay"
+      delay_function = "exponential"
+      max_delay      = "2m"
     }
 
     reschedule {
@@ -190,8 +192,27 @@ job "binstore-storagelocker" {
       }
 
       logs {
-        max_files     = 14
-        max_file_size = 101
+        max_files      = 14
+        max_file_size  = 101
+        max_total_size = 707
+        rotate_every   = "1h"
+        compression    = "zstd"
+        format         = "json"
+        level          = "info"
+
+        sink "syslog" {
+          address  = "tcp://logs.example.com:514"
+          facility = "local3"
+        }
+
+        sink "http" {
+          address    = "https://logs.example.com/ingest"
+          batch_wait = "5s"
+
+          header {
+            Authorization = "Bearer token"
+          }
+        }
       }
 
       env {
@@ -0,0 +1,19 @@
+job "transform" {
+  type = "batch"
+
+  depends_on {
+    job       = "extract"
+    condition = "success"
+  }
+
+  depends_on {
+    job       = "cleanup"
+    condition = "complete"
+  }
+
+  group "transform" {
+    task "transform" {
+      driver = "exec"
+    }
+  }
+}
@@ -3,6 +3,12 @@ job "parameterized_job" {
     payload       = "required"
     meta_required = ["foo", "bar"]
     meta_optional = ["baz", "bam"]
+    max_running   = 10
+
+    rate {
+      limit = 0.5
+      burst = 20
+    }
   }
 
   group "foo" {
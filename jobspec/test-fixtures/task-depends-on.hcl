@@ -0,0 +1,20 @@
+job "etl" {
+  type = "batch"
+
+  group "etl" {
+    task "extract" {
+      driver = "exec"
+    }
+
+    task "transform" {
+      driver     = "exec"
+      depends_on = ["extract"]
+    }
+
+    task "report" {
+      driver               = "exec"
+      depends_on           = ["extract", "transform"]
+      depends_on_condition = "complete"
+    }
+  }
+}
@@ -0,0 +1,24 @@
+job "web" {
+  group "web" {
+    task "api" {
+      driver = "docker"
+
+      shutdown_hook {
+        type    = "http"
+        port    = "http"
+        path    = "/drain"
+        timeout = "30s"
+      }
+    }
+
+    task "worker" {
+      driver = "docker"
+
+      shutdown_hook {
+        type    = "exec"
+        command = "/bin/drain"
+        args    = ["-wait"]
+      }
+    }
+  }
+}
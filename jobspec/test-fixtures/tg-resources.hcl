@@ -0,0 +1,27 @@
+job "web" {
+  group "web" {
+    resources {
+      cpu    = 1000
+      memory = 512
+    }
+
+    task "app" {
+      driver = "exec"
+
+      resources {
+        cpu    = 800
+        memory = 384
+      }
+    }
+
+    task "sidecar" {
+      driver = "exec"
+
+      resources {
+        cpu        = 100
+        memory     = 64
+        memory_max = 256
+      }
+    }
+  }
+}
@@ -0,0 +1,14 @@
+job "db" {
+  group "db" {
+    count    = 3
+    stateful = true
+
+    update {
+      max_parallel = 1
+    }
+
+    task "db" {
+      driver = "docker"
+    }
+  }
+}
@@ -0,0 +1,378 @@
+// Package compose converts docker-compose files into Nomad jobs.
+package compose
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	shellwords "github.com/mattn/go-shellwords"
+	"gopkg.in/yaml.v2"
+)
+
+// file is the subset of the compose file format the converter understands.
+// Keys that aren't listed are collected in Extra so they can be reported.
+type file struct {
+	Name     string                 `yaml:"name"`
+	Version  string                 `yaml:"version"`
+	Services map[string]*service    `yaml:"services"`
+	Volumes  map[string]*volume     `yaml:"volumes"`
+	Networks map[string]interface{} `yaml:"networks"`
+	Extra    map[string]interface{} `yaml:",inline"`
+}
+
+type service struct {
+	Image           string        `yaml:"image"`
+	Command         interface{}   `yaml:"command"`
+	Entrypoint      interface{}   `yaml:"entrypoint"`
+	Environment     interface{}   `yaml:"environment"`
+	Ports           []interface{} `yaml:"ports"`
+	Expose          []interface{} `yaml:"expose"`
+	Volumes         []interface{} `yaml:"volumes"`
+	DependsOn       interface{}   `yaml:"depends_on"`
+	Restart         string        `yaml:"restart"`
+	Deploy          *deploy       `yaml:"deploy"`
+	MemLimit        interface{}   `yaml:"mem_limit"`
+	CPUs            interface{}   `yaml:"cpus"`
+	CapAdd          []string      `yaml:"cap_add"`
+	CapDrop         []string      `yaml:"cap_drop"`
+	Privileged      bool          `yaml:"privileged"`
+	WorkingDir      string        `yaml:"working_dir"`
+	User            string        `yaml:"user"`
+	Labels          interface{}   `yaml:"labels"`
+	DNS             interface{}   `yaml:"dns"`
+	ExtraHosts      interface{}   `yaml:"extra_hosts"`
+	SecurityOpt     []string      `yaml:"security_opt"`
+	Sysctls         interface{}   `yaml:"sysctls"`
+	Tty             bool          `yaml:"tty"`
+	StdinOpen       bool          `yaml:"stdin_open"`
+	Init            bool          `yaml:"init"`
+	ShmSize         interface{}   `yaml:"shm_size"`
+	StopGracePeriod string        `yaml:"stop_grace_period"`
+	StopSignal      string        `yaml:"stop_signal"`
+
+	Extra map[string]interface{} `yaml:",inline"`
+}
+
+type deploy struct {
+	Replicas  *int `yaml:"replicas"`
+	Resources struct {
+		Limits struct {
+			CPUs   interface{} `yaml:"cpus"`
+			Memory interface{} `yaml:"memory"`
+		} `yaml:"limits"`
+	} `yaml:"resources"`
+
+	Extra map[string]interface{} `yaml:",inline"`
+}
+
+type volume struct {
+	Driver   string      `yaml:"driver"`
+	External interface{} `yaml:"external"`
+
+	Extra map[string]interface{} `yaml:",inline"`
+}
+
+// portSpec is a port mapping in either the short or the long syntax.
+type portSpec struct {
+	HostIP    string
+	Published int
+	Target    int
+	Protocol  string
+}
+
+// volumeSpec is a volume mount in either the short or the long syntax.
+type volumeSpec struct {
+	Type     string
+	Source   string
+	Target   string
+	ReadOnly bool
+}
+
+const (
+	volumeTypeVolume = "volume"
+	volumeTypeBind   = "bind"
+	volumeTypeTmpfs  = "tmpfs"
+)
+
+func parseFile(src []byte) (*file, error) {
+	var f file
+	if err := yaml.Unmarshal(src, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse compose file: %v", err)
+	}
+	if len(f.Services) == 0 {
+		return nil, fmt.Errorf("compose file doesn't define any services")
+	}
+	return &f, nil
+}
+
+// sortedKeys returns the keys of m in lexical order, skipping compose
+// extension fields.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		if strings.HasPrefix(k, "x-") {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// scalarString converts a YAML scalar into its string form.
+func scalarString(v interface{}) (string, error) {
+	switch t := v.(type) {
+	case string:
+		return t, nil
+	case int, int64, float64, bool:
+		return fmt.Sprint(t), nil
+	default:
+		return "", fmt.Errorf("expected a scalar but found %T", v)
+	}
+}
+
+// stringList accepts either a single string or a list of strings.
+func stringList(v interface{}) ([]string, error) {
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{t}, nil
+	case []interface{}:
+		out := make([]string, 0, len(t))
+		for _, e := range t {
+			s, err := scalarString(e)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, s)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("expected a string or a list but found %T", v)
+	}
+}
+
+// commandList accepts a command as a list or as a string that's split the
+// way a shell would.
+func commandList(v interface{}) ([]string, error) {
+	if s, ok := v.(string); ok {
+		return shellwords.Parse(s)
+	}
+	return stringList(v)
+}
+
+// mappingOrList accepts either a mapping or a list of "key<sep>value"
+// entries. Entries without a value are returned separately.
+func mappingOrList(v interface{}, sep string) (map[string]string, []string, error) {
+	out := map[string]string{}
+	var bare []string
+
+	switch t := v.(type) {
+	case nil:
+		return nil, nil, nil
+	case map[interface{}]interface{}:
+		for k, e := range t {
+			key := fmt.Sprint(k)
+			if e == nil {
+				bare = append(bare, key)
+				continue
+			}
+			s, err := scalarString(e)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", key, err)
+			}
+			out[key] = s
+		}
+	case []interface{}:
+		for _, e := range t {
+			s, err := scalarString(e)
+			if err != nil {
+				return nil, nil, err
+			}
+			idx := strings.Index(s, sep)
+			if idx < 0 {
+				bare = append(bare, s)
+				continue
+			}
+			out[s[:idx]] = s[idx+len(sep):]
+		}
+	default:
+		return nil, nil, fmt.Errorf("expected a mapping or a list but found %T", v)
+	}
+
+	sort.Strings(bare)
+	return out, bare, nil
+}
+
+// dependencies returns the services a service depends on along with the
+// condition each has to meet.
+func dependencies(v interface{}) (map[string]string, error) {
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		names, err := stringList(t)
+		if err != nil {
+			return nil, err
+		}
+		out := make(map[string]string, len(names))
+		for _, name := range names {
+			out[name] = "service_started"
+		}
+		return out, nil
+	case map[interface{}]interface{}:
+		out := make(map[string]string, len(t))
+		for k, e := range t {
+			condition := "service_started"
+			if m, ok := e.(map[interface{}]interface{}); ok {
+				if c, ok := m["condition"].(string); ok {
+					condition = c
+				}
+			}
+			out[fmt.Sprint(k)] = condition
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("expected a list or a mapping but found %T", v)
+	}
+}
+
+func parsePort(v interface{}) (*portSpec, error) {
+	switch t := v.(type) {
+	case int:
+		return &portSpec{Target: t, Protocol: "tcp"}, nil
+	case string:
+		return parseShortPort(t)
+	case map[interface{}]interface{}:
+		p := &portSpec{Protocol: "tcp"}
+		for k, e := range t {
+			s, err := scalarString(e)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %v", k, err)
+			}
+			switch k {
+			case "target":
+				p.Target, err = parsePortNumber(s)
+			case "published":
+				p.Published, err = parsePortNumber(s)
+			case "protocol":
+				p.Protocol = s
+			case "host_ip":
+				p.HostIP = s
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		if p.Target == 0 {
+			return nil, fmt.Errorf("port is missing a target")
+		}
+		return p, nil
+	default:
+		return nil, fmt.Errorf("unexpected port %v", v)
+	}
+}
+
+// parseShortPort parses "[[host_ip:]published:]target[/protocol]".
+func parseShortPort(s string) (*portSpec, error) {
+	p := &portSpec{Protocol: "tcp"}
+	if idx := strings.LastIndex(s, "/"); idx >= 0 {
+		p.Protocol = s[idx+1:]
+		s = s[:idx]
+	}
+
+	var published, target string
+	parts := strings.Split(s, ":")
+	switch len(parts) {
+	case 1:
+		target = parts[0]
+	case 2:
+		published, target = parts[0], parts[1]
+	case 3:
+		p.HostIP, published, target = parts[0], parts[1], parts[2]
+	default:
+		return nil, fmt.Errorf("invalid port %q", s)
+	}
+
+	var err error
+	if p.Target, err = parsePortNumber(target); err != nil {
+		return nil, err
+	}
+	if published != "" {
+		if p.Published, err = parsePortNumber(published); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+func parsePortNumber(s string) (int, error) {
+	if strings.Contains(s, "-") {
+		return 0, fmt.Errorf("port ranges aren't supported: %q", s)
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 || n > 65535 {
+		return 0, fmt.Errorf("invalid port number %q", s)
+	}
+	return n, nil
+}
+
+func parseVolume(v interface{}) (*volumeSpec, error) {
+	switch t := v.(type) {
+	case string:
+		return parseShortVolume(t)
+	case map[interface{}]interface{}:
+		vol := &volumeSpec{}
+		for k, e := range t {
+			switch k {
+			case "type":
+				vol.Type, _ = e.(string)
+			case "source":
+				vol.Source, _ = e.(string)
+			case "target":
+				vol.Target, _ = e.(string)
+			case "read_only":
+				vol.ReadOnly, _ = e.(bool)
+			}
+		}
+		if vol.Target == "" {
+			return nil, fmt.Errorf("volume is missing a target")
+		}
+		if vol.Type == "" {
+			vol.Type = volumeTypeVolume
+		}
+		return vol, nil
+	default:
+		return nil, fmt.Errorf("unexpected volume %v", v)
+	}
+}
+
+// parseShortVolume parses "[source:]target[:mode]".
+func parseShortVolume(s string) (*volumeSpec, error) {
+	vol := &volumeSpec{Type: volumeTypeVolume}
+
+	parts := strings.Split(s, ":")
+	switch len(parts) {
+	case 1:
+		vol.Target = parts[0]
+	case 2:
+		vol.Source, vol.Target = parts[0], parts[1]
+	case 3:
+		vol.Source, vol.Target = parts[0], parts[1]
+		for _, opt := range strings.Split(parts[2], ",") {
+			if opt == "ro" {
+				vol.ReadOnly = true
+			}
+		}
+	default:
+		return nil, fmt.Errorf("invalid volume %q", s)
+	}
+
+	if strings.HasPrefix(vol.Source, "/") || strings.HasPrefix(vol.Source, ".") || strings.HasPrefix(vol.Source, "~") {
+		vol.Type = volumeTypeBind
+	}
+	return vol, nil
+}
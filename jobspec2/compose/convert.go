@@ -0,0 +1,602 @@
+package compose
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/hashicorp/nomad/api"
+)
+
+// serviceHints explains how to replace compose keys the converter skips.
+var serviceHints = map[string]string{
+	"build":          "build the image ahead of time and set image",
+	"container_name": "Nomad names containers after the task",
+	"env_file":       "use a template block with env = true",
+	"healthcheck":    "add a service block with a check",
+	"hostname":       "tasks share the group's network namespace",
+	"links":          "tasks reach each other on localhost",
+	"logging":        "configure logging in the docker plugin block",
+	"network_mode":   "tasks share the group's bridge network",
+	"networks":       "tasks share the group's bridge network",
+}
+
+// invalidLabelChars matches characters that can't be used in port and
+// volume labels.
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// sharedDataDir is the shared alloc data dir relative to a task's working
+// directory, which docker tasks may bind without enabling host volumes.
+const sharedDataDir = "../alloc/data"
+
+type converter struct {
+	file     *file
+	job      *api.Job
+	group    *api.TaskGroup
+	warnings []string
+
+	// hostVolumes maps bind mount sources to group volume names
+	hostVolumes map[string]string
+}
+
+// Convert translates a docker-compose file into a job with a single task
+// group that runs each service as a docker task. The tasks share the group's
+// bridge network, so services keep reaching each other on localhost.
+// Anything that can't be mapped is skipped and reported in the returned
+// warnings.
+func Convert(name string, src []byte) (*api.Job, []string, error) {
+	f, err := parseFile(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	if f.Name != "" {
+		name = f.Name
+	}
+	if name == "" {
+		return nil, nil, fmt.Errorf("job name can't be empty")
+	}
+
+	c := &converter{
+		file:        f,
+		hostVolumes: map[string]string{},
+	}
+	if err := c.convert(name); err != nil {
+		return nil, nil, err
+	}
+	return c.job, c.warnings, nil
+}
+
+func (c *converter) warn(format string, args ...interface{}) {
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
+}
+
+func (c *converter) convert(name string) error {
+	c.group = &api.TaskGroup{
+		Name: stringToPtr(name),
+		Networks: []*api.NetworkResource{{
+			Mode: "bridge",
+		}},
+	}
+	c.job = &api.Job{
+		ID:          stringToPtr(name),
+		Name:        stringToPtr(name),
+		Type:        stringToPtr("service"),
+		Datacenters: []string{"dc1"},
+		TaskGroups:  []*api.TaskGroup{c.group},
+	}
+
+	for _, key := range sortedKeys(c.file.Extra) {
+		c.warn("%s isn't supported and was ignored", key)
+	}
+	if len(c.file.Networks) != 0 {
+		c.warn("networks aren't supported; tasks share the group's bridge network")
+	}
+	for _, name := range sortedVolumeNames(c.file.Volumes) {
+		if v := c.file.Volumes[name]; v != nil && (v.Driver != "" || v.External != nil) {
+			c.warn("volume %q is converted to a directory in the shared alloc dir, its driver and external settings are ignored", name)
+		}
+	}
+
+	names := make([]string, 0, len(c.file.Services))
+	for name := range c.file.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lifecycles, err := c.lifecycles(names)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		task, err := c.convertService(name, c.file.Services[name])
+		if err != nil {
+			return fmt.Errorf("service %q: %v", name, err)
+		}
+		task.Lifecycle = lifecycles[name]
+		c.group.Tasks = append(c.group.Tasks, task)
+	}
+
+	if len(c.group.Networks[0].ReservedPorts) == 0 && len(c.group.Networks[0].DynamicPorts) == 0 {
+		c.group.Networks[0].ReservedPorts = nil
+		c.group.Networks[0].DynamicPorts = nil
+	}
+	return nil
+}
+
+// lifecycles turns depends_on into prestart hooks. Services that others
+// depend on become prestart tasks: sidecars if they keep running, and
+// ephemeral tasks if their dependents wait for them to complete.
+func (c *converter) lifecycles(names []string) (map[string]*api.TaskLifecycle, error) {
+	deps := map[string]map[string]string{}
+	for _, name := range names {
+		d, err := dependencies(c.file.Services[name].DependsOn)
+		if err != nil {
+			return nil, fmt.Errorf("service %q: depends_on: %v", name, err)
+		}
+		deps[name] = d
+	}
+
+	lifecycles := map[string]*api.TaskLifecycle{}
+	for _, name := range names {
+		for _, dep := range sortedStringKeys(deps[name]) {
+			if _, ok := c.file.Services[dep]; !ok {
+				return nil, fmt.Errorf("service %q depends on undefined service %q", name, dep)
+			}
+
+			condition := deps[name][dep]
+			switch condition {
+			case "service_started":
+			case "service_healthy":
+				c.warn("service %q: condition service_healthy isn't supported, the task only waits for %q to start", name, dep)
+			case "service_completed_successfully":
+			default:
+				c.warn("service %q: unknown depends_on condition %q", name, condition)
+			}
+
+			l, ok := lifecycles[dep]
+			if !ok {
+				l = &api.TaskLifecycle{Hook: "prestart", Sidecar: true}
+				lifecycles[dep] = l
+			}
+			if condition == "service_completed_successfully" {
+				l.Sidecar = false
+			}
+		}
+	}
+
+	if len(lifecycles) == len(names) {
+		return nil, fmt.Errorf("depends_on forms a cycle, at least one service must not be a dependency")
+	}
+	for _, name := range names {
+		if lifecycles[name] != nil && len(deps[name]) != 0 {
+			c.warn("service %q: prestart tasks start together, so its dependencies on %s aren't ordered",
+				name, strings.Join(sortedStringKeys(deps[name]), ", "))
+		}
+	}
+	return lifecycles, nil
+}
+
+func (c *converter) convertService(name string, svc *service) (*api.Task, error) {
+	if svc == nil {
+		return nil, fmt.Errorf("service is empty")
+	}
+	if svc.Image == "" {
+		return nil, fmt.Errorf("image is required")
+	}
+
+	for _, key := range sortedKeys(svc.Extra) {
+		if hint, ok := serviceHints[key]; ok {
+			c.warn("service %q: %s isn't supported, %s", name, key, hint)
+		} else {
+			c.warn("service %q: %s isn't supported and was ignored", name, key)
+		}
+	}
+
+	config := map[string]interface{}{
+		"image": svc.Image,
+	}
+	task := &api.Task{
+		Name:   name,
+		Driver: "docker",
+		Config: config,
+		User:   svc.User,
+	}
+
+	if svc.Entrypoint != nil {
+		entrypoint, err := commandList(svc.Entrypoint)
+		if err != nil {
+			return nil, fmt.Errorf("entrypoint: %v", err)
+		}
+		config["entrypoint"] = entrypoint
+	}
+	if svc.Command != nil {
+		command, err := commandList(svc.Command)
+		if err != nil {
+			return nil, fmt.Errorf("command: %v", err)
+		}
+		if len(command) > 0 {
+			config["command"] = command[0]
+		}
+		if len(command) > 1 {
+			config["args"] = command[1:]
+		}
+	}
+
+	env, bare, err := mappingOrList(svc.Environment, "=")
+	if err != nil {
+		return nil, fmt.Errorf("environment: %v", err)
+	}
+	if len(env) != 0 {
+		task.Env = env
+	}
+	for _, key := range bare {
+		c.warn("service %q: environment variable %s has no value and is taken from the shell running compose, set it explicitly", name, key)
+	}
+
+	c.convertPorts(name, svc)
+	if err := c.convertVolumes(name, svc, task); err != nil {
+		return nil, err
+	}
+	if err := c.convertHosts(name, svc, task.Config); err != nil {
+		return nil, err
+	}
+	if err := c.convertDockerOptions(name, svc, config); err != nil {
+		return nil, err
+	}
+	if err := c.convertResources(name, svc, task); err != nil {
+		return nil, err
+	}
+	c.convertRestart(name, svc, task)
+
+	if svc.StopGracePeriod != "" {
+		d, err := time.ParseDuration(svc.StopGracePeriod)
+		if err != nil {
+			return nil, fmt.Errorf("stop_grace_period: %v", err)
+		}
+		task.KillTimeout = &d
+	}
+	task.KillSignal = svc.StopSignal
+
+	if svc.Deploy != nil {
+		for _, key := range sortedKeys(svc.Deploy.Extra) {
+			c.warn("service %q: deploy.%s isn't supported and was ignored", name, key)
+		}
+		if r := svc.Deploy.Replicas; r != nil {
+			switch {
+			case c.group.Count == nil:
+				c.group.Count = intToPtr(*r)
+			case *c.group.Count != *r:
+				c.warn("service %q: deploy.replicas %d differs from other services, all tasks run in one group with count %d",
+					name, *r, *c.group.Count)
+			}
+		}
+	}
+	return task, nil
+}
+
+// convertPorts adds published and container ports to the group network. Tasks
+// share the network namespace, so the port is mapped for the whole group.
+func (c *converter) convertPorts(name string, svc *service) {
+	network := c.group.Networks[0]
+	for _, raw := range svc.Ports {
+		p, err := parsePort(raw)
+		if err != nil {
+			c.warn("service %q: %v", name, err)
+			continue
+		}
+		if p.HostIP != "" {
+			c.warn("service %q: host_ip %s isn't supported, port %d is bound on the node's address", name, p.HostIP, p.Target)
+		}
+
+		port := api.Port{
+			Label: label(fmt.Sprintf("%s_%d", name, p.Target)),
+			Value: p.Published,
+			To:    p.Target,
+		}
+		if hasPort(network, port.Label) {
+			port.Label = label(fmt.Sprintf("%s_%d_%d", name, p.Target, p.Published))
+		}
+		if hasPort(network, port.Label) {
+			continue
+		}
+
+		if port.Value != 0 {
+			network.ReservedPorts = append(network.ReservedPorts, port)
+		} else {
+			network.DynamicPorts = append(network.DynamicPorts, port)
+		}
+	}
+}
+
+// convertVolumes maps named volumes to directories in the shared alloc dir
+// and bind mounts to host volumes.
+func (c *converter) convertVolumes(name string, svc *service, task *api.Task) error {
+	var binds []string
+	for _, raw := range svc.Volumes {
+		vol, err := parseVolume(raw)
+		if err != nil {
+			return fmt.Errorf("volumes: %v", err)
+		}
+
+		switch {
+		case vol.Type == volumeTypeVolume && vol.Source == "":
+			c.warn("service %q: anonymous volume %s is kept in the container's filesystem", name, vol.Target)
+
+		case vol.Type == volumeTypeVolume:
+			if _, ok := c.file.Volumes[vol.Source]; !ok {
+				return fmt.Errorf("volumes: undefined volume %q", vol.Source)
+			}
+			bind := path.Join(sharedDataDir, vol.Source) + ":" + vol.Target
+			if vol.ReadOnly {
+				bind += ":ro"
+			}
+			binds = append(binds, bind)
+			c.group.EphemeralDisk = &api.EphemeralDisk{
+				Sticky:  boolToPtr(true),
+				Migrate: boolToPtr(true),
+			}
+
+		case vol.Type == volumeTypeBind:
+			volName := c.hostVolume(vol)
+			task.VolumeMounts = append(task.VolumeMounts, &api.VolumeMount{
+				Volume:      stringToPtr(volName),
+				Destination: stringToPtr(vol.Target),
+				ReadOnly:    boolToPtr(vol.ReadOnly),
+			})
+
+		default:
+			c.warn("service %q: %s volume %s isn't supported and was ignored", name, vol.Type, vol.Target)
+		}
+	}
+
+	if len(binds) != 0 {
+		task.Config["volumes"] = binds
+	}
+	return nil
+}
+
+// hostVolume returns the group volume for a bind mount source, adding it on
+// first use.
+func (c *converter) hostVolume(vol *volumeSpec) string {
+	if name, ok := c.hostVolumes[vol.Source]; ok {
+		if !vol.ReadOnly {
+			c.group.Volumes[name].ReadOnly = false
+		}
+		return name
+	}
+
+	base := label(filepath.Base(filepath.Clean(vol.Source)))
+	if base == "_" {
+		base = "root"
+	}
+	name := base
+	for i := 2; c.group.Volumes[name] != nil; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+
+	if c.group.Volumes == nil {
+		c.group.Volumes = map[string]*api.VolumeRequest{}
+	}
+	c.group.Volumes[name] = &api.VolumeRequest{
+		Name:     name,
+		Type:     "host",
+		Source:   name,
+		ReadOnly: vol.ReadOnly,
+	}
+	c.hostVolumes[vol.Source] = name
+
+	c.warn("volume %q requires clients to define host_volume %q pointing at %s", name, name, vol.Source)
+	return name
+}
+
+// convertHosts keeps service names resolvable by pointing them at localhost,
+// where every task in the group is reachable.
+func (c *converter) convertHosts(name string, svc *service, config map[string]interface{}) error {
+	hosts, _, err := mappingOrList(svc.ExtraHosts, ":")
+	if err != nil {
+		return fmt.Errorf("extra_hosts: %v", err)
+	}
+	if hosts == nil {
+		hosts = map[string]string{}
+	}
+	for other := range c.file.Services {
+		if _, ok := hosts[other]; !ok && other != name {
+			hosts[other] = "127.0.0.1"
+		}
+	}
+
+	if len(hosts) == 0 {
+		return nil
+	}
+	entries := make([]string, 0, len(hosts))
+	for _, host := range sortedStringKeys(hosts) {
+		entries = append(entries, host+":"+hosts[host])
+	}
+	config["extra_hosts"] = entries
+	return nil
+}
+
+// convertDockerOptions copies the container options that map one to one onto
+// the docker driver's task config.
+func (c *converter) convertDockerOptions(name string, svc *service, config map[string]interface{}) error {
+	if len(svc.CapAdd) != 0 {
+		config["cap_add"] = svc.CapAdd
+	}
+	if len(svc.CapDrop) != 0 {
+		config["cap_drop"] = svc.CapDrop
+	}
+	if len(svc.SecurityOpt) != 0 {
+		config["security_opt"] = svc.SecurityOpt
+	}
+	if svc.WorkingDir != "" {
+		config["work_dir"] = svc.WorkingDir
+	}
+	if svc.Privileged {
+		config["privileged"] = true
+	}
+	if svc.Init {
+		config["init"] = true
+	}
+	if svc.Tty {
+		config["tty"] = true
+	}
+	if svc.StdinOpen {
+		config["interactive"] = true
+	}
+
+	dns, err := stringList(svc.DNS)
+	if err != nil {
+		return fmt.Errorf("dns: %v", err)
+	}
+	if len(dns) != 0 {
+		config["dns_servers"] = dns
+	}
+
+	labels, bare, err := mappingOrList(svc.Labels, "=")
+	if err != nil {
+		return fmt.Errorf("labels: %v", err)
+	}
+	for _, key := range bare {
+		labels[key] = ""
+	}
+	if len(labels) != 0 {
+		config["labels"] = []map[string]interface{}{stringMap(labels)}
+	}
+
+	sysctls, _, err := mappingOrList(svc.Sysctls, "=")
+	if err != nil {
+		return fmt.Errorf("sysctls: %v", err)
+	}
+	if len(sysctls) != 0 {
+		config["sysctl"] = []map[string]interface{}{stringMap(sysctls)}
+	}
+
+	if svc.ShmSize != nil {
+		size, err := parseBytes(svc.ShmSize)
+		if err != nil {
+			return fmt.Errorf("shm_size: %v", err)
+		}
+		config["shm_size"] = size
+	}
+	return nil
+}
+
+func (c *converter) convertResources(name string, svc *service, task *api.Task) error {
+	memory := svc.MemLimit
+	cpus := svc.CPUs
+	if svc.Deploy != nil {
+		if m := svc.Deploy.Resources.Limits.Memory; m != nil {
+			memory = m
+		}
+		if cpu := svc.Deploy.Resources.Limits.CPUs; cpu != nil {
+			cpus = cpu
+		}
+	}
+
+	if cpus != nil {
+		c.warn("service %q: cpus can't be converted to MHz, set resources.cpu", name)
+	}
+	if memory != nil {
+		size, err := parseBytes(memory)
+		if err != nil {
+			return fmt.Errorf("memory: %v", err)
+		}
+		mb := int(size / units.MiB)
+		if mb < 1 {
+			mb = 1
+		}
+		task.Resources = &api.Resources{MemoryMB: intToPtr(mb)}
+	}
+	return nil
+}
+
+func (c *converter) convertRestart(name string, svc *service, task *api.Task) {
+	switch policy := svc.Restart; {
+	case policy == "":
+	case policy == "no":
+		task.RestartPolicy = &api.RestartPolicy{
+			Attempts: intToPtr(0),
+			Mode:     stringToPtr("fail"),
+		}
+	case policy == "always" || policy == "unless-stopped":
+		task.RestartPolicy = &api.RestartPolicy{
+			Mode: stringToPtr("delay"),
+		}
+	case strings.HasPrefix(policy, "on-failure"):
+		c.warn("service %q: restart %s also restarts the task after it exits successfully", name, policy)
+	default:
+		c.warn("service %q: unknown restart policy %q", name, policy)
+	}
+}
+
+// parseBytes parses a byte count or a size such as "512m".
+func parseBytes(v interface{}) (int64, error) {
+	switch t := v.(type) {
+	case int:
+		return int64(t), nil
+	case string:
+		return units.RAMInBytes(t)
+	default:
+		return 0, fmt.Errorf("invalid size %v", v)
+	}
+}
+
+func hasPort(network *api.NetworkResource, label string) bool {
+	for _, ports := range [][]api.Port{network.ReservedPorts, network.DynamicPorts} {
+		for _, p := range ports {
+			if p.Label == label {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// label turns s into a valid port or volume label.
+func label(s string) string {
+	return invalidLabelChars.ReplaceAllString(s, "_")
+}
+
+func stringMap(m map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedVolumeNames(m map[string]*volume) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func stringToPtr(v string) *string {
+	return &v
+}
+
+func intToPtr(v int) *int {
+	return &v
+}
+
+func boolToPtr(v bool) *bool {
+	return &v
+}
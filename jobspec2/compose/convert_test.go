@@ -0,0 +1,191 @@
+package compose
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/jobspec2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	src, err := ioutil.ReadFile("test-fixtures/docker-compose.yml")
+	require.NoError(t, err)
+
+	job, warnings, err := Convert("app", src)
+	require.NoError(t, err)
+
+	out, err := Render(job)
+	require.NoError(t, err)
+
+	parsed, err := jobspec2.Parse("app.nomad", bytes.NewReader(out))
+	require.NoError(t, err)
+
+	require.Equal(t, "app", *parsed.ID)
+	require.Equal(t, "service", *parsed.Type)
+	require.Equal(t, []string{"dc1"}, parsed.Datacenters)
+	require.Len(t, parsed.TaskGroups, 1)
+
+	tg := parsed.TaskGroups[0]
+	require.Equal(t, 2, *tg.Count)
+	require.Equal(t, []*api.NetworkResource{{
+		Mode:          "bridge",
+		ReservedPorts: []api.Port{{Label: "web_8080", Value: 80, To: 8080}},
+		DynamicPorts:  []api.Port{{Label: "web_9090", To: 9090}},
+	}}, tg.Networks)
+	require.True(t, *tg.EphemeralDisk.Sticky)
+	require.True(t, *tg.EphemeralDisk.Migrate)
+	require.Equal(t, map[string]*api.VolumeRequest{
+		"conf": {Name: "conf", Type: "host", Source: "conf", ReadOnly: true},
+	}, tg.Volumes)
+
+	require.Len(t, tg.Tasks, 3)
+	db, migrate, web := tg.Tasks[0], tg.Tasks[1], tg.Tasks[2]
+
+	require.Equal(t, "db", db.Name)
+	require.Equal(t, &api.TaskLifecycle{Hook: "prestart", Sidecar: true}, db.Lifecycle)
+	require.Equal(t, 90*time.Second, *db.KillTimeout)
+	require.Equal(t, []interface{}{"../alloc/data/dbdata:/var/lib/postgresql/data"}, db.Config["volumes"])
+
+	require.Equal(t, "migrate", migrate.Name)
+	require.Equal(t, &api.TaskLifecycle{Hook: "prestart"}, migrate.Lifecycle)
+	require.Equal(t, "migrate", migrate.Config["command"])
+	require.Equal(t, []interface{}{"--wait"}, migrate.Config["args"])
+	require.Equal(t, map[string]string{"DB_HOST": "db"}, migrate.Env)
+	require.Equal(t, 0, *migrate.RestartPolicy.Attempts)
+	require.Equal(t, "fail", *migrate.RestartPolicy.Mode)
+
+	require.Equal(t, "web", web.Name)
+	require.Nil(t, web.Lifecycle)
+	require.Equal(t, "docker", web.Driver)
+	require.Equal(t, "example/web:1.4", web.Config["image"])
+	require.Equal(t, "serve", web.Config["command"])
+	require.Equal(t, []interface{}{"--port", "8080"}, web.Config["args"])
+	require.Equal(t, []interface{}{"db:127.0.0.1", "migrate:127.0.0.1"}, web.Config["extra_hosts"])
+	require.Equal(t, []interface{}{"../alloc/data/uploads:/srv/uploads"}, web.Config["volumes"])
+	require.Equal(t, []map[string]interface{}{{"com.example.team": "web"}}, web.Config["labels"])
+	require.Equal(t, map[string]string{"DB_HOST": "db", "CACHE_TTL": "30"}, web.Env)
+	require.Equal(t, []*api.VolumeMount{{
+		Volume:      stringToPtr("conf"),
+		Destination: stringToPtr("/etc/web"),
+		ReadOnly:    boolToPtr(true),
+	}}, web.VolumeMounts)
+	require.Equal(t, "delay", *web.RestartPolicy.Mode)
+	require.Equal(t, 512, *web.Resources.MemoryMB)
+
+	require.ElementsMatch(t, []string{
+		`networks aren't supported; tasks share the group's bridge network`,
+		`volume "dbdata" is converted to a directory in the shared alloc dir, its driver and external settings are ignored`,
+		`service "web": condition service_healthy isn't supported, the task only waits for "db" to start`,
+		`service "db": build isn't supported, build the image ahead of time and set image`,
+		`service "db": anonymous volume /var/lib/postgresql is kept in the container's filesystem`,
+		`service "migrate": environment variable DB_PASSWORD has no value and is taken from the shell running compose, set it explicitly`,
+		`service "web": healthcheck isn't supported, add a service block with a check`,
+		`volume "conf" requires clients to define host_volume "conf" pointing at ./conf`,
+	}, warnings)
+}
+
+func TestConvert_Name(t *testing.T) {
+	job, _, err := Convert("dir", []byte("name: vendor\nservices:\n  app:\n    image: redis\n"))
+	require.NoError(t, err)
+	require.Equal(t, "vendor", *job.ID)
+	require.Equal(t, "vendor", *job.TaskGroups[0].Name)
+	require.Nil(t, job.TaskGroups[0].Networks[0].ReservedPorts)
+}
+
+func TestConvert_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{
+			name: "no services",
+			src:  "version: '3'\n",
+			err:  "compose file doesn't define any services",
+		},
+		{
+			name: "missing image",
+			src:  "services:\n  app:\n    build: .\n",
+			err:  `service "app": image is required`,
+		},
+		{
+			name: "undefined dependency",
+			src:  "services:\n  app:\n    image: redis\n    depends_on: [db]\n",
+			err:  `service "app" depends on undefined service "db"`,
+		},
+		{
+			name: "dependency cycle",
+			src:  "services:\n  a:\n    image: redis\n    depends_on: [b]\n  b:\n    image: redis\n    depends_on: [a]\n",
+			err:  "depends_on forms a cycle",
+		},
+		{
+			name: "undefined volume",
+			src:  "services:\n  app:\n    image: redis\n    volumes: ['data:/data']\n",
+			err:  `undefined volume "data"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := Convert("example", []byte(tc.src))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestParsePort(t *testing.T) {
+	cases := []struct {
+		in  interface{}
+		out *portSpec
+		err string
+	}{
+		{in: 80, out: &portSpec{Target: 80, Protocol: "tcp"}},
+		{in: "8080:80", out: &portSpec{Published: 8080, Target: 80, Protocol: "tcp"}},
+		{in: "127.0.0.1:53:53/udp", out: &portSpec{HostIP: "127.0.0.1", Published: 53, Target: 53, Protocol: "udp"}},
+		{in: "127.0.0.1::80", out: &portSpec{HostIP: "127.0.0.1", Target: 80, Protocol: "tcp"}},
+		{
+			in:  map[interface{}]interface{}{"target": 80, "published": "8080", "protocol": "tcp"},
+			out: &portSpec{Published: 8080, Target: 80, Protocol: "tcp"},
+		},
+		{in: "8000-8010:8000-8010", err: "port ranges aren't supported"},
+		{in: "http", err: "invalid port number"},
+	}
+
+	for _, tc := range cases {
+		p, err := parsePort(tc.in)
+		if tc.err != "" {
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.out, p)
+	}
+}
+
+func TestParseVolume(t *testing.T) {
+	cases := []struct {
+		in  interface{}
+		out *volumeSpec
+	}{
+		{in: "/data", out: &volumeSpec{Type: volumeTypeVolume, Target: "/data"}},
+		{in: "data:/data", out: &volumeSpec{Type: volumeTypeVolume, Source: "data", Target: "/data"}},
+		{in: "./conf:/etc/app:ro", out: &volumeSpec{Type: volumeTypeBind, Source: "./conf", Target: "/etc/app", ReadOnly: true}},
+		{in: "/var/run:/var/run:rw,z", out: &volumeSpec{Type: volumeTypeBind, Source: "/var/run", Target: "/var/run"}},
+		{
+			in:  map[interface{}]interface{}{"type": "tmpfs", "target": "/tmp"},
+			out: &volumeSpec{Type: volumeTypeTmpfs, Target: "/tmp"},
+		},
+	}
+
+	for _, tc := range cases {
+		v, err := parseVolume(tc.in)
+		require.NoError(t, err)
+		require.Equal(t, tc.out, v)
+	}
+}
@@ -0,0 +1,207 @@
+package compose
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/nomad/api"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Render writes a job produced by Convert as an HCL2 jobspec. It only
+// handles the fields the converter sets.
+func Render(job *api.Job) ([]byte, error) {
+	f := hclwrite.NewEmptyFile()
+
+	jobBlock := f.Body().AppendNewBlock("job", []string{*job.ID})
+	body := jobBlock.Body()
+	body.SetAttributeValue("datacenters", stringListValue(job.Datacenters))
+	if job.Type != nil {
+		body.SetAttributeValue("type", cty.StringVal(*job.Type))
+	}
+
+	for _, tg := range job.TaskGroups {
+		body.AppendNewline()
+		if err := renderGroup(body, tg); err != nil {
+			return nil, err
+		}
+	}
+
+	return hclwrite.Format(f.Bytes()), nil
+}
+
+func renderGroup(parent *hclwrite.Body, tg *api.TaskGroup) error {
+	body := parent.AppendNewBlock("group", []string{*tg.Name}).Body()
+	if tg.Count != nil {
+		body.SetAttributeValue("count", cty.NumberIntVal(int64(*tg.Count)))
+	}
+
+	for _, network := range tg.Networks {
+		body.AppendNewline()
+		nb := body.AppendNewBlock("network", nil).Body()
+		nb.SetAttributeValue("mode", cty.StringVal(network.Mode))
+		for _, p := range append(append([]api.Port{}, network.ReservedPorts...), network.DynamicPorts...) {
+			nb.AppendNewline()
+			pb := nb.AppendNewBlock("port", []string{p.Label}).Body()
+			if p.Value != 0 {
+				pb.SetAttributeValue("static", cty.NumberIntVal(int64(p.Value)))
+			}
+			if p.To != 0 {
+				pb.SetAttributeValue("to", cty.NumberIntVal(int64(p.To)))
+			}
+		}
+	}
+
+	if d := tg.EphemeralDisk; d != nil {
+		body.AppendNewline()
+		db := body.AppendNewBlock("ephemeral_disk", nil).Body()
+		if d.Sticky != nil {
+			db.SetAttributeValue("sticky", cty.BoolVal(*d.Sticky))
+		}
+		if d.Migrate != nil {
+			db.SetAttributeValue("migrate", cty.BoolVal(*d.Migrate))
+		}
+	}
+
+	names := make([]string, 0, len(tg.Volumes))
+	for name := range tg.Volumes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		v := tg.Volumes[name]
+		body.AppendNewline()
+		vb := body.AppendNewBlock("volume", []string{name}).Body()
+		vb.SetAttributeValue("type", cty.StringVal(v.Type))
+		vb.SetAttributeValue("source", cty.StringVal(v.Source))
+		if v.ReadOnly {
+			vb.SetAttributeValue("read_only", cty.True)
+		}
+	}
+
+	for _, task := range tg.Tasks {
+		body.AppendNewline()
+		if err := renderTask(body, task); err != nil {
+			return fmt.Errorf("task %q: %v", task.Name, err)
+		}
+	}
+	return nil
+}
+
+func renderTask(parent *hclwrite.Body, task *api.Task) error {
+	body := parent.AppendNewBlock("task", []string{task.Name}).Body()
+	body.SetAttributeValue("driver", cty.StringVal(task.Driver))
+	if task.User != "" {
+		body.SetAttributeValue("user", cty.StringVal(task.User))
+	}
+	if task.KillTimeout != nil {
+		body.SetAttributeValue("kill_timeout", cty.StringVal(task.KillTimeout.String()))
+	}
+	if task.KillSignal != "" {
+		body.SetAttributeValue("kill_signal", cty.StringVal(task.KillSignal))
+	}
+
+	if l := task.Lifecycle; l != nil {
+		body.AppendNewline()
+		lb := body.AppendNewBlock("lifecycle", nil).Body()
+		lb.SetAttributeValue("hook", cty.StringVal(l.Hook))
+		lb.SetAttributeValue("sidecar", cty.BoolVal(l.Sidecar))
+	}
+
+	body.AppendNewline()
+	cb := body.AppendNewBlock("config", nil).Body()
+	keys := make([]string, 0, len(task.Config))
+	for k := range task.Config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v, err := configValue(task.Config[k])
+		if err != nil {
+			return fmt.Errorf("config %q: %v", k, err)
+		}
+		cb.SetAttributeValue(k, v)
+	}
+
+	if len(task.Env) != 0 {
+		body.AppendNewline()
+		env := make(map[string]cty.Value, len(task.Env))
+		identifiers := true
+		for k, v := range task.Env {
+			env[k] = cty.StringVal(v)
+			identifiers = identifiers && hclsyntax.ValidIdentifier(k)
+		}
+		if identifiers {
+			eb := body.AppendNewBlock("env", nil).Body()
+			for _, k := range sortedStringKeys(task.Env) {
+				eb.SetAttributeValue(k, env[k])
+			}
+		} else {
+			body.SetAttributeValue("env", cty.MapVal(env))
+		}
+	}
+
+	for _, vm := range task.VolumeMounts {
+		body.AppendNewline()
+		vb := body.AppendNewBlock("volume_mount", nil).Body()
+		vb.SetAttributeValue("volume", cty.StringVal(*vm.Volume))
+		vb.SetAttributeValue("destination", cty.StringVal(*vm.Destination))
+		if vm.ReadOnly != nil {
+			vb.SetAttributeValue("read_only", cty.BoolVal(*vm.ReadOnly))
+		}
+	}
+
+	if r := task.RestartPolicy; r != nil {
+		body.AppendNewline()
+		rb := body.AppendNewBlock("restart", nil).Body()
+		if r.Attempts != nil {
+			rb.SetAttributeValue("attempts", cty.NumberIntVal(int64(*r.Attempts)))
+		}
+		if r.Mode != nil {
+			rb.SetAttributeValue("mode", cty.StringVal(*r.Mode))
+		}
+	}
+
+	if r := task.Resources; r != nil && r.MemoryMB != nil {
+		body.AppendNewline()
+		rb := body.AppendNewBlock("resources", nil).Body()
+		rb.SetAttributeValue("memory", cty.NumberIntVal(int64(*r.MemoryMB)))
+	}
+	return nil
+}
+
+// configValue converts a driver config value set by the converter.
+func configValue(v interface{}) (cty.Value, error) {
+	switch t := v.(type) {
+	case string:
+		return cty.StringVal(t), nil
+	case bool:
+		return cty.BoolVal(t), nil
+	case int64:
+		return cty.NumberIntVal(t), nil
+	case []string:
+		return stringListValue(t), nil
+	case []map[string]interface{}:
+		vals := make([]cty.Value, 0, len(t))
+		for _, m := range t {
+			obj := make(map[string]cty.Value, len(m))
+			for k, e := range m {
+				obj[k] = cty.StringVal(fmt.Sprint(e))
+			}
+			vals = append(vals, cty.ObjectVal(obj))
+		}
+		return cty.TupleVal(vals), nil
+	default:
+		return cty.NilVal, fmt.Errorf("unsupported value %T", v)
+	}
+}
+
+func stringListValue(l []string) cty.Value {
+	vals := make([]cty.Value, 0, len(l))
+	for _, s := range l {
+		vals = append(vals, cty.StringVal(s))
+	}
+	return cty.TupleVal(vals)
+}
@@ -909,6 +909,7 @@ func TestParse_UndefinedVariables(t *testing.T) {
 		"plain",
 		"foo-${BAR}",
 		"foo-${attr.network.dev-us-east1-relay-vpc.external-ip.0}",
+		"${attr.unique.driver.docker.image.registry_2eexample_2ecom_2fteam_2fapp_3a1_2e21-alpine}",
 		`${env["BLAH"]}`,
 		`${mixed-indexing.0[3]["FOO"].5}`,
 		`with spaces ${   root.  field[  "FOO"].5  }`,
@@ -1,11 +1,16 @@
 package nomad
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	metrics "github.com/armon/go-metrics"
@@ -28,8 +33,21 @@ type FileSystem struct {
 func (f *FileSystem) register() {
 	f.srv.streamingRpcs.Register("FileSystem.Logs", f.logs)
 	f.srv.streamingRpcs.Register("FileSystem.Stream", f.stream)
+	f.srv.streamingRpcs.Register("FileSystem.SearchJobLogs", f.searchJobLogs)
 }
 
+const (
+	// jobLogsSearchConcurrency is the number of allocations whose logs are
+	// searched concurrently when searching the logs of a job.
+	jobLogsSearchConcurrency = 8
+
+	// defaultJobLogsSearchLimit and maxJobLogsSearchLimit are the default
+	// and maximum number of matches returned by a search of the logs of a
+	// job.
+	defaultJobLogsSearchLimit = 1000
+	maxJobLogsSearchLimit     = 10000
+)
+
 // handleStreamResultError is a helper for sending an error with a potential
 // error code. The transmission of the error is ignored if the error has been
 // generated by the closing of the underlying transport.
@@ -201,6 +219,272 @@ func (f *FileSystem) Stat(args *cstructs.FsStatRequest, reply *cstructs.FsStatRe
 	return NodeRpc(state.Session, "FileSystem.Stat", args, reply)
 }
 
+// SearchLogs is used to search the logs of an allocation's tasks for lines
+// matching a regular expression.
+func (f *FileSystem) SearchLogs(args *cstructs.FsSearchLogsRequest, reply *cstructs.FsSearchLogsResponse) error {
+	// We only allow stale reads since the only potentially stale information is
+	// the Node registration and the cost is fairly high for adding another hope
+	// in the forwarding chain.
+	args.QueryOptions.AllowStale = true
+
+	// Potentially forward to a different region.
+	if done, err := f.srv.forward("FileSystem.SearchLogs", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "file_system", "search_logs"}, time.Now())
+
+	// Verify the arguments.
+	if args.AllocID == "" {
+		return structs.ErrMissingAllocID
+	}
+
+	// Lookup the allocation
+	snap, err := f.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	alloc, err := getAlloc(snap, args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Check namespace read-logs *or* read-fs permissions.
+	allowNsOp := acl.NamespaceValidator(
+		acl.NamespaceCapabilityReadFS, acl.NamespaceCapabilityReadLogs)
+	aclObj, err := f.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if !allowNsOp(aclObj, alloc.Namespace) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Make sure Node is valid and new enough to support RPC
+	_, err = getNodeForRpc(snap, alloc.NodeID)
+	if err != nil {
+		return err
+	}
+
+	// Get the connection to the client
+	state, ok := f.srv.getNodeConn(alloc.NodeID)
+	if !ok {
+		return findNodeConnAndForward(f.srv, alloc.NodeID, "FileSystem.SearchLogs", args, reply)
+	}
+
+	// Make the RPC
+	return NodeRpc(state.Session, "FileSystem.SearchLogs", args, reply)
+}
+
+// searchJobLogs is used to search the logs of all the allocations of a job
+// for lines matching a regular expression. The allocations are searched
+// concurrently by their clients and the matches are streamed as JSON
+// encoded LogMatches, one per line.
+func (f *FileSystem) searchJobLogs(conn io.ReadWriteCloser) {
+	defer conn.Close()
+	defer metrics.MeasureSince([]string{"nomad", "file_system", "search_job_logs"}, time.Now())
+
+	// Decode the arguments
+	var args cstructs.FsSearchJobLogsRequest
+	decoder := codec.NewDecoder(conn, structs.MsgpackHandle)
+	encoder := codec.NewEncoder(conn, structs.MsgpackHandle)
+
+	if err := decoder.Decode(&args); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+		return
+	}
+
+	// Check if we need to forward to a different region
+	if r := args.RequestRegion(); r != f.srv.Region() {
+		srv, err := f.srv.findRegionServer(r)
+		if err != nil {
+			handleStreamResultError(err, nil, encoder)
+			return
+		}
+
+		srvConn, err := f.srv.streamingRpc(srv, "FileSystem.SearchJobLogs")
+		if err != nil {
+			handleStreamResultError(err, nil, encoder)
+			return
+		}
+		defer srvConn.Close()
+
+		outEncoder := codec.NewEncoder(srvConn, structs.MsgpackHandle)
+		if err := outEncoder.Encode(args); err != nil {
+			handleStreamResultError(err, nil, encoder)
+			return
+		}
+
+		structs.Bridge(conn, srvConn)
+		return
+	}
+
+	// Verify the arguments.
+	if args.JobID == "" {
+		handleStreamResultError(errors.New("missing job ID"), helper.Int64ToPtr(400), encoder)
+		return
+	}
+	if args.Pattern == "" {
+		handleStreamResultError(errors.New("missing pattern"), helper.Int64ToPtr(400), encoder)
+		return
+	}
+	if _, err := regexp.Compile(args.Pattern); err != nil {
+		handleStreamResultError(fmt.Errorf("invalid pattern: %v", err), helper.Int64ToPtr(400), encoder)
+		return
+	}
+	switch args.LogType {
+	case "", "stdout", "stderr":
+	default:
+		handleStreamResultError(fmt.Errorf("invalid log type %q", args.LogType), helper.Int64ToPtr(400), encoder)
+		return
+	}
+	limit := args.Limit
+	if limit <= 0 {
+		limit = defaultJobLogsSearchLimit
+	} else if limit > maxJobLogsSearchLimit {
+		limit = maxJobLogsSearchLimit
+	}
+
+	// Check namespace read-logs *or* read-fs permissions.
+	allowNsOp := acl.NamespaceValidator(
+		acl.NamespaceCapabilityReadFS, acl.NamespaceCapabilityReadLogs)
+	aclObj, err := f.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		handleStreamResultError(err, nil, encoder)
+		return
+	} else if !allowNsOp(aclObj, args.RequestNamespace()) {
+		handleStreamResultError(structs.ErrPermissionDenied, nil, encoder)
+		return
+	}
+
+	// Retrieve the allocations of the job
+	snap, err := f.srv.State().Snapshot()
+	if err != nil {
+		handleStreamResultError(err, nil, encoder)
+		return
+	}
+
+	job, err := snap.JobByID(nil, args.RequestNamespace(), args.JobID)
+	if err != nil {
+		handleStreamResultError(err, nil, encoder)
+		return
+	}
+	if job == nil {
+		handleStreamResultError(fmt.Errorf("job %q not found", args.JobID), helper.Int64ToPtr(404), encoder)
+		return
+	}
+
+	allocs, err := snap.AllocsByJob(nil, args.RequestNamespace(), args.JobID, false)
+	if err != nil {
+		handleStreamResultError(err, nil, encoder)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Detect the remote side closing
+	go func() {
+		io.Copy(ioutil.Discard, conn)
+		cancel()
+	}()
+
+	// Search the allocations with bounded concurrency. The matches of an
+	// allocation are sent together once its search is done.
+	results := make(chan []*cstructs.LogMatch)
+	go func() {
+		defer close(results)
+
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, jobLogsSearchConcurrency)
+	OUTER:
+		for _, alloc := range allocs {
+			// Pending allocations have no logs yet
+			if alloc.ClientStatus == structs.AllocClientStatusPending {
+				continue
+			}
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				break OUTER
+			}
+
+			wg.Add(1)
+			go func(alloc *structs.Allocation) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				matches := f.searchAllocLogs(alloc, &args, limit)
+				select {
+				case results <- matches:
+				case <-ctx.Done():
+				}
+			}(alloc)
+		}
+		wg.Wait()
+	}()
+
+	sent := 0
+	for matches := range results {
+		for _, m := range matches {
+			if sent == limit {
+				m = &cstructs.LogMatch{Truncated: true}
+			}
+
+			payload, err := json.Marshal(m)
+			if err != nil {
+				handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+				return
+			}
+			if err := encoder.Encode(&cstructs.StreamErrWrapper{Payload: append(payload, '\n')}); err != nil {
+				return
+			}
+			encoder.Reset(conn)
+
+			if m.Truncated {
+				return
+			}
+			sent++
+		}
+	}
+}
+
+// searchAllocLogs searches the logs of an allocation of a job. Errors are
+// returned as a LogMatch with the error set.
+func (f *FileSystem) searchAllocLogs(alloc *structs.Allocation, args *cstructs.FsSearchJobLogsRequest,
+	limit int) []*cstructs.LogMatch {
+
+	req := &cstructs.FsSearchLogsRequest{
+		AllocID:      alloc.ID,
+		Task:         args.Task,
+		LogType:      args.LogType,
+		Pattern:      args.Pattern,
+		Limit:        limit,
+		QueryOptions: args.QueryOptions,
+	}
+	var reply cstructs.FsSearchLogsResponse
+	if err := f.SearchLogs(req, &reply); err != nil {
+		// Terminal allocations may have been garbage collected by their
+		// client, and groups may not have the task being searched
+		if alloc.ClientTerminalStatus() && structs.IsErrUnknownAllocation(err) {
+			return nil
+		}
+		if args.Task != "" && strings.Contains(err.Error(), "unknown task name") {
+			return nil
+		}
+		return []*cstructs.LogMatch{{
+			AllocID: alloc.ID,
+			NodeID:  alloc.NodeID,
+			Error:   err.Error(),
+		}}
+	}
+
+	if reply.Truncated {
+		reply.Matches = append(reply.Matches, &cstructs.LogMatch{Truncated: true})
+	}
+	return reply.Matches
+}
+
 // stream is is used to stream the contents of file in an allocation's
 // directory.
 func (f *FileSystem) stream(conn io.ReadWriteCloser) {
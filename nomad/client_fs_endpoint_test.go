@@ -1,9 +1,11 @@
 package nomad
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -1691,6 +1693,188 @@ OUTER:
 	}
 }
 
+func TestClientFS_SearchJobLogs(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Start a server and client
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+	rpcCodec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	c, cleanupC := client.TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.config.RPCAddr.String()}
+	})
+	defer cleanupC()
+
+	// Force an allocation onto the node, and another one onto an unknown
+	// node
+	a := mock.Alloc()
+	a.Job.Type = structs.JobTypeBatch
+	a.NodeID = c.NodeID()
+	a.Job.TaskGroups[0].Count = 2
+	a.Job.TaskGroups[0].Tasks[0] = &structs.Task{
+		Name:   "web",
+		Driver: "mock_driver",
+		Config: map[string]interface{}{
+			"run_for":       "2s",
+			"stdout_string": "starting\nerror: out of cheese\ndone\n",
+		},
+		LogConfig: structs.DefaultLogConfig(),
+		Resources: &structs.Resources{
+			CPU:      500,
+			MemoryMB: 256,
+		},
+	}
+	b := mock.Alloc()
+	b.Job = a.Job
+	b.JobID = a.JobID
+	b.NodeID = uuid.Generate()
+	b.ClientStatus = structs.AllocClientStatusRunning
+
+	// Wait for the client to connect
+	testutil.WaitForResult(func() (bool, error) {
+		nodes := s.connectedNodes()
+		return len(nodes) == 1, nil
+	}, func(err error) {
+		t.Fatalf("should have a clients")
+	})
+
+	// Upsert the allocations
+	state := s.State()
+	require.Nil(state.UpsertJob(structs.MsgTypeTestSetup, 999, a.Job))
+	require.Nil(state.UpsertAllocs(structs.MsgTypeTestSetup, 1003, []*structs.Allocation{a, b}))
+
+	// Wait for the client to run the allocation
+	testutil.WaitForResult(func() (bool, error) {
+		alloc, err := state.AllocByID(nil, a.ID)
+		if err != nil {
+			return false, err
+		}
+		if alloc == nil {
+			return false, fmt.Errorf("unknown alloc")
+		}
+		if alloc.ClientStatus != structs.AllocClientStatusComplete {
+			return false, fmt.Errorf("alloc client status: %v", alloc.ClientStatus)
+		}
+
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("Alloc on node %q not finished: %v", c.NodeID(), err)
+	})
+
+	// Search the logs of the allocation
+	allocReq := &cstructs.FsSearchLogsRequest{
+		AllocID:      a.ID,
+		Pattern:      "^(starting|done)$",
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var allocResp cstructs.FsSearchLogsResponse
+	require.NoError(msgpackrpc.CallWithCodec(rpcCodec, "FileSystem.SearchLogs", allocReq, &allocResp))
+	require.Len(allocResp.Matches, 2)
+	require.Equal("starting", allocResp.Matches[0].Text)
+	require.Equal(1, allocResp.Matches[0].Line)
+	require.Equal("done", allocResp.Matches[1].Text)
+	require.Equal(3, allocResp.Matches[1].Line)
+	require.False(allocResp.Truncated)
+
+	// Search the logs of the job
+	req := &cstructs.FsSearchJobLogsRequest{
+		JobID:        a.JobID,
+		Pattern:      "(?i)ERROR",
+		QueryOptions: structs.QueryOptions{Region: "global", Namespace: a.Namespace},
+	}
+
+	handler, err := s.StreamingRpcHandler("FileSystem.SearchJobLogs")
+	require.Nil(err)
+
+	p1, p2 := net.Pipe()
+	defer p1.Close()
+	defer p2.Close()
+	p1.SetDeadline(time.Now().Add(10 * time.Second))
+
+	go handler(p2)
+
+	encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+	require.Nil(encoder.Encode(req))
+
+	// The matches are streamed until the search is done
+	var matches []*cstructs.LogMatch
+	decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+	for {
+		var msg cstructs.StreamErrWrapper
+		if err := decoder.Decode(&msg); err != nil {
+			if err == io.EOF || strings.Contains(err.Error(), "closed") {
+				break
+			}
+			t.Fatalf("error decoding: %v", err)
+		}
+		require.Nil(msg.Error)
+
+		var m cstructs.LogMatch
+		require.NoError(json.Unmarshal(msg.Payload, &m))
+		matches = append(matches, &m)
+	}
+
+	require.Len(matches, 2)
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Error == "" })
+	require.Equal(&cstructs.LogMatch{
+		AllocID: a.ID,
+		NodeID:  c.NodeID(),
+		Task:    "web",
+		LogType: "stdout",
+		File:    "web.stdout.0",
+		Line:    2,
+		Text:    "error: out of cheese",
+	}, matches[0])
+	require.Equal(b.ID, matches[1].AllocID)
+	require.Contains(matches[1].Error, "Unknown node")
+}
+
+func TestClientFS_SearchJobLogs_Invalid(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	handler, err := s.StreamingRpcHandler("FileSystem.SearchJobLogs")
+	require.Nil(err)
+
+	cases := []struct {
+		req  *cstructs.FsSearchJobLogsRequest
+		code int64
+		err  string
+	}{
+		{&cstructs.FsSearchJobLogsRequest{Pattern: "foo"}, 400, "missing job ID"},
+		{&cstructs.FsSearchJobLogsRequest{JobID: "example"}, 400, "missing pattern"},
+		{&cstructs.FsSearchJobLogsRequest{JobID: "example", Pattern: "("}, 400, "invalid pattern"},
+		{&cstructs.FsSearchJobLogsRequest{JobID: "example", Pattern: "foo"}, 404, "not found"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.err, func(t *testing.T) {
+			tc.req.QueryOptions = structs.QueryOptions{Region: "global"}
+
+			p1, p2 := net.Pipe()
+			defer p1.Close()
+			defer p2.Close()
+			p1.SetDeadline(time.Now().Add(5 * time.Second))
+
+			go handler(p2)
+			require.NoError(codec.NewEncoder(p1, structs.MsgpackHandle).Encode(tc.req))
+
+			var msg cstructs.StreamErrWrapper
+			require.NoError(codec.NewDecoder(p1, structs.MsgpackHandle).Decode(&msg))
+			require.NotNil(msg.Error)
+			require.Contains(msg.Error.Error(), tc.err)
+			require.Equal(tc.code, *msg.Error.Code)
+		})
+	}
+}
+
 func TestClientFS_Logs_Local_Follow(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
@@ -0,0 +1,76 @@
+package nomad
+
+import (
+	"errors"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	nstructs "github.com/hashicorp/nomad/nomad/structs"
+
+	"github.com/hashicorp/nomad/client/structs"
+)
+
+// ClientImages is used to forward RPC requests to the targed Nomad client's
+// ClientImages endpoint.
+type ClientImages struct {
+	srv    *Server
+	logger log.Logger
+}
+
+func (i *ClientImages) Prefetch(args *structs.ImagePrefetchRequest, reply *nstructs.GenericResponse) error {
+	// We only allow stale reads since the only potentially stale information is
+	// the Node registration and the cost is fairly high for adding another hope
+	// in the forwarding chain.
+	args.QueryOptions.AllowStale = true
+
+	// Potentially forward to a different region.
+	if done, err := i.srv.forward("ClientImages.Prefetch", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client_images", "prefetch"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := i.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+		return nstructs.ErrPermissionDenied
+	}
+
+	// Verify the arguments.
+	if args.NodeID == "" {
+		return errors.New("missing NodeID")
+	}
+
+	// Check if the node even exists and is compatible with NodeRpc
+	snap, err := i.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// Make sure Node is new enough to support RPC
+	_, err = getNodeForRpc(snap, args.NodeID)
+	if err != nil {
+		return err
+	}
+
+	// Get the connection to the client
+	state, ok := i.srv.getNodeConn(args.NodeID)
+	if !ok {
+
+		// Determine the Server that has a connection to the node.
+		srv, err := i.srv.serverWithNodeConn(args.NodeID, i.srv.Region())
+		if err != nil {
+			return err
+		}
+
+		if srv == nil {
+			return nstructs.ErrNoNodeConn
+		}
+
+		return i.srv.forwardServer(srv, "ClientImages.Prefetch", args, reply)
+	}
+
+	// Make the RPC
+	return NodeRpc(state.Session, "ClientImages.Prefetch", args, reply)
+}
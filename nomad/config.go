@@ -349,6 +349,14 @@ type Config struct {
 	// DeploymentQueryRateLimit is in queries per second and is used by the
 	// DeploymentWatcher to throttle the amount of simultaneously deployments
 	DeploymentQueryRateLimit float64
+
+	// RecommenderEnabled enables the recommender of the leader, which samples
+	// the resource usage of running tasks every RecommenderInterval and
+	// recommends their CPU and memory once it has RecommenderMinSamples
+	// samples of a task.
+	RecommenderEnabled    bool
+	RecommenderInterval   time.Duration
+	RecommenderMinSamples int
 }
 
 // DefaultConfig returns the default configuration. Only used as the basis for
@@ -423,6 +431,8 @@ func DefaultConfig() *Config {
 			},
 		},
 		DeploymentQueryRateLimit: deploymentwatcher.LimitStateQueriesPerSecond,
+		RecommenderInterval:      1 * time.Minute,
+		RecommenderMinSamples:    60,
 	}
 
 	// Enable all known schedulers by default
@@ -0,0 +1,343 @@
+package nomad
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// dispatchReleaseRetryInterval is how long the leader waits before
+	// retrying after failing to release queued dispatched jobs.
+	dispatchReleaseRetryInterval = 5 * time.Second
+)
+
+// dispatchLimiter enforces the rate and max_running limits of parameterized
+// jobs. It is only used by the leader, since Job.Dispatch is always forwarded
+// there.
+type dispatchLimiter struct {
+	l        sync.Mutex
+	limiters map[structs.NamespacedID]*dispatchRateLimit
+
+	// queueLocks serialize, per parameterized job with max_running set, the
+	// decision to queue a dispatched job with the registration of that job
+	// and with the release of queued jobs, so that concurrent dispatches
+	// cannot exceed max_running. Entries are dropped once no caller holds or
+	// waits on them.
+	queueLocks map[structs.NamespacedID]*queueLock
+}
+
+// queueLock is the queue lock of a single parameterized job along with the
+// number of callers holding or waiting on it.
+type queueLock struct {
+	sync.Mutex
+	refs int
+}
+
+// dispatchRateLimit is the token bucket of a single parameterized job along
+// with the configuration it was built from.
+type dispatchRateLimit struct {
+	config  structs.DispatchRateConfig
+	limiter *rate.Limiter
+
+	// refunds are the tokens given back by failed dispatches, which are used
+	// before taking tokens from the bucket.
+	refunds int
+}
+
+func newDispatchLimiter() *dispatchLimiter {
+	return &dispatchLimiter{
+		limiters:   make(map[structs.NamespacedID]*dispatchRateLimit),
+		queueLocks: make(map[structs.NamespacedID]*queueLock),
+	}
+}
+
+// Allow returns whether the parameterized job may be dispatched now, taking a
+// token from its bucket if so. Jobs without a rate are always allowed. The
+// returned function gives the token back and must be called if the dispatch
+// fails.
+func (d *dispatchLimiter) Allow(job *structs.Job) (bool, func()) {
+	d.l.Lock()
+	defer d.l.Unlock()
+
+	id := job.NamespacedID()
+	cfg := job.ParameterizedJob.Rate
+	if cfg == nil {
+		delete(d.limiters, id)
+		return true, func() {}
+	}
+
+	// Rebuild the bucket if the job's rate has been updated
+	existing, ok := d.limiters[id]
+	if !ok || existing.config != *cfg {
+		existing = &dispatchRateLimit{
+			config:  *cfg,
+			limiter: rate.NewLimiter(rate.Limit(cfg.Limit), cfg.Burst),
+		}
+		d.limiters[id] = existing
+	}
+
+	if existing.refunds > 0 {
+		existing.refunds--
+	} else if !existing.limiter.Allow() {
+		return false, nil
+	}
+
+	return true, func() {
+		d.l.Lock()
+		defer d.l.Unlock()
+		if d.limiters[id] == existing && existing.refunds < existing.config.Burst {
+			existing.refunds++
+		}
+	}
+}
+
+// lockQueue acquires the lock serializing the queueing decisions of the
+// parameterized job and returns the function releasing it.
+func (d *dispatchLimiter) lockQueue(id structs.NamespacedID) func() {
+	d.l.Lock()
+	lock, ok := d.queueLocks[id]
+	if !ok {
+		lock = &queueLock{}
+		d.queueLocks[id] = lock
+	}
+	lock.refs++
+	d.l.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+
+		d.l.Lock()
+		defer d.l.Unlock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(d.queueLocks, id)
+		}
+	}
+}
+
+// Remove drops the bucket of a job. It is called when the job is
+// deregistered.
+func (d *dispatchLimiter) Remove(id structs.NamespacedID) {
+	d.l.Lock()
+	defer d.l.Unlock()
+	delete(d.limiters, id)
+}
+
+// Reset drops the buckets of all jobs. It is called when leadership is lost.
+// Queue locks are kept since they are dropped once unused.
+func (d *dispatchLimiter) Reset() {
+	d.l.Lock()
+	defer d.l.Unlock()
+	d.limiters = make(map[structs.NamespacedID]*dispatchRateLimit)
+}
+
+// shouldQueue returns whether a new dispatch of the parameterized job must be
+// queued because the job already has max_running children pending or running,
+// or because earlier dispatches are still queued. The caller must hold the
+// queue lock of the job.
+func (d *dispatchLimiter) shouldQueue(ws memdb.WatchSet, snap *state.StateStore, job *structs.Job) (bool, error) {
+	max := job.ParameterizedJob.MaxRunning
+	if max == 0 {
+		return false, nil
+	}
+
+	summary, err := snap.JobSummaryByID(ws, job.Namespace, job.ID)
+	if err != nil {
+		return false, err
+	}
+	if summary == nil || summary.Children == nil {
+		return false, nil
+	}
+
+	children := summary.Children
+	return children.Queued > 0 || children.Pending+children.Running >= int64(max), nil
+}
+
+// releaseQueuedDispatches is a long lived function run by the leader that
+// watches the children summaries of parameterized jobs and releases dispatched
+// jobs queued by max_running as running children complete.
+func (s *Server) releaseQueuedDispatches(stopCh chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	logger := s.logger.Named("dispatch_queue")
+	var index uint64 = 1
+	for {
+		resp, newIndex, err := s.State().BlockingQuery(queuedDispatchParents, index, ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Error("failed to retrieve parameterized jobs with queued dispatches", "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(dispatchReleaseRetryInterval):
+			}
+			continue
+		}
+
+		released := true
+		for _, summary := range resp.([]*structs.JobSummary) {
+			if err := s.releaseQueuedDispatchesForJob(summary); err != nil {
+				logger.Error("failed to release queued dispatched jobs",
+					"job_id", summary.JobID, "namespace", summary.Namespace, "error", err)
+				released = false
+			}
+		}
+
+		if released {
+			index = newIndex
+			continue
+		}
+
+		// Run the next query without blocking so the release is retried even
+		// if no further changes happen
+		index = 0
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(dispatchReleaseRetryInterval):
+		}
+	}
+}
+
+// queuedDispatchParents is a blocking query function returning the summaries
+// of jobs that have queued dispatched children. It also watches the parent
+// jobs themselves, so that raising max_running releases queued children.
+func queuedDispatchParents(ws memdb.WatchSet, snap *state.StateStore) (interface{}, uint64, error) {
+	iter, err := snap.JobSummaries(ws)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var summaries []*structs.JobSummary
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		summary := raw.(*structs.JobSummary)
+		if summary.Children == nil || summary.Children.Queued <= 0 {
+			continue
+		}
+		if _, err := snap.JobByID(ws, summary.Namespace, summary.JobID); err != nil {
+			return nil, 0, err
+		}
+		summaries = append(summaries, summary)
+	}
+
+	index, err := snap.Index("job_summary")
+	if err != nil {
+		return nil, 0, err
+	}
+	jobsIndex, err := snap.Index("jobs")
+	if err != nil {
+		return nil, 0, err
+	}
+	if jobsIndex > index {
+		index = jobsIndex
+	}
+
+	return summaries, index, nil
+}
+
+// releaseQueuedDispatchesForJob releases as many of the oldest queued children
+// of the parameterized job as its max_running limit allows.
+func (s *Server) releaseQueuedDispatchesForJob(summary *structs.JobSummary) error {
+	unlock := s.dispatchLimiter.lockQueue(structs.NewNamespacedID(summary.JobID, summary.Namespace))
+	defer unlock()
+
+	snap, err := s.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	ws := memdb.NewWatchSet()
+	parent, err := snap.JobByID(ws, summary.Namespace, summary.JobID)
+	if err != nil {
+		return err
+	}
+
+	// Release every queued child if the parent was removed or its limit
+	// lifted, otherwise only as many as there is room for.
+	available := -1
+	if parent != nil && parent.IsParameterized() && parent.ParameterizedJob.MaxRunning > 0 {
+		current, err := snap.JobSummaryByID(ws, summary.Namespace, summary.JobID)
+		if err != nil {
+			return err
+		}
+		if current == nil || current.Children == nil {
+			return nil
+		}
+
+		running := current.Children.Pending + current.Children.Running
+		available = parent.ParameterizedJob.MaxRunning - int(running)
+		if available <= 0 {
+			return nil
+		}
+	}
+
+	iter, err := snap.JobsByIDPrefix(ws, summary.Namespace, summary.JobID)
+	if err != nil {
+		return err
+	}
+
+	var queued []*structs.Job
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		job := raw.(*structs.Job)
+		if job.ParentID != summary.JobID || !job.DispatchQueued || job.Stop {
+			continue
+		}
+		queued = append(queued, job)
+	}
+	if len(queued) == 0 {
+		return nil
+	}
+
+	// Release in dispatch order
+	sort.Slice(queued, func(i, j int) bool {
+		return queued[i].CreateIndex < queued[j].CreateIndex
+	})
+	if available >= 0 && len(queued) > available {
+		queued = queued[:available]
+	}
+
+	now := time.Now().UnixNano()
+	evals := make([]*structs.Evaluation, 0, len(queued))
+	for _, job := range queued {
+		evals = append(evals, &structs.Evaluation{
+			ID:             uuid.Generate(),
+			Namespace:      job.Namespace,
+			Priority:       job.Priority,
+			Type:           job.Type,
+			TriggeredBy:    structs.EvalTriggerJobRegister,
+			JobID:          job.ID,
+			JobModifyIndex: job.JobModifyIndex,
+			Status:         structs.EvalStatusPending,
+			CreateTime:     now,
+			ModifyTime:     now,
+		})
+	}
+
+	req := &structs.JobDispatchReleaseRequest{
+		Evals: evals,
+		WriteRequest: structs.WriteRequest{
+			Region: s.config.Region,
+		},
+	}
+	_, _, err = s.raftApply(structs.JobDispatchReleaseRequestType, req)
+	return err
+}
@@ -0,0 +1,64 @@
+package nomad
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatchLimiter_Allow_Cancel(t *testing.T) {
+	t.Parallel()
+
+	job := mock.BatchJob()
+	job.ParameterizedJob = &structs.ParameterizedJobConfig{
+		Rate: &structs.DispatchRateConfig{
+			Limit: 0.001,
+			Burst: 1,
+		},
+	}
+
+	d := newDispatchLimiter()
+	allowed, cancel := d.Allow(job)
+	require.True(t, allowed)
+
+	allowed, _ = d.Allow(job)
+	require.False(t, allowed)
+
+	// Cancelling a failed dispatch gives its token back
+	cancel()
+	allowed, _ = d.Allow(job)
+	require.True(t, allowed)
+}
+
+func TestDispatchLimiter_LockQueue(t *testing.T) {
+	t.Parallel()
+
+	d := newDispatchLimiter()
+	id := structs.NewNamespacedID("example", structs.DefaultNamespace)
+
+	// Concurrent holders of the queue lock are serialized
+	var wg sync.WaitGroup
+	var held int
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := d.lockQueue(id)
+			defer unlock()
+
+			held++
+			require.Equal(t, 1, held)
+			d.Remove(id)
+			held--
+		}()
+	}
+	wg.Wait()
+
+	// The lock is dropped once unused
+	d.l.Lock()
+	defer d.l.Unlock()
+	require.Empty(t, d.queueLocks)
+}
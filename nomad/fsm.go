@@ -54,6 +54,7 @@ const (
 	CSIVolumeSnapshot                    SnapshotType = 18
 	ScalingEventsSnapshot                SnapshotType = 19
 	EventSinkSnapshot                    SnapshotType = 20
+	RecommendationSnapshot               SnapshotType = 21
 	// Namespace appliers were moved from enterprise and therefore start at 64
 	NamespaceSnapshot SnapshotType = 64
 )
@@ -306,6 +307,14 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyOneTimeTokenDelete(msgType, buf[1:], log.Index)
 	case structs.OneTimeTokenExpireRequestType:
 		return n.applyOneTimeTokenExpire(msgType, buf[1:], log.Index)
+	case structs.JobDispatchReleaseRequestType:
+		return n.applyJobDispatchRelease(msgType, buf[1:], log.Index)
+	case structs.JobDependencyReleaseRequestType:
+		return n.applyJobDependencyRelease(msgType, buf[1:], log.Index)
+	case structs.RecommendationUpsertRequestType:
+		return n.applyRecommendationUpsert(buf[1:], log.Index)
+	case structs.RecommendationDeleteRequestType:
+		return n.applyRecommendationDelete(buf[1:], log.Index)
 	}
 
 	// Check enterprise only message types.
@@ -738,6 +747,44 @@ func (n *nomadFSM) applyUpdateEval(msgType structs.MessageType, buf []byte, inde
 	return n.upsertEvals(msgType, index, req.Evals)
 }
 
+// applyJobDispatchRelease is used to release queued dispatched jobs and create
+// their evaluations
+func (n *nomadFSM) applyJobDispatchRelease(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "job_dispatch_release"}, time.Now())
+	var req structs.JobDispatchReleaseRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	released, err := n.state.ReleaseDispatchedJobs(msgType, index, req.Evals)
+	if err != nil {
+		n.logger.Error("ReleaseDispatchedJobs failed", "error", err)
+		return err
+	}
+
+	n.handleUpsertedEvals(released)
+	return nil
+}
+
+// applyJobDependencyRelease is used to release jobs blocked on their
+// dependencies and create their evaluations
+func (n *nomadFSM) applyJobDependencyRelease(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "job_dependency_release"}, time.Now())
+	var req structs.JobDependencyReleaseRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	released, err := n.state.ReleaseBlockedJobs(msgType, index, req.Evals)
+	if err != nil {
+		n.logger.Error("ReleaseBlockedJobs failed", "error", err)
+		return err
+	}
+
+	n.handleUpsertedEvals(released)
+	return nil
+}
+
 func (n *nomadFSM) upsertEvals(msgType structs.MessageType, index uint64, evals []*structs.Evaluation) error {
 	if err := n.state.UpsertEvals(msgType, index, evals); err != nil {
 		n.logger.Error("UpsertEvals failed", "error", err)
@@ -1384,6 +1431,38 @@ func (n *nomadFSM) applyNamespaceDelete(buf []byte, index uint64) interface{} {
 	return nil
 }
 
+// applyRecommendationUpsert is used to upsert a set of recommendations
+func (n *nomadFSM) applyRecommendationUpsert(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_recommendation_upsert"}, time.Now())
+	var req structs.RecommendationUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertRecommendations(index, req.Recommendations); err != nil {
+		n.logger.Error("UpsertRecommendations failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyRecommendationDelete is used to delete a set of recommendations
+func (n *nomadFSM) applyRecommendationDelete(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_recommendation_delete"}, time.Now())
+	var req structs.RecommendationDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteRecommendations(index, req.Recommendations); err != nil {
+		n.logger.Error("DeleteRecommendations failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 func (n *nomadFSM) Snapshot() (raft.FSMSnapshot, error) {
 	// Create a new snapshot
 	snap, err := n.state.Snapshot()
@@ -1660,6 +1739,15 @@ func (n *nomadFSM) Restore(old io.ReadCloser) error {
 				return err
 			}
 
+		case RecommendationSnapshot:
+			rec := new(structs.Recommendation)
+			if err := dec.Decode(rec); err != nil {
+				return err
+			}
+			if err := restore.RecommendationRestore(rec); err != nil {
+				return err
+			}
+
 		// COMPAT(1.0): Allow 1.0-beta clusterers to gracefully handle
 		case EventSinkSnapshot:
 			return nil
@@ -1969,6 +2057,10 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistRecommendations(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	if err := s.persistEnterpriseTables(sink, encoder); err != nil {
 		sink.Cancel()
 		return err
@@ -2358,6 +2450,33 @@ func (s *nomadSnapshot) persistNamespaces(sink raft.SnapshotSink, encoder *codec
 	return nil
 }
 
+// persistRecommendations persists all the recommendations.
+func (s *nomadSnapshot) persistRecommendations(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	ws := memdb.NewWatchSet()
+	recs, err := s.snap.Recommendations(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := recs.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		rec := raw.(*structs.Recommendation)
+
+		// Write out a recommendation registration
+		sink.Write([]byte{byte(RecommendationSnapshot)})
+		if err := encoder.Encode(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *nomadSnapshot) persistSchedulerConfig(sink raft.SnapshotSink,
 	encoder *codec.Encoder) error {
 	// Get scheduler config
@@ -3259,6 +3259,65 @@ func TestFSM_SnapshotRestore_Namespaces(t *testing.T) {
 	}
 }
 
+func TestFSM_UpsertRecommendations(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	fsm := testFSM(t)
+
+	job := mock.Job()
+	require.NoError(fsm.State().UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	rec := mock.Recommendation(job)
+	req := structs.RecommendationUpsertRequest{
+		Recommendations: []*structs.Recommendation{rec},
+	}
+	buf, err := structs.Encode(structs.RecommendationUpsertRequestType, req)
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	out, err := fsm.State().RecommendationByID(nil, rec.ID)
+	require.NoError(err)
+	require.NotNil(out)
+	require.Equal(rec.Value, out.Value)
+
+	// Delete the recommendation
+	del := structs.RecommendationDeleteRequest{
+		Recommendations: []string{rec.ID},
+	}
+	buf, err = structs.Encode(structs.RecommendationDeleteRequestType, del)
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	out, err = fsm.State().RecommendationByID(nil, rec.ID)
+	require.NoError(err)
+	require.Nil(out)
+}
+
+func TestFSM_SnapshotRestore_Recommendations(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	job := mock.Job()
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+	rec1 := mock.Recommendation(job)
+	rec2 := mock.Recommendation(job)
+	rec2.Resource = structs.RecommendationResourceMemory
+	require.NoError(state.UpsertRecommendations(1001, []*structs.Recommendation{rec1, rec2}))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out1, err := state2.RecommendationByID(nil, rec1.ID)
+	require.NoError(err)
+	require.Equal(rec1, out1)
+	out2, err := state2.RecommendationByID(nil, rec2.ID)
+	require.NoError(err)
+	require.Equal(rec2, out2)
+}
+
 func TestFSM_ACLEvents(t *testing.T) {
 	t.Parallel()
 
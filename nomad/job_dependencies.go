@@ -0,0 +1,279 @@
+package nomad
+
+import (
+	"context"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// dependencyReleaseRetryInterval is how long the leader waits before
+	// retrying after failing to release jobs blocked on their dependencies.
+	dependencyReleaseRetryInterval = 5 * time.Second
+)
+
+// jobDependenciesSatisfied returns whether all the dependencies of the job
+// are satisfied.
+func jobDependenciesSatisfied(ws memdb.WatchSet, snap *state.StateStore, job *structs.Job) (bool, error) {
+	for _, dep := range job.DependsOn {
+		ok, err := jobDependencySatisfied(ws, snap, job.Namespace, dep)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// jobDependencySatisfied returns whether the upstream job of the dependency
+// has reached the dependency's condition. An upstream job that doesn't exist,
+// for example because it was garbage collected, never satisfies a dependency.
+func jobDependencySatisfied(ws memdb.WatchSet, snap *state.StateStore, namespace string, dep *structs.JobDependency) (bool, error) {
+	upstream, err := snap.JobByID(ws, namespace, dep.JobID)
+	if err != nil || upstream == nil {
+		return false, err
+	}
+
+	// Periodic and parameterized jobs never finish themselves, so they are
+	// judged by the children they have launched.
+	if upstream.IsPeriodic() || upstream.IsParameterized() {
+		return parentJobDependencySatisfied(ws, snap, upstream, dep.Condition)
+	}
+
+	return childJobDependencySatisfied(ws, snap, upstream, dep.Condition)
+}
+
+// parentJobDependencySatisfied returns whether a periodic or parameterized job
+// has launched children and all of them have reached the condition.
+func parentJobDependencySatisfied(ws memdb.WatchSet, snap *state.StateStore, parent *structs.Job, condition string) (bool, error) {
+	summary, err := snap.JobSummaryByID(ws, parent.Namespace, parent.ID)
+	if err != nil || summary == nil || summary.Children == nil {
+		return false, err
+	}
+
+	children := summary.Children
+	if children.Dead == 0 || children.Pending+children.Running+children.Queued > 0 {
+		return false, nil
+	}
+
+	if condition == structs.JobDependencyConditionComplete {
+		return true, nil
+	}
+
+	iter, err := snap.JobsByIDPrefix(ws, parent.Namespace, parent.ID)
+	if err != nil {
+		return false, err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		child := raw.(*structs.Job)
+		if child.ParentID != parent.ID {
+			continue
+		}
+
+		ok, err := childJobDependencySatisfied(ws, snap, child, condition)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// childJobDependencySatisfied returns whether a job that runs allocations has
+// reached the condition. A job completes once it is dead, and succeeds if it
+// wasn't stopped and the latest allocation of every slot completed.
+func childJobDependencySatisfied(ws memdb.WatchSet, snap *state.StateStore, job *structs.Job, condition string) (bool, error) {
+	if job.Status != structs.JobStatusDead {
+		return false, nil
+	}
+
+	if condition == structs.JobDependencyConditionComplete {
+		return true, nil
+	}
+
+	if job.Stop {
+		return false, nil
+	}
+
+	allocs, err := snap.AllocsByJob(ws, job.Namespace, job.ID, false)
+	if err != nil {
+		return false, err
+	}
+	for _, alloc := range allocs {
+		// Allocations that were replaced are superseded by their replacement
+		if alloc.NextAllocation != "" {
+			continue
+		}
+		if alloc.ClientStatus != structs.AllocClientStatusComplete {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// jobDependencyCycle returns the chain of job IDs leading from the job back to
+// itself through the dependencies of the registered upstream jobs, or nil if
+// registering the job doesn't create a cycle.
+func jobDependencyCycle(ws memdb.WatchSet, snap *state.StateStore, job *structs.Job) ([]string, error) {
+	visited := make(map[string]struct{})
+
+	var visit func(path []string, deps []*structs.JobDependency) ([]string, error)
+	visit = func(path []string, deps []*structs.JobDependency) ([]string, error) {
+		for _, dep := range deps {
+			if dep.JobID == job.ID {
+				return append(path, dep.JobID), nil
+			}
+			if _, ok := visited[dep.JobID]; ok {
+				continue
+			}
+			visited[dep.JobID] = struct{}{}
+
+			upstream, err := snap.JobByID(ws, job.Namespace, dep.JobID)
+			if err != nil {
+				return nil, err
+			}
+			if upstream == nil {
+				continue
+			}
+
+			cycle, err := visit(append(path, dep.JobID), upstream.DependsOn)
+			if err != nil || cycle != nil {
+				return cycle, err
+			}
+		}
+		return nil, nil
+	}
+
+	return visit([]string{job.ID}, job.DependsOn)
+}
+
+// releaseBlockedDependents is a long lived function run by the leader that
+// watches jobs blocked on their dependencies and creates evaluations for them
+// once the dependencies are satisfied.
+func (s *Server) releaseBlockedDependents(stopCh chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	logger := s.logger.Named("job_dependencies")
+	var index uint64 = 1
+	for {
+		resp, newIndex, err := s.State().BlockingQuery(satisfiedBlockedJobs, index, ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Error("failed to retrieve jobs blocked on dependencies", "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(dependencyReleaseRetryInterval):
+			}
+			continue
+		}
+
+		jobs := resp.([]*structs.Job)
+		if len(jobs) == 0 {
+			index = newIndex
+			continue
+		}
+
+		if err := s.releaseBlockedJobs(jobs); err != nil {
+			logger.Error("failed to release jobs blocked on dependencies", "error", err)
+
+			// Run the next query without blocking so the release is retried
+			// even if no further changes happen
+			index = 0
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(dependencyReleaseRetryInterval):
+			}
+			continue
+		}
+
+		index = newIndex
+	}
+}
+
+// satisfiedBlockedJobs is a blocking query function returning the jobs that
+// are blocked on dependencies which are now all satisfied. Only the blocked
+// jobs and the state of their upstream jobs are watched, so unrelated writes
+// don't rerun the query.
+func satisfiedBlockedJobs(ws memdb.WatchSet, snap *state.StateStore) (interface{}, uint64, error) {
+	iter, err := snap.JobsByDependenciesBlocked(ws, true)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var satisfied []*structs.Job
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		job := raw.(*structs.Job)
+		if job.Stop {
+			continue
+		}
+
+		ok, err := jobDependenciesSatisfied(ws, snap, job)
+		if err != nil {
+			return nil, 0, err
+		}
+		if ok {
+			satisfied = append(satisfied, job)
+		}
+	}
+
+	// Summaries change as the allocations of upstream jobs progress
+	index, err := snap.Index("job_summary")
+	if err != nil {
+		return nil, 0, err
+	}
+	jobsIndex, err := snap.Index("jobs")
+	if err != nil {
+		return nil, 0, err
+	}
+	if jobsIndex > index {
+		index = jobsIndex
+	}
+
+	return satisfied, index, nil
+}
+
+// releaseBlockedJobs creates evaluations for the jobs and clears their blocked
+// state.
+func (s *Server) releaseBlockedJobs(jobs []*structs.Job) error {
+	now := time.Now().UnixNano()
+	evals := make([]*structs.Evaluation, 0, len(jobs))
+	for _, job := range jobs {
+		evals = append(evals, &structs.Evaluation{
+			ID:             uuid.Generate(),
+			Namespace:      job.Namespace,
+			Priority:       job.Priority,
+			Type:           job.Type,
+			TriggeredBy:    structs.EvalTriggerJobDependency,
+			JobID:          job.ID,
+			JobModifyIndex: job.JobModifyIndex,
+			Status:         structs.EvalStatusPending,
+			CreateTime:     now,
+			ModifyTime:     now,
+		})
+	}
+
+	req := &structs.JobDependencyReleaseRequest{
+		Evals: evals,
+		WriteRequest: structs.WriteRequest{
+			Region: s.config.Region,
+		},
+	}
+	_, _, err := s.raftApply(structs.JobDependencyReleaseRequestType, req)
+	return err
+}
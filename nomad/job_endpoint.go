@@ -74,6 +74,7 @@ func NewJobEndpoints(s *Server) *Job {
 			jobExposeCheckHook{},
 			jobNamespaceConstraintCheckHook{srv: s},
 			jobValidate{},
+			jobCheckpointHook{},
 			&memoryOversubscriptionValidate{srv: s},
 		},
 	}
@@ -215,6 +216,18 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		return err
 	}
 
+	// Reject dependencies that would never be satisfied since the upstream
+	// jobs depend on this job
+	if len(args.Job.DependsOn) > 0 {
+		cycle, err := jobDependencyCycle(ws, &snap.StateStore, args.Job)
+		if err != nil {
+			return err
+		}
+		if cycle != nil {
+			return fmt.Errorf("job dependencies form a cycle: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
 	// Ensure that all scaling policies have an appropriate ID
 	if err := propagateScalingPolicyIDs(existingJob, args.Job); err != nil {
 		return err
@@ -364,8 +377,24 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 	// Set the submit time
 	args.Job.SubmitTime = now
 
-	// If the job is periodic or parameterized, we don't create an eval.
-	if !(args.Job.IsPeriodic() || args.Job.IsParameterized()) {
+	// Hold back the evaluation of a job whose dependencies are not satisfied
+	// yet. The leader creates it once they are. Only new jobs and jobs that
+	// were never released are held back: once released, the dependencies no
+	// longer apply to updates of the job, even if the upstream jobs are later
+	// garbage collected or run again.
+	args.Job.DependenciesBlocked = false
+	gated := existingJob == nil || existingJob.DependenciesBlocked
+	if gated && len(args.Job.DependsOn) > 0 && !args.Job.Stop {
+		satisfied, err := jobDependenciesSatisfied(ws, &snap.StateStore, args.Job)
+		if err != nil {
+			return err
+		}
+		args.Job.DependenciesBlocked = !satisfied
+	}
+
+	// If the job is periodic or parameterized, or is blocked on its
+	// dependencies, we don't create an eval.
+	if !(args.Job.IsPeriodic() || args.Job.IsParameterized() || args.Job.DependenciesBlocked) {
 
 		// Initially set the eval priority to that of the job priority. If the
 		// user supplied an eval priority override, we subsequently use this.
@@ -751,6 +780,8 @@ func (j *Job) Evaluate(args *structs.JobEvaluateRequest, reply *structs.JobRegis
 		return fmt.Errorf("can't evaluate periodic job")
 	} else if job.IsParameterized() {
 		return fmt.Errorf("can't evaluate parameterized job")
+	} else if job.DependenciesBlocked {
+		return fmt.Errorf("can't evaluate job blocked on its dependencies")
 	}
 
 	forceRescheduleAllocs := make(map[string]*structs.DesiredTransition)
@@ -889,6 +920,9 @@ func (j *Job) Deregister(args *structs.JobDeregisterRequest, reply *structs.JobD
 		return err
 	}
 
+	// Drop the dispatch limits of the job
+	j.srv.dispatchLimiter.Remove(structs.NewNamespacedID(args.JobID, args.RequestNamespace()))
+
 	// Populate the reply with job information
 	reply.JobModifyIndex = index
 	reply.EvalCreateIndex = index
@@ -1004,6 +1038,11 @@ func (j *Job) BatchDeregister(args *structs.JobBatchDeregisterRequest, reply *st
 		return err
 	}
 
+	// Drop the dispatch limits of the jobs
+	for jobNS := range args.Jobs {
+		j.srv.dispatchLimiter.Remove(jobNS)
+	}
+
 	reply.Index = index
 	return nil
 }
@@ -1137,8 +1176,9 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 		}
 		reply.JobModifyIndex = jobModifyIndex
 
-		// Create an eval for non-dispatch jobs
-		if !(job.IsPeriodic() || job.IsParameterized()) {
+		// Create an eval for non-dispatch jobs, unless the job is blocked on
+		// its dependencies and will be evaluated once they are satisfied
+		if !(job.IsPeriodic() || job.IsParameterized() || job.DependenciesBlocked) {
 			eval := &structs.Evaluation{
 				ID:             uuid.Generate(),
 				Namespace:      namespace,
@@ -1934,6 +1974,12 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 		}
 	}
 
+	// Enforce the dispatch rate of the parameterized job
+	allowed, cancelRate := j.srv.dispatchLimiter.Allow(parameterizedJob)
+	if !allowed {
+		return structs.NewErrRPCCodedf(429, "dispatch rate limit exceeded for job %q", args.JobID)
+	}
+
 	// Derive the child job and commit it via Raft - with initial status
 	dispatchJob := parameterizedJob.Copy()
 	dispatchJob.ID = structs.DispatchedID(parameterizedJob.ID, time.Now())
@@ -1956,6 +2002,26 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 	// Compress the payload
 	dispatchJob.Payload = snappy.Encode(nil, args.Payload)
 
+	// Hold the queue lock of the parameterized job until the dispatched job
+	// is committed so that the max_running limit can't be exceeded by
+	// concurrent dispatches
+	unlockQueue := func() {}
+	if !dispatchJob.IsPeriodic() && parameterizedJob.ParameterizedJob.MaxRunning > 0 {
+		unlockQueue = j.srv.dispatchLimiter.lockQueue(parameterizedJob.NamespacedID())
+
+		// Queue the job instead of creating an evaluation if the parameterized
+		// job is at its max_running limit. The summary is read from the latest
+		// state rather than the snapshot, since it is protected by the queue
+		// lock.
+		queued, err := j.srv.dispatchLimiter.shouldQueue(ws, j.srv.fsm.State(), parameterizedJob)
+		if err != nil {
+			unlockQueue()
+			cancelRate()
+			return err
+		}
+		dispatchJob.DispatchQueued = queued
+	}
+
 	regReq := &structs.JobRegisterRequest{
 		Job:          dispatchJob,
 		WriteRequest: args.WriteRequest,
@@ -1963,12 +2029,15 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 
 	// Commit this update via Raft
 	fsmErr, jobCreateIndex, err := j.srv.raftApply(structs.JobRegisterRequestType, regReq)
+	unlockQueue()
 	if err, ok := fsmErr.(error); ok && err != nil {
 		j.logger.Error("dispatched job register failed", "error", err, "fsm", true)
+		cancelRate()
 		return err
 	}
 	if err != nil {
 		j.logger.Error("dispatched job register failed", "error", err, "raft", true)
+		cancelRate()
 		return err
 	}
 
@@ -1976,6 +2045,12 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 	reply.DispatchedJobID = dispatchJob.ID
 	reply.Index = jobCreateIndex
 
+	// If the job is queued, the eval is created once it is released
+	if dispatchJob.DispatchQueued {
+		reply.Queued = true
+		return nil
+	}
+
 	// If the job is periodic, we don't create an eval.
 	if !dispatchJob.IsPeriodic() {
 		// Create a new evaluation
@@ -0,0 +1,41 @@
+package nomad
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+type jobCheckpointHook struct{}
+
+func (jobCheckpointHook) Name() string {
+	return "checkpoint"
+}
+
+// Validate ensures that exec tasks with checkpoint enabled are in groups whose
+// ephemeral disk is migrated. Checkpoints are moved between clients with the
+// ephemeral disk, and restoring them needs the files the task had open, so
+// without migrate the task could never be restored on another client.
+func (jobCheckpointHook) Validate(job *structs.Job) (warnings []error, err error) {
+	for _, tg := range job.TaskGroups {
+		if tg.EphemeralDisk != nil && tg.EphemeralDisk.Migrate {
+			continue
+		}
+		for _, task := range tg.Tasks {
+			if taskUsesCheckpoint(task) {
+				return nil, fmt.Errorf("Task %q in group %q enables checkpoint, which requires the group's ephemeral_disk to have migrate enabled", task.Name, tg.Name)
+			}
+		}
+	}
+	return nil, nil
+}
+
+// taskUsesCheckpoint returns whether the task enables the checkpoint option
+// of the exec driver.
+func taskUsesCheckpoint(task *structs.Task) bool {
+	if task.Driver != "exec" {
+		return false
+	}
+	checkpoint, ok := task.Config["checkpoint"].(bool)
+	return ok && checkpoint
+}
@@ -0,0 +1,34 @@
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobCheckpointHook_Validate(t *testing.T) {
+	t.Parallel()
+
+	hook := jobCheckpointHook{}
+
+	// Tasks without checkpoint are valid
+	job := mock.Job()
+	_, err := hook.Validate(job)
+	require.NoError(t, err)
+
+	// Checkpoint requires migrate
+	job.TaskGroups[0].Tasks[0].Config["checkpoint"] = true
+	_, err = hook.Validate(job)
+	require.EqualError(t, err, `Task "web" in group "web" enables checkpoint, which requires the group's ephemeral_disk to have migrate enabled`)
+
+	job.TaskGroups[0].EphemeralDisk.Migrate = true
+	_, err = hook.Validate(job)
+	require.NoError(t, err)
+
+	// Other drivers' options are not interpreted
+	job.TaskGroups[0].EphemeralDisk.Migrate = false
+	job.TaskGroups[0].Tasks[0].Driver = "docker"
+	_, err = hook.Validate(job)
+	require.NoError(t, err)
+}
@@ -233,6 +233,12 @@ func (v *memoryOversubscriptionValidate) Validate(job *structs.Job) (warnings []
 	}
 
 	for _, tg := range job.TaskGroups {
+		// The memory_max of tasks is bounded by the group memory, which is
+		// what the scheduler accounts
+		if tg.Resources != nil {
+			continue
+		}
+
 		for _, t := range tg.Tasks {
 			if t.Resources != nil && t.Resources.MemoryMaxMB != 0 {
 				warnings = append(warnings, fmt.Errorf("Memory oversubscription is not enabled; Task \"%v.%v\" memory_max value will be ignored. Update the Scheduler Configuration to allow oversubscription.", tg.Name, t.Name))
@@ -2091,6 +2091,157 @@ func TestJobEndpoint_Register_EvalCreation_Legacy(t *testing.T) {
 	})
 }
 
+func TestJobEndpoint_Register_DependsOn(t *testing.T) {
+	t.Parallel()
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+
+	state := s1.fsm.State()
+
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	register := func(job *structs.Job) *structs.JobRegisterResponse {
+		req := &structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+		return &resp
+	}
+
+	upstream := mock.BatchJob()
+	upstreamResp := register(upstream)
+	require.NotEmpty(t, upstreamResp.EvalID)
+
+	// The downstream job is blocked without an eval
+	downstream := mock.BatchJob()
+	downstream.DependsOn = []*structs.JobDependency{
+		{JobID: upstream.ID, Condition: structs.JobDependencyConditionComplete},
+	}
+	downstreamResp := register(downstream)
+	require.Empty(t, downstreamResp.EvalID)
+
+	out, err := state.JobByID(nil, downstream.Namespace, downstream.ID)
+	require.NoError(t, err)
+	require.True(t, out.DependenciesBlocked)
+
+	// The blocked job can't be evaluated
+	evalReq := &structs.JobEvaluateRequest{
+		JobID: downstream.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: downstream.Namespace,
+		},
+	}
+	var evalResp structs.JobRegisterResponse
+	err = msgpackrpc.CallWithCodec(codec, "Job.Evaluate", evalReq, &evalResp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "blocked on its dependencies")
+
+	// Scaling the blocked job doesn't create an eval either
+	scaleReq := &structs.JobScaleRequest{
+		JobID: downstream.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: downstream.TaskGroups[0].Name,
+		},
+		Count: helper.Int64ToPtr(2),
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: downstream.Namespace,
+		},
+	}
+	var scaleResp structs.JobRegisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Scale", scaleReq, &scaleResp))
+	require.Empty(t, scaleResp.EvalID)
+
+	out, err = state.JobByID(nil, downstream.Namespace, downstream.ID)
+	require.NoError(t, err)
+	require.True(t, out.DependenciesBlocked)
+	require.Equal(t, 2, out.TaskGroups[0].Count)
+
+	evals, err := state.EvalsByJob(nil, downstream.Namespace, downstream.ID)
+	require.NoError(t, err)
+	require.Empty(t, evals)
+
+	// Complete the upstream job's eval, which finishes the job and releases
+	// the downstream job
+	eval, err := state.EvalByID(nil, upstreamResp.EvalID)
+	require.NoError(t, err)
+	eval = eval.Copy()
+	eval.Status = structs.EvalStatusComplete
+	_, _, err = s1.raftApply(structs.EvalUpdateRequestType, &structs.EvalUpdateRequest{
+		Evals: []*structs.Evaluation{eval},
+	})
+	require.NoError(t, err)
+
+	testutil.WaitForResult(func() (bool, error) {
+		evals, err := state.EvalsByJob(nil, downstream.Namespace, downstream.ID)
+		if err != nil {
+			return false, err
+		}
+		if len(evals) != 1 {
+			return false, fmt.Errorf("expected 1 eval, got %d", len(evals))
+		}
+		if evals[0].TriggeredBy != structs.EvalTriggerJobDependency {
+			return false, fmt.Errorf("unexpected eval trigger %q", evals[0].TriggeredBy)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+
+	out, err = state.JobByID(nil, downstream.Namespace, downstream.ID)
+	require.NoError(t, err)
+	require.False(t, out.DependenciesBlocked)
+
+	// Making the upstream job depend on the downstream job is rejected
+	cyclic := upstream.Copy()
+	cyclic.DependsOn = []*structs.JobDependency{
+		{JobID: downstream.ID, Condition: structs.JobDependencyConditionComplete},
+	}
+	cyclicReq := &structs.JobRegisterRequest{
+		Job: cyclic,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: cyclic.Namespace,
+		},
+	}
+	var cyclicResp structs.JobRegisterResponse
+	err = msgpackrpc.CallWithCodec(codec, "Job.Register", cyclicReq, &cyclicResp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "job dependencies form a cycle")
+
+	// Once released, updates of the downstream job are evaluated even if the
+	// upstream job is purged
+	deregReq := &structs.JobDeregisterRequest{
+		JobID: upstream.ID,
+		Purge: true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: upstream.Namespace,
+		},
+	}
+	var deregResp structs.JobDeregisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Deregister", deregReq, &deregResp))
+
+	updated := downstream.Copy()
+	updated.Priority = 60
+	updatedResp := register(updated)
+	require.NotEmpty(t, updatedResp.EvalID)
+
+	out, err = state.JobByID(nil, downstream.Namespace, downstream.ID)
+	require.NoError(t, err)
+	require.False(t, out.DependenciesBlocked)
+}
+
 func TestJobEndpoint_Register_ValidateMemoryMax(t *testing.T) {
 	t.Parallel()
 
@@ -7009,6 +7160,177 @@ func TestJobEndpoint_Dispatch_JobChildrenSummary(t *testing.T) {
 	require.Equal(t, structs.JobStatusDead, dispatchedStatus())
 }
 
+func TestJobEndpoint_Dispatch_MaxRunning(t *testing.T) {
+	t.Parallel()
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+
+	state := s1.fsm.State()
+
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	parameterizedJob := mock.BatchJob()
+	parameterizedJob.ParameterizedJob = &structs.ParameterizedJobConfig{
+		MaxRunning: 1,
+	}
+
+	// Create the register request
+	regReq := &structs.JobRegisterRequest{
+		Job: parameterizedJob,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: parameterizedJob.Namespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+
+	jobChildren := func() *structs.JobChildrenSummary {
+		summary, err := state.JobSummaryByID(nil, parameterizedJob.Namespace, parameterizedJob.ID)
+		require.NoError(t, err)
+
+		return summary.Children
+	}
+
+	dispatch := func() *structs.JobDispatchResponse {
+		dispatchReq := &structs.JobDispatchRequest{
+			JobID: parameterizedJob.ID,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: parameterizedJob.Namespace,
+			},
+		}
+		var dispatchResp structs.JobDispatchResponse
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Dispatch", dispatchReq, &dispatchResp))
+		return &dispatchResp
+	}
+
+	// The first dispatch is below the limit and gets an eval
+	first := dispatch()
+	require.False(t, first.Queued)
+	require.NotEmpty(t, first.EvalID)
+	require.Equal(t, &structs.JobChildrenSummary{Pending: 1}, jobChildren())
+
+	// The second dispatch is queued without an eval
+	second := dispatch()
+	require.True(t, second.Queued)
+	require.Empty(t, second.EvalID)
+	require.Equal(t, &structs.JobChildrenSummary{Pending: 1, Queued: 1}, jobChildren())
+
+	queuedJob, err := state.JobByID(nil, parameterizedJob.Namespace, second.DispatchedJobID)
+	require.NoError(t, err)
+	require.True(t, queuedJob.DispatchQueued)
+
+	evals, err := state.EvalsByJob(nil, parameterizedJob.Namespace, second.DispatchedJobID)
+	require.NoError(t, err)
+	require.Empty(t, evals)
+
+	// Complete the first child, which releases the queued one
+	eval, err := state.EvalByID(nil, first.EvalID)
+	require.NoError(t, err)
+	eval = eval.Copy()
+	eval.Status = structs.EvalStatusComplete
+	_, _, err = s1.raftApply(structs.EvalUpdateRequestType, &structs.EvalUpdateRequest{
+		Evals: []*structs.Evaluation{eval},
+	})
+	require.NoError(t, err)
+
+	testutil.WaitForResult(func() (bool, error) {
+		children := jobChildren()
+		expected := &structs.JobChildrenSummary{Pending: 1, Dead: 1}
+		if !reflect.DeepEqual(expected, children) {
+			return false, fmt.Errorf("expected children summary %#v, got %#v", expected, children)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+
+	releasedJob, err := state.JobByID(nil, parameterizedJob.Namespace, second.DispatchedJobID)
+	require.NoError(t, err)
+	require.False(t, releasedJob.DispatchQueued)
+
+	evals, err = state.EvalsByJob(nil, parameterizedJob.Namespace, second.DispatchedJobID)
+	require.NoError(t, err)
+	require.Len(t, evals, 1)
+	require.Equal(t, structs.EvalStatusPending, evals[0].Status)
+}
+
+func TestJobEndpoint_Dispatch_RateLimited(t *testing.T) {
+	t.Parallel()
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	parameterizedJob := mock.BatchJob()
+	parameterizedJob.ParameterizedJob = &structs.ParameterizedJobConfig{
+		Rate: &structs.DispatchRateConfig{
+			Limit: 0.001,
+			Burst: 2,
+		},
+	}
+
+	// Create the register request
+	regReq := &structs.JobRegisterRequest{
+		Job: parameterizedJob,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: parameterizedJob.Namespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+
+	dispatchReq := &structs.JobDispatchRequest{
+		JobID: parameterizedJob.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: parameterizedJob.Namespace,
+		},
+	}
+
+	// The burst allows the first two dispatches
+	for i := 0; i < 2; i++ {
+		var dispatchResp structs.JobDispatchResponse
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Dispatch", dispatchReq, &dispatchResp))
+		require.NotEmpty(t, dispatchResp.DispatchedJobID)
+	}
+
+	// The bucket is now empty
+	var dispatchResp structs.JobDispatchResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Dispatch", dispatchReq, &dispatchResp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "dispatch rate limit exceeded")
+
+	code, _, ok := structs.CodeFromRPCCodedErr(err)
+	require.True(t, ok)
+	require.Equal(t, 429, code)
+
+	// Deregistering the job drops its bucket
+	deregReq := &structs.JobDeregisterRequest{
+		JobID: parameterizedJob.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: parameterizedJob.Namespace,
+		},
+	}
+	var deregResp structs.JobDeregisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Deregister", deregReq, &deregResp))
+
+	s1.dispatchLimiter.l.Lock()
+	require.Empty(t, s1.dispatchLimiter.limiters)
+	s1.dispatchLimiter.l.Unlock()
+}
+
 func TestJobEndpoint_Dispatch_ACL_RejectedBySchedulerConfig(t *testing.T) {
 	t.Parallel()
 	s1, root, cleanupS1 := TestACLServer(t, nil)
@@ -291,6 +291,17 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Scheduler periodic jobs
 	go s.schedulePeriodic(stopCh)
 
+	// Release dispatched jobs queued by their parent's max_running limit
+	go s.releaseQueuedDispatches(stopCh)
+
+	// Sample the resource usage of tasks to recommend their resources
+	if s.config.RecommenderEnabled {
+		go s.runRecommender(stopCh)
+	}
+
+	// Release jobs blocked on their dependencies
+	go s.releaseBlockedDependents(stopCh)
+
 	// Reap any failed evaluations
 	go s.reapFailedEvaluations(stopCh)
 
@@ -1064,6 +1075,9 @@ func (s *Server) revokeLeadership() error {
 	// Disable the periodic dispatcher, since it is only useful as a leader
 	s.periodicDispatcher.SetEnabled(false)
 
+	// Clear the dispatch rate limits, since they are only enforced by the leader
+	s.dispatchLimiter.Reset()
+
 	// Disable the Vault client as it is only useful as a leader.
 	s.vault.SetActive(false)
 
@@ -2107,6 +2107,28 @@ func JobWithScalingPolicy() (*structs.Job, *structs.ScalingPolicy) {
 	return job, policy
 }
 
+// Recommendation returns a recommendation for the CPU of the first task of
+// the job.
+func Recommendation(job *structs.Job) *structs.Recommendation {
+	tg := job.TaskGroups[0]
+	task := tg.Tasks[0]
+	return &structs.Recommendation{
+		ID:         uuid.Generate(),
+		Region:     job.Region,
+		Namespace:  job.Namespace,
+		JobID:      job.ID,
+		JobVersion: job.Version,
+		Group:      tg.Name,
+		Task:       task.Name,
+		Resource:   structs.RecommendationResourceCPU,
+		Value:      task.Resources.CPU * 2,
+		Current:    task.Resources.CPU,
+		Meta:       map[string]interface{}{"source": "test"},
+		Stats:      map[string]float64{"max": float64(task.Resources.CPU) * 1.8},
+		SubmitTime: time.Now().UnixNano(),
+	}
+}
+
 func MultiregionJob() *structs.Job {
 	job := Job()
 	update := *structs.DefaultUpdateStrategy
@@ -0,0 +1,449 @@
+package nomad
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	multierror "github.com/hashicorp/go-multierror"
+
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Recommendation endpoint is used for manipulating the resource
+// recommendations of tasks
+type Recommendation struct {
+	srv    *Server
+	logger log.Logger
+}
+
+// ListRecommendations is used to list the recommendations
+func (r *Recommendation) ListRecommendations(args *structs.RecommendationListRequest,
+	reply *structs.RecommendationListResponse) error {
+	if done, err := r.srv.forward("Recommendation.ListRecommendations", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "recommendation", "list_recommendations"}, time.Now())
+
+	if args.Group != "" && args.JobID == "" {
+		return fmt.Errorf("job must be specified to filter by group")
+	}
+	if args.Task != "" && args.Group == "" {
+		return fmt.Errorf("group must be specified to filter by task")
+	}
+
+	aclObj, err := r.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	}
+	allow := func(ns string) bool {
+		return aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJob) ||
+			aclObj.AllowNsOp(ns, acl.NamespaceCapabilitySubmitRecommendation) ||
+			aclObj.AllowNsOp(ns, acl.NamespaceCapabilitySubmitJob)
+	}
+	allNamespaces := args.RequestNamespace() == structs.AllNamespacesSentinel
+	if aclObj != nil && !allNamespaces && !allow(args.RequestNamespace()) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			var allowed map[string]bool
+			if allNamespaces {
+				var err error
+				allowed, err = allowedNSes(aclObj, s, allow)
+				if err == structs.ErrPermissionDenied {
+					// return empty if token isn't authorized for any namespace
+					reply.Recommendations = []*structs.Recommendation{}
+					return nil
+				} else if err != nil {
+					return err
+				}
+			}
+
+			var iter memdb.ResultIterator
+			var err error
+			switch {
+			case allNamespaces:
+				iter, err = s.Recommendations(ws)
+			case args.JobID != "":
+				iter, err = s.RecommendationsByJob(ws, args.RequestNamespace(), args.JobID)
+			default:
+				iter, err = s.RecommendationsByNamespace(ws, args.RequestNamespace())
+			}
+			if err != nil {
+				return err
+			}
+
+			recs := []*structs.Recommendation{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				rec := raw.(*structs.Recommendation)
+				if allowed != nil && !allowed[rec.Namespace] {
+					continue
+				}
+				if (args.JobID != "" && rec.JobID != args.JobID) ||
+					(args.Group != "" && rec.Group != args.Group) ||
+					(args.Task != "" && rec.Task != args.Task) {
+					continue
+				}
+				recs = append(recs, rec)
+			}
+			reply.Recommendations = recs
+
+			// Use the last index that affected the recommendations table
+			index, err := s.Index(state.TableRecommendations)
+			if err != nil {
+				return err
+			}
+			reply.Index = helper.Uint64Max(1, index)
+
+			// Set the query response
+			r.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return r.srv.blockingRPC(&opts)
+}
+
+// GetRecommendation is used to get a specific recommendation
+func (r *Recommendation) GetRecommendation(args *structs.RecommendationSpecificRequest,
+	reply *structs.SingleRecommendationResponse) error {
+	if done, err := r.srv.forward("Recommendation.GetRecommendation", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "recommendation", "get_recommendation"}, time.Now())
+
+	aclObj, err := r.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			rec, err := s.RecommendationByID(ws, args.RecommendationID)
+			if err != nil {
+				return err
+			}
+
+			// Check for read-job permissions in the namespace of the
+			// recommendation
+			if rec != nil && aclObj != nil && !aclObj.AllowNsOp(rec.Namespace, acl.NamespaceCapabilityReadJob) {
+				return structs.ErrPermissionDenied
+			}
+			reply.Recommendation = rec
+
+			// If the state lookup returned a recommendation, use its modify
+			// index for the response. Otherwise, use the index table to
+			// supply this, ensuring a non-zero value.
+			if rec != nil {
+				reply.Index = rec.ModifyIndex
+			} else {
+				index, err := s.Index(state.TableRecommendations)
+				if err != nil {
+					return err
+				}
+				reply.Index = helper.Uint64Max(1, index)
+			}
+			return nil
+		}}
+	return r.srv.blockingRPC(&opts)
+}
+
+// UpsertRecommendations is used to create or update a set of recommendations
+func (r *Recommendation) UpsertRecommendations(args *structs.RecommendationUpsertRequest,
+	reply *structs.RecommendationUpsertResponse) error {
+	if done, err := r.srv.forward("Recommendation.UpsertRecommendations", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "recommendation", "upsert_recommendations"}, time.Now())
+
+	if len(args.Recommendations) == 0 {
+		return fmt.Errorf("must specify at least one recommendation")
+	}
+
+	aclObj, err := r.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	}
+
+	snap, err := r.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UnixNano()
+	for _, rec := range args.Recommendations {
+		if rec.Namespace == "" {
+			rec.Namespace = args.RequestNamespace()
+		}
+
+		// Check for submit-recommendation or submit-job permissions
+		if aclObj != nil &&
+			!aclObj.AllowNsOp(rec.Namespace, acl.NamespaceCapabilitySubmitRecommendation) &&
+			!aclObj.AllowNsOp(rec.Namespace, acl.NamespaceCapabilitySubmitJob) {
+			return structs.ErrPermissionDenied
+		}
+
+		if err := rec.Validate(); err != nil {
+			return err
+		}
+
+		// Updating a recommendation cannot change its target
+		if rec.ID != "" {
+			existing, err := snap.RecommendationByID(nil, rec.ID)
+			if err != nil {
+				return err
+			}
+			if existing == nil {
+				return fmt.Errorf("recommendation %q not found", rec.ID)
+			}
+			if !existing.SameTarget(rec) {
+				return fmt.Errorf("recommendation %q cannot change its target", rec.ID)
+			}
+		}
+
+		if err := setRecommendationTarget(snap, rec); err != nil {
+			return err
+		}
+		rec.Region = r.srv.Region()
+		rec.SubmitTime = now
+	}
+
+	// Update via Raft
+	out, index, err := r.srv.raftApply(structs.RecommendationUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Check if there was an error when applying.
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	// Update the index
+	reply.Recommendations = args.Recommendations
+	reply.Index = index
+	return nil
+}
+
+// ApplyRecommendations is used to apply recommendations to their jobs and to
+// dismiss recommendations
+func (r *Recommendation) ApplyRecommendations(args *structs.RecommendationApplyRequest,
+	reply *structs.RecommendationApplyResponse) error {
+	if done, err := r.srv.forward("Recommendation.ApplyRecommendations", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "recommendation", "apply_recommendations"}, time.Now())
+
+	if len(args.Apply) == 0 && len(args.Dismiss) == 0 {
+		return fmt.Errorf("must specify at least one recommendation to apply or dismiss")
+	}
+
+	aclObj, err := r.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	}
+
+	snap, err := r.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	lookup := func(ids []string) ([]*structs.Recommendation, error) {
+		var mErr multierror.Error
+		recs := make([]*structs.Recommendation, 0, len(ids))
+		for _, id := range ids {
+			rec, err := snap.RecommendationByID(nil, id)
+			if err != nil {
+				return nil, err
+			}
+			if rec == nil {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("recommendation %q not found", id))
+				continue
+			}
+			recs = append(recs, rec)
+		}
+		return recs, mErr.ErrorOrNil()
+	}
+
+	dismiss, err := lookup(args.Dismiss)
+	if err != nil {
+		return err
+	}
+	apply, err := lookup(args.Apply)
+	if err != nil {
+		return err
+	}
+
+	// Dismissing requires the same permissions as submitting
+	for _, rec := range dismiss {
+		if aclObj != nil &&
+			!aclObj.AllowNsOp(rec.Namespace, acl.NamespaceCapabilitySubmitRecommendation) &&
+			!aclObj.AllowNsOp(rec.Namespace, acl.NamespaceCapabilitySubmitJob) {
+			return structs.ErrPermissionDenied
+		}
+	}
+
+	if len(dismiss) > 0 {
+		req := &structs.RecommendationDeleteRequest{
+			Recommendations: args.Dismiss,
+			WriteRequest:    args.WriteRequest,
+		}
+		out, index, err := r.srv.raftApply(structs.RecommendationDeleteRequestType, req)
+		if err != nil {
+			return err
+		}
+		if err, ok := out.(error); ok && err != nil {
+			return err
+		}
+		reply.Index = index
+	}
+
+	// Group the applied recommendations by job, so that each job is only
+	// registered once
+	byJob := make(map[structs.NamespacedID][]*structs.Recommendation)
+	for _, rec := range apply {
+		id := structs.NewNamespacedID(rec.JobID, rec.Namespace)
+		byJob[id] = append(byJob[id], rec)
+	}
+	jobIDs := make([]structs.NamespacedID, 0, len(byJob))
+	for id := range byJob {
+		jobIDs = append(jobIDs, id)
+	}
+	sort.Slice(jobIDs, func(i, j int) bool {
+		if jobIDs[i].Namespace != jobIDs[j].Namespace {
+			return jobIDs[i].Namespace < jobIDs[j].Namespace
+		}
+		return jobIDs[i].ID < jobIDs[j].ID
+	})
+
+	for _, id := range jobIDs {
+		recs := byJob[id]
+		ids := make([]string, 0, len(recs))
+		for _, rec := range recs {
+			ids = append(ids, rec.ID)
+		}
+
+		result, err := r.applyToJob(snap, args, id, recs)
+		if err != nil {
+			reply.Errors = append(reply.Errors, &structs.SingleRecommendationApplyError{
+				Namespace:       id.Namespace,
+				JobID:           id.ID,
+				Recommendations: ids,
+				Error:           err.Error(),
+			})
+			continue
+		}
+
+		result.Recommendations = ids
+		reply.UpdatedJobs = append(reply.UpdatedJobs, result)
+		if result.JobModifyIndex > reply.Index {
+			reply.Index = result.JobModifyIndex
+		}
+	}
+
+	return nil
+}
+
+// applyToJob registers a new version of the job with the values of the
+// recommendations. The recommendations are deleted by the state store once
+// the job is updated.
+func (r *Recommendation) applyToJob(snap *state.StateSnapshot, args *structs.RecommendationApplyRequest,
+	id structs.NamespacedID, recs []*structs.Recommendation) (*structs.SingleRecommendationApplyResult, error) {
+
+	job, err := snap.JobByID(nil, id.Namespace, id.ID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, fmt.Errorf("job not found")
+	}
+
+	newJob := job.Copy()
+	for _, rec := range recs {
+		if rec.EnforceVersion && rec.JobVersion != job.Version {
+			return nil, fmt.Errorf("recommendation %q is for job version %d; current version is %d",
+				rec.ID, rec.JobVersion, job.Version)
+		}
+		if err := rec.ApplyTo(newJob); err != nil {
+			return nil, err
+		}
+	}
+
+	// Register the job with the token of the request, so that it is subject
+	// to the same checks as any other registration
+	reg := &structs.JobRegisterRequest{
+		Job:            newJob,
+		EnforceIndex:   true,
+		JobModifyIndex: job.JobModifyIndex,
+		PolicyOverride: args.PolicyOverride,
+		WriteRequest: structs.WriteRequest{
+			Region:    args.Region,
+			Namespace: job.Namespace,
+			AuthToken: args.AuthToken,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	if err := r.srv.RPC("Job.Register", reg, &resp); err != nil {
+		return nil, err
+	}
+
+	return &structs.SingleRecommendationApplyResult{
+		Namespace:       job.Namespace,
+		JobID:           job.ID,
+		JobModifyIndex:  resp.JobModifyIndex,
+		EvalID:          resp.EvalID,
+		EvalCreateIndex: resp.EvalCreateIndex,
+		Warnings:        resp.Warnings,
+	}, nil
+}
+
+// setRecommendationTarget validates that the task targeted by the
+// recommendation exists and records the job version and the current value of
+// the resource. A recommendation without an ID takes the ID of the existing
+// recommendation for the same target, if any.
+func setRecommendationTarget(snap *state.StateSnapshot, rec *structs.Recommendation) error {
+	job, err := snap.JobByID(nil, rec.Namespace, rec.JobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return fmt.Errorf("job %q not found in namespace %q", rec.JobID, rec.Namespace)
+	}
+
+	current, err := rec.CurrentValue(job)
+	if err != nil {
+		return err
+	}
+	rec.JobVersion = job.Version
+	rec.Current = current
+
+	if rec.ID != "" {
+		return nil
+	}
+
+	iter, err := snap.RecommendationsByJob(nil, rec.Namespace, rec.JobID)
+	if err != nil {
+		return err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		if existing := raw.(*structs.Recommendation); existing.SameTarget(rec) {
+			rec.ID = existing.ID
+			return nil
+		}
+	}
+	rec.ID = uuid.Generate()
+	return nil
+}
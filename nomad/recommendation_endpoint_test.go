@@ -0,0 +1,258 @@
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecommendationEndpoint_Upsert_List_Get(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	require.NoError(s1.fsm.State().UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	// Upsert a recommendation without an ID
+	rec := &structs.Recommendation{
+		JobID:    job.ID,
+		Group:    job.TaskGroups[0].Name,
+		Task:     job.TaskGroups[0].Tasks[0].Name,
+		Resource: structs.RecommendationResourceMemory,
+		Value:    512,
+	}
+	upsert := &structs.RecommendationUpsertRequest{
+		Recommendations: []*structs.Recommendation{rec},
+		WriteRequest:    structs.WriteRequest{Region: "global", Namespace: job.Namespace},
+	}
+	var upsertResp structs.RecommendationUpsertResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Recommendation.UpsertRecommendations", upsert, &upsertResp))
+	require.NotZero(upsertResp.Index)
+	require.Len(upsertResp.Recommendations, 1)
+	out := upsertResp.Recommendations[0]
+	require.NotEmpty(out.ID)
+	require.Equal(job.Namespace, out.Namespace)
+	require.Equal("global", out.Region)
+	require.Equal(job.TaskGroups[0].Tasks[0].Resources.MemoryMB, out.Current)
+	require.NotZero(out.SubmitTime)
+
+	// Upserting for the same target updates the recommendation
+	rec2 := rec.Copy()
+	rec2.ID = ""
+	rec2.Value = 1024
+	upsert.Recommendations = []*structs.Recommendation{rec2}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Recommendation.UpsertRecommendations", upsert, &upsertResp))
+	require.Equal(out.ID, upsertResp.Recommendations[0].ID)
+
+	// Invalid recommendations are rejected
+	bad := rec.Copy()
+	bad.ID = ""
+	bad.Task = "missing"
+	upsert.Recommendations = []*structs.Recommendation{bad}
+	err := msgpackrpc.CallWithCodec(codec, "Recommendation.UpsertRecommendations", upsert, &upsertResp)
+	require.Error(err)
+	require.Contains(err.Error(), "no task")
+
+	bad = rec.Copy()
+	bad.ID = ""
+	bad.Value = 1
+	upsert.Recommendations = []*structs.Recommendation{bad}
+	err = msgpackrpc.CallWithCodec(codec, "Recommendation.UpsertRecommendations", upsert, &upsertResp)
+	require.Error(err)
+	require.Contains(err.Error(), "minimum MemoryMB value")
+
+	// Get the recommendation
+	get := &structs.RecommendationSpecificRequest{
+		RecommendationID: out.ID,
+		QueryOptions:     structs.QueryOptions{Region: "global"},
+	}
+	var getResp structs.SingleRecommendationResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Recommendation.GetRecommendation", get, &getResp))
+	require.NotNil(getResp.Recommendation)
+	require.Equal(1024, getResp.Recommendation.Value)
+
+	// List the recommendations, filtered by task
+	list := &structs.RecommendationListRequest{
+		JobID:        job.ID,
+		Group:        job.TaskGroups[0].Name,
+		Task:         job.TaskGroups[0].Tasks[0].Name,
+		QueryOptions: structs.QueryOptions{Region: "global", Namespace: job.Namespace},
+	}
+	var listResp structs.RecommendationListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Recommendation.ListRecommendations", list, &listResp))
+	require.Len(listResp.Recommendations, 1)
+
+	list.Task = "other"
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Recommendation.ListRecommendations", list, &listResp))
+	require.Len(listResp.Recommendations, 0)
+
+	list.JobID, list.Group, list.Task = "", "", ""
+	list.Namespace = structs.AllNamespacesSentinel
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Recommendation.ListRecommendations", list, &listResp))
+	require.Len(listResp.Recommendations, 1)
+
+	// Filtering by group requires the job
+	list.Group = job.TaskGroups[0].Name
+	require.Error(msgpackrpc.CallWithCodec(codec, "Recommendation.ListRecommendations", list, &listResp))
+}
+
+func TestRecommendationEndpoint_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "read",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	submitToken := mock.CreatePolicyAndToken(t, state, 1003, "submit",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitRecommendation}))
+
+	rec := mock.Recommendation(job)
+	rec.ID = ""
+	upsert := &structs.RecommendationUpsertRequest{
+		Recommendations: []*structs.Recommendation{rec},
+		WriteRequest:    structs.WriteRequest{Region: "global"},
+	}
+	var upsertResp structs.RecommendationUpsertResponse
+
+	// Submitting requires submit-recommendation or submit-job
+	for _, token := range []string{"", readToken.SecretID} {
+		upsert.AuthToken = token
+		err := msgpackrpc.CallWithCodec(codec, "Recommendation.UpsertRecommendations", upsert, &upsertResp)
+		require.EqualError(err, structs.ErrPermissionDenied.Error())
+	}
+	upsert.AuthToken = submitToken.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Recommendation.UpsertRecommendations", upsert, &upsertResp))
+	id := upsertResp.Recommendations[0].ID
+
+	// Reading requires read-job
+	get := &structs.RecommendationSpecificRequest{
+		RecommendationID: id,
+		QueryOptions:     structs.QueryOptions{Region: "global", AuthToken: submitToken.SecretID},
+	}
+	var getResp structs.SingleRecommendationResponse
+	err := msgpackrpc.CallWithCodec(codec, "Recommendation.GetRecommendation", get, &getResp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+	get.AuthToken = readToken.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Recommendation.GetRecommendation", get, &getResp))
+	require.NotNil(getResp.Recommendation)
+
+	// Listing all namespaces only returns the allowed ones
+	list := &structs.RecommendationListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global", Namespace: structs.AllNamespacesSentinel},
+	}
+	var listResp structs.RecommendationListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Recommendation.ListRecommendations", list, &listResp))
+	require.Len(listResp.Recommendations, 0)
+	list.AuthToken = readToken.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Recommendation.ListRecommendations", list, &listResp))
+	require.Len(listResp.Recommendations, 1)
+
+	// Applying requires submit-job
+	apply := &structs.RecommendationApplyRequest{
+		Apply:        []string{id},
+		WriteRequest: structs.WriteRequest{Region: "global", AuthToken: submitToken.SecretID},
+	}
+	var applyResp structs.RecommendationApplyResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Recommendation.ApplyRecommendations", apply, &applyResp))
+	require.Len(applyResp.Errors, 1)
+	require.Contains(applyResp.Errors[0].Error, "Permission denied")
+
+	apply.AuthToken = root.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Recommendation.ApplyRecommendations", apply, &applyResp))
+	require.Len(applyResp.UpdatedJobs, 1)
+}
+
+func TestRecommendationEndpoint_Apply(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Register the jobs
+	job := mock.Job()
+	job2 := mock.Job()
+	for _, j := range []*structs.Job{job, job2} {
+		reg := &structs.JobRegisterRequest{
+			Job:          j,
+			WriteRequest: structs.WriteRequest{Region: "global", Namespace: j.Namespace},
+		}
+		var regResp structs.JobRegisterResponse
+		require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", reg, &regResp))
+	}
+
+	cpu := mock.Recommendation(job)
+	memory := mock.Recommendation(job)
+	memory.Resource = structs.RecommendationResourceMemory
+	memory.Value = 1024
+	dismissed := mock.Recommendation(job2)
+	missing := mock.Recommendation(job2)
+	missing.Resource = structs.RecommendationResourceMemory
+	missing.Task = "missing"
+	require.NoError(state.UpsertRecommendations(2000, []*structs.Recommendation{cpu, memory, dismissed, missing}))
+
+	// Unknown recommendations are rejected
+	apply := &structs.RecommendationApplyRequest{
+		Apply:        []string{"unknown"},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.RecommendationApplyResponse
+	err := msgpackrpc.CallWithCodec(codec, "Recommendation.ApplyRecommendations", apply, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "not found")
+
+	// Apply both recommendations of the first job and dismiss one of the
+	// second job
+	apply.Apply = []string{cpu.ID, memory.ID, missing.ID}
+	apply.Dismiss = []string{dismissed.ID}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Recommendation.ApplyRecommendations", apply, &resp))
+
+	require.Len(resp.UpdatedJobs, 1)
+	updated := resp.UpdatedJobs[0]
+	require.Equal(job.ID, updated.JobID)
+	require.NotEmpty(updated.EvalID)
+	require.ElementsMatch([]string{cpu.ID, memory.ID}, updated.Recommendations)
+
+	require.Len(resp.Errors, 1)
+	require.Equal(job2.ID, resp.Errors[0].JobID)
+	require.Equal([]string{missing.ID}, resp.Errors[0].Recommendations)
+	require.Contains(resp.Errors[0].Error, "no task")
+
+	// The job is updated and the applied and dismissed recommendations are
+	// deleted
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.EqualValues(1, out.Version)
+	require.Equal(cpu.Value, out.TaskGroups[0].Tasks[0].Resources.CPU)
+	require.Equal(memory.Value, out.TaskGroups[0].Tasks[0].Resources.MemoryMB)
+
+	for _, id := range []string{cpu.ID, memory.ID, dismissed.ID} {
+		rec, err := state.RecommendationByID(nil, id)
+		require.NoError(err)
+		require.Nil(rec)
+	}
+	rec, err := state.RecommendationByID(nil, missing.ID)
+	require.NoError(err)
+	require.NotNil(rec)
+}
@@ -0,0 +1,455 @@
+package nomad
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// recommenderMaxSamples is the number of samples kept for each task,
+	// which is a day of samples at the default interval in clusters sampled in
+	// one go.
+	recommenderMaxSamples = 1440
+
+	// recommenderHeadroom is the percentage of the observed usage added to
+	// the recommended value of a resource.
+	recommenderHeadroom = 10
+
+	// recommenderThreshold is the minimum relative difference between the
+	// recommended value of a resource and its current value, or the value of
+	// the existing recommendation, for a recommendation to be submitted.
+	recommenderThreshold = 0.1
+
+	// recommenderCPUPercentile is the percentile of the CPU usage samples
+	// which is recommended. Memory is recommended from the maximum usage
+	// since a task exceeding its memory is killed.
+	recommenderCPUPercentile = 95
+
+	// recommenderStatsConcurrency is the maximum number of allocation stats
+	// requests in flight at once.
+	recommenderStatsConcurrency = 32
+
+	// recommenderMaxAllocsPerSample is the maximum number of allocations
+	// whose stats are fetched on each sample. Larger clusters are sampled
+	// in turns, so each allocation is sampled less often.
+	recommenderMaxAllocsPerSample = 512
+
+	// recommenderStatsTimeout is how long to wait for the stats of an
+	// allocation before skipping it for the current sample.
+	recommenderStatsTimeout = 10 * time.Second
+)
+
+// recommendationTarget identifies a task whose resource usage is sampled.
+type recommendationTarget struct {
+	Namespace string
+	JobID     string
+	Group     string
+	Task      string
+}
+
+// usageSamples holds the latest resource usage samples of a task, across all
+// its allocations.
+type usageSamples struct {
+	cpu    []float64
+	memory []float64
+}
+
+// add records a sample, dropping the oldest one when the window is full.
+func (u *usageSamples) add(cpu, memory float64) {
+	u.cpu = append(u.cpu, cpu)
+	u.memory = append(u.memory, memory)
+	if n := len(u.cpu); n > recommenderMaxSamples {
+		u.cpu = u.cpu[n-recommenderMaxSamples:]
+		u.memory = u.memory[n-recommenderMaxSamples:]
+	}
+}
+
+// allocStatsFn returns the resource usage of a running allocation.
+type allocStatsFn func(alloc *structs.Allocation) (*cstructs.AllocResourceUsage, error)
+
+// recommender aggregates the resource usage of the tasks of running
+// allocations and recommends their CPU and memory. It is run by the leader and
+// its samples are lost on leadership transitions.
+type recommender struct {
+	logger     log.Logger
+	stats      allocStatsFn
+	minSamples int
+	samples    map[recommendationTarget]*usageSamples
+
+	// statsSem bounds the stats requests in flight. A slot is only released
+	// once its request returns, so requests which timed out still count
+	// against the bound.
+	statsSem     chan struct{}
+	statsTimeout time.Duration
+
+	// maxAllocs is the maximum number of allocations sampled at once, and
+	// lastAllocID the ID of the last allocation sampled, from which the next
+	// sample resumes.
+	maxAllocs   int
+	lastAllocID string
+}
+
+func newRecommender(logger log.Logger, stats allocStatsFn, minSamples int) *recommender {
+	return &recommender{
+		logger:       logger,
+		stats:        stats,
+		minSamples:   minSamples,
+		samples:      make(map[recommendationTarget]*usageSamples),
+		statsSem:     make(chan struct{}, recommenderStatsConcurrency),
+		statsTimeout: recommenderStatsTimeout,
+		maxAllocs:    recommenderMaxAllocsPerSample,
+	}
+}
+
+// runRecommender periodically samples the resource usage of running tasks and
+// submits recommendations for their resources until the stop channel is
+// closed.
+func (s *Server) runRecommender(stopCh chan struct{}) {
+	r := newRecommender(s.logger.Named("recommender"), s.allocStats, s.config.RecommenderMinSamples)
+	ticker := time.NewTicker(s.config.RecommenderInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		snap, err := s.fsm.State().Snapshot()
+		if err != nil {
+			r.logger.Error("failed to snapshot state", "error", err)
+			continue
+		}
+
+		r.sample(snap, stopCh)
+		recs, err := r.recommend(snap)
+		if err != nil {
+			r.logger.Error("failed to compute recommendations", "error", err)
+			continue
+		}
+		if len(recs) == 0 {
+			continue
+		}
+
+		now := time.Now().UnixNano()
+		for _, rec := range recs {
+			rec.Region = s.Region()
+			rec.SubmitTime = now
+		}
+		req := &structs.RecommendationUpsertRequest{
+			Recommendations: recs,
+			WriteRequest:    structs.WriteRequest{Region: s.Region()},
+		}
+		out, _, err := s.raftApply(structs.RecommendationUpsertRequestType, req)
+		if err == nil {
+			if applyErr, ok := out.(error); ok {
+				err = applyErr
+			}
+		}
+		if err != nil {
+			r.logger.Error("failed to upsert recommendations", "error", err)
+			continue
+		}
+		r.logger.Debug("upserted recommendations", "count", len(recs))
+	}
+}
+
+// allocStats fetches the resource usage of an allocation from its client.
+func (s *Server) allocStats(alloc *structs.Allocation) (*cstructs.AllocResourceUsage, error) {
+	req := &cstructs.AllocStatsRequest{
+		AllocID: alloc.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    s.Region(),
+			Namespace: alloc.Namespace,
+			AuthToken: s.getLeaderAcl(),
+		},
+	}
+	var resp cstructs.AllocStatsResponse
+	if err := s.RPC("ClientAllocations.Stats", req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Stats, nil
+}
+
+// allocUsage is the resource usage fetched for an allocation.
+type allocUsage struct {
+	alloc *structs.Allocation
+	usage *cstructs.AllocResourceUsage
+}
+
+// sample records the resource usage of the tasks of the running allocations
+// of service and system jobs. Batch tasks are skipped since their usage is
+// usually too short lived to be sampled. At most maxAllocs allocations are
+// sampled, resuming after the last allocation of the previous sample so that
+// all the allocations are sampled in turns. The stats of the allocations are
+// fetched concurrently.
+func (r *recommender) sample(snap *state.StateSnapshot, stopCh chan struct{}) {
+	iter, err := snap.Allocs(nil, state.SortDefault)
+	if err != nil {
+		r.logger.Error("failed to list allocations", "error", err)
+		return
+	}
+
+	var allocs []*structs.Allocation
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		alloc := raw.(*structs.Allocation)
+		if alloc.ClientStatus != structs.AllocClientStatusRunning || alloc.TerminalStatus() || alloc.Job == nil {
+			continue
+		}
+		if alloc.Job.Type != structs.JobTypeService && alloc.Job.Type != structs.JobTypeSystem {
+			continue
+		}
+		allocs = append(allocs, alloc)
+	}
+	allocs = r.nextAllocs(allocs)
+
+	results := make(chan allocUsage, len(allocs))
+	var wg sync.WaitGroup
+FETCH:
+	for _, alloc := range allocs {
+		select {
+		case <-stopCh:
+			break FETCH
+		case r.statsSem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(alloc *structs.Allocation) {
+			defer wg.Done()
+			usage, err := r.fetchStats(alloc)
+			if err != nil {
+				r.logger.Debug("failed to fetch allocation stats", "alloc_id", alloc.ID, "error", err)
+				return
+			}
+			if usage != nil {
+				results <- allocUsage{alloc: alloc, usage: usage}
+			}
+		}(alloc)
+	}
+	wg.Wait()
+	close(results)
+
+	for res := range results {
+		r.record(res.alloc, res.usage)
+	}
+}
+
+// nextAllocs returns the allocations to sample among the given ones, which are
+// sorted by ID: up to maxAllocs of them, starting after the last allocation
+// sampled and wrapping around.
+func (r *recommender) nextAllocs(allocs []*structs.Allocation) []*structs.Allocation {
+	if len(allocs) <= r.maxAllocs {
+		r.lastAllocID = ""
+		return allocs
+	}
+
+	start := sort.Search(len(allocs), func(i int) bool {
+		return allocs[i].ID > r.lastAllocID
+	})
+	next := make([]*structs.Allocation, 0, r.maxAllocs)
+	for i := 0; i < r.maxAllocs; i++ {
+		next = append(next, allocs[(start+i)%len(allocs)])
+	}
+	r.lastAllocID = next[len(next)-1].ID
+	return next
+}
+
+// fetchStats fetches the stats of an allocation, giving up after the stats
+// timeout. The caller must hold a slot of the stats semaphore, which is
+// released once the request returns.
+func (r *recommender) fetchStats(alloc *structs.Allocation) (*cstructs.AllocResourceUsage, error) {
+	type result struct {
+		usage *cstructs.AllocResourceUsage
+		err   error
+	}
+	doneCh := make(chan result, 1)
+	go func() {
+		defer func() { <-r.statsSem }()
+		usage, err := r.stats(alloc)
+		doneCh <- result{usage, err}
+	}()
+
+	timer := time.NewTimer(r.statsTimeout)
+	defer timer.Stop()
+	select {
+	case res := <-doneCh:
+		return res.usage, res.err
+	case <-timer.C:
+		return nil, fmt.Errorf("timed out after %s", r.statsTimeout)
+	}
+}
+
+// record adds the resource usage of the tasks of an allocation to their
+// samples.
+func (r *recommender) record(alloc *structs.Allocation, usage *cstructs.AllocResourceUsage) {
+	for task, tu := range usage.Tasks {
+		if tu == nil || tu.ResourceUsage == nil ||
+			tu.ResourceUsage.CpuStats == nil || tu.ResourceUsage.MemoryStats == nil {
+			continue
+		}
+
+		// RSS is not measured on cgroups v2, where the usage is used
+		// instead
+		mem := tu.ResourceUsage.MemoryStats
+		memBytes := mem.RSS
+		if memBytes == 0 {
+			memBytes = mem.Usage
+		}
+
+		target := recommendationTarget{
+			Namespace: alloc.Namespace,
+			JobID:     alloc.JobID,
+			Group:     alloc.TaskGroup,
+			Task:      task,
+		}
+		samples, ok := r.samples[target]
+		if !ok {
+			samples = &usageSamples{}
+			r.samples[target] = samples
+		}
+		samples.add(tu.ResourceUsage.CpuStats.TotalTicks, float64(memBytes)/1024/1024)
+	}
+}
+
+// recommend returns the recommendations for the tasks with enough samples
+// whose usage differs from their resources. The samples of tasks which no
+// longer exist are dropped.
+func (r *recommender) recommend(snap *state.StateSnapshot) ([]*structs.Recommendation, error) {
+	targets := make([]recommendationTarget, 0, len(r.samples))
+	for target := range r.samples {
+		targets = append(targets, target)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		a, b := targets[i], targets[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.JobID != b.JobID {
+			return a.JobID < b.JobID
+		}
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		return a.Task < b.Task
+	})
+
+	var recs []*structs.Recommendation
+	for _, target := range targets {
+		samples := r.samples[target]
+
+		job, err := snap.JobByID(nil, target.Namespace, target.JobID)
+		if err != nil {
+			return nil, err
+		}
+		if job == nil || job.Stopped() {
+			delete(r.samples, target)
+			continue
+		}
+		if len(samples.cpu) < r.minSamples {
+			continue
+		}
+
+		cpu := percentile(samples.cpu, recommenderCPUPercentile) * (100 + recommenderHeadroom) / 100
+		memory := percentile(samples.memory, 100) * (100 + recommenderHeadroom) / 100
+		candidates := []struct {
+			resource string
+			value    int
+			min      int
+			samples  []float64
+		}{
+			{structs.RecommendationResourceCPU, int(math.Ceil(cpu)), structs.RecommendationMinCPU, samples.cpu},
+			{structs.RecommendationResourceMemory, int(math.Ceil(memory)), structs.RecommendationMinMemoryMB, samples.memory},
+		}
+
+		for _, c := range candidates {
+			rec := &structs.Recommendation{
+				Namespace: target.Namespace,
+				JobID:     target.JobID,
+				Group:     target.Group,
+				Task:      target.Task,
+				Resource:  c.resource,
+				Value:     c.value,
+				Stats:     usageStats(c.samples),
+				Meta: map[string]interface{}{
+					"source":  "nomad",
+					"samples": len(c.samples),
+				},
+			}
+			if rec.Value < c.min {
+				rec.Value = c.min
+			}
+
+			if err := setRecommendationTarget(snap, rec); err != nil {
+				// The task was removed from the job or reserves CPU cores
+				r.logger.Trace("skipping recommendation", "task", target.Task, "resource", c.resource, "error", err)
+				continue
+			}
+			if !significantChange(rec.Current, rec.Value) {
+				continue
+			}
+
+			existing, err := snap.RecommendationByID(nil, rec.ID)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil && !significantChange(existing.Value, rec.Value) {
+				continue
+			}
+
+			recs = append(recs, rec)
+		}
+	}
+
+	return recs, nil
+}
+
+// significantChange returns whether the value differs from the previous one by
+// more than the recommender threshold.
+func significantChange(prev, value int) bool {
+	if prev == 0 {
+		return value != 0
+	}
+	return math.Abs(float64(value-prev))/float64(prev) >= recommenderThreshold
+}
+
+// usageStats summarizes the samples of a resource.
+func usageStats(samples []float64) map[string]float64 {
+	sum := 0.0
+	for _, v := range samples {
+		sum += v
+	}
+
+	return map[string]float64{
+		"min":  percentile(samples, 0),
+		"max":  percentile(samples, 100),
+		"mean": sum / float64(len(samples)),
+		"p95":  percentile(samples, 95),
+		"p99":  percentile(samples, 99),
+	}
+}
+
+// percentile returns the nearest-rank percentile of the samples.
+func percentile(samples []float64, p float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+
+	sorted := make([]float64, len(samples))
+	copy(sorted, samples)
+	sort.Float64s(sorted)
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
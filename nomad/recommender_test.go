@@ -0,0 +1,292 @@
+package nomad
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAllocStats returns the same task usage for every allocation, failing
+// for the allocations in the failing set and blocking the allocations in the
+// hanging set until unblock is closed.
+type fakeAllocStats struct {
+	cpu     float64
+	memory  uint64
+	failing map[string]bool
+	hanging map[string]bool
+	unblock chan struct{}
+
+	l           sync.Mutex
+	calls       int
+	inFlight    int
+	maxInFlight int
+}
+
+func (f *fakeAllocStats) stats(alloc *structs.Allocation) (*cstructs.AllocResourceUsage, error) {
+	f.l.Lock()
+	f.calls++
+	f.inFlight++
+	if f.inFlight > f.maxInFlight {
+		f.maxInFlight = f.inFlight
+	}
+	f.l.Unlock()
+	defer func() {
+		f.l.Lock()
+		f.inFlight--
+		f.l.Unlock()
+	}()
+
+	if f.hanging[alloc.ID] {
+		<-f.unblock
+	}
+	if f.failing[alloc.ID] {
+		return nil, fmt.Errorf("no connection to node")
+	}
+
+	usage := &cstructs.AllocResourceUsage{Tasks: map[string]*cstructs.TaskResourceUsage{}}
+	for _, task := range alloc.Job.LookupTaskGroup(alloc.TaskGroup).Tasks {
+		usage.Tasks[task.Name] = &cstructs.TaskResourceUsage{
+			ResourceUsage: &cstructs.ResourceUsage{
+				CpuStats:    &cstructs.CpuStats{TotalTicks: f.cpu},
+				MemoryStats: &cstructs.MemoryStats{RSS: f.memory},
+			},
+		}
+	}
+	return usage, nil
+}
+
+func TestRecommender_Recommend(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	store := state.TestStateStore(t)
+	job := mock.Job()
+	require.NoError(store.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	// Two running allocations of the job and a batch allocation
+	running := mock.Alloc()
+	running.Job = job
+	running.JobID = job.ID
+	running.ClientStatus = structs.AllocClientStatusRunning
+	failing := running.Copy()
+	failing.ID = uuid.Generate()
+	pending := mock.Alloc()
+	pending.Job = job
+	pending.JobID = job.ID
+	batch := mock.BatchAlloc()
+	batch.ClientStatus = structs.AllocClientStatusRunning
+	require.NoError(store.UpsertAllocs(structs.MsgTypeTestSetup, 1001,
+		[]*structs.Allocation{running, failing, pending, batch}))
+
+	stats := &fakeAllocStats{memory: 100 * 1024 * 1024, failing: map[string]bool{failing.ID: true}}
+	r := newRecommender(testlog.HCLogger(t), stats.stats, 3)
+
+	snap, err := store.Snapshot()
+	require.NoError(err)
+
+	// No recommendation is made until there are enough samples
+	for _, cpu := range []float64{100, 300} {
+		stats.cpu = cpu
+		r.sample(snap, nil)
+		recs, err := r.recommend(snap)
+		require.NoError(err)
+		require.Empty(recs)
+	}
+	stats.l.Lock()
+	require.Equal(4, stats.calls)
+	stats.l.Unlock()
+
+	stats.cpu = 200
+	r.sample(snap, nil)
+	recs, err := r.recommend(snap)
+	require.NoError(err)
+	require.Len(recs, 2)
+
+	task := job.TaskGroups[0].Tasks[0]
+	cpu, memory := recs[0], recs[1]
+	require.Equal(structs.RecommendationResourceCPU, cpu.Resource)
+	require.Equal(job.ID, cpu.JobID)
+	require.Equal(task.Name, cpu.Task)
+	require.Equal(330, cpu.Value)
+	require.Equal(task.Resources.CPU, cpu.Current)
+	require.Equal(200.0, cpu.Stats["mean"])
+	require.Equal(300.0, cpu.Stats["p99"])
+	require.Equal(structs.RecommendationResourceMemory, memory.Resource)
+	require.Equal(110, memory.Value)
+	require.Equal(task.Resources.MemoryMB, memory.Current)
+	require.NotEqual(cpu.ID, memory.ID)
+
+	// Recommendations are not made again while the usage is stable
+	require.NoError(store.UpsertRecommendations(1002, recs))
+	snap, err = store.Snapshot()
+	require.NoError(err)
+	r.sample(snap, nil)
+	recs, err = r.recommend(snap)
+	require.NoError(err)
+	require.Empty(recs)
+
+	// The samples of removed jobs are dropped
+	require.NoError(store.DeleteJob(1003, job.Namespace, job.ID))
+	snap, err = store.Snapshot()
+	require.NoError(err)
+	recs, err = r.recommend(snap)
+	require.NoError(err)
+	require.Empty(recs)
+	require.Empty(r.samples)
+}
+
+func TestRecommender_MaxSamples(t *testing.T) {
+	t.Parallel()
+
+	var u usageSamples
+	for i := 0; i < recommenderMaxSamples+10; i++ {
+		u.add(float64(i), float64(i))
+	}
+	require.Len(t, u.cpu, recommenderMaxSamples)
+	require.Len(t, u.memory, recommenderMaxSamples)
+	require.Equal(t, 10.0, u.cpu[0])
+}
+
+func TestRecommender_Percentile(t *testing.T) {
+	t.Parallel()
+
+	samples := []float64{5, 1, 4, 2, 3, 6, 8, 7, 10, 9}
+	require.Equal(t, 1.0, percentile(samples, 0))
+	require.Equal(t, 5.0, percentile(samples, 50))
+	require.Equal(t, 10.0, percentile(samples, 95))
+	require.Equal(t, 10.0, percentile(samples, 100))
+	require.Equal(t, 0.0, percentile(nil, 50))
+
+	// The samples are not reordered
+	require.Equal(t, 5.0, samples[0])
+}
+
+func TestRecommender_SignificantChange(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, significantChange(0, 10))
+	require.False(t, significantChange(100, 105))
+	require.True(t, significantChange(100, 110))
+	require.True(t, significantChange(100, 50))
+}
+
+func TestRecommender_Sample_Timeout(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	store := state.TestStateStore(t)
+	job := mock.Job()
+	require.NoError(store.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2*recommenderStatsConcurrency; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(store.UpsertAllocs(structs.MsgTypeTestSetup, 1001, allocs))
+
+	// One allocation never answers until the test ends
+	stats := &fakeAllocStats{
+		cpu:     100,
+		memory:  100 * 1024 * 1024,
+		hanging: map[string]bool{allocs[0].ID: true},
+		unblock: make(chan struct{}),
+	}
+	defer close(stats.unblock)
+	r := newRecommender(testlog.HCLogger(t), stats.stats, 1)
+	r.statsTimeout = 50 * time.Millisecond
+
+	snap, err := store.Snapshot()
+	require.NoError(err)
+
+	start := time.Now()
+	r.sample(snap, nil)
+	require.Less(int64(time.Since(start)), int64(5*time.Second))
+
+	stats.l.Lock()
+	require.Equal(len(allocs), stats.calls)
+	require.LessOrEqual(stats.maxInFlight, recommenderStatsConcurrency)
+	stats.l.Unlock()
+
+	// The samples of the answering allocations are recorded
+	task := job.TaskGroups[0].Tasks[0]
+	samples := r.samples[recommendationTarget{
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		Group:     job.TaskGroups[0].Name,
+		Task:      task.Name,
+	}]
+	require.NotNil(samples)
+	require.Len(samples.cpu, len(allocs)-1)
+
+	// The hanging request still holds its slot of the semaphore
+	require.Len(r.statsSem, 1)
+}
+
+func TestRecommender_Sample_MaxAllocs(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	store := state.TestStateStore(t)
+	job := mock.Job()
+	require.NoError(store.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 5; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(store.UpsertAllocs(structs.MsgTypeTestSetup, 1001, allocs))
+
+	stats := &fakeAllocStats{cpu: 100, memory: 100 * 1024 * 1024}
+	r := newRecommender(testlog.HCLogger(t), stats.stats, 1)
+	r.maxAllocs = 2
+
+	// Each sample fetches at most maxAllocs allocations, resuming after the
+	// previous sample and wrapping around
+	sort.Slice(allocs, func(i, j int) bool { return allocs[i].ID < allocs[j].ID })
+	expected := [][]*structs.Allocation{
+		{allocs[0], allocs[1]},
+		{allocs[2], allocs[3]},
+		{allocs[4], allocs[0]},
+		{allocs[1], allocs[2]},
+	}
+	for _, exp := range expected {
+		require.Equal(exp, r.nextAllocs(allocs))
+	}
+
+	// All the allocations are below the limit
+	r.maxAllocs = len(allocs)
+	require.Equal(allocs, r.nextAllocs(allocs))
+
+	r.maxAllocs = 2
+	snap, err := store.Snapshot()
+	require.NoError(err)
+	for i := 0; i < 3; i++ {
+		r.sample(snap, nil)
+	}
+	require.Equal(6, stats.calls)
+	samples := r.samples[recommendationTarget{
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		Group:     job.TaskGroups[0].Name,
+		Task:      job.TaskGroups[0].Tasks[0].Name,
+	}]
+	require.Len(samples.cpu, 6)
+}
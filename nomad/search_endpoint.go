@@ -36,6 +36,7 @@ var (
 		structs.Volumes,
 		structs.ScalingPolicies,
 		structs.Namespaces,
+		structs.Recommendations,
 	}
 )
 
@@ -74,6 +75,8 @@ func (s *Search) getPrefixMatches(iter memdb.ResultIterator, prefix string) ([]s
 			id = t.ID
 		case *structs.ScalingPolicy:
 			id = t.ID
+		case *structs.Recommendation:
+			id = t.ID
 		case *structs.Namespace:
 			id = t.Name
 		default:
@@ -373,6 +376,8 @@ func getResourceIter(context structs.Context, aclObj *acl.ACL, namespace, prefix
 		return state.ScalingPoliciesByIDPrefix(ws, namespace, prefix)
 	case structs.Volumes:
 		return state.CSIVolumesByIDPrefix(ws, namespace, prefix)
+	case structs.Recommendations:
+		return state.RecommendationsByIDPrefix(ws, namespace, prefix)
 	case structs.Namespaces:
 		iter, err := state.NamespacesByNamePrefix(ws, prefix)
 		if err != nil {
@@ -76,7 +76,7 @@ func sufficientSearchPerms(aclObj *acl.ACL, namespace string, context structs.Co
 
 	if !jobRead {
 		switch context {
-		case structs.Allocs, structs.Deployments, structs.Evals, structs.Jobs:
+		case structs.Allocs, structs.Deployments, structs.Evals, structs.Jobs, structs.Recommendations:
 			return false
 		}
 	}
@@ -111,7 +111,7 @@ func filteredSearchContexts(aclObj *acl.ACL, namespace string, context structs.C
 	available := make([]structs.Context, 0, len(desired))
 	for _, c := range desired {
 		switch c {
-		case structs.Allocs, structs.Jobs, structs.Evals, structs.Deployments:
+		case structs.Allocs, structs.Jobs, structs.Evals, structs.Deployments, structs.Recommendations:
 			if jobRead {
 				available = append(available, c)
 			}
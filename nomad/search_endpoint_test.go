@@ -951,6 +951,50 @@ func TestSearch_PrefixSearch_ScalingPolicy(t *testing.T) {
 	require.Equal(t, uint64(jobIndex), resp.Index)
 }
 
+func TestSearch_PrefixSearch_Recommendation(t *testing.T) {
+	t.Parallel()
+
+	s, cleanupS := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	job := mock.Job()
+	rec := mock.Recommendation(job)
+	prefix := rec.ID[:len(rec.ID)-5]
+	fsmState := s.fsm.State()
+
+	require.NoError(t, fsmState.UpsertJob(structs.MsgTypeTestSetup, jobIndex, job))
+	require.NoError(t, fsmState.UpsertRecommendations(jobIndex+1, []*structs.Recommendation{rec}))
+
+	req := &structs.SearchRequest{
+		Prefix:  prefix,
+		Context: structs.Recommendations,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	var resp structs.SearchResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Search.PrefixSearch", req, &resp))
+	require.Len(t, resp.Matches[structs.Recommendations], 1)
+	require.Equal(t, rec.ID, resp.Matches[structs.Recommendations][0])
+	require.Equal(t, uint64(jobIndex+1), resp.Index)
+
+	req.Context = structs.All
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Search.PrefixSearch", req, &resp))
+	require.Len(t, resp.Matches[structs.Recommendations], 1)
+	require.Equal(t, rec.ID, resp.Matches[structs.Recommendations][0])
+
+	// Recommendations of other namespaces are not matched
+	req.Namespace = "other"
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Search.PrefixSearch", req, &resp))
+	require.Empty(t, resp.Matches[structs.Recommendations])
+}
+
 func TestSearch_FuzzySearch_ACL(t *testing.T) {
 	t.Parallel()
 
@@ -10,7 +10,8 @@ import (
 )
 
 const (
-	TableNamespaces = "namespaces"
+	TableNamespaces      = "namespaces"
+	TableRecommendations = "recommendations"
 )
 
 var (
@@ -58,6 +59,7 @@ func init() {
 		scalingPolicyTableSchema,
 		scalingEventTableSchema,
 		namespaceTableSchema,
+		recommendationTableSchema,
 	}...)
 }
 
@@ -179,6 +181,14 @@ func jobTableSchema() *memdb.TableSchema {
 					Conditional: jobIsPeriodic,
 				},
 			},
+			"dependencies_blocked": {
+				Name:         "dependencies_blocked",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.ConditionalIndex{
+					Conditional: jobIsDependenciesBlocked,
+				},
+			},
 		},
 	}
 }
@@ -298,6 +308,17 @@ func jobIsPeriodic(obj interface{}) (bool, error) {
 	return false, nil
 }
 
+// jobIsDependenciesBlocked satisfies the ConditionalIndexFunc interface and
+// creates an index on whether a job is blocked on its dependencies.
+func jobIsDependenciesBlocked(obj interface{}) (bool, error) {
+	j, ok := obj.(*structs.Job)
+	if !ok {
+		return false, fmt.Errorf("Unexpected type: %v", obj)
+	}
+
+	return j.DependenciesBlocked, nil
+}
+
 // deploymentSchema returns the MemDB schema tracking a job's deployments
 func deploymentSchema() *memdb.TableSchema {
 	return &memdb.TableSchema{
@@ -1113,3 +1134,40 @@ func namespaceTableSchema() *memdb.TableSchema {
 		},
 	}
 }
+
+// recommendationTableSchema returns the MemDB schema for the recommendations
+// table.
+func recommendationTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableRecommendations,
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "ID",
+				},
+			},
+			"job": {
+				Name:         "job",
+				AllowMissing: false,
+				Unique:       false,
+
+				// Use a compound index so the recommendations of a job can be
+				// looked up by the tuple of (Namespace, JobID)
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+
+						&memdb.StringFieldIndex{
+							Field: "JobID",
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -45,6 +45,10 @@ const (
 	NodeRegisterEventReregistered = "Node re-registered"
 )
 
+// jobChildStatusQueued is the status used to count queued dispatched jobs in
+// their parent's children summary. It is never stored as a job's status.
+const jobChildStatusQueued = "queued"
+
 // terminate appends the go-memdb terminator character to s.
 //
 // We can then use the result for exact matches during prefix
@@ -1673,6 +1677,113 @@ func (s *StateStore) upsertJobImpl(index uint64, job *structs.Job, keepVersion b
 	return nil
 }
 
+// ReleaseDispatchedJobs releases dispatched jobs that were queued because
+// their parent reached its max_running limit. Each job is identified by one of
+// the passed evaluations, which are created along with the release. Jobs that
+// no longer exist or are not queued are skipped along with their evaluations.
+// The evaluations that were created are returned.
+func (s *StateStore) ReleaseDispatchedJobs(msgType structs.MessageType, index uint64, evals []*structs.Evaluation) ([]*structs.Evaluation, error) {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	released := make([]*structs.Evaluation, 0, len(evals))
+	for _, eval := range evals {
+		existing, err := txn.First("jobs", "id", eval.Namespace, eval.JobID)
+		if err != nil {
+			return nil, fmt.Errorf("job lookup failed: %v", err)
+		}
+		if existing == nil {
+			continue
+		}
+
+		job := existing.(*structs.Job)
+		if !job.DispatchQueued {
+			continue
+		}
+
+		updated := job.Copy()
+		updated.DispatchQueued = false
+		updated.ModifyIndex = index
+
+		if err := txn.Insert("jobs", updated); err != nil {
+			return nil, fmt.Errorf("job insert failed: %v", err)
+		}
+
+		// Move the job out of the queued count of its parent's summary
+		oldChildStatus := childSummaryStatus(job, job.Status)
+		newChildStatus := childSummaryStatus(updated, updated.Status)
+		if err := s.setJobSummary(txn, updated, index, oldChildStatus, newChildStatus); err != nil {
+			return nil, fmt.Errorf("job summary update failed: %w", err)
+		}
+
+		released = append(released, eval)
+	}
+
+	if len(released) == 0 {
+		return nil, nil
+	}
+
+	if err := txn.Insert("index", &IndexEntry{"jobs", index}); err != nil {
+		return nil, fmt.Errorf("index update failed: %v", err)
+	}
+
+	if err := s.UpsertEvalsTxn(index, released, txn); err != nil {
+		return nil, err
+	}
+
+	return released, txn.Commit()
+}
+
+// ReleaseBlockedJobs releases jobs that were blocked on their dependencies.
+// Each job is identified by one of the passed evaluations, which are created
+// along with the release. Jobs that no longer exist or are not blocked are
+// skipped along with their evaluations. The evaluations that were created are
+// returned.
+func (s *StateStore) ReleaseBlockedJobs(msgType structs.MessageType, index uint64, evals []*structs.Evaluation) ([]*structs.Evaluation, error) {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	released := make([]*structs.Evaluation, 0, len(evals))
+	for _, eval := range evals {
+		existing, err := txn.First("jobs", "id", eval.Namespace, eval.JobID)
+		if err != nil {
+			return nil, fmt.Errorf("job lookup failed: %v", err)
+		}
+		if existing == nil {
+			continue
+		}
+
+		job := existing.(*structs.Job)
+		if !job.DependenciesBlocked {
+			continue
+		}
+
+		updated := job.Copy()
+		updated.DependenciesBlocked = false
+		updated.ModifyIndex = index
+
+		if err := txn.Insert("jobs", updated); err != nil {
+			return nil, fmt.Errorf("job insert failed: %v", err)
+		}
+
+		released = append(released, eval)
+	}
+
+	if len(released) == 0 {
+		return nil, nil
+	}
+
+	if err := txn.Insert("index", &IndexEntry{"jobs", index}); err != nil {
+		return nil, fmt.Errorf("index update failed: %v", err)
+	}
+
+	if err := s.UpsertEvalsTxn(index, released, txn); err != nil {
+		return nil, err
+	}
+
+	return released, txn.Commit()
+}
+
 // DeleteJob is used to deregister a job
 func (s *StateStore) DeleteJob(index uint64, namespace, jobID string) error {
 	txn := s.db.WriteTxn(index)
@@ -1713,11 +1824,15 @@ func (s *StateStore) DeleteJobTxn(index uint64, namespace, jobID string, txn Txn
 			if pSummary.Children != nil {
 
 				modified := false
-				switch job.Status {
+				switch childSummaryStatus(job, job.Status) {
 				case structs.JobStatusPending:
 					pSummary.Children.Pending--
 					pSummary.Children.Dead++
 					modified = true
+				case jobChildStatusQueued:
+					pSummary.Children.Queued--
+					pSummary.Children.Dead++
+					modified = true
 				case structs.JobStatusRunning:
 					pSummary.Children.Running--
 					pSummary.Children.Dead++
@@ -2108,6 +2223,21 @@ func (s *StateStore) JobsByPeriodic(ws memdb.WatchSet, periodic bool) (memdb.Res
 	return iter, nil
 }
 
+// JobsByDependenciesBlocked returns an iterator over all the jobs that are or
+// are not blocked on their dependencies.
+func (s *StateStore) JobsByDependenciesBlocked(ws memdb.WatchSet, blocked bool) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("jobs", "dependencies_blocked", blocked)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
 // JobsByScheduler returns an iterator over all the jobs with the specific
 // scheduler type.
 func (s *StateStore) JobsByScheduler(ws memdb.WatchSet, schedulerType string) (memdb.ResultIterator, error) {
@@ -3343,6 +3473,7 @@ func (s *StateStore) nestedUpdateAllocFromClient(txn *txn, index uint64, alloc *
 	copyAlloc.ClientDescription = alloc.ClientDescription
 	copyAlloc.TaskStates = alloc.TaskStates
 	copyAlloc.NetworkStatus = alloc.NetworkStatus
+	copyAlloc.CheckResults = alloc.CheckResults
 
 	// The client can only set its deployment health and timestamp, so just take
 	// those
@@ -4596,9 +4727,11 @@ func (s *StateStore) ReconcileJobSummaries(index uint64) error {
 			// Iterate over children of this job if any to fix summary counts
 			children := parentMap[job.ID]
 			for _, childJob := range children {
-				switch childJob.Status {
+				switch childSummaryStatus(childJob, childJob.Status) {
 				case structs.JobStatusPending:
 					summary.Children.Pending++
+				case jobChildStatusQueued:
+					summary.Children.Queued++
 				case structs.JobStatusDead:
 					summary.Children.Dead++
 				case structs.JobStatusRunning:
@@ -4753,12 +4886,26 @@ func (s *StateStore) setJobStatus(index uint64, txn *txn,
 	}
 
 	// Update the children summary
-	if err := s.setJobSummary(txn, updated, index, oldStatus, newStatus); err != nil {
+	oldChildStatus := childSummaryStatus(job, oldStatus)
+	newChildStatus := childSummaryStatus(updated, newStatus)
+	if err := s.setJobSummary(txn, updated, index, oldChildStatus, newChildStatus); err != nil {
 		return fmt.Errorf("job summary update failed %w", err)
 	}
 	return nil
 }
 
+// childSummaryStatus returns the status under which a child job is counted in
+// its parent's JobChildrenSummary. Pending dispatched jobs that are waiting on
+// the parent's max_running limit are counted as queued.
+func childSummaryStatus(job *structs.Job, status string) string {
+	if status == structs.JobStatusPending && job.DispatchQueued {
+		return jobChildStatusQueued
+	}
+	return status
+}
+
+// setJobSummary updates the children summary of the updated job's parent. The
+// old and new statuses are those returned by childSummaryStatus.
 func (s *StateStore) setJobSummary(txn *txn, updated *structs.Job, index uint64, oldStatus, newStatus string) error {
 	if updated.ParentID == "" {
 		return nil
@@ -4787,6 +4934,8 @@ func (s *StateStore) setJobSummary(txn *txn, updated *structs.Job, index uint64,
 			switch oldStatus {
 			case structs.JobStatusPending:
 				children.Pending--
+			case jobChildStatusQueued:
+				children.Queued--
 			case structs.JobStatusRunning:
 				children.Running--
 			case structs.JobStatusDead:
@@ -4800,6 +4949,8 @@ func (s *StateStore) setJobSummary(txn *txn, updated *structs.Job, index uint64,
 		switch newStatus {
 		case structs.JobStatusPending:
 			children.Pending++
+		case jobChildStatusQueued:
+			children.Queued++
 		case structs.JobStatusRunning:
 			children.Running++
 		case structs.JobStatusDead:
@@ -6489,3 +6640,229 @@ func (s *StateSnapshot) DenormalizeAllocationDiffSlice(allocDiffs []*structs.All
 func getPreemptedAllocDesiredDescription(preemptedByAllocID string) string {
 	return fmt.Sprintf("Preempted by alloc ID %v", preemptedByAllocID)
 }
+
+// UpsertRecommendations is used to upsert a set of recommendations. A
+// recommendation replaces any other recommendation for the same resource of
+// the same task.
+func (s *StateStore) UpsertRecommendations(index uint64, recs []*structs.Recommendation) error {
+	txn := s.db.WriteTxn(index)
+	defer txn.Abort()
+
+	for _, rec := range recs {
+		existing, err := txn.First(TableRecommendations, "id", rec.ID)
+		if err != nil {
+			return fmt.Errorf("recommendation lookup failed: %v", err)
+		}
+
+		if existing != nil {
+			rec.CreateIndex = existing.(*structs.Recommendation).CreateIndex
+		} else {
+			rec.CreateIndex = index
+		}
+		rec.ModifyIndex = index
+
+		// Delete the recommendations with the same target
+		iter, err := txn.Get(TableRecommendations, "job", rec.Namespace, rec.JobID)
+		if err != nil {
+			return fmt.Errorf("recommendation lookup failed: %v", err)
+		}
+		var replaced []*structs.Recommendation
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			other := raw.(*structs.Recommendation)
+			if other.ID != rec.ID && other.SameTarget(rec) {
+				replaced = append(replaced, other)
+			}
+		}
+		for _, other := range replaced {
+			if err := txn.Delete(TableRecommendations, other); err != nil {
+				return fmt.Errorf("recommendation deletion failed: %v", err)
+			}
+		}
+
+		if err := txn.Insert(TableRecommendations, rec); err != nil {
+			return fmt.Errorf("recommendation insert failed: %v", err)
+		}
+	}
+
+	if err := txn.Insert("index", &IndexEntry{TableRecommendations, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// DeleteRecommendations is used to delete a set of recommendations
+func (s *StateStore) DeleteRecommendations(index uint64, ids []string) error {
+	txn := s.db.WriteTxn(index)
+	defer txn.Abort()
+
+	for _, id := range ids {
+		existing, err := txn.First(TableRecommendations, "id", id)
+		if err != nil {
+			return fmt.Errorf("recommendation lookup failed: %v", err)
+		}
+		if existing == nil {
+			return fmt.Errorf("recommendation %q not found", id)
+		}
+
+		if err := txn.Delete(TableRecommendations, existing); err != nil {
+			return fmt.Errorf("recommendation deletion failed: %v", err)
+		}
+	}
+
+	if err := txn.Insert("index", &IndexEntry{TableRecommendations, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// RecommendationByID is used to lookup a recommendation by its ID
+func (s *StateStore) RecommendationByID(ws memdb.WatchSet, id string) (*structs.Recommendation, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableRecommendations, "id", id)
+	if err != nil {
+		return nil, fmt.Errorf("recommendation lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.Recommendation), nil
+	}
+	return nil, nil
+}
+
+// Recommendations returns an iterator over all the recommendations
+func (s *StateStore) Recommendations(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableRecommendations, "id")
+	if err != nil {
+		return nil, err
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// RecommendationsByNamespace returns an iterator over the recommendations of
+// all the jobs of a namespace
+func (s *StateStore) RecommendationsByNamespace(ws memdb.WatchSet, namespace string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	// Prefix the namespace with an empty job ID to exactly match the
+	// namespace
+	iter, err := txn.Get(TableRecommendations, "job_prefix", namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// RecommendationsByJob returns an iterator over the recommendations of a job
+func (s *StateStore) RecommendationsByJob(ws memdb.WatchSet, namespace, jobID string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableRecommendations, "job", namespace, jobID)
+	if err != nil {
+		return nil, err
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// RecommendationsByIDPrefix returns an iterator over the recommendations of
+// a namespace whose ID begins with the given prefix
+func (s *StateStore) RecommendationsByIDPrefix(ws memdb.WatchSet, namespace, prefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableRecommendations, "id_prefix", prefix)
+	if err != nil {
+		return nil, fmt.Errorf("recommendation lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return memdb.NewFilterIterator(iter, recommendationNamespaceFilter(namespace)), nil
+}
+
+// recommendationNamespaceFilter returns a filter function that filters all
+// recommendations not in the given namespace.
+func recommendationNamespaceFilter(namespace string) func(interface{}) bool {
+	return func(raw interface{}) bool {
+		r, ok := raw.(*structs.Recommendation)
+		if !ok {
+			return true
+		}
+
+		return r.Namespace != namespace
+	}
+}
+
+// deleteRecommendationsByJob deletes all recommendations for the specified job
+func (s *StateStore) deleteRecommendationsByJob(index uint64, txn Txn, job *structs.Job) error {
+	n, err := txn.DeleteAll(TableRecommendations, "job", job.Namespace, job.ID)
+	if err != nil {
+		return fmt.Errorf("recommendation deletion failed: %v", err)
+	}
+	if n == 0 {
+		return nil
+	}
+
+	if err := txn.Insert("index", &IndexEntry{TableRecommendations, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// updateJobRecommendations updates/deletes job recommendations as necessary for a job update
+//
+// Recommendations are deleted once their task is removed from the job, once
+// their value is applied, and on any new version of the job if they enforce
+// the version they were made for. The current value of the others is updated.
+func (s *StateStore) updateJobRecommendations(index uint64, txn Txn, prevJob, newJob *structs.Job) error {
+	if prevJob == nil {
+		return nil
+	}
+
+	iter, err := txn.Get(TableRecommendations, "job", newJob.Namespace, newJob.ID)
+	if err != nil {
+		return fmt.Errorf("recommendation lookup failed: %v", err)
+	}
+	var recs []*structs.Recommendation
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		recs = append(recs, raw.(*structs.Recommendation))
+	}
+
+	updated := false
+	for _, rec := range recs {
+		current, err := rec.CurrentValue(newJob)
+		outdated := rec.EnforceVersion && prevJob.Version != newJob.Version
+		if err != nil || outdated || current == rec.Value {
+			if err := txn.Delete(TableRecommendations, rec); err != nil {
+				return fmt.Errorf("recommendation deletion failed: %v", err)
+			}
+			updated = true
+			continue
+		}
+
+		if current != rec.Current {
+			rec = rec.Copy()
+			rec.Current = current
+			rec.ModifyIndex = index
+			if err := txn.Insert(TableRecommendations, rec); err != nil {
+				return fmt.Errorf("recommendation insert failed: %v", err)
+			}
+			updated = true
+		}
+	}
+
+	if !updated {
+		return nil
+	}
+
+	if err := txn.Insert("index", &IndexEntry{TableRecommendations, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
@@ -189,3 +189,11 @@ func (r *StateRestore) NamespaceRestore(ns *structs.Namespace) error {
 	}
 	return nil
 }
+
+// RecommendationRestore is used to restore a recommendation
+func (r *StateRestore) RecommendationRestore(rec *structs.Recommendation) error {
+	if err := r.txn.Insert(TableRecommendations, rec); err != nil {
+		return fmt.Errorf("recommendation insert failed: %v", err)
+	}
+	return nil
+}
@@ -541,3 +541,23 @@ func TestStateStore_RestoreSchedulerConfig(t *testing.T) {
 
 	require.Equal(schedConfig, out)
 }
+
+func TestStateStore_RestoreRecommendation(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+	rec := mock.Recommendation(mock.Job())
+
+	restore, err := state.Restore()
+	require.NoError(err)
+
+	err = restore.RecommendationRestore(rec)
+	require.NoError(err)
+	require.NoError(restore.Commit())
+
+	ws := memdb.NewWatchSet()
+	out, err := state.RecommendationByID(ws, rec.ID)
+	require.NoError(err)
+	require.EqualValues(out, rec)
+}
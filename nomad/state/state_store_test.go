@@ -1648,6 +1648,120 @@ func TestStateStore_UpsertJob_ChildJob(t *testing.T) {
 	}
 }
 
+func TestStateStore_ReleaseDispatchedJobs(t *testing.T) {
+	t.Parallel()
+
+	state := testStateStore(t)
+
+	parent := mock.BatchJob()
+	parent.ParameterizedJob = &structs.ParameterizedJobConfig{MaxRunning: 1}
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, parent))
+
+	child := mock.BatchJob()
+	child.Status = ""
+	child.ParentID = parent.ID
+	child.Dispatched = true
+	child.DispatchQueued = true
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, child))
+
+	summary, err := state.JobSummaryByID(nil, parent.Namespace, parent.ID)
+	require.NoError(t, err)
+	require.Equal(t, &structs.JobChildrenSummary{Queued: 1}, summary.Children)
+
+	// Watch the child so we can test that the release fires the watch
+	ws := memdb.NewWatchSet()
+	_, err = state.JobByID(ws, child.Namespace, child.ID)
+	require.NoError(t, err)
+
+	eval := mock.Eval()
+	eval.Namespace = child.Namespace
+	eval.JobID = child.ID
+
+	// Evals for missing jobs are skipped
+	missing := mock.Eval()
+
+	released, err := state.ReleaseDispatchedJobs(structs.MsgTypeTestSetup, 1002,
+		[]*structs.Evaluation{eval, missing})
+	require.NoError(t, err)
+	require.Equal(t, []*structs.Evaluation{eval}, released)
+	require.True(t, watchFired(ws))
+
+	out, err := state.JobByID(nil, child.Namespace, child.ID)
+	require.NoError(t, err)
+	require.False(t, out.DispatchQueued)
+	require.Equal(t, structs.JobStatusPending, out.Status)
+	require.Equal(t, uint64(1002), out.ModifyIndex)
+
+	evalOut, err := state.EvalByID(nil, eval.ID)
+	require.NoError(t, err)
+	require.NotNil(t, evalOut)
+
+	evalOut, err = state.EvalByID(nil, missing.ID)
+	require.NoError(t, err)
+	require.Nil(t, evalOut)
+
+	summary, err = state.JobSummaryByID(nil, parent.Namespace, parent.ID)
+	require.NoError(t, err)
+	require.Equal(t, &structs.JobChildrenSummary{Pending: 1}, summary.Children)
+
+	// Releasing the job again is a no-op
+	released, err = state.ReleaseDispatchedJobs(structs.MsgTypeTestSetup, 1003,
+		[]*structs.Evaluation{mock.Eval()})
+	require.NoError(t, err)
+	require.Empty(t, released)
+}
+
+func TestStateStore_ReleaseBlockedJobs(t *testing.T) {
+	t.Parallel()
+
+	state := testStateStore(t)
+
+	job := mock.BatchJob()
+	job.DependsOn = []*structs.JobDependency{{JobID: "upstream", Condition: structs.JobDependencyConditionSuccess}}
+	job.DependenciesBlocked = true
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	// Watch the job so we can test that the release fires the watch
+	ws := memdb.NewWatchSet()
+	_, err := state.JobByID(ws, job.Namespace, job.ID)
+	require.NoError(t, err)
+
+	eval := mock.Eval()
+	eval.Namespace = job.Namespace
+	eval.JobID = job.ID
+	eval.TriggeredBy = structs.EvalTriggerJobDependency
+
+	// Evals for missing jobs are skipped
+	missing := mock.Eval()
+
+	released, err := state.ReleaseBlockedJobs(structs.MsgTypeTestSetup, 1001,
+		[]*structs.Evaluation{eval, missing})
+	require.NoError(t, err)
+	require.Equal(t, []*structs.Evaluation{eval}, released)
+	require.True(t, watchFired(ws))
+
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.False(t, out.DependenciesBlocked)
+	require.Equal(t, uint64(1001), out.ModifyIndex)
+
+	evalOut, err := state.EvalByID(nil, eval.ID)
+	require.NoError(t, err)
+	require.NotNil(t, evalOut)
+
+	evalOut, err = state.EvalByID(nil, missing.ID)
+	require.NoError(t, err)
+	require.Nil(t, evalOut)
+
+	// Releasing the job again is a no-op
+	again := eval.Copy()
+	again.ID = uuid.Generate()
+	released, err = state.ReleaseBlockedJobs(structs.MsgTypeTestSetup, 1002,
+		[]*structs.Evaluation{again})
+	require.NoError(t, err)
+	require.Empty(t, released)
+}
+
 func TestStateStore_UpdateUpsertJob_JobVersion(t *testing.T) {
 	t.Parallel()
 
@@ -2242,6 +2356,41 @@ func TestStateStore_JobsByPeriodic(t *testing.T) {
 	}
 }
 
+func TestStateStore_JobsByDependenciesBlocked(t *testing.T) {
+	t.Parallel()
+
+	state := testStateStore(t)
+
+	job := mock.BatchJob()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	blocked := mock.BatchJob()
+	blocked.DependsOn = []*structs.JobDependency{
+		{JobID: job.ID, Condition: structs.JobDependencyConditionComplete},
+	}
+	blocked.DependenciesBlocked = true
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, blocked))
+
+	ws := memdb.NewWatchSet()
+	iter, err := state.JobsByDependenciesBlocked(ws, true)
+	require.NoError(t, err)
+
+	var out []*structs.Job
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		out = append(out, raw.(*structs.Job))
+	}
+	require.Len(t, out, 1)
+	require.Equal(t, blocked.ID, out[0].ID)
+
+	// Updating a job that isn't blocked doesn't fire the watch
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1002, job.Copy()))
+	require.False(t, watchFired(ws))
+
+	// Updating a blocked job does
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1003, blocked.Copy()))
+	require.True(t, watchFired(ws))
+}
+
 func TestStateStore_JobsByScheduler(t *testing.T) {
 	t.Parallel()
 
@@ -9070,3 +9219,201 @@ func nextIndex(s *StateStore) uint64 {
 	index++
 	return index
 }
+
+func TestStateStore_UpsertRecommendations(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+	job := mock.Job()
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	rec := mock.Recommendation(job)
+	ws := memdb.NewWatchSet()
+	_, err := state.RecommendationByID(ws, rec.ID)
+	require.NoError(err)
+
+	require.NoError(state.UpsertRecommendations(1001, []*structs.Recommendation{rec}))
+	require.True(watchFired(ws))
+
+	out, err := state.RecommendationByID(nil, rec.ID)
+	require.NoError(err)
+	require.Equal(rec, out)
+	require.EqualValues(1001, out.CreateIndex)
+	require.EqualValues(1001, out.ModifyIndex)
+
+	index, err := state.Index(TableRecommendations)
+	require.NoError(err)
+	require.EqualValues(1001, index)
+
+	// Updating keeps the create index
+	update := rec.Copy()
+	update.Value++
+	require.NoError(state.UpsertRecommendations(1002, []*structs.Recommendation{update}))
+	out, err = state.RecommendationByID(nil, rec.ID)
+	require.NoError(err)
+	require.Equal(update.Value, out.Value)
+	require.EqualValues(1001, out.CreateIndex)
+	require.EqualValues(1002, out.ModifyIndex)
+
+	// A recommendation for the same target replaces the existing one
+	other := mock.Recommendation(job)
+	memory := mock.Recommendation(job)
+	memory.Resource = structs.RecommendationResourceMemory
+	require.NoError(state.UpsertRecommendations(1003, []*structs.Recommendation{other, memory}))
+
+	out, err = state.RecommendationByID(nil, rec.ID)
+	require.NoError(err)
+	require.Nil(out)
+
+	iter, err := state.RecommendationsByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	var ids []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		ids = append(ids, raw.(*structs.Recommendation).ID)
+	}
+	require.ElementsMatch([]string{other.ID, memory.ID}, ids)
+}
+
+func TestStateStore_RecommendationsByNamespace(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+	ns1 := mock.Namespace()
+	ns1.Name = "name"
+	ns2 := mock.Namespace()
+	ns2.Name = "name2"
+	require.NoError(state.UpsertNamespaces(1000, []*structs.Namespace{ns1, ns2}))
+
+	job1 := mock.Job()
+	job1.Namespace = ns1.Name
+	job2 := mock.Job()
+	job2.Namespace = ns2.Name
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1001, job1))
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1002, job2))
+
+	rec1 := mock.Recommendation(job1)
+	rec2 := mock.Recommendation(job2)
+	require.NoError(state.UpsertRecommendations(1003, []*structs.Recommendation{rec1, rec2}))
+
+	// The namespace is matched exactly
+	iter, err := state.RecommendationsByNamespace(nil, ns1.Name)
+	require.NoError(err)
+	var ids []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		ids = append(ids, raw.(*structs.Recommendation).ID)
+	}
+	require.Equal([]string{rec1.ID}, ids)
+}
+
+func TestStateStore_DeleteRecommendations(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+	job := mock.Job()
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	rec := mock.Recommendation(job)
+	require.NoError(state.UpsertRecommendations(1001, []*structs.Recommendation{rec}))
+
+	ws := memdb.NewWatchSet()
+	_, err := state.RecommendationByID(ws, rec.ID)
+	require.NoError(err)
+
+	require.NoError(state.DeleteRecommendations(1002, []string{rec.ID}))
+	require.True(watchFired(ws))
+
+	out, err := state.RecommendationByID(nil, rec.ID)
+	require.NoError(err)
+	require.Nil(out)
+
+	index, err := state.Index(TableRecommendations)
+	require.NoError(err)
+	require.EqualValues(1002, index)
+
+	// Deleting a missing recommendation fails
+	require.Error(state.DeleteRecommendations(1003, []string{rec.ID}))
+}
+
+func TestStateStore_UpsertJob_UpdateRecommendations(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+	job := mock.Job()
+	job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 256
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	// A CPU recommendation which is applied, a memory recommendation which
+	// is kept and another which enforces the job version
+	cpu := mock.Recommendation(job)
+	memory := mock.Recommendation(job)
+	memory.Resource = structs.RecommendationResourceMemory
+	memory.Value = 512
+	memory.Current = 256
+	require.NoError(state.UpsertRecommendations(1001, []*structs.Recommendation{cpu, memory}))
+
+	job2 := job.Copy()
+	job2.TaskGroups[0].Tasks[0].Resources.CPU = cpu.Value
+	job2.TaskGroups[0].Tasks[0].Resources.MemoryMB = 300
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1002, job2))
+
+	out, err := state.RecommendationByID(nil, cpu.ID)
+	require.NoError(err)
+	require.Nil(out)
+
+	out, err = state.RecommendationByID(nil, memory.ID)
+	require.NoError(err)
+	require.NotNil(out)
+	require.Equal(300, out.Current)
+	require.EqualValues(1002, out.ModifyIndex)
+
+	// Recommendations enforcing the version are dismissed by new versions
+	enforced := out.Copy()
+	enforced.EnforceVersion = true
+	require.NoError(state.UpsertRecommendations(1003, []*structs.Recommendation{enforced}))
+
+	job3 := job2.Copy()
+	job3.Meta["version"] = "3"
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1004, job3))
+
+	out, err = state.RecommendationByID(nil, memory.ID)
+	require.NoError(err)
+	require.Nil(out)
+
+	// Recommendations for removed tasks are deleted
+	removed := mock.Recommendation(job3)
+	require.NoError(state.UpsertRecommendations(1005, []*structs.Recommendation{removed}))
+
+	job4 := job3.Copy()
+	job4.TaskGroups[0].Tasks[0].Name = "renamed"
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1006, job4))
+
+	out, err = state.RecommendationByID(nil, removed.ID)
+	require.NoError(err)
+	require.Nil(out)
+}
+
+func TestStateStore_DeleteJob_DeleteRecommendations(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+	job := mock.Job()
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	rec := mock.Recommendation(job)
+	require.NoError(state.UpsertRecommendations(1001, []*structs.Recommendation{rec}))
+
+	require.NoError(state.DeleteJob(1002, job.Namespace, job.ID))
+
+	out, err := state.RecommendationByID(nil, rec.ID)
+	require.NoError(err)
+	require.Nil(out)
+
+	index, err := state.Index(TableRecommendations)
+	require.NoError(err)
+	require.EqualValues(1002, index)
+}
@@ -68,14 +68,20 @@ func (j *Job) ConsulUsages() map[string]*ConsulUsage {
 			m[namespace] = new(ConsulUsage)
 		}
 
-		// Gather group services
+		// Gather group services, leaving out the ones not registered in Consul
 		for _, service := range tg.Services {
+			if service.Provider == ServiceProviderNomad {
+				continue
+			}
 			m[namespace].Services = append(m[namespace].Services, service.Name)
 		}
 
 		// Gather task services and KV usage
 		for _, task := range tg.Tasks {
 			for _, service := range task.Services {
+				if service.Provider == ServiceProviderNomad {
+					continue
+				}
 				m[namespace].Services = append(m[namespace].Services, service.Name)
 			}
 			if len(task.Templates) > 0 {
@@ -69,7 +69,8 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 	diff := &JobDiff{Type: DiffTypeNone}
 	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
 	filter := []string{"ID", "Status", "StatusDescription", "Version", "Stable", "CreateIndex",
-		"ModifyIndex", "JobModifyIndex", "Update", "SubmitTime", "NomadTokenID"}
+		"ModifyIndex", "JobModifyIndex", "Update", "SubmitTime", "NomadTokenID", "DispatchQueued",
+		"DependenciesBlocked"}
 
 	if j == nil && other == nil {
 		return diff, nil
@@ -135,6 +136,11 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 		diff.Objects = append(diff.Objects, pDiff)
 	}
 
+	// Dependencies diff
+	if dDiffs := jobDependencyDiffs(j.DependsOn, other.DependsOn, contextual); dDiffs != nil {
+		diff.Objects = append(diff.Objects, dDiffs...)
+	}
+
 	// ParameterizedJob diff
 	if cDiff := parameterizedJobDiff(j.ParameterizedJob, other.ParameterizedJob, contextual); cDiff != nil {
 		diff.Objects = append(diff.Objects, cDiff)
@@ -308,6 +314,11 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		diff.Objects = append(diff.Objects, diskDiff)
 	}
 
+	// Resources diff
+	if rDiff := primitiveObjectDiff(tg.Resources, other.Resources, nil, "Resources", contextual); rDiff != nil {
+		diff.Objects = append(diff.Objects, rDiff)
+	}
+
 	consulDiff := primitiveObjectDiff(tg.Consul, other.Consul, nil, "Consul", contextual)
 	if consulDiff != nil {
 		diff.Objects = append(diff.Objects, consulDiff)
@@ -491,7 +502,7 @@ func (t *Task) Diff(other *Task, contextual bool) (*TaskDiff, error) {
 	}
 
 	// LogConfig diff
-	lDiff := primitiveObjectDiff(t.LogConfig, other.LogConfig, nil, "LogConfig", contextual)
+	lDiff := logConfigDiff(t.LogConfig, other.LogConfig, contextual)
 	if lDiff != nil {
 		diff.Objects = append(diff.Objects, lDiff)
 	}
@@ -502,6 +513,16 @@ func (t *Task) Diff(other *Task, contextual bool) (*TaskDiff, error) {
 		diff.Objects = append(diff.Objects, dDiff)
 	}
 
+	// Task dependencies diff
+	if setDiff := stringSetDiff(t.DependsOn, other.DependsOn, "DependsOn", contextual); setDiff != nil {
+		diff.Objects = append(diff.Objects, setDiff)
+	}
+
+	// Shutdown hook diff
+	if shDiff := shutdownHookDiff(t.ShutdownHook, other.ShutdownHook, contextual); shDiff != nil {
+		diff.Objects = append(diff.Objects, shDiff)
+	}
+
 	// Artifacts diff
 	diffs := primitiveObjectSetDiff(
 		interfaceSlice(t.Artifacts),
@@ -810,6 +831,31 @@ func serviceCheckDiff(old, new *ServiceCheck, contextual bool) *ObjectDiff {
 	return diff
 }
 
+// shutdownHookDiff returns the diff of two task shutdown hooks. If contextual
+// diff is enabled, all fields will be returned, even if no diff occurred.
+func shutdownHookDiff(old, new *TaskShutdownHook, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "ShutdownHook"}
+	var oldFlat, newFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		diff.Type = DiffTypeAdded
+		newFlat = flatmap.Flatten(new, nil, false)
+	} else if new == nil {
+		diff.Type = DiffTypeDeleted
+		oldFlat = flatmap.Flatten(old, nil, false)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldFlat = flatmap.Flatten(old, nil, false)
+		newFlat = flatmap.Flatten(new, nil, false)
+	}
+
+	// Diff the fields, including the command arguments
+	diff.Fields = fieldDiffs(oldFlat, newFlat, contextual)
+	return diff
+}
+
 // checkHeaderDiff returns the diff of two service check header objects. If
 // contextual diff is enabled, all fields will be returned, even if no diff
 // occurred.
@@ -1421,7 +1467,7 @@ func sidecarTaskDiff(old, new *SidecarTask, contextual bool) *ObjectDiff {
 	}
 
 	// LogConfig diff
-	lDiff := primitiveObjectDiff(old.LogConfig, new.LogConfig, nil, "LogConfig", contextual)
+	lDiff := logConfigDiff(old.LogConfig, new.LogConfig, contextual)
 	if lDiff != nil {
 		diff.Objects = append(diff.Objects, lDiff)
 	}
@@ -1429,6 +1475,94 @@ func sidecarTaskDiff(old, new *SidecarTask, contextual bool) *ObjectDiff {
 	return diff
 }
 
+// logConfigDiff returns the diff of two LogConfig objects, including their
+// sinks. If contextual diff is enabled, all fields will be returned, even if
+// no diff occurred.
+func logConfigDiff(old, new *LogConfig, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "LogConfig"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &LogConfig{}
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	} else if new == nil {
+		new = &LogConfig{}
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	}
+
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+	diff.Objects = logSinkDiffs(old.Sinks, new.Sinks, contextual)
+	if diff.Type == DiffTypeEdited && len(diff.Objects) == 0 {
+		// Nil and empty sinks differ without any field changing
+		for _, f := range diff.Fields {
+			if f.Type != DiffTypeNone {
+				return diff
+			}
+		}
+		return nil
+	}
+	return diff
+}
+
+// logSinkDiffs diffs two sets of log sinks, matching them by type and
+// address.
+func logSinkDiffs(old, new []*LogSink, contextual bool) []*ObjectDiff {
+	oldSet := make(map[string]*LogSink, len(old))
+	for _, s := range old {
+		oldSet[s.DiffID()] = s
+	}
+	newSet := make(map[string]*LogSink, len(new))
+	for _, s := range new {
+		newSet[s.DiffID()] = s
+	}
+
+	var diffs []*ObjectDiff
+	for id, s := range oldSet {
+		if diff := logSinkDiff(s, newSet[id], contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+	for id, s := range newSet {
+		if _, ok := oldSet[id]; !ok {
+			diffs = append(diffs, logSinkDiff(nil, s, contextual))
+		}
+	}
+
+	sort.Sort(ObjectDiffs(diffs))
+	return diffs
+}
+
+// logSinkDiff returns the diff of two log sinks, including their headers.
+func logSinkDiff(old, new *LogSink, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Sink"}
+	var oldFlat, newFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		diff.Type = DiffTypeAdded
+		newFlat = flatmap.Flatten(new, nil, false)
+	} else if new == nil {
+		diff.Type = DiffTypeDeleted
+		oldFlat = flatmap.Flatten(old, nil, false)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldFlat = flatmap.Flatten(old, nil, false)
+		newFlat = flatmap.Flatten(new, nil, false)
+	}
+
+	diff.Fields = fieldDiffs(oldFlat, newFlat, contextual)
+	return diff
+}
+
 // consulProxyDiff returns the diff of two ConsulProxy objects.
 // If contextual diff is enabled, all fields will be returned, even if no diff occurred.
 func consulProxyDiff(old, new *ConsulProxy, contextual bool) *ObjectDiff {
@@ -1776,9 +1910,47 @@ func parameterizedJobDiff(old, new *ParameterizedJobConfig, contextual bool) *Ob
 		diff.Objects = append(diff.Objects, requiredDiff)
 	}
 
+	// Rate diff
+	if rateDiff := primitiveObjectDiff(old.Rate, new.Rate, nil, "Rate", contextual); rateDiff != nil {
+		diff.Objects = append(diff.Objects, rateDiff)
+	}
+
 	return diff
 }
 
+// jobDependencyDiffs diffs a set of job dependencies, keyed by upstream job
+// ID. If contextual diff is enabled, unchanged fields will be returned.
+func jobDependencyDiffs(old, new []*JobDependency, contextual bool) []*ObjectDiff {
+	oldMap := make(map[string]*JobDependency, len(old))
+	newMap := make(map[string]*JobDependency, len(new))
+	for _, d := range old {
+		oldMap[d.JobID] = d
+	}
+	for _, d := range new {
+		newMap[d.JobID] = d
+	}
+
+	var diffs []*ObjectDiff
+	for id, oldDep := range oldMap {
+		// Diff the same, deleted and edited
+		if diff := primitiveObjectDiff(oldDep, newMap[id], nil, "DependsOn", contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+	for id, newDep := range newMap {
+		// Diff the added
+		if _, ok := oldMap[id]; ok {
+			continue
+		}
+		if diff := primitiveObjectDiff(oldMap[id], newDep, nil, "DependsOn", contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	sort.Sort(ObjectDiffs(diffs))
+	return diffs
+}
+
 func multiregionDiff(old, new *Multiregion, contextual bool) *ObjectDiff {
 
 	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Multiregion"}
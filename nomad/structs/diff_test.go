@@ -668,6 +668,86 @@ func TestJobDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			// DependsOn edited
+			Old: &Job{
+				DependsOn: []*JobDependency{
+					{
+						JobID:     "a",
+						Condition: JobDependencyConditionSuccess,
+					},
+					{
+						JobID:     "b",
+						Condition: JobDependencyConditionComplete,
+					},
+				},
+			},
+			New: &Job{
+				DependsOn: []*JobDependency{
+					{
+						JobID:     "a",
+						Condition: JobDependencyConditionComplete,
+					},
+					{
+						JobID:     "c",
+						Condition: JobDependencyConditionSuccess,
+					},
+				},
+			},
+			Expected: &JobDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "DependsOn",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Condition",
+								Old:  "success",
+								New:  "complete",
+							},
+						},
+					},
+					{
+						Type: DiffTypeAdded,
+						Name: "DependsOn",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Condition",
+								Old:  "",
+								New:  "success",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "JobID",
+								Old:  "",
+								New:  "c",
+							},
+						},
+					},
+					{
+						Type: DiffTypeDeleted,
+						Name: "DependsOn",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "Condition",
+								Old:  "complete",
+								New:  "",
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "JobID",
+								Old:  "b",
+								New:  "",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			// Constraints edited
 			Old: &Job{
@@ -899,6 +979,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Stateful",
+								Old:  "",
+								New:  "false",
+							},
 						},
 					},
 					{
@@ -927,6 +1013,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "1",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Stateful",
+								Old:  "false",
+								New:  "",
+							},
 						},
 					},
 				},
@@ -940,6 +1032,11 @@ func TestJobDiff(t *testing.T) {
 					Payload:      DispatchPayloadRequired,
 					MetaOptional: []string{"foo"},
 					MetaRequired: []string{"bar"},
+					MaxRunning:   5,
+					Rate: &DispatchRateConfig{
+						Limit: 1.5,
+						Burst: 10,
+					},
 				},
 			},
 			Expected: &JobDiff{
@@ -949,6 +1046,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxRunning",
+								Old:  "",
+								New:  "5",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Payload",
@@ -981,6 +1084,24 @@ func TestJobDiff(t *testing.T) {
 									},
 								},
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Rate",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Burst",
+										Old:  "",
+										New:  "10",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Limit",
+										Old:  "",
+										New:  "1.5",
+									},
+								},
+							},
 						},
 					},
 				},
@@ -1003,6 +1124,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeDeleted,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxRunning",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Payload",
@@ -1054,6 +1181,7 @@ func TestJobDiff(t *testing.T) {
 					Payload:      DispatchPayloadOptional,
 					MetaOptional: []string{"bam"},
 					MetaRequired: []string{"bang"},
+					MaxRunning:   10,
 				},
 			},
 			Expected: &JobDiff{
@@ -1063,6 +1191,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "MaxRunning",
+								Old:  "0",
+								New:  "10",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Payload",
@@ -1136,6 +1270,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "MaxRunning",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Payload",
@@ -1732,10 +1872,12 @@ func TestTaskGroupDiff(t *testing.T) {
 			Old:      &TaskGroup{},
 			New: &TaskGroup{
 				RestartPolicy: &RestartPolicy{
-					Attempts: 1,
-					Interval: 1 * time.Second,
-					Delay:    1 * time.Second,
-					Mode:     "fail",
+					Attempts:      1,
+					Interval:      1 * time.Second,
+					Delay:         1 * time.Second,
+					Mode:          "fail",
+					DelayFunction: "exponential",
+					MaxDelay:      2 * time.Second,
 				},
 			},
 			Expected: &TaskGroupDiff{
@@ -1757,12 +1899,24 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "1000000000",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "DelayFunction",
+								Old:  "",
+								New:  "exponential",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Interval",
 								Old:  "",
 								New:  "1000000000",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxDelay",
+								Old:  "",
+								New:  "2000000000",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Mode",
@@ -1778,10 +1932,12 @@ func TestTaskGroupDiff(t *testing.T) {
 			TestCase: "RestartPolicy deleted",
 			Old: &TaskGroup{
 				RestartPolicy: &RestartPolicy{
-					Attempts: 1,
-					Interval: 1 * time.Second,
-					Delay:    1 * time.Second,
-					Mode:     "fail",
+					Attempts:      1,
+					Interval:      1 * time.Second,
+					Delay:         1 * time.Second,
+					Mode:          "fail",
+					DelayFunction: "exponential",
+					MaxDelay:      2 * time.Second,
 				},
 			},
 			New: &TaskGroup{},
@@ -1804,12 +1960,24 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "1000000000",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "DelayFunction",
+								Old:  "exponential",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Interval",
 								Old:  "1000000000",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxDelay",
+								Old:  "2000000000",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Mode",
@@ -1913,12 +2081,24 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "1000000000",
 								New:  "1000000000",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "DelayFunction",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Interval",
 								Old:  "1000000000",
 								New:  "2000000000",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "MaxDelay",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "Mode",
@@ -2603,6 +2783,71 @@ func TestTaskGroupDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			TestCase: "Resources added",
+			Old:      &TaskGroup{},
+			New: &TaskGroup{
+				Resources: &TaskGroupResources{
+					CPU:      1000,
+					MemoryMB: 512,
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeAdded,
+						Name: "Resources",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "CPU",
+								Old:  "",
+								New:  "1000",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MemoryMB",
+								Old:  "",
+								New:  "512",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			TestCase: "Resources edited",
+			Old: &TaskGroup{
+				Resources: &TaskGroupResources{
+					CPU:      1000,
+					MemoryMB: 512,
+				},
+			},
+			New: &TaskGroup{
+				Resources: &TaskGroupResources{
+					CPU:      1000,
+					MemoryMB: 1024,
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Resources",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "MemoryMB",
+								Old:  "512",
+								New:  "1024",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			TestCase:   "EphemeralDisk edited with context",
 			Contextual: true,
@@ -2872,6 +3117,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Provider",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "TaskName",
@@ -2938,6 +3189,12 @@ func TestTaskGroupDiff(t *testing.T) {
 										Old:  "1000000000",
 										New:  "2000000000",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "Kind",
+										Old:  "",
+										New:  "",
+									},
 									{
 										Type: DiffTypeDeleted,
 										Name: "Method",
@@ -4317,6 +4574,18 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxTotalSizeMB",
+								Old:  "",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "RotateEvery",
+								Old:  "",
+								New:  "0",
+							},
 						},
 					},
 				},
@@ -4350,6 +4619,18 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "1",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxTotalSizeMB",
+								Old:  "0",
+								New:  "",
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "RotateEvery",
+								Old:  "0",
+								New:  "",
+							},
 						},
 					},
 				},
@@ -4415,6 +4696,24 @@ func TestTaskDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "LogConfig",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "Compression",
+								Old:  "",
+								New:  "",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "Format",
+								Old:  "",
+								New:  "",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "Level",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "MaxFileSizeMB",
@@ -4427,6 +4726,153 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "1",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "MaxTotalSizeMB",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "RotateEvery",
+								Old:  "0",
+								New:  "0",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "LogConfig sinks edited",
+			Old: &Task{
+				LogConfig: &LogConfig{
+					MaxFiles:      1,
+					MaxFileSizeMB: 10,
+					Sinks: []*LogSink{
+						{
+							Type:       LogSinkTypeSyslog,
+							Address:    "udp://127.0.0.1:514",
+							BufferSize: 100,
+						},
+						{
+							Type:       LogSinkTypeJournald,
+							BufferSize: 100,
+						},
+					},
+				},
+			},
+			New: &Task{
+				LogConfig: &LogConfig{
+					MaxFiles:      1,
+					MaxFileSizeMB: 10,
+					Sinks: []*LogSink{
+						{
+							Type:       LogSinkTypeSyslog,
+							Address:    "udp://127.0.0.1:514",
+							BufferSize: 200,
+						},
+						{
+							Type:       LogSinkTypeHTTP,
+							Address:    "http://127.0.0.1:8080",
+							Header:     map[string]string{"Authorization": "token"},
+							BatchSize:  10,
+							BufferSize: 100,
+						},
+					},
+				},
+			},
+			Expected: &TaskDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "LogConfig",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Sink",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeEdited,
+										Name: "BufferSize",
+										Old:  "100",
+										New:  "200",
+									},
+								},
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Sink",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Address",
+										New:  "http://127.0.0.1:8080",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Backpressure",
+										New:  "false",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "BatchSize",
+										New:  "10",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "BatchWait",
+										New:  "0",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "BufferSize",
+										New:  "100",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Header[Authorization]",
+										New:  "token",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Type",
+										New:  "http",
+									},
+								},
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Sink",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeDeleted,
+										Name: "Backpressure",
+										Old:  "false",
+									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "BatchSize",
+										Old:  "0",
+									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "BatchWait",
+										Old:  "0",
+									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "BufferSize",
+										Old:  "100",
+									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "Type",
+										Old:  "journald",
+									},
+								},
+							},
 						},
 					},
 				},
@@ -5601,6 +6047,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "foo",
 								New:  "bar",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Provider",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "TaskName",
@@ -5745,6 +6197,10 @@ func TestTaskDiff(t *testing.T) {
 								Type: DiffTypeNone,
 								Name: "PortLabel",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Provider",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "TaskName",
@@ -6264,6 +6720,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "",
 								New:  "",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Provider",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "TaskName",
@@ -6330,6 +6792,12 @@ func TestTaskDiff(t *testing.T) {
 										Old:  "1000000000",
 										New:  "1000000000",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "Kind",
+										Old:  "",
+										New:  "",
+									},
 									{
 										Type: DiffTypeAdded,
 										Name: "Method",
@@ -7241,6 +7709,48 @@ func TestTaskDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "ShutdownHook edited",
+			Old: &Task{
+				ShutdownHook: &TaskShutdownHook{
+					Type:    TaskShutdownHookTypeExec,
+					Command: "/bin/drain",
+					Args:    []string{"-a"},
+					Timeout: 10 * time.Second,
+				},
+			},
+			New: &Task{
+				ShutdownHook: &TaskShutdownHook{
+					Type:    TaskShutdownHookTypeExec,
+					Command: "/bin/drain",
+					Args:    []string{"-a", "-b"},
+					Timeout: 20 * time.Second,
+				},
+			},
+			Expected: &TaskDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "ShutdownHook",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Args[1]",
+								Old:  "",
+								New:  "-b",
+							},
+							{
+								Type: DiffTypeEdited,
+								Name: "Timeout",
+								Old:  "10000000000",
+								New:  "20000000000",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -7347,6 +7857,10 @@ func TestServicesDiff(t *testing.T) {
 							Old:  "http",
 							New:  "https",
 						},
+						{
+							Type: DiffTypeNone,
+							Name: "Provider",
+						},
 						{
 							Type: DiffTypeNone,
 							Name: "TaskName",
@@ -7432,6 +7946,10 @@ func TestServicesDiff(t *testing.T) {
 							Name: "PortLabel",
 							New:  "http",
 						},
+						{
+							Type: DiffTypeNone,
+							Name: "Provider",
+						},
 						{
 							Type: DiffTypeNone,
 							Name: "TaskName",
@@ -7491,6 +8009,10 @@ func TestServicesDiff(t *testing.T) {
 							Name: "PortLabel",
 							New:  "https",
 						},
+						{
+							Type: DiffTypeNone,
+							Name: "Provider",
+						},
 						{
 							Type: DiffTypeNone,
 							Name: "TaskName",
@@ -7557,6 +8079,10 @@ func TestServicesDiff(t *testing.T) {
 							Old:  "http",
 							New:  "https-redirect",
 						},
+						{
+							Type: DiffTypeNone,
+							Name: "Provider",
+						},
 						{
 							Type: DiffTypeNone,
 							Name: "TaskName",
@@ -7627,6 +8153,10 @@ func TestServicesDiff(t *testing.T) {
 							Old:  "http",
 							New:  "http",
 						},
+						{
+							Type: DiffTypeNone,
+							Name: "Provider",
+						},
 						{
 							Type: DiffTypeNone,
 							Name: "TaskName",
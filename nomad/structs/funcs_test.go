@@ -607,6 +607,66 @@ func TestAllocsFit_MemoryOversubscription(t *testing.T) {
 	require.EqualValues(t, 12000, used.Flattened.Memory.MemoryMaxMB)
 }
 
+func TestAllocsFit_GroupResources(t *testing.T) {
+	n := &Node{
+		NodeResources: &NodeResources{
+			Cpu: NodeCpuResources{
+				CpuShares: 2000,
+			},
+			Memory: NodeMemoryResources{
+				MemoryMB: 2048,
+			},
+		},
+	}
+
+	// The tasks add up to more than the node but share a smaller ceiling
+	a1 := &Allocation{
+		AllocatedResources: &AllocatedResources{
+			Tasks: map[string]*AllocatedTaskResources{
+				"web": {
+					Cpu: AllocatedCpuResources{
+						CpuShares: 900,
+					},
+					Memory: AllocatedMemoryResources{
+						MemoryMB: 900,
+					},
+				},
+				"sidecar": {
+					Cpu: AllocatedCpuResources{
+						CpuShares: 900,
+					},
+					Memory: AllocatedMemoryResources{
+						MemoryMB:    500,
+						MemoryMaxMB: 900,
+					},
+				},
+			},
+			Shared: AllocatedSharedResources{
+				Cpu: AllocatedCpuResources{
+					CpuShares: 1000,
+				},
+				Memory: AllocatedMemoryResources{
+					MemoryMB: 1000,
+				},
+			},
+		},
+	}
+
+	// Should fit two allocations
+	fit, _, used, err := AllocsFit(n, []*Allocation{a1, a1}, nil, false)
+	require.NoError(t, err)
+	require.True(t, fit)
+	require.EqualValues(t, 2000, used.Flattened.Cpu.CpuShares)
+	require.EqualValues(t, 2000, used.Flattened.Memory.MemoryMB)
+	require.EqualValues(t, 2000, used.Flattened.Memory.MemoryMaxMB)
+
+	// Should not fit a third allocation
+	fit, dim, _, err := AllocsFit(n, []*Allocation{a1, a1, a1}, nil, false)
+	require.NoError(t, err)
+	require.False(t, fit)
+	require.Equal(t, "cpu", dim)
+}
+
 // COMPAT(0.11): Remove in 0.11
 func TestScoreFitBinPack_Old(t *testing.T) {
 	node := &Node{}
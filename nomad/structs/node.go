@@ -1,6 +1,7 @@
 package structs
 
 import (
+	"fmt"
 	"reflect"
 	"time"
 
@@ -298,6 +299,23 @@ func (c *CSIInfo) IsNode() bool {
 	return true
 }
 
+const (
+	// DriverCapabilityUpdateResources is the capability of a driver to apply
+	// new CPU and memory resources to running tasks.
+	DriverCapabilityUpdateResources = "update_resources"
+
+	// DriverCapabilityGroupResources is the capability of a driver to nest
+	// tasks under the cgroup of their allocation, which enforces the
+	// resources of their task group.
+	DriverCapabilityGroupResources = "group_resources"
+)
+
+// DriverCapabilityAttr returns the node attribute set to "true" when the
+// driver fingerprinted on the node has the capability.
+func DriverCapabilityAttr(driver, capability string) string {
+	return fmt.Sprintf("driver.%s.capabilities.%s", driver, capability)
+}
+
 // DriverInfo is the current state of a single driver. This is updated
 // regularly as driver health changes on the node.
 type DriverInfo struct {
@@ -0,0 +1,239 @@
+package structs
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+)
+
+const (
+	// RecommendationResourceCPU is the resource of recommendations for the
+	// CPU of a task, in MHz.
+	RecommendationResourceCPU = "CPU"
+
+	// RecommendationResourceMemory is the resource of recommendations for
+	// the memory of a task, in MB.
+	RecommendationResourceMemory = "MemoryMB"
+
+	// RecommendationMinCPU and RecommendationMinMemoryMB are the lowest
+	// values which can be recommended for each resource.
+	RecommendationMinCPU      = 1
+	RecommendationMinMemoryMB = 10
+)
+
+// Recommendation is a suggested value for a resource of a task. It is either
+// generated by the recommender of the leader from the resource usage of the
+// task, or submitted by an external tool such as an autoscaler.
+type Recommendation struct {
+	ID         string
+	Region     string
+	Namespace  string
+	JobID      string
+	JobVersion uint64
+	Group      string
+	Task       string
+
+	// Resource is either RecommendationResourceCPU or
+	// RecommendationResourceMemory.
+	Resource string
+
+	// Value is the recommended value of the resource and Current its value
+	// in the job when the recommendation was made.
+	Value   int
+	Current int
+
+	Meta  map[string]interface{}
+	Stats map[string]float64
+
+	// EnforceVersion dismisses the recommendation when the job is updated
+	// and only allows applying it to the version it was made for.
+	EnforceVersion bool
+
+	SubmitTime int64
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// Copy returns a deep copy of the recommendation.
+func (r *Recommendation) Copy() *Recommendation {
+	if r == nil {
+		return nil
+	}
+
+	nr := *r
+	nr.Meta = helper.CopyMapStringInterface(r.Meta)
+	nr.Stats = helper.CopyMapStringFloat64(r.Stats)
+	return &nr
+}
+
+// SameTarget returns whether both recommendations are for the same resource
+// of the same task.
+func (r *Recommendation) SameTarget(o *Recommendation) bool {
+	return r.Namespace == o.Namespace &&
+		r.JobID == o.JobID &&
+		r.Group == o.Group &&
+		r.Task == o.Task &&
+		r.Resource == o.Resource
+}
+
+// Validate validates the fields set by the submitter of the recommendation.
+func (r *Recommendation) Validate() error {
+	var mErr multierror.Error
+	if r.JobID == "" {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("recommendation must have a job ID"))
+	}
+	if r.Group == "" {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("recommendation must have a group"))
+	}
+	if r.Task == "" {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("recommendation must have a task"))
+	}
+
+	switch r.Resource {
+	case RecommendationResourceCPU:
+		if r.Value < RecommendationMinCPU {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("minimum CPU value is %d; got %d", RecommendationMinCPU, r.Value))
+		}
+	case RecommendationResourceMemory:
+		if r.Value < RecommendationMinMemoryMB {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("minimum MemoryMB value is %d; got %d", RecommendationMinMemoryMB, r.Value))
+		}
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("recommendation resource must be %q or %q; got %q",
+			RecommendationResourceCPU, RecommendationResourceMemory, r.Resource))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// targetTask returns the task of the job targeted by the recommendation.
+func (r *Recommendation) targetTask(job *Job) (*Task, error) {
+	tg := job.LookupTaskGroup(r.Group)
+	if tg == nil {
+		return nil, fmt.Errorf("job %q has no group %q", job.ID, r.Group)
+	}
+	task := tg.LookupTask(r.Task)
+	if task == nil {
+		return nil, fmt.Errorf("group %q of job %q has no task %q", r.Group, job.ID, r.Task)
+	}
+	if task.Resources == nil {
+		return nil, fmt.Errorf("task %q has no resources", r.Task)
+	}
+	if r.Resource == RecommendationResourceCPU && task.Resources.Cores > 0 {
+		return nil, fmt.Errorf("task %q reserves CPU cores", r.Task)
+	}
+	return task, nil
+}
+
+// CurrentValue returns the value of the resource in the job.
+func (r *Recommendation) CurrentValue(job *Job) (int, error) {
+	task, err := r.targetTask(job)
+	if err != nil {
+		return 0, err
+	}
+
+	if r.Resource == RecommendationResourceCPU {
+		return task.Resources.CPU, nil
+	}
+	return task.Resources.MemoryMB, nil
+}
+
+// ApplyTo sets the resource of the job to the recommended value.
+func (r *Recommendation) ApplyTo(job *Job) error {
+	task, err := r.targetTask(job)
+	if err != nil {
+		return err
+	}
+
+	if r.Resource == RecommendationResourceCPU {
+		task.Resources.CPU = r.Value
+	} else {
+		task.Resources.MemoryMB = r.Value
+	}
+	return nil
+}
+
+// RecommendationListRequest is used to list recommendations, optionally
+// filtered by job, group and task.
+type RecommendationListRequest struct {
+	JobID string
+	Group string
+	Task  string
+	QueryOptions
+}
+
+// RecommendationListResponse is used for a list request
+type RecommendationListResponse struct {
+	Recommendations []*Recommendation
+	QueryMeta
+}
+
+// RecommendationSpecificRequest is used to query a specific recommendation
+type RecommendationSpecificRequest struct {
+	RecommendationID string
+	QueryOptions
+}
+
+// SingleRecommendationResponse is used to return a single recommendation
+type SingleRecommendationResponse struct {
+	Recommendation *Recommendation
+	QueryMeta
+}
+
+// RecommendationUpsertRequest is used to upsert a set of recommendations
+type RecommendationUpsertRequest struct {
+	Recommendations []*Recommendation
+	WriteRequest
+}
+
+// RecommendationUpsertResponse returns the upserted recommendations
+type RecommendationUpsertResponse struct {
+	Recommendations []*Recommendation
+	WriteMeta
+}
+
+// RecommendationDeleteRequest is used to delete a set of recommendations
+type RecommendationDeleteRequest struct {
+	Recommendations []string
+	WriteRequest
+}
+
+// RecommendationApplyRequest is used to apply and/or dismiss a set of
+// recommendations
+type RecommendationApplyRequest struct {
+	Apply          []string
+	Dismiss        []string
+	PolicyOverride bool
+	WriteRequest
+}
+
+// RecommendationApplyResponse returns the jobs updated by applying
+// recommendations and the errors of the jobs which could not be updated
+type RecommendationApplyResponse struct {
+	UpdatedJobs []*SingleRecommendationApplyResult
+	Errors      []*SingleRecommendationApplyError
+	WriteMeta
+}
+
+// SingleRecommendationApplyResult is the registration of a job updated by
+// applying recommendations
+type SingleRecommendationApplyResult struct {
+	Namespace       string
+	JobID           string
+	JobModifyIndex  uint64
+	EvalID          string
+	EvalCreateIndex uint64
+	Warnings        string
+	Recommendations []string
+}
+
+// SingleRecommendationApplyError is the error of a job which could not be
+// updated by applying recommendations
+type SingleRecommendationApplyError struct {
+	Namespace       string
+	JobID           string
+	Recommendations []string
+	Error           string
+}
@@ -29,6 +29,12 @@ const (
 	ServiceCheckScript = "script"
 	ServiceCheckGRPC   = "grpc"
 
+	// ServiceCheckReadiness checks only determine whether the service is
+	// healthy in the catalog and for deployments, while ServiceCheckLiveness
+	// checks only restart the task. Checks without a kind are both.
+	ServiceCheckReadiness = "readiness"
+	ServiceCheckLiveness  = "liveness"
+
 	// minCheckInterval is the minimum check interval permitted.  Consul
 	// currently has its MinInterval set to 1s.  Mirror that here for
 	// consistency.
@@ -64,6 +70,7 @@ type ServiceCheck struct {
 	FailuresBeforeCritical int                 // Number of consecutive failures required before considered unhealthy
 	Body                   string              // Body to use in HTTP check
 	OnUpdate               string
+	Kind                   string // Kind of the check - readiness, liveness or both if empty
 }
 
 // Copy the stanza recursively. Returns nil if nil.
@@ -178,6 +185,10 @@ func (sc *ServiceCheck) Equals(o *ServiceCheck) bool {
 		return false
 	}
 
+	if sc.Kind != o.Kind {
+		return false
+	}
+
 	return true
 }
 
@@ -306,8 +317,22 @@ func (sc *ServiceCheck) validate() error {
 		return fmt.Errorf("failures_before_critical not supported for check of type %q", sc.Type)
 	}
 
-	// Check that CheckRestart and OnUpdate do not conflict
-	if sc.CheckRestart != nil {
+	// Validate Kind
+	switch sc.Kind {
+	case "", ServiceCheckReadiness, ServiceCheckLiveness:
+		// OK
+	default:
+		return fmt.Errorf("kind must be %q, %q or empty; got %q", ServiceCheckReadiness, ServiceCheckLiveness, sc.Kind)
+	}
+
+	// Readiness checks never restart the task
+	if sc.Kind == ServiceCheckReadiness && sc.CheckRestart != nil {
+		return fmt.Errorf("check_restart is not supported by %s checks", ServiceCheckReadiness)
+	}
+
+	// Check that CheckRestart and OnUpdate do not conflict. OnUpdate does not
+	// apply to liveness checks as they are ignored by deployments.
+	if sc.CheckRestart != nil && sc.Kind != ServiceCheckLiveness {
 		// CheckRestart and OnUpdate Ignore are incompatible If OnUpdate treats
 		// an error has healthy, and the deployment succeeds followed by check
 		// restart restarting erroring checks, the deployment is left in an odd
@@ -325,6 +350,21 @@ func (sc *ServiceCheck) validate() error {
 	return sc.CheckRestart.Validate()
 }
 
+// validateNomad validates a check of a service using the nomad provider, which
+// supports a subset of the checks run by Consul.
+func (sc *ServiceCheck) validateNomad() error {
+	switch strings.ToLower(sc.Type) {
+	case ServiceCheckHTTP, ServiceCheckTCP, ServiceCheckScript:
+	default:
+		return fmt.Errorf("%s checks are not supported by the %q provider", sc.Type, ServiceProviderNomad)
+	}
+
+	if sc.Expose {
+		return fmt.Errorf("expose is not supported by the %q provider", ServiceProviderNomad)
+	}
+	return nil
+}
+
 // RequiresPort returns whether the service check requires the task has a port.
 func (sc *ServiceCheck) RequiresPort() bool {
 	switch sc.Type {
@@ -338,7 +378,18 @@ func (sc *ServiceCheck) RequiresPort() bool {
 // TriggersRestarts returns true if this check should be watched and trigger a restart
 // on failure.
 func (sc *ServiceCheck) TriggersRestarts() bool {
-	return sc.CheckRestart != nil && sc.CheckRestart.Limit > 0
+	return sc.IsLiveness() && sc.CheckRestart != nil && sc.CheckRestart.Limit > 0
+}
+
+// IsReadiness returns true if the status of this check determines the health
+// of its service for deployments.
+func (sc *ServiceCheck) IsReadiness() bool {
+	return sc.Kind != ServiceCheckLiveness
+}
+
+// IsLiveness returns true if this check may restart its task on failure.
+func (sc *ServiceCheck) IsLiveness() bool {
+	return sc.Kind != ServiceCheckReadiness
 }
 
 // Hash all ServiceCheck fields and the check's corresponding service ID to
@@ -415,6 +466,60 @@ func hashHeader(h hash.Hash, m map[string][]string) {
 	}
 }
 
+const (
+	CheckStatusPassing  = "passing"
+	CheckStatusWarning  = "warning"
+	CheckStatusCritical = "critical"
+)
+
+// CheckResult is the latest result of a check of a service using the nomad
+// provider, run by the client of the allocation.
+type CheckResult struct {
+	// ID of the check, unique within the allocation
+	ID string
+
+	// Service and Check are the names of the service and of the check
+	Service string
+	Check   string
+
+	// Group and Task the service is defined in. Task is empty for group
+	// services.
+	Group string
+	Task  string
+
+	// Type of the check: http, tcp or script
+	Type string
+
+	// OnUpdate is how the status of the check is evaluated during updates
+	OnUpdate string
+
+	// Kind of the check: readiness, liveness or both if empty
+	Kind string
+
+	// Status of the check: passing, warning or critical
+	Status string
+
+	// StatusCode is the HTTP status code of http checks and the exit code of
+	// script checks
+	StatusCode int
+
+	// Output of the last run of the check
+	Output string
+
+	// Timestamp is the time of the last run of the check
+	Timestamp time.Time
+}
+
+// Copy returns a copy of the result. Returns nil if nil.
+func (r *CheckResult) Copy() *CheckResult {
+	if r == nil {
+		return nil
+	}
+	nr := new(CheckResult)
+	*nr = *r
+	return nr
+}
+
 const (
 	AddressModeAuto   = "auto"
 	AddressModeHost   = "host"
@@ -468,8 +573,18 @@ type Service struct {
 	// OnUpdate Specifies how the service and its checks should be evaluated
 	// during an update
 	OnUpdate string
+
+	// Provider of the service: Consul registers the service and runs its
+	// checks, while Nomad runs the checks on the client and reports their
+	// results with the allocation.
+	Provider string
 }
 
+const (
+	ServiceProviderConsul = "consul"
+	ServiceProviderNomad  = "nomad"
+)
+
 const (
 	OnUpdateRequireHealthy = "require_healthy"
 	OnUpdateIgnoreWarn     = "ignore_warnings"
@@ -533,6 +648,10 @@ func (s *Service) Canonicalize(job string, taskGroup string, task string) {
 	if s.Namespace == "" {
 		s.Namespace = "default"
 	}
+
+	if s.Provider == "" {
+		s.Provider = ServiceProviderConsul
+	}
 }
 
 // Validate checks if the Service definition is valid
@@ -563,6 +682,17 @@ func (s *Service) Validate() error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("Service on_update must be %q, %q, or %q; not %q", OnUpdateRequireHealthy, OnUpdateIgnoreWarn, OnUpdateIgnore, s.OnUpdate))
 	}
 
+	switch s.Provider {
+	case "", ServiceProviderConsul:
+		// OK
+	case ServiceProviderNomad:
+		if s.Connect != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Service %s is Connect enabled and must use the %q provider", s.Name, ServiceProviderConsul))
+		}
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Service provider must be %q or %q; not %q", ServiceProviderConsul, ServiceProviderNomad, s.Provider))
+	}
+
 	// check checks
 	for _, c := range s.Checks {
 		if s.PortLabel == "" && c.PortLabel == "" && c.RequiresPort() {
@@ -580,6 +710,13 @@ func (s *Service) Validate() error {
 
 		if err := c.validate(); err != nil {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("Check %s invalid: %v", c.Name, err))
+			continue
+		}
+
+		if s.Provider == ServiceProviderNomad {
+			if err := c.validateNomad(); err != nil {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Check %s invalid: %v", c.Name, err))
+			}
 		}
 	}
 
@@ -699,6 +836,10 @@ func (s *Service) Equals(o *Service) bool {
 		return false
 	}
 
+	if s.Provider != o.Provider {
+		return false
+	}
+
 	if !helper.CompareSliceSetString(s.CanaryTags, o.CanaryTags) {
 		return false
 	}
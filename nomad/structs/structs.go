@@ -16,7 +16,9 @@ import (
 	"hash/crc32"
 	"math"
 	"net"
+	"net/url"
 	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"sort"
@@ -105,6 +107,10 @@ const (
 	OneTimeTokenUpsertRequestType                MessageType = 44
 	OneTimeTokenDeleteRequestType                MessageType = 45
 	OneTimeTokenExpireRequestType                MessageType = 46
+	JobDispatchReleaseRequestType                MessageType = 47
+	JobDependencyReleaseRequestType              MessageType = 48
+	RecommendationUpsertRequestType              MessageType = 49
+	RecommendationDeleteRequestType              MessageType = 50
 
 	// Namespace types were moved from enterprise and therefore start at 64
 	NamespaceUpsertRequestType MessageType = 64
@@ -756,6 +762,26 @@ type JobDispatchRequest struct {
 	WriteRequest
 }
 
+// JobDispatchReleaseRequest is used by the leader to release dispatched jobs
+// that were queued because their parent reached its max_running limit.
+type JobDispatchReleaseRequest struct {
+	// Evals are the evaluations to create for the released jobs. The jobs to
+	// release are identified by each evaluation's namespace and job ID.
+	Evals []*Evaluation
+
+	WriteRequest
+}
+
+// JobDependencyReleaseRequest is used by the leader to release jobs that were
+// blocked on their dependencies once the dependencies are satisfied.
+type JobDependencyReleaseRequest struct {
+	// Evals are the evaluations to create for the released jobs. The jobs to
+	// release are identified by each evaluation's namespace and job ID.
+	Evals []*Evaluation
+
+	WriteRequest
+}
+
 // JobValidateRequest is used to validate a job
 type JobValidateRequest struct {
 	Job *Job
@@ -1417,6 +1443,12 @@ type JobDispatchResponse struct {
 	EvalID          string
 	EvalCreateIndex uint64
 	JobCreateIndex  uint64
+
+	// Queued is set if the dispatched job was queued because the parameterized
+	// job reached its max_running limit. No evaluation is created until the
+	// job is released from the queue.
+	Queued bool
+
 	WriteMeta
 }
 
@@ -3567,6 +3599,17 @@ func (a *AllocatedResources) Comparable() *ComparableResources {
 	prestartSidecarTasks.Add(prestartEphemeralTasks)
 	c.Flattened.Add(prestartSidecarTasks)
 
+	// Account the ceiling of task groups with group resources instead of
+	// the sum of their tasks, which validation only allows for drivers
+	// nesting the tasks under the allocation cgroup
+	if a.Shared.Cpu.CpuShares > 0 {
+		c.Flattened.Cpu.CpuShares = a.Shared.Cpu.CpuShares
+	}
+	if a.Shared.Memory.MemoryMB > 0 {
+		c.Flattened.Memory.MemoryMB = a.Shared.Memory.MemoryMB
+		c.Flattened.Memory.MemoryMaxMB = a.Shared.Memory.MemoryMB
+	}
+
 	// Add network resources that are at the task group level
 	for _, network := range a.Shared.Networks {
 		c.Flattened.Add(&AllocatedTaskResources{
@@ -3737,6 +3780,11 @@ type AllocatedSharedResources struct {
 	Networks Networks
 	DiskMB   int64
 	Ports    AllocatedPorts
+
+	// Cpu and Memory are the CPU shares and memory ceiling shared by the
+	// tasks of a task group with group resources, and are empty otherwise.
+	Cpu    AllocatedCpuResources
+	Memory AllocatedMemoryResources
 }
 
 func (a AllocatedSharedResources) Copy() AllocatedSharedResources {
@@ -3744,6 +3792,8 @@ func (a AllocatedSharedResources) Copy() AllocatedSharedResources {
 		Networks: a.Networks.Copy(),
 		DiskMB:   a.DiskMB,
 		Ports:    a.Ports,
+		Cpu:      a.Cpu,
+		Memory:   a.Memory,
 	}
 }
 
@@ -4116,6 +4166,15 @@ type Job struct {
 	// for dispatching.
 	ParameterizedJob *ParameterizedJobConfig
 
+	// DependsOn lists jobs in the same namespace that must reach a condition
+	// before this job is evaluated.
+	DependsOn []*JobDependency
+
+	// DependenciesBlocked is set when the job was registered before its
+	// dependencies were satisfied. No evaluation is created for a blocked job
+	// until the leader releases it.
+	DependenciesBlocked bool
+
 	// Dispatched is used to identify if the Job has been dispatched from a
 	// parameterized job.
 	Dispatched bool
@@ -4124,6 +4183,11 @@ type Job struct {
 	// non-terminal siblings which have the same token value.
 	DispatchIdempotencyToken string
 
+	// DispatchQueued is set on a dispatched job that was held back because
+	// its parent had reached its max_running limit. No evaluation is created
+	// for a queued job until the leader releases it.
+	DispatchQueued bool
+
 	// Payload is the payload supplied when the job was dispatched.
 	Payload []byte
 
@@ -4248,6 +4312,10 @@ func (j *Job) Canonicalize() {
 	if j.Periodic != nil {
 		j.Periodic.Canonicalize()
 	}
+
+	for _, dep := range j.DependsOn {
+		dep.Canonicalize()
+	}
 }
 
 // Copy returns a deep copy of the Job. It is expected that callers use recover.
@@ -4274,6 +4342,7 @@ func (j *Job) Copy() *Job {
 	nj.Periodic = nj.Periodic.Copy()
 	nj.Meta = helper.CopyMapStringString(nj.Meta)
 	nj.ParameterizedJob = nj.ParameterizedJob.Copy()
+	nj.DependsOn = CopySliceJobDependencies(nj.DependsOn)
 	return nj
 }
 
@@ -4423,6 +4492,28 @@ func (j *Job) Validate() error {
 		}
 	}
 
+	if len(j.DependsOn) > 0 {
+		if j.IsPeriodic() || j.IsParameterized() {
+			mErr.Errors = append(mErr.Errors, errors.New("Periodic and parameterized jobs can't depend on other jobs"))
+		}
+
+		upstreams := make(map[string]struct{}, len(j.DependsOn))
+		for idx, dep := range j.DependsOn {
+			if err := dep.Validate(); err != nil {
+				outer := fmt.Errorf("Dependency %d validation failed: %v", idx+1, err)
+				mErr.Errors = append(mErr.Errors, outer)
+				continue
+			}
+			if dep.JobID == j.ID {
+				mErr.Errors = append(mErr.Errors, errors.New("Job can't depend on itself"))
+			}
+			if _, ok := upstreams[dep.JobID]; ok {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Job depends on %q more than once", dep.JobID))
+			}
+			upstreams[dep.JobID] = struct{}{}
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -4752,6 +4843,10 @@ type JobChildrenSummary struct {
 	Pending int64
 	Running int64
 	Dead    int64
+
+	// Queued is the number of dispatched children waiting for the parent's
+	// max_running limit to allow them to start.
+	Queued int64
 }
 
 // Copy returns a new copy of a JobChildrenSummary
@@ -5301,6 +5396,73 @@ type PeriodicLaunch struct {
 	ModifyIndex uint64
 }
 
+const (
+	// JobDependencyConditionComplete is satisfied once the upstream job has
+	// finished, regardless of the outcome of its allocations.
+	JobDependencyConditionComplete = "complete"
+
+	// JobDependencyConditionSuccess is satisfied once the upstream job has
+	// finished and all of its latest allocations completed successfully.
+	JobDependencyConditionSuccess = "success"
+)
+
+// JobDependency is a dependency of a job on another job in the same
+// namespace. The dependent job is not evaluated until the condition is met.
+type JobDependency struct {
+	// JobID is the ID of the upstream job
+	JobID string
+
+	// Condition is the state the upstream job must reach
+	Condition string
+}
+
+func (d *JobDependency) Copy() *JobDependency {
+	if d == nil {
+		return nil
+	}
+	nd := new(JobDependency)
+	*nd = *d
+	return nd
+}
+
+func (d *JobDependency) Canonicalize() {
+	if d.Condition == "" {
+		d.Condition = JobDependencyConditionSuccess
+	}
+}
+
+func (d *JobDependency) Validate() error {
+	var mErr multierror.Error
+	if d.JobID == "" {
+		_ = multierror.Append(&mErr, errors.New("Missing upstream job ID"))
+	}
+
+	switch d.Condition {
+	case JobDependencyConditionComplete, JobDependencyConditionSuccess:
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Unknown condition %q", d.Condition))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+func (d *JobDependency) String() string {
+	return fmt.Sprintf("%s (%s)", d.JobID, d.Condition)
+}
+
+func CopySliceJobDependencies(s []*JobDependency) []*JobDependency {
+	l := len(s)
+	if l == 0 {
+		return nil
+	}
+
+	c := make([]*JobDependency, l)
+	for i, v := range s {
+		c[i] = v.Copy()
+	}
+	return c
+}
+
 const (
 	DispatchPayloadForbidden = "forbidden"
 	DispatchPayloadOptional  = "optional"
@@ -5321,6 +5483,14 @@ type ParameterizedJobConfig struct {
 
 	// MetaOptional is metadata keys that may be specified by the dispatcher
 	MetaOptional []string
+
+	// MaxRunning is the maximum number of dispatched children that may be
+	// pending or running at once. Dispatches beyond the limit are queued and
+	// released as children complete. Zero means no limit.
+	MaxRunning int
+
+	// Rate limits how quickly children can be dispatched.
+	Rate *DispatchRateConfig
 }
 
 func (d *ParameterizedJobConfig) Validate() error {
@@ -5337,6 +5507,16 @@ func (d *ParameterizedJobConfig) Validate() error {
 		_ = multierror.Append(&mErr, fmt.Errorf("Required and optional meta keys should be disjoint. Following keys exist in both: %v", offending))
 	}
 
+	if d.MaxRunning < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Max running must be non-negative: %d", d.MaxRunning))
+	}
+
+	if d.Rate != nil {
+		if err := d.Rate.Validate(); err != nil {
+			_ = multierror.Append(&mErr, multierror.Prefix(err, "rate ->"))
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -5354,9 +5534,41 @@ func (d *ParameterizedJobConfig) Copy() *ParameterizedJobConfig {
 	*nd = *d
 	nd.MetaOptional = helper.CopySliceString(nd.MetaOptional)
 	nd.MetaRequired = helper.CopySliceString(nd.MetaRequired)
+	nd.Rate = nd.Rate.Copy()
 	return nd
 }
 
+// DispatchRateConfig configures a token bucket that limits how quickly a
+// parameterized job can be dispatched.
+type DispatchRateConfig struct {
+	// Limit is the number of dispatches per second added to the bucket.
+	Limit float64
+
+	// Burst is the size of the bucket, and so the number of dispatches that
+	// may be made at once after a period of inactivity.
+	Burst int
+}
+
+func (r *DispatchRateConfig) Validate() error {
+	var mErr multierror.Error
+	if r.Limit <= 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Limit must be greater than zero: %v", r.Limit))
+	}
+	if r.Burst < 1 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Burst must be at least one: %d", r.Burst))
+	}
+	return mErr.ErrorOrNil()
+}
+
+func (r *DispatchRateConfig) Copy() *DispatchRateConfig {
+	if r == nil {
+		return nil
+	}
+	nr := new(DispatchRateConfig)
+	*nr = *r
+	return nr
+}
+
 // DispatchedID returns an ID appropriate for a job dispatched against a
 // particular parameterized job
 func DispatchedID(templateID string, t time.Time) string {
@@ -5397,6 +5609,92 @@ const (
 	TaskLifecycleHookPoststop  = "poststop"
 )
 
+const (
+	TaskShutdownHookTypeHTTP = "http"
+	TaskShutdownHookTypeExec = "exec"
+)
+
+// TaskShutdownHook is an action run against a running task before it is
+// killed. It either sends an HTTP request to one of the task's ports or
+// executes a command inside the task.
+type TaskShutdownHook struct {
+	// Type is the kind of action, either "http" or "exec"
+	Type string
+
+	// PortLabel is the label of the port the HTTP request is sent to
+	PortLabel string
+
+	// Path, Method and Protocol configure the HTTP request
+	Path     string
+	Method   string
+	Protocol string
+
+	// Command and Args are executed inside the task
+	Command string
+	Args    []string
+
+	// Timeout is how long to wait for the action to complete before
+	// proceeding with killing the task
+	Timeout time.Duration
+}
+
+func (h *TaskShutdownHook) Copy() *TaskShutdownHook {
+	if h == nil {
+		return nil
+	}
+	nh := new(TaskShutdownHook)
+	*nh = *h
+	nh.Args = helper.CopySliceString(nh.Args)
+	return nh
+}
+
+func (h *TaskShutdownHook) Validate() error {
+	if h == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	switch h.Type {
+	case TaskShutdownHookTypeHTTP:
+		if h.PortLabel == "" {
+			_ = multierror.Append(&mErr, errors.New("HTTP shutdown hook must specify a port"))
+		}
+		if !strings.HasPrefix(h.Path, "/") {
+			_ = multierror.Append(&mErr, fmt.Errorf("HTTP shutdown hook path must be absolute (got %q)", h.Path))
+		}
+		switch h.Protocol {
+		case "http", "https":
+		default:
+			_ = multierror.Append(&mErr, fmt.Errorf("Unsupported protocol %q", h.Protocol))
+		}
+		if h.Method == "" {
+			_ = multierror.Append(&mErr, errors.New("HTTP shutdown hook must specify a method"))
+		}
+	case TaskShutdownHookTypeExec:
+		if h.Command == "" {
+			_ = multierror.Append(&mErr, errors.New("Exec shutdown hook must specify a command"))
+		}
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Unsupported shutdown hook type %q", h.Type))
+	}
+
+	if h.Timeout <= 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Timeout must be greater than zero (got %v)", h.Timeout))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+const (
+	// TaskDependencyConditionSuccess is satisfied once the tasks a task
+	// depends on have finished successfully.
+	TaskDependencyConditionSuccess = "success"
+
+	// TaskDependencyConditionComplete is satisfied once the tasks a task
+	// depends on have finished, whether they failed or not.
+	TaskDependencyConditionComplete = "complete"
+)
+
 type TaskLifecycleConfig struct {
 	Hook    string
 	Sidecar bool
@@ -5478,6 +5776,11 @@ const (
 	// restart policy.
 	RestartPolicyMinInterval = 5 * time.Second
 
+	// RestartPolicyDefaultMaxDelayFactor is the multiple of the delay used as
+	// the max delay of a restart policy with a growing delay function when it
+	// isn't set.
+	RestartPolicyDefaultMaxDelayFactor = 10
+
 	// ReasonWithinPolicy describes restart events that are within policy
 	ReasonWithinPolicy = "Restart within policy"
 )
@@ -5765,6 +6068,15 @@ type RestartPolicy struct {
 	// Mode controls what happens when the task restarts more than attempt times
 	// in an interval.
 	Mode string
+
+	// DelayFunction determines how the delay grows on subsequent restarts
+	// within an interval. Valid values are "constant", "exponential" and
+	// "fibonacci". An empty value behaves as "constant".
+	DelayFunction string
+
+	// MaxDelay is an upper bound on the delay when the delay function isn't
+	// constant.
+	MaxDelay time.Duration
 }
 
 func (r *RestartPolicy) Copy() *RestartPolicy {
@@ -5792,13 +6104,88 @@ func (r *RestartPolicy) Validate() error {
 	if r.Interval.Nanoseconds() < RestartPolicyMinInterval.Nanoseconds() {
 		_ = multierror.Append(&mErr, fmt.Errorf("Interval can not be less than %v (got %v)", RestartPolicyMinInterval, r.Interval))
 	}
-	if time.Duration(r.Attempts)*r.Delay > r.Interval {
+
+	if r.DelayFunction != "" && !isValidDelayFunction(r.DelayFunction) {
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid delay function %q, must be one of %q", r.DelayFunction, RescheduleDelayFunctions))
+		return mErr.ErrorOrNil()
+	}
+
+	if !r.hasBackoff() {
+		if time.Duration(r.Attempts)*r.Delay > r.Interval {
+			_ = multierror.Append(&mErr,
+				fmt.Errorf("Nomad can't restart the TaskGroup %v times in an interval of %v with a delay of %v", r.Attempts, r.Interval, r.Delay))
+		}
+		return mErr.ErrorOrNil()
+	}
+
+	if r.MaxDelay < r.Delay {
+		_ = multierror.Append(&mErr, fmt.Errorf("Max Delay cannot be less than Delay %v (got %v)", r.Delay, r.MaxDelay))
+		return mErr.ErrorOrNil()
+	}
+
+	var total time.Duration
+	for attempt := 1; attempt <= r.Attempts; attempt++ {
+		total += r.NextDelay(attempt)
+	}
+	if total > r.Interval {
 		_ = multierror.Append(&mErr,
-			fmt.Errorf("Nomad can't restart the TaskGroup %v times in an interval of %v with a delay of %v", r.Attempts, r.Interval, r.Delay))
+			fmt.Errorf("Nomad can't restart the TaskGroup %v times in an interval of %v with an initial delay of %v, "+
+				"delay function %q, and delay ceiling %v", r.Attempts, r.Interval, r.Delay, r.DelayFunction, r.MaxDelay))
 	}
 	return mErr.ErrorOrNil()
 }
 
+// Canonicalize defaults the max delay of a policy with a growing delay
+// function.
+func (r *RestartPolicy) Canonicalize() {
+	if r == nil {
+		return
+	}
+	if r.hasBackoff() && r.MaxDelay == 0 {
+		r.MaxDelay = RestartPolicyDefaultMaxDelayFactor * r.Delay
+	}
+}
+
+// hasBackoff returns true if the delay grows on subsequent restarts.
+func (r *RestartPolicy) hasBackoff() bool {
+	return r.DelayFunction != "" && r.DelayFunction != "constant"
+}
+
+// NextDelay returns the delay before the given restart attempt within an
+// interval, starting at one. It is calculated according to the delay function
+// and bounded by the max delay.
+func (r *RestartPolicy) NextDelay(attempt int) time.Duration {
+	if !r.hasBackoff() || attempt <= 1 {
+		return r.Delay
+	}
+
+	prev, delay := time.Duration(0), r.Delay
+	for i := 1; i < attempt && delay < r.MaxDelay; i++ {
+		// Growing past the max delay is avoided as it could overflow
+		switch r.DelayFunction {
+		case "exponential":
+			if delay > r.MaxDelay/2 {
+				delay = r.MaxDelay
+			} else {
+				delay *= 2
+			}
+		case "fibonacci":
+			if prev == 0 {
+				prev = delay
+			} else if prev > r.MaxDelay-delay {
+				delay = r.MaxDelay
+			} else {
+				prev, delay = delay, prev+delay
+			}
+		}
+	}
+
+	if delay > r.MaxDelay {
+		delay = r.MaxDelay
+	}
+	return delay
+}
+
 func NewRestartPolicy(jobType string) *RestartPolicy {
 	switch jobType {
 	case JobTypeService, JobTypeSystem:
@@ -6080,6 +6467,10 @@ func (m *MigrateStrategy) Validate() error {
 	return mErr.ErrorOrNil()
 }
 
+// maxDNSLabelLength is the maximum length of a label of a DNS name, such as the
+// hostname of an allocation of a stateful task group.
+const maxDNSLabelLength = 63
+
 // TaskGroup is an atomic unit of placement. Each task group belongs to
 // a job and may contain any number of tasks. A task group support running
 // in many replicas using the same configuration..
@@ -6149,6 +6540,19 @@ type TaskGroup struct {
 	// StopAfterClientDisconnect, if set, configures the client to stop the task group
 	// after this duration since the last known good heartbeat
 	StopAfterClientDisconnect *time.Duration
+
+	// Stateful gives each allocation of the group a stable identity: an
+	// index is used by at most one allocation at a time, failed allocations
+	// are replaced with the same index, updates are rolled one allocation
+	// at a time from the highest index and each allocation has a hostname
+	// derived from its index.
+	Stateful bool
+
+	// Resources is the CPU shares and memory ceiling shared by the tasks of
+	// the group. When set, the tasks are nested under a cgroup of the
+	// allocation and the scheduler accounts the group resources instead of
+	// the sum of the tasks.
+	Resources *TaskGroupResources
 }
 
 func (tg *TaskGroup) Copy() *TaskGroup {
@@ -6166,6 +6570,7 @@ func (tg *TaskGroup) Copy() *TaskGroup {
 	ntg.Volumes = CopyMapVolumeRequest(ntg.Volumes)
 	ntg.Scaling = ntg.Scaling.Copy()
 	ntg.Consul = ntg.Consul.Copy()
+	ntg.Resources = ntg.Resources.Copy()
 
 	// Copy the network objects
 	if tg.Networks != nil {
@@ -6220,6 +6625,7 @@ func (tg *TaskGroup) Canonicalize(job *Job) {
 	if tg.RestartPolicy == nil {
 		tg.RestartPolicy = NewRestartPolicy(job.Type)
 	}
+	tg.RestartPolicy.Canonicalize()
 
 	if tg.ReschedulePolicy == nil {
 		tg.ReschedulePolicy = NewReschedulePolicy(job.Type)
@@ -6342,6 +6748,26 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	// Validate the stateful mode
+	if tg.Stateful {
+		if j.Type != JobTypeService {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Job type %q does not allow stateful task groups", j.Type))
+		}
+		if tg.Update.IsEmpty() {
+			mErr.Errors = append(mErr.Errors, errors.New("Stateful task groups require an update block"))
+		} else if tg.Update.Canary != 0 {
+			mErr.Errors = append(mErr.Errors, errors.New("Stateful task groups can not have canaries"))
+		}
+	}
+
+	// Validate the group resources
+	if tg.Resources != nil {
+		if err := tg.validateResources(); err != nil {
+			outer := fmt.Errorf("Task group resources validation failed: %v", err)
+			mErr.Errors = append(mErr.Errors, outer)
+		}
+	}
+
 	// Validate the migration strategy
 	switch j.Type {
 	case JobTypeService:
@@ -6407,6 +6833,12 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		mErr.Errors = append(mErr.Errors, outer)
 	}
 
+	// Validate the dependencies between tasks
+	if err := tg.validateTaskDependencies(j); err != nil {
+		outer := fmt.Errorf("Task group task dependency validation failed: %v", err)
+		mErr.Errors = append(mErr.Errors, outer)
+	}
+
 	// Validate the scaling policy
 	if err := tg.validateScalingPolicy(j); err != nil {
 		outer := fmt.Errorf("Task group scaling policy validation failed: %v", err)
@@ -6436,6 +6868,120 @@ func (tg *TaskGroup) Validate(j *Job) error {
 	return mErr.ErrorOrNil()
 }
 
+// validateTaskDependencies checks that the dependencies between the tasks of
+// the group form a directed acyclic graph of main tasks.
+func (tg *TaskGroup) validateTaskDependencies(j *Job) error {
+	var mErr multierror.Error
+
+	tasks := make(map[string]*Task, len(tg.Tasks))
+	hasDependencies := false
+	for _, task := range tg.Tasks {
+		tasks[task.Name] = task
+		if len(task.DependsOn) > 0 {
+			hasDependencies = true
+		}
+	}
+	if !hasDependencies {
+		return nil
+	}
+
+	switch j.Type {
+	case JobTypeBatch, JobTypeSysBatch:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Job type %q does not allow task dependencies", j.Type))
+	}
+
+	for _, task := range tg.Tasks {
+		if len(task.DependsOn) == 0 {
+			continue
+		}
+		if task.Lifecycle != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Task %s has a lifecycle and can't depend on other tasks", task.Name))
+		}
+
+		seen := make(map[string]struct{}, len(task.DependsOn))
+		for _, dep := range task.DependsOn {
+			if _, ok := seen[dep]; ok {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Task %s depends on %s more than once", task.Name, dep))
+				continue
+			}
+			seen[dep] = struct{}{}
+
+			upstream, ok := tasks[dep]
+			switch {
+			case dep == task.Name:
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Task %s can't depend on itself", task.Name))
+			case !ok:
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Task %s depends on undefined task %s", task.Name, dep))
+			case upstream.Lifecycle != nil:
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Task %s can't depend on task %s which has a lifecycle", task.Name, dep))
+			}
+		}
+	}
+
+	if cycle := taskDependencyCycle(tg.Tasks, tasks); len(cycle) > 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Task dependencies form a cycle: %s", strings.Join(cycle, " -> ")))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// taskDependencyCycle returns the names of the tasks forming a dependency
+// cycle, starting and ending with the same task, or nil if there are none.
+func taskDependencyCycle(ordered []*Task, tasks map[string]*Task) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int, len(tasks))
+	var path []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			// Trim the path to the start of the cycle
+			for i, n := range path {
+				if n == name {
+					return append(append([]string{}, path[i:]...), name)
+				}
+			}
+			return nil
+		}
+
+		task, ok := tasks[name]
+		if !ok {
+			return nil
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range task.DependsOn {
+			// Self dependencies are reported separately
+			if dep == name {
+				continue
+			}
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+
+	for _, task := range ordered {
+		if cycle := visit(task.Name); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
 func (tg *TaskGroup) validateNetworks() error {
 	var mErr multierror.Error
 	portLabels := make(map[string]string)
@@ -6565,6 +7111,10 @@ func (tg *TaskGroup) validateServices() error {
 		}
 
 		for _, check := range service.Checks {
+			if service.Provider == ServiceProviderNomad && check.Type == ServiceCheckScript {
+				mErr.Errors = append(mErr.Errors,
+					fmt.Errorf("Check %s invalid: script checks of group services are not supported by the %q provider", check.Name, ServiceProviderNomad))
+			}
 			if check.TaskName != "" {
 				if check.Type != ServiceCheckScript && check.Type != ServiceCheckGRPC {
 					mErr.Errors = append(mErr.Errors,
@@ -6704,6 +7254,61 @@ func (tg *TaskGroup) UsesConnectGateway() bool {
 	return false
 }
 
+// validateResources validates the group resources and checks that each
+// task fits within them.
+func (tg *TaskGroup) validateResources() error {
+	var mErr multierror.Error
+	if err := tg.Resources.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
+	for _, task := range tg.Tasks {
+		r := task.Resources
+		if r == nil {
+			continue
+		}
+		if r.Cores > 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Task %q can not reserve cores in a group with resources", task.Name))
+		}
+		if r.CPU > tg.Resources.CPU {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Task %q CPU (%d) exceeds the group CPU (%d)", task.Name, r.CPU, tg.Resources.CPU))
+		}
+		if r.MemoryMB > tg.Resources.MemoryMB {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Task %q memory (%d) exceeds the group memory (%d)", task.Name, r.MemoryMB, tg.Resources.MemoryMB))
+		}
+		if r.MemoryMaxMB > tg.Resources.MemoryMB {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Task %q memory_max (%d) exceeds the group memory (%d)", task.Name, r.MemoryMaxMB, tg.Resources.MemoryMB))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// StatefulHostname returns the hostname of the allocation of a stateful task
+// group with the given index. It is the group name, reduced to the characters
+// allowed in a DNS label, followed by the index.
+func (tg *TaskGroup) StatefulHostname(index uint) string {
+	suffix := fmt.Sprintf("-%d", index)
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(tg.Name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+
+	name := strings.Trim(b.String(), "-")
+	if max := maxDNSLabelLength - len(suffix); len(name) > max {
+		name = strings.TrimRight(name[:max], "-")
+	}
+	if name == "" {
+		name = "alloc"
+	}
+	return name + suffix
+}
+
 func (tg *TaskGroup) GoString() string {
 	return fmt.Sprintf("*%#v", *tg)
 }
@@ -6773,6 +7378,32 @@ const (
 type LogConfig struct {
 	MaxFiles      int
 	MaxFileSizeMB int
+
+	// Sinks ship the task's logs to external destinations in addition to
+	// the rotated log files.
+	Sinks []*LogSink
+
+	// Format is either text or json. JSON records are tagged with the
+	// task's identity, and can be filtered by level and by expression when
+	// read.
+	Format string
+
+	// Level is the level below which JSON records are dropped before being
+	// written.
+	Level string
+
+	// RotateEvery rotates the log files once they are older than it, in
+	// addition to when they reach MaxFileSizeMB.
+	RotateEvery time.Duration
+
+	// Compression is the algorithm rotated log files are compressed with,
+	// one of none, gzip or zstd.
+	Compression string
+
+	// MaxTotalSizeMB caps the size of the log files of each stream on disk,
+	// counting compressed files by their compressed size. Older files are
+	// removed once it is exceeded.
+	MaxTotalSizeMB int
 }
 
 func (l *LogConfig) Equals(o *LogConfig) bool {
@@ -6788,6 +7419,23 @@ func (l *LogConfig) Equals(o *LogConfig) bool {
 		return false
 	}
 
+	if l.Format != o.Format || l.Level != o.Level {
+		return false
+	}
+
+	if l.RotateEvery != o.RotateEvery || l.Compression != o.Compression || l.MaxTotalSizeMB != o.MaxTotalSizeMB {
+		return false
+	}
+
+	if len(l.Sinks) != len(o.Sinks) {
+		return false
+	}
+	for i, sink := range l.Sinks {
+		if !sink.Equals(o.Sinks[i]) {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -6795,10 +7443,22 @@ func (l *LogConfig) Copy() *LogConfig {
 	if l == nil {
 		return nil
 	}
-	return &LogConfig{
-		MaxFiles:      l.MaxFiles,
-		MaxFileSizeMB: l.MaxFileSizeMB,
+	nl := &LogConfig{
+		MaxFiles:       l.MaxFiles,
+		MaxFileSizeMB:  l.MaxFileSizeMB,
+		Format:         l.Format,
+		Level:          l.Level,
+		RotateEvery:    l.RotateEvery,
+		Compression:    l.Compression,
+		MaxTotalSizeMB: l.MaxTotalSizeMB,
 	}
+	if l.Sinks != nil {
+		nl.Sinks = make([]*LogSink, len(l.Sinks))
+		for i, sink := range l.Sinks {
+			nl.Sinks[i] = sink.Copy()
+		}
+	}
+	return nl
 }
 
 // DefaultLogConfig returns the default LogConfig values.
@@ -6809,6 +7469,15 @@ func DefaultLogConfig() *LogConfig {
 	}
 }
 
+// StorageMB returns the most disk space the log files of a stream may use.
+func (l *LogConfig) StorageMB() int {
+	usage := l.MaxFiles * l.MaxFileSizeMB
+	if l.MaxTotalSizeMB > 0 && l.MaxTotalSizeMB < usage {
+		return l.MaxTotalSizeMB
+	}
+	return usage
+}
+
 // Validate returns an error if the log config specified are less than
 // the minimum allowed.
 func (l *LogConfig) Validate() error {
@@ -6819,6 +7488,189 @@ func (l *LogConfig) Validate() error {
 	if l.MaxFileSizeMB < 1 {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("minimum file size is 1MB; got %d", l.MaxFileSizeMB))
 	}
+	if l.RotateEvery != 0 && l.RotateEvery < MinLogRotateEvery {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("minimum rotation period is %v; got %v", MinLogRotateEvery, l.RotateEvery))
+	}
+	switch l.Compression {
+	case "", LogCompressionNone, LogCompressionGzip, LogCompressionZstd:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid compression %q; must be one of %q, %q or %q",
+			l.Compression, LogCompressionNone, LogCompressionGzip, LogCompressionZstd))
+	}
+	if l.MaxTotalSizeMB < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("maximum total size must not be negative; got %d", l.MaxTotalSizeMB))
+	} else if l.MaxTotalSizeMB > 0 && l.MaxTotalSizeMB < l.MaxFileSizeMB {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("maximum total size (%d MB) must be at least the maximum file size (%d MB)",
+			l.MaxTotalSizeMB, l.MaxFileSizeMB))
+	}
+	switch l.Format {
+	case "", LogFormatText:
+		if l.Level != "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("level requires the %q format", LogFormatJSON))
+		}
+	case LogFormatJSON:
+		if _, ok := logLevels[strings.ToLower(l.Level)]; l.Level != "" && !ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid level %q; must be one of trace, debug, info, warn, error or fatal", l.Level))
+		}
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid format %q; must be %q or %q", l.Format, LogFormatText, LogFormatJSON))
+	}
+	for i, sink := range l.Sinks {
+		if err := sink.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, multierror.Prefix(err, fmt.Sprintf("sink %d:", i+1)))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+
+	LogCompressionNone = "none"
+	LogCompressionGzip = "gzip"
+	LogCompressionZstd = "zstd"
+
+	// MinLogRotateEvery is the shortest period log files may be rotated at.
+	MinLogRotateEvery = 1 * time.Minute
+)
+
+// logLevels are the levels JSON records may be filtered by.
+var logLevels = map[string]struct{}{
+	"trace": {}, "debug": {}, "info": {}, "notice": {}, "warn": {}, "warning": {},
+	"error": {}, "err": {}, "fatal": {}, "panic": {}, "critical": {}, "crit": {},
+}
+
+const (
+	LogSinkTypeSyslog   = "syslog"
+	LogSinkTypeHTTP     = "http"
+	LogSinkTypeJournald = "journald"
+
+	// DefaultLogSinkBufferSize is the default number of log lines a sink
+	// buffers while its destination is slow or unavailable.
+	DefaultLogSinkBufferSize = 1024
+
+	// DefaultLogSinkBatchSize and DefaultLogSinkBatchWait are the default
+	// number of lines and time an HTTP sink batches before sending them.
+	DefaultLogSinkBatchSize = 100
+	DefaultLogSinkBatchWait = 1 * time.Second
+)
+
+// logSinkSyslogFacilities are the syslog facilities a sink may log to.
+var logSinkSyslogFacilities = map[string]struct{}{
+	"kern": {}, "user": {}, "mail": {}, "daemon": {}, "auth": {}, "syslog": {},
+	"lpr": {}, "news": {}, "uucp": {}, "cron": {}, "authpriv": {}, "ftp": {},
+	"local0": {}, "local1": {}, "local2": {}, "local3": {},
+	"local4": {}, "local5": {}, "local6": {}, "local7": {},
+}
+
+// LogSink ships the lines a task writes to stdout and stderr to a syslog
+// server, an HTTP endpoint or the local journald.
+type LogSink struct {
+	// Type is one of syslog, http or journald.
+	Type string
+
+	// Address is the destination of the sink: a tcp://, udp://, unix:// or
+	// unixgram:// address for syslog, a URL for http, and the path of the
+	// journald socket.
+	Address string
+
+	// Facility is the syslog facility of messages.
+	Facility string
+
+	// Tag is the syslog APP-NAME and journald SYSLOG_IDENTIFIER of
+	// messages. Defaults to the task's name.
+	Tag string
+
+	// Header is sent with the requests of http sinks.
+	Header map[string]string
+
+	// BatchSize and BatchWait bound the number of lines sent in each
+	// request of http sinks and how long lines wait to be sent.
+	BatchSize int
+	BatchWait time.Duration
+
+	// BufferSize is the number of lines buffered while the destination is
+	// slow or unavailable.
+	BufferSize int
+
+	// Backpressure blocks the task's writes while the buffer is full
+	// instead of dropping lines.
+	Backpressure bool
+}
+
+func (s *LogSink) Equals(o *LogSink) bool {
+	if s == nil || o == nil {
+		return s == o
+	}
+	return reflect.DeepEqual(s, o)
+}
+
+func (s *LogSink) Copy() *LogSink {
+	if s == nil {
+		return nil
+	}
+	ns := new(LogSink)
+	*ns = *s
+	ns.Header = helper.CopyMapStringString(s.Header)
+	return ns
+}
+
+// DiffID identifies a sink across job versions.
+func (s *LogSink) DiffID() string {
+	return s.Type + s.Address
+}
+
+func (s *LogSink) Validate() error {
+	var mErr multierror.Error
+	switch s.Type {
+	case LogSinkTypeSyslog:
+		u, err := url.Parse(s.Address)
+		if err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid syslog address %q: %v", s.Address, err))
+			break
+		}
+		switch u.Scheme {
+		case "tcp", "udp":
+			if u.Host == "" {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("syslog address %q is missing a host", s.Address))
+			}
+		case "unix", "unixgram":
+			if u.Path == "" {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("syslog address %q is missing a path", s.Address))
+			}
+		default:
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("syslog address must start with tcp://, udp://, unix:// or unixgram://; got %q", s.Address))
+		}
+		if _, ok := logSinkSyslogFacilities[s.Facility]; s.Facility != "" && !ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid syslog facility %q", s.Facility))
+		}
+	case LogSinkTypeHTTP:
+		u, err := url.Parse(s.Address)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("http address must be an http:// or https:// URL; got %q", s.Address))
+		}
+		if s.BatchSize < 1 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("batch_size must be at least 1; got %d", s.BatchSize))
+		}
+		if s.BatchWait <= 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("batch_wait must be positive; got %v", s.BatchWait))
+		}
+	case LogSinkTypeJournald:
+		if s.Address != "" && !filepath.IsAbs(s.Address) {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("journald address must be an absolute path; got %q", s.Address))
+		}
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("unknown sink type %q, must be one of %q, %q or %q",
+			s.Type, LogSinkTypeSyslog, LogSinkTypeHTTP, LogSinkTypeJournald))
+	}
+
+	if s.Type != LogSinkTypeHTTP && (len(s.Header) != 0 || s.BatchSize != 0 || s.BatchWait != 0) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("header, batch_size and batch_wait are only supported by http sinks"))
+	}
+	if s.BufferSize < 1 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("buffer_size must be at least 1; got %d", s.BufferSize))
+	}
 	return mErr.ErrorOrNil()
 }
 
@@ -6869,6 +7721,14 @@ type Task struct {
 
 	Lifecycle *TaskLifecycleConfig
 
+	// DependsOn is the list of tasks in the same group that must finish
+	// before this task is started.
+	DependsOn []string
+
+	// DependsOnCondition is the state the tasks in DependsOn must finish in
+	// for this task to start.
+	DependsOnCondition string
+
 	// Meta is used to associate arbitrary metadata with this
 	// task. This is opaque to Nomad.
 	Meta map[string]string
@@ -6892,6 +7752,10 @@ type Task struct {
 	// task from Consul and sending it a signal to shutdown. See #2441
 	ShutdownDelay time.Duration
 
+	// ShutdownHook is an action run against the task before it is sent its
+	// kill signal, such as asking it to drain connections.
+	ShutdownHook *TaskShutdownHook
+
 	// VolumeMounts is a list of Volume name <-> mount configurations that will be
 	// attached to this task.
 	VolumeMounts []*VolumeMount
@@ -6950,6 +7814,8 @@ func (t *Task) Copy() *Task {
 	nt.Meta = helper.CopyMapStringString(nt.Meta)
 	nt.DispatchPayload = nt.DispatchPayload.Copy()
 	nt.Lifecycle = nt.Lifecycle.Copy()
+	nt.DependsOn = helper.CopySliceString(nt.DependsOn)
+	nt.ShutdownHook = nt.ShutdownHook.Copy()
 
 	if t.Artifacts != nil {
 		artifacts := make([]*TaskArtifact, 0, len(t.Artifacts))
@@ -6989,6 +7855,11 @@ func (t *Task) Canonicalize(job *Job, tg *TaskGroup) {
 	if len(t.Env) == 0 {
 		t.Env = nil
 	}
+	if len(t.DependsOn) == 0 {
+		t.DependsOn = nil
+	} else if t.DependsOnCondition == "" {
+		t.DependsOnCondition = TaskDependencyConditionSuccess
+	}
 
 	for _, service := range t.Services {
 		service.Canonicalize(job.Name, tg.Name, t.Name)
@@ -7003,6 +7874,8 @@ func (t *Task) Canonicalize(job *Job, tg *TaskGroup) {
 
 	if t.RestartPolicy == nil {
 		t.RestartPolicy = tg.RestartPolicy
+	} else {
+		t.RestartPolicy.Canonicalize()
 	}
 
 	// Set the default timeout if it is not specified.
@@ -7092,7 +7965,7 @@ func (t *Task) Validate(ephemeralDisk *EphemeralDisk, jobType string, tgServices
 	}
 
 	if t.LogConfig != nil && ephemeralDisk != nil {
-		logUsage := (t.LogConfig.MaxFiles * t.LogConfig.MaxFileSizeMB)
+		logUsage := t.LogConfig.StorageMB()
 		if ephemeralDisk.SizeMB <= logUsage {
 			mErr.Errors = append(mErr.Errors,
 				fmt.Errorf("log storage (%d MB) must be less than requested disk capacity (%d MB)",
@@ -7143,6 +8016,21 @@ func (t *Task) Validate(ephemeralDisk *EphemeralDisk, jobType string, tgServices
 
 	}
 
+	// Validate the shutdown hook if there
+	if t.ShutdownHook != nil {
+		if err := t.ShutdownHook.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Shutdown Hook validation failed: %v", err))
+		}
+	}
+
+	if len(t.DependsOn) > 0 {
+		switch t.DependsOnCondition {
+		case TaskDependencyConditionSuccess, TaskDependencyConditionComplete:
+		default:
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Unknown dependency condition %q", t.DependsOnCondition))
+		}
+	}
+
 	// Validation for TaskKind field which is used for Consul Connect integration
 	if t.Kind.IsConnectProxy() {
 		// This task is a Connect proxy so it should not have service stanzas
@@ -7922,10 +8810,22 @@ const (
 	// TaskHookFailed indicates that one of the hooks for a task failed.
 	TaskHookFailed = "Task hook failed"
 
+	// TaskRunningShutdownHook indicates the progress of the task's shutdown
+	// hook.
+	TaskRunningShutdownHook = "Running Shutdown Hook"
+
+	// TaskCheckpoint indicates the progress of checkpointing a task before
+	// its allocation is migrated, or of restoring it from the checkpoint.
+	TaskCheckpoint = "Checkpoint"
+
 	// TaskRestoreFailed indicates Nomad was unable to reattach to a
 	// restored task.
 	TaskRestoreFailed = "Failed Restoring Task"
 
+	// TaskResourcesUpdated indicates the updated CPU and memory resources of
+	// the allocation were applied to the running task.
+	TaskResourcesUpdated = "Resources Updated"
+
 	// TaskPluginUnhealthy indicates that a plugin managed by Nomad became unhealthy
 	TaskPluginUnhealthy = "Plugin became unhealthy"
 
@@ -8675,6 +9575,10 @@ func (a *Affinity) Validate() error {
 		if _, err := semver.NewConstraint(a.RTarget); err != nil {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("Semver affinity is invalid: %v", err))
 		}
+	case ConstraintAttributeIsSet, ConstraintAttributeIsNotSet:
+		if a.RTarget != "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Operator %q does not support an RTarget", a.Operand))
+		}
 	case "=", "==", "is", "!=", "not", "<", "<=", ">", ">=":
 		if a.RTarget == "" {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("Operator %q requires an RTarget", a.Operand))
@@ -8833,6 +9737,58 @@ type EphemeralDisk struct {
 	Migrate bool
 }
 
+// TaskGroupResources is the CPU shares and memory ceiling shared by the tasks
+// of a task group
+type TaskGroupResources struct {
+	// CPU is the CPU shares of the group in MHz. Like the CPU of tasks, it is
+	// a relative weight rather than a hard limit.
+	CPU int
+
+	// MemoryMB is the memory ceiling of the group in MB
+	MemoryMB int
+}
+
+// Validate validates TaskGroupResources
+func (r *TaskGroupResources) Validate() error {
+	var mErr multierror.Error
+	minResources := MinResources()
+	if r.CPU < minResources.CPU {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("minimum CPU value is %d; got %d", minResources.CPU, r.CPU))
+	}
+	if r.MemoryMB < minResources.MemoryMB {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("minimum MemoryMB value is %d; got %d", minResources.MemoryMB, r.MemoryMB))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// Copy copies the TaskGroupResources struct and returns a new one
+func (r *TaskGroupResources) Copy() *TaskGroupResources {
+	if r == nil {
+		return nil
+	}
+	nr := new(TaskGroupResources)
+	*nr = *r
+	return nr
+}
+
+// AllocatedCpu returns the CPU shares allocated to each allocation of the
+// group, which is empty if r is nil.
+func (r *TaskGroupResources) AllocatedCpu() AllocatedCpuResources {
+	if r == nil {
+		return AllocatedCpuResources{}
+	}
+	return AllocatedCpuResources{CpuShares: int64(r.CPU)}
+}
+
+// AllocatedMemory returns the memory ceiling allocated to each allocation of
+// the group, which is empty if r is nil.
+func (r *TaskGroupResources) AllocatedMemory() AllocatedMemoryResources {
+	if r == nil {
+		return AllocatedMemoryResources{}
+	}
+	return AllocatedMemoryResources{MemoryMB: int64(r.MemoryMB)}
+}
+
 // DefaultEphemeralDisk returns a EphemeralDisk with default configurations
 func DefaultEphemeralDisk() *EphemeralDisk {
 	return &EphemeralDisk{
@@ -9475,6 +10431,10 @@ type Allocation struct {
 	// NetworkStatus captures networking details of an allocation known at runtime
 	NetworkStatus *AllocNetworkStatus
 
+	// CheckResults are the latest results of the checks of the services
+	// using the nomad provider, keyed by check ID
+	CheckResults map[string]*CheckResult
+
 	// FollowupEvalID captures a follow up evaluation created to handle a failed allocation
 	// that can be rescheduled in the future
 	FollowupEvalID string
@@ -9628,6 +10588,14 @@ func (a *Allocation) copyImpl(job bool) *Allocation {
 		na.TaskStates = ts
 	}
 
+	if a.CheckResults != nil {
+		cr := make(map[string]*CheckResult, len(na.CheckResults))
+		for id, result := range na.CheckResults {
+			cr[id] = result.Copy()
+		}
+		na.CheckResults = cr
+	}
+
 	na.RescheduleTracker = a.RescheduleTracker.Copy()
 	na.PreemptedAllocations = helper.CopySliceString(a.PreemptedAllocations)
 	return na
@@ -10467,6 +11435,7 @@ const (
 	EvalTriggerQueuedAllocs      = "queued-allocs"
 	EvalTriggerPreemption        = "preemption"
 	EvalTriggerScaling           = "job-scaling"
+	EvalTriggerJobDependency     = "job-dependency"
 )
 
 const (
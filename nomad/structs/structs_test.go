@@ -2228,6 +2228,50 @@ func TestTask_Validate_Service_Check_CheckRestart(t *testing.T) {
 	assert.Nil(t, validCheckRestart.Validate())
 }
 
+func TestTask_Validate_Service_Check_Kind(t *testing.T) {
+	t.Parallel()
+
+	check := &ServiceCheck{
+		Name:     "check",
+		Type:     ServiceCheckTCP,
+		Interval: time.Second,
+		Timeout:  time.Second,
+		CheckRestart: &CheckRestart{
+			Limit: 3,
+		},
+	}
+
+	// Checks without a kind are both readiness and liveness checks
+	require.NoError(t, check.validate())
+	require.True(t, check.IsReadiness())
+	require.True(t, check.IsLiveness())
+	require.True(t, check.TriggersRestarts())
+
+	check.Kind = "foo"
+	require.Error(t, check.validate())
+
+	// Readiness checks never restart the task
+	check.Kind = ServiceCheckReadiness
+	err := check.validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "check_restart is not supported")
+	require.False(t, check.TriggersRestarts())
+
+	check.CheckRestart = nil
+	require.NoError(t, check.validate())
+	require.True(t, check.IsReadiness())
+	require.False(t, check.IsLiveness())
+
+	// on_update does not apply to liveness checks so it does not conflict
+	// with check_restart
+	check.Kind = ServiceCheckLiveness
+	check.OnUpdate = OnUpdateIgnore
+	check.CheckRestart = &CheckRestart{Limit: 3}
+	require.NoError(t, check.validate())
+	require.False(t, check.IsReadiness())
+	require.True(t, check.TriggersRestarts())
+}
+
 func TestTask_Validate_ConnectProxyKind(t *testing.T) {
 	ephemeralDisk := DefaultEphemeralDisk()
 	getTask := func(kind TaskKind, leader bool) *Task {
@@ -2372,6 +2416,27 @@ func TestLogConfig_Equals(t *testing.T) {
 		require.False(t, a.Equals(b))
 	})
 
+	t.Run("sinks", func(t *testing.T) {
+		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, Sinks: []*LogSink{{Type: LogSinkTypeJournald}}}
+		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, Sinks: []*LogSink{{Type: LogSinkTypeSyslog}}}
+		require.False(t, a.Equals(b))
+		require.True(t, a.Equals(a.Copy()))
+	})
+
+	t.Run("format", func(t *testing.T) {
+		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, Format: LogFormatJSON, Level: "info"}
+		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, Format: LogFormatJSON, Level: "warn"}
+		require.False(t, a.Equals(b))
+		require.True(t, a.Equals(a.Copy()))
+	})
+
+	t.Run("rotation", func(t *testing.T) {
+		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, RotateEvery: time.Hour, Compression: LogCompressionGzip, MaxTotalSizeMB: 400}
+		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, RotateEvery: time.Hour, Compression: LogCompressionZstd, MaxTotalSizeMB: 400}
+		require.False(t, a.Equals(b))
+		require.True(t, a.Equals(a.Copy()))
+	})
+
 	t.Run("same", func(t *testing.T) {
 		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
 		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
@@ -2379,6 +2444,255 @@ func TestLogConfig_Equals(t *testing.T) {
 	})
 }
 
+func TestLogConfig_Validate_Rotation(t *testing.T) {
+	cases := []struct {
+		name   string
+		config *LogConfig
+		err    string
+	}{
+		{name: "default", config: &LogConfig{MaxFiles: 10, MaxFileSizeMB: 10}},
+		{
+			name:   "valid",
+			config: &LogConfig{MaxFiles: 10, MaxFileSizeMB: 10, RotateEvery: time.Hour, Compression: LogCompressionZstd, MaxTotalSizeMB: 50},
+		},
+		{
+			name:   "short rotation",
+			config: &LogConfig{MaxFiles: 10, MaxFileSizeMB: 10, RotateEvery: time.Second},
+			err:    "minimum rotation period is 1m0s; got 1s",
+		},
+		{
+			name:   "unknown compression",
+			config: &LogConfig{MaxFiles: 10, MaxFileSizeMB: 10, Compression: "lz4"},
+			err:    `invalid compression "lz4"`,
+		},
+		{
+			name:   "negative total size",
+			config: &LogConfig{MaxFiles: 10, MaxFileSizeMB: 10, MaxTotalSizeMB: -1},
+			err:    "maximum total size must not be negative",
+		},
+		{
+			name:   "total size below file size",
+			config: &LogConfig{MaxFiles: 10, MaxFileSizeMB: 10, MaxTotalSizeMB: 5},
+			err:    "maximum total size (5 MB) must be at least the maximum file size (10 MB)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestLogConfig_StorageMB(t *testing.T) {
+	l := &LogConfig{MaxFiles: 10, MaxFileSizeMB: 10}
+	require.Equal(t, 100, l.StorageMB())
+
+	l.MaxTotalSizeMB = 30
+	require.Equal(t, 30, l.StorageMB())
+
+	l.MaxTotalSizeMB = 300
+	require.Equal(t, 100, l.StorageMB())
+
+	// The total size caps the storage checked against the ephemeral disk
+	task := &Task{LogConfig: &LogConfig{MaxFiles: 10, MaxFileSizeMB: 10, MaxTotalSizeMB: 50}}
+	err := task.Validate(&EphemeralDisk{SizeMB: 60}, JobTypeService, nil, nil)
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "log storage")
+}
+
+func TestLogConfig_Validate_Format(t *testing.T) {
+	cases := []struct {
+		name   string
+		format string
+		level  string
+		err    string
+	}{
+		{name: "default"},
+		{name: "text", format: LogFormatText},
+		{name: "json", format: LogFormatJSON},
+		{name: "json level", format: LogFormatJSON, level: "WARN"},
+		{name: "unknown format", format: "logfmt", err: `invalid format "logfmt"`},
+		{name: "unknown level", format: LogFormatJSON, level: "loud", err: `invalid level "loud"`},
+		{name: "text level", level: "info", err: `level requires the "json" format`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := DefaultLogConfig()
+			l.Format = tc.format
+			l.Level = tc.level
+			err := l.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestLogSink_Validate(t *testing.T) {
+	cases := []struct {
+		name string
+		sink *LogSink
+		err  string
+	}{
+		{
+			name: "valid syslog",
+			sink: &LogSink{Type: LogSinkTypeSyslog, Address: "tcp://logs.example.com:6514", Facility: "local3", BufferSize: 10},
+		},
+		{
+			name: "valid unix syslog",
+			sink: &LogSink{Type: LogSinkTypeSyslog, Address: "unixgram:///dev/log", BufferSize: 10},
+		},
+		{
+			name: "valid http",
+			sink: &LogSink{Type: LogSinkTypeHTTP, Address: "https://logs.example.com/ingest", BatchSize: 10, BatchWait: time.Second, BufferSize: 10},
+		},
+		{
+			name: "valid journald",
+			sink: &LogSink{Type: LogSinkTypeJournald, BufferSize: 10},
+		},
+		{
+			name: "unknown type",
+			sink: &LogSink{Type: "kafka", BufferSize: 10},
+			err:  `unknown sink type "kafka"`,
+		},
+		{
+			name: "syslog scheme",
+			sink: &LogSink{Type: LogSinkTypeSyslog, Address: "logs.example.com:514", BufferSize: 10},
+			err:  "syslog address must start with",
+		},
+		{
+			name: "syslog facility",
+			sink: &LogSink{Type: LogSinkTypeSyslog, Address: "udp://127.0.0.1:514", Facility: "local9", BufferSize: 10},
+			err:  `invalid syslog facility "local9"`,
+		},
+		{
+			name: "http address",
+			sink: &LogSink{Type: LogSinkTypeHTTP, Address: "ftp://logs.example.com", BatchSize: 10, BatchWait: time.Second, BufferSize: 10},
+			err:  "http address must be an http:// or https:// URL",
+		},
+		{
+			name: "http options on journald",
+			sink: &LogSink{Type: LogSinkTypeJournald, BatchSize: 10, BufferSize: 10},
+			err:  "only supported by http sinks",
+		},
+		{
+			name: "buffer size",
+			sink: &LogSink{Type: LogSinkTypeJournald},
+			err:  "buffer_size must be at least 1",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.sink.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestTaskShutdownHook_Validate(t *testing.T) {
+	cases := []struct {
+		name string
+		hook *TaskShutdownHook
+		err  string
+	}{
+		{
+			name: "valid http",
+			hook: &TaskShutdownHook{
+				Type:      TaskShutdownHookTypeHTTP,
+				PortLabel: "http",
+				Path:      "/drain",
+				Method:    "POST",
+				Protocol:  "http",
+				Timeout:   time.Second,
+			},
+		},
+		{
+			name: "valid exec",
+			hook: &TaskShutdownHook{
+				Type:    TaskShutdownHookTypeExec,
+				Command: "/bin/drain",
+				Timeout: time.Second,
+			},
+		},
+		{
+			name: "http missing port",
+			hook: &TaskShutdownHook{
+				Type:     TaskShutdownHookTypeHTTP,
+				Path:     "/drain",
+				Method:   "POST",
+				Protocol: "http",
+				Timeout:  time.Second,
+			},
+			err: "must specify a port",
+		},
+		{
+			name: "http relative path",
+			hook: &TaskShutdownHook{
+				Type:      TaskShutdownHookTypeHTTP,
+				PortLabel: "http",
+				Path:      "drain",
+				Method:    "POST",
+				Protocol:  "http",
+				Timeout:   time.Second,
+			},
+			err: "path must be absolute",
+		},
+		{
+			name: "exec missing command",
+			hook: &TaskShutdownHook{
+				Type:    TaskShutdownHookTypeExec,
+				Timeout: time.Second,
+			},
+			err: "must specify a command",
+		},
+		{
+			name: "bad type",
+			hook: &TaskShutdownHook{
+				Type:    "grpc",
+				Timeout: time.Second,
+			},
+			err: "Unsupported shutdown hook type",
+		},
+		{
+			name: "missing timeout",
+			hook: &TaskShutdownHook{
+				Type:    TaskShutdownHookTypeExec,
+				Command: "/bin/drain",
+			},
+			err: "Timeout must be greater than zero",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.hook.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
+
 func TestTask_Validate_CSIPluginConfig(t *testing.T) {
 	table := []struct {
 		name          string
@@ -2804,6 +3118,22 @@ func TestAffinity_Validate(t *testing.T) {
 			},
 			err: fmt.Errorf("Regular expression failed to compile"),
 		},
+		{
+			affinity: &Affinity{
+				Operand: ConstraintAttributeIsSet,
+				LTarget: "${attr.unique.driver.docker.image.redis_3a7}",
+				RTarget: "foo",
+				Weight:  50,
+			},
+			err: fmt.Errorf("Operator \"is_set\" does not support an RTarget"),
+		},
+		{
+			affinity: &Affinity{
+				Operand: ConstraintAttributeIsSet,
+				LTarget: "${attr.unique.driver.docker.image.redis_3a7}",
+				Weight:  50,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -3319,6 +3649,53 @@ func TestService_Validate(t *testing.T) {
 	require.Error(t, s.Validate())
 }
 
+func TestService_Validate_Provider(t *testing.T) {
+	s := Service{
+		Name:      "testservice",
+		PortLabel: "http",
+		Provider:  ServiceProviderNomad,
+		Checks: []*ServiceCheck{
+			{
+				Name:     "http",
+				Type:     ServiceCheckHTTP,
+				Path:     "/health",
+				Interval: time.Second,
+				Timeout:  time.Second,
+			},
+		},
+	}
+
+	s.Canonicalize("testjob", "testgroup", "testtask")
+	require.NoError(t, s.Validate())
+
+	// Unknown providers are invalid
+	s.Provider = "foo"
+	require.Error(t, s.Validate())
+	s.Provider = ServiceProviderNomad
+
+	// Checks only run by Consul are invalid
+	s.Checks[0].Type = ServiceCheckGRPC
+	err := s.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not supported by the \"nomad\" provider")
+	s.Checks[0].Type = ServiceCheckHTTP
+
+	// Exposed checks are invalid
+	s.Checks[0].Expose = true
+	require.Error(t, s.Validate())
+	s.Checks[0].Expose = false
+
+	// Connect requires Consul
+	s.Connect = &ConsulConnect{Native: true}
+	s.TaskName = "testtask"
+	err = s.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Connect enabled")
+
+	s.Provider = ServiceProviderConsul
+	require.NoError(t, s.Validate())
+}
+
 func TestService_Equals(t *testing.T) {
 	s := Service{
 		Name: "testservice",
@@ -3362,6 +3739,9 @@ func TestService_Equals(t *testing.T) {
 
 	o.EnableTagOverride = true
 	assertDiff()
+
+	o.Provider = ServiceProviderNomad
+	assertDiff()
 }
 
 func TestJob_ExpandServiceNames(t *testing.T) {
@@ -3679,6 +4059,99 @@ func TestRestartPolicy_Validate(t *testing.T) {
 	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "Interval can not be less than") {
 		t.Fatalf("expect interval too small error, got: %v", err)
 	}
+
+	// Fails with an unknown delay function
+	p = &RestartPolicy{
+		Mode:          RestartPolicyModeDelay,
+		Attempts:      3,
+		Delay:         5 * time.Second,
+		Interval:      time.Minute,
+		DelayFunction: "linear",
+	}
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "Invalid delay function") {
+		t.Fatalf("expect delay function error, got: %v", err)
+	}
+
+	// Fails when the max delay is less than the delay
+	p.DelayFunction = "exponential"
+	p.MaxDelay = time.Second
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "Max Delay cannot be less than Delay") {
+		t.Fatalf("expect max delay error, got: %v", err)
+	}
+
+	// Fails when the backed off delays do not fit inside interval
+	p.MaxDelay = time.Minute
+	p.Interval = 30 * time.Second
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "can't restart") {
+		t.Fatalf("expect restart interval error, got: %v", err)
+	}
+
+	// 5s + 10s + 20s fits inside the interval
+	p.Interval = 35 * time.Second
+	if err := p.Validate(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+}
+
+func TestRestartPolicy_NextDelay(t *testing.T) {
+	cases := []struct {
+		fn       string
+		expected []time.Duration
+	}{
+		{
+			fn:       "",
+			expected: []time.Duration{5, 5, 5, 5, 5, 5},
+		},
+		{
+			fn:       "constant",
+			expected: []time.Duration{5, 5, 5, 5, 5, 5},
+		},
+		{
+			fn:       "exponential",
+			expected: []time.Duration{5, 10, 20, 40, 60, 60},
+		},
+		{
+			fn:       "fibonacci",
+			expected: []time.Duration{5, 5, 10, 15, 25, 40, 60, 60},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.fn, func(t *testing.T) {
+			p := &RestartPolicy{
+				Delay:         5 * time.Second,
+				DelayFunction: tc.fn,
+				MaxDelay:      60 * time.Second,
+			}
+			for i, expected := range tc.expected {
+				require.Equal(t, expected*time.Second, p.NextDelay(i+1), "attempt %d", i+1)
+			}
+		})
+	}
+}
+
+func TestRestartPolicy_Canonicalize(t *testing.T) {
+	// A growing delay function without a max delay gets a default one
+	p := &RestartPolicy{
+		Attempts:      3,
+		Interval:      30 * time.Minute,
+		Delay:         15 * time.Second,
+		Mode:          RestartPolicyModeFail,
+		DelayFunction: "exponential",
+	}
+	p.Canonicalize()
+	require.Equal(t, 150*time.Second, p.MaxDelay)
+	require.NoError(t, p.Validate())
+
+	// An explicit max delay is kept
+	p.MaxDelay = time.Minute
+	p.Canonicalize()
+	require.Equal(t, time.Minute, p.MaxDelay)
+
+	// A constant delay has no max delay
+	p = &RestartPolicy{Delay: 15 * time.Second}
+	p.Canonicalize()
+	require.Zero(t, p.MaxDelay)
 }
 
 func TestReschedulePolicy_Validate(t *testing.T) {
@@ -5168,6 +5641,255 @@ func TestParameterizedJobConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestParameterizedJobConfig_Validate_Limits(t *testing.T) {
+	d := &ParameterizedJobConfig{
+		Payload:    DispatchPayloadOptional,
+		MaxRunning: -1,
+	}
+
+	err := d.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Max running must be non-negative")
+
+	d.MaxRunning = 5
+	d.Rate = &DispatchRateConfig{}
+	err = d.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Limit must be greater than zero")
+	require.Contains(t, err.Error(), "Burst must be at least one")
+
+	d.Rate = &DispatchRateConfig{Limit: 0.5, Burst: 10}
+	require.NoError(t, d.Validate())
+}
+
+func TestJob_Validate_DependsOn(t *testing.T) {
+	job := testJob()
+	job.Periodic = nil
+	job.DependsOn = []*JobDependency{
+		{JobID: job.ID, Condition: JobDependencyConditionSuccess},
+		{JobID: "upstream", Condition: JobDependencyConditionComplete},
+		{JobID: "upstream", Condition: JobDependencyConditionSuccess},
+		{JobID: "", Condition: "finished"},
+	}
+
+	err := job.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Job can't depend on itself")
+	require.Contains(t, err.Error(), `Job depends on "upstream" more than once`)
+	require.Contains(t, err.Error(), "Missing upstream job ID")
+	require.Contains(t, err.Error(), `Unknown condition "finished"`)
+
+	job.DependsOn = []*JobDependency{
+		{JobID: "upstream", Condition: JobDependencyConditionSuccess},
+	}
+	require.NoError(t, job.Validate())
+
+	job.Type = JobTypeBatch
+	job.ParameterizedJob = &ParameterizedJobConfig{
+		Payload: DispatchPayloadOptional,
+	}
+	err = job.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "can't depend on other jobs")
+}
+
+func TestTaskGroup_Validate_TaskDependencies(t *testing.T) {
+	task := func(name string, deps ...string) *Task {
+		return &Task{
+			Name:               name,
+			DependsOn:          deps,
+			DependsOnCondition: TaskDependencyConditionSuccess,
+		}
+	}
+
+	cases := []struct {
+		name     string
+		jobType  string
+		tasks    []*Task
+		expected []string
+	}{
+		{
+			name:    "valid dag",
+			jobType: JobTypeBatch,
+			tasks: []*Task{
+				task("a"),
+				task("b", "a"),
+				task("c", "a", "b"),
+			},
+		},
+		{
+			name:     "service job",
+			jobType:  JobTypeService,
+			tasks:    []*Task{task("a"), task("b", "a")},
+			expected: []string{`Job type "service" does not allow task dependencies`},
+		},
+		{
+			name:    "bad references",
+			jobType: JobTypeBatch,
+			tasks: []*Task{
+				task("a", "a", "missing"),
+				task("b", "c", "c"),
+				{
+					Name:      "c",
+					Lifecycle: &TaskLifecycleConfig{Hook: TaskLifecycleHookPrestart},
+				},
+			},
+			expected: []string{
+				"Task a can't depend on itself",
+				"Task a depends on undefined task missing",
+				"Task b depends on c more than once",
+				"Task b can't depend on task c which has a lifecycle",
+			},
+		},
+		{
+			name:    "cycle",
+			jobType: JobTypeBatch,
+			tasks: []*Task{
+				task("a", "c"),
+				task("b", "a"),
+				task("c", "b"),
+			},
+			expected: []string{"Task dependencies form a cycle: a -> c -> b -> a"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			j := &Job{Type: tc.jobType}
+			tg := &TaskGroup{Tasks: tc.tasks}
+			err := tg.validateTaskDependencies(j)
+			if len(tc.expected) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, expected := range tc.expected {
+				require.Contains(t, err.Error(), expected)
+			}
+		})
+	}
+}
+
+func TestTaskGroup_Validate_Stateful(t *testing.T) {
+	canaries := DefaultUpdateStrategy.Copy()
+	canaries.Canary = 1
+
+	cases := []struct {
+		name     string
+		jobType  string
+		update   *UpdateStrategy
+		expected string
+	}{
+		{
+			name:    "valid",
+			jobType: JobTypeService,
+			update:  DefaultUpdateStrategy.Copy(),
+		},
+		{
+			name:     "batch job",
+			jobType:  JobTypeBatch,
+			expected: `Job type "batch" does not allow stateful task groups`,
+		},
+		{
+			name:     "no update",
+			jobType:  JobTypeService,
+			expected: "Stateful task groups require an update block",
+		},
+		{
+			name:     "canaries",
+			jobType:  JobTypeService,
+			update:   canaries,
+			expected: "Stateful task groups can not have canaries",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			j := testJob()
+			j.Type = tc.jobType
+			tg := j.TaskGroups[0]
+			tg.Stateful = true
+			tg.Update = tc.update
+			err := tg.Validate(j)
+			if tc.expected == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestTaskGroup_Validate_Resources(t *testing.T) {
+	cases := []struct {
+		name     string
+		tg       *TaskGroupResources
+		task     *Resources
+		expected string
+	}{
+		{
+			name: "valid",
+			tg:   &TaskGroupResources{CPU: 1000, MemoryMB: 512},
+			task: &Resources{CPU: 500, MemoryMB: 256, MemoryMaxMB: 512},
+		},
+		{
+			name:     "missing memory",
+			tg:       &TaskGroupResources{CPU: 1000},
+			task:     &Resources{CPU: 500, MemoryMB: 256},
+			expected: "minimum MemoryMB value is 10; got 0",
+		},
+		{
+			name:     "task cpu",
+			tg:       &TaskGroupResources{CPU: 400, MemoryMB: 512},
+			task:     &Resources{CPU: 500, MemoryMB: 256},
+			expected: `Task "web" CPU (500) exceeds the group CPU (400)`,
+		},
+		{
+			name:     "task memory max",
+			tg:       &TaskGroupResources{CPU: 1000, MemoryMB: 512},
+			task:     &Resources{CPU: 500, MemoryMB: 256, MemoryMaxMB: 1024},
+			expected: `Task "web" memory_max (1024) exceeds the group memory (512)`,
+		},
+		{
+			name:     "task cores",
+			tg:       &TaskGroupResources{CPU: 1000, MemoryMB: 512},
+			task:     &Resources{Cores: 1, MemoryMB: 256},
+			expected: `Task "web" can not reserve cores in a group with resources`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			j := testJob()
+			tg := j.TaskGroups[0]
+			tg.Resources = tc.tg
+			tg.Tasks[0].Resources = tc.task
+			err := tg.Validate(j)
+			if tc.expected == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestTaskGroup_StatefulHostname(t *testing.T) {
+	cases := map[string]string{
+		"db":                    "db-3",
+		"Cache_Primary":         "cache-primary-3",
+		"__":                    "alloc-3",
+		strings.Repeat("a", 70): strings.Repeat("a", 61) + "-3",
+	}
+	for name, expected := range cases {
+		tg := &TaskGroup{Name: name}
+		require.Equal(t, expected, tg.StatefulHostname(3))
+	}
+}
+
 func TestParameterizedJobConfig_Validate_NonBatch(t *testing.T) {
 	job := testJob()
 	job.ParameterizedJob = &ParameterizedJobConfig{
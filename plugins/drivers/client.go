@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/nomad/plugins/shared/hclspec"
 	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
 	sproto "github.com/hashicorp/nomad/plugins/shared/structs/proto"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
@@ -74,6 +75,9 @@ func (d *driverPluginClient) Capabilities() (*Capabilities, error) {
 
 		caps.MountConfigs = MountConfigSupport(resp.Capabilities.MountConfigs)
 		caps.RemoteTasks = resp.Capabilities.RemoteTasks
+		caps.Checkpoint = resp.Capabilities.Checkpoint
+		caps.UpdateResources = resp.Capabilities.UpdateResources
+		caps.GroupResources = resp.Capabilities.GroupResources
 	}
 
 	return caps, nil
@@ -145,28 +149,38 @@ func (d *driverPluginClient) StartTask(c *TaskConfig) (*TaskHandle, *DriverNetwo
 
 	resp, err := d.client.StartTask(d.doneCtx, req)
 	if err != nil {
-		st := status.Convert(err)
-		if len(st.Details()) > 0 {
-			if rec, ok := st.Details()[0].(*sproto.RecoverableError); ok {
-				return nil, nil, structs.NewRecoverableError(err, rec.Recoverable)
-			}
-		}
-		return nil, nil, grpcutils.HandleGrpcErr(err, d.doneCtx)
+		return nil, nil, d.startTaskErrorFromProto(err)
 	}
 
-	var net *DriverNetwork
-	if resp.NetworkOverride != nil {
-		net = &DriverNetwork{
-			PortMap:       map[string]int{},
-			IP:            resp.NetworkOverride.Addr,
-			AutoAdvertise: resp.NetworkOverride.AutoAdvertise,
-		}
-		for k, v := range resp.NetworkOverride.PortMap {
-			net.PortMap[k] = int(v)
+	return taskHandleFromProto(resp.Handle), networkOverrideFromProto(resp.NetworkOverride), nil
+}
+
+// startTaskErrorFromProto returns the error of a StartTask or RestoreTask
+// RPC, preserving whether it is recoverable.
+func (d *driverPluginClient) startTaskErrorFromProto(err error) error {
+	st := status.Convert(err)
+	if len(st.Details()) > 0 {
+		if rec, ok := st.Details()[0].(*sproto.RecoverableError); ok {
+			return structs.NewRecoverableError(err, rec.Recoverable)
 		}
 	}
+	return grpcutils.HandleGrpcErr(err, d.doneCtx)
+}
+
+func networkOverrideFromProto(pb *proto.NetworkOverride) *DriverNetwork {
+	if pb == nil {
+		return nil
+	}
 
-	return taskHandleFromProto(resp.Handle), net, nil
+	net := &DriverNetwork{
+		PortMap:       map[string]int{},
+		IP:            pb.Addr,
+		AutoAdvertise: pb.AutoAdvertise,
+	}
+	for k, v := range pb.PortMap {
+		net.PortMap[k] = int(v)
+	}
+	return net
 }
 
 // WaitTask returns a channel that will have an ExitResult pushed to it once when the task
@@ -494,3 +508,55 @@ func (d *driverPluginClient) DestroyNetwork(allocID string, spec *NetworkIsolati
 
 	return nil
 }
+
+// CheckpointTask saves the state of the task to dir and stops it.
+func (d *driverPluginClient) CheckpointTask(taskID string, dir string) error {
+	req := &proto.CheckpointTaskRequest{
+		TaskId: taskID,
+		Dir:    dir,
+	}
+
+	_, err := d.client.CheckpointTask(d.doneCtx, req)
+	if status.Code(err) == codes.FailedPrecondition {
+		return ErrCheckpointNotEnabled
+	}
+	return grpcutils.HandleGrpcErr(err, d.doneCtx)
+}
+
+// RestoreTask starts a task from the checkpoint in dir. It returns the same
+// values as StartTask.
+func (d *driverPluginClient) RestoreTask(c *TaskConfig, dir string) (*TaskHandle, *DriverNetwork, error) {
+	req := &proto.RestoreTaskRequest{
+		Task: taskConfigToProto(c),
+		Dir:  dir,
+	}
+
+	resp, err := d.client.RestoreTask(d.doneCtx, req)
+	if err != nil {
+		return nil, nil, d.startTaskErrorFromProto(err)
+	}
+
+	return taskHandleFromProto(resp.Handle), networkOverrideFromProto(resp.NetworkOverride), nil
+}
+
+// PrefetchImages starts pulling the images in the background.
+func (d *driverPluginClient) PrefetchImages(images []string) error {
+	req := &proto.PrefetchImagesRequest{
+		Images: images,
+	}
+
+	_, err := d.client.PrefetchImages(d.doneCtx, req)
+	return grpcutils.HandleGrpcErr(err, d.doneCtx)
+}
+
+// UpdateTaskResources applies the CPU and memory of the resources to the
+// running task.
+func (d *driverPluginClient) UpdateTaskResources(taskID string, resources *Resources) error {
+	req := &proto.UpdateTaskResourcesRequest{
+		TaskId:    taskID,
+		Resources: ResourcesToProto(resources),
+	}
+
+	_, err := d.client.UpdateTaskResources(d.doneCtx, req)
+	return grpcutils.HandleGrpcErr(err, d.doneCtx)
+}
@@ -94,6 +94,37 @@ type DriverNetworkManager interface {
 	DestroyNetwork(allocID string, spec *NetworkIsolationSpec) error
 }
 
+// DriverCheckpointer is the interface exposing functions to checkpoint a
+// running task to a directory and later restore it, possibly on another
+// node. It only needs to be implemented if the driver sets the Checkpoint
+// capability.
+type DriverCheckpointer interface {
+	// CheckpointTask saves the state of the task to dir and stops it.
+	CheckpointTask(taskID string, dir string) error
+
+	// RestoreTask starts the task from the checkpoint in dir instead of
+	// launching it anew. It otherwise behaves as StartTask.
+	RestoreTask(config *TaskConfig, dir string) (*TaskHandle, *DriverNetwork, error)
+}
+
+// DriverImagePrefetcher is the interface exposing a function to pull images
+// ahead of tasks using them. It only needs to be implemented by drivers
+// running tasks from images they download.
+type DriverImagePrefetcher interface {
+	// PrefetchImages starts pulling the images in the background and
+	// returns once the pulls are started.
+	PrefetchImages(images []string) error
+}
+
+// DriverResourceUpdater is the interface exposing a function to apply new
+// resources to a running task. It only needs to be implemented if the driver
+// sets the UpdateResources capability.
+type DriverResourceUpdater interface {
+	// UpdateTaskResources applies the CPU shares and memory limits of the
+	// resources to the running task.
+	UpdateTaskResources(taskID string, resources *Resources) error
+}
+
 // DriverSignalTaskNotSupported can be embedded by drivers which don't support
 // the SignalTask RPC. This satisfies the SignalTask func requirement for the
 // DriverPlugin interface.
@@ -171,6 +202,21 @@ type Capabilities struct {
 	// adjust behavior such as propogating task handles between allocations
 	// to avoid downtime when a client is lost.
 	RemoteTasks bool
+
+	// Checkpoint indicates the driver can checkpoint running tasks and
+	// restore them, and that the CheckpointTask and RestoreTask RPCs are
+	// implemented.
+	Checkpoint bool
+
+	// UpdateResources indicates the driver can apply new CPU and memory
+	// resources to running tasks, and that the UpdateTaskResources RPC is
+	// implemented.
+	UpdateResources bool
+
+	// GroupResources indicates the driver nests tasks under the cgroup of
+	// their allocation, so that they are bounded by the resources of their
+	// task group.
+	GroupResources bool
 }
 
 func (c *Capabilities) HasNetIsolationMode(m NetIsolationMode) bool {
@@ -335,6 +381,7 @@ func (tc *TaskConfig) TaskDir() *allocdir.TaskDir {
 		SharedTaskDir:  filepath.Join(taskDir, allocdir.SharedAllocName),
 		LocalDir:       filepath.Join(taskDir, allocdir.TaskLocal),
 		SecretsDir:     filepath.Join(taskDir, allocdir.TaskSecrets),
+		CheckpointDir:  filepath.Join(tc.AllocDir, allocdir.CheckpointDirName, tc.Name),
 	}
 }
 
@@ -400,6 +447,11 @@ type LinuxResources struct {
 	CpusetCpus       string
 	CpusetCgroupPath string
 
+	// CgroupParent is the cgroup, relative to the cgroup root, under which
+	// the task cgroup is created. It is set when the tasks of the group share
+	// the CPU and memory ceiling of an allocation cgroup.
+	CgroupParent string
+
 	// PrecentTicks is used to calculate the CPUQuota, currently the docker
 	// driver exposes cpu period and quota through the driver configuration
 	// and thus the calculation for CPUQuota cannot be done on the client.
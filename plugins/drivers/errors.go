@@ -4,6 +4,10 @@ import "fmt"
 
 var ErrTaskNotFound = fmt.Errorf("task not found for given id")
 
+// ErrCheckpointNotEnabled is returned by CheckpointTask if the task wasn't
+// configured to be checkpointed.
+var ErrCheckpointNotEnabled = fmt.Errorf("checkpointing is not enabled for the task")
+
 var DriverRequiresRootMessage = "Driver must run as root"
 
 var NoCgroupMountMessage = "Failed to discover cgroup mount point"
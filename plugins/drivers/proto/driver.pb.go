@@ -137,7 +137,7 @@ func (x DriverCapabilities_FSIsolation) String() string {
 }
 
 func (DriverCapabilities_FSIsolation) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{32, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{40, 0}
 }
 
 type DriverCapabilities_MountConfigs int32
@@ -165,7 +165,7 @@ func (x DriverCapabilities_MountConfigs) String() string {
 }
 
 func (DriverCapabilities_MountConfigs) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{32, 1}
+	return fileDescriptor_4a8f45747846a74d, []int{40, 1}
 }
 
 type NetworkIsolationSpec_NetworkIsolationMode int32
@@ -196,7 +196,7 @@ func (x NetworkIsolationSpec_NetworkIsolationMode) String() string {
 }
 
 func (NetworkIsolationSpec_NetworkIsolationMode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{33, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{41, 0}
 }
 
 type CPUUsage_Fields int32
@@ -233,7 +233,7 @@ func (x CPUUsage_Fields) String() string {
 }
 
 func (CPUUsage_Fields) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{54, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{62, 0}
 }
 
 type MemoryUsage_Fields int32
@@ -273,7 +273,7 @@ func (x MemoryUsage_Fields) String() string {
 }
 
 func (MemoryUsage_Fields) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{55, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{63, 0}
 }
 
 type TaskConfigSchemaRequest struct {
@@ -1833,6 +1833,355 @@ func (m *DestroyNetworkResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DestroyNetworkResponse proto.InternalMessageInfo
 
+type CheckpointTaskRequest struct {
+	// TaskId is the ID of the target task
+	TaskId string `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Dir is the directory the checkpoint is saved to
+	Dir                  string   `protobuf:"bytes,2,opt,name=dir,proto3" json:"dir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointTaskRequest) Reset()         { *m = CheckpointTaskRequest{} }
+func (m *CheckpointTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckpointTaskRequest) ProtoMessage()    {}
+func (*CheckpointTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{32}
+}
+
+func (m *CheckpointTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointTaskRequest.Unmarshal(m, b)
+}
+func (m *CheckpointTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckpointTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointTaskRequest.Merge(m, src)
+}
+func (m *CheckpointTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckpointTaskRequest.Size(m)
+}
+func (m *CheckpointTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointTaskRequest proto.InternalMessageInfo
+
+func (m *CheckpointTaskRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *CheckpointTaskRequest) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+type CheckpointTaskResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointTaskResponse) Reset()         { *m = CheckpointTaskResponse{} }
+func (m *CheckpointTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckpointTaskResponse) ProtoMessage()    {}
+func (*CheckpointTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{33}
+}
+
+func (m *CheckpointTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointTaskResponse.Unmarshal(m, b)
+}
+func (m *CheckpointTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckpointTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointTaskResponse.Merge(m, src)
+}
+func (m *CheckpointTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckpointTaskResponse.Size(m)
+}
+func (m *CheckpointTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointTaskResponse proto.InternalMessageInfo
+
+type RestoreTaskRequest struct {
+	// Task configuration to launch
+	Task *TaskConfig `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// Dir is the directory the checkpoint is restored from
+	Dir                  string   `protobuf:"bytes,2,opt,name=dir,proto3" json:"dir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreTaskRequest) Reset()         { *m = RestoreTaskRequest{} }
+func (m *RestoreTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreTaskRequest) ProtoMessage()    {}
+func (*RestoreTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{34}
+}
+
+func (m *RestoreTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTaskRequest.Unmarshal(m, b)
+}
+func (m *RestoreTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTaskRequest.Merge(m, src)
+}
+func (m *RestoreTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreTaskRequest.Size(m)
+}
+func (m *RestoreTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTaskRequest proto.InternalMessageInfo
+
+func (m *RestoreTaskRequest) GetTask() *TaskConfig {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+func (m *RestoreTaskRequest) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+type RestoreTaskResponse struct {
+	// Result is set as in StartTaskResponse
+	Result StartTaskResponse_Result `protobuf:"varint,1,opt,name=result,proto3,enum=hashicorp.nomad.plugins.drivers.proto.StartTaskResponse_Result" json:"result,omitempty"`
+	// DriverErrorMsg is set if an error occurred
+	DriverErrorMsg string `protobuf:"bytes,2,opt,name=driver_error_msg,json=driverErrorMsg,proto3" json:"driver_error_msg,omitempty"`
+	// Handle is opaque to the client, but must be stored in order to recover
+	// the task.
+	Handle *TaskHandle `protobuf:"bytes,3,opt,name=handle,proto3" json:"handle,omitempty"`
+	// NetworkOverride is set if the driver sets network settings and the service ip/port
+	// needs to be set differently.
+	NetworkOverride      *NetworkOverride `protobuf:"bytes,4,opt,name=network_override,json=networkOverride,proto3" json:"network_override,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RestoreTaskResponse) Reset()         { *m = RestoreTaskResponse{} }
+func (m *RestoreTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreTaskResponse) ProtoMessage()    {}
+func (*RestoreTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{35}
+}
+
+func (m *RestoreTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTaskResponse.Unmarshal(m, b)
+}
+func (m *RestoreTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTaskResponse.Merge(m, src)
+}
+func (m *RestoreTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreTaskResponse.Size(m)
+}
+func (m *RestoreTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTaskResponse proto.InternalMessageInfo
+
+func (m *RestoreTaskResponse) GetResult() StartTaskResponse_Result {
+	if m != nil {
+		return m.Result
+	}
+	return StartTaskResponse_SUCCESS
+}
+
+func (m *RestoreTaskResponse) GetDriverErrorMsg() string {
+	if m != nil {
+		return m.DriverErrorMsg
+	}
+	return ""
+}
+
+func (m *RestoreTaskResponse) GetHandle() *TaskHandle {
+	if m != nil {
+		return m.Handle
+	}
+	return nil
+}
+
+func (m *RestoreTaskResponse) GetNetworkOverride() *NetworkOverride {
+	if m != nil {
+		return m.NetworkOverride
+	}
+	return nil
+}
+
+type PrefetchImagesRequest struct {
+	// Images are the references of the images to pull
+	Images               []string `protobuf:"bytes,1,rep,name=images,proto3" json:"images,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrefetchImagesRequest) Reset()         { *m = PrefetchImagesRequest{} }
+func (m *PrefetchImagesRequest) String() string { return proto.CompactTextString(m) }
+func (*PrefetchImagesRequest) ProtoMessage()    {}
+func (*PrefetchImagesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{36}
+}
+
+func (m *PrefetchImagesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrefetchImagesRequest.Unmarshal(m, b)
+}
+func (m *PrefetchImagesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrefetchImagesRequest.Marshal(b, m, deterministic)
+}
+func (m *PrefetchImagesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrefetchImagesRequest.Merge(m, src)
+}
+func (m *PrefetchImagesRequest) XXX_Size() int {
+	return xxx_messageInfo_PrefetchImagesRequest.Size(m)
+}
+func (m *PrefetchImagesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrefetchImagesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrefetchImagesRequest proto.InternalMessageInfo
+
+func (m *PrefetchImagesRequest) GetImages() []string {
+	if m != nil {
+		return m.Images
+	}
+	return nil
+}
+
+type PrefetchImagesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrefetchImagesResponse) Reset()         { *m = PrefetchImagesResponse{} }
+func (m *PrefetchImagesResponse) String() string { return proto.CompactTextString(m) }
+func (*PrefetchImagesResponse) ProtoMessage()    {}
+func (*PrefetchImagesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{37}
+}
+
+func (m *PrefetchImagesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrefetchImagesResponse.Unmarshal(m, b)
+}
+func (m *PrefetchImagesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrefetchImagesResponse.Marshal(b, m, deterministic)
+}
+func (m *PrefetchImagesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrefetchImagesResponse.Merge(m, src)
+}
+func (m *PrefetchImagesResponse) XXX_Size() int {
+	return xxx_messageInfo_PrefetchImagesResponse.Size(m)
+}
+func (m *PrefetchImagesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrefetchImagesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrefetchImagesResponse proto.InternalMessageInfo
+
+type UpdateTaskResourcesRequest struct {
+	// TaskId is the ID of the target task
+	TaskId string `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Resources are the new resources of the task
+	Resources            *Resources `protobuf:"bytes,2,opt,name=resources,proto3" json:"resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *UpdateTaskResourcesRequest) Reset()         { *m = UpdateTaskResourcesRequest{} }
+func (m *UpdateTaskResourcesRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateTaskResourcesRequest) ProtoMessage()    {}
+func (*UpdateTaskResourcesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{38}
+}
+
+func (m *UpdateTaskResourcesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTaskResourcesRequest.Unmarshal(m, b)
+}
+func (m *UpdateTaskResourcesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTaskResourcesRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateTaskResourcesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTaskResourcesRequest.Merge(m, src)
+}
+func (m *UpdateTaskResourcesRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateTaskResourcesRequest.Size(m)
+}
+func (m *UpdateTaskResourcesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTaskResourcesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTaskResourcesRequest proto.InternalMessageInfo
+
+func (m *UpdateTaskResourcesRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *UpdateTaskResourcesRequest) GetResources() *Resources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+type UpdateTaskResourcesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateTaskResourcesResponse) Reset()         { *m = UpdateTaskResourcesResponse{} }
+func (m *UpdateTaskResourcesResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateTaskResourcesResponse) ProtoMessage()    {}
+func (*UpdateTaskResourcesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{39}
+}
+
+func (m *UpdateTaskResourcesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTaskResourcesResponse.Unmarshal(m, b)
+}
+func (m *UpdateTaskResourcesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTaskResourcesResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateTaskResourcesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTaskResourcesResponse.Merge(m, src)
+}
+func (m *UpdateTaskResourcesResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateTaskResourcesResponse.Size(m)
+}
+func (m *UpdateTaskResourcesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTaskResourcesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTaskResourcesResponse proto.InternalMessageInfo
+
 type DriverCapabilities struct {
 	// SendSignals indicates that the driver can send process signals (ex. SIGUSR1)
 	// to the task.
@@ -1848,7 +2197,16 @@ type DriverCapabilities struct {
 	MountConfigs DriverCapabilities_MountConfigs `protobuf:"varint,6,opt,name=mount_configs,json=mountConfigs,proto3,enum=hashicorp.nomad.plugins.drivers.proto.DriverCapabilities_MountConfigs" json:"mount_configs,omitempty"`
 	// remote_tasks indicates whether the driver executes tasks remotely such
 	// on cloud runtimes like AWS ECS.
-	RemoteTasks          bool     `protobuf:"varint,7,opt,name=remote_tasks,json=remoteTasks,proto3" json:"remote_tasks,omitempty"`
+	RemoteTasks bool `protobuf:"varint,7,opt,name=remote_tasks,json=remoteTasks,proto3" json:"remote_tasks,omitempty"`
+	// checkpoint indicates whether the driver can checkpoint running tasks
+	// and restore them.
+	Checkpoint bool `protobuf:"varint,8,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	// update_resources indicates whether the driver can apply new CPU and
+	// memory resources to running tasks.
+	UpdateResources bool `protobuf:"varint,9,opt,name=update_resources,json=updateResources,proto3" json:"update_resources,omitempty"`
+	// group_resources indicates whether the driver nests tasks under the
+	// cgroup of their allocation, so they are bounded by the group resources.
+	GroupResources       bool     `protobuf:"varint,10,opt,name=group_resources,json=groupResources,proto3" json:"group_resources,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1858,7 +2216,7 @@ func (m *DriverCapabilities) Reset()         { *m = DriverCapabilities{} }
 func (m *DriverCapabilities) String() string { return proto.CompactTextString(m) }
 func (*DriverCapabilities) ProtoMessage()    {}
 func (*DriverCapabilities) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{32}
+	return fileDescriptor_4a8f45747846a74d, []int{40}
 }
 
 func (m *DriverCapabilities) XXX_Unmarshal(b []byte) error {
@@ -1928,6 +2286,27 @@ func (m *DriverCapabilities) GetRemoteTasks() bool {
 	return false
 }
 
+func (m *DriverCapabilities) GetCheckpoint() bool {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return false
+}
+
+func (m *DriverCapabilities) GetUpdateResources() bool {
+	if m != nil {
+		return m.UpdateResources
+	}
+	return false
+}
+
+func (m *DriverCapabilities) GetGroupResources() bool {
+	if m != nil {
+		return m.GroupResources
+	}
+	return false
+}
+
 type NetworkIsolationSpec struct {
 	Mode                 NetworkIsolationSpec_NetworkIsolationMode `protobuf:"varint,1,opt,name=mode,proto3,enum=hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec_NetworkIsolationMode" json:"mode,omitempty"`
 	Path                 string                                    `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
@@ -1942,7 +2321,7 @@ func (m *NetworkIsolationSpec) Reset()         { *m = NetworkIsolationSpec{} }
 func (m *NetworkIsolationSpec) String() string { return proto.CompactTextString(m) }
 func (*NetworkIsolationSpec) ProtoMessage()    {}
 func (*NetworkIsolationSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{33}
+	return fileDescriptor_4a8f45747846a74d, []int{41}
 }
 
 func (m *NetworkIsolationSpec) XXX_Unmarshal(b []byte) error {
@@ -2003,7 +2382,7 @@ func (m *HostsConfig) Reset()         { *m = HostsConfig{} }
 func (m *HostsConfig) String() string { return proto.CompactTextString(m) }
 func (*HostsConfig) ProtoMessage()    {}
 func (*HostsConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{34}
+	return fileDescriptor_4a8f45747846a74d, []int{42}
 }
 
 func (m *HostsConfig) XXX_Unmarshal(b []byte) error {
@@ -2051,7 +2430,7 @@ func (m *DNSConfig) Reset()         { *m = DNSConfig{} }
 func (m *DNSConfig) String() string { return proto.CompactTextString(m) }
 func (*DNSConfig) ProtoMessage()    {}
 func (*DNSConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{35}
+	return fileDescriptor_4a8f45747846a74d, []int{43}
 }
 
 func (m *DNSConfig) XXX_Unmarshal(b []byte) error {
@@ -2143,7 +2522,7 @@ func (m *TaskConfig) Reset()         { *m = TaskConfig{} }
 func (m *TaskConfig) String() string { return proto.CompactTextString(m) }
 func (*TaskConfig) ProtoMessage()    {}
 func (*TaskConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{36}
+	return fileDescriptor_4a8f45747846a74d, []int{44}
 }
 
 func (m *TaskConfig) XXX_Unmarshal(b []byte) error {
@@ -2300,7 +2679,7 @@ func (m *Resources) Reset()         { *m = Resources{} }
 func (m *Resources) String() string { return proto.CompactTextString(m) }
 func (*Resources) ProtoMessage()    {}
 func (*Resources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{37}
+	return fileDescriptor_4a8f45747846a74d, []int{45}
 }
 
 func (m *Resources) XXX_Unmarshal(b []byte) error {
@@ -2355,7 +2734,7 @@ func (m *AllocatedTaskResources) Reset()         { *m = AllocatedTaskResources{}
 func (m *AllocatedTaskResources) String() string { return proto.CompactTextString(m) }
 func (*AllocatedTaskResources) ProtoMessage()    {}
 func (*AllocatedTaskResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{38}
+	return fileDescriptor_4a8f45747846a74d, []int{46}
 }
 
 func (m *AllocatedTaskResources) XXX_Unmarshal(b []byte) error {
@@ -2408,7 +2787,7 @@ func (m *AllocatedCpuResources) Reset()         { *m = AllocatedCpuResources{} }
 func (m *AllocatedCpuResources) String() string { return proto.CompactTextString(m) }
 func (*AllocatedCpuResources) ProtoMessage()    {}
 func (*AllocatedCpuResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{39}
+	return fileDescriptor_4a8f45747846a74d, []int{47}
 }
 
 func (m *AllocatedCpuResources) XXX_Unmarshal(b []byte) error {
@@ -2448,7 +2827,7 @@ func (m *AllocatedMemoryResources) Reset()         { *m = AllocatedMemoryResourc
 func (m *AllocatedMemoryResources) String() string { return proto.CompactTextString(m) }
 func (*AllocatedMemoryResources) ProtoMessage()    {}
 func (*AllocatedMemoryResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{40}
+	return fileDescriptor_4a8f45747846a74d, []int{48}
 }
 
 func (m *AllocatedMemoryResources) XXX_Unmarshal(b []byte) error {
@@ -2499,7 +2878,7 @@ func (m *NetworkResource) Reset()         { *m = NetworkResource{} }
 func (m *NetworkResource) String() string { return proto.CompactTextString(m) }
 func (*NetworkResource) ProtoMessage()    {}
 func (*NetworkResource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{41}
+	return fileDescriptor_4a8f45747846a74d, []int{49}
 }
 
 func (m *NetworkResource) XXX_Unmarshal(b []byte) error {
@@ -2574,7 +2953,7 @@ func (m *NetworkPort) Reset()         { *m = NetworkPort{} }
 func (m *NetworkPort) String() string { return proto.CompactTextString(m) }
 func (*NetworkPort) ProtoMessage()    {}
 func (*NetworkPort) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{42}
+	return fileDescriptor_4a8f45747846a74d, []int{50}
 }
 
 func (m *NetworkPort) XXX_Unmarshal(b []byte) error {
@@ -2623,7 +3002,7 @@ func (m *PortMapping) Reset()         { *m = PortMapping{} }
 func (m *PortMapping) String() string { return proto.CompactTextString(m) }
 func (*PortMapping) ProtoMessage()    {}
 func (*PortMapping) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{43}
+	return fileDescriptor_4a8f45747846a74d, []int{51}
 }
 
 func (m *PortMapping) XXX_Unmarshal(b []byte) error {
@@ -2688,6 +3067,9 @@ type LinuxResources struct {
 	CpusetCpus string `protobuf:"bytes,6,opt,name=cpuset_cpus,json=cpusetCpus,proto3" json:"cpuset_cpus,omitempty"`
 	// CpusetCgroup is the path to the cpuset cgroup managed by the client
 	CpusetCgroup string `protobuf:"bytes,9,opt,name=cpuset_cgroup,json=cpusetCgroup,proto3" json:"cpuset_cgroup,omitempty"`
+	// CgroupParent is the cgroup, relative to the cgroup root, under which
+	// the task cgroup is created. Default: "" (not specified)
+	CgroupParent string `protobuf:"bytes,10,opt,name=cgroup_parent,json=cgroupParent,proto3" json:"cgroup_parent,omitempty"`
 	// PercentTicks is a compatibility option for docker and should not be used
 	// buf:lint:ignore FIELD_LOWER_SNAKE_CASE
 	PercentTicks         float64  `protobuf:"fixed64,8,opt,name=PercentTicks,proto3" json:"PercentTicks,omitempty"`
@@ -2700,7 +3082,7 @@ func (m *LinuxResources) Reset()         { *m = LinuxResources{} }
 func (m *LinuxResources) String() string { return proto.CompactTextString(m) }
 func (*LinuxResources) ProtoMessage()    {}
 func (*LinuxResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{44}
+	return fileDescriptor_4a8f45747846a74d, []int{52}
 }
 
 func (m *LinuxResources) XXX_Unmarshal(b []byte) error {
@@ -2770,6 +3152,13 @@ func (m *LinuxResources) GetCpusetCgroup() string {
 	return ""
 }
 
+func (m *LinuxResources) GetCgroupParent() string {
+	if m != nil {
+		return m.CgroupParent
+	}
+	return ""
+}
+
 func (m *LinuxResources) GetPercentTicks() float64 {
 	if m != nil {
 		return m.PercentTicks
@@ -2793,7 +3182,7 @@ func (m *Mount) Reset()         { *m = Mount{} }
 func (m *Mount) String() string { return proto.CompactTextString(m) }
 func (*Mount) ProtoMessage()    {}
 func (*Mount) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{45}
+	return fileDescriptor_4a8f45747846a74d, []int{53}
 }
 
 func (m *Mount) XXX_Unmarshal(b []byte) error {
@@ -2857,7 +3246,7 @@ func (m *Device) Reset()         { *m = Device{} }
 func (m *Device) String() string { return proto.CompactTextString(m) }
 func (*Device) ProtoMessage()    {}
 func (*Device) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{46}
+	return fileDescriptor_4a8f45747846a74d, []int{54}
 }
 
 func (m *Device) XXX_Unmarshal(b []byte) error {
@@ -2919,7 +3308,7 @@ func (m *TaskHandle) Reset()         { *m = TaskHandle{} }
 func (m *TaskHandle) String() string { return proto.CompactTextString(m) }
 func (*TaskHandle) ProtoMessage()    {}
 func (*TaskHandle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{47}
+	return fileDescriptor_4a8f45747846a74d, []int{55}
 }
 
 func (m *TaskHandle) XXX_Unmarshal(b []byte) error {
@@ -2987,7 +3376,7 @@ func (m *NetworkOverride) Reset()         { *m = NetworkOverride{} }
 func (m *NetworkOverride) String() string { return proto.CompactTextString(m) }
 func (*NetworkOverride) ProtoMessage()    {}
 func (*NetworkOverride) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{48}
+	return fileDescriptor_4a8f45747846a74d, []int{56}
 }
 
 func (m *NetworkOverride) XXX_Unmarshal(b []byte) error {
@@ -3046,7 +3435,7 @@ func (m *ExitResult) Reset()         { *m = ExitResult{} }
 func (m *ExitResult) String() string { return proto.CompactTextString(m) }
 func (*ExitResult) ProtoMessage()    {}
 func (*ExitResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{49}
+	return fileDescriptor_4a8f45747846a74d, []int{57}
 }
 
 func (m *ExitResult) XXX_Unmarshal(b []byte) error {
@@ -3110,7 +3499,7 @@ func (m *TaskStatus) Reset()         { *m = TaskStatus{} }
 func (m *TaskStatus) String() string { return proto.CompactTextString(m) }
 func (*TaskStatus) ProtoMessage()    {}
 func (*TaskStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{50}
+	return fileDescriptor_4a8f45747846a74d, []int{58}
 }
 
 func (m *TaskStatus) XXX_Unmarshal(b []byte) error {
@@ -3186,7 +3575,7 @@ func (m *TaskDriverStatus) Reset()         { *m = TaskDriverStatus{} }
 func (m *TaskDriverStatus) String() string { return proto.CompactTextString(m) }
 func (*TaskDriverStatus) ProtoMessage()    {}
 func (*TaskDriverStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{51}
+	return fileDescriptor_4a8f45747846a74d, []int{59}
 }
 
 func (m *TaskDriverStatus) XXX_Unmarshal(b []byte) error {
@@ -3232,7 +3621,7 @@ func (m *TaskStats) Reset()         { *m = TaskStats{} }
 func (m *TaskStats) String() string { return proto.CompactTextString(m) }
 func (*TaskStats) ProtoMessage()    {}
 func (*TaskStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{52}
+	return fileDescriptor_4a8f45747846a74d, []int{60}
 }
 
 func (m *TaskStats) XXX_Unmarshal(b []byte) error {
@@ -3295,7 +3684,7 @@ func (m *TaskResourceUsage) Reset()         { *m = TaskResourceUsage{} }
 func (m *TaskResourceUsage) String() string { return proto.CompactTextString(m) }
 func (*TaskResourceUsage) ProtoMessage()    {}
 func (*TaskResourceUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{53}
+	return fileDescriptor_4a8f45747846a74d, []int{61}
 }
 
 func (m *TaskResourceUsage) XXX_Unmarshal(b []byte) error {
@@ -3348,7 +3737,7 @@ func (m *CPUUsage) Reset()         { *m = CPUUsage{} }
 func (m *CPUUsage) String() string { return proto.CompactTextString(m) }
 func (*CPUUsage) ProtoMessage()    {}
 func (*CPUUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{54}
+	return fileDescriptor_4a8f45747846a74d, []int{62}
 }
 
 func (m *CPUUsage) XXX_Unmarshal(b []byte) error {
@@ -3437,7 +3826,7 @@ func (m *MemoryUsage) Reset()         { *m = MemoryUsage{} }
 func (m *MemoryUsage) String() string { return proto.CompactTextString(m) }
 func (*MemoryUsage) ProtoMessage()    {}
 func (*MemoryUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{55}
+	return fileDescriptor_4a8f45747846a74d, []int{63}
 }
 
 func (m *MemoryUsage) XXX_Unmarshal(b []byte) error {
@@ -3536,7 +3925,7 @@ func (m *DriverTaskEvent) Reset()         { *m = DriverTaskEvent{} }
 func (m *DriverTaskEvent) String() string { return proto.CompactTextString(m) }
 func (*DriverTaskEvent) ProtoMessage()    {}
 func (*DriverTaskEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{56}
+	return fileDescriptor_4a8f45747846a74d, []int{64}
 }
 
 func (m *DriverTaskEvent) XXX_Unmarshal(b []byte) error {
@@ -3643,6 +4032,14 @@ func init() {
 	proto.RegisterType((*CreateNetworkResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.CreateNetworkResponse")
 	proto.RegisterType((*DestroyNetworkRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.DestroyNetworkRequest")
 	proto.RegisterType((*DestroyNetworkResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.DestroyNetworkResponse")
+	proto.RegisterType((*CheckpointTaskRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.CheckpointTaskRequest")
+	proto.RegisterType((*CheckpointTaskResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.CheckpointTaskResponse")
+	proto.RegisterType((*RestoreTaskRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.RestoreTaskRequest")
+	proto.RegisterType((*RestoreTaskResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.RestoreTaskResponse")
+	proto.RegisterType((*PrefetchImagesRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.PrefetchImagesRequest")
+	proto.RegisterType((*PrefetchImagesResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.PrefetchImagesResponse")
+	proto.RegisterType((*UpdateTaskResourcesRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.UpdateTaskResourcesRequest")
+	proto.RegisterType((*UpdateTaskResourcesResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.UpdateTaskResourcesResponse")
 	proto.RegisterType((*DriverCapabilities)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverCapabilities")
 	proto.RegisterType((*NetworkIsolationSpec)(nil), "hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec.LabelsEntry")
@@ -3682,243 +4079,257 @@ func init() {
 }
 
 var fileDescriptor_4a8f45747846a74d = []byte{
-	// 3776 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0xcd, 0x6f, 0x1b, 0x49,
-	0x76, 0x57, 0xb3, 0xf9, 0xf9, 0x48, 0x51, 0xad, 0xb2, 0x6c, 0xd3, 0x9c, 0x24, 0xe3, 0xed, 0x60,
-	0x02, 0x61, 0x77, 0x86, 0x9e, 0xd5, 0x22, 0xe3, 0xf1, 0xac, 0x67, 0x3d, 0x1c, 0x8a, 0xb6, 0x34,
-	0x96, 0x28, 0xa5, 0x48, 0xc1, 0xeb, 0x38, 0x3b, 0x9d, 0x16, 0xbb, 0x4c, 0xb5, 0xc5, 0xfe, 0x98,
-	0xae, 0xa6, 0x2c, 0x6d, 0x10, 0x24, 0xd8, 0x00, 0xc1, 0x06, 0x48, 0x90, 0x5c, 0x26, 0x7b, 0xc9,
-	0x69, 0x81, 0x9c, 0xf2, 0x0f, 0x04, 0x1b, 0xec, 0x29, 0x87, 0xfc, 0x13, 0xb9, 0xe4, 0x96, 0x63,
-	0x72, 0xca, 0x35, 0xa8, 0x8f, 0x6e, 0x76, 0x93, 0xf4, 0xb8, 0x49, 0xf9, 0xc4, 0x7e, 0xf5, 0xf1,
-	0xab, 0xc7, 0xf7, 0x5e, 0xbd, 0x7a, 0xf5, 0xea, 0x81, 0xee, 0x8f, 0x27, 0x23, 0xdb, 0xa5, 0xf7,
-	0xac, 0xc0, 0xbe, 0x20, 0x01, 0xbd, 0xe7, 0x07, 0x5e, 0xe8, 0x49, 0xaa, 0xc5, 0x09, 0xf4, 0xc1,
-	0x99, 0x49, 0xcf, 0xec, 0xa1, 0x17, 0xf8, 0x2d, 0xd7, 0x73, 0x4c, 0xab, 0x25, 0xe7, 0xb4, 0xe4,
-	0x1c, 0x31, 0xac, 0xf9, 0x7b, 0x23, 0xcf, 0x1b, 0x8d, 0x89, 0x40, 0x38, 0x9d, 0xbc, 0xbc, 0x67,
-	0x4d, 0x02, 0x33, 0xb4, 0x3d, 0x57, 0xf6, 0xbf, 0x3f, 0xdb, 0x1f, 0xda, 0x0e, 0xa1, 0xa1, 0xe9,
-	0xf8, 0x72, 0xc0, 0x07, 0x11, 0x2f, 0xf4, 0xcc, 0x0c, 0x88, 0x75, 0xef, 0x6c, 0x38, 0xa6, 0x3e,
-	0x19, 0xb2, 0x5f, 0x83, 0x7d, 0xc8, 0x61, 0x1f, 0xce, 0x0c, 0xa3, 0x61, 0x30, 0x19, 0x86, 0x11,
-	0xe7, 0x66, 0x18, 0x06, 0xf6, 0xe9, 0x24, 0x24, 0x62, 0xb4, 0x7e, 0x07, 0x6e, 0x0f, 0x4c, 0x7a,
-	0xde, 0xf1, 0xdc, 0x97, 0xf6, 0xa8, 0x3f, 0x3c, 0x23, 0x8e, 0x89, 0xc9, 0x37, 0x13, 0x42, 0x43,
-	0xfd, 0x4f, 0xa0, 0x31, 0xdf, 0x45, 0x7d, 0xcf, 0xa5, 0x04, 0x7d, 0x01, 0x79, 0xb6, 0x64, 0x43,
-	0xb9, 0xab, 0x6c, 0x57, 0x77, 0x3e, 0x6c, 0xbd, 0x49, 0x04, 0x82, 0x87, 0x96, 0x64, 0xb5, 0xd5,
-	0xf7, 0xc9, 0x10, 0xf3, 0x99, 0xfa, 0x4d, 0xb8, 0xd1, 0x31, 0x7d, 0xf3, 0xd4, 0x1e, 0xdb, 0xa1,
-	0x4d, 0x68, 0xb4, 0xe8, 0x04, 0xb6, 0xd2, 0xcd, 0x72, 0xc1, 0x9f, 0x41, 0x6d, 0x98, 0x68, 0x97,
-	0x0b, 0x3f, 0x68, 0x65, 0x92, 0x7d, 0x6b, 0x97, 0x53, 0x29, 0xe0, 0x14, 0x9c, 0xbe, 0x05, 0xe8,
-	0xb1, 0xed, 0x8e, 0x48, 0xe0, 0x07, 0xb6, 0x1b, 0x46, 0xcc, 0xfc, 0x56, 0x85, 0x1b, 0xa9, 0x66,
-	0xc9, 0xcc, 0x2b, 0x80, 0x58, 0x8e, 0x8c, 0x15, 0x75, 0xbb, 0xba, 0xf3, 0x55, 0x46, 0x56, 0x16,
-	0xe0, 0xb5, 0xda, 0x31, 0x58, 0xd7, 0x0d, 0x83, 0x2b, 0x9c, 0x40, 0x47, 0x5f, 0x43, 0xf1, 0x8c,
-	0x98, 0xe3, 0xf0, 0xac, 0x91, 0xbb, 0xab, 0x6c, 0xd7, 0x77, 0x1e, 0x5f, 0x63, 0x9d, 0x3d, 0x0e,
-	0xd4, 0x0f, 0xcd, 0x90, 0x60, 0x89, 0x8a, 0x3e, 0x02, 0x24, 0xbe, 0x0c, 0x8b, 0xd0, 0x61, 0x60,
-	0xfb, 0xcc, 0x24, 0x1b, 0xea, 0x5d, 0x65, 0xbb, 0x82, 0x37, 0x45, 0xcf, 0xee, 0xb4, 0xa3, 0xe9,
-	0xc3, 0xc6, 0x0c, 0xb7, 0x48, 0x03, 0xf5, 0x9c, 0x5c, 0x71, 0x8d, 0x54, 0x30, 0xfb, 0x44, 0x4f,
-	0xa0, 0x70, 0x61, 0x8e, 0x27, 0x84, 0xb3, 0x5c, 0xdd, 0xf9, 0xe1, 0xdb, 0xcc, 0x43, 0x9a, 0xe8,
-	0x54, 0x0e, 0x58, 0xcc, 0xff, 0x2c, 0xf7, 0xa9, 0xa2, 0x3f, 0x80, 0x6a, 0x82, 0x6f, 0x54, 0x07,
-	0x38, 0xe9, 0xed, 0x76, 0x07, 0xdd, 0xce, 0xa0, 0xbb, 0xab, 0xad, 0xa1, 0x75, 0xa8, 0x9c, 0xf4,
-	0xf6, 0xba, 0xed, 0x83, 0xc1, 0xde, 0x73, 0x4d, 0x41, 0x55, 0x28, 0x45, 0x44, 0x4e, 0xbf, 0x04,
-	0x84, 0xc9, 0xd0, 0xbb, 0x20, 0x01, 0x33, 0x64, 0xa9, 0x55, 0x74, 0x1b, 0x4a, 0xa1, 0x49, 0xcf,
-	0x0d, 0xdb, 0x92, 0x3c, 0x17, 0x19, 0xb9, 0x6f, 0xa1, 0x7d, 0x28, 0x9e, 0x99, 0xae, 0x35, 0x7e,
-	0x3b, 0xdf, 0x69, 0x51, 0x33, 0xf0, 0x3d, 0x3e, 0x11, 0x4b, 0x00, 0x66, 0xdd, 0xa9, 0x95, 0x85,
-	0x02, 0xf4, 0xe7, 0xa0, 0xf5, 0x43, 0x33, 0x08, 0x93, 0xec, 0x74, 0x21, 0xcf, 0xd6, 0x97, 0x16,
-	0xbd, 0xcc, 0x9a, 0x62, 0x67, 0x62, 0x3e, 0x5d, 0xff, 0xdf, 0x1c, 0x6c, 0x26, 0xb0, 0xa5, 0xa5,
-	0x3e, 0x83, 0x62, 0x40, 0xe8, 0x64, 0x1c, 0x72, 0xf8, 0xfa, 0xce, 0xa3, 0x8c, 0xf0, 0x73, 0x48,
-	0x2d, 0xcc, 0x61, 0xb0, 0x84, 0x43, 0xdb, 0xa0, 0x89, 0x19, 0x06, 0x09, 0x02, 0x2f, 0x30, 0x1c,
-	0x3a, 0xe2, 0x52, 0xab, 0xe0, 0xba, 0x68, 0xef, 0xb2, 0xe6, 0x43, 0x3a, 0x4a, 0x48, 0x55, 0xbd,
-	0xa6, 0x54, 0x91, 0x09, 0x9a, 0x4b, 0xc2, 0xd7, 0x5e, 0x70, 0x6e, 0x30, 0xd1, 0x06, 0xb6, 0x45,
-	0x1a, 0x79, 0x0e, 0xfa, 0x49, 0x46, 0xd0, 0x9e, 0x98, 0x7e, 0x24, 0x67, 0xe3, 0x0d, 0x37, 0xdd,
-	0xa0, 0xff, 0x00, 0x8a, 0xe2, 0x9f, 0x32, 0x4b, 0xea, 0x9f, 0x74, 0x3a, 0xdd, 0x7e, 0x5f, 0x5b,
-	0x43, 0x15, 0x28, 0xe0, 0xee, 0x00, 0x33, 0x0b, 0xab, 0x40, 0xe1, 0x71, 0x7b, 0xd0, 0x3e, 0xd0,
-	0x72, 0xfa, 0xf7, 0x61, 0xe3, 0x99, 0x69, 0x87, 0x59, 0x8c, 0x4b, 0xf7, 0x40, 0x9b, 0x8e, 0x95,
-	0xda, 0xd9, 0x4f, 0x69, 0x27, 0xbb, 0x68, 0xba, 0x97, 0x76, 0x38, 0xa3, 0x0f, 0x0d, 0x54, 0x12,
-	0x04, 0x52, 0x05, 0xec, 0x53, 0x7f, 0x0d, 0x1b, 0xfd, 0xd0, 0xf3, 0x33, 0x59, 0xfe, 0x8f, 0xa0,
-	0xc4, 0x4e, 0x1b, 0x6f, 0x12, 0x4a, 0xd3, 0xbf, 0xd3, 0x12, 0xa7, 0x51, 0x2b, 0x3a, 0x8d, 0x5a,
-	0xbb, 0xf2, 0xb4, 0xc2, 0xd1, 0x48, 0x74, 0x0b, 0x8a, 0xd4, 0x1e, 0xb9, 0xe6, 0x58, 0x7a, 0x0b,
-	0x49, 0xe9, 0x88, 0x19, 0x79, 0xb4, 0xb0, 0x34, 0xfc, 0x0e, 0xa0, 0x5d, 0x42, 0xc3, 0xc0, 0xbb,
-	0xca, 0xc4, 0xcf, 0x16, 0x14, 0x5e, 0x7a, 0xc1, 0x50, 0x6c, 0xc4, 0x32, 0x16, 0x04, 0xdb, 0x54,
-	0x29, 0x10, 0x89, 0xfd, 0x11, 0xa0, 0x7d, 0x97, 0x9d, 0x29, 0xd9, 0x14, 0xf1, 0x0f, 0x39, 0xb8,
-	0x91, 0x1a, 0x2f, 0x95, 0xb1, 0xfa, 0x3e, 0x64, 0x8e, 0x69, 0x42, 0xc5, 0x3e, 0x44, 0x47, 0x50,
-	0x14, 0x23, 0xa4, 0x24, 0xef, 0x2f, 0x01, 0x24, 0x8e, 0x29, 0x09, 0x27, 0x61, 0x16, 0x1a, 0xbd,
-	0xfa, 0x6e, 0x8d, 0xfe, 0x35, 0x68, 0xd1, 0xff, 0xa0, 0x6f, 0xd5, 0xcd, 0x57, 0x70, 0x63, 0xe8,
-	0x8d, 0xc7, 0x64, 0xc8, 0xac, 0xc1, 0xb0, 0xdd, 0x90, 0x04, 0x17, 0xe6, 0xf8, 0xed, 0x76, 0x83,
-	0xa6, 0xb3, 0xf6, 0xe5, 0x24, 0xfd, 0x05, 0x6c, 0x26, 0x16, 0x96, 0x8a, 0x78, 0x0c, 0x05, 0xca,
-	0x1a, 0xa4, 0x26, 0x3e, 0x5e, 0x52, 0x13, 0x14, 0x8b, 0xe9, 0xfa, 0x0d, 0x01, 0xde, 0xbd, 0x20,
-	0x6e, 0xfc, 0xb7, 0xf4, 0x5d, 0xd8, 0xec, 0x73, 0x33, 0xcd, 0x64, 0x87, 0x53, 0x13, 0xcf, 0xa5,
-	0x4c, 0x7c, 0x0b, 0x50, 0x12, 0x45, 0x1a, 0xe2, 0x15, 0x6c, 0x74, 0x2f, 0xc9, 0x30, 0x13, 0x72,
-	0x03, 0x4a, 0x43, 0xcf, 0x71, 0x4c, 0xd7, 0x6a, 0xe4, 0xee, 0xaa, 0xdb, 0x15, 0x1c, 0x91, 0xc9,
-	0xbd, 0xa8, 0x66, 0xdd, 0x8b, 0xfa, 0xdf, 0x29, 0xa0, 0x4d, 0xd7, 0x96, 0x82, 0x64, 0xdc, 0x87,
-	0x16, 0x03, 0x62, 0x6b, 0xd7, 0xb0, 0xa4, 0x64, 0x7b, 0xe4, 0x2e, 0x44, 0x3b, 0x09, 0x82, 0x84,
-	0x3b, 0x52, 0xaf, 0xe9, 0x8e, 0xf4, 0x3d, 0xf8, 0x9d, 0x88, 0x9d, 0x7e, 0x18, 0x10, 0xd3, 0xb1,
-	0xdd, 0xd1, 0xfe, 0xd1, 0x91, 0x4f, 0x04, 0xe3, 0x08, 0x41, 0xde, 0x32, 0x43, 0x53, 0x32, 0xc6,
-	0xbf, 0xd9, 0xa6, 0x1f, 0x8e, 0x3d, 0x1a, 0x6f, 0x7a, 0x4e, 0xe8, 0xff, 0xa1, 0x42, 0x63, 0x0e,
-	0x2a, 0x12, 0xef, 0x0b, 0x28, 0x50, 0x12, 0x4e, 0x7c, 0x69, 0x2a, 0xdd, 0xcc, 0x0c, 0x2f, 0xc6,
-	0x6b, 0xf5, 0x19, 0x18, 0x16, 0x98, 0x68, 0x04, 0xe5, 0x30, 0xbc, 0x32, 0xa8, 0xfd, 0xf3, 0x28,
-	0x20, 0x38, 0xb8, 0x2e, 0xfe, 0x80, 0x04, 0x8e, 0xed, 0x9a, 0xe3, 0xbe, 0xfd, 0x73, 0x82, 0x4b,
-	0x61, 0x78, 0xc5, 0x3e, 0xd0, 0x73, 0x66, 0xf0, 0x96, 0xed, 0x4a, 0xb1, 0x77, 0x56, 0x5d, 0x25,
-	0x21, 0x60, 0x2c, 0x10, 0x9b, 0x07, 0x50, 0xe0, 0xff, 0x69, 0x15, 0x43, 0xd4, 0x40, 0x0d, 0xc3,
-	0x2b, 0xce, 0x54, 0x19, 0xb3, 0xcf, 0xe6, 0x43, 0xa8, 0x25, 0xff, 0x01, 0x33, 0xa4, 0x33, 0x62,
-	0x8f, 0xce, 0x84, 0x81, 0x15, 0xb0, 0xa4, 0x98, 0x26, 0x5f, 0xdb, 0x96, 0x0c, 0x59, 0x0b, 0x58,
-	0x10, 0xfa, 0xbf, 0xe6, 0xe0, 0xce, 0x02, 0xc9, 0x48, 0x63, 0x7d, 0x91, 0x32, 0xd6, 0x77, 0x24,
-	0x85, 0xc8, 0xe2, 0x5f, 0xa4, 0x2c, 0xfe, 0x1d, 0x82, 0xb3, 0x6d, 0x73, 0x0b, 0x8a, 0xe4, 0xd2,
-	0x0e, 0x89, 0x25, 0x45, 0x25, 0xa9, 0xc4, 0x76, 0xca, 0x5f, 0x77, 0x3b, 0x1d, 0xc2, 0x56, 0x27,
-	0x20, 0x66, 0x48, 0xa4, 0x2b, 0x8f, 0xec, 0xff, 0x0e, 0x94, 0xcd, 0xf1, 0xd8, 0x1b, 0x4e, 0xd5,
-	0x5a, 0xe2, 0xf4, 0xbe, 0x85, 0x9a, 0x50, 0x3e, 0xf3, 0x68, 0xe8, 0x9a, 0x0e, 0x91, 0xce, 0x2b,
-	0xa6, 0xf5, 0x6f, 0x15, 0xb8, 0x39, 0x83, 0x27, 0xb5, 0x70, 0x0a, 0x75, 0x9b, 0x7a, 0x63, 0xfe,
-	0x07, 0x8d, 0xc4, 0x0d, 0xef, 0xc7, 0xcb, 0x1d, 0x35, 0xfb, 0x11, 0x06, 0xbf, 0xf0, 0xad, 0xdb,
-	0x49, 0x92, 0x5b, 0x1c, 0x5f, 0xdc, 0x92, 0x3b, 0x3d, 0x22, 0xf5, 0x7f, 0x54, 0xe0, 0xa6, 0x3c,
-	0xe1, 0xb3, 0xff, 0xd1, 0x79, 0x96, 0x73, 0xef, 0x9a, 0x65, 0xbd, 0x01, 0xb7, 0x66, 0xf9, 0x92,
-	0x3e, 0xff, 0xff, 0xf2, 0x80, 0xe6, 0x6f, 0x97, 0xe8, 0x7b, 0x50, 0xa3, 0xc4, 0xb5, 0x0c, 0x71,
-	0x5e, 0x88, 0xa3, 0xac, 0x8c, 0xab, 0xac, 0x4d, 0x1c, 0x1c, 0x94, 0xb9, 0x40, 0x72, 0x29, 0xb9,
-	0x2d, 0x63, 0xfe, 0x8d, 0xce, 0xa0, 0xf6, 0x92, 0x1a, 0xf1, 0xda, 0xdc, 0xa0, 0xea, 0x99, 0xdd,
-	0xda, 0x3c, 0x1f, 0xad, 0xc7, 0xfd, 0xf8, 0x7f, 0xe1, 0xea, 0x4b, 0x1a, 0x13, 0xe8, 0x97, 0x0a,
-	0xdc, 0x8e, 0xc2, 0x8a, 0xa9, 0xf8, 0x1c, 0xcf, 0x22, 0xb4, 0x91, 0xbf, 0xab, 0x6e, 0xd7, 0x77,
-	0x8e, 0xaf, 0x21, 0xbf, 0xb9, 0xc6, 0x43, 0xcf, 0x22, 0xf8, 0xa6, 0xbb, 0xa0, 0x95, 0xa2, 0x16,
-	0xdc, 0x70, 0x26, 0x34, 0x34, 0x84, 0x15, 0x18, 0x72, 0x50, 0xa3, 0xc0, 0xe5, 0xb2, 0xc9, 0xba,
-	0x52, 0xb6, 0x8a, 0xce, 0x61, 0xdd, 0xf1, 0x26, 0x6e, 0x68, 0x0c, 0xf9, 0xfd, 0x87, 0x36, 0x8a,
-	0x4b, 0x5d, 0x8c, 0x17, 0x48, 0xe9, 0x90, 0xc1, 0x89, 0xdb, 0x14, 0xc5, 0x35, 0x27, 0x41, 0x31,
-	0x45, 0x06, 0xc4, 0xf1, 0x42, 0x62, 0x30, 0x7f, 0x49, 0x1b, 0x25, 0xa1, 0x48, 0xd1, 0xc6, 0x5c,
-	0x03, 0xd5, 0x5b, 0x50, 0x4d, 0x88, 0x19, 0x95, 0x21, 0xdf, 0x3b, 0xea, 0x75, 0xb5, 0x35, 0x04,
-	0x50, 0xec, 0xec, 0xe1, 0xa3, 0xa3, 0x81, 0xb8, 0x35, 0xec, 0x1f, 0xb6, 0x9f, 0x74, 0xb5, 0x9c,
-	0xde, 0x85, 0x5a, 0x72, 0x41, 0x84, 0xa0, 0x7e, 0xd2, 0x7b, 0xda, 0x3b, 0x7a, 0xd6, 0x33, 0x0e,
-	0x8f, 0x4e, 0x7a, 0x03, 0x76, 0xdf, 0xa8, 0x03, 0xb4, 0x7b, 0xcf, 0xa7, 0xf4, 0x3a, 0x54, 0x7a,
-	0x47, 0x11, 0xa9, 0x34, 0x73, 0x9a, 0xa2, 0xff, 0xbb, 0x0a, 0x5b, 0x8b, 0x64, 0x8f, 0x2c, 0xc8,
-	0x33, 0x3d, 0xca, 0x1b, 0xdf, 0xbb, 0x57, 0x23, 0x47, 0x67, 0xe6, 0xeb, 0x9b, 0xd2, 0xc5, 0x57,
-	0x30, 0xff, 0x46, 0x06, 0x14, 0xc7, 0xe6, 0x29, 0x19, 0xd3, 0x86, 0xca, 0x73, 0x22, 0x4f, 0xae,
-	0xb3, 0xf6, 0x01, 0x47, 0x12, 0x09, 0x11, 0x09, 0x8b, 0x06, 0x50, 0x65, 0x4e, 0x8c, 0x0a, 0xd1,
-	0x49, 0xbf, 0xba, 0x93, 0x71, 0x95, 0xbd, 0xe9, 0x4c, 0x9c, 0x84, 0x69, 0x3e, 0x80, 0x6a, 0x62,
-	0xb1, 0x05, 0xf9, 0x8c, 0xad, 0x64, 0x3e, 0xa3, 0x92, 0x4c, 0x4e, 0x3c, 0x9a, 0xd7, 0x01, 0x93,
-	0x11, 0x33, 0x82, 0xbd, 0xa3, 0xfe, 0x40, 0xdc, 0x1c, 0x9f, 0xe0, 0xa3, 0x93, 0x63, 0x4d, 0x61,
-	0x8d, 0x83, 0x76, 0xff, 0xa9, 0x96, 0x8b, 0x6d, 0x44, 0xd5, 0x3b, 0x50, 0x4d, 0xf0, 0x95, 0xf2,
-	0xda, 0x4a, 0xda, 0x6b, 0x33, 0xbf, 0x69, 0x5a, 0x56, 0x40, 0x28, 0x95, 0x7c, 0x44, 0xa4, 0xfe,
-	0x02, 0x2a, 0xbb, 0xbd, 0xbe, 0x84, 0x68, 0x40, 0x89, 0x92, 0x80, 0xfd, 0x6f, 0x9e, 0x99, 0xaa,
-	0xe0, 0x88, 0x64, 0xe0, 0x94, 0x98, 0xc1, 0xf0, 0x8c, 0x50, 0x79, 0xd6, 0xc7, 0x34, 0x9b, 0xe5,
-	0xf1, 0x0c, 0x8f, 0xd0, 0x5d, 0x05, 0x47, 0xa4, 0xfe, 0x3f, 0x25, 0x80, 0x69, 0xb6, 0x01, 0xd5,
-	0x21, 0x17, 0xfb, 0xe0, 0x9c, 0x6d, 0x31, 0x3b, 0x48, 0x9c, 0x31, 0xfc, 0x1b, 0xed, 0xc0, 0x4d,
-	0x87, 0x8e, 0x7c, 0x73, 0x78, 0x6e, 0xc8, 0x24, 0x81, 0xd8, 0xaa, 0xdc, 0x9f, 0xd5, 0xf0, 0x0d,
-	0xd9, 0x29, 0x77, 0xa2, 0xc0, 0x3d, 0x00, 0x95, 0xb8, 0x17, 0xdc, 0xf7, 0x54, 0x77, 0x3e, 0x5b,
-	0x3a, 0x0b, 0xd2, 0xea, 0xba, 0x17, 0xc2, 0x56, 0x18, 0x0c, 0x32, 0x00, 0x2c, 0x72, 0x61, 0x0f,
-	0x89, 0xc1, 0x40, 0x0b, 0x1c, 0xf4, 0x8b, 0xe5, 0x41, 0x77, 0x39, 0x46, 0x0c, 0x5d, 0xb1, 0x22,
-	0x1a, 0xf5, 0xa0, 0x12, 0x10, 0xea, 0x4d, 0x82, 0x21, 0x11, 0x0e, 0x28, 0xfb, 0x45, 0x05, 0x47,
-	0xf3, 0xf0, 0x14, 0x02, 0xed, 0x42, 0x91, 0xfb, 0x1d, 0xe6, 0x61, 0xd4, 0xef, 0x4c, 0xa9, 0xa6,
-	0xc1, 0xb8, 0x27, 0xc1, 0x72, 0x2e, 0x7a, 0x02, 0x25, 0xc1, 0x22, 0x6d, 0x94, 0x39, 0xcc, 0x47,
-	0x59, 0x9d, 0x22, 0x9f, 0x85, 0xa3, 0xd9, 0x4c, 0xab, 0x13, 0x4a, 0x82, 0x46, 0x45, 0x68, 0x95,
-	0x7d, 0xa3, 0xf7, 0xa0, 0x22, 0xce, 0x60, 0xcb, 0x0e, 0x1a, 0x20, 0x8c, 0x93, 0x37, 0xec, 0xda,
-	0x01, 0x7a, 0x1f, 0xaa, 0x22, 0xd6, 0x32, 0xb8, 0x57, 0xa8, 0xf2, 0x6e, 0x10, 0x4d, 0xc7, 0xcc,
-	0x37, 0x88, 0x01, 0x24, 0x08, 0xc4, 0x80, 0x5a, 0x3c, 0x80, 0x04, 0x01, 0x1f, 0xf0, 0x07, 0xb0,
-	0xc1, 0x23, 0xd4, 0x51, 0xe0, 0x4d, 0x7c, 0x83, 0xdb, 0xd4, 0x3a, 0x1f, 0xb4, 0xce, 0x9a, 0x9f,
-	0xb0, 0xd6, 0x1e, 0x33, 0xae, 0x3b, 0x50, 0x7e, 0xe5, 0x9d, 0x8a, 0x01, 0x75, 0xb1, 0x0f, 0x5e,
-	0x79, 0xa7, 0x51, 0x57, 0x1c, 0x25, 0x6c, 0xa4, 0xa3, 0x84, 0x6f, 0xe0, 0xd6, 0xfc, 0x71, 0xc7,
-	0xa3, 0x05, 0xed, 0xfa, 0xd1, 0xc2, 0x96, 0xbb, 0xc8, 0x0f, 0x7f, 0x09, 0xaa, 0xe5, 0xd2, 0xc6,
-	0xe6, 0x52, 0xc6, 0x11, 0xef, 0x63, 0xcc, 0x26, 0x37, 0x3f, 0x81, 0x72, 0x64, 0x7d, 0xcb, 0xf8,
-	0xa5, 0xe6, 0x43, 0xa8, 0xa7, 0x6d, 0x77, 0x29, 0xaf, 0xf6, 0xcf, 0x39, 0xa8, 0xc4, 0x56, 0x8a,
-	0x5c, 0xb8, 0xc1, 0xa5, 0xc8, 0x42, 0x34, 0x63, 0x6a, 0xf4, 0x22, 0x30, 0xfc, 0x3c, 0xe3, 0xff,
-	0x6a, 0x47, 0x08, 0xf2, 0x86, 0x2a, 0x77, 0x00, 0x8a, 0x91, 0xa7, 0xeb, 0x7d, 0x0d, 0x1b, 0x63,
-	0xdb, 0x9d, 0x5c, 0x26, 0xd6, 0x12, 0x11, 0xdd, 0x1f, 0x66, 0x5c, 0xeb, 0x80, 0xcd, 0x9e, 0xae,
-	0x51, 0x1f, 0xa7, 0x68, 0xb4, 0x07, 0x05, 0xdf, 0x0b, 0xc2, 0xe8, 0x90, 0xca, 0x7a, 0x7c, 0x1c,
-	0x7b, 0x41, 0x78, 0x68, 0xfa, 0x3e, 0xbb, 0xb4, 0x08, 0x00, 0xfd, 0xdb, 0x1c, 0xdc, 0x5a, 0xfc,
-	0xc7, 0x50, 0x0f, 0xd4, 0xa1, 0x3f, 0x91, 0x42, 0x7a, 0xb8, 0xac, 0x90, 0x3a, 0xfe, 0x64, 0xca,
-	0x3f, 0x03, 0x42, 0xcf, 0xa0, 0xe8, 0x10, 0xc7, 0x0b, 0xae, 0xa4, 0x2c, 0x1e, 0x2d, 0x0b, 0x79,
-	0xc8, 0x67, 0x4f, 0x51, 0x25, 0x1c, 0xc2, 0x50, 0x96, 0xd6, 0x4b, 0xa5, 0x9f, 0x5c, 0x32, 0xad,
-	0x14, 0x41, 0xe2, 0x18, 0x47, 0xff, 0x04, 0x6e, 0x2e, 0xfc, 0x2b, 0xe8, 0x77, 0x01, 0x86, 0xfe,
-	0xc4, 0xe0, 0x69, 0x7f, 0x61, 0x41, 0x2a, 0xae, 0x0c, 0xfd, 0x49, 0x9f, 0x37, 0xe8, 0x2f, 0xa0,
-	0xf1, 0x26, 0x7e, 0x99, 0xf7, 0x11, 0x1c, 0x1b, 0xce, 0x29, 0x97, 0x81, 0x8a, 0xcb, 0xa2, 0xe1,
-	0xf0, 0x14, 0xe9, 0xb0, 0x1e, 0x75, 0x9a, 0x97, 0x6c, 0x80, 0xca, 0x07, 0x54, 0xe5, 0x00, 0xf3,
-	0xf2, 0xf0, 0x54, 0xff, 0x55, 0x0e, 0x36, 0x66, 0x58, 0x66, 0x57, 0x37, 0xe1, 0xf1, 0xa2, 0x4b,
-	0xb1, 0xa0, 0x98, 0xfb, 0x1b, 0xda, 0x56, 0x94, 0x4e, 0xe5, 0xdf, 0xfc, 0xe0, 0xf3, 0x65, 0xaa,
-	0x33, 0x67, 0xfb, 0x6c, 0xfb, 0x38, 0xa7, 0x76, 0x48, 0x79, 0x14, 0x52, 0xc0, 0x82, 0x40, 0xcf,
-	0xa1, 0x1e, 0x10, 0x7e, 0xe0, 0x5a, 0x86, 0xb0, 0xb2, 0xc2, 0x52, 0x56, 0x26, 0x39, 0x64, 0xc6,
-	0x86, 0xd7, 0x23, 0x24, 0x46, 0x51, 0xf4, 0x0c, 0xd6, 0xad, 0x2b, 0xd7, 0x74, 0xec, 0xa1, 0x44,
-	0x2e, 0xae, 0x8c, 0x5c, 0x93, 0x40, 0x1c, 0x58, 0x7f, 0x00, 0xd5, 0x44, 0x27, 0xfb, 0x63, 0x3c,
-	0xdc, 0x92, 0x32, 0x11, 0x44, 0xda, 0x5b, 0x14, 0xa4, 0xb7, 0xd0, 0x4f, 0xa1, 0x9a, 0xd8, 0x17,
-	0xcb, 0x4c, 0x65, 0xf2, 0x0c, 0x3d, 0x2e, 0xcf, 0x02, 0xce, 0x85, 0x1e, 0xba, 0x0d, 0x25, 0x16,
-	0xea, 0x18, 0xb6, 0xcf, 0x25, 0x5a, 0xc1, 0x45, 0x46, 0xee, 0xfb, 0xfa, 0x6f, 0x72, 0x50, 0x4f,
-	0x6f, 0xe9, 0xc8, 0x8e, 0x7c, 0x12, 0xd8, 0x9e, 0x95, 0xb0, 0xa3, 0x63, 0xde, 0xc0, 0x6c, 0x85,
-	0x75, 0x7f, 0x33, 0xf1, 0x42, 0x33, 0xb2, 0x95, 0xa1, 0x3f, 0xf9, 0x23, 0x46, 0xcf, 0xd8, 0xa0,
-	0x3a, 0x63, 0x83, 0xe8, 0x43, 0x40, 0xd2, 0x94, 0xc6, 0xb6, 0x63, 0x87, 0xc6, 0xe9, 0x55, 0x48,
-	0x84, 0x8e, 0x55, 0xac, 0x89, 0x9e, 0x03, 0xd6, 0xf1, 0x25, 0x6b, 0x67, 0x86, 0xe7, 0x79, 0x8e,
-	0x41, 0x87, 0x5e, 0x40, 0x0c, 0xd3, 0x7a, 0xc5, 0x6f, 0x2d, 0x2a, 0xae, 0x7a, 0x9e, 0xd3, 0x67,
-	0x6d, 0x6d, 0xeb, 0x15, 0x3b, 0xf9, 0x86, 0xfe, 0x84, 0x92, 0xd0, 0x60, 0x3f, 0x3c, 0x58, 0xa8,
-	0x60, 0x10, 0x4d, 0x1d, 0x7f, 0x42, 0xd1, 0xef, 0xc3, 0x7a, 0x34, 0x80, 0x1f, 0x7e, 0xf2, 0xd4,
-	0xad, 0xc9, 0x21, 0xbc, 0x0d, 0xe9, 0x50, 0x3b, 0x26, 0xc1, 0x90, 0xb8, 0xe1, 0xc0, 0x1e, 0x9e,
-	0xb3, 0xf3, 0x5d, 0xd9, 0x56, 0x70, 0xaa, 0xed, 0xab, 0x7c, 0xb9, 0xa4, 0x95, 0x71, 0xb4, 0x9a,
-	0x43, 0x1c, 0xaa, 0xff, 0x0c, 0x0a, 0x3c, 0x44, 0x60, 0x32, 0xe1, 0xc7, 0x2b, 0x3f, 0x7d, 0x65,
-	0x68, 0xc9, 0x1a, 0xf8, 0xd9, 0xfb, 0x1e, 0x54, 0xb8, 0xec, 0x13, 0x11, 0x3d, 0x8f, 0x3b, 0x79,
-	0x67, 0x13, 0xca, 0x01, 0x31, 0x2d, 0xcf, 0x1d, 0x47, 0xc9, 0xa0, 0x98, 0xd6, 0xbf, 0x81, 0xa2,
-	0x38, 0x67, 0xae, 0x81, 0xff, 0x11, 0x20, 0xf1, 0xbf, 0x99, 0x3e, 0x1d, 0x9b, 0x52, 0x19, 0x85,
-	0xf2, 0x17, 0x48, 0xd1, 0x73, 0x3c, 0xed, 0xd0, 0xff, 0x53, 0x11, 0xf1, 0xa8, 0x78, 0x1b, 0x62,
-	0x81, 0x2b, 0x33, 0x72, 0x76, 0x5b, 0x16, 0x49, 0xa8, 0x88, 0x44, 0xfb, 0x50, 0x94, 0x61, 0x67,
-	0x6e, 0xd5, 0xa7, 0x35, 0x09, 0x10, 0xa5, 0xa4, 0x89, 0xbc, 0x90, 0x2f, 0x9b, 0x92, 0x26, 0x22,
-	0x25, 0x4d, 0xd8, 0x6d, 0x52, 0x06, 0xc4, 0x02, 0x2e, 0xcf, 0xe3, 0xe1, 0xaa, 0x15, 0xe7, 0xfd,
-	0x89, 0xfe, 0xdf, 0x4a, 0xec, 0xa6, 0xa2, 0xfc, 0x3c, 0xfa, 0x1a, 0xca, 0x6c, 0xc7, 0x1b, 0x8e,
-	0xe9, 0xcb, 0xd7, 0xe6, 0xce, 0x6a, 0xa9, 0xff, 0xe8, 0x10, 0x13, 0xe1, 0x6c, 0xc9, 0x17, 0x14,
-	0x73, 0x77, 0xec, 0x2a, 0x11, 0xb9, 0x3b, 0xf6, 0x8d, 0x3e, 0x80, 0xba, 0x39, 0x09, 0x3d, 0xc3,
-	0xb4, 0x2e, 0x48, 0x10, 0xda, 0x94, 0x48, 0xdd, 0xaf, 0xb3, 0xd6, 0x76, 0xd4, 0xd8, 0xfc, 0x0c,
-	0x6a, 0x49, 0xcc, 0xb7, 0x85, 0x19, 0x85, 0x64, 0x98, 0xf1, 0xa7, 0x00, 0xd3, 0x5c, 0x17, 0xb3,
-	0x11, 0x72, 0x69, 0xb3, 0x5b, 0xbd, 0xbc, 0xbb, 0x16, 0x70, 0x99, 0x35, 0x74, 0xd8, 0x7d, 0x2a,
-	0x9d, 0x88, 0x2f, 0x44, 0x89, 0x78, 0xb6, 0x99, 0xd9, 0xfe, 0x3b, 0xb7, 0xc7, 0xe3, 0x38, 0xff,
-	0x56, 0xf1, 0x3c, 0xe7, 0x29, 0x6f, 0xd0, 0x7f, 0x9b, 0x13, 0xb6, 0x22, 0x9e, 0x54, 0x32, 0xdd,
-	0x5d, 0xde, 0x95, 0xaa, 0x1f, 0x00, 0xd0, 0xd0, 0x0c, 0x58, 0xcc, 0x64, 0x46, 0x19, 0xc0, 0xe6,
-	0x5c, 0x26, 0x7f, 0x10, 0xd5, 0x78, 0xe0, 0x8a, 0x1c, 0xdd, 0x0e, 0xd1, 0xe7, 0x50, 0x1b, 0x7a,
-	0x8e, 0x3f, 0x26, 0x72, 0x72, 0xe1, 0xad, 0x93, 0xab, 0xf1, 0xf8, 0x76, 0x98, 0xc8, 0x3b, 0x16,
-	0xaf, 0x9b, 0x77, 0xfc, 0x8d, 0x22, 0x5e, 0x86, 0x92, 0x0f, 0x53, 0x68, 0xb4, 0xa0, 0xfa, 0xe1,
-	0xc9, 0x8a, 0xaf, 0x5c, 0xdf, 0x55, 0xfa, 0xd0, 0xfc, 0x3c, 0x4b, 0xad, 0xc1, 0x9b, 0xa3, 0xd8,
-	0x7f, 0x53, 0xa1, 0x12, 0x3f, 0x0a, 0xcd, 0xe9, 0xfe, 0x53, 0xa8, 0xc4, 0x05, 0x36, 0xd2, 0x41,
-	0x7c, 0xa7, 0x7a, 0xe2, 0xc1, 0xe8, 0x25, 0x20, 0x73, 0x34, 0x8a, 0xa3, 0x53, 0x63, 0x42, 0xcd,
-	0x51, 0xf4, 0x24, 0xf7, 0xe9, 0x12, 0x72, 0x88, 0x8e, 0xb3, 0x13, 0x36, 0x1f, 0x6b, 0xe6, 0x68,
-	0x94, 0x6a, 0x41, 0x7f, 0x06, 0x37, 0xd3, 0x6b, 0x18, 0xa7, 0x57, 0x86, 0x6f, 0x5b, 0xf2, 0x8e,
-	0xbc, 0xb7, 0xec, 0xbb, 0x58, 0x2b, 0x05, 0xff, 0xe5, 0xd5, 0xb1, 0x6d, 0x09, 0x99, 0xa3, 0x60,
-	0xae, 0xa3, 0xf9, 0x17, 0x70, 0xfb, 0x0d, 0xc3, 0x17, 0xe8, 0xa0, 0x97, 0xae, 0xf7, 0x58, 0x5d,
-	0x08, 0x09, 0xed, 0xfd, 0x5a, 0x11, 0xcf, 0x77, 0x69, 0x99, 0xb4, 0x93, 0x61, 0xf5, 0xbd, 0x8c,
-	0xeb, 0x74, 0x8e, 0x4f, 0x04, 0x3c, 0x8f, 0xa4, 0xbf, 0x9a, 0x89, 0xa4, 0xb3, 0xc6, 0x4f, 0x22,
-	0x20, 0x15, 0x40, 0x12, 0x41, 0xff, 0x17, 0x15, 0xca, 0x11, 0x3a, 0xbf, 0xe1, 0x5e, 0xd1, 0x90,
-	0x38, 0x46, 0x9c, 0x7e, 0x53, 0x30, 0x88, 0x26, 0x9e, 0x14, 0x7a, 0x0f, 0x2a, 0xec, 0x22, 0x2d,
-	0xba, 0x73, 0xbc, 0xbb, 0xcc, 0x1a, 0x78, 0xe7, 0xfb, 0x50, 0x0d, 0xbd, 0xd0, 0x1c, 0x1b, 0x21,
-	0x3f, 0xde, 0x55, 0x31, 0x9b, 0x37, 0xf1, 0xc3, 0x1d, 0xfd, 0x00, 0x36, 0xc3, 0xb3, 0xc0, 0x0b,
-	0xc3, 0x31, 0x0b, 0x2d, 0x79, 0xa0, 0x23, 0xe2, 0x92, 0x3c, 0xd6, 0xe2, 0x0e, 0x11, 0x00, 0x51,
-	0xe6, 0xbd, 0xa7, 0x83, 0x99, 0xe9, 0x72, 0x27, 0x92, 0xc7, 0xeb, 0x71, 0x2b, 0x33, 0x6d, 0x76,
-	0x78, 0xfa, 0x22, 0x80, 0xe0, 0xbe, 0x42, 0xc1, 0x11, 0x89, 0x0c, 0xd8, 0x70, 0x88, 0x49, 0x27,
-	0x01, 0xb1, 0x8c, 0x97, 0x36, 0x19, 0x5b, 0x22, 0x31, 0x51, 0xcf, 0x7c, 0x3b, 0x88, 0xc4, 0xd2,
-	0x7a, 0xcc, 0x67, 0xe3, 0x7a, 0x04, 0x27, 0x68, 0x16, 0x39, 0x88, 0x2f, 0xb4, 0x01, 0xd5, 0xfe,
-	0xf3, 0xfe, 0xa0, 0x7b, 0x68, 0x1c, 0x1e, 0xed, 0x76, 0x65, 0x49, 0x4f, 0xbf, 0x8b, 0x05, 0xa9,
-	0xb0, 0xfe, 0xc1, 0xd1, 0xa0, 0x7d, 0x60, 0x0c, 0xf6, 0x3b, 0x4f, 0xfb, 0x5a, 0x0e, 0xdd, 0x84,
-	0xcd, 0xc1, 0x1e, 0x3e, 0x1a, 0x0c, 0x0e, 0xba, 0xbb, 0xc6, 0x71, 0x17, 0xef, 0x1f, 0xed, 0xf6,
-	0x35, 0x15, 0x21, 0xa8, 0x4f, 0x9b, 0x07, 0xfb, 0x87, 0x5d, 0x2d, 0x8f, 0xaa, 0x50, 0x3a, 0xee,
-	0xe2, 0x4e, 0xb7, 0x37, 0xd0, 0x0a, 0xfa, 0xaf, 0x54, 0xa8, 0x26, 0xb4, 0xc8, 0x0c, 0x39, 0xa0,
-	0xe2, 0x1a, 0x92, 0xc7, 0xec, 0x93, 0x3f, 0x41, 0x9a, 0xc3, 0x33, 0xa1, 0x9d, 0x3c, 0x16, 0x04,
-	0xbf, 0x7a, 0x98, 0x97, 0x89, 0x7d, 0x9e, 0xc7, 0x65, 0xc7, 0xbc, 0x14, 0x20, 0xdf, 0x83, 0xda,
-	0x39, 0x09, 0x5c, 0x32, 0x96, 0xfd, 0x42, 0x23, 0x55, 0xd1, 0x26, 0x86, 0x6c, 0x83, 0x26, 0x87,
-	0x4c, 0x61, 0x84, 0x3a, 0xea, 0xa2, 0xfd, 0x30, 0x02, 0xdb, 0x82, 0x82, 0xe8, 0x2e, 0x89, 0xf5,
-	0x39, 0xc1, 0x8e, 0x29, 0xfa, 0xda, 0xf4, 0x79, 0xc8, 0x97, 0xc7, 0xfc, 0x1b, 0x9d, 0xce, 0xeb,
-	0xa7, 0xc8, 0xf5, 0xf3, 0x60, 0x79, 0x73, 0x7e, 0x93, 0x8a, 0xce, 0x62, 0x15, 0x95, 0x40, 0xc5,
-	0x51, 0x1d, 0x4c, 0xa7, 0xdd, 0xd9, 0x63, 0x6a, 0x59, 0x87, 0xca, 0x61, 0xfb, 0xa7, 0xc6, 0x49,
-	0x9f, 0x67, 0xb5, 0x91, 0x06, 0xb5, 0xa7, 0x5d, 0xdc, 0xeb, 0x1e, 0xc8, 0x16, 0x15, 0x6d, 0x81,
-	0x26, 0x5b, 0xa6, 0xe3, 0xf2, 0x0c, 0x41, 0x7c, 0x16, 0x50, 0x19, 0xf2, 0xfd, 0x67, 0xed, 0x63,
-	0xad, 0xa8, 0xff, 0x57, 0x0e, 0x36, 0xc4, 0xb1, 0x10, 0xbf, 0xd8, 0xbf, 0xf9, 0xc5, 0x32, 0x99,
-	0xe5, 0xc9, 0xa5, 0xb3, 0x3c, 0x51, 0x10, 0xca, 0x4f, 0x75, 0x75, 0x1a, 0x84, 0xf2, 0xec, 0x50,
-	0xca, 0xe3, 0xe7, 0x97, 0xf1, 0xf8, 0x0d, 0x28, 0x39, 0x84, 0xc6, 0x7a, 0xab, 0xe0, 0x88, 0x44,
-	0x36, 0x54, 0x4d, 0xd7, 0xf5, 0x42, 0x53, 0xa4, 0x4e, 0x8b, 0x4b, 0x1d, 0x86, 0x33, 0xff, 0xb8,
-	0xd5, 0x9e, 0x22, 0x09, 0xc7, 0x9c, 0xc4, 0x6e, 0xfe, 0x04, 0xb4, 0xd9, 0x01, 0xcb, 0x1c, 0x87,
-	0xdf, 0xff, 0xe1, 0xf4, 0x34, 0x24, 0x6c, 0x5f, 0xc8, 0x37, 0x07, 0x6d, 0x8d, 0x11, 0xf8, 0xa4,
-	0xd7, 0xdb, 0xef, 0x3d, 0xd1, 0x14, 0x04, 0x50, 0xec, 0xfe, 0x74, 0x7f, 0xd0, 0xdd, 0xd5, 0x72,
-	0x3b, 0xbf, 0xde, 0x84, 0xa2, 0x60, 0x12, 0x7d, 0x2b, 0x23, 0x81, 0x64, 0x35, 0x28, 0xfa, 0xc9,
-	0xd2, 0x11, 0x75, 0xaa, 0xc2, 0xb4, 0xf9, 0x68, 0xe5, 0xf9, 0xf2, 0xf5, 0x6d, 0x0d, 0xfd, 0x8d,
-	0x02, 0xb5, 0xd4, 0xcb, 0x5b, 0xd6, 0xd4, 0xf1, 0x82, 0xe2, 0xd3, 0xe6, 0x8f, 0x57, 0x9a, 0x1b,
-	0xf3, 0xf2, 0x4b, 0x05, 0xaa, 0x89, 0xb2, 0x4b, 0xf4, 0x60, 0x95, 0x52, 0x4d, 0xc1, 0xc9, 0x67,
-	0xab, 0x57, 0x79, 0xea, 0x6b, 0x1f, 0x2b, 0xe8, 0xaf, 0x15, 0xa8, 0x26, 0x0a, 0x10, 0x33, 0xb3,
-	0x32, 0x5f, 0x2e, 0x99, 0x99, 0x95, 0x45, 0xf5, 0x8e, 0x6b, 0xe8, 0x2f, 0x15, 0xa8, 0xc4, 0xc5,
-	0x84, 0xe8, 0xfe, 0xf2, 0xe5, 0x87, 0x82, 0x89, 0x4f, 0x57, 0xad, 0x5b, 0xd4, 0xd7, 0xd0, 0x9f,
-	0x43, 0x39, 0xaa, 0xbc, 0x43, 0x59, 0x4f, 0xaf, 0x99, 0xb2, 0xbe, 0xe6, 0xfd, 0xa5, 0xe7, 0x25,
-	0x97, 0x8f, 0xca, 0xe1, 0x32, 0x2f, 0x3f, 0x53, 0xb8, 0xd7, 0xbc, 0xbf, 0xf4, 0xbc, 0x78, 0x79,
-	0x66, 0x09, 0x89, 0xaa, 0xb9, 0xcc, 0x96, 0x30, 0x5f, 0xae, 0x97, 0xd9, 0x12, 0x16, 0x15, 0xe9,
-	0x09, 0x46, 0x12, 0x75, 0x77, 0x99, 0x19, 0x99, 0xaf, 0xed, 0xcb, 0xcc, 0xc8, 0x82, 0x32, 0x3f,
-	0x7d, 0x0d, 0xfd, 0x42, 0x49, 0xde, 0x0b, 0xee, 0x2f, 0x5d, 0x5e, 0xb6, 0xa4, 0x49, 0xce, 0x15,
-	0xb8, 0xf1, 0x0d, 0xfa, 0x0b, 0x99, 0xc5, 0x10, 0xd5, 0x69, 0x68, 0x19, 0xb0, 0x54, 0x41, 0x5b,
-	0xf3, 0x93, 0xd5, 0x0e, 0x1b, 0xce, 0xc4, 0x5f, 0x29, 0x00, 0xd3, 0x3a, 0xb6, 0xcc, 0x4c, 0xcc,
-	0x15, 0xd0, 0x35, 0x1f, 0xac, 0x30, 0x33, 0xb9, 0x41, 0xa2, 0x3a, 0x9b, 0xcc, 0x1b, 0x64, 0xa6,
-	0xce, 0x2e, 0xf3, 0x06, 0x99, 0xad, 0x91, 0xd3, 0xd7, 0xd0, 0x3f, 0x29, 0xb0, 0x39, 0x57, 0xe7,
-	0x83, 0x1e, 0x5d, 0xb3, 0xd4, 0xab, 0xf9, 0xc5, 0xea, 0x00, 0x11, 0x6b, 0xdb, 0xca, 0xc7, 0x0a,
-	0xfa, 0x5b, 0x05, 0xd6, 0xd3, 0xf5, 0x0f, 0x99, 0x4f, 0xa9, 0x05, 0x15, 0x43, 0xcd, 0x87, 0xab,
-	0x4d, 0x8e, 0xa5, 0xf5, 0xf7, 0x0a, 0xd4, 0xd3, 0xa5, 0x30, 0xe8, 0xe1, 0x72, 0x6e, 0x61, 0x86,
-	0xa1, 0xcf, 0x57, 0x9c, 0x1d, 0x71, 0xf4, 0x65, 0xe9, 0x8f, 0x0b, 0x22, 0x7a, 0x2b, 0xf2, 0x9f,
-	0x1f, 0xfd, 0x7f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x76, 0x8b, 0xb6, 0x52, 0xb4, 0x33, 0x00, 0x00,
+	// 3992 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0x5f, 0x6f, 0x1b, 0x49,
+	0x72, 0xf7, 0xf0, 0x9f, 0xc8, 0xa2, 0x44, 0x8d, 0x5a, 0x92, 0x97, 0xe6, 0xe6, 0x6e, 0x7d, 0x13,
+	0x6c, 0xe2, 0xdc, 0xed, 0xd2, 0x7b, 0x3a, 0x64, 0xbd, 0xf6, 0xd9, 0xe7, 0xa5, 0x29, 0xda, 0xd2,
+	0x5a, 0xa2, 0x94, 0x26, 0x05, 0x9f, 0xe3, 0xdc, 0x4e, 0x46, 0x33, 0x6d, 0x72, 0x2c, 0xce, 0x9f,
+	0x9d, 0x69, 0xca, 0xd2, 0x05, 0x41, 0x82, 0x0b, 0x12, 0x5c, 0x80, 0x04, 0xc9, 0xcb, 0xe6, 0x5e,
+	0x82, 0x3c, 0x04, 0xc8, 0x53, 0xbe, 0x40, 0x90, 0xe0, 0x9e, 0x02, 0x24, 0x5f, 0x22, 0x40, 0x90,
+	0xb7, 0x00, 0x79, 0xb9, 0x7c, 0x83, 0xa0, 0xff, 0xcc, 0x70, 0x86, 0xa4, 0xd7, 0x43, 0xca, 0x8f,
+	0x79, 0xe2, 0x54, 0x75, 0xf7, 0xaf, 0x8b, 0x5d, 0xd5, 0x55, 0xd5, 0xdd, 0x05, 0x9a, 0x3f, 0x1a,
+	0x0f, 0x6c, 0x37, 0xbc, 0x6d, 0x05, 0xf6, 0x39, 0x09, 0xc2, 0xdb, 0x7e, 0xe0, 0x51, 0x4f, 0x52,
+	0x4d, 0x4e, 0xa0, 0x0f, 0x87, 0x46, 0x38, 0xb4, 0x4d, 0x2f, 0xf0, 0x9b, 0xae, 0xe7, 0x18, 0x56,
+	0x53, 0x8e, 0x69, 0xca, 0x31, 0xa2, 0x5b, 0xe3, 0xdb, 0x03, 0xcf, 0x1b, 0x8c, 0x88, 0x40, 0x38,
+	0x1d, 0xbf, 0xbc, 0x6d, 0x8d, 0x03, 0x83, 0xda, 0x9e, 0x2b, 0xdb, 0x3f, 0x98, 0x6e, 0xa7, 0xb6,
+	0x43, 0x42, 0x6a, 0x38, 0xbe, 0xec, 0xf0, 0x61, 0x24, 0x4b, 0x38, 0x34, 0x02, 0x62, 0xdd, 0x1e,
+	0x9a, 0xa3, 0xd0, 0x27, 0x26, 0xfb, 0xd5, 0xd9, 0x87, 0xec, 0xf6, 0xd1, 0x54, 0xb7, 0x90, 0x06,
+	0x63, 0x93, 0x46, 0x92, 0x1b, 0x94, 0x06, 0xf6, 0xe9, 0x98, 0x12, 0xd1, 0x5b, 0xbb, 0x01, 0xef,
+	0xf5, 0x8d, 0xf0, 0xac, 0xed, 0xb9, 0x2f, 0xed, 0x41, 0xcf, 0x1c, 0x12, 0xc7, 0xc0, 0xe4, 0xab,
+	0x31, 0x09, 0xa9, 0xf6, 0x7b, 0x50, 0x9f, 0x6d, 0x0a, 0x7d, 0xcf, 0x0d, 0x09, 0xfa, 0x1c, 0x0a,
+	0x6c, 0xca, 0xba, 0x72, 0x53, 0xb9, 0x55, 0xdd, 0xf9, 0xa8, 0xf9, 0xa6, 0x25, 0x10, 0x32, 0x34,
+	0xa5, 0xa8, 0xcd, 0x9e, 0x4f, 0x4c, 0xcc, 0x47, 0x6a, 0xdb, 0xb0, 0xd9, 0x36, 0x7c, 0xe3, 0xd4,
+	0x1e, 0xd9, 0xd4, 0x26, 0x61, 0x34, 0xe9, 0x18, 0xb6, 0xd2, 0x6c, 0x39, 0xe1, 0x4f, 0x60, 0xd5,
+	0x4c, 0xf0, 0xe5, 0xc4, 0x77, 0x9b, 0x99, 0xd6, 0xbe, 0xb9, 0xcb, 0xa9, 0x14, 0x70, 0x0a, 0x4e,
+	0xdb, 0x02, 0xf4, 0xd8, 0x76, 0x07, 0x24, 0xf0, 0x03, 0xdb, 0xa5, 0x91, 0x30, 0xbf, 0xcc, 0xc3,
+	0x66, 0x8a, 0x2d, 0x85, 0x79, 0x05, 0x10, 0xaf, 0x23, 0x13, 0x25, 0x7f, 0xab, 0xba, 0xf3, 0x45,
+	0x46, 0x51, 0xe6, 0xe0, 0x35, 0x5b, 0x31, 0x58, 0xc7, 0xa5, 0xc1, 0x25, 0x4e, 0xa0, 0xa3, 0x2f,
+	0xa1, 0x34, 0x24, 0xc6, 0x88, 0x0e, 0xeb, 0xb9, 0x9b, 0xca, 0xad, 0xda, 0xce, 0xe3, 0x2b, 0xcc,
+	0xb3, 0xc7, 0x81, 0x7a, 0xd4, 0xa0, 0x04, 0x4b, 0x54, 0xf4, 0x31, 0x20, 0xf1, 0xa5, 0x5b, 0x24,
+	0x34, 0x03, 0xdb, 0x67, 0x26, 0x59, 0xcf, 0xdf, 0x54, 0x6e, 0x55, 0xf0, 0x86, 0x68, 0xd9, 0x9d,
+	0x34, 0x34, 0x7c, 0x58, 0x9f, 0x92, 0x16, 0xa9, 0x90, 0x3f, 0x23, 0x97, 0x5c, 0x23, 0x15, 0xcc,
+	0x3e, 0xd1, 0x13, 0x28, 0x9e, 0x1b, 0xa3, 0x31, 0xe1, 0x22, 0x57, 0x77, 0xbe, 0xff, 0x36, 0xf3,
+	0x90, 0x26, 0x3a, 0x59, 0x07, 0x2c, 0xc6, 0xdf, 0xcb, 0x7d, 0xa6, 0x68, 0x77, 0xa1, 0x9a, 0x90,
+	0x1b, 0xd5, 0x00, 0x4e, 0xba, 0xbb, 0x9d, 0x7e, 0xa7, 0xdd, 0xef, 0xec, 0xaa, 0xd7, 0xd0, 0x1a,
+	0x54, 0x4e, 0xba, 0x7b, 0x9d, 0xd6, 0x41, 0x7f, 0xef, 0xb9, 0xaa, 0xa0, 0x2a, 0xac, 0x44, 0x44,
+	0x4e, 0xbb, 0x00, 0x84, 0x89, 0xe9, 0x9d, 0x93, 0x80, 0x19, 0xb2, 0xd4, 0x2a, 0x7a, 0x0f, 0x56,
+	0xa8, 0x11, 0x9e, 0xe9, 0xb6, 0x25, 0x65, 0x2e, 0x31, 0x72, 0xdf, 0x42, 0xfb, 0x50, 0x1a, 0x1a,
+	0xae, 0x35, 0x7a, 0xbb, 0xdc, 0xe9, 0xa5, 0x66, 0xe0, 0x7b, 0x7c, 0x20, 0x96, 0x00, 0xcc, 0xba,
+	0x53, 0x33, 0x0b, 0x05, 0x68, 0xcf, 0x41, 0xed, 0x51, 0x23, 0xa0, 0x49, 0x71, 0x3a, 0x50, 0x60,
+	0xf3, 0xd7, 0x95, 0x85, 0xe7, 0x14, 0x3b, 0x13, 0xf3, 0xe1, 0xda, 0xff, 0xe6, 0x60, 0x23, 0x81,
+	0x2d, 0x2d, 0xf5, 0x19, 0x94, 0x02, 0x12, 0x8e, 0x47, 0x94, 0xc3, 0xd7, 0x76, 0x1e, 0x66, 0x84,
+	0x9f, 0x41, 0x6a, 0x62, 0x0e, 0x83, 0x25, 0x1c, 0xba, 0x05, 0xaa, 0x18, 0xa1, 0x93, 0x20, 0xf0,
+	0x02, 0xdd, 0x09, 0x07, 0x7c, 0xd5, 0x2a, 0xb8, 0x26, 0xf8, 0x1d, 0xc6, 0x3e, 0x0c, 0x07, 0x89,
+	0x55, 0xcd, 0x5f, 0x71, 0x55, 0x91, 0x01, 0xaa, 0x4b, 0xe8, 0x6b, 0x2f, 0x38, 0xd3, 0xd9, 0xd2,
+	0x06, 0xb6, 0x45, 0xea, 0x05, 0x0e, 0xfa, 0x69, 0x46, 0xd0, 0xae, 0x18, 0x7e, 0x24, 0x47, 0xe3,
+	0x75, 0x37, 0xcd, 0xd0, 0xbe, 0x07, 0x25, 0xf1, 0x4f, 0x99, 0x25, 0xf5, 0x4e, 0xda, 0xed, 0x4e,
+	0xaf, 0xa7, 0x5e, 0x43, 0x15, 0x28, 0xe2, 0x4e, 0x1f, 0x33, 0x0b, 0xab, 0x40, 0xf1, 0x71, 0xab,
+	0xdf, 0x3a, 0x50, 0x73, 0xda, 0x77, 0x61, 0xfd, 0x99, 0x61, 0xd3, 0x2c, 0xc6, 0xa5, 0x79, 0xa0,
+	0x4e, 0xfa, 0x4a, 0xed, 0xec, 0xa7, 0xb4, 0x93, 0x7d, 0x69, 0x3a, 0x17, 0x36, 0x9d, 0xd2, 0x87,
+	0x0a, 0x79, 0x12, 0x04, 0x52, 0x05, 0xec, 0x53, 0x7b, 0x0d, 0xeb, 0x3d, 0xea, 0xf9, 0x99, 0x2c,
+	0xff, 0x07, 0xb0, 0xc2, 0xa2, 0x8d, 0x37, 0xa6, 0xd2, 0xf4, 0x6f, 0x34, 0x45, 0x34, 0x6a, 0x46,
+	0xd1, 0xa8, 0xb9, 0x2b, 0xa3, 0x15, 0x8e, 0x7a, 0xa2, 0xeb, 0x50, 0x0a, 0xed, 0x81, 0x6b, 0x8c,
+	0xa4, 0xb7, 0x90, 0x94, 0x86, 0x40, 0x9d, 0x4c, 0x2c, 0x0d, 0xbf, 0x0d, 0x68, 0x97, 0x84, 0x34,
+	0xf0, 0x2e, 0x33, 0xc9, 0xb3, 0x05, 0xc5, 0x97, 0x5e, 0x60, 0x8a, 0x8d, 0x58, 0xc6, 0x82, 0x60,
+	0x9b, 0x2a, 0x05, 0x22, 0xb1, 0x3f, 0x06, 0xb4, 0xef, 0xb2, 0x98, 0x92, 0x4d, 0x11, 0x7f, 0x9d,
+	0x83, 0xcd, 0x54, 0x7f, 0xa9, 0x8c, 0xe5, 0xf7, 0x21, 0x73, 0x4c, 0xe3, 0x50, 0xec, 0x43, 0x74,
+	0x04, 0x25, 0xd1, 0x43, 0xae, 0xe4, 0x9d, 0x05, 0x80, 0x44, 0x98, 0x92, 0x70, 0x12, 0x66, 0xae,
+	0xd1, 0xe7, 0xdf, 0xad, 0xd1, 0xbf, 0x06, 0x35, 0xfa, 0x1f, 0xe1, 0x5b, 0x75, 0xf3, 0x05, 0x6c,
+	0x9a, 0xde, 0x68, 0x44, 0x4c, 0x66, 0x0d, 0xba, 0xed, 0x52, 0x12, 0x9c, 0x1b, 0xa3, 0xb7, 0xdb,
+	0x0d, 0x9a, 0x8c, 0xda, 0x97, 0x83, 0xb4, 0x17, 0xb0, 0x91, 0x98, 0x58, 0x2a, 0xe2, 0x31, 0x14,
+	0x43, 0xc6, 0x90, 0x9a, 0xf8, 0x64, 0x41, 0x4d, 0x84, 0x58, 0x0c, 0xd7, 0x36, 0x05, 0x78, 0xe7,
+	0x9c, 0xb8, 0xf1, 0xdf, 0xd2, 0x76, 0x61, 0xa3, 0xc7, 0xcd, 0x34, 0x93, 0x1d, 0x4e, 0x4c, 0x3c,
+	0x97, 0x32, 0xf1, 0x2d, 0x40, 0x49, 0x14, 0x69, 0x88, 0x97, 0xb0, 0xde, 0xb9, 0x20, 0x66, 0x26,
+	0xe4, 0x3a, 0xac, 0x98, 0x9e, 0xe3, 0x18, 0xae, 0x55, 0xcf, 0xdd, 0xcc, 0xdf, 0xaa, 0xe0, 0x88,
+	0x4c, 0xee, 0xc5, 0x7c, 0xd6, 0xbd, 0xa8, 0xfd, 0xa5, 0x02, 0xea, 0x64, 0x6e, 0xb9, 0x90, 0x4c,
+	0x7a, 0x6a, 0x31, 0x20, 0x36, 0xf7, 0x2a, 0x96, 0x94, 0xe4, 0x47, 0xee, 0x42, 0xf0, 0x49, 0x10,
+	0x24, 0xdc, 0x51, 0xfe, 0x8a, 0xee, 0x48, 0xdb, 0x83, 0x5f, 0x8b, 0xc4, 0xe9, 0xd1, 0x80, 0x18,
+	0x8e, 0xed, 0x0e, 0xf6, 0x8f, 0x8e, 0x7c, 0x22, 0x04, 0x47, 0x08, 0x0a, 0x96, 0x41, 0x0d, 0x29,
+	0x18, 0xff, 0x66, 0x9b, 0xde, 0x1c, 0x79, 0x61, 0xbc, 0xe9, 0x39, 0xa1, 0xfd, 0x7b, 0x1e, 0xea,
+	0x33, 0x50, 0xd1, 0xf2, 0xbe, 0x80, 0x62, 0x48, 0xe8, 0xd8, 0x97, 0xa6, 0xd2, 0xc9, 0x2c, 0xf0,
+	0x7c, 0xbc, 0x66, 0x8f, 0x81, 0x61, 0x81, 0x89, 0x06, 0x50, 0xa6, 0xf4, 0x52, 0x0f, 0xed, 0x9f,
+	0x46, 0x09, 0xc1, 0xc1, 0x55, 0xf1, 0xfb, 0x24, 0x70, 0x6c, 0xd7, 0x18, 0xf5, 0xec, 0x9f, 0x12,
+	0xbc, 0x42, 0xe9, 0x25, 0xfb, 0x40, 0xcf, 0x99, 0xc1, 0x5b, 0xb6, 0x2b, 0x97, 0xbd, 0xbd, 0xec,
+	0x2c, 0x89, 0x05, 0xc6, 0x02, 0xb1, 0x71, 0x00, 0x45, 0xfe, 0x9f, 0x96, 0x31, 0x44, 0x15, 0xf2,
+	0x94, 0x5e, 0x72, 0xa1, 0xca, 0x98, 0x7d, 0x36, 0xee, 0xc3, 0x6a, 0xf2, 0x1f, 0x30, 0x43, 0x1a,
+	0x12, 0x7b, 0x30, 0x14, 0x06, 0x56, 0xc4, 0x92, 0x62, 0x9a, 0x7c, 0x6d, 0x5b, 0x32, 0x65, 0x2d,
+	0x62, 0x41, 0x68, 0xff, 0x94, 0x83, 0x1b, 0x73, 0x56, 0x46, 0x1a, 0xeb, 0x8b, 0x94, 0xb1, 0xbe,
+	0xa3, 0x55, 0x88, 0x2c, 0xfe, 0x45, 0xca, 0xe2, 0xdf, 0x21, 0x38, 0xdb, 0x36, 0xd7, 0xa1, 0x44,
+	0x2e, 0x6c, 0x4a, 0x2c, 0xb9, 0x54, 0x92, 0x4a, 0x6c, 0xa7, 0xc2, 0x55, 0xb7, 0xd3, 0x21, 0x6c,
+	0xb5, 0x03, 0x62, 0x50, 0x22, 0x5d, 0x79, 0x64, 0xff, 0x37, 0xa0, 0x6c, 0x8c, 0x46, 0x9e, 0x39,
+	0x51, 0xeb, 0x0a, 0xa7, 0xf7, 0x2d, 0xd4, 0x80, 0xf2, 0xd0, 0x0b, 0xa9, 0x6b, 0x38, 0x44, 0x3a,
+	0xaf, 0x98, 0xd6, 0xbe, 0x56, 0x60, 0x7b, 0x0a, 0x4f, 0x6a, 0xe1, 0x14, 0x6a, 0x76, 0xe8, 0x8d,
+	0xf8, 0x1f, 0xd4, 0x13, 0x27, 0xbc, 0x1f, 0x2e, 0x16, 0x6a, 0xf6, 0x23, 0x0c, 0x7e, 0xe0, 0x5b,
+	0xb3, 0x93, 0x24, 0xb7, 0x38, 0x3e, 0xb9, 0x25, 0x77, 0x7a, 0x44, 0x6a, 0x7f, 0xa3, 0xc0, 0xb6,
+	0x8c, 0xf0, 0xd9, 0xff, 0xe8, 0xac, 0xc8, 0xb9, 0x77, 0x2d, 0xb2, 0x56, 0x87, 0xeb, 0xd3, 0x72,
+	0x49, 0x9f, 0xff, 0x08, 0xb6, 0xdb, 0x43, 0x62, 0x9e, 0xf9, 0x9e, 0xed, 0x66, 0xca, 0x3f, 0xd8,
+	0xb6, 0xb2, 0xec, 0x38, 0x53, 0xb3, 0xec, 0x80, 0xa1, 0x4f, 0x63, 0x48, 0x74, 0x87, 0x1d, 0x60,
+	0x42, 0xea, 0x05, 0xe4, 0xdd, 0x9f, 0x18, 0xe6, 0x08, 0xf2, 0x6f, 0x39, 0xd8, 0x4c, 0xcd, 0xf7,
+	0xff, 0xa7, 0x88, 0xe5, 0x12, 0xaa, 0xdb, 0xb0, 0x7d, 0x1c, 0x90, 0x97, 0x84, 0x9a, 0xc3, 0x7d,
+	0xc7, 0x18, 0xc4, 0xd7, 0x1b, 0xcc, 0x57, 0xd8, 0x9c, 0xc1, 0x6f, 0x0d, 0x2a, 0x58, 0x52, 0xcc,
+	0x04, 0xa6, 0x07, 0x48, 0x13, 0xf8, 0x53, 0x05, 0x1a, 0x27, 0xbe, 0x65, 0xd0, 0x48, 0x25, 0xde,
+	0x38, 0x30, 0xc9, 0xdb, 0xd3, 0xb4, 0x2e, 0x54, 0x82, 0xa8, 0x73, 0x3d, 0xb7, 0x50, 0x26, 0x35,
+	0x99, 0x64, 0x02, 0xa1, 0x7d, 0x0b, 0xde, 0x9f, 0x2b, 0x86, 0x14, 0xf3, 0x7f, 0x8a, 0x80, 0x66,
+	0x6f, 0x59, 0xd0, 0x77, 0x60, 0x35, 0x24, 0xae, 0xa5, 0x8b, 0xbc, 0x49, 0xa4, 0x74, 0x65, 0x5c,
+	0x65, 0x3c, 0x91, 0x40, 0x85, 0x2c, 0x15, 0x20, 0x17, 0x72, 0xd7, 0x96, 0x31, 0xff, 0x46, 0x43,
+	0x58, 0x7d, 0x19, 0xea, 0xf1, 0x1e, 0xe4, 0x3a, 0xaf, 0x65, 0x0e, 0xef, 0xb3, 0x72, 0x34, 0x1f,
+	0xf7, 0xe2, 0xfd, 0x8d, 0xab, 0x2f, 0xc3, 0x98, 0x40, 0x3f, 0x57, 0xe0, 0xbd, 0xc8, 0x1a, 0x26,
+	0x6e, 0xc4, 0xf1, 0x2c, 0x12, 0xd6, 0x0b, 0x37, 0xf3, 0xb7, 0x6a, 0x3b, 0xc7, 0x57, 0xf0, 0x23,
+	0x33, 0xcc, 0x43, 0xcf, 0x22, 0x78, 0xdb, 0x9d, 0xc3, 0x0d, 0x51, 0x13, 0x36, 0x9d, 0x71, 0x48,
+	0x75, 0xe1, 0x0d, 0x75, 0xd9, 0xa9, 0x5e, 0xe4, 0xeb, 0xb2, 0xc1, 0x9a, 0x52, 0x3e, 0x1b, 0x9d,
+	0xc1, 0x9a, 0xe3, 0x8d, 0x5d, 0xaa, 0x9b, 0x7c, 0x57, 0x87, 0xf5, 0xd2, 0x42, 0x17, 0x44, 0x73,
+	0x56, 0xe9, 0x90, 0xc1, 0x09, 0x1f, 0x11, 0xe2, 0x55, 0x27, 0x41, 0x31, 0x45, 0x06, 0xc4, 0xf1,
+	0x28, 0xd1, 0x99, 0x7d, 0x85, 0xf5, 0x15, 0xa1, 0x48, 0xc1, 0x63, 0x26, 0x11, 0xa2, 0x6f, 0x03,
+	0x98, 0xb1, 0x1b, 0xab, 0x97, 0x79, 0x87, 0x04, 0x07, 0xfd, 0x16, 0xa8, 0x63, 0x6e, 0x41, 0xfa,
+	0xc4, 0x30, 0x2b, 0xbc, 0xd7, 0xba, 0xe0, 0xc7, 0x56, 0x85, 0x7e, 0x13, 0xd6, 0x07, 0x81, 0x37,
+	0xf6, 0x13, 0x3d, 0x81, 0xf7, 0xac, 0x71, 0x76, 0xdc, 0x51, 0x6b, 0x42, 0x35, 0xa1, 0x5a, 0x54,
+	0x86, 0x42, 0xf7, 0xa8, 0xdb, 0x51, 0xaf, 0x21, 0x80, 0x52, 0x7b, 0x0f, 0x1f, 0x1d, 0xf5, 0xc5,
+	0x89, 0x7d, 0xff, 0xb0, 0xf5, 0xa4, 0xa3, 0xe6, 0xb4, 0x0e, 0xac, 0x26, 0xff, 0x24, 0x42, 0x50,
+	0x3b, 0xe9, 0x3e, 0xed, 0x1e, 0x3d, 0xeb, 0xea, 0x87, 0x47, 0x27, 0xdd, 0x3e, 0x3b, 0xeb, 0xd7,
+	0x00, 0x5a, 0xdd, 0xe7, 0x13, 0x7a, 0x0d, 0x2a, 0xdd, 0xa3, 0x88, 0x54, 0x1a, 0x39, 0x55, 0xd1,
+	0xfe, 0x35, 0x0f, 0x5b, 0xf3, 0xf4, 0x8d, 0x2c, 0x28, 0x30, 0xdb, 0x91, 0x7e, 0xf2, 0xdd, 0x9b,
+	0x0e, 0x47, 0x67, 0x5b, 0xc6, 0x37, 0x64, 0x7a, 0x55, 0xc1, 0xfc, 0x1b, 0xe9, 0x50, 0x1a, 0x19,
+	0xa7, 0x64, 0x14, 0xd6, 0xf3, 0xfc, 0x3e, 0xf2, 0xc9, 0x55, 0xe6, 0x3e, 0xe0, 0x48, 0xe2, 0x32,
+	0x52, 0xc2, 0xa2, 0x3e, 0x54, 0x59, 0x02, 0x11, 0x8a, 0xa5, 0x93, 0x1e, 0x73, 0x27, 0xe3, 0x2c,
+	0x7b, 0x93, 0x91, 0x38, 0x09, 0xd3, 0xb8, 0x0b, 0xd5, 0xc4, 0x64, 0x73, 0xee, 0x12, 0xb7, 0x92,
+	0x77, 0x89, 0x95, 0xe4, 0xc5, 0xe0, 0x43, 0xd8, 0x9a, 0xb7, 0x46, 0xcc, 0x08, 0xf6, 0x8e, 0x7a,
+	0x7d, 0x71, 0x6b, 0xf3, 0x04, 0x1f, 0x9d, 0x1c, 0xab, 0x0a, 0x63, 0xf6, 0x5b, 0xbd, 0xa7, 0x6a,
+	0x2e, 0xb6, 0x91, 0xbc, 0xd6, 0x86, 0x6a, 0x42, 0xae, 0x54, 0xc6, 0xa4, 0xa4, 0x33, 0x26, 0x96,
+	0xb3, 0x18, 0x96, 0x15, 0x90, 0x30, 0x94, 0x72, 0x44, 0xa4, 0xf6, 0x02, 0x2a, 0xbb, 0xdd, 0x9e,
+	0x84, 0xa8, 0xc3, 0x4a, 0x48, 0x02, 0xf6, 0xbf, 0xa5, 0x7f, 0x8f, 0x48, 0x06, 0x1e, 0x12, 0x23,
+	0x30, 0x87, 0xdc, 0x1b, 0xb3, 0xa6, 0x98, 0x66, 0xa3, 0x3c, 0x7e, 0xbb, 0x2a, 0x74, 0x57, 0xc1,
+	0x11, 0xa9, 0xfd, 0x6a, 0x05, 0x60, 0x12, 0xb7, 0x51, 0x0d, 0x72, 0xb1, 0x9f, 0xcf, 0xd9, 0x16,
+	0xb3, 0x83, 0x44, 0x7e, 0xc7, 0xbf, 0xd1, 0x0e, 0x6c, 0x3b, 0xe1, 0xc0, 0x37, 0xcc, 0x33, 0x5d,
+	0x86, 0x56, 0xe1, 0x1e, 0xb8, 0x0f, 0x5d, 0xc5, 0x9b, 0xb2, 0x51, 0xee, 0x7e, 0x81, 0x7b, 0x00,
+	0x79, 0xe2, 0x9e, 0x73, 0x7f, 0x57, 0xdd, 0xb9, 0xb7, 0x70, 0x3e, 0xd1, 0xec, 0xb8, 0xe7, 0xc2,
+	0x56, 0x18, 0x0c, 0xd2, 0x01, 0x2c, 0x72, 0x6e, 0x9b, 0x44, 0x67, 0xa0, 0x45, 0x0e, 0xfa, 0xf9,
+	0xe2, 0xa0, 0xbb, 0x1c, 0x23, 0x86, 0xae, 0x58, 0x11, 0x9d, 0x0e, 0x6d, 0xa5, 0x2b, 0x87, 0x36,
+	0xb4, 0x0b, 0x25, 0xee, 0xeb, 0x98, 0x57, 0xcb, 0x7f, 0xe3, 0x73, 0x46, 0x1a, 0x8c, 0x7b, 0x12,
+	0x2c, 0xc7, 0xa2, 0x27, 0xb0, 0x22, 0x44, 0x0c, 0xeb, 0x65, 0x0e, 0xf3, 0x71, 0x56, 0x47, 0xcc,
+	0x47, 0xe1, 0x68, 0x34, 0xd3, 0xea, 0x38, 0x24, 0x01, 0xf7, 0x8d, 0x15, 0xcc, 0xbf, 0xd1, 0xfb,
+	0x50, 0x11, 0xf9, 0x2f, 0xcb, 0xd8, 0x40, 0x18, 0x27, 0x67, 0xec, 0xda, 0x01, 0xfa, 0x00, 0xaa,
+	0xe2, 0x9c, 0xa3, 0x73, 0xaf, 0x50, 0xe5, 0xcd, 0x20, 0x58, 0xc7, 0xcc, 0x37, 0x88, 0x0e, 0x24,
+	0x08, 0x44, 0x87, 0xd5, 0xb8, 0x03, 0x09, 0x02, 0xde, 0xe1, 0x37, 0x60, 0x9d, 0x67, 0x11, 0xc2,
+	0xe9, 0x72, 0x9b, 0x5a, 0xe3, 0x9d, 0xd6, 0x18, 0xfb, 0x09, 0xe3, 0x76, 0x99, 0x71, 0xdd, 0x80,
+	0xf2, 0x2b, 0xef, 0x54, 0x74, 0xa8, 0x89, 0x7d, 0xf0, 0xca, 0x3b, 0x8d, 0x9a, 0xe2, 0x0c, 0x7d,
+	0x3d, 0x9d, 0xa1, 0x7f, 0x05, 0xd7, 0x67, 0x43, 0x2c, 0xcf, 0xd4, 0xd5, 0xab, 0x67, 0xea, 0x5b,
+	0xee, 0x1c, 0x2e, 0x7a, 0x04, 0x79, 0xcb, 0x0d, 0xeb, 0x1b, 0x0b, 0x19, 0x47, 0xbc, 0x8f, 0x31,
+	0x1b, 0xdc, 0xf8, 0x14, 0xca, 0x91, 0xf5, 0x2d, 0xe2, 0x97, 0x1a, 0xf7, 0xa1, 0x96, 0xb6, 0xdd,
+	0x85, 0xbc, 0xda, 0x3f, 0xe4, 0xa0, 0x32, 0x09, 0x84, 0x2e, 0x6c, 0xf2, 0x55, 0x34, 0x28, 0xb1,
+	0x12, 0xc1, 0x50, 0x64, 0xfe, 0x0f, 0x32, 0xfe, 0xaf, 0x56, 0x84, 0x90, 0x4e, 0xdd, 0x50, 0x8c,
+	0x3c, 0x99, 0xef, 0x4b, 0x58, 0x1f, 0xd9, 0xee, 0xf8, 0x42, 0x9f, 0xce, 0x1d, 0x7f, 0x3b, 0xe3,
+	0x5c, 0x07, 0x6c, 0xf4, 0x64, 0x8e, 0xda, 0x28, 0x45, 0xa3, 0x3d, 0x28, 0xfa, 0x5e, 0x40, 0xa3,
+	0x20, 0x95, 0x35, 0x7c, 0x1c, 0x7b, 0x01, 0x3d, 0x34, 0x7c, 0x9f, 0x5d, 0x18, 0x08, 0x00, 0xed,
+	0xeb, 0x1c, 0x5c, 0x9f, 0xff, 0xc7, 0x50, 0x17, 0xf2, 0xa6, 0x3f, 0x96, 0x8b, 0x74, 0x7f, 0xd1,
+	0x45, 0x6a, 0xfb, 0xe3, 0x89, 0xfc, 0x0c, 0x88, 0x1d, 0x7f, 0x1c, 0xe2, 0x78, 0xc1, 0xa5, 0x5c,
+	0x8b, 0x87, 0x8b, 0x42, 0x1e, 0xf2, 0xd1, 0x13, 0x54, 0x09, 0x87, 0x30, 0x94, 0xa5, 0xf5, 0x86,
+	0xd2, 0x4f, 0x2e, 0x78, 0x02, 0x89, 0x20, 0x71, 0x8c, 0xa3, 0x7d, 0x0a, 0xdb, 0x73, 0xff, 0x0a,
+	0xfa, 0x16, 0x80, 0xe9, 0x8f, 0x75, 0xfe, 0xe4, 0x26, 0x2c, 0x28, 0x8f, 0x2b, 0xa6, 0x3f, 0xee,
+	0x71, 0x86, 0xf6, 0x02, 0xea, 0x6f, 0x92, 0x97, 0x79, 0x1f, 0x21, 0xb1, 0xee, 0x9c, 0xf2, 0x35,
+	0xc8, 0xe3, 0xb2, 0x60, 0x1c, 0x9e, 0x22, 0x0d, 0xd6, 0xa2, 0x46, 0xe3, 0x82, 0x75, 0xc8, 0xf3,
+	0x0e, 0x55, 0xd9, 0xc1, 0xb8, 0x38, 0x3c, 0xd5, 0x7e, 0x91, 0x83, 0xf5, 0x29, 0x91, 0xd9, 0x51,
+	0x48, 0x78, 0xbc, 0xe8, 0xe0, 0x22, 0x28, 0xe6, 0xfe, 0x4c, 0xdb, 0x8a, 0xce, 0xa5, 0xfc, 0x9b,
+	0x07, 0x3e, 0x5f, 0x3e, 0x33, 0xe4, 0x6c, 0x9f, 0x6d, 0x1f, 0xe7, 0xd4, 0xa6, 0x21, 0xcf, 0x42,
+	0x8a, 0x58, 0x10, 0xe8, 0x39, 0xd4, 0x02, 0xc2, 0x03, 0xae, 0xa5, 0x0b, 0x2b, 0x2b, 0x2e, 0x64,
+	0x65, 0x52, 0x42, 0x66, 0x6c, 0x78, 0x2d, 0x42, 0x62, 0x54, 0x88, 0x9e, 0xc1, 0x9a, 0x75, 0xe9,
+	0x1a, 0x8e, 0x6d, 0x4a, 0xe4, 0xd2, 0xd2, 0xc8, 0xab, 0x12, 0x88, 0x03, 0xb3, 0xd7, 0xcd, 0x44,
+	0x23, 0xfb, 0x63, 0x3c, 0xdd, 0x92, 0x6b, 0x22, 0x88, 0xb4, 0xb7, 0x28, 0x4a, 0x6f, 0xa1, 0x9d,
+	0x42, 0x35, 0xb1, 0x2f, 0x16, 0x19, 0xca, 0xd6, 0x93, 0x7a, 0x7c, 0x3d, 0x8b, 0x38, 0x47, 0x3d,
+	0x76, 0x8a, 0x64, 0xa9, 0x8e, 0x6e, 0xfb, 0x7c, 0x45, 0x2b, 0xb8, 0xc4, 0xc8, 0x7d, 0x5f, 0xfb,
+	0xcf, 0x1c, 0xd4, 0xd2, 0x5b, 0x3a, 0xb2, 0x23, 0x9f, 0x04, 0xb6, 0x67, 0x25, 0xec, 0xe8, 0x98,
+	0x33, 0x98, 0xad, 0xb0, 0xe6, 0xaf, 0xc6, 0x1e, 0x35, 0x22, 0x5b, 0x31, 0xfd, 0xf1, 0xef, 0x30,
+	0x7a, 0xca, 0x06, 0xf3, 0x53, 0x36, 0x88, 0x3e, 0x02, 0x24, 0x4d, 0x69, 0x64, 0x3b, 0x36, 0xd5,
+	0x4f, 0x2f, 0x29, 0x11, 0x3a, 0xce, 0x63, 0x55, 0xb4, 0x1c, 0xb0, 0x86, 0x47, 0x8c, 0xcf, 0x0c,
+	0xcf, 0xf3, 0x1c, 0x3d, 0x34, 0xbd, 0x80, 0xe8, 0x86, 0xf5, 0x8a, 0x9f, 0x94, 0xf2, 0xb8, 0xea,
+	0x79, 0x4e, 0x8f, 0xf1, 0x5a, 0xd6, 0x2b, 0x16, 0xf9, 0x4c, 0x7f, 0x1c, 0x12, 0xaa, 0xb3, 0x1f,
+	0x9e, 0x2c, 0x54, 0x30, 0x08, 0x56, 0xdb, 0x1f, 0x87, 0xe8, 0xd7, 0x61, 0x2d, 0xea, 0xc0, 0x83,
+	0x9f, 0x8c, 0xba, 0xab, 0xb2, 0x0b, 0xe7, 0xf1, 0x4e, 0xfc, 0x4b, 0xf7, 0x8d, 0x80, 0xb8, 0x54,
+	0x46, 0xe0, 0x55, 0xc1, 0x3c, 0xe6, 0x3c, 0xa4, 0xc1, 0xea, 0x31, 0x09, 0x4c, 0xe2, 0xd2, 0xbe,
+	0x6d, 0x9e, 0x85, 0xfc, 0x00, 0xa4, 0xe0, 0x14, 0xef, 0x8b, 0x42, 0x79, 0x45, 0x2d, 0xe3, 0x48,
+	0x24, 0x87, 0x38, 0xa1, 0xf6, 0x13, 0x28, 0xf2, 0x3c, 0x82, 0x2d, 0x1c, 0x8f, 0xc1, 0x3c, 0x44,
+	0xcb, 0xfc, 0x93, 0x31, 0x78, 0x80, 0x7e, 0x1f, 0x2a, 0x5c, 0x41, 0x89, 0xb4, 0x9f, 0x27, 0xa7,
+	0xbc, 0xb1, 0x01, 0xe5, 0x80, 0x18, 0x96, 0xe7, 0x8e, 0xa2, 0xdb, 0xda, 0x98, 0xd6, 0xbe, 0x82,
+	0x92, 0x08, 0x46, 0x57, 0xc0, 0xff, 0x18, 0x50, 0xf4, 0xf7, 0xd9, 0xed, 0x6f, 0x18, 0xca, 0x54,
+	0x95, 0x97, 0x08, 0xc8, 0x35, 0x98, 0x34, 0x68, 0xff, 0xa1, 0x00, 0x4c, 0xae, 0x5d, 0x58, 0x76,
+	0xcb, 0x76, 0x02, 0x3b, 0xc6, 0x8b, 0x5b, 0xe2, 0x88, 0x64, 0x77, 0x3a, 0x32, 0x37, 0xcd, 0x2d,
+	0x7b, 0x93, 0x25, 0x01, 0xa2, 0x37, 0x23, 0x22, 0x6f, 0x0a, 0x16, 0x7d, 0x33, 0x22, 0xe2, 0xcd,
+	0x88, 0xb0, 0x63, 0xae, 0xcc, 0x9a, 0x05, 0x5c, 0x81, 0x27, 0xcd, 0x55, 0x2b, 0x7e, 0x98, 0x23,
+	0xda, 0x7f, 0x2b, 0xb1, 0x2f, 0x8b, 0xee, 0x7b, 0xd0, 0x97, 0x50, 0x66, 0x6e, 0x41, 0x77, 0x0c,
+	0x5f, 0x96, 0x83, 0xb4, 0x97, 0xbb, 0x4a, 0x8a, 0x22, 0x9d, 0xc8, 0x79, 0x57, 0x7c, 0x41, 0x31,
+	0x9f, 0xc8, 0xce, 0x1b, 0x91, 0x4f, 0x64, 0xdf, 0xe8, 0x43, 0xa8, 0x19, 0x63, 0xea, 0xe9, 0x86,
+	0x75, 0x4e, 0x02, 0x6a, 0x87, 0x44, 0xea, 0x7e, 0x8d, 0x71, 0x5b, 0x11, 0xb3, 0x71, 0x0f, 0x56,
+	0x93, 0x98, 0x6f, 0xcb, 0x45, 0x8a, 0xc9, 0x5c, 0xe4, 0xf7, 0x01, 0x26, 0x97, 0xd1, 0xcc, 0x46,
+	0xd8, 0xcd, 0xb6, 0x6e, 0x46, 0x07, 0xdc, 0x22, 0x2e, 0x33, 0x46, 0x9b, 0x1d, 0xba, 0xd2, 0x2f,
+	0x65, 0xc5, 0xe8, 0xa5, 0x8c, 0xed, 0x78, 0xb6, 0x49, 0xcf, 0xec, 0xd1, 0x28, 0xbe, 0x20, 0xaf,
+	0x78, 0x9e, 0xf3, 0x94, 0x33, 0xb4, 0x5f, 0xe6, 0x84, 0xad, 0x88, 0x37, 0xcf, 0x4c, 0x07, 0x9c,
+	0x77, 0xa5, 0xea, 0xbb, 0x00, 0x21, 0x35, 0x02, 0x96, 0x58, 0x19, 0xd1, 0x15, 0x7d, 0x63, 0xe6,
+	0xa9, 0xad, 0x1f, 0x15, 0x61, 0xe1, 0x8a, 0xec, 0xdd, 0xa2, 0xe8, 0x01, 0xac, 0x9a, 0x9e, 0xe3,
+	0x8f, 0x88, 0x1c, 0x5c, 0x7c, 0xeb, 0xe0, 0x6a, 0xdc, 0xbf, 0x45, 0x13, 0x0f, 0x03, 0xa5, 0xab,
+	0x3e, 0x0c, 0xfc, 0xb3, 0x22, 0x9e, 0x6e, 0x93, 0x2f, 0xc7, 0x68, 0x30, 0xa7, 0x3c, 0xe9, 0xc9,
+	0x92, 0xcf, 0xd0, 0xdf, 0x54, 0x9b, 0xd4, 0x78, 0x90, 0xa5, 0x18, 0xe8, 0xcd, 0xa9, 0xee, 0xbf,
+	0xe4, 0xa1, 0x12, 0xa9, 0x65, 0x56, 0xf7, 0x9f, 0x41, 0x25, 0xae, 0x80, 0xab, 0xe7, 0xde, 0xba,
+	0xc2, 0x93, 0xce, 0xe8, 0x25, 0x20, 0x63, 0x30, 0x88, 0x53, 0x58, 0x7d, 0x1c, 0x1a, 0x83, 0xe8,
+	0xde, 0xf8, 0xb3, 0x05, 0xd6, 0x21, 0x8a, 0x79, 0x27, 0x6c, 0x3c, 0x56, 0x8d, 0xc1, 0x20, 0xc5,
+	0x41, 0x7f, 0x00, 0xdb, 0xe9, 0x39, 0xf4, 0xd3, 0x4b, 0xdd, 0xb7, 0x2d, 0x79, 0x90, 0xde, 0x5b,
+	0xf4, 0xe1, 0xba, 0x99, 0x82, 0x7f, 0x74, 0x79, 0x6c, 0x5b, 0x62, 0xcd, 0x51, 0x30, 0xd3, 0xd0,
+	0xf8, 0x23, 0x78, 0xef, 0x0d, 0xdd, 0xe7, 0xe8, 0xa0, 0x9b, 0x2e, 0xc8, 0x5a, 0x7e, 0x11, 0x12,
+	0xda, 0xfb, 0x7b, 0x05, 0x36, 0x66, 0x3a, 0xa0, 0x56, 0x32, 0xf7, 0xbe, 0x9d, 0x71, 0x9e, 0xf6,
+	0xf1, 0x89, 0x80, 0x67, 0x63, 0xd1, 0x17, 0x53, 0xe9, 0x76, 0xd6, 0x24, 0x4b, 0x64, 0xad, 0x02,
+	0x48, 0x22, 0x68, 0xff, 0x98, 0x87, 0x72, 0x84, 0xce, 0x8f, 0xc1, 0x97, 0x21, 0x25, 0x8e, 0x1e,
+	0xdf, 0xd1, 0x29, 0x18, 0x04, 0x8b, 0xdf, 0x1c, 0xbd, 0x0f, 0x95, 0x71, 0x48, 0x02, 0xd1, 0x9c,
+	0xe3, 0xcd, 0x65, 0xc6, 0xe0, 0x8d, 0x1f, 0x40, 0x95, 0x7a, 0xd4, 0x18, 0xe9, 0x94, 0x87, 0xf7,
+	0xbc, 0x18, 0xcd, 0x59, 0x3c, 0xb8, 0xa3, 0xef, 0xc1, 0x06, 0x1d, 0x06, 0x1e, 0xa5, 0x23, 0x96,
+	0x7f, 0xf2, 0x6c, 0x48, 0x24, 0x2f, 0x05, 0xac, 0xc6, 0x0d, 0x22, 0x4b, 0x0a, 0x99, 0xf7, 0x9e,
+	0x74, 0x66, 0xa6, 0xcb, 0x9d, 0x48, 0x01, 0xaf, 0xc5, 0x5c, 0x66, 0xda, 0x2c, 0x78, 0xfa, 0x22,
+	0x81, 0xe0, 0xbe, 0x42, 0xc1, 0x11, 0x89, 0x74, 0x58, 0x77, 0x88, 0x11, 0x8e, 0x03, 0x62, 0xe9,
+	0x2f, 0x6d, 0x32, 0xb2, 0xc4, 0xed, 0x45, 0x2d, 0xf3, 0x11, 0x22, 0x5a, 0x96, 0xe6, 0x63, 0x3e,
+	0x1a, 0xd7, 0x22, 0x38, 0x41, 0xb3, 0xcc, 0x41, 0x7c, 0xa1, 0x75, 0xa8, 0xf6, 0x9e, 0xf7, 0xfa,
+	0x9d, 0x43, 0xfd, 0xf0, 0x68, 0xb7, 0x23, 0x6b, 0xee, 0x7a, 0x1d, 0x2c, 0x48, 0x85, 0xb5, 0xf7,
+	0x8f, 0xfa, 0xad, 0x03, 0xbd, 0xbf, 0xdf, 0x7e, 0xda, 0x53, 0x73, 0x68, 0x1b, 0x36, 0xfa, 0x7b,
+	0xf8, 0xa8, 0xdf, 0x3f, 0xe8, 0xec, 0xea, 0xc7, 0x1d, 0xbc, 0x7f, 0xb4, 0xdb, 0x53, 0xf3, 0xec,
+	0xb2, 0x75, 0xc2, 0xee, 0xef, 0x1f, 0x76, 0xd4, 0x02, 0xab, 0xb2, 0x3a, 0xee, 0xe0, 0x76, 0xa7,
+	0xdb, 0x57, 0x8b, 0xda, 0x2f, 0xf2, 0x50, 0x4d, 0x68, 0x91, 0x19, 0x72, 0x10, 0x8a, 0xb3, 0x4a,
+	0x01, 0xb3, 0x4f, 0x5e, 0x23, 0x60, 0x98, 0x43, 0xa1, 0x9d, 0x02, 0x16, 0x04, 0x3f, 0x9f, 0x18,
+	0x17, 0x89, 0x7d, 0x5e, 0xc0, 0x65, 0xc7, 0xb8, 0x10, 0x20, 0xdf, 0x81, 0xd5, 0x33, 0x12, 0xb8,
+	0x64, 0x24, 0xdb, 0x85, 0x46, 0xaa, 0x82, 0x27, 0xba, 0xdc, 0x02, 0x55, 0x76, 0x99, 0xc0, 0x08,
+	0x75, 0xd4, 0x04, 0xff, 0x30, 0x02, 0xdb, 0x82, 0xa2, 0x68, 0x5e, 0x11, 0xf3, 0x73, 0x82, 0x85,
+	0xa9, 0xf0, 0xb5, 0xe1, 0xf3, 0x94, 0xaf, 0x80, 0xf9, 0x37, 0x3a, 0x9d, 0xd5, 0x4f, 0x89, 0xeb,
+	0xe7, 0xee, 0xe2, 0xe6, 0xfc, 0x26, 0x15, 0x0d, 0x63, 0x15, 0xad, 0x40, 0x1e, 0x47, 0x85, 0x6a,
+	0xed, 0x56, 0x7b, 0x8f, 0xa9, 0x65, 0x0d, 0x2a, 0x87, 0xad, 0x1f, 0xeb, 0x27, 0x3d, 0x7e, 0xf5,
+	0x8d, 0x54, 0x58, 0x7d, 0xda, 0xc1, 0xdd, 0xce, 0x81, 0xe4, 0xe4, 0xd1, 0x16, 0xa8, 0x92, 0x33,
+	0xe9, 0x57, 0x60, 0x08, 0xe2, 0xb3, 0xc8, 0xae, 0x4a, 0x7b, 0xcf, 0x5a, 0xc7, 0x6a, 0x49, 0xfb,
+	0xaf, 0x1c, 0xac, 0x8b, 0xb0, 0x10, 0x97, 0xd4, 0xbc, 0xf9, 0xe9, 0x29, 0x79, 0x15, 0x94, 0x4b,
+	0x5f, 0x05, 0x45, 0x49, 0x28, 0x8f, 0xea, 0xf9, 0x49, 0x12, 0xca, 0xaf, 0x90, 0x52, 0x1e, 0xbf,
+	0xb0, 0x88, 0xc7, 0xaf, 0xc3, 0x8a, 0x43, 0xc2, 0x58, 0x6f, 0x15, 0x1c, 0x91, 0xc8, 0x86, 0xaa,
+	0xe1, 0xba, 0x1e, 0x35, 0xc4, 0xfd, 0x6a, 0x69, 0xa1, 0x60, 0x38, 0xf5, 0x8f, 0x9b, 0xad, 0x09,
+	0x92, 0x70, 0xcc, 0x49, 0xec, 0xc6, 0x8f, 0x40, 0x9d, 0xee, 0xb0, 0x48, 0x38, 0xfc, 0xee, 0xf7,
+	0x27, 0xd1, 0x90, 0xb0, 0x7d, 0x21, 0x1f, 0x26, 0xd4, 0x6b, 0x8c, 0xc0, 0x27, 0xdd, 0xee, 0x7e,
+	0xf7, 0x89, 0xaa, 0xb0, 0x97, 0x8d, 0xce, 0x8f, 0xf7, 0x59, 0xf1, 0x6b, 0x6e, 0xe7, 0x57, 0xdb,
+	0x50, 0x12, 0x42, 0xa2, 0xaf, 0x65, 0x26, 0x90, 0x2c, 0xd7, 0x46, 0x3f, 0x5a, 0x38, 0xa3, 0x4e,
+	0x95, 0x80, 0x37, 0x1e, 0x2e, 0x3d, 0x5e, 0x3e, 0x0b, 0x5e, 0x43, 0x7f, 0xae, 0xc0, 0x6a, 0xea,
+	0x49, 0x30, 0xeb, 0xfd, 0xf2, 0x9c, 0xea, 0xf0, 0xc6, 0x0f, 0x97, 0x1a, 0x1b, 0xcb, 0xf2, 0x73,
+	0x05, 0xaa, 0x89, 0xba, 0x68, 0x74, 0x77, 0x99, 0x5a, 0x6a, 0x21, 0xc9, 0xbd, 0xe5, 0xcb, 0xb0,
+	0xb5, 0x6b, 0x9f, 0x28, 0xe8, 0xcf, 0x14, 0xa8, 0x26, 0x2a, 0x84, 0x33, 0x8b, 0x32, 0x5b, 0xcf,
+	0xdc, 0xb8, 0xb7, 0xcc, 0xd0, 0x78, 0x4d, 0xfe, 0x58, 0x81, 0x4a, 0xfc, 0x4e, 0x8f, 0xee, 0x2c,
+	0xfe, 0xb2, 0x2f, 0x84, 0xf8, 0x6c, 0xd9, 0x92, 0x00, 0xed, 0x1a, 0xfa, 0x43, 0x28, 0x47, 0xa5,
+	0xb1, 0x28, 0x6b, 0xf4, 0x9a, 0xaa, 0xbb, 0x6d, 0xdc, 0x59, 0x78, 0x5c, 0x72, 0xfa, 0xa8, 0x5e,
+	0x35, 0xf3, 0xf4, 0x53, 0x95, 0xb5, 0x8d, 0x3b, 0x0b, 0x8f, 0x8b, 0xa7, 0x67, 0x96, 0x90, 0x28,
+	0x6b, 0xcd, 0x6c, 0x09, 0xb3, 0xf5, 0xb4, 0x8d, 0x7b, 0xcb, 0x0c, 0x4d, 0x09, 0x92, 0x28, 0x8c,
+	0xcd, 0x2c, 0xc8, 0x6c, 0xf1, 0x6d, 0xe3, 0xde, 0x32, 0x43, 0x63, 0x41, 0x7e, 0xa6, 0x24, 0xcf,
+	0x05, 0x77, 0x16, 0xae, 0xff, 0x5c, 0xd0, 0x24, 0x67, 0x2a, 0x50, 0xf9, 0x06, 0xfd, 0x99, 0xbc,
+	0xc5, 0x10, 0xe5, 0xa3, 0x68, 0x11, 0xb0, 0x54, 0xc5, 0x69, 0xe3, 0xd3, 0xe5, 0x82, 0x0d, 0x17,
+	0xe2, 0x4f, 0x14, 0x80, 0x49, 0xa1, 0x69, 0x66, 0x21, 0x66, 0x2a, 0x5c, 0x1b, 0x77, 0x97, 0x18,
+	0x99, 0xdc, 0x20, 0x51, 0x21, 0x5c, 0xe6, 0x0d, 0x32, 0x55, 0x08, 0xdb, 0xb8, 0xb3, 0xf0, 0xb8,
+	0x78, 0xfa, 0xbf, 0x55, 0x60, 0x63, 0xa6, 0x10, 0x0f, 0x3d, 0xbc, 0x62, 0x2d, 0x66, 0xe3, 0xf3,
+	0xe5, 0x01, 0x22, 0xd1, 0x6e, 0x29, 0x9f, 0x28, 0xe8, 0x2f, 0x14, 0x58, 0x4b, 0x17, 0x66, 0x64,
+	0x8e, 0x52, 0x73, 0x4a, 0xfa, 0x1a, 0xf7, 0x97, 0x1b, 0x1c, 0xaf, 0xd6, 0x5f, 0x29, 0x50, 0x93,
+	0xfb, 0x3b, 0x92, 0xe7, 0xfe, 0x62, 0x6e, 0x61, 0x4a, 0xa0, 0x07, 0x4b, 0x8e, 0x4e, 0x49, 0x94,
+	0xae, 0x6f, 0xcb, 0x2c, 0xd1, 0xdc, 0xd2, 0xba, 0xc6, 0x83, 0x25, 0x47, 0xa7, 0x3c, 0x5d, 0xa2,
+	0xce, 0x6d, 0x81, 0xe0, 0x3b, 0x5d, 0x8b, 0xd7, 0xb8, 0xb7, 0xcc, 0xd0, 0xd4, 0xd2, 0xa4, 0xeb,
+	0xbe, 0x32, 0x2f, 0xcd, 0xdc, 0xfa, 0xb2, 0xc6, 0x83, 0x25, 0x47, 0xc7, 0x12, 0xfd, 0x9d, 0x02,
+	0x9b, 0x73, 0xea, 0xbc, 0x50, 0x2b, 0x23, 0xf0, 0x9b, 0x4b, 0xd5, 0x1a, 0x8f, 0xae, 0x02, 0x11,
+	0x09, 0xf8, 0x68, 0xe5, 0x77, 0x8b, 0xe2, 0x2c, 0x50, 0xe2, 0x3f, 0x3f, 0xf8, 0xbf, 0x01, 0x00,
+	0x15, 0x2b, 0x7c, 0x88, 0xa3, 0x39, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3986,6 +4397,21 @@ type DriverClient interface {
 	// DestroyNetwork destroys a previously created network. This rpc is only
 	// implemented if the driver needs to manage network namespace creation.
 	DestroyNetwork(ctx context.Context, in *DestroyNetworkRequest, opts ...grpc.CallOption) (*DestroyNetworkResponse, error)
+	// CheckpointTask saves the state of a running task to a directory and
+	// stops it. This rpc is only implemented if the driver supports
+	// checkpointing.
+	CheckpointTask(ctx context.Context, in *CheckpointTaskRequest, opts ...grpc.CallOption) (*CheckpointTaskResponse, error)
+	// RestoreTask starts a task from a checkpoint saved by CheckpointTask.
+	// This rpc is only implemented if the driver supports checkpointing.
+	RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*RestoreTaskResponse, error)
+	// PrefetchImages pulls images in the background so that they're cached
+	// before tasks use them. This rpc is only implemented if the driver
+	// supports prefetching images.
+	PrefetchImages(ctx context.Context, in *PrefetchImagesRequest, opts ...grpc.CallOption) (*PrefetchImagesResponse, error)
+	// UpdateTaskResources applies new CPU and memory resources to a running
+	// task. This rpc is only implemented if the driver supports updating
+	// resources in place.
+	UpdateTaskResources(ctx context.Context, in *UpdateTaskResourcesRequest, opts ...grpc.CallOption) (*UpdateTaskResourcesResponse, error)
 }
 
 type driverClient struct {
@@ -4231,6 +4657,42 @@ func (c *driverClient) DestroyNetwork(ctx context.Context, in *DestroyNetworkReq
 	return out, nil
 }
 
+func (c *driverClient) CheckpointTask(ctx context.Context, in *CheckpointTaskRequest, opts ...grpc.CallOption) (*CheckpointTaskResponse, error) {
+	out := new(CheckpointTaskResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/CheckpointTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*RestoreTaskResponse, error) {
+	out := new(RestoreTaskResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/RestoreTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) PrefetchImages(ctx context.Context, in *PrefetchImagesRequest, opts ...grpc.CallOption) (*PrefetchImagesResponse, error) {
+	out := new(PrefetchImagesResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/PrefetchImages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) UpdateTaskResources(ctx context.Context, in *UpdateTaskResourcesRequest, opts ...grpc.CallOption) (*UpdateTaskResourcesResponse, error) {
+	out := new(UpdateTaskResourcesResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/UpdateTaskResources", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DriverServer is the server API for Driver service.
 type DriverServer interface {
 	// TaskConfigSchema returns the schema for parsing the driver
@@ -4286,6 +4748,21 @@ type DriverServer interface {
 	// DestroyNetwork destroys a previously created network. This rpc is only
 	// implemented if the driver needs to manage network namespace creation.
 	DestroyNetwork(context.Context, *DestroyNetworkRequest) (*DestroyNetworkResponse, error)
+	// CheckpointTask saves the state of a running task to a directory and
+	// stops it. This rpc is only implemented if the driver supports
+	// checkpointing.
+	CheckpointTask(context.Context, *CheckpointTaskRequest) (*CheckpointTaskResponse, error)
+	// RestoreTask starts a task from a checkpoint saved by CheckpointTask.
+	// This rpc is only implemented if the driver supports checkpointing.
+	RestoreTask(context.Context, *RestoreTaskRequest) (*RestoreTaskResponse, error)
+	// PrefetchImages pulls images in the background so that they're cached
+	// before tasks use them. This rpc is only implemented if the driver
+	// supports prefetching images.
+	PrefetchImages(context.Context, *PrefetchImagesRequest) (*PrefetchImagesResponse, error)
+	// UpdateTaskResources applies new CPU and memory resources to a running
+	// task. This rpc is only implemented if the driver supports updating
+	// resources in place.
+	UpdateTaskResources(context.Context, *UpdateTaskResourcesRequest) (*UpdateTaskResourcesResponse, error)
 }
 
 // UnimplementedDriverServer can be embedded to have forward compatible implementations.
@@ -4340,6 +4817,18 @@ func (*UnimplementedDriverServer) CreateNetwork(ctx context.Context, req *Create
 func (*UnimplementedDriverServer) DestroyNetwork(ctx context.Context, req *DestroyNetworkRequest) (*DestroyNetworkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroyNetwork not implemented")
 }
+func (*UnimplementedDriverServer) CheckpointTask(ctx context.Context, req *CheckpointTaskRequest) (*CheckpointTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckpointTask not implemented")
+}
+func (*UnimplementedDriverServer) RestoreTask(ctx context.Context, req *RestoreTaskRequest) (*RestoreTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTask not implemented")
+}
+func (*UnimplementedDriverServer) PrefetchImages(ctx context.Context, req *PrefetchImagesRequest) (*PrefetchImagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrefetchImages not implemented")
+}
+func (*UnimplementedDriverServer) UpdateTaskResources(ctx context.Context, req *UpdateTaskResourcesRequest) (*UpdateTaskResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTaskResources not implemented")
+}
 
 func RegisterDriverServer(s *grpc.Server, srv DriverServer) {
 	s.RegisterService(&_Driver_serviceDesc, srv)
@@ -4650,6 +5139,78 @@ func _Driver_DestroyNetwork_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Driver_CheckpointTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).CheckpointTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/CheckpointTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).CheckpointTask(ctx, req.(*CheckpointTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_RestoreTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).RestoreTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/RestoreTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).RestoreTask(ctx, req.(*RestoreTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_PrefetchImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrefetchImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).PrefetchImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/PrefetchImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).PrefetchImages(ctx, req.(*PrefetchImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_UpdateTaskResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTaskResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).UpdateTaskResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/UpdateTaskResources",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).UpdateTaskResources(ctx, req.(*UpdateTaskResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Driver_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hashicorp.nomad.plugins.drivers.proto.Driver",
 	HandlerType: (*DriverServer)(nil),
@@ -4702,6 +5263,22 @@ var _Driver_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DestroyNetwork",
 			Handler:    _Driver_DestroyNetwork_Handler,
 		},
+		{
+			MethodName: "CheckpointTask",
+			Handler:    _Driver_CheckpointTask_Handler,
+		},
+		{
+			MethodName: "RestoreTask",
+			Handler:    _Driver_RestoreTask_Handler,
+		},
+		{
+			MethodName: "PrefetchImages",
+			Handler:    _Driver_PrefetchImages_Handler,
+		},
+		{
+			MethodName: "UpdateTaskResources",
+			Handler:    _Driver_UpdateTaskResources_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -45,6 +45,9 @@ func (b *driverPluginServer) Capabilities(ctx context.Context, req *proto.Capabi
 			MustCreateNetwork:     caps.MustInitiateNetwork,
 			NetworkIsolationModes: []proto.NetworkIsolationSpec_NetworkIsolationMode{},
 			RemoteTasks:           caps.RemoteTasks,
+			Checkpoint:            caps.Checkpoint,
+			UpdateResources:       caps.UpdateResources,
+			GroupResources:        caps.GroupResources,
 		},
 	}
 
@@ -106,31 +109,12 @@ func (b *driverPluginServer) RecoverTask(ctx context.Context, req *proto.Recover
 func (b *driverPluginServer) StartTask(ctx context.Context, req *proto.StartTaskRequest) (*proto.StartTaskResponse, error) {
 	handle, net, err := b.impl.StartTask(taskConfigFromProto(req.Task))
 	if err != nil {
-		if rec, ok := err.(structs.Recoverable); ok {
-			st := status.New(codes.FailedPrecondition, rec.Error())
-			st, err := st.WithDetails(&sproto.RecoverableError{Recoverable: rec.IsRecoverable()})
-			if err != nil {
-				// If this error, it will always error
-				panic(err)
-			}
-			return nil, st.Err()
-		}
-		return nil, err
+		return nil, startTaskErrorToProto(err)
 	}
 
-	var pbNet *proto.NetworkOverride
-	if net != nil {
-		pbNet = &proto.NetworkOverride{
-			PortMap:       map[string]int32{},
-			Addr:          net.IP,
-			AutoAdvertise: net.AutoAdvertise,
-		}
-		for k, v := range net.PortMap {
-			if v > math.MaxInt32 {
-				return nil, fmt.Errorf("port map out of bounds")
-			}
-			pbNet.PortMap[k] = int32(v)
-		}
+	pbNet, err := networkOverrideToProto(net)
+	if err != nil {
+		return nil, err
 	}
 
 	resp := &proto.StartTaskResponse{
@@ -141,6 +125,40 @@ func (b *driverPluginServer) StartTask(ctx context.Context, req *proto.StartTask
 	return resp, nil
 }
 
+// startTaskErrorToProto preserves whether errors starting a task are
+// recoverable in the status returned to the client.
+func startTaskErrorToProto(err error) error {
+	if rec, ok := err.(structs.Recoverable); ok {
+		st := status.New(codes.FailedPrecondition, rec.Error())
+		st, err := st.WithDetails(&sproto.RecoverableError{Recoverable: rec.IsRecoverable()})
+		if err != nil {
+			// If this error, it will always error
+			panic(err)
+		}
+		return st.Err()
+	}
+	return err
+}
+
+func networkOverrideToProto(net *DriverNetwork) (*proto.NetworkOverride, error) {
+	if net == nil {
+		return nil, nil
+	}
+
+	pbNet := &proto.NetworkOverride{
+		PortMap:       map[string]int32{},
+		Addr:          net.IP,
+		AutoAdvertise: net.AutoAdvertise,
+	}
+	for k, v := range net.PortMap {
+		if v > math.MaxInt32 {
+			return nil, fmt.Errorf("port map out of bounds")
+		}
+		pbNet.PortMap[k] = int32(v)
+	}
+	return pbNet, nil
+}
+
 func (b *driverPluginServer) WaitTask(ctx context.Context, req *proto.WaitTaskRequest) (*proto.WaitTaskResponse, error) {
 	ch, err := b.impl.WaitTask(ctx, req.TaskId)
 	if err != nil {
@@ -416,3 +434,67 @@ func (b *driverPluginServer) DestroyNetwork(ctx context.Context, req *proto.Dest
 
 	return &proto.DestroyNetworkResponse{}, nil
 }
+
+func (b *driverPluginServer) CheckpointTask(ctx context.Context, req *proto.CheckpointTaskRequest) (*proto.CheckpointTaskResponse, error) {
+	cp, ok := b.impl.(DriverCheckpointer)
+	if !ok {
+		return nil, fmt.Errorf("CheckpointTask RPC not supported by driver")
+	}
+
+	if err := cp.CheckpointTask(req.TaskId, req.Dir); err != nil {
+		if err == ErrCheckpointNotEnabled {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, err
+	}
+
+	return &proto.CheckpointTaskResponse{}, nil
+}
+
+func (b *driverPluginServer) RestoreTask(ctx context.Context, req *proto.RestoreTaskRequest) (*proto.RestoreTaskResponse, error) {
+	cp, ok := b.impl.(DriverCheckpointer)
+	if !ok {
+		return nil, fmt.Errorf("RestoreTask RPC not supported by driver")
+	}
+
+	handle, net, err := cp.RestoreTask(taskConfigFromProto(req.Task), req.Dir)
+	if err != nil {
+		return nil, startTaskErrorToProto(err)
+	}
+
+	pbNet, err := networkOverrideToProto(net)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.RestoreTaskResponse{
+		Handle:          taskHandleToProto(handle),
+		NetworkOverride: pbNet,
+	}, nil
+}
+
+func (b *driverPluginServer) PrefetchImages(ctx context.Context, req *proto.PrefetchImagesRequest) (*proto.PrefetchImagesResponse, error) {
+	p, ok := b.impl.(DriverImagePrefetcher)
+	if !ok {
+		return nil, fmt.Errorf("PrefetchImages RPC not supported by driver")
+	}
+
+	if err := p.PrefetchImages(req.Images); err != nil {
+		return nil, err
+	}
+
+	return &proto.PrefetchImagesResponse{}, nil
+}
+
+func (b *driverPluginServer) UpdateTaskResources(ctx context.Context, req *proto.UpdateTaskResourcesRequest) (*proto.UpdateTaskResourcesResponse, error) {
+	u, ok := b.impl.(DriverResourceUpdater)
+	if !ok {
+		return nil, fmt.Errorf("UpdateTaskResources RPC not supported by driver")
+	}
+
+	if err := u.UpdateTaskResources(req.TaskId, ResourcesFromProto(req.Resources)); err != nil {
+		return nil, err
+	}
+
+	return &proto.UpdateTaskResourcesResponse{}, nil
+}
@@ -144,6 +144,7 @@ func ResourcesFromProto(pb *proto.Resources) *Resources {
 			OOMScoreAdj:      pb.LinuxResources.OomScoreAdj,
 			CpusetCpus:       pb.LinuxResources.CpusetCpus,
 			CpusetCgroupPath: pb.LinuxResources.CpusetCgroup,
+			CgroupParent:     pb.LinuxResources.CgroupParent,
 			PercentTicks:     pb.LinuxResources.PercentTicks,
 		}
 	}
@@ -214,6 +215,7 @@ func ResourcesToProto(r *Resources) *proto.Resources {
 			OomScoreAdj:      r.LinuxResources.OOMScoreAdj,
 			CpusetCpus:       r.LinuxResources.CpusetCpus,
 			CpusetCgroup:     r.LinuxResources.CpusetCgroupPath,
+			CgroupParent:     r.LinuxResources.CgroupParent,
 			PercentTicks:     r.LinuxResources.PercentTicks,
 		}
 	}
@@ -7,10 +7,11 @@ import (
 )
 
 const (
-	AnnotationForcesCreate            = "forces create"
-	AnnotationForcesDestroy           = "forces destroy"
-	AnnotationForcesInplaceUpdate     = "forces in-place update"
-	AnnotationForcesDestructiveUpdate = "forces create/destroy update"
+	AnnotationForcesCreate                 = "forces create"
+	AnnotationForcesDestroy                = "forces destroy"
+	AnnotationForcesInplaceUpdate          = "forces in-place update"
+	AnnotationForcesInplaceResourcesUpdate = "forces in-place (resources) update"
+	AnnotationForcesDestructiveUpdate      = "forces create/destroy update"
 )
 
 // UpdateTypes denote the type of update to occur against the task group.
@@ -35,6 +36,7 @@ const (
 // * Task changes will be annotated with:
 //    * forces create/destroy update
 //    * forces in-place update
+//    * forces in-place (resources) update
 func Annotate(diff *structs.JobDiff, annotations *structs.PlanAnnotations) error {
 	tgDiffs := diff.TaskGroups
 	if len(tgDiffs) == 0 {
@@ -95,8 +97,16 @@ func annotateTaskGroup(diff *structs.TaskGroupDiff, annotations *structs.PlanAnn
 		return nil
 	}
 
+	// Resource changes are only applied in place if the scheduler doesn't
+	// need any destructive update of the task group
+	var updates *structs.DesiredUpdates
+	if annotations != nil {
+		updates = annotations.DesiredTGUpdates[diff.Name]
+	}
+	inplaceResources := updates != nil && updates.DestructiveUpdate == 0
+
 	for _, taskDiff := range taskDiffs {
-		annotateTask(taskDiff, diff)
+		annotateTask(taskDiff, diff, inplaceResources)
 	}
 
 	return nil
@@ -146,8 +156,10 @@ func annotateCountChange(diff *structs.TaskGroupDiff) error {
 	return nil
 }
 
-// annotateCountChange takes a task diff and annotates it.
-func annotateTask(diff *structs.TaskDiff, parent *structs.TaskGroupDiff) {
+// annotateCountChange takes a task diff and annotates it. Changes to the CPU
+// and memory of the resources are only annotated as in-place updates if
+// inplaceResources is true, since they can't be applied in place on all nodes.
+func annotateTask(diff *structs.TaskDiff, parent *structs.TaskGroupDiff, inplaceResources bool) {
 	if diff.Type == structs.DiffTypeNone {
 		return
 	}
@@ -178,14 +190,21 @@ FieldsLoop:
 	}
 
 	// Object changes that can be done in-place are log configs, services,
-	// constraints.
-
+	// constraints, and changes to the CPU and memory of the resources.
+	resources := false
 	if !destructive {
 	ObjectsLoop:
 		for _, oDiff := range diff.Objects {
 			switch oDiff.Name {
 			case "LogConfig", "Service", "Constraint":
 				continue
+			case "Resources":
+				if inplaceResources && inplaceResourcesDiff(oDiff) {
+					resources = true
+					continue
+				}
+				destructive = true
+				break ObjectsLoop
 			default:
 				destructive = true
 				break ObjectsLoop
@@ -193,9 +212,39 @@ FieldsLoop:
 		}
 	}
 
-	if destructive {
+	switch {
+	case destructive:
 		diff.Annotations = append(diff.Annotations, AnnotationForcesDestructiveUpdate)
-	} else {
+	case resources:
+		diff.Annotations = append(diff.Annotations, AnnotationForcesInplaceResourcesUpdate)
+	default:
 		diff.Annotations = append(diff.Annotations, AnnotationForcesInplaceUpdate)
 	}
 }
+
+// inplaceResourcesDiff returns whether the resources diff only changes the
+// CPU and memory, which are applied to the running tasks.
+func inplaceResourcesDiff(diff *structs.ObjectDiff) bool {
+	if diff.Type != structs.DiffTypeEdited {
+		return false
+	}
+
+	for _, oDiff := range diff.Objects {
+		if oDiff.Type != structs.DiffTypeNone {
+			return false
+		}
+	}
+
+	for _, fDiff := range diff.Fields {
+		if fDiff.Type == structs.DiffTypeNone {
+			continue
+		}
+		switch fDiff.Name {
+		case "CPU", "MemoryMB", "MemoryMaxMB":
+			continue
+		default:
+			return false
+		}
+	}
+	return true
+}
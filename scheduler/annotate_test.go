@@ -103,7 +103,7 @@ func TestAnnotateTask_NonEdited(t *testing.T) {
 	tgd := &structs.TaskGroupDiff{Type: structs.DiffTypeNone}
 	td := &structs.TaskDiff{Type: structs.DiffTypeNone}
 	tdOrig := &structs.TaskDiff{Type: structs.DiffTypeNone}
-	annotateTask(td, tgd)
+	annotateTask(td, tgd, true)
 	if !reflect.DeepEqual(tdOrig, td) {
 		t.Fatalf("annotateTask(%#v) should not have caused any annotation: %#v", tdOrig, td)
 	}
@@ -114,6 +114,10 @@ func TestAnnotateTask(t *testing.T) {
 		Diff    *structs.TaskDiff
 		Parent  *structs.TaskGroupDiff
 		Desired string
+
+		// Destructive is set when the task group update is destructive, as
+		// when the node's driver can't update resources in place.
+		Destructive bool
 	}{
 		{
 			Diff: &structs.TaskDiff{
@@ -241,6 +245,92 @@ func TestAnnotateTask(t *testing.T) {
 			Parent:  &structs.TaskGroupDiff{Type: structs.DiffTypeEdited},
 			Desired: AnnotationForcesDestructiveUpdate,
 		},
+		{
+			Diff: &structs.TaskDiff{
+				Type: structs.DiffTypeEdited,
+				Objects: []*structs.ObjectDiff{
+					{
+						Type: structs.DiffTypeEdited,
+						Name: "Resources",
+						Fields: []*structs.FieldDiff{
+							{
+								Type: structs.DiffTypeEdited,
+								Name: "CPU",
+								Old:  "100",
+								New:  "200",
+							},
+							{
+								Type: structs.DiffTypeNone,
+								Name: "DiskMB",
+								Old:  "100",
+								New:  "100",
+							},
+							{
+								Type: structs.DiffTypeEdited,
+								Name: "MemoryMB",
+								Old:  "100",
+								New:  "200",
+							},
+						},
+					},
+					{
+						Type: structs.DiffTypeAdded,
+						Name: "Service",
+					},
+				},
+			},
+			Parent:  &structs.TaskGroupDiff{Type: structs.DiffTypeEdited},
+			Desired: AnnotationForcesInplaceResourcesUpdate,
+		},
+		{
+			Diff: &structs.TaskDiff{
+				Type: structs.DiffTypeEdited,
+				Objects: []*structs.ObjectDiff{
+					{
+						Type: structs.DiffTypeEdited,
+						Name: "Resources",
+						Fields: []*structs.FieldDiff{
+							{
+								Type: structs.DiffTypeEdited,
+								Name: "CPU",
+								Old:  "100",
+								New:  "200",
+							},
+						},
+					},
+				},
+			},
+			Parent:      &structs.TaskGroupDiff{Type: structs.DiffTypeEdited},
+			Desired:     AnnotationForcesDestructiveUpdate,
+			Destructive: true,
+		},
+		{
+			Diff: &structs.TaskDiff{
+				Type: structs.DiffTypeEdited,
+				Objects: []*structs.ObjectDiff{
+					{
+						Type: structs.DiffTypeEdited,
+						Name: "Resources",
+						Fields: []*structs.FieldDiff{
+							{
+								Type: structs.DiffTypeEdited,
+								Name: "MemoryMB",
+								Old:  "100",
+								New:  "200",
+							},
+						},
+						Objects: []*structs.ObjectDiff{
+							{
+								Type: structs.DiffTypeAdded,
+								Name: "Device",
+							},
+						},
+					},
+				},
+			},
+			Parent:  &structs.TaskGroupDiff{Type: structs.DiffTypeEdited},
+			Desired: AnnotationForcesDestructiveUpdate,
+		},
 		{
 			Diff: &structs.TaskDiff{
 				Type: structs.DiffTypeEdited,
@@ -425,7 +515,7 @@ func TestAnnotateTask(t *testing.T) {
 	}
 
 	for i, c := range cases {
-		annotateTask(c.Diff, c.Parent)
+		annotateTask(c.Diff, c.Parent, !c.Destructive)
 		if len(c.Diff.Annotations) != 1 || c.Diff.Annotations[0] != c.Desired {
 			t.Fatalf("case %d not properly annotated; got %s, want %s", i+1, c.Diff.Annotations[0], c.Desired)
 		}
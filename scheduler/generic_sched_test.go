@@ -261,7 +261,7 @@ func TestServiceSched_JobRegister_StickyAllocs(t *testing.T) {
 
 	// Update the job to force a rolling upgrade
 	updated := job.Copy()
-	updated.TaskGroups[0].Tasks[0].Resources.CPU += 10
+	updated.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
 	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), updated))
 
 	// Create a mock evaluation to handle the update
@@ -383,6 +383,48 @@ func TestServiceSched_JobRegister_DiskConstraints(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+// TestServiceSched_JobRegister_GroupResources asserts that task groups with
+// resources are only placed on nodes whose drivers nest the tasks under the
+// cgroup of the allocation.
+func TestServiceSched_JobRegister_GroupResources(t *testing.T) {
+	h := NewHarness(t)
+
+	// Only the second node is recent enough and its driver has the capability
+	var nodes []*structs.Node
+	for i := 0; i < 3; i++ {
+		node := mock.Node()
+		nodes = append(nodes, node)
+	}
+	nodes[1].Attributes["nomad.version"] = "1.2.6"
+	nodes[1].Attributes[structs.DriverCapabilityAttr("exec", structs.DriverCapabilityGroupResources)] = "true"
+	nodes[2].Attributes[structs.DriverCapabilityAttr("exec", structs.DriverCapabilityGroupResources)] = "true"
+	for _, node := range nodes {
+		node.ComputeClass()
+		require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Resources = &structs.TaskGroupResources{CPU: 1000, MemoryMB: 512}
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	require.Len(t, h.Plans, 1)
+	plan := h.Plans[0]
+	require.Len(t, plan.NodeAllocation, 1)
+	require.Len(t, plan.NodeAllocation[nodes[1].ID], 2)
+}
+
 func TestServiceSched_JobRegister_DistinctHosts(t *testing.T) {
 	h := NewHarness(t)
 
@@ -2385,6 +2427,129 @@ func TestServiceSched_JobModify_InPlace(t *testing.T) {
 	}
 }
 
+// TestServiceSched_JobModify_InPlace_Resources asserts that CPU and memory
+// changes are applied in place when they fit on the allocs' nodes and the
+// nodes' driver can update the resources of running tasks.
+func TestServiceSched_JobModify_InPlace_Resources(t *testing.T) {
+	h := NewHarness(t)
+
+	var nodes []*structs.Node
+	for i := 0; i < 2; i++ {
+		node := mock.Node()
+		node.Attributes[structs.DriverCapabilityAttr("exec", structs.DriverCapabilityUpdateResources)] = "true"
+		nodes = append(nodes, node)
+		require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.AllocForNode(nodes[i])
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.Name = fmt.Sprintf("my-job.web[%d]", i)
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	process := func(job *structs.Job) *structs.Plan {
+		h.Plans = nil
+		eval := &structs.Evaluation{
+			Namespace:    structs.DefaultNamespace,
+			ID:           uuid.Generate(),
+			Priority:     50,
+			TriggeredBy:  structs.EvalTriggerJobRegister,
+			JobID:        job.ID,
+			AnnotatePlan: true,
+			Status:       structs.EvalStatusPending,
+		}
+		require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+		require.NoError(t, h.Process(NewServiceScheduler, eval))
+		require.Len(t, h.Plans, 1)
+		return h.Plans[0]
+	}
+
+	// Bump the CPU and memory of the task
+	job2 := job.Copy()
+	job2.TaskGroups[0].Tasks[0].Resources.CPU += 50
+	job2.TaskGroups[0].Tasks[0].Resources.MemoryMB += 256
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job2))
+
+	plan := process(job2)
+	require.Empty(t, plan.NodeUpdate)
+	require.EqualValues(t, 2, plan.Annotations.DesiredTGUpdates["web"].InPlaceUpdate)
+	for _, allocList := range plan.NodeAllocation {
+		for _, alloc := range allocList {
+			task := alloc.AllocatedResources.Tasks["web"]
+			require.EqualValues(t, job2.TaskGroups[0].Tasks[0].Resources.CPU, task.Cpu.CpuShares)
+			require.EqualValues(t, job2.TaskGroups[0].Tasks[0].Resources.MemoryMB, task.Memory.MemoryMB)
+		}
+	}
+
+	// Memory that doesn't fit on the nodes requires replacing the allocs
+	job3 := job2.Copy()
+	job3.TaskGroups[0].Tasks[0].Resources.MemoryMB = 100000
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job3))
+
+	plan = process(job3)
+	require.EqualValues(t, 0, plan.Annotations.DesiredTGUpdates["web"].InPlaceUpdate)
+	require.EqualValues(t, 2, plan.Annotations.DesiredTGUpdates["web"].DestructiveUpdate)
+}
+
+// TestServiceSched_JobModify_Resources_NoDriverSupport asserts that CPU and
+// memory changes are destructive on nodes whose driver can't update the
+// resources of running tasks.
+func TestServiceSched_JobModify_Resources_NoDriverSupport(t *testing.T) {
+	h := NewHarness(t)
+
+	var nodes []*structs.Node
+	for i := 0; i < 2; i++ {
+		node := mock.Node()
+		nodes = append(nodes, node)
+		require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.AllocForNode(nodes[i])
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.Name = fmt.Sprintf("my-job.web[%d]", i)
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	job2 := job.Copy()
+	job2.TaskGroups[0].Tasks[0].Resources.CPU += 50
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job2))
+
+	eval := &structs.Evaluation{
+		Namespace:    structs.DefaultNamespace,
+		ID:           uuid.Generate(),
+		Priority:     50,
+		TriggeredBy:  structs.EvalTriggerJobRegister,
+		JobID:        job.ID,
+		AnnotatePlan: true,
+		Status:       structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+	require.Len(t, h.Plans, 1)
+
+	// The update is rolled out as a destructive update, which the update
+	// strategy of the mock job limits to one alloc at a time
+	updates := h.Plans[0].Annotations.DesiredTGUpdates["web"]
+	require.EqualValues(t, 0, updates.InPlaceUpdate)
+	require.NotZero(t, updates.DestructiveUpdate)
+}
+
 // TestServiceSched_JobModify_InPlace08 asserts that inplace updates of
 // allocations created with Nomad 0.8 do not cause panics.
 //
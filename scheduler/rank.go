@@ -251,6 +251,8 @@ OUTER:
 				len(iter.taskGroup.Tasks)),
 			Shared: structs.AllocatedSharedResources{
 				DiskMB: int64(iter.taskGroup.EphemeralDisk.SizeMB),
+				Cpu:    iter.taskGroup.Resources.AllocatedCpu(),
+				Memory: iter.taskGroup.Resources.AllocatedMemory(),
 			},
 		}
 
@@ -356,7 +358,9 @@ OUTER:
 					MemoryMB: int64(task.Resources.MemoryMB),
 				},
 			}
-			if iter.memoryOversubscription {
+			// Tasks of a group with resources may use up to their memory_max
+			// of the group memory ceiling, which is what is accounted
+			if iter.memoryOversubscription || iter.taskGroup.Resources != nil {
 				taskResources.Memory.MemoryMaxMB = int64(task.Resources.MemoryMaxMB)
 			}
 
@@ -133,6 +133,84 @@ func TestBinPackIterator_NoExistingAlloc(t *testing.T) {
 	}
 }
 
+// TestBinPackIterator_GroupResources asserts that the ceiling of a task group
+// with group resources is accounted instead of the sum of its tasks.
+func TestBinPackIterator_GroupResources(t *testing.T) {
+	_, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{
+			Node: &structs.Node{
+				NodeResources: &structs.NodeResources{
+					Cpu: structs.NodeCpuResources{
+						CpuShares: 2048,
+					},
+					Memory: structs.NodeMemoryResources{
+						MemoryMB: 2048,
+					},
+				},
+				ReservedResources: &structs.NodeReservedResources{
+					Cpu: structs.NodeReservedCpuResources{
+						CpuShares: 1024,
+					},
+					Memory: structs.NodeReservedMemoryResources{
+						MemoryMB: 1024,
+					},
+				},
+			},
+		},
+	}
+	static := NewStaticRankIterator(ctx, nodes)
+
+	// The tasks add up to more than the node has available
+	taskGroup := &structs.TaskGroup{
+		EphemeralDisk: &structs.EphemeralDisk{},
+		Resources: &structs.TaskGroupResources{
+			CPU:      1024,
+			MemoryMB: 1024,
+		},
+		Tasks: []*structs.Task{
+			{
+				Name: "web",
+				Resources: &structs.Resources{
+					CPU:      800,
+					MemoryMB: 800,
+				},
+			},
+			{
+				Name: "sidecar",
+				Resources: &structs.Resources{
+					CPU:         400,
+					MemoryMB:    200,
+					MemoryMaxMB: 1024,
+				},
+			},
+		},
+	}
+
+	// Memory oversubscription is disabled but the memory_max of the tasks
+	// is bounded by the group ceiling
+	schedConfig := &structs.SchedulerConfiguration{
+		SchedulerAlgorithm: structs.SchedulerAlgorithmBinpack,
+	}
+	binp := NewBinPackIterator(ctx, static, false, 0, schedConfig)
+	binp.SetTaskGroup(taskGroup)
+
+	scoreNorm := NewScoreNormalizationIterator(ctx, binp)
+
+	out := collectRanked(scoreNorm)
+	require.Len(t, out, 1)
+	require.Equal(t, nodes[0], out[0])
+	require.Equal(t, 1.0, out[0].FinalScore)
+	require.EqualValues(t, 1024, out[0].TaskResources["sidecar"].Memory.MemoryMaxMB)
+
+	// Without the group resources the tasks don't fit
+	taskGroup.Resources = nil
+	static.Reset()
+	binp.SetTaskGroup(taskGroup)
+	out = collectRanked(scoreNorm)
+	require.Empty(t, out)
+}
+
 // TestBinPackIterator_NoExistingAlloc_MixedReserve asserts that node's with
 // reserved resources are scored equivalent to as if they had a lower amount of
 // resources.
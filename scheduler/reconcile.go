@@ -408,7 +408,7 @@ func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
 	// Stop any unneeded allocations and update the untainted set to not
 	// include stopped allocations.
 	isCanarying := dstate != nil && dstate.DesiredCanaries != 0 && !dstate.Promoted
-	stop := a.computeStop(tg, nameIndex, untainted, migrate, lost, canaries, isCanarying, lostLaterEvals)
+	stop := a.computeStop(tg, nameIndex, untainted, migrate, rescheduleNow, lost, canaries, isCanarying, lostLaterEvals)
 	desiredChanges.Stop += uint64(len(stop))
 	untainted = untainted.difference(stop)
 
@@ -600,9 +600,15 @@ func (a *allocReconciler) computeUnderProvisionedBy(group *structs.TaskGroup, un
 		return group.Count
 	}
 
+	// Stateful groups are updated one allocation at a time
+	maxParallel := group.Update.MaxParallel
+	if group.Stateful {
+		maxParallel = 1
+	}
+
 	// If the deployment is nil, allow MaxParallel placements
 	if a.deployment == nil {
-		return group.Update.MaxParallel
+		return maxParallel
 	}
 
 	// If the deployment is paused, failed, or we have un-promoted canaries, do not create anything else.
@@ -612,7 +618,7 @@ func (a *allocReconciler) computeUnderProvisionedBy(group *structs.TaskGroup, un
 		return 0
 	}
 
-	underProvisionedBy := group.Update.MaxParallel
+	underProvisionedBy := maxParallel
 	partOf, _ := untainted.filterByDeployment(a.deployment.ID)
 	for _, alloc := range partOf {
 		// An unhealthy allocation means nothing else should happen.
@@ -681,9 +687,14 @@ func (a *allocReconciler) computePlacements(group *structs.TaskGroup,
 		})
 	}
 
-	// Add remaining placement results
+	// Add remaining placement results. Stateful groups only use the unused
+	// indexes so that an index is never shared by two allocations.
 	if existing < group.Count {
-		for _, name := range nameIndex.Next(uint(group.Count - existing)) {
+		next := nameIndex.Next
+		if group.Stateful {
+			next = nameIndex.NextUnused
+		}
+		for _, name := range next(uint(group.Count - existing)) {
 			place = append(place, allocPlaceResult{
 				name:               name,
 				taskGroup:          group,
@@ -763,7 +774,12 @@ func (a *allocReconciler) computeDestructiveUpdates(destructive allocSet, underP
 	min := helper.IntMin(len(destructive), underProvisionedBy)
 	desiredChanges.DestructiveUpdate += uint64(min)
 	desiredChanges.Ignore += uint64(len(destructive) - min)
-	for _, alloc := range destructive.nameOrder()[:min] {
+	// Stateful groups are updated from the highest index down
+	order := destructive.nameOrder()
+	if tg.Stateful {
+		order = destructive.reverseNameOrder()
+	}
+	for _, alloc := range order[:min] {
 		a.result.destructiveUpdate = append(a.result.destructiveUpdate, allocDestructiveResult{
 			placeName:             alloc.Name,
 			placeTaskGroup:        tg,
@@ -856,9 +872,10 @@ func (a *allocReconciler) isDeploymentComplete(groupName string, destructive, in
 
 // computeStop returns the set of allocations that are marked for stopping given
 // the group definition, the set of allocations in various states and whether we
-// are canarying.
+// are canarying. Allocations of stateful groups that are stopped are removed
+// from the migrate and rescheduleNow sets.
 func (a *allocReconciler) computeStop(group *structs.TaskGroup, nameIndex *allocNameIndex,
-	untainted, migrate, lost, canaries allocSet, isCanarying bool, followupEvals map[string]string) allocSet {
+	untainted, migrate, rescheduleNow, lost, canaries allocSet, isCanarying bool, followupEvals map[string]string) allocSet {
 
 	// Mark all lost allocations for stop.
 	var stop allocSet
@@ -870,6 +887,41 @@ func (a *allocReconciler) computeStop(group *structs.TaskGroup, nameIndex *alloc
 		untainted = untainted.difference(canaries)
 	}
 
+	// Stateful groups run a single allocation per index, so stop any newer
+	// allocation using the index of another one, including allocations being
+	// migrated. Failed allocations are not rescheduled if a running
+	// allocation or an older failed one uses their index.
+	if group.Stateful {
+		live := filterByTerminal(untainted).union(migrate)
+		dups := live.duplicateIndexes()
+
+		taken := make(map[uint]struct{}, len(live))
+		for id, alloc := range live {
+			if _, ok := dups[id]; !ok {
+				taken[alloc.Index()] = struct{}{}
+			}
+		}
+		for id, alloc := range rescheduleNow.duplicateIndexes() {
+			dups[id] = alloc
+		}
+		for id, alloc := range rescheduleNow {
+			if _, ok := taken[alloc.Index()]; ok {
+				dups[id] = alloc
+			}
+		}
+
+		for id, alloc := range dups {
+			stop[id] = alloc
+			a.result.stop = append(a.result.stop, allocStopResult{
+				alloc:             alloc,
+				statusDescription: allocNotNeeded,
+			})
+			delete(untainted, id)
+			delete(migrate, id)
+			delete(rescheduleNow, id)
+		}
+	}
+
 	// Hot path the nothing to do case
 	remove := len(untainted) + len(migrate) - group.Count
 	if remove <= 0 {
@@ -5019,3 +5019,188 @@ func TestReconciler_RescheduleNot_Batch(t *testing.T) {
 	})
 
 }
+
+// Tests the reconciler stops the allocations of a stateful group which share
+// an index and only places allocations with unused indexes
+func TestReconciler_Stateful_DuplicateIndexes(t *testing.T) {
+	job := mock.Job()
+	job.TaskGroups[0].Count = 3
+	job.TaskGroups[0].Stateful = true
+	job.TaskGroups[0].Update = noCanaryUpdate
+
+	var allocs []*structs.Allocation
+	for i, idx := range []uint{0, 0, 2} {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, idx)
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		alloc.CreateIndex = uint64(10 + i)
+		allocs = append(allocs, alloc)
+	}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job,
+		nil, allocs, nil, "", 50)
+	r := reconciler.Compute()
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		place:             1,
+		stop:              1,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Place:  1,
+				Stop:   1,
+				Ignore: 2,
+			},
+		},
+	})
+
+	require.Equal(t, allocs[1].ID, r.stop[0].alloc.ID)
+	assertNamesHaveIndexes(t, intRange(1, 1), placeResultsToNames(r.place))
+}
+
+// Tests the reconciler neither migrates nor reschedules the allocations of a
+// stateful group whose index is used by another allocation
+func TestReconciler_Stateful_DuplicateIndexes_MigrateReschedule(t *testing.T) {
+	job := mock.Job()
+	job.TaskGroups[0].Count = 3
+	job.TaskGroups[0].Stateful = true
+	job.TaskGroups[0].Update = noCanaryUpdate
+	job.TaskGroups[0].ReschedulePolicy = &structs.ReschedulePolicy{
+		Attempts: 1,
+		Interval: 24 * time.Hour,
+		Delay:    5 * time.Second,
+		MaxDelay: 1 * time.Hour,
+	}
+	tgName := job.TaskGroups[0].Name
+	now := time.Now()
+
+	var allocs []*structs.Allocation
+	for i, idx := range []uint{0, 0, 1, 1, 2} {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, tgName, idx)
+		alloc.TaskGroup = tgName
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		alloc.CreateIndex = uint64(10 + i)
+		allocs = append(allocs, alloc)
+	}
+
+	// The newer allocation of index 0 is on a draining node
+	tainted := map[string]*structs.Node{}
+	n := mock.DrainNode()
+	n.ID = allocs[1].NodeID
+	allocs[1].DesiredTransition.Migrate = helper.BoolToPtr(true)
+	tainted[n.ID] = n
+
+	// The older allocation of index 1 failed
+	allocs[2].ClientStatus = structs.AllocClientStatusFailed
+	allocs[2].TaskStates = map[string]*structs.TaskState{tgName: {State: "dead",
+		StartedAt:  now.Add(-1 * time.Hour),
+		FinishedAt: now.Add(-10 * time.Second)}}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job,
+		nil, allocs, tainted, "", 50)
+	r := reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		place:             0,
+		stop:              2,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			tgName: {
+				Stop:   2,
+				Ignore: 3,
+			},
+		},
+	})
+
+	stopped := []string{r.stop[0].alloc.ID, r.stop[1].alloc.ID}
+	require.ElementsMatch(t, []string{allocs[1].ID, allocs[2].ID}, stopped)
+}
+
+// Tests the reconciler updates the allocations of a stateful group one at a
+// time, from the highest index down, waiting for each to be healthy
+func TestReconciler_Stateful_RollingUpgrade(t *testing.T) {
+	job := mock.Job()
+	job.TaskGroups[0].Stateful = true
+	job.TaskGroups[0].Update = noCanaryUpdate
+
+	// Without a deployment only the highest index is updated even though
+	// max_parallel is higher
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		allocs = append(allocs, alloc)
+	}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnDestructive, false, job.ID, job,
+		nil, allocs, nil, "", 50)
+	r := reconciler.Compute()
+
+	d := structs.NewDeployment(job, 50)
+	d.TaskGroups[job.TaskGroups[0].Name] = &structs.DeploymentState{
+		DesiredTotal: 10,
+	}
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  d,
+		deploymentUpdates: nil,
+		destructive:       1,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				DestructiveUpdate: 1,
+				Ignore:            9,
+			},
+		},
+	})
+	assertNamesHaveIndexes(t, intRange(9, 9), destructiveResultsToNames(r.destructiveUpdate))
+
+	// Replace the highest index with an allocation of the deployment
+	d = structs.NewDeployment(job, 50)
+	d.TaskGroups[job.TaskGroups[0].Name] = &structs.DeploymentState{
+		DesiredTotal: 10,
+		PlacedAllocs: 1,
+	}
+	new := mock.Alloc()
+	new.Job = job
+	new.JobID = job.ID
+	new.NodeID = uuid.Generate()
+	new.Name = allocs[9].Name
+	new.TaskGroup = job.TaskGroups[0].Name
+	new.DeploymentID = d.ID
+	allocs[9] = new
+	handled := map[string]allocUpdateType{new.ID: allocUpdateFnIgnore}
+
+	for _, healthy := range []bool{false, true} {
+		if healthy {
+			new.DeploymentStatus = &structs.AllocDeploymentStatus{
+				Healthy: helper.BoolToPtr(true),
+			}
+		}
+
+		mockUpdateFn := allocUpdateFnMock(handled, allocUpdateFnDestructive)
+		reconciler := NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job,
+			d, allocs, nil, "", 50)
+		r := reconciler.Compute()
+
+		// The next index is only updated once the previous one is healthy
+		if !healthy {
+			require.Empty(t, r.destructiveUpdate)
+			continue
+		}
+		require.Len(t, r.destructiveUpdate, 1)
+		assertNamesHaveIndexes(t, intRange(8, 8), destructiveResultsToNames(r.destructiveUpdate))
+	}
+}
@@ -163,6 +163,38 @@ func (a allocSet) nameOrder() []*structs.Allocation {
 	return allocs
 }
 
+// reverseNameOrder returns the set of allocation names in reverse sorted
+// order, which is the order the allocations of stateful task groups are
+// updated in
+func (a allocSet) reverseNameOrder() []*structs.Allocation {
+	allocs := a.nameOrder()
+	for i, j := 0, len(allocs)-1; i < j; i, j = i+1, j-1 {
+		allocs[i], allocs[j] = allocs[j], allocs[i]
+	}
+	return allocs
+}
+
+// duplicateIndexes returns the allocations whose index is also used by an
+// older allocation of the set
+func (a allocSet) duplicateIndexes() allocSet {
+	oldest := make(map[uint]*structs.Allocation)
+	for _, alloc := range a {
+		idx := alloc.Index()
+		if prev, ok := oldest[idx]; !ok || alloc.CreateIndex < prev.CreateIndex ||
+			(alloc.CreateIndex == prev.CreateIndex && alloc.ID < prev.ID) {
+			oldest[idx] = alloc
+		}
+	}
+
+	dups := make(map[string]*structs.Allocation)
+	for id, alloc := range a {
+		if oldest[alloc.Index()] != alloc {
+			dups[id] = alloc
+		}
+	}
+	return dups
+}
+
 // difference returns a new allocSet that has all the existing item except those
 // contained within the other allocation sets
 func (a allocSet) difference(others ...allocSet) allocSet {
@@ -568,6 +600,21 @@ func (a *allocNameIndex) NextCanaries(n uint, existing, destructive allocSet) []
 	return next
 }
 
+// NextUnused returns up to n unused names for use as new placements and sets
+// them as used. Unlike Next, it never returns a name which is already used,
+// so it may return less than n names.
+func (a *allocNameIndex) NextUnused(n uint) []string {
+	next := make([]string, 0, n)
+	for _, idx := range a.b.IndexesInRange(false, uint(0), uint(a.count)-1) {
+		if uint(len(next)) == n {
+			break
+		}
+		next = append(next, structs.AllocName(a.job, a.taskGroup, uint(idx)))
+		a.b.Set(uint(idx))
+	}
+	return next
+}
+
 // Next returns the next n names for use as new placements and sets them as
 // used.
 func (a *allocNameIndex) Next(n uint) []string {
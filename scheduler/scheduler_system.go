@@ -415,6 +415,8 @@ func (s *SystemScheduler) computePlacements(place []allocTuple) error {
 			TaskLifecycles: option.TaskLifecycles,
 			Shared: structs.AllocatedSharedResources{
 				DiskMB: int64(missing.TaskGroup.EphemeralDisk.SizeMB),
+				Cpu:    missing.TaskGroup.Resources.AllocatedCpu(),
+				Memory: missing.TaskGroup.Resources.AllocatedMemory(),
 			},
 		}
 
@@ -508,6 +510,7 @@ func (s *SystemScheduler) canHandle(trigger string) bool {
 	case structs.EvalTriggerAllocStop:
 	case structs.EvalTriggerQueuedAllocs:
 	case structs.EvalTriggerScaling:
+	case structs.EvalTriggerJobDependency:
 	default:
 		switch s.sysbatch {
 		case true:
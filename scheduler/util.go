@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/rand"
 	"reflect"
+	"sort"
 
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
@@ -419,6 +420,12 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) bool {
 		return true
 	}
 
+	// Check group resources, which are set on the allocation cgroup when it
+	// is created
+	if !reflect.DeepEqual(a.Resources, b.Resources) {
+		return true
+	}
+
 	// Check that the network resources haven't changed
 	if networkUpdated(a.Networks, b.Networks) {
 		return true
@@ -484,22 +491,53 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) bool {
 			return true
 		}
 
-		// Inspect the non-network resources
-		if ar, br := at.Resources, bt.Resources; ar.CPU != br.CPU {
+		// Inspect the non-network resources. CPU and memory changes are
+		// checked by tasksResourcesUpdated since they may be applied in
+		// place depending on the node, while reserved cores and devices are
+		// bound when the task starts.
+		if ar, br := at.Resources, bt.Resources; ar.Cores != br.Cores {
 			return true
-		} else if ar.Cores != br.Cores {
-			return true
-		} else if ar.MemoryMB != br.MemoryMB {
+		} else if !ar.Devices.Equals(&br.Devices) {
 			return true
-		} else if ar.MemoryMaxMB != br.MemoryMaxMB {
+		}
+	}
+	return false
+}
+
+// tasksResourcesUpdated returns whether the CPU or memory of the tasks of the
+// task group differ between the jobs. Such changes are only applied in place
+// on nodes where nodeUpdatesResources is true.
+func tasksResourcesUpdated(jobA, jobB *structs.Job, taskGroup string) bool {
+	a := jobA.LookupTaskGroup(taskGroup)
+	b := jobB.LookupTaskGroup(taskGroup)
+
+	for _, at := range a.Tasks {
+		bt := b.LookupTask(at.Name)
+		if bt == nil {
 			return true
-		} else if !ar.Devices.Equals(&br.Devices) {
+		}
+		ar, br := at.Resources, bt.Resources
+		if ar.CPU != br.CPU || ar.MemoryMB != br.MemoryMB || ar.MemoryMaxMB != br.MemoryMaxMB {
 			return true
 		}
 	}
 	return false
 }
 
+// nodeUpdatesResources returns whether the drivers of all the tasks of the
+// task group can apply new resources to running tasks on the node. Otherwise
+// the client would restart the tasks, so resource changes must be rolled out
+// as destructive updates.
+func nodeUpdatesResources(node *structs.Node, tg *structs.TaskGroup) bool {
+	for _, task := range tg.Tasks {
+		attr := structs.DriverCapabilityAttr(task.Driver, structs.DriverCapabilityUpdateResources)
+		if node.Attributes[attr] != "true" {
+			return false
+		}
+	}
+	return true
+}
+
 // consulNamespaceUpdated returns true if the Consul namespace in the task group
 // has been changed.
 //
@@ -772,6 +810,11 @@ func inplaceUpdate(ctx Context, eval *structs.Evaluation, job *structs.Job,
 			continue
 		}
 
+		// The resources of the tasks can't be updated in place on the node
+		if tasksResourcesUpdated(job, existing, update.TaskGroup.Name) && !nodeUpdatesResources(node, update.TaskGroup) {
+			continue
+		}
+
 		// Set the existing node as the base set
 		stack.SetNodes([]*structs.Node{node})
 
@@ -829,6 +872,8 @@ func inplaceUpdate(ctx Context, eval *structs.Evaluation, job *structs.Job,
 				DiskMB:   int64(update.TaskGroup.EphemeralDisk.SizeMB),
 				Ports:    update.Alloc.AllocatedResources.Shared.Ports,
 				Networks: update.Alloc.AllocatedResources.Shared.Networks.Copy(),
+				Cpu:      update.TaskGroup.Resources.AllocatedCpu(),
+				Memory:   update.TaskGroup.Resources.AllocatedMemory(),
 			},
 		}
 		newAlloc.Metrics = ctx.Metrics()
@@ -871,6 +916,14 @@ type tgConstrainTuple struct {
 	drivers map[string]struct{}
 }
 
+// groupResourcesVersionConstraint restricts task groups with resources to
+// clients that create the cgroup of the allocation.
+var groupResourcesVersionConstraint = &structs.Constraint{
+	LTarget: "${attr.nomad.version}",
+	RTarget: ">= 1.2.6-dev",
+	Operand: structs.ConstraintSemver,
+}
+
 // taskGroupConstraints collects the constraints, drivers and resources required by each
 // sub-task to aggregate the TaskGroup totals
 func taskGroupConstraints(tg *structs.TaskGroup) tgConstrainTuple {
@@ -885,6 +938,25 @@ func taskGroupConstraints(tg *structs.TaskGroup) tgConstrainTuple {
 		c.constraints = append(c.constraints, task.Constraints...)
 	}
 
+	// The resources of the group are only enforced if the drivers of all its
+	// tasks nest them under the cgroup of the allocation
+	if tg.Resources != nil {
+		c.constraints = append(c.constraints, groupResourcesVersionConstraint)
+
+		drivers := make([]string, 0, len(c.drivers))
+		for driver := range c.drivers {
+			drivers = append(drivers, driver)
+		}
+		sort.Strings(drivers)
+		for _, driver := range drivers {
+			c.constraints = append(c.constraints, &structs.Constraint{
+				LTarget: fmt.Sprintf("${attr.%s}", structs.DriverCapabilityAttr(driver, structs.DriverCapabilityGroupResources)),
+				RTarget: "true",
+				Operand: "=",
+			})
+		}
+	}
+
 	return c
 }
 
@@ -1060,6 +1132,11 @@ func genericAllocUpdateFn(ctx Context, stack Stack, evalID string) allocUpdateTy
 			return false, true, nil
 		}
 
+		// The resources of the tasks can't be updated in place on the node
+		if tasksResourcesUpdated(newJob, existing.Job, newTG.Name) && !nodeUpdatesResources(node, newTG) {
+			return false, true, nil
+		}
+
 		// Set the existing node as the base set
 		stack.SetNodes([]*structs.Node{node})
 
@@ -1114,6 +1191,8 @@ func genericAllocUpdateFn(ctx Context, stack Stack, evalID string) allocUpdateTy
 			TaskLifecycles: option.TaskLifecycles,
 			Shared: structs.AllocatedSharedResources{
 				DiskMB: int64(newTG.EphemeralDisk.SizeMB),
+				Cpu:    newTG.Resources.AllocatedCpu(),
+				Memory: newTG.Resources.AllocatedMemory(),
 			},
 		}
 
@@ -703,9 +703,14 @@ func TestTasksUpdated(t *testing.T) {
 	j10.TaskGroups[0].Tasks[0].Meta["baz"] = "boom"
 	require.True(t, tasksUpdated(j1, j10, name))
 
+	// CPU and memory changes are applied in place
 	j11 := mock.Job()
 	j11.TaskGroups[0].Tasks[0].Resources.CPU = 1337
-	require.True(t, tasksUpdated(j1, j11, name))
+	j11.TaskGroups[0].Tasks[0].Resources.MemoryMB = 1024
+	j11.TaskGroups[0].Tasks[0].Resources.MemoryMaxMB = 2048
+	require.False(t, tasksUpdated(j1, j11, name))
+	require.True(t, tasksResourcesUpdated(j1, j11, name))
+	require.False(t, tasksResourcesUpdated(j1, j1.Copy(), name))
 
 	j11d1 := mock.Job()
 	j11d1.TaskGroups[0].Tasks[0].Resources.Devices = structs.ResourceDevices{
@@ -786,6 +791,12 @@ func TestTasksUpdated(t *testing.T) {
 	// Compare changed Template wait configs
 	j23.TaskGroups[0].Tasks[0].Templates[0].Wait.Max = helper.TimeToPtr(10 * time.Second)
 	require.True(t, tasksUpdated(j22, j23, name))
+
+	// Compare changed group resources
+	j24 := mock.Job()
+	j25 := mock.Job()
+	j25.TaskGroups[0].Resources = &structs.TaskGroupResources{CPU: 1000, MemoryMB: 512}
+	require.True(t, tasksUpdated(j24, j25, name))
 }
 
 func TestTasksUpdated_connectServiceUpdated(t *testing.T) {
@@ -867,6 +878,21 @@ func TestTasksUpdated_connectServiceUpdated(t *testing.T) {
 	})
 }
 
+func TestNodeUpdatesResources(t *testing.T) {
+	tg := mock.Job().TaskGroups[0]
+	attr := structs.DriverCapabilityAttr("exec", structs.DriverCapabilityUpdateResources)
+
+	node := mock.Node()
+	require.False(t, nodeUpdatesResources(node, tg))
+
+	node.Attributes[attr] = "true"
+	require.True(t, nodeUpdatesResources(node, tg))
+
+	// Every task's driver must support it
+	tg.Tasks = append(tg.Tasks, &structs.Task{Name: "other", Driver: "docker"})
+	require.False(t, nodeUpdatesResources(node, tg))
+}
+
 func TestNetworkUpdated(t *testing.T) {
 	t.Parallel()
 	cases := []struct {
@@ -1333,6 +1359,25 @@ func TestTaskGroupConstraints(t *testing.T) {
 		"taskGroupConstraints(%v) returned %v; want %v", tg, actConstrains.constraints, expConstr)
 	require.True(t, reflect.DeepEqual(actConstrains.drivers, expDrivers),
 		"taskGroupConstraints(%v) returned %v; want %v", tg, actConstrains.drivers, expDrivers)
+
+	// Group resources require the drivers to nest the tasks under the cgroup
+	// of the allocation
+	tg.Resources = &structs.TaskGroupResources{CPU: 1000, MemoryMB: 512}
+	expConstr = append(expConstr,
+		groupResourcesVersionConstraint,
+		&structs.Constraint{
+			LTarget: "${attr.driver.docker.capabilities.group_resources}",
+			RTarget: "true",
+			Operand: "=",
+		},
+		&structs.Constraint{
+			LTarget: "${attr.driver.exec.capabilities.group_resources}",
+			RTarget: "true",
+			Operand: "=",
+		},
+	)
+	actConstrains = taskGroupConstraints(tg)
+	require.Equal(t, expConstr, actConstrains.constraints)
 }
 
 func TestProgressMade(t *testing.T) {